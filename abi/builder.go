@@ -42,6 +42,26 @@ func NewBuilderFromSources(ctx context.Context, sources *solgo.Sources) (*Builde
 	}, nil
 }
 
+// NewBuilderFromSolcJSON creates a new ABI builder from the AST produced by the solc compiler.
+// Sources are optional and are only used to resolve node locations.
+func NewBuilderFromSolcJSON(ctx context.Context, data []byte, sources *solgo.Sources) (*Builder, error) {
+	parser, err := ir.NewBuilderFromSolcJSON(ctx, data, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		ctx:        ctx,
+		sources:    sources,
+		parser:     parser,
+		astBuilder: parser.GetAstBuilder(),
+		resolver: &TypeResolver{
+			parser:         parser,
+			processedTypes: make(map[string]bool),
+		},
+	}, nil
+}
+
 // GetSources returns the source files being processed.
 func (b *Builder) GetSources() *solgo.Sources {
 	return b.sources
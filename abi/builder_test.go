@@ -5,7 +5,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/unpackdev/solgo"
 	"github.com/unpackdev/solgo/ast"
 	"github.com/unpackdev/solgo/ir"
@@ -335,6 +337,25 @@ func TestBuilderFromSources(t *testing.T) {
 	}
 }
 
+func TestBuilderFromSolcJSON(t *testing.T) {
+	builder, err := NewBuilderFromSolcJSON(context.TODO(), tests.ReadJsonBytesForTest(t, "ast/TokenSale.solc.ast").Bytes, nil)
+	require.NoError(t, err)
+	assert.Empty(t, builder.Parse())
+	require.NoError(t, builder.Build())
+	require.NotNil(t, builder.GetRoot())
+	assert.Equal(t, "TokenSale", builder.GetRoot().GetEntryName())
+
+	// The ABI built from the compiler AST matches the one built from the sources.
+	var expected struct {
+		Contracts map[string]json.RawMessage `json:"contracts"`
+	}
+	require.NoError(t, json.Unmarshal(tests.ReadJsonBytesForTest(t, "abi/TokenSale.abi").Bytes, &expected))
+
+	abi, err := builder.ToJSON(builder.GetRoot().GetEntryContract())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected.Contracts["TokenSale"]), string(abi))
+}
+
 func buildFullPath(relativePath string) string {
 	absPath, _ := filepath.Abs(relativePath)
 	return absPath
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/unpackdev/solgo/clients"
@@ -98,7 +99,8 @@ func (a *Account) Balance(ctx context.Context, blockNum *big.Int) (*big.Int, err
 // The method also handles different account types: simple and keystore-based accounts.
 // For simple accounts, it directly uses the provided private key.
 // For keystore accounts, it decrypts the key using the stored password.
-// If 'simulate' is true, it returns transaction options without signing.
+// If 'simulate' is true, it returns transaction options signed by the node through eth_signTransaction, which
+// the local simulator serves for any sender, so transactions of impersonated accounts can be sent.
 func (a *Account) TransactOpts(client *clients.Client, amount *big.Int, simulate bool) (*bind.TransactOpts, error) {
 	nonce, err := client.NonceAt(context.Background(), a.Address, nil)
 	if err != nil {
@@ -163,9 +165,45 @@ func (a *Account) TransactOpts(client *clients.Client, amount *big.Int, simulate
 		Nonce:    big.NewInt(int64(nonce)),
 		Context:  context.Background(),
 		Value:    amount,
+		Signer:   simulatorSigner(client),
 	}, nil
 }
 
+// simulatorSigner returns a signer delegating signing to the node through eth_signTransaction.
+func simulatorSigner(client *clients.Client) bind.SignerFn {
+	return func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		args := map[string]interface{}{
+			"from":  from,
+			"to":    tx.To(),
+			"gas":   hexutil.Uint64(tx.Gas()),
+			"value": (*hexutil.Big)(tx.Value()),
+			"nonce": hexutil.Uint64(tx.Nonce()),
+			"input": hexutil.Bytes(tx.Data()),
+		}
+
+		if tx.Type() == types.DynamicFeeTxType {
+			args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+			args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+		} else {
+			args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+		}
+
+		var result struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := client.GetRpcClient().CallContext(context.Background(), &result, "eth_signTransaction", args); err != nil {
+			return nil, fmt.Errorf("failed to sign simulated transaction: %w", err)
+		}
+
+		signed := new(types.Transaction)
+		if err := signed.UnmarshalBinary(result.Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode simulated transaction: %w", err)
+		}
+
+		return signed, nil
+	}
+}
+
 // Transfer initiates an Ethereum transfer from this account to another address.
 // It ensures the account has sufficient balance and then constructs and signs a transaction.
 // The method uses the account's stored passphrase for signing.
@@ -169,6 +169,7 @@ func (f *AndOperation) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
@@ -300,6 +300,7 @@ func (a *Assignment) ParseStatement(
 		End:         int64(eCtx.GetStop().GetStop()),
 		Length:      int64(eCtx.GetStop().GetStop() - eCtx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(eCtx.GetStart().GetStart())),
 	}
 
 	a.Text = eCtx.GetText()
@@ -330,6 +331,7 @@ func (a *Assignment) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Parsing the operator.
@@ -417,7 +419,8 @@ func parseOperator(operator parser.IAssignOpContext) ast_pb.Operator {
 		return ast_pb.Operator_XOR_EQUAL
 	case operator.AssignShl() != nil:
 		return ast_pb.Operator_SHIFT_LEFT_EQUAL
-	case operator.AssignShr() != nil:
+	case operator.AssignSar() != nil:
+		// Grammar names `>>=` the arithmetic shift, as that is what it does for signed integers.
 		return ast_pb.Operator_SHIFT_RIGHT_EQUAL
 	case operator.AssignBitAnd() != nil:
 		return ast_pb.Operator_BIT_AND_EQUAL
@@ -425,7 +428,7 @@ func parseOperator(operator parser.IAssignOpContext) ast_pb.Operator {
 		return ast_pb.Operator_BIT_OR_EQUAL
 	case operator.AssignBitXor() != nil:
 		return ast_pb.Operator_BIT_XOR_EQUAL
-	case operator.AssignSar() != nil:
+	case operator.AssignShr() != nil:
 		return ast_pb.Operator_POW_EQUAL
 	default:
 		zap.L().Warn(
@@ -235,6 +235,7 @@ func (a *BinaryOperation) ParseAddSub(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	a.Operator = ast_pb.Operator_ADDITION
@@ -274,6 +275,7 @@ func (a *BinaryOperation) ParseOrderComparison(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.GreaterThanOrEqual() != nil {
@@ -321,6 +323,7 @@ func (a *BinaryOperation) ParseMulDivMod(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.Mul() != nil {
@@ -370,6 +373,7 @@ func (a *BinaryOperation) ParseEqualityComparison(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.Equal() != nil {
@@ -413,6 +417,7 @@ func (a *BinaryOperation) ParseOr(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	a.Operator = ast_pb.Operator_OR
@@ -166,6 +166,7 @@ func (f *BitAndOperation) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
@@ -178,6 +178,7 @@ func (f *BitXorOperation) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
@@ -253,6 +253,7 @@ func (b *BodyNode) ParseDefinitions(
 		End:         int64(bodyCtx.GetStop().GetStop()),
 		Length:      int64(bodyCtx.GetStop().GetStop() - bodyCtx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   b.GetSourceFileIndex(int64(bodyCtx.GetStart().GetStart())),
 	}
 	return b
 }
@@ -275,6 +276,7 @@ func (b *BodyNode) ParseBlock(
 		End:         int64(bodyCtx.GetStop().GetStop()),
 		Length:      int64(bodyCtx.GetStop().GetStop() - bodyCtx.GetStart().GetStart() + 1),
 		ParentIndex: parentNode.GetId(),
+		FileIndex:   b.GetSourceFileIndex(int64(bodyCtx.GetStart().GetStart())),
 	}
 
 	// We are considering function implemented in case that there's really anything defined in the body.
@@ -309,6 +311,7 @@ func (b *BodyNode) ParseUncheckedBlock(
 		End:         int64(bodyCtx.GetStop().GetStop()),
 		Length:      int64(bodyCtx.GetStop().GetStop() - bodyCtx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   b.GetSourceFileIndex(int64(bodyCtx.GetStart().GetStart())),
 	}
 
 	for _, statementCtx := range bodyCtx.Block().AllStatement() {
@@ -320,6 +323,21 @@ func (b *BodyNode) ParseUncheckedBlock(
 	return b
 }
 
+// parseUncheckedBlocks appends the unchecked blocks of the block context to the statements, as the
+// grammar does not treat them as statements and ParseBlock does not see them.
+func (b *BodyNode) parseUncheckedBlocks(
+	unit *SourceUnit[Node[ast_pb.SourceUnit]],
+	contractNode Node[NodeType],
+	fnNode Node[NodeType],
+	blockCtx parser.IBlockContext,
+) {
+	for _, uncheckedCtx := range blockCtx.AllUncheckedBlock() {
+		bodyNode := NewBodyNode(b.ASTBuilder, false)
+		bodyNode.ParseUncheckedBlock(unit, contractNode, fnNode, uncheckedCtx)
+		b.Statements = append(b.Statements, bodyNode)
+	}
+}
+
 func (b *BodyNode) ToString() string {
 	return ""
 }
@@ -403,9 +421,9 @@ func (b *BodyNode) parseStatements(
 		))
 	case *parser.BlockContext:
 		bodyNode := NewBodyNode(b.ASTBuilder, true)
-		b.Statements = append(b.Statements, bodyNode.ParseBlock(
-			unit, contractNode, b, childCtx,
-		))
+		bodyNode.ParseBlock(unit, contractNode, b, childCtx)
+		bodyNode.parseUncheckedBlocks(unit, contractNode, fnNode, childCtx)
+		b.Statements = append(b.Statements, bodyNode)
 	default:
 		zap.L().Warn(
 			"Unknown body statement type @ BodyNode.parseStatements",
@@ -22,6 +22,7 @@ type ASTBuilder struct {
 	comments                    []*Comment
 	commentsParsed              bool
 	sourceUnits                 []*SourceUnit[Node[ast_pb.SourceUnit]]
+	sourceFiles                 []*SourceFile
 	currentStateVariables       []*StateVariableDeclaration
 	currentUserDefinedVariables []*UserDefinedValueTypeDefinition
 	currentEvents               []Node[NodeType]
@@ -101,10 +102,20 @@ func (b *ASTBuilder) InterfaceToJSON(data interface{}) ([]byte, error) {
 
 // ResolveReferences resolves the references in the AST using the Resolver of the ASTBuilder.
 func (b *ASTBuilder) ResolveReferences() []error {
+	// Builder state is garbage collected once references are resolved, so it has to be collected
+	// again from the tree in case tree got rewritten afterwards.
+	if b.tree != nil && (len(b.tree.rewritten) > 0 || len(b.tree.unresolved) > 0) {
+		b.tree.reindex()
+	}
+
 	if err := b.resolver.Resolve(); err != nil {
 		return err
 	}
 
+	// Documentation is attached once references are resolved, as inherited documentation is matched
+	// through canonical signatures which depend on referenced declarations.
+	b.attachNatSpec()
+
 	// Cleanup the builder so garbage collector and memory usage is minimized...
 	b.GarbageCollect()
 
@@ -126,6 +137,11 @@ func (b *ASTBuilder) ImportFromJSON(ctx context.Context, jsonBytes []byte) (*Roo
 
 	b.tree.SetRoot(toReturn)
 
+	// Snippets won't be available as file contents are not part of the JSON, however, locations can be resolved.
+	if len(toReturn.SourceFiles) > 0 {
+		b.sourceFiles = toReturn.SourceFiles
+	}
+
 	return toReturn, nil
 }
 
@@ -178,6 +178,7 @@ func (t *CatchStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: tryNode.Id,
+		FileIndex:   t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.Identifier() != nil {
@@ -67,6 +67,7 @@ func (b *ASTBuilder) EnterEveryRule(ctx antlr.ParserRuleContext) {
 						End:         int64(token.GetStop()),
 						Length:      int64(token.GetStop() - token.GetStart() + 1),
 						ParentIndex: b.nextID,
+						FileIndex:   b.GetSourceFileIndex(int64(token.GetStart())),
 					},
 					NodeType: ast_pb.NodeType_COMMENT,
 					Text:     strings.TrimSpace(token.GetText()),
@@ -88,6 +89,7 @@ func (b *ASTBuilder) EnterEveryRule(ctx antlr.ParserRuleContext) {
 						End:         int64(token.GetStop()),
 						Length:      int64(token.GetStop() - token.GetStart() + 1),
 						ParentIndex: b.nextID,
+						FileIndex:   b.GetSourceFileIndex(int64(token.GetStart())),
 					},
 					NodeType: ast_pb.NodeType_COMMENT_MULTILINE,
 					Text:     strings.TrimSpace(token.GetText()),
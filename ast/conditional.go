@@ -188,6 +188,7 @@ func (f *Conditional) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
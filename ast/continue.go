@@ -82,6 +82,7 @@ func (b *ContinueStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.Id,
+		FileIndex:   b.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	return b
 }
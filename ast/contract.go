@@ -25,6 +25,7 @@ type Contract struct {
 	LinearizedBaseContracts []int64          `json:"linearizedBaseContracts"`
 	BaseContracts           []*BaseContract  `json:"baseContracts"`
 	ContractDependencies    []int64          `json:"contractDependencies"`
+	Documentation           *NatSpec         `json:"documentation,omitempty"`
 }
 
 // NewContractDefinition creates a new instance of Contract.
@@ -61,6 +62,11 @@ func (c *Contract) GetSrc() SrcNode {
 	return c.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the Contract or nil if it is not documented.
+func (c *Contract) GetDocumentation() *NatSpec {
+	return c.Documentation
+}
+
 // GetNameLocation returns the source information of the name of the Contract.
 func (c *Contract) GetNameLocation() SrcNode {
 	return c.NameLocation
@@ -257,6 +263,12 @@ func (s *Contract) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &s.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if nameLocation, ok := tempMap["nameLocation"]; ok {
 		if err := json.Unmarshal(nameLocation, &s.NameLocation); err != nil {
 			return err
@@ -364,6 +376,7 @@ func (c *Contract) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Contract
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: rootNode.Id,
+		FileIndex:   c.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Set the absolute path of the source unit from provided sources map.
@@ -401,6 +414,7 @@ func (c *Contract) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Contract
 			End:         int64(ctx.GetStop().GetStop()),
 			Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 			ParentIndex: unit.Id,
+			FileIndex:   c.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 		},
 		NameLocation: SrcNode{
 			Line:        int64(ctx.Identifier().GetStart().GetLine()),
@@ -409,8 +423,9 @@ func (c *Contract) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Contract
 			End:         int64(ctx.Identifier().GetStop().GetStop()),
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: contractId,
+			FileIndex:   c.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 		},
-		Abstract:                false,
+		Abstract:                ctx.Abstract() != nil,
 		NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
 		Kind:                    ast_pb.NodeType_KIND_CONTRACT,
 		LinearizedBaseContracts: make([]int64, 0),
@@ -154,6 +154,7 @@ func (d *Declaration) ParseVariableDeclaration(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: vDeclar.GetId(),
+		FileIndex:   d.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	d.StorageLocation = getStorageLocationFromDataLocationCtx(ctx.DataLocation())
@@ -169,6 +170,7 @@ func (d *Declaration) ParseVariableDeclaration(
 			End:         int64(ctx.Identifier().GetStop().GetStop()),
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: d.Id,
+			FileIndex:   d.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 		}
 	}
 
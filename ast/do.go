@@ -155,6 +155,7 @@ func (d *DoWhileStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.Id,
+		FileIndex:   d.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(d.ASTBuilder)
@@ -172,6 +173,11 @@ func (d *DoWhileStatement) Parse(
 				d.Body.Statements = append(d.Body.Statements, bodyNode)
 			}
 		}
+	} else if ctx.Statement() != nil && ctx.Statement().Block() == nil {
+		// Body that is not a block is a single statement, as in do i--; while (...);
+		bodyNode := NewBodyNode(d.ASTBuilder, false)
+		bodyNode.parseStatements(unit, contractNode, d, ctx.Statement().GetChild(0))
+		d.Body = bodyNode
 	}
 
 	return d
@@ -12,11 +12,12 @@ import (
 type Emit struct {
 	*ASTBuilder
 
-	Id         int64            `json:"id"`         // Unique identifier of the emit statement node.
-	NodeType   ast_pb.NodeType  `json:"nodeType"`   // Type of the node.
-	Src        SrcNode          `json:"src"`        // Source location information.
-	Arguments  []Node[NodeType] `json:"arguments"`  // List of arguments for the emit statement.
-	Expression Node[NodeType]   `json:"expression"` // Expression node associated with the emit statement.
+	Id         int64            `json:"id"`              // Unique identifier of the emit statement node.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`        // Type of the node.
+	Src        SrcNode          `json:"src"`             // Source location information.
+	Arguments  []Node[NodeType] `json:"arguments"`       // List of arguments for the emit statement.
+	Names      []string         `json:"names,omitempty"` // Names of the arguments when they are passed by name.
+	Expression Node[NodeType]   `json:"expression"`      // Expression node associated with the emit statement.
 }
 
 // NewEmitStatement creates a new instance of Emit with the provided ASTBuilder.
@@ -54,6 +55,11 @@ func (e *Emit) GetArguments() []Node[NodeType] {
 	return e.Arguments
 }
 
+// GetNames returns the names of the arguments when they are passed by name, in the order of the arguments.
+func (e *Emit) GetNames() []string {
+	return e.Names
+}
+
 // GetExpression returns the expression node associated with the emit statement.
 func (e *Emit) GetExpression() Node[NodeType] {
 	return e.Expression
@@ -99,6 +105,12 @@ func (e *Emit) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &e.Names); err != nil {
+			return err
+		}
+	}
+
 	if arguments, ok := tempMap["arguments"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(arguments, &nodes); err != nil {
@@ -148,6 +160,7 @@ func (e *Emit) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto converts the Emit node to its corresponding protobuf representation.
+// Protocol buffer definitions have no field for argument names, so named arguments are carried as plain arguments.
 func (e *Emit) ToProto() NodeType {
 	proto := ast_pb.Emit{
 		Id:         e.GetId(),
@@ -178,11 +191,18 @@ func (e *Emit) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]],
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fnNode.GetId(),
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(e.ASTBuilder)
 
-	for _, argumentCtx := range ctx.CallArgumentList().AllExpression() {
+	argumentCtxs := ctx.CallArgumentList().AllExpression()
+	for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+		e.Names = append(e.Names, namedArgumentCtx.GetName().GetText())
+		argumentCtxs = append(argumentCtxs, namedArgumentCtx.GetValue())
+	}
+
+	for _, argumentCtx := range argumentCtxs {
 		argument := expression.Parse(unit, contractNode, fnNode, bodyNode, nil, e, e.GetId(), argumentCtx)
 		e.Arguments = append(e.Arguments, argument)
 	}
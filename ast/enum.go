@@ -191,6 +191,7 @@ func (e *EnumDefinition) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart()),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	e.SourceUnitName = unit.GetName()
 	e.Name = ctx.GetName().GetText()
@@ -201,6 +202,7 @@ func (e *EnumDefinition) Parse(
 		End:         int64(ctx.GetName().GetStop().GetStop()),
 		Length:      int64(ctx.GetName().GetStop().GetStop() - ctx.GetName().GetStart().GetStart() + 1),
 		ParentIndex: e.Id,
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetName().GetStart().GetStart())),
 	}
 	e.CanonicalName = fmt.Sprintf("%s.%s", unit.GetName(), e.Name)
 	e.TypeDescription = &TypeDescription{
@@ -221,6 +223,7 @@ func (e *EnumDefinition) Parse(
 					End:         int64(enumCtx.GetStop().GetStop()),
 					Length:      int64(enumCtx.GetStop().GetStop() - enumCtx.GetStart().GetStart()),
 					ParentIndex: e.Id,
+					FileIndex:   e.GetSourceFileIndex(int64(enumCtx.GetStart().GetStart())),
 				},
 				Name: enumCtx.GetText(),
 				NameLocation: &SrcNode{
@@ -230,6 +233,7 @@ func (e *EnumDefinition) Parse(
 					End:         int64(enumCtx.Identifier().GetSymbol().GetStop()),
 					Length:      int64(enumCtx.Identifier().GetSymbol().GetStop() - enumCtx.Identifier().GetSymbol().GetStart() + 1),
 					ParentIndex: e.Id,
+					FileIndex:   e.GetSourceFileIndex(int64(enumCtx.Identifier().GetSymbol().GetStart())),
 				},
 				NodeType: ast_pb.NodeType_ENUM_VALUE,
 				TypeDescription: &TypeDescription{
@@ -249,11 +253,12 @@ func (e *EnumDefinition) ParseGlobal(
 	ctx *parser.EnumDefinitionContext,
 ) Node[NodeType] {
 	e.Src = SrcNode{
-		Line:   int64(ctx.GetStart().GetLine()),
-		Column: int64(ctx.GetStart().GetColumn()),
-		Start:  int64(ctx.GetStart().GetStart()),
-		End:    int64(ctx.GetStop().GetStop()),
-		Length: int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart()),
+		Line:      int64(ctx.GetStart().GetLine()),
+		Column:    int64(ctx.GetStart().GetColumn()),
+		Start:     int64(ctx.GetStart().GetStart()),
+		End:       int64(ctx.GetStop().GetStop()),
+		Length:    int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart()),
+		FileIndex: e.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	e.SourceUnitName = "Global"
 	e.Name = ctx.GetName().GetText()
@@ -264,6 +269,7 @@ func (e *EnumDefinition) ParseGlobal(
 		End:         int64(ctx.GetName().GetStop().GetStop()),
 		Length:      int64(ctx.GetName().GetStop().GetStop() - ctx.GetName().GetStart().GetStart() + 1),
 		ParentIndex: e.Id,
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetName().GetStart().GetStart())),
 	}
 	e.CanonicalName = fmt.Sprintf("%s.%s", "Global", e.Name)
 	e.TypeDescription = &TypeDescription{
@@ -284,6 +290,7 @@ func (e *EnumDefinition) ParseGlobal(
 					End:         int64(enumCtx.GetStop().GetStop()),
 					Length:      int64(enumCtx.GetStop().GetStop() - enumCtx.GetStart().GetStart()),
 					ParentIndex: e.Id,
+					FileIndex:   e.GetSourceFileIndex(int64(enumCtx.GetStart().GetStart())),
 				},
 				Name: enumCtx.GetText(),
 				NameLocation: &SrcNode{
@@ -293,6 +300,7 @@ func (e *EnumDefinition) ParseGlobal(
 					End:         int64(enumCtx.Identifier().GetSymbol().GetStop()),
 					Length:      int64(enumCtx.Identifier().GetSymbol().GetStop() - enumCtx.Identifier().GetSymbol().GetStart() + 1),
 					ParentIndex: e.Id,
+					FileIndex:   e.GetSourceFileIndex(int64(enumCtx.Identifier().GetSymbol().GetStart())),
 				},
 				NodeType: ast_pb.NodeType_ENUM_VALUE,
 				TypeDescription: &TypeDescription{
@@ -11,13 +11,14 @@ import (
 type EventDefinition struct {
 	*ASTBuilder                      // Embedding the ASTBuilder for common functionality
 	SourceUnitName  string           `json:"-"`
-	Id              int64            `json:"id"`              // Unique identifier for the event definition
-	NodeType        ast_pb.NodeType  `json:"nodeType"`        // Type of the node (EVENT_DEFINITION for event definition)
-	Src             SrcNode          `json:"src"`             // Source information about the event definition
-	Parameters      *ParameterList   `json:"parameters"`      // Parameters of the event
-	Name            string           `json:"name"`            // Name of the event
-	Anonymous       bool             `json:"anonymous"`       // Indicates if the event is anonymous
-	TypeDescription *TypeDescription `json:"typeDescription"` // Type description of the event
+	Id              int64            `json:"id"`                      // Unique identifier for the event definition
+	NodeType        ast_pb.NodeType  `json:"nodeType"`                // Type of the node (EVENT_DEFINITION for event definition)
+	Src             SrcNode          `json:"src"`                     // Source information about the event definition
+	Parameters      *ParameterList   `json:"parameters"`              // Parameters of the event
+	Name            string           `json:"name"`                    // Name of the event
+	Anonymous       bool             `json:"anonymous"`               // Indicates if the event is anonymous
+	TypeDescription *TypeDescription `json:"typeDescription"`         // Type description of the event
+	Documentation   *NatSpec         `json:"documentation,omitempty"` // NatSpec documentation of the event
 }
 
 // NewEventDefinition creates a new EventDefinition instance.
@@ -50,6 +51,11 @@ func (e *EventDefinition) GetSrc() SrcNode {
 	return e.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the event definition or nil if it is not documented.
+func (e *EventDefinition) GetDocumentation() *NatSpec {
+	return e.Documentation
+}
+
 // GetName returns the name of the event.
 func (e *EventDefinition) GetName() string {
 	return e.Name
@@ -105,6 +111,7 @@ func (e *EventDefinition) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	e.Anonymous = ctx.Anonymous() != nil
 	e.Name = ctx.Identifier().GetText()
@@ -139,6 +146,7 @@ func (e *EventDefinition) ParseGlobal(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   e.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	e.Anonymous = ctx.Anonymous() != nil
 	e.Name = ctx.Identifier().GetText()
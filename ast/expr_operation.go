@@ -194,6 +194,7 @@ func (f *ExprOperation) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
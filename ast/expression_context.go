@@ -89,6 +89,7 @@ func (f *ExpressionContext) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	f.Value = ctx.GetText()
 	f.TypeDescription = &TypeDescription{
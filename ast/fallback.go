@@ -9,19 +9,20 @@ import (
 // It encapsulates information about the characteristics and properties of a fallback function within a contract.
 type Fallback struct {
 	*ASTBuilder                            // Embedded ASTBuilder for building the AST.
-	Id               int64                 `json:"id"`               // Unique identifier for the Fallback node.
-	NodeType         ast_pb.NodeType       `json:"nodeType"`         // Type of the AST node.
-	Kind             ast_pb.NodeType       `json:"kind"`             // Kind of the fallback function.
-	Src              SrcNode               `json:"src"`              // Source location information.
-	Implemented      bool                  `json:"implemented"`      // Indicates whether the function is implemented.
-	Visibility       ast_pb.Visibility     `json:"visibility"`       // Visibility of the fallback function.
-	StateMutability  ast_pb.Mutability     `json:"stateMutability"`  // State mutability of the fallback function.
-	Modifiers        []*ModifierInvocation `json:"modifiers"`        // List of modifier invocations applied to the fallback function.
-	Overrides        []*OverrideSpecifier  `json:"overrides"`        // List of override specifiers for the fallback function.
-	Parameters       *ParameterList        `json:"parameters"`       // List of parameters for the fallback function.
-	ReturnParameters *ParameterList        `json:"returnParameters"` // List of return parameters for the fallback function.
-	Body             *BodyNode             `json:"body"`             // Body of the fallback function.
-	Virtual          bool                  `json:"virtual"`          // Indicates whether the function is virtual.
+	Id               int64                 `json:"id"`                      // Unique identifier for the Fallback node.
+	NodeType         ast_pb.NodeType       `json:"nodeType"`                // Type of the AST node.
+	Kind             ast_pb.NodeType       `json:"kind"`                    // Kind of the fallback function.
+	Src              SrcNode               `json:"src"`                     // Source location information.
+	Implemented      bool                  `json:"implemented"`             // Indicates whether the function is implemented.
+	Visibility       ast_pb.Visibility     `json:"visibility"`              // Visibility of the fallback function.
+	StateMutability  ast_pb.Mutability     `json:"stateMutability"`         // State mutability of the fallback function.
+	Modifiers        []*ModifierInvocation `json:"modifiers"`               // List of modifier invocations applied to the fallback function.
+	Overrides        []*OverrideSpecifier  `json:"overrides"`               // List of override specifiers for the fallback function.
+	Parameters       *ParameterList        `json:"parameters"`              // List of parameters for the fallback function.
+	ReturnParameters *ParameterList        `json:"returnParameters"`        // List of return parameters for the fallback function.
+	Body             *BodyNode             `json:"body"`                    // Body of the fallback function.
+	Virtual          bool                  `json:"virtual"`                 // Indicates whether the function is virtual.
+	Documentation    *NatSpec              `json:"documentation,omitempty"` // NatSpec documentation of the fallback function.
 }
 
 // NewFallbackDefinition creates a new Fallback node with default values and returns it.
@@ -53,6 +54,11 @@ func (f *Fallback) GetSrc() SrcNode {
 	return f.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the Fallback node or nil if it is not documented.
+func (f *Fallback) GetDocumentation() *NatSpec {
+	return f.Documentation
+}
+
 // GetType returns the type of the AST node, which is NodeType_FUNCTION_DEFINITION for a fallback function.
 func (f *Fallback) GetType() ast_pb.NodeType {
 	return f.NodeType
@@ -169,6 +175,7 @@ func (f *Fallback) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	f.Implemented = ctx.Block() != nil && !ctx.Block().IsEmpty()
 
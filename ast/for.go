@@ -222,6 +222,7 @@ func (f *ForStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.Id,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.SimpleStatement() != nil {
@@ -258,6 +259,10 @@ func (f *ForStatement) Parse(
 		}
 	} else {
 		bodyNode := NewBodyNode(f.ASTBuilder, false)
+		if ctx.Statement() != nil && ctx.Statement().Block() == nil {
+			// Body that is not a block is a single statement, as in for (...) x += i;
+			bodyNode.parseStatements(unit, contractNode, f, ctx.Statement().GetChild(0))
+		}
 		f.Body = bodyNode
 	}
 
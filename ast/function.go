@@ -39,6 +39,7 @@ type Function struct {
 	ReferencedDeclaration int64                 `json:"referencedDeclaration,omitempty"`
 	TypeDescription       *TypeDescription      `json:"typeDescription"`
 	Text                  string                `json:"text,omitempty"`
+	Documentation         *NatSpec              `json:"documentation,omitempty"`
 }
 
 // NewFunction creates and initializes a new Function node.
@@ -75,6 +76,11 @@ func (f *Function) GetSrc() SrcNode {
 	return f.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the Function node or nil if it is not documented.
+func (f *Function) GetDocumentation() *NatSpec {
+	return f.Documentation
+}
+
 // GetNameLocation returns the source location information of the name of the Function node.
 func (f *Function) GetNameLocation() SrcNode {
 	return f.NameLocation
@@ -244,6 +250,12 @@ func (f *Function) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &f.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if nameLocation, ok := tempMap["nameLocation"]; ok {
 		if err := json.Unmarshal(nameLocation, &f.NameLocation); err != nil {
 			return err
@@ -401,6 +413,7 @@ func (f *Function) Parse(
 			End:         int64(ctx.Identifier().GetStop().GetStop()),
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: f.Id,
+			FileIndex:   f.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 		}
 	}
 	f.Implemented = ctx.Block() != nil && !ctx.Block().IsEmpty()
@@ -411,6 +424,7 @@ func (f *Function) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Set function visibility state.
@@ -519,6 +533,7 @@ func (f *Function) ParseTypeName(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Set function visibility state.
@@ -21,6 +21,7 @@ type FunctionCall struct {
 	Src                   SrcNode            `json:"src"`                             // Source location of the node.
 	ArgumentTypes         []*TypeDescription `json:"argumentTypes"`                   // Types of the arguments.
 	Arguments             []Node[NodeType]   `json:"arguments"`                       // Arguments of the function call.
+	Names                 []string           `json:"names,omitempty"`                 // Names of the arguments when they are passed by name.
 	Expression            Node[NodeType]     `json:"expression"`                      // Expression of the function call.
 	ReferencedDeclaration int64              `json:"referencedDeclaration,omitempty"` // Referenced declaration of the function call.
 	TypeDescription       *TypeDescription   `json:"typeDescription"`                 // Type description of the function call.
@@ -88,6 +89,11 @@ func (f *FunctionCall) GetExpression() Node[NodeType] {
 	return f.Expression
 }
 
+// GetNames returns the names of the arguments when they are passed by name, in the order of the arguments.
+func (f *FunctionCall) GetNames() []string {
+	return f.Names
+}
+
 // GetTypeDescription returns the type description of the FunctionCall node.
 // Currently, it returns nil and needs to be implemented.
 func (f *FunctionCall) GetTypeDescription() *TypeDescription {
@@ -165,6 +171,12 @@ func (f *FunctionCall) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &f.Names); err != nil {
+			return err
+		}
+	}
+
 	if arguments, ok := tempMap["arguments"]; ok {
 		f.Arguments = make([]Node[NodeType], 0)
 		var nodes []json.RawMessage
@@ -215,7 +227,7 @@ func (f *FunctionCall) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto returns a protobuf representation of the FunctionCall node.
-// Currently, it returns an empty Statement and needs to be implemented.
+// Protocol buffer definitions have no field for argument names, so named arguments are carried as plain arguments.
 func (f *FunctionCall) ToProto() NodeType {
 	proto := ast_pb.FunctionCall{
 		Id:                    f.GetId(),
@@ -284,6 +296,7 @@ func (f *FunctionCall) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
@@ -295,7 +308,13 @@ func (f *FunctionCall) Parse(
 	}
 
 	if ctx.CallArgumentList() != nil {
-		for _, expressionCtx := range ctx.CallArgumentList().AllExpression() {
+		expressionCtxs := ctx.CallArgumentList().AllExpression()
+		for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+			f.Names = append(f.Names, namedArgumentCtx.GetName().GetText())
+			expressionCtxs = append(expressionCtxs, namedArgumentCtx.GetValue())
+		}
+
+		for _, expressionCtx := range expressionCtxs {
 			expr := expression.Parse(unit, contractNode, fnNode, bodyNode, nil, f, f.GetId(), expressionCtx)
 			f.Arguments = append(
 				f.Arguments,
@@ -366,6 +385,8 @@ type FunctionCallOption struct {
 	Kind                  ast_pb.NodeType  `json:"kind"`                            // Kind of the node.
 	Src                   SrcNode          `json:"src"`                             // Source location of the node.
 	Expression            Node[NodeType]   `json:"expression"`                      // Expression of the function call.
+	Names                 []string         `json:"names,omitempty"`                 // Names of the call options, such as value or gas.
+	Options               []Node[NodeType] `json:"options,omitempty"`               // Values of the call options, in the order of the names.
 	ReferencedDeclaration int64            `json:"referencedDeclaration,omitempty"` // Referenced declaration of the function call.
 	TypeDescription       *TypeDescription `json:"typeDescription"`                 // Type description of the function call.
 }
@@ -412,15 +433,27 @@ func (f *FunctionCallOption) GetExpression() Node[NodeType] {
 	return f.Expression
 }
 
+// GetNames returns the names of the call options.
+func (f *FunctionCallOption) GetNames() []string {
+	return f.Names
+}
+
+// GetOptions returns the values of the call options, in the order of the names.
+func (f *FunctionCallOption) GetOptions() []Node[NodeType] {
+	return f.Options
+}
+
 // GetTypeDescription returns the type description of the FunctionCallOption node.
 // Currently, it returns nil and needs to be implemented.
 func (f *FunctionCallOption) GetTypeDescription() *TypeDescription {
 	return f.TypeDescription
 }
 
-// GetNodes returns a slice of nodes that includes the expression of the FunctionCallOption node.
+// GetNodes returns a slice of nodes that includes the expression and the option values of the FunctionCallOption node.
 func (f *FunctionCallOption) GetNodes() []Node[NodeType] {
-	return []Node[NodeType]{f.Expression}
+	toReturn := []Node[NodeType]{f.Expression}
+	toReturn = append(toReturn, f.Options...)
+	return toReturn
 }
 
 // GetReferenceDeclaration returns the referenced declaration of the FunctionCallOption node.
@@ -471,6 +504,37 @@ func (f *FunctionCallOption) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &f.Names); err != nil {
+			return err
+		}
+	}
+
+	if options, ok := tempMap["options"]; ok {
+		var nodes []json.RawMessage
+		if err := json.Unmarshal(options, &nodes); err != nil {
+			return err
+		}
+
+		for _, tempNode := range nodes {
+			var tempNodeMap map[string]json.RawMessage
+			if err := json.Unmarshal(tempNode, &tempNodeMap); err != nil {
+				return err
+			}
+
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(tempNode, tempNodeType)
+			if err != nil {
+				return err
+			}
+			f.Options = append(f.Options, node)
+		}
+	}
+
 	if expression, ok := tempMap["expression"]; ok {
 		if err := json.Unmarshal(expression, &f.Expression); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -495,7 +559,7 @@ func (f *FunctionCallOption) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto returns a protobuf representation of the FunctionCallOption node.
-// Currently, it returns an empty Statement and needs to be implemented.
+// Protocol buffer definitions have no fields for the options, so only the called expression is carried.
 func (f *FunctionCallOption) ToProto() NodeType {
 	proto := ast_pb.FunctionCallOption{
 		Id:                    f.GetId(),
@@ -551,6 +615,7 @@ func (f *FunctionCallOption) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
@@ -562,5 +627,12 @@ func (f *FunctionCallOption) Parse(
 		f.TypeDescription = f.Expression.GetTypeDescription()
 	}
 
+	for _, namedArgumentCtx := range ctx.AllNamedArgument() {
+		f.Names = append(f.Names, namedArgumentCtx.GetName().GetText())
+		f.Options = append(f.Options, expression.Parse(
+			unit, contractNode, fnNode, bodyNode, nil, f, f.GetId(), namedArgumentCtx.GetValue(),
+		))
+	}
+
 	return f
 }
@@ -12,11 +12,12 @@ import (
 type IfStatement struct {
 	*ASTBuilder
 
-	Id        int64           `json:"id"`        // Unique identifier of the if statement node.
-	NodeType  ast_pb.NodeType `json:"nodeType"`  // Type of the node.
-	Src       SrcNode         `json:"src"`       // Source location information.
-	Condition Node[NodeType]  `json:"condition"` // Condition node.
-	Body      Node[NodeType]  `json:"body"`      // Body node.
+	Id        int64           `json:"id"`                  // Unique identifier of the if statement node.
+	NodeType  ast_pb.NodeType `json:"nodeType"`            // Type of the node.
+	Src       SrcNode         `json:"src"`                 // Source location information.
+	Condition Node[NodeType]  `json:"condition"`           // Condition node.
+	Body      Node[NodeType]  `json:"body"`                // Body node.
+	FalseBody Node[NodeType]  `json:"falseBody,omitempty"` // Body of the else branch, nil without one.
 }
 
 // NewIfStatement creates a new instance of IfStatement with the provided ASTBuilder.
@@ -61,8 +62,11 @@ func (i *IfStatement) GetTypeDescription() *TypeDescription {
 	}
 }
 
-// GetNodes returns a list of nodes associated with the if statement (condition and body).
+// GetNodes returns a list of nodes associated with the if statement (condition, body and else body).
 func (i *IfStatement) GetNodes() []Node[NodeType] {
+	if i.FalseBody != nil {
+		return []Node[NodeType]{i.Condition, i.Body, i.FalseBody}
+	}
 	return []Node[NodeType]{i.Condition, i.Body}
 }
 
@@ -71,6 +75,12 @@ func (i *IfStatement) GetBody() Node[NodeType] {
 	return i.Body
 }
 
+// GetFalseBody returns the body of the else branch of the if statement, or nil if there is none.
+// An `else if` is represented as a body holding the nested if statement.
+func (i *IfStatement) GetFalseBody() Node[NodeType] {
+	return i.FalseBody
+}
+
 // UnmarshalJSON unmarshals the JSON data into a IfStatement.
 func (i *IfStatement) UnmarshalJSON(data []byte) error {
 	var tempMap map[string]json.RawMessage
@@ -136,10 +146,31 @@ func (i *IfStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if falseBody, ok := tempMap["falseBody"]; ok {
+		if err := json.Unmarshal(falseBody, &i.FalseBody); err != nil {
+			var tempNodeMap map[string]json.RawMessage
+			if err := json.Unmarshal(falseBody, &tempNodeMap); err != nil {
+				return err
+			}
+
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(falseBody, tempNodeType)
+			if err != nil {
+				return err
+			}
+			i.FalseBody = node
+		}
+	}
+
 	return nil
 }
 
 // ToProto converts the IfStatement node to its corresponding protobuf representation.
+// The protobuf definition has no else branch, so the else body is only retained by the JSON representation.
 func (i *IfStatement) ToProto() NodeType {
 	proto := ast_pb.If{
 		Id:        i.GetId(),
@@ -169,34 +200,41 @@ func (i *IfStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.Id,
+		FileIndex:   i.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(i.ASTBuilder)
 
 	i.Condition = expression.Parse(unit, contractNode, fnNode, bodyNode, nil, i, i.GetId(), ctx.Expression())
+	i.Body = i.parseBranch(unit, contractNode, fnNode, ctx.Statement(0))
 
-	body := NewBodyNode(i.ASTBuilder, false)
-	if len(ctx.AllStatement()) > 0 {
-		for _, statementCtx := range ctx.AllStatement() {
-			if statementCtx.IsEmpty() {
-				continue
-			}
-
-			if statementCtx.Block() != nil {
-				body.ParseBlock(unit, contractNode, fnNode, statementCtx.Block())
-				break
-			}
+	if ctx.Else() != nil {
+		i.FalseBody = i.parseBranch(unit, contractNode, fnNode, ctx.Statement(1))
+	}
 
-			// There can be single-statement conditional...
-			body.parseStatements(unit, contractNode, fnNode, statementCtx.GetChild(0))
+	return i
+}
 
-			i.Body = body
-		}
+// parseBranch parses the statement of the then or else branch into a body. Branches that are not
+// blocks hold a single statement, such as the nested if statement of an `else if`, and like other
+// single statement bodies do not get a location of their own.
+func (i *IfStatement) parseBranch(
+	unit *SourceUnit[Node[ast_pb.SourceUnit]],
+	contractNode Node[NodeType],
+	fnNode Node[NodeType],
+	statementCtx parser.IStatementContext,
+) *BodyNode {
+	body := NewBodyNode(i.ASTBuilder, false)
+	if statementCtx == nil || statementCtx.IsEmpty() {
+		return body
+	}
 
-		i.Body = body
-	} else {
-		i.Body = body
+	if statementCtx.Block() != nil {
+		body.ParseBlock(unit, contractNode, fnNode, statementCtx.Block())
+		body.parseUncheckedBlocks(unit, contractNode, fnNode, statementCtx.Block())
+		return body
 	}
 
-	return i
+	body.parseStatements(unit, contractNode, fnNode, statementCtx.GetChild(0))
+	return body
 }
@@ -11,17 +11,24 @@ import (
 
 // Import represents an import node in the abstract syntax tree.
 type Import struct {
-	Id           int64           `json:"id"`                     // Unique identifier of the import node.
-	NodeType     ast_pb.NodeType `json:"nodeType"`               // Type of the node.
-	Src          SrcNode         `json:"src"`                    // Source location information.
-	NameLocation *SrcNode        `json:"nameLocation,omitempty"` // Source location information of the name.
-	AbsolutePath string          `json:"absolutePath"`           // Absolute path of the imported file.
-	File         string          `json:"file"`                   // Filepath of the import statement.
-	Scope        int64           `json:"scope"`                  // Scope of the import.
-	UnitAlias    string          `json:"unitAlias"`              // Alias of the imported unit.
-	As           string          `json:"as"`                     // Alias of the imported unit.
-	UnitAliases  []string        `json:"unitAliases"`            // Alias of the imported unit.
-	SourceUnit   int64           `json:"sourceUnit"`             // Source unit identifier.
+	Id            int64           `json:"id"`                      // Unique identifier of the import node.
+	NodeType      ast_pb.NodeType `json:"nodeType"`                // Type of the node.
+	Src           SrcNode         `json:"src"`                     // Source location information.
+	NameLocation  *SrcNode        `json:"nameLocation,omitempty"`  // Source location information of the name.
+	AbsolutePath  string          `json:"absolutePath"`            // Absolute path of the imported file.
+	File          string          `json:"file"`                    // Filepath of the import statement.
+	Scope         int64           `json:"scope"`                   // Scope of the import.
+	UnitAlias     string          `json:"unitAlias"`               // Alias of the imported unit.
+	As            string          `json:"as"`                      // Alias of the imported unit.
+	UnitAliases   []string        `json:"unitAliases"`             // Alias of the imported unit.
+	SymbolAliases []*ImportSymbol `json:"symbolAliases,omitempty"` // Symbols imported by name.
+	SourceUnit    int64           `json:"sourceUnit"`              // Source unit identifier.
+}
+
+// ImportSymbol is a symbol imported by name, together with its local alias.
+type ImportSymbol struct {
+	Name  string `json:"name"`            // Name of the symbol in the imported file.
+	Alias string `json:"alias,omitempty"` // Local alias of the symbol.
 }
 
 // SetReferenceDescriptor sets the reference descriptions of the Import node.
@@ -104,6 +111,11 @@ func (i *Import) GetUnitAliases() []string {
 	return i.UnitAliases
 }
 
+// GetSymbolAliases returns the symbols imported by name.
+func (i *Import) GetSymbolAliases() []*ImportSymbol {
+	return i.SymbolAliases
+}
+
 // GetAs returns the alias of the imported unit.
 func (i *Import) GetAs() string {
 	return i.As
@@ -178,6 +190,7 @@ func parseImportPathsForSourceUnit(
 					End:         int64(importCtx.GetStop().GetStop()),
 					Length:      int64(importCtx.GetStop().GetStop() - importCtx.GetStart().GetStart() + 1),
 					ParentIndex: unit.Id,
+					FileIndex:   b.GetSourceFileIndex(int64(importCtx.GetStart().GetStart())),
 				},
 				AbsolutePath: func() string {
 					path := filepath.Clean(importCtx.Path().GetText())
@@ -186,9 +199,9 @@ func parseImportPathsForSourceUnit(
 					toReturn = strings.ReplaceAll(toReturn, "'", "")
 					return toReturn
 				}(),
+				// File is kept as written in the import directive, the same way solc does.
 				File: func() string {
-					toReturn := filepath.Clean(importCtx.Path().GetText())
-					toReturn = strings.ReplaceAll(toReturn, "\"", "")
+					toReturn := strings.ReplaceAll(importCtx.Path().GetText(), "\"", "")
 					toReturn = strings.ReplaceAll(toReturn, "'", "")
 					return toReturn
 				}(),
@@ -204,6 +217,7 @@ func parseImportPathsForSourceUnit(
 					End:         int64(importCtx.Identifier().GetStop().GetStop()),
 					Length:      int64(importCtx.Identifier().GetStop().GetStop() - importCtx.Identifier().GetStart().GetStart() + 1),
 					ParentIndex: importNodeId,
+					FileIndex:   b.GetSourceFileIndex(int64(importCtx.Identifier().GetStart().GetStart())),
 				}
 			}
 
@@ -211,15 +225,14 @@ func parseImportPathsForSourceUnit(
 				importNode.UnitAlias = importCtx.GetUnitAlias().GetText()
 			}
 
-			if importCtx.As() != nil {
-				importNode.UnitAlias = importCtx.As().GetText()
-			}
-
 			if importCtx.SymbolAliases() != nil {
 				for _, aliasCtx := range importCtx.SymbolAliases().AllImportAliases() {
+					symbol := &ImportSymbol{Name: aliasCtx.GetSymbol().GetText()}
 					if aliasCtx.GetAlias() != nil {
+						symbol.Alias = aliasCtx.GetAlias().GetText()
 						importNode.UnitAliases = append(importNode.UnitAliases, aliasCtx.GetAlias().GetText())
 					}
+					importNode.SymbolAliases = append(importNode.SymbolAliases, symbol)
 				}
 			}
 
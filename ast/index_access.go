@@ -241,6 +241,7 @@ func (i *IndexAccess) Parse(
 
 			return bodyNode.GetId()
 		}(),
+		FileIndex: i.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(i.ASTBuilder)
@@ -15,6 +15,7 @@ type IndexRange struct {
 	Id               int64              `json:"id"`
 	NodeType         ast_pb.NodeType    `json:"nodeType"`
 	Src              SrcNode            `json:"src"`
+	BaseExpression   Node[NodeType]     `json:"baseExpression"`
 	LeftExpression   Node[NodeType]     `json:"leftExpression"`
 	RightExpression  Node[NodeType]     `json:"rightExpression"`
 	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`
@@ -53,20 +54,34 @@ func (f *IndexRange) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description associated with the IndexRange.
 func (f *IndexRange) GetTypeDescription() *TypeDescription {
+	if len(f.TypeDescriptions) == 0 {
+		return nil
+	}
 	return f.TypeDescriptions[0]
 }
 
 // GetNodes returns the list of nodes within the IndexRange.
 func (f *IndexRange) GetNodes() []Node[NodeType] {
-	return []Node[NodeType]{f.LeftExpression, f.RightExpression}
+	toReturn := make([]Node[NodeType], 0, 3)
+	for _, node := range []Node[NodeType]{f.BaseExpression, f.LeftExpression, f.RightExpression} {
+		if node != nil {
+			toReturn = append(toReturn, node)
+		}
+	}
+	return toReturn
 }
 
-// GetLeftExpression returns the left expression of the IndexRange.
+// GetBaseExpression returns the expression that is sliced by the IndexRange.
+func (f *IndexRange) GetBaseExpression() Node[NodeType] {
+	return f.BaseExpression
+}
+
+// GetLeftExpression returns the start index expression of the IndexRange, or nil if it is omitted.
 func (f *IndexRange) GetLeftExpression() Node[NodeType] {
 	return f.LeftExpression
 }
 
-// GetRightExpression returns the right expression of the IndexRange.
+// GetRightExpression returns the end index expression of the IndexRange, or nil if it is omitted.
 func (f *IndexRange) GetRightExpression() Node[NodeType] {
 	return f.RightExpression
 }
@@ -95,6 +110,26 @@ func (f *IndexRange) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if baseExpression, ok := tempMap["baseExpression"]; ok {
+		if err := json.Unmarshal(baseExpression, &f.BaseExpression); err != nil {
+			var tempNodeMap map[string]json.RawMessage
+			if err := json.Unmarshal(baseExpression, &tempNodeMap); err != nil {
+				return err
+			}
+
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(baseExpression, tempNodeType)
+			if err != nil {
+				return err
+			}
+			f.BaseExpression = node
+		}
+	}
+
 	if leftExpression, ok := tempMap["leftExpression"]; ok {
 		if err := json.Unmarshal(leftExpression, &f.LeftExpression); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -145,16 +180,23 @@ func (f *IndexRange) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto converts the IndexRange node to its Protocol Buffers representation.
+// Protocol buffer definitions have no field for the base expression, so only the indexes are carried.
 func (f *IndexRange) ToProto() NodeType {
 	proto := ast_pb.IndexRange{
 		Id:              f.GetId(),
 		NodeType:        f.GetType(),
 		Src:             f.GetSrc().ToProto(),
-		LeftExpression:  f.GetLeftExpression().ToProto().(*v3.TypedStruct),
-		RightExpression: f.GetRightExpression().ToProto().(*v3.TypedStruct),
 		TypeDescription: f.GetTypeDescription().ToProto(),
 	}
 
+	if f.GetLeftExpression() != nil {
+		proto.LeftExpression = f.GetLeftExpression().ToProto().(*v3.TypedStruct)
+	}
+
+	if f.GetRightExpression() != nil {
+		proto.RightExpression = f.GetRightExpression().ToProto().(*v3.TypedStruct)
+	}
+
 	return NewTypedStruct(&proto, "IndexRange")
 }
 
@@ -194,15 +236,24 @@ func (f *IndexRange) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(f.ASTBuilder)
 
-	f.LeftExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.Expression(0))
-	f.RightExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.Expression(1))
+	f.BaseExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.Expression(0))
+	f.TypeDescriptions = append(f.TypeDescriptions, f.BaseExpression.GetTypeDescription())
 
-	f.TypeDescriptions = append(f.TypeDescriptions, f.LeftExpression.GetTypeDescription())
-	f.TypeDescriptions = append(f.TypeDescriptions, f.RightExpression.GetTypeDescription())
+	// Both indexes of the range are optional, as in data[4:] or data[:4].
+	if ctx.GetStartIndex() != nil {
+		f.LeftExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.GetStartIndex())
+		f.TypeDescriptions = append(f.TypeDescriptions, f.LeftExpression.GetTypeDescription())
+	}
+
+	if ctx.GetEndIndex() != nil {
+		f.RightExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.GetEndIndex())
+		f.TypeDescriptions = append(f.TypeDescriptions, f.RightExpression.GetTypeDescription())
+	}
 
 	return f
 }
@@ -24,6 +24,7 @@ func parseInheritanceFromCtx(b *ASTBuilder, unit *SourceUnit[Node[ast_pb.SourceU
 				End:         int64(specifierCtx.GetStop().GetStop()),
 				Length:      int64(specifierCtx.GetStop().GetStop() - specifierCtx.GetStart().GetStart() + 1),
 				ParentIndex: contractNode.GetId(),
+				FileIndex:   b.GetSourceFileIndex(int64(specifierCtx.GetStart().GetStart())),
 			},
 			NodeType: ast_pb.NodeType_INHERITANCE_SPECIFIER,
 			BaseName: &BaseContractName{
@@ -35,6 +36,7 @@ func parseInheritanceFromCtx(b *ASTBuilder, unit *SourceUnit[Node[ast_pb.SourceU
 					End:         int64(specifierCtx.GetStop().GetStop()),
 					Length:      int64(specifierCtx.GetStop().GetStop() - specifierCtx.GetStart().GetStart() + 1),
 					ParentIndex: contractNode.GetId(),
+					FileIndex:   b.GetSourceFileIndex(int64(specifierCtx.GetStart().GetStart())),
 				},
 				NodeType: ast_pb.NodeType_IDENTIFIER_PATH,
 				Name:     specifierCtx.IdentifierPath().GetText(),
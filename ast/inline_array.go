@@ -204,6 +204,7 @@ func (f *InlineArray) Parse(
 
 			return contractNode.GetId()
 		}(),
+		FileIndex: f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	f.Empty = ctx.IsEmpty()
 
@@ -27,6 +27,7 @@ type Interface struct {
 	LinearizedBaseContracts []int64          `json:"linearizedBaseContracts"` // List of linearized base contract identifiers.
 	BaseContracts           []*BaseContract  `json:"baseContracts"`           // List of base contracts.
 	ContractDependencies    []int64          `json:"contractDependencies"`    // List of contract dependency identifiers.
+	Documentation           *NatSpec         `json:"documentation,omitempty"` // NatSpec documentation of the interface.
 }
 
 // NewInterfaceDefinition creates a new Interface node with default values and returns it.
@@ -63,6 +64,11 @@ func (l *Interface) GetSrc() SrcNode {
 	return l.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the Interface node or nil if it is not documented.
+func (l *Interface) GetDocumentation() *NatSpec {
+	return l.Documentation
+}
+
 // GetNameLocation returns the location of the interface name.
 func (l *Interface) GetNameLocation() SrcNode {
 	return l.NameLocation
@@ -257,6 +263,12 @@ func (l *Interface) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &l.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if nameLocation, ok := tempMap["nameLocation"]; ok {
 		if err := json.Unmarshal(nameLocation, &l.NameLocation); err != nil {
 			return err
@@ -365,6 +377,7 @@ func (l *Interface) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Interfa
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: rootNode.Id,
+		FileIndex:   l.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Set the absolute path of the source unit from provided sources map.
@@ -394,6 +407,7 @@ func (l *Interface) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Interfa
 			End:         int64(ctx.GetStop().GetStop()),
 			Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 			ParentIndex: unit.Id,
+			FileIndex:   l.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 		},
 		NameLocation: SrcNode{
 			Line:        int64(ctx.Identifier().GetStart().GetLine()),
@@ -402,6 +416,7 @@ func (l *Interface) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Interfa
 			End:         int64(ctx.Identifier().GetStop().GetStop()),
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: interfaceId,
+			FileIndex:   l.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 		},
 		Abstract:                false,
 		NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
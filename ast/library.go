@@ -28,6 +28,7 @@ type Library struct {
 	LinearizedBaseContracts []int64          `json:"linearizedBaseContracts"` // LinearizedBaseContracts are the linearized base contracts of the library.
 	BaseContracts           []*BaseContract  `json:"baseContracts"`           // BaseContracts are the base contracts of the library.
 	ContractDependencies    []int64          `json:"contractDependencies"`    // ContractDependencies are the contract dependencies of the library.
+	Documentation           *NatSpec         `json:"documentation,omitempty"` // Documentation is the NatSpec documentation of the library.
 }
 
 // NewLibraryDefinition creates a new Library with the provided ASTBuilder.
@@ -64,6 +65,11 @@ func (l *Library) GetSrc() SrcNode {
 	return l.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the library node or nil if it is not documented.
+func (l *Library) GetDocumentation() *NatSpec {
+	return l.Documentation
+}
+
 // GetNameLocation returns the source node associated with the name of the library node.
 func (l *Library) GetNameLocation() SrcNode {
 	return l.NameLocation
@@ -259,6 +265,12 @@ func (l *Library) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &l.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if nameLocation, ok := tempMap["nameLocation"]; ok {
 		if err := json.Unmarshal(nameLocation, &l.NameLocation); err != nil {
 			return err
@@ -369,6 +381,7 @@ func (l *Library) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.LibraryDe
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: rootNode.Id,
+		FileIndex:   l.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Set the absolute path of the source unit from provided sources map.
@@ -404,6 +417,7 @@ func (l *Library) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.LibraryDe
 			End:         int64(ctx.GetStop().GetStop()),
 			Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 			ParentIndex: unit.Id,
+			FileIndex:   l.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 		},
 		NameLocation: SrcNode{
 			Line:        int64(ctx.Identifier().GetStart().GetLine()),
@@ -412,6 +426,7 @@ func (l *Library) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.LibraryDe
 			End:         int64(ctx.Identifier().GetStop().GetStop()),
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: libraryId,
+			FileIndex:   l.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 		},
 		Abstract:                false,
 		NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
@@ -0,0 +1,234 @@
+package ast
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// combinedSourceSeparator is the separator solgo.Sources uses between source units when combining them.
+const combinedSourceSeparator = "\n\n"
+
+// SourceFile describes a single source file that is part of the combined source the AST was built from.
+// Start and StartLine describe where the file begins within the combined source, which is what
+// positions within SrcNode are relative to. Positions are in characters, same as the ANTLR tokens.
+type SourceFile struct {
+	Index      int64    `json:"index"`     // Index of the source file, same as solc source id.
+	Name       string   `json:"name"`      // Name of the source unit.
+	Path       string   `json:"path"`      // Source unit name of the file, never an absolute filesystem path.
+	Start      int64    `json:"start"`     // Start position of the file within the combined source.
+	Length     int64    `json:"length"`    // Length of the file in characters.
+	StartLine  int64    `json:"startLine"` // Line within the combined source at which the file begins.
+	lines      []string // Content of the file split into lines, used to extract snippets.
+	size       int64    // Size of the file in bytes.
+	runeStarts []int64  // Byte offset of every character, only set when the file is not pure ASCII.
+	diskPath   string   // Path of the file on the disk, if the source unit was read from it.
+}
+
+// GetIndex returns the index of the source file.
+func (f *SourceFile) GetIndex() int64 {
+	return f.Index
+}
+
+// GetName returns the name of the source file.
+func (f *SourceFile) GetName() string {
+	return f.Name
+}
+
+// GetPath returns the path of the source file.
+func (f *SourceFile) GetPath() string {
+	return f.Path
+}
+
+// GetDiskPath returns the path of the source file on the disk. It is only known for source units read from
+// the disk and is not part of the serialized AST.
+func (f *SourceFile) GetDiskPath() string {
+	return f.diskPath
+}
+
+// Contains returns true if the position within the combined source belongs to the source file.
+func (f *SourceFile) Contains(start int64) bool {
+	return start >= f.Start && start < f.Start+f.Length
+}
+
+// byteOffset converts the character offset within the source file into a byte offset, which is what
+// solc source locations are expressed in.
+func (f *SourceFile) byteOffset(offset int64) int64 {
+	if offset < 0 {
+		return 0
+	}
+	if f.runeStarts == nil {
+		return min(offset, f.size)
+	}
+	if offset >= int64(len(f.runeStarts)) {
+		return f.size
+	}
+	return f.runeStarts[offset]
+}
+
+// GetLine returns the line of the source file, starting from 1, or an empty string if it does not exist.
+func (f *SourceFile) GetLine(line int64) string {
+	if line < 1 || line > int64(len(f.lines)) {
+		return ""
+	}
+	return f.lines[line-1]
+}
+
+// GetContent returns the full content of the source file.
+func (f *SourceFile) GetContent() string {
+	return strings.Join(f.lines, "\n")
+}
+
+// SourceLocation represents a node location resolved back to the source file it came from.
+// Line, column and start are relative to the source file and not to the combined source. Start and length
+// are in bytes, same as solc source locations, while the column is in characters.
+type SourceLocation struct {
+	NodeId    int64  `json:"nodeId"`
+	FileIndex int64  `json:"fileIndex"`
+	Path      string `json:"path"`
+	Line      int64  `json:"line"`
+	Column    int64  `json:"column"`
+	Start     int64  `json:"start"`
+	Length    int64  `json:"length"`
+	Snippet   string `json:"snippet"`
+}
+
+// String returns the location in the `path:line:column` format understood by editors and terminals.
+// Column is converted to start from 1.
+func (l *SourceLocation) String() string {
+	return fmt.Sprintf("%s:%d:%d", l.Path, l.Line, l.Column+1)
+}
+
+// ToSolcSrc returns the location in the solc `start:length:fileIndex` format.
+func (l *SourceLocation) ToSolcSrc() string {
+	return fmt.Sprintf("%d:%d:%d", l.Start, l.Length, l.FileIndex)
+}
+
+// GetSourceFiles returns the source files the AST is built from, in the order they appear in the
+// combined source. Files are resolved from the sources on first use.
+func (b *ASTBuilder) GetSourceFiles() []*SourceFile {
+	if b == nil {
+		return nil
+	}
+
+	if b.sourceFiles != nil || b.sources == nil {
+		return b.sourceFiles
+	}
+
+	b.sourceFiles = make([]*SourceFile, 0, len(b.sources.SourceUnits))
+
+	var start, startLine int64 = 0, 1
+	separatorLength := int64(len(combinedSourceSeparator))
+	separatorLines := int64(strings.Count(combinedSourceSeparator, "\n"))
+
+	for i, unit := range b.sources.SourceUnits {
+		content := unit.GetContent()
+		length := int64(utf8.RuneCountInString(content))
+		file := &SourceFile{
+			Index:     int64(i),
+			Name:      unit.GetName(),
+			Path:      b.sources.GetSourceUnitPath(unit),
+			Start:     start,
+			Length:    length,
+			StartLine: startLine,
+			lines:     strings.Split(content, "\n"),
+			size:      int64(len(content)),
+			diskPath:  unit.GetPath(),
+		}
+
+		if length != file.size {
+			file.runeStarts = make([]int64, 0, length)
+			for offset := range content {
+				file.runeStarts = append(file.runeStarts, int64(offset))
+			}
+		}
+
+		b.sourceFiles = append(b.sourceFiles, file)
+
+		start += length + separatorLength
+		startLine += int64(strings.Count(unit.GetContent(), "\n")) + separatorLines
+	}
+
+	return b.sourceFiles
+}
+
+// GetSourceFile returns the source file under the provided index or nil if it does not exist.
+func (b *ASTBuilder) GetSourceFile(index int64) *SourceFile {
+	files := b.GetSourceFiles()
+	if index < 0 || index >= int64(len(files)) {
+		return nil
+	}
+	return files[index]
+}
+
+// GetSourceFileIndex returns the index of the source file the position within the combined source belongs to.
+// Positions that fall between files, or when sources are not available, resolve to the closest preceding file.
+func (b *ASTBuilder) GetSourceFileIndex(start int64) int64 {
+	files := b.GetSourceFiles()
+	if len(files) == 0 {
+		return 0
+	}
+
+	idx := sort.Search(len(files), func(i int) bool {
+		return files[i].Start > start
+	})
+
+	if idx == 0 {
+		return 0
+	}
+
+	return files[idx-1].Index
+}
+
+// LocateSrc resolves the source node back to the file it came from, including file relative
+// line, column and byte offset together with the source line as the snippet.
+func (b *ASTBuilder) LocateSrc(src SrcNode) (*SourceLocation, error) {
+	file := b.GetSourceFile(src.GetFileIndex())
+	if file == nil {
+		return nil, fmt.Errorf("source file with index %d not found", src.GetFileIndex())
+	}
+
+	line := src.GetLine() - file.StartLine + 1
+	start := file.byteOffset(src.GetStart() - file.Start)
+
+	return &SourceLocation{
+		FileIndex: file.Index,
+		Path:      file.Path,
+		Line:      line,
+		Column:    src.GetColumn(),
+		Start:     start,
+		Length:    file.byteOffset(src.GetStart()-file.Start+src.GetLength()) - start,
+		Snippet:   file.GetLine(line),
+	}, nil
+}
+
+// LocateNode resolves the node with the provided id back to the source file it came from.
+// It returns an error if the node does not exist or the file cannot be resolved.
+func (b *ASTBuilder) LocateNode(id int64) (*SourceLocation, error) {
+	if b.tree == nil || b.tree.GetRoot() == nil {
+		return nil, fmt.Errorf("ast is not built")
+	}
+
+	node := b.tree.GetById(id)
+	if node == nil {
+		return nil, fmt.Errorf("node with id %d not found", id)
+	}
+
+	location, err := b.LocateSrc(node.GetSrc())
+	if err != nil {
+		return nil, err
+	}
+	location.NodeId = id
+
+	return location, nil
+}
+
+// ToSolcSrc returns the source node in the solc `start:length:fileIndex` format, with the start
+// position relative to the source file. Start and length are converted into bytes, same as solc uses.
+func (b *ASTBuilder) ToSolcSrc(src SrcNode) string {
+	if location, err := b.LocateSrc(src); err == nil {
+		return location.ToSolcSrc()
+	}
+	return fmt.Sprintf("%d:%d:%d", src.GetStart(), src.GetLength(), src.GetFileIndex())
+}
@@ -0,0 +1,143 @@
+package ast
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+)
+
+func TestLocateNode(t *testing.T) {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name: "Math",
+				Path: "src/libraries/Math.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library Math {
+    function max(uint256 a, uint256 b) internal pure returns (uint256) {
+        return a >= b ? a : b;
+    }
+}`,
+			},
+			{
+				Name: "Token",
+				Path: "src/Token.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+// © Tökën
+import "./libraries/Math.sol";
+
+contract Token {
+    uint256 public total;
+
+    function bump(uint256 amount) public {
+        total = Math.max(total, amount);
+    }
+}`,
+			},
+		},
+		EntrySourceUnitName: "Token",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	parser, err := solgo.NewParserFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(parser.GetParser(), parser.GetSources())
+	require.NoError(t, parser.RegisterListener(solgo.ListenerAst, astBuilder))
+	assert.Empty(t, parser.Parse())
+	assert.Empty(t, astBuilder.ResolveReferences())
+
+	files := astBuilder.GetRoot().GetSourceFiles()
+	require.Len(t, files, 2)
+	assert.Equal(t, "src/libraries/Math.sol", files[0].GetPath())
+	assert.Equal(t, "src/Token.sol", files[1].GetPath())
+
+	functions := map[string]*Function{}
+	var collect func(nodes []Node[NodeType])
+	collect = func(nodes []Node[NodeType]) {
+		for _, node := range nodes {
+			if fn, ok := node.(*Function); ok {
+				functions[fn.GetName()] = fn
+			}
+			collect(node.GetNodes())
+		}
+	}
+	collect(astBuilder.GetRoot().GetNodes())
+
+	require.Contains(t, functions, "max")
+	require.Contains(t, functions, "bump")
+	assert.Equal(t, int64(0), functions["max"].GetSrc().GetFileIndex())
+	assert.Equal(t, int64(1), functions["bump"].GetSrc().GetFileIndex())
+
+	location, err := astBuilder.LocateNode(functions["bump"].GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "src/Token.sol", location.Path)
+	assert.Equal(t, int64(9), location.Line)
+	assert.Equal(t, int64(4), location.Column)
+	assert.Equal(t, "    function bump(uint256 amount) public {", location.Snippet)
+	assert.Equal(t, "src/Token.sol:9:5", location.String())
+	assert.Equal(t, astBuilder.ToSolcSrc(functions["bump"].GetSrc()), location.ToSolcSrc())
+
+	// Offsets are in bytes, same as solc, even though the file contains multibyte characters before the function.
+	content := sources.SourceUnits[1].GetContent()
+	assert.Equal(t, int64(strings.Index(content, "function bump")), location.Start)
+	function := content[strings.Index(content, "function bump"):strings.LastIndex(content, "\n}")]
+	assert.Equal(t, int64(len(function)), location.Length)
+
+	_, err = astBuilder.LocateNode(-1)
+	assert.Error(t, err)
+}
+
+func TestSourceFilePaths(t *testing.T) {
+	localSourcesPath := t.TempDir()
+	outsidePath := t.TempDir()
+
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name:    "Math",
+				Path:    filepath.Join(localSourcesPath, "libraries", "Math.sol"),
+				Content: "pragma solidity ^0.8.0;\n\nlibrary Math {}",
+			},
+			{
+				Name:    "Token",
+				Path:    filepath.Join(outsidePath, "Token.sol"),
+				Content: "pragma solidity ^0.8.0;\n\ncontract Token {}",
+			},
+			{
+				Name:    "Vault",
+				Content: "pragma solidity ^0.8.0;\n\ncontract Vault {}",
+			},
+		},
+		EntrySourceUnitName: "Token",
+		LocalSourcesPath:    localSourcesPath,
+	}
+
+	parser, err := solgo.NewParserFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(parser.GetParser(), parser.GetSources())
+	require.NoError(t, parser.RegisterListener(solgo.ListenerAst, astBuilder))
+	assert.Empty(t, parser.Parse())
+
+	paths := make(map[string]string)
+	for _, file := range astBuilder.GetSourceFiles() {
+		paths[file.GetName()] = file.GetPath()
+	}
+
+	assert.Equal(t, map[string]string{"Math": "libraries/Math.sol", "Token": "Token.sol", "Vault": "Vault.sol"}, paths)
+	assert.Equal(t, filepath.Join(outsidePath, "Token.sol"), astBuilder.GetSourceFiles()[1].GetDiskPath())
+
+	data, err := astBuilder.ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), localSourcesPath)
+	assert.NotContains(t, string(data), outsidePath)
+}
@@ -289,6 +289,7 @@ func (m *MemberAccessExpression) Parse(
 
 			return 0 // Should fix this in the future...
 		}(),
+		FileIndex: m.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	m.NodeType = ast_pb.NodeType_MEMBER_ACCESS
 	m.MemberName = ctx.Identifier().GetText()
@@ -299,6 +300,7 @@ func (m *MemberAccessExpression) Parse(
 		End:         int64(ctx.Identifier().GetStop().GetStop()),
 		Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 		ParentIndex: m.Id,
+		FileIndex:   m.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 	}
 
 	// Parsing the expression in the member access.
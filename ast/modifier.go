@@ -11,15 +11,16 @@ import (
 type ModifierDefinition struct {
 	*ASTBuilder
 
-	Id           int64             `json:"id"`           // Unique identifier of the modifier definition node.
-	Name         string            `json:"name"`         // Name of the modifier.
-	NodeType     ast_pb.NodeType   `json:"nodeType"`     // Type of the node.
-	Src          SrcNode           `json:"src"`          // Source location information.
-	NameLocation SrcNode           `json:"nameLocation"` // Source location information of the name.
-	Visibility   ast_pb.Visibility `json:"visibility"`   // Visibility of the modifier.
-	Virtual      bool              `json:"virtual"`      // Indicates if the modifier is virtual.
-	Parameters   *ParameterList    `json:"parameters"`   // List of parameters for the modifier.
-	Body         *BodyNode         `json:"body"`         // Body node of the modifier.
+	Id            int64             `json:"id"`                      // Unique identifier of the modifier definition node.
+	Name          string            `json:"name"`                    // Name of the modifier.
+	NodeType      ast_pb.NodeType   `json:"nodeType"`                // Type of the node.
+	Src           SrcNode           `json:"src"`                     // Source location information.
+	NameLocation  SrcNode           `json:"nameLocation"`            // Source location information of the name.
+	Visibility    ast_pb.Visibility `json:"visibility"`              // Visibility of the modifier.
+	Virtual       bool              `json:"virtual"`                 // Indicates if the modifier is virtual.
+	Parameters    *ParameterList    `json:"parameters"`              // List of parameters for the modifier.
+	Body          *BodyNode         `json:"body"`                    // Body node of the modifier.
+	Documentation *NatSpec          `json:"documentation,omitempty"` // NatSpec documentation of the modifier.
 }
 
 // NewModifierDefinition creates a new instance of ModifierDefinition with the provided ASTBuilder.
@@ -52,6 +53,11 @@ func (m *ModifierDefinition) GetSrc() SrcNode {
 	return m.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the modifier definition node or nil if it is not documented.
+func (m *ModifierDefinition) GetDocumentation() *NatSpec {
+	return m.Documentation
+}
+
 // GetNameLocation returns the source location information of the name of the modifier definition.
 func (m *ModifierDefinition) GetNameLocation() SrcNode {
 	return m.NameLocation
@@ -125,6 +131,12 @@ func (m *ModifierDefinition) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &m.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if nameLocation, ok := tempMap["nameLocation"]; ok {
 		if err := json.Unmarshal(nameLocation, &m.NameLocation); err != nil {
 			return err
@@ -189,6 +201,7 @@ func (m *ModifierDefinition) ParseDefinition(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   m.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	m.Name = ctx.Identifier().GetText()
 	m.NameLocation = SrcNode{
@@ -198,6 +211,7 @@ func (m *ModifierDefinition) ParseDefinition(
 		End:         int64(ctx.Identifier().GetStop().GetStop()),
 		Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 		ParentIndex: m.GetId(),
+		FileIndex:   m.GetSourceFileIndex(int64(ctx.Identifier().GetStart().GetStart())),
 	}
 
 	if ctx.AllVirtual() != nil {
@@ -221,6 +221,7 @@ func (m *ModifierInvocation) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fnNode.GetId(),
+		FileIndex:   m.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	m.NodeType = ast_pb.NodeType_MODIFIER_INVOCATION
 	m.Arguments = make([]Node[NodeType], 0)
@@ -237,6 +238,7 @@ func (m *ModifierInvocation) Parse(
 				End:         int64(iCtx.GetStop().GetStop()),
 				Length:      int64(iCtx.GetStop().GetStop() - iCtx.GetStart().GetStart() + 1),
 				ParentIndex: m.GetId(),
+				FileIndex:   m.GetSourceFileIndex(int64(iCtx.GetStart().GetStart())),
 			},
 		}
 		m.Name = m.ModifierName.Name
@@ -0,0 +1,677 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// natSpecMaxDepth limits how deep documentation is looked up through base contracts.
+const natSpecMaxDepth = 32
+
+// NatSpec represents the Ethereum Natural Language Specification Format documentation of a contract,
+// function, modifier, event, error or state variable.
+type NatSpec struct {
+	Src        SrcNode           `json:"src"`                  // Source location of the documentation comments.
+	Text       string            `json:"text"`                 // Text of the documentation without comment delimiters.
+	Title      string            `json:"title,omitempty"`      // Title describing the contract.
+	Author     string            `json:"author,omitempty"`     // Name of the author.
+	Notice     string            `json:"notice,omitempty"`     // Explanation to an end user.
+	Dev        string            `json:"dev,omitempty"`        // Extra details for a developer.
+	Params     map[string]string `json:"params,omitempty"`     // Parameter descriptions keyed by parameter name.
+	Returns    []string          `json:"returns,omitempty"`    // Return value descriptions in order of declaration.
+	InheritDoc string            `json:"inheritdoc,omitempty"` // Name of the base contract documentation is inherited from.
+	Custom     map[string]string `json:"custom,omitempty"`     // Custom tags keyed by the name following `@custom:`.
+}
+
+// GetSrc returns the source location of the documentation comments.
+func (n *NatSpec) GetSrc() SrcNode {
+	return n.Src
+}
+
+// GetText returns the text of the documentation without comment delimiters.
+func (n *NatSpec) GetText() string {
+	return n.Text
+}
+
+// GetTitle returns the @title tag of the documentation.
+func (n *NatSpec) GetTitle() string {
+	return n.Title
+}
+
+// GetAuthor returns the @author tag of the documentation.
+func (n *NatSpec) GetAuthor() string {
+	return n.Author
+}
+
+// GetNotice returns the @notice tag of the documentation. Text that is not preceded by any tag is
+// treated as a notice.
+func (n *NatSpec) GetNotice() string {
+	return n.Notice
+}
+
+// GetDev returns the @dev tag of the documentation.
+func (n *NatSpec) GetDev() string {
+	return n.Dev
+}
+
+// GetParams returns the @param descriptions keyed by the parameter name.
+func (n *NatSpec) GetParams() map[string]string {
+	return n.Params
+}
+
+// GetReturns returns the @return descriptions in order of declaration.
+func (n *NatSpec) GetReturns() []string {
+	return n.Returns
+}
+
+// GetInheritDoc returns the name of the base contract the documentation is inherited from.
+func (n *NatSpec) GetInheritDoc() string {
+	return n.InheritDoc
+}
+
+// GetCustom returns the @custom tags keyed by the name following `@custom:`.
+func (n *NatSpec) GetCustom() map[string]string {
+	return n.Custom
+}
+
+// ParseNatSpec parses the NatSpec documentation out of the provided text. Text may contain comment
+// delimiters (`///`, `/**` and `*/`) as found in the source code. Multi-line values are joined with
+// a single space and unknown tags are ignored.
+func ParseNatSpec(text string) *NatSpec {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "///"):
+			line = strings.TrimPrefix(line, "///")
+		case strings.HasPrefix(line, "/**"):
+			line = strings.TrimPrefix(line, "/**")
+		}
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "*/"))
+		if strings.HasPrefix(line, "*") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		}
+		lines = append(lines, line)
+	}
+
+	toReturn := &NatSpec{
+		Text:   strings.TrimSpace(strings.Join(lines, "\n")),
+		Params: make(map[string]string),
+		Custom: make(map[string]string),
+	}
+
+	// Continuation lines are appended to the value of the last seen tag.
+	tag, key := "", ""
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "@") {
+			if tag == "" {
+				tag = "notice"
+			}
+			toReturn.append(tag, key, line)
+			continue
+		}
+
+		var content string
+		tag, content = splitNatSpecWord(line[1:])
+		key = ""
+
+		switch {
+		case tag == "title", tag == "author", tag == "notice", tag == "dev":
+		case tag == "param":
+			key, content = splitNatSpecWord(content)
+			if key == "" {
+				tag = "-"
+				continue
+			}
+		case tag == "return":
+			toReturn.Returns = append(toReturn.Returns, "")
+		case tag == "inheritdoc":
+			toReturn.InheritDoc, _ = splitNatSpecWord(content)
+			tag = "-"
+			continue
+		case strings.HasPrefix(tag, "custom:") && len(tag) > len("custom:"):
+			tag, key = "custom", strings.TrimPrefix(tag, "custom:")
+		default:
+			// Unknown tags and their continuation lines are ignored.
+			tag = "-"
+			continue
+		}
+
+		toReturn.append(tag, key, content)
+	}
+
+	return toReturn
+}
+
+// append appends the content to the value of the tag. Key is the parameter name for @param tags and
+// the custom tag name for @custom tags.
+func (n *NatSpec) append(tag string, key string, content string) {
+	switch tag {
+	case "title":
+		n.Title = joinNatSpec(n.Title, content)
+	case "author":
+		n.Author = joinNatSpec(n.Author, content)
+	case "notice":
+		n.Notice = joinNatSpec(n.Notice, content)
+	case "dev":
+		n.Dev = joinNatSpec(n.Dev, content)
+	case "param":
+		n.Params[key] = joinNatSpec(n.Params[key], content)
+	case "return":
+		n.Returns[len(n.Returns)-1] = joinNatSpec(n.Returns[len(n.Returns)-1], content)
+	case "custom":
+		n.Custom[key] = joinNatSpec(n.Custom[key], content)
+	}
+}
+
+// inherit copies the tags missing in the documentation from the documentation of the base member.
+func (n *NatSpec) inherit(base *NatSpec) {
+	if n.Notice == "" {
+		n.Notice = base.Notice
+	}
+	if n.Dev == "" {
+		n.Dev = base.Dev
+	}
+	if len(n.Returns) == 0 && len(base.Returns) > 0 {
+		n.Returns = append([]string{}, base.Returns...)
+	}
+
+	if n.Params == nil {
+		n.Params = make(map[string]string)
+	}
+	for name, description := range base.Params {
+		if _, ok := n.Params[name]; !ok {
+			n.Params[name] = description
+		}
+	}
+
+	if n.Custom == nil {
+		n.Custom = make(map[string]string)
+	}
+	for name, value := range base.Custom {
+		if _, ok := n.Custom[name]; !ok {
+			n.Custom[name] = value
+		}
+	}
+}
+
+// clone returns a copy of the documentation.
+func (n *NatSpec) clone() *NatSpec {
+	toReturn := *n
+	toReturn.Params, toReturn.Custom, toReturn.Returns = nil, nil, nil
+	toReturn.inherit(n)
+	return &toReturn
+}
+
+// joinNatSpec joins the continuation of a tag value to the value.
+func joinNatSpec(value string, content string) string {
+	content = strings.TrimSpace(content)
+	switch {
+	case content == "":
+		return value
+	case value == "":
+		return content
+	default:
+		return value + " " + content
+	}
+}
+
+// splitNatSpecWord splits the first word off the text.
+func splitNatSpecWord(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	index := strings.IndexFunc(text, unicode.IsSpace)
+	if index < 0 {
+		return text, ""
+	}
+	return text[:index], strings.TrimSpace(text[index:])
+}
+
+// isNatSpecComment returns true if the comment is a documentation comment, that is a `///` line
+// comment or a `/** */` block comment.
+func isNatSpecComment(comment *Comment) bool {
+	text := comment.GetText()
+	switch {
+	case comment.GetType() == ast_pb.NodeType_LICENSE:
+		return false
+	case strings.HasPrefix(text, "///"):
+		return !strings.HasPrefix(text, "////")
+	case strings.HasPrefix(text, "/**"):
+		return !strings.HasPrefix(text, "/***") && text != "/**/"
+	}
+	return false
+}
+
+// documentationOf returns the documentation of the node or nil if the node cannot be documented.
+func documentationOf(node Node[NodeType]) *NatSpec {
+	if documented, ok := node.(interface{ GetDocumentation() *NatSpec }); ok {
+		return documented.GetDocumentation()
+	}
+	return nil
+}
+
+// setDocumentation sets the documentation of the node if the node can be documented.
+func setDocumentation(node Node[NodeType], documentation *NatSpec) {
+	switch node := node.(type) {
+	case *Contract:
+		node.Documentation = documentation
+	case *Interface:
+		node.Documentation = documentation
+	case *Library:
+		node.Documentation = documentation
+	case *Function:
+		node.Documentation = documentation
+	case *Constructor:
+		node.Documentation = documentation
+	case *Fallback:
+		node.Documentation = documentation
+	case *Receive:
+		node.Documentation = documentation
+	case *ModifierDefinition:
+		node.Documentation = documentation
+	case *EventDefinition:
+		node.Documentation = documentation
+	case *ErrorDefinition:
+		node.Documentation = documentation
+	case *StateVariableDeclaration:
+		node.Documentation = documentation
+	}
+}
+
+// attachNatSpec attaches the documentation comments collected from the source code to the nodes
+// they precede and resolves documentation inherited from base contracts. Nodes that are already
+// documented, such as the ones imported from JSON, are left as they are.
+func (b *ASTBuilder) attachNatSpec() {
+	if b.tree == nil || b.GetRoot() == nil {
+		return
+	}
+	root := b.GetRoot()
+
+	source := combinedSource(b)
+	comments := make([]*Comment, 0, len(root.GetComments()))
+	for _, comment := range root.GetComments() {
+		if comment != nil {
+			comments = append(comments, comment)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetSrc().GetStart() < comments[j].GetSrc().GetStart()
+	})
+
+	index := newNatSpecIndex(root)
+	if source != nil && len(comments) > 0 {
+		for _, contract := range index.contracts {
+			nodes := append([]Node[NodeType]{contract}, contract.GetNodes()...)
+			for _, node := range nodes {
+				if documentationOf(node) != nil {
+					continue
+				}
+				if documentation := natSpecPreceding(source, comments, node); documentation != nil {
+					setDocumentation(node, documentation)
+				}
+			}
+		}
+	}
+
+	index.resolve()
+}
+
+// natSpecPreceding returns the documentation built from the documentation comments directly
+// preceding the node, separated from it by whitespace only. Consecutive `///` comments are merged
+// while only the last `/** */` comment is taken.
+func natSpecPreceding(source []rune, comments []*Comment, node Node[NodeType]) *NatSpec {
+	start := node.GetSrc().GetStart()
+	index := sort.Search(len(comments), func(i int) bool {
+		return comments[i].GetSrc().GetStart() >= start
+	}) - 1
+
+	parts := make([]*Comment, 0)
+	for end := start; index >= 0; index-- {
+		comment := comments[index]
+		if !isNatSpecComment(comment) || !isWhitespace(source, comment.GetSrc().GetEnd()+1, end) {
+			break
+		}
+
+		if strings.HasPrefix(comment.GetText(), "/**") {
+			if len(parts) == 0 {
+				parts = append(parts, comment)
+			}
+			break
+		}
+
+		parts = append([]*Comment{comment}, parts...)
+		end = comment.GetSrc().GetStart()
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		texts = append(texts, part.GetText())
+	}
+
+	first, last := parts[0].GetSrc(), parts[len(parts)-1].GetSrc()
+	toReturn := ParseNatSpec(strings.Join(texts, "\n"))
+	toReturn.Src = SrcNode{
+		Line:        first.GetLine(),
+		Column:      first.GetColumn(),
+		Start:       first.GetStart(),
+		End:         last.GetEnd(),
+		Length:      last.GetEnd() - first.GetStart() + 1,
+		ParentIndex: node.GetId(),
+		FileIndex:   first.GetFileIndex(),
+	}
+
+	return toReturn
+}
+
+// isWhitespace returns true if the source between start (inclusive) and end (exclusive) consists
+// of whitespace only.
+func isWhitespace(source []rune, start int64, end int64) bool {
+	if start < 0 || start > end || end > int64(len(source)) {
+		return false
+	}
+
+	for _, r := range source[start:end] {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// natSpecIndex indexes the declarations of the tree needed to resolve inherited documentation
+// and to compute the canonical signatures of documented members.
+type natSpecIndex struct {
+	contracts    []Node[NodeType]          // Contracts, interfaces and libraries of the tree.
+	declarations map[int64]Node[NodeType]  // Contracts and user defined types keyed by their ID.
+	names        map[string]Node[NodeType] // Contracts keyed by their name.
+	resolved     map[int64]bool            // Members whose inherited documentation is already resolved.
+}
+
+// newNatSpecIndex indexes contracts, structs, enums and user defined value types of the tree.
+func newNatSpecIndex(root *RootNode) *natSpecIndex {
+	toReturn := &natSpecIndex{
+		contracts:    make([]Node[NodeType], 0),
+		declarations: make(map[int64]Node[NodeType]),
+		names:        make(map[string]Node[NodeType]),
+		resolved:     make(map[int64]bool),
+	}
+
+	walkSlots(root, func(s slot, v reflect.Value) {
+		node, ok := v.Interface().(Node[NodeType])
+		if !ok {
+			return
+		}
+
+		switch node.(type) {
+		case *Contract, *Interface, *Library:
+			if _, seen := toReturn.declarations[node.GetId()]; seen {
+				return
+			}
+			toReturn.contracts = append(toReturn.contracts, node)
+			if _, named := toReturn.names[contractName(node)]; !named {
+				toReturn.names[contractName(node)] = node
+			}
+		case *StructDefinition, *EnumDefinition, *UserDefinedValueTypeDefinition:
+		default:
+			return
+		}
+
+		toReturn.declarations[node.GetId()] = node
+	})
+
+	return toReturn
+}
+
+// bases returns the base contracts of the contract found in the tree, nearest first.
+func (x *natSpecIndex) bases(contract Node[NodeType]) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0)
+	seen := map[int64]bool{contract.GetId(): true}
+
+	queue := []Node[NodeType]{contract}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var baseContracts []*BaseContract
+		switch current := current.(type) {
+		case *Contract:
+			baseContracts = current.BaseContracts
+		case *Interface:
+			baseContracts = current.BaseContracts
+		case *Library:
+			baseContracts = current.BaseContracts
+		}
+
+		// Bases listed last are the most derived ones.
+		for i := len(baseContracts) - 1; i >= 0; i-- {
+			name := baseContracts[i].GetBaseName()
+			if name == nil {
+				continue
+			}
+
+			base, ok := x.names[name.Name]
+			if !ok {
+				base, ok = x.declarations[name.ReferencedDeclaration]
+			}
+			if !ok || seen[base.GetId()] || contractName(base) == "" {
+				continue
+			}
+
+			seen[base.GetId()] = true
+			toReturn = append(toReturn, base)
+			queue = append(queue, base)
+		}
+	}
+
+	return toReturn
+}
+
+// resolve resolves the documentation the members of all contracts inherit through `@inheritdoc` tags,
+// or implicitly when an undocumented function matches a documented function of a base contract.
+func (x *natSpecIndex) resolve() {
+	for _, contract := range x.contracts {
+		for _, member := range contract.GetNodes() {
+			x.resolveMember(contract, member, 0)
+		}
+	}
+}
+
+// resolveMember resolves the inherited documentation of the contract member and returns it.
+func (x *natSpecIndex) resolveMember(contract Node[NodeType], member Node[NodeType], depth int) *NatSpec {
+	documentation := documentationOf(member)
+	if depth > natSpecMaxDepth || x.resolved[member.GetId()] {
+		return documentation
+	}
+	x.resolved[member.GetId()] = true
+
+	key := x.memberKey(member)
+	if key == "" {
+		return documentation
+	}
+
+	switch {
+	case documentation != nil && documentation.GetInheritDoc() != "":
+		base, ok := x.names[documentation.GetInheritDoc()]
+		if !ok {
+			return documentation
+		}
+		candidates := append([]Node[NodeType]{base}, x.bases(base)...)
+		if inherited, _ := x.lookup(candidates, key, depth); inherited != nil {
+			documentation.inherit(inherited)
+		}
+	case documentation == nil:
+		if _, ok := member.(*Function); !ok {
+			return documentation
+		}
+		if inherited, base := x.lookup(x.bases(contract), key, depth); inherited != nil {
+			documentation = inherited.clone()
+			documentation.InheritDoc = contractName(base)
+			setDocumentation(member, documentation)
+		}
+	}
+
+	return documentation
+}
+
+// lookup returns the documentation of the first member matching the key found in the contracts,
+// together with the contract it was found in.
+func (x *natSpecIndex) lookup(contracts []Node[NodeType], key string, depth int) (*NatSpec, Node[NodeType]) {
+	for _, contract := range contracts {
+		for _, member := range contract.GetNodes() {
+			if x.memberKey(member) != key {
+				continue
+			}
+			if documentation := x.resolveMember(contract, member, depth+1); documentation != nil {
+				return documentation, contract
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// memberKey returns the key members overriding each other share. Functions and public state
+// variables are keyed by their external signature so that getters match interface functions.
+func (x *natSpecIndex) memberKey(member Node[NodeType]) string {
+	switch member := member.(type) {
+	case *Function:
+		return x.signature(member.GetName(), member.GetParameters())
+	case *StateVariableDeclaration:
+		if member.GetVisibility() == ast_pb.Visibility_PUBLIC {
+			return x.getterSignature(member)
+		}
+	case *ModifierDefinition:
+		return "modifier " + x.signature(member.GetName(), member.GetParameters())
+	case *EventDefinition:
+		return "event " + x.signature(member.GetName(), member.GetParameters())
+	case *ErrorDefinition:
+		return "error " + x.signature(member.GetName(), member.GetParameters())
+	case *Fallback:
+		return "fallback"
+	case *Receive:
+		return "receive"
+	}
+
+	return ""
+}
+
+// signature returns the canonical signature of the function, event or error, as used by the ABI.
+func (x *natSpecIndex) signature(name string, parameters *ParameterList) string {
+	types := make([]string, 0)
+	if parameters != nil {
+		for _, parameter := range parameters.GetParameters() {
+			types = append(types, x.canonicalType(parameter.GetTypeName()))
+		}
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(types, ","))
+}
+
+// getterSignature returns the canonical signature of the getter generated for the public state
+// variable, taking mapping keys and array indexes as parameters.
+func (x *natSpecIndex) getterSignature(variable *StateVariableDeclaration) string {
+	types := make([]string, 0)
+	for typeName := variable.GetTypeName(); typeName != nil; {
+		if typeName.GetKeyType() != nil {
+			types = append(types, x.canonicalType(typeName.GetKeyType()))
+			typeName = typeName.GetValueType()
+			continue
+		}
+
+		for i := strings.Count(natSpecTypeName(typeName), "["); i > 0; i-- {
+			types = append(types, "uint256")
+		}
+		break
+	}
+
+	return fmt.Sprintf("%s(%s)", variable.GetName(), strings.Join(types, ","))
+}
+
+// canonicalType returns the canonical ABI type of the type name. Contracts are represented as
+// addresses, enums as uint8, structs as tuples and user defined value types as their underlying type.
+func (x *natSpecIndex) canonicalType(typeName *TypeName) string {
+	if typeName == nil {
+		return ""
+	}
+
+	name := natSpecTypeName(typeName)
+	suffix := ""
+	if index := strings.Index(name, "["); index >= 0 {
+		name, suffix = name[:index], name[index:]
+	}
+
+	if typeName.GetType() == ast_pb.NodeType_FUNCTION_TYPE_NAME || strings.HasPrefix(name, "function") {
+		return "function" + suffix
+	}
+
+	referencedDeclaration := typeName.GetReferencedDeclaration()
+	if referencedDeclaration == 0 && typeName.GetPathNode() != nil {
+		referencedDeclaration = typeName.GetPathNode().ReferencedDeclaration
+	}
+
+	switch declaration := x.declarations[referencedDeclaration].(type) {
+	case *Contract, *Interface, *Library:
+		return "address" + suffix
+	case *EnumDefinition:
+		return "uint8" + suffix
+	case *UserDefinedValueTypeDefinition:
+		return x.canonicalType(declaration.TypeName) + suffix
+	case *StructDefinition:
+		types := make([]string, 0, len(declaration.Members))
+		for _, member := range declaration.Members {
+			if typed, ok := member.(interface{ GetTypeName() *TypeName }); ok {
+				types = append(types, x.canonicalType(typed.GetTypeName()))
+			}
+		}
+		return "(" + strings.Join(types, ",") + ")" + suffix
+	}
+
+	// Declarations outside of the tree are recognized by their type identifier.
+	if typeName.GetType() == ast_pb.NodeType_USER_DEFINED_PATH_NAME && typeName.GetTypeDescription() != nil {
+		switch identifier := typeName.GetTypeDescription().GetIdentifier(); {
+		case strings.HasPrefix(identifier, "t_contract"):
+			return "address" + suffix
+		case strings.HasPrefix(identifier, "t_enum"):
+			return "uint8" + suffix
+		}
+	}
+
+	switch name {
+	case "uint":
+		name = "uint256"
+	case "int":
+		name = "int256"
+	case "fixed":
+		name = "fixed128x18"
+	case "ufixed":
+		name = "ufixed128x18"
+	case "addresspayable", "address payable", "payable":
+		name = "address"
+	case "byte":
+		name = "bytes1"
+	}
+
+	return name + suffix
+}
+
+// natSpecTypeName returns the name of the type, falling back to the path of user defined types.
+func natSpecTypeName(typeName *TypeName) string {
+	name := typeName.GetName()
+	if name == "" && typeName.GetPathNode() != nil {
+		name = typeName.GetPathNode().Name
+	}
+	return strings.ReplaceAll(name, " ", "")
+}
@@ -0,0 +1,308 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// UserDoc represents the user documentation of a contract in the format produced by the solc compiler.
+type UserDoc struct {
+	Errors  map[string][]*UserDocEntry `json:"errors,omitempty"`
+	Events  map[string]*UserDocEntry   `json:"events,omitempty"`
+	Kind    string                     `json:"kind"`
+	Methods map[string]*UserDocEntry   `json:"methods"`
+	Notice  string                     `json:"notice,omitempty"`
+	Version int                        `json:"version"`
+}
+
+// UserDocEntry represents the user documentation of a single function, event or error.
+type UserDocEntry struct {
+	Notice string `json:"notice"`
+}
+
+// ToJSON returns the user documentation encoded as JSON.
+func (d *UserDoc) ToJSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// DevDoc represents the developer documentation of a contract in the format produced by the solc compiler.
+// Custom tags are encoded as `custom:<name>` keys next to the rest of the fields.
+type DevDoc struct {
+	Author         string                    `json:"author,omitempty"`
+	Custom         map[string]string         `json:"-"`
+	Details        string                    `json:"details,omitempty"`
+	Errors         map[string][]*DevDocEntry `json:"errors,omitempty"`
+	Events         map[string]*DevDocEntry   `json:"events,omitempty"`
+	Kind           string                    `json:"kind"`
+	Methods        map[string]*DevDocEntry   `json:"methods"`
+	StateVariables map[string]*DevDocEntry   `json:"stateVariables,omitempty"`
+	Title          string                    `json:"title,omitempty"`
+	Version        int                       `json:"version"`
+}
+
+// DevDocEntry represents the developer documentation of a single function, event, error or state variable.
+type DevDocEntry struct {
+	Custom  map[string]string `json:"-"`
+	Details string            `json:"details,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+	Return  string            `json:"return,omitempty"`
+	Returns map[string]string `json:"returns,omitempty"`
+}
+
+// ToJSON returns the developer documentation encoded as JSON.
+func (d *DevDoc) ToJSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// MarshalJSON encodes the developer documentation together with its custom tags.
+func (d *DevDoc) MarshalJSON() ([]byte, error) {
+	type devDoc DevDoc
+	return marshalWithCustomTags((*devDoc)(d), d.Custom)
+}
+
+// MarshalJSON encodes the developer documentation entry together with its custom tags.
+func (e *DevDocEntry) MarshalJSON() ([]byte, error) {
+	type devDocEntry DevDocEntry
+	return marshalWithCustomTags((*devDocEntry)(e), e.Custom)
+}
+
+// marshalWithCustomTags encodes the value and adds custom tags as `custom:<name>` keys to it.
+func marshalWithCustomTags(value any, custom map[string]string) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || len(custom) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]any)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, text := range custom {
+		fields["custom:"+name] = text
+	}
+
+	return json.Marshal(fields)
+}
+
+// GetUserDoc returns the user documentation of the contract, interface or library with the given ID,
+// including the documentation of members inherited from base contracts.
+func (b *ASTBuilder) GetUserDoc(contractId int64) (*UserDoc, error) {
+	index, contract, err := b.natSpecContract(contractId)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := &UserDoc{
+		Kind:    "user",
+		Version: 1,
+		Methods: make(map[string]*UserDocEntry),
+	}
+
+	if documentation := documentationOf(contract); documentation != nil {
+		toReturn.Notice = documentation.GetNotice()
+	}
+
+	for _, member := range index.externalMembers(contract) {
+		if member.documentation == nil || member.documentation.GetNotice() == "" {
+			continue
+		}
+
+		entry := &UserDocEntry{Notice: member.documentation.GetNotice()}
+		switch member.node.(type) {
+		case *EventDefinition:
+			if toReturn.Events == nil {
+				toReturn.Events = make(map[string]*UserDocEntry)
+			}
+			toReturn.Events[member.key] = entry
+		case *ErrorDefinition:
+			if toReturn.Errors == nil {
+				toReturn.Errors = make(map[string][]*UserDocEntry)
+			}
+			toReturn.Errors[member.key] = []*UserDocEntry{entry}
+		default:
+			toReturn.Methods[member.key] = entry
+		}
+	}
+
+	return toReturn, nil
+}
+
+// GetDevDoc returns the developer documentation of the contract, interface or library with the given ID,
+// including the documentation of members inherited from base contracts.
+func (b *ASTBuilder) GetDevDoc(contractId int64) (*DevDoc, error) {
+	index, contract, err := b.natSpecContract(contractId)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := &DevDoc{
+		Kind:    "dev",
+		Version: 1,
+		Methods: make(map[string]*DevDocEntry),
+	}
+
+	if documentation := documentationOf(contract); documentation != nil {
+		toReturn.Title = documentation.GetTitle()
+		toReturn.Author = documentation.GetAuthor()
+		toReturn.Details = documentation.GetDev()
+		if len(documentation.GetCustom()) > 0 {
+			toReturn.Custom = documentation.GetCustom()
+		}
+	}
+
+	for _, member := range index.externalMembers(contract) {
+		if member.documentation == nil {
+			continue
+		}
+
+		entry := &DevDocEntry{Details: member.documentation.GetDev()}
+		if len(member.documentation.GetParams()) > 0 {
+			entry.Params = member.documentation.GetParams()
+		}
+		if len(member.documentation.GetCustom()) > 0 {
+			entry.Custom = member.documentation.GetCustom()
+		}
+
+		switch node := member.node.(type) {
+		case *StateVariableDeclaration:
+			entry.Params = nil
+			if returns := member.documentation.GetReturns(); len(returns) == 1 {
+				entry.Return = returns[0]
+			} else {
+				entry.Returns = natSpecReturns(returns, nil)
+			}
+		case *Function:
+			entry.Returns = natSpecReturns(member.documentation.GetReturns(), node.GetReturnParameters())
+		}
+
+		if entry.Details == "" && entry.Params == nil && entry.Return == "" && entry.Returns == nil && entry.Custom == nil {
+			continue
+		}
+
+		switch node := member.node.(type) {
+		case *EventDefinition:
+			if toReturn.Events == nil {
+				toReturn.Events = make(map[string]*DevDocEntry)
+			}
+			toReturn.Events[member.key] = entry
+		case *ErrorDefinition:
+			if toReturn.Errors == nil {
+				toReturn.Errors = make(map[string][]*DevDocEntry)
+			}
+			toReturn.Errors[member.key] = []*DevDocEntry{entry}
+		case *StateVariableDeclaration:
+			if toReturn.StateVariables == nil {
+				toReturn.StateVariables = make(map[string]*DevDocEntry)
+			}
+			toReturn.StateVariables[node.GetName()] = entry
+		default:
+			toReturn.Methods[member.key] = entry
+		}
+	}
+
+	return toReturn, nil
+}
+
+// natSpecContract returns the index of the tree with resolved documentation and the contract with the given ID.
+func (b *ASTBuilder) natSpecContract(contractId int64) (*natSpecIndex, Node[NodeType], error) {
+	if b.tree == nil || b.GetRoot() == nil {
+		return nil, nil, fmt.Errorf("root node is not set")
+	}
+	root := b.GetRoot()
+
+	index := newNatSpecIndex(root)
+	index.resolve()
+
+	for _, contract := range index.contracts {
+		if contract.GetId() == contractId {
+			return index, contract, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("contract with id %d not found", contractId)
+}
+
+// natSpecMember is a documented member of the contract interface.
+type natSpecMember struct {
+	key           string // Canonical signature, `constructor` or the state variable name.
+	node          Node[NodeType]
+	documentation *NatSpec
+}
+
+// externalMembers returns the members making the external interface of the contract: its constructor,
+// public and external functions, public state variables, events and errors, including the ones inherited
+// from base contracts. Members of the most derived contract take precedence.
+func (x *natSpecIndex) externalMembers(contract Node[NodeType]) []natSpecMember {
+	toReturn := make([]natSpecMember, 0)
+	seen := make(map[string]bool)
+
+	contracts := append([]Node[NodeType]{contract}, x.bases(contract)...)
+	for _, current := range contracts {
+		for _, node := range current.GetNodes() {
+			key := ""
+			switch node := node.(type) {
+			case *Constructor:
+				if current == contract {
+					key = "constructor"
+				}
+			case *Function:
+				if node.GetVisibility() == ast_pb.Visibility_PUBLIC || node.GetVisibility() == ast_pb.Visibility_EXTERNAL {
+					key = x.memberKey(node)
+				}
+			case *StateVariableDeclaration, *EventDefinition, *ErrorDefinition:
+				key = x.memberKey(node)
+			}
+
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			// Events and errors are keyed by their signature in the documentation.
+			switch node.(type) {
+			case *EventDefinition:
+				key = key[len("event "):]
+			case *ErrorDefinition:
+				key = key[len("error "):]
+			}
+
+			toReturn = append(toReturn, natSpecMember{
+				key:           key,
+				node:          node,
+				documentation: documentationOf(node),
+			})
+		}
+	}
+
+	return toReturn
+}
+
+// natSpecReturns returns the @return descriptions keyed by the name of the return parameter. Descriptions
+// of named return parameters start with the name, which is removed. Unnamed ones are keyed by `_<index>`.
+func natSpecReturns(returns []string, parameters *ParameterList) map[string]string {
+	if len(returns) == 0 {
+		return nil
+	}
+
+	var named []*Parameter
+	if parameters != nil {
+		named = parameters.GetParameters()
+	}
+
+	toReturn := make(map[string]string)
+	for i, description := range returns {
+		key := fmt.Sprintf("_%d", i)
+		if i < len(named) && named[i].GetName() != "" {
+			key = named[i].GetName()
+			if word, rest := splitNatSpecWord(description); word == key {
+				description = rest
+			}
+		}
+		toReturn[key] = description
+	}
+
+	return toReturn
+}
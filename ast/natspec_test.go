@@ -0,0 +1,274 @@
+package ast
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const natSpecContract = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @title Token interface
+interface IToken {
+    /// @notice Returns the balance of the account.
+    /// @param account Account to query.
+    /// @return balance Balance of the account.
+    function balanceOf(address account) external view returns (uint256 balance);
+
+    /// @notice Returns the amount of tokens in existence.
+    function totalSupply() external view returns (uint256);
+
+    /// @notice Moves tokens to the recipient.
+    /// @dev Emits a {Transfer} event.
+    function transfer(address to, uint value) external returns (bool);
+
+    /// @notice Emitted when tokens are moved.
+    /// @param from Sender of the tokens.
+    event Transfer(address indexed from, address indexed to, uint value);
+}
+
+/**
+ * @title Token
+ * @author Unpack
+ * @notice A simple token.
+ * @dev Implements {IToken}.
+ * @custom:security-contact security@example.com
+ */
+contract Token is IToken {
+    struct Point {
+        uint x;
+        IToken token;
+    }
+
+    /// @inheritdoc IToken
+    uint256 public override totalSupply;
+
+    // Regular comments are not documentation.
+    uint256 public hidden;
+
+    /// @notice Thrown when the balance is too low.
+    /// @param needed Amount needed.
+    error Insufficient(uint needed);
+
+    /// @notice Creates the token.
+    constructor() {}
+
+    /// @notice Restricts the caller.
+    modifier onlyOwner() {
+        _;
+    }
+
+    /// @inheritdoc IToken
+    /// @dev Reads the balance from storage.
+    function balanceOf(address account) external view override returns (uint256 balance) {
+        return 0;
+    }
+
+    function transfer(address to, uint value) external override onlyOwner returns (bool) {
+        return true;
+    }
+
+    /**
+     * @notice Moves the points.
+     * @return Whether the points moved.
+     */
+    function move(Point[] memory points) public returns (bool) {
+        return true;
+    }
+
+    /// @notice Internal functions are not part of the documentation.
+    function _helper() internal {}
+}
+`
+
+func TestParseNatSpec(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected *NatSpec
+	}{
+		{
+			name: "Line Comments",
+			text: "/// @notice Returns the balance.\n/// @param account Account to query.\n/// @return Balance of the account.",
+			expected: &NatSpec{
+				Text:    "@notice Returns the balance.\n@param account Account to query.\n@return Balance of the account.",
+				Notice:  "Returns the balance.",
+				Params:  map[string]string{"account": "Account to query."},
+				Returns: []string{"Balance of the account."},
+				Custom:  map[string]string{},
+			},
+		},
+		{
+			name: "Block Comment With Continuation Lines",
+			text: "/**\n * @title Token\n * @author Unpack\n * @dev Details spanning\n * multiple lines.\n *\n * @custom:security-contact security@example.com\n */",
+			expected: &NatSpec{
+				Text:   "@title Token\n@author Unpack\n@dev Details spanning\nmultiple lines.\n\n@custom:security-contact security@example.com",
+				Title:  "Token",
+				Author: "Unpack",
+				Dev:    "Details spanning multiple lines.",
+				Params: map[string]string{},
+				Custom: map[string]string{"security-contact": "security@example.com"},
+			},
+		},
+		{
+			name: "Untagged Text And Inheritdoc",
+			text: "/// Transfers tokens.\n/// @inheritdoc IERC20\n/// @unknown ignored\n/// still ignored",
+			expected: &NatSpec{
+				Text:       "Transfers tokens.\n@inheritdoc IERC20\n@unknown ignored\nstill ignored",
+				Notice:     "Transfers tokens.",
+				InheritDoc: "IERC20",
+				Params:     map[string]string{},
+				Custom:     map[string]string{},
+			},
+		},
+		{
+			name: "Solc Documentation Text",
+			text: " @dev Moves `amount` tokens.\n Returns a boolean value.",
+			expected: &NatSpec{
+				Text:   "@dev Moves `amount` tokens.\nReturns a boolean value.",
+				Dev:    "Moves `amount` tokens. Returns a boolean value.",
+				Params: map[string]string{},
+				Custom: map[string]string{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, ParseNatSpec(testCase.text))
+		})
+	}
+}
+
+func TestNatSpec(t *testing.T) {
+	astBuilder := buildPrinterAst(t, natSpecContract)
+
+	documentation := make(map[string]*NatSpec)
+	var token, tokenInterface Node[NodeType]
+	for _, unit := range astBuilder.GetRoot().GetSourceUnits() {
+		contract := unit.GetContract()
+		if contractName(contract) == "Token" {
+			token = contract
+		} else {
+			tokenInterface = contract
+		}
+
+		documentation[contractName(contract)] = documentationOf(contract)
+		for _, node := range contract.GetNodes() {
+			if named, ok := node.(interface{ GetName() string }); ok && documentationOf(node) != nil {
+				documentation[contractName(contract)+"."+named.GetName()] = documentationOf(node)
+			}
+		}
+	}
+	require.NotNil(t, token)
+	require.NotNil(t, tokenInterface)
+
+	assert.Equal(t, "Token", documentation["Token"].GetTitle())
+	assert.Equal(t, "Unpack", documentation["Token"].GetAuthor())
+	assert.Equal(t, map[string]string{"security-contact": "security@example.com"}, documentation["Token"].GetCustom())
+	assert.Equal(t, int64(23), documentation["Token"].GetSrc().GetLine())
+	assert.Equal(t, "Token interface", documentation["IToken"].GetTitle())
+	assert.Equal(t, "Restricts the caller.", documentation["Token.onlyOwner"].GetNotice())
+	assert.Nil(t, documentation["Token.hidden"])
+
+	// Explicitly inherited documentation keeps own tags and fills in the missing ones.
+	balanceOf := documentation["Token.balanceOf"]
+	assert.Equal(t, "IToken", balanceOf.GetInheritDoc())
+	assert.Equal(t, "Returns the balance of the account.", balanceOf.GetNotice())
+	assert.Equal(t, "Reads the balance from storage.", balanceOf.GetDev())
+	assert.Equal(t, map[string]string{"account": "Account to query."}, balanceOf.GetParams())
+	assert.Equal(t, "Returns the amount of tokens in existence.", documentation["Token.totalSupply"].GetNotice())
+
+	// Undocumented functions inherit the documentation of the function they override.
+	transfer := documentation["Token.transfer"]
+	require.NotNil(t, transfer)
+	assert.Equal(t, "IToken", transfer.GetInheritDoc())
+	assert.Equal(t, "Emits a {Transfer} event.", transfer.GetDev())
+
+	userDoc, err := astBuilder.GetUserDoc(token.GetId())
+	require.NoError(t, err)
+	userDocJSON, err := userDoc.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"errors": {"Insufficient(uint256)": [{"notice": "Thrown when the balance is too low."}]},
+		"events": {"Transfer(address,address,uint256)": {"notice": "Emitted when tokens are moved."}},
+		"kind": "user",
+		"methods": {
+			"balanceOf(address)": {"notice": "Returns the balance of the account."},
+			"constructor": {"notice": "Creates the token."},
+			"move((uint256,address)[])": {"notice": "Moves the points."},
+			"totalSupply()": {"notice": "Returns the amount of tokens in existence."},
+			"transfer(address,uint256)": {"notice": "Moves tokens to the recipient."}
+		},
+		"notice": "A simple token.",
+		"version": 1
+	}`, string(userDocJSON))
+
+	devDoc, err := astBuilder.GetDevDoc(token.GetId())
+	require.NoError(t, err)
+	devDocJSON, err := devDoc.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"author": "Unpack",
+		"custom:security-contact": "security@example.com",
+		"details": "Implements {IToken}.",
+		"errors": {"Insufficient(uint256)": [{"params": {"needed": "Amount needed."}}]},
+		"events": {"Transfer(address,address,uint256)": {"params": {"from": "Sender of the tokens."}}},
+		"kind": "dev",
+		"methods": {
+			"balanceOf(address)": {
+				"details": "Reads the balance from storage.",
+				"params": {"account": "Account to query."},
+				"returns": {"balance": "Balance of the account."}
+			},
+			"move((uint256,address)[])": {"returns": {"_0": "Whether the points moved."}},
+			"transfer(address,uint256)": {"details": "Emits a {Transfer} event."}
+		},
+		"title": "Token",
+		"version": 1
+	}`, string(devDocJSON))
+
+	_, err = astBuilder.GetUserDoc(-1)
+	assert.Error(t, err)
+
+	// Documentation survives the JSON round trip of the tree.
+	data, err := astBuilder.ToJSON()
+	require.NoError(t, err)
+	imported, err := NewAstBuilder(nil, nil).ImportFromJSON(context.TODO(), data)
+	require.NoError(t, err)
+
+	for _, unit := range imported.GetSourceUnits() {
+		if contract, ok := unit.GetContract().(*Contract); ok {
+			require.NotNil(t, contract.GetDocumentation())
+			assert.Equal(t, "A simple token.", contract.GetDocumentation().GetNotice())
+		}
+	}
+}
+
+func TestNatSpecFromSolcJSON(t *testing.T) {
+	data, err := os.ReadFile("../data/tests/ast/IERC20.solc.ast.json")
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(nil, nil)
+	root, err := astBuilder.ImportFromSolcJSON(context.TODO(), data)
+	require.NoError(t, err)
+
+	contract := root.GetSourceUnits()[0].GetContract()
+	require.NotNil(t, documentationOf(contract))
+	assert.Equal(t, "Interface of the ERC20 standard as defined in the EIP.", documentationOf(contract).GetDev())
+
+	devDoc, err := astBuilder.GetDevDoc(contract.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "Returns the amount of tokens in existence.", devDoc.Methods["totalSupply()"].Details)
+	assert.Equal(t, "Returns the amount of tokens owned by `account`.", devDoc.Methods["balanceOf(address)"].Details)
+	assert.Contains(t, devDoc.Events, "Transfer(address,address,uint256)")
+	assert.NotZero(t, documentationOf(contract).GetSrc().GetLength())
+
+	userDoc, err := astBuilder.GetUserDoc(contract.GetId())
+	require.NoError(t, err)
+	assert.Empty(t, userDoc.Methods)
+}
@@ -124,6 +124,7 @@ func (n *NewExpr) Parse(
 
 			return bodyNode.GetId()
 		}(),
+		FileIndex: n.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Parsing the type name associated with the new expression.
@@ -163,6 +163,7 @@ func (o *OverrideSpecifier) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnN
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fnNode.GetId(),
+		FileIndex:   o.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	o.NodeType = ast_pb.NodeType_OVERRIDE_SPECIFIER
 
@@ -181,6 +182,7 @@ func (o *OverrideSpecifier) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnN
 						End:         int64(override.GetStop().GetStop()),
 						Length:      int64(override.GetStop().GetStop() - override.GetStart().GetStart() + 1),
 						ParentIndex: o.Id,
+						FileIndex:   o.GetSourceFileIndex(int64(override.GetStart().GetStart())),
 					},
 				}
 
@@ -260,6 +260,7 @@ func (p *Parameter) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: plNode.GetId(),
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	p.Scope = fnNode.GetId()
 
@@ -297,6 +298,7 @@ func (p *Parameter) ParseEventParameter(unit *SourceUnit[Node[ast_pb.SourceUnit]
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: plNode.GetId(),
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	p.Scope = fnNode.GetId()
 	p.Indexed = ctx.Indexed() != nil
@@ -343,6 +345,7 @@ func (p *Parameter) ParseStructParameter(unit *SourceUnit[Node[ast_pb.SourceUnit
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: structNode.GetId(),
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if contractNode != nil {
@@ -389,6 +392,7 @@ func (p *Parameter) ParseErrorParameter(unit *SourceUnit[Node[ast_pb.SourceUnit]
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: plNode.GetId(),
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	p.Scope = fnNode.GetId()
 
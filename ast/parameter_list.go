@@ -142,6 +142,7 @@ func (p *ParameterList) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fNode N
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fNode.GetId(),
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// No need to move forwards as there are no parameters to parse in this context.
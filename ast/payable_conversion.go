@@ -242,6 +242,7 @@ func (p *PayableConversion) Parse(
 
 			return bodyNode.GetId()
 		}(),
+		FileIndex: p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	p.Payable = ctx.Payable() != nil
 
@@ -100,6 +100,7 @@ func CreatePragmaFromCtx(b *ASTBuilder, unit *SourceUnit[Node[ast_pb.SourceUnit]
 			End:         int64(pragmaCtx.GetStop().GetStop()),
 			Length:      int64(pragmaCtx.GetStop().GetStop() - pragmaCtx.GetStart().GetStart() + 1),
 			ParentIndex: unit.Id,
+			FileIndex:   b.GetSourceFileIndex(int64(pragmaCtx.GetStart().GetStart())),
 		},
 		NodeType: ast_pb.NodeType_PRAGMA_DIRECTIVE,
 		Literals: getLiterals(pragmaCtx.GetText()),
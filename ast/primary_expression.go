@@ -204,6 +204,7 @@ func (p *PrimaryExpression) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   p.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.Identifier() != nil {
@@ -513,6 +514,19 @@ func (p *PrimaryExpression) Parse(
 		}
 	}
 
+	// Number literals with an ether or time unit keep the unit attached in the text, as in 1e10ether.
+	if denominationCtx := ctx.LiteralWithSubDenomination(); denominationCtx != nil && denominationCtx.NumberLiteral() != nil {
+		p.NodeType = ast_pb.NodeType_LITERAL
+		p.Kind = ast_pb.NodeType_NUMBER
+		p.Pure = true
+		p.Value = denominationCtx.NumberLiteral().GetText()
+		p.HexValue = hex.EncodeToString([]byte(p.Value))
+		p.TypeDescription = &TypeDescription{
+			TypeIdentifier: "t_rational",
+			TypeString:     fmt.Sprintf("int_const %s", denominationCtx.GetText()),
+		}
+	}
+
 	if fnNode != nil && p.TypeDescription == nil {
 		if fn, ok := fnNode.(*Function); ok {
 			if fn.GetParameters() != nil {
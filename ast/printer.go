@@ -0,0 +1,782 @@
+package ast
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// PrinterOptions defines how the Printer lays out regenerated Solidity source code.
+type PrinterOptions struct {
+	// Indent is the indentation unit, four spaces by default.
+	Indent string `json:"indent"`
+	// Canonical drops comments and the original layout, so that structurally equal code
+	// always prints the same. Useful for structural diffs and fingerprints.
+	Canonical bool `json:"canonical"`
+	// Imports controls whether import directives are printed. Flattened output omits them.
+	Imports bool `json:"imports"`
+}
+
+// NewDefaultPrinterOptions returns the default printer options: four space indentation,
+// comments preserved and imports printed.
+func NewDefaultPrinterOptions() *PrinterOptions {
+	return &PrinterOptions{
+		Indent:  "    ",
+		Imports: true,
+	}
+}
+
+// Printer regenerates Solidity source code from the AST.
+//
+// Code is regenerated from the nodes only, with consistent formatting, so changes made to the tree are
+// always reflected in the output. Sources the AST was built from, when available, are only used to
+// keep comments and blank lines. Printing fails for constructs the AST does not retain, instead of
+// producing code that would not compile.
+type Printer struct {
+	builder  *ASTBuilder
+	opts     *PrinterOptions
+	source   []rune // source is the combined source the AST was built from, if available.
+	comments []*Comment
+	next     int    // next is the index of the next comment that was not printed yet.
+	pos      int64  // pos is the position in the combined source up to which code was printed.
+	indent   string // indent is the indentation of the statement or member being printed.
+	err      error
+}
+
+// NewPrinter creates a new Printer for the AST built by the provided builder.
+// Builder can be nil, in which case code is regenerated from the nodes only.
+func NewPrinter(b *ASTBuilder, opts *PrinterOptions) *Printer {
+	if opts == nil {
+		opts = NewDefaultPrinterOptions()
+	}
+
+	if opts.Indent == "" {
+		opts.Indent = "    "
+	}
+
+	p := &Printer{
+		builder: b,
+		opts:    opts,
+		source:  combinedSource(b),
+	}
+
+	if p.source != nil && !opts.Canonical {
+		// Builder releases its comments once references are resolved, root node keeps them.
+		if root := b.GetRoot(); root != nil {
+			p.comments = append(p.comments, root.GetComments()...)
+		} else {
+			p.comments = append(p.comments, b.comments...)
+		}
+		sort.SliceStable(p.comments, func(i, j int) bool {
+			return p.comments[i].GetSrc().GetStart() < p.comments[j].GetSrc().GetStart()
+		})
+	}
+
+	return p
+}
+
+// GetOptions returns the options of the printer.
+func (p *Printer) GetOptions() *PrinterOptions {
+	return p.opts
+}
+
+// PrintRoot regenerates source code of all source units and file level definitions of the root node,
+// in the order they appear in the sources. Pragmas and imports shared by source units of the same
+// file are printed once. When sources are available, printing fails if they hold file level code the AST
+// does not retain, such as free functions.
+func (p *Printer) PrintRoot(root *RootNode) (string, error) {
+	if root == nil {
+		return "", fmt.Errorf("root node is not set")
+	}
+
+	p.reset(0)
+
+	nodes := make([]Node[NodeType], 0)
+	for _, unit := range root.GetSourceUnits() {
+		nodes = append(nodes, unit.GetNodes()...)
+	}
+
+	// Global nodes also hold definitions declared within contracts, only the file level ones are printed.
+	for _, global := range root.GetGlobalNodes() {
+		start, contained := global.GetSrc().GetStart(), false
+		for _, unit := range root.GetSourceUnits() {
+			if contract := unit.GetContract(); contract != nil {
+				src := contract.GetSrc()
+				if start >= src.GetStart() && start < src.GetStart()+src.GetLength() {
+					contained = true
+					break
+				}
+			}
+		}
+
+		if !contained {
+			nodes = append(nodes, global)
+		}
+	}
+
+	code := p.topLevel(nodes, true)
+	if trailing := p.leadingComments(int64(len(p.source)), ""); trailing != "" {
+		code = strings.TrimSuffix(code+"\n\n"+trailing, "\n")
+	}
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	return strings.TrimLeft(code, "\n") + "\n", nil
+}
+
+// PrintSourceUnit regenerates source code of a single source unit.
+func (p *Printer) PrintSourceUnit(unit *SourceUnit[Node[ast_pb.SourceUnit]]) (string, error) {
+	if unit == nil {
+		return "", fmt.Errorf("source unit is not set")
+	}
+
+	p.reset(0)
+	code := p.topLevel(unit.GetNodes(), false)
+	if p.err != nil {
+		return "", p.err
+	}
+
+	return code + "\n", nil
+}
+
+// Print regenerates source code of the provided node. Node can be a source unit, contract,
+// contract member, statement, expression, type name or yul node.
+func (p *Printer) Print(node Node[NodeType]) (string, error) {
+	if node == nil {
+		return "", fmt.Errorf("node is not set")
+	}
+
+	p.reset(node.GetSrc().GetStart())
+
+	var code string
+	switch node := node.(type) {
+	case *SourceUnit[Node[ast_pb.SourceUnit]]:
+		code = p.topLevel(node.GetNodes(), false)
+	case *Contract, *Interface, *Library, *Pragma, *Import,
+		*StateVariableDeclaration, *Function, *Constructor, *Fallback, *Receive,
+		*ModifierDefinition, *EventDefinition, *ErrorDefinition, *StructDefinition,
+		*EnumDefinition, *UsingDirective, *UserDefinedValueTypeDefinition:
+		code = p.member(node, "")
+	case *TypeName:
+		code = p.typeName(node)
+	case *Parameter:
+		code = p.parameter(node, true)
+	default:
+		if isExpressionNode(node) {
+			code = p.expression(node)
+		} else {
+			code = p.statement(node, "")
+		}
+	}
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	return code, nil
+}
+
+// ToSource regenerates Solidity source code of the whole AST built by the builder.
+func (b *ASTBuilder) ToSource(opts *PrinterOptions) (string, error) {
+	if b.GetRoot() == nil {
+		return "", fmt.Errorf("ast is not built")
+	}
+
+	return NewPrinter(b, opts).PrintRoot(b.GetRoot())
+}
+
+// reset prepares the printer for printing code that starts at the provided position.
+func (p *Printer) reset(start int64) {
+	p.err = nil
+	p.pos = start
+	p.next = sort.Search(len(p.comments), func(i int) bool {
+		return p.comments[i].GetSrc().GetStart() >= start
+	})
+}
+
+// fail records the first error encountered while printing.
+func (p *Printer) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// topLevelItem is a source unit level node along with its location.
+type topLevelItem struct {
+	src  SrcNode
+	node Node[NodeType]
+}
+
+// topLevel prints source unit level nodes in the order they appear in the sources. Nodes sharing the
+// same position, such as pragmas repeated for each contract of a file, are printed once. Consecutive
+// pragmas and imports are grouped together, while other nodes are separated by an empty line.
+// When retained is set, printing fails if the sources hold code between the nodes.
+func (p *Printer) topLevel(nodes []Node[NodeType], retained bool) string {
+	items := make([]topLevelItem, 0, len(nodes))
+	seen := make(map[int64]bool)
+
+	for _, node := range sortedNodes(nodes) {
+		if seen[node.GetSrc().GetStart()] {
+			continue
+		}
+		seen[node.GetSrc().GetStart()] = true
+		items = append(items, topLevelItem{src: p.srcOf(node), node: node})
+	}
+
+	if retained {
+		p.checkRetained(items)
+	}
+
+	groups := make([]string, 0, len(items))
+	previous := ast_pb.NodeType_NT_DEFAULT
+
+	for _, item := range items {
+		if _, isImport := item.node.(*Import); isImport && !p.opts.Imports {
+			continue
+		}
+
+		code := p.leadingComments(item.src.GetStart(), "")
+		if constant, ok := item.node.(*StateVariableDeclaration); ok {
+			code += p.stateVariable(constant, true)
+		} else {
+			code += p.member(item.node, "")
+		}
+		code += p.trailingComment(item.src, SrcNode{Length: int64(len(p.source))})
+
+		current := item.node.GetType()
+		if len(groups) > 0 && current == previous &&
+			(current == ast_pb.NodeType_PRAGMA_DIRECTIVE || current == ast_pb.NodeType_IMPORT_DIRECTIVE) {
+			groups[len(groups)-1] += "\n" + code
+		} else {
+			groups = append(groups, code)
+		}
+		previous = current
+	}
+
+	return strings.Join(groups, "\n\n")
+}
+
+// checkRetained records an error if any of the source files holds code, other than comments, between
+// the items. Such code, e.g. a free function, is not retained by the AST and cannot be printed.
+func (p *Printer) checkRetained(items []topLevelItem) {
+	if p.source == nil {
+		return
+	}
+
+	check := func(start, end int64) {
+		if start >= end || start < 0 || end > int64(len(p.source)) {
+			return
+		}
+
+		tokens := scanTokens(p.source[start:end])
+
+		// Version pragmas the builder does not attach to a contract are repeated for every contract of
+		// flattened sources, so they are skipped.
+		for len(tokens) >= 3 && tokens[0].text == "pragma" && tokens[1].text == "solidity" {
+			idx := 2
+			for idx < len(tokens) && tokens[idx].text != ";" {
+				idx++
+			}
+			tokens = tokens[min(idx+1, len(tokens)):]
+		}
+
+		if len(tokens) > 0 {
+			p.fail(p.notRetained(SrcNode{Start: start + int64(tokens[0].start), Length: end - start - int64(tokens[0].start)}))
+		}
+	}
+
+	idx := 0
+	for _, file := range p.builder.GetSourceFiles() {
+		pos := file.Start
+		for ; idx < len(items) && items[idx].src.GetStart() < file.Start+file.Length; idx++ {
+			check(pos, items[idx].src.GetStart())
+			pos = max(pos, items[idx].src.GetStart()+items[idx].src.GetLength())
+		}
+		check(pos, file.Start+file.Length)
+	}
+}
+
+// member prints a contract, a contract member or a source unit level node.
+func (p *Printer) member(node Node[NodeType], indent string) string {
+	defer func(previous string) { p.indent = previous }(p.indent)
+	p.indent = indent
+
+	var code string
+
+	switch node := node.(type) {
+	case *Pragma:
+		code = p.pragma(node)
+	case *Import:
+		code = p.importDirective(node)
+	case *Contract:
+		return p.contract(node, "contract", node.Abstract, node.BaseContracts, node.Nodes, indent)
+	case *Interface:
+		return p.contract(node, "interface", false, node.BaseContracts, node.Nodes, indent)
+	case *Library:
+		return p.contract(node, "library", false, node.BaseContracts, node.Nodes, indent)
+	case *StateVariableDeclaration:
+		code = p.stateVariable(node, false)
+	case *Function:
+		return p.function(node, indent)
+	case *Constructor:
+		return p.callable(node, p.constructorHeader(node), node.Implemented, node.Body, indent)
+	case *Fallback:
+		return p.callable(node, p.fallbackHeader(node), node.Implemented, node.Body, indent)
+	case *Receive:
+		return p.callable(node, p.receiveHeader(node), node.Implemented, node.Body, indent)
+	case *ModifierDefinition:
+		return p.callable(node, p.modifierHeader(node), node.Body != nil, node.Body, indent)
+	case *EventDefinition:
+		code = "event " + node.Name + "(" + p.parameters(node.Parameters, false) + ")"
+		if node.Anonymous {
+			code += " anonymous"
+		}
+		code += ";"
+	case *ErrorDefinition:
+		code = "error " + node.Name + "(" + p.parameters(node.Parameters, false) + ");"
+	case *StructDefinition:
+		code = p.structDefinition(node, indent)
+	case *EnumDefinition:
+		code = p.enumDefinition(node, indent)
+	case *UsingDirective:
+		code = p.usingDirective(node)
+	case *UserDefinedValueTypeDefinition:
+		underlying := node.Type
+		if node.TypeName != nil {
+			underlying = p.typeName(node.TypeName)
+		}
+		code = "type " + node.Name + " is " + underlying + ";"
+	default:
+		p.fail(fmt.Errorf("printer does not support %T as a contract member", node))
+		return ""
+	}
+
+	return code
+}
+
+// pragma prints a pragma directive. Text of the pragma is kept by the AST without whitespace,
+// so the name and well known values are separated again.
+func (p *Printer) pragma(pragma *Pragma) string {
+	text := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(pragma.Text), "pragma"), ";"))
+
+	for _, name := range []string{"solidity", "abicoder", "experimental"} {
+		if strings.HasPrefix(text, name) {
+			value := strings.Join(strings.Fields(strings.TrimPrefix(text, name)), " ")
+			if name == "solidity" {
+				value = pragmaVersionRegex.ReplaceAllString(value, "$1 $2")
+			}
+			return "pragma " + name + " " + value + ";"
+		}
+	}
+
+	return "pragma " + text + ";"
+}
+
+// importDirective prints an import directive.
+func (p *Printer) importDirective(imp *Import) string {
+	if len(imp.SymbolAliases) > 0 {
+		symbols := make([]string, 0, len(imp.SymbolAliases))
+		for _, symbol := range imp.SymbolAliases {
+			if symbol.Alias != "" {
+				symbols = append(symbols, symbol.Name+" as "+symbol.Alias)
+			} else {
+				symbols = append(symbols, symbol.Name)
+			}
+		}
+		return "import {" + strings.Join(symbols, ", ") + "} from \"" + imp.File + "\";"
+	}
+
+	code := "import \"" + imp.File + "\""
+	if imp.UnitAlias != "" {
+		code += " as " + imp.UnitAlias
+	}
+	return code + ";"
+}
+
+// contract prints a contract, interface or library together with all of its members.
+func (p *Printer) contract(node Node[NodeType], kind string, abstract bool, bases []*BaseContract, members []Node[NodeType], indent string) string {
+	header := kind + " " + contractName(node)
+	if abstract {
+		header = "abstract " + header
+	}
+
+	if len(bases) > 0 {
+		names := make([]string, 0, len(bases))
+		for _, base := range bases {
+			if base.BaseName != nil {
+				names = append(names, base.BaseName.Name)
+			}
+		}
+		header += " is " + strings.Join(names, ", ")
+	}
+
+	return header + " " + p.members(node.GetSrc(), members, indent)
+}
+
+// members prints the body of a contract or struct like block holding declarations.
+// Declarations of different kinds, and all multi-line declarations, are separated by an empty line.
+func (p *Printer) members(src SrcNode, members []Node[NodeType], indent string) string {
+	inner := indent + p.opts.Indent
+	lines := make([]string, 0, len(members))
+
+	var previous Node[NodeType]
+	for _, member := range sortedNodes(members) {
+		start := member.GetSrc().GetStart()
+		comments := p.leadingComments(start, inner)
+		code := p.member(member, inner)
+
+		if previous != nil && (previous.GetType() != member.GetType() || !isSimpleMember(member) ||
+			strings.Contains(code, "\n") || strings.Contains(lines[len(lines)-1], "\n") ||
+			p.hasBlankLine(p.srcOf(previous), start)) {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, comments+inner+code+p.trailingComment(p.srcOf(member), src))
+		previous = member
+	}
+
+	if trailing := p.leadingComments(src.GetStart()+src.GetLength(), inner); trailing != "" {
+		lines = append(lines, strings.TrimSuffix(trailing, "\n"))
+	}
+
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// stateVariable prints a state variable declaration. File level constants are declared without visibility.
+func (p *Printer) stateVariable(v *StateVariableDeclaration, fileLevel bool) string {
+	parts := []string{p.typeName(v.TypeName)}
+
+	if v.Visibility != ast_pb.Visibility_INTERNAL && !fileLevel {
+		parts = append(parts, visibilityKeyword(v.Visibility))
+	}
+
+	if v.Constant {
+		parts = append(parts, "constant")
+	} else if v.StateMutability == ast_pb.Mutability_IMMUTABLE {
+		parts = append(parts, "immutable")
+	}
+
+	if overrides := p.overrides(v.Overrides); overrides != "" {
+		parts = append(parts, strings.TrimSpace(overrides))
+	}
+
+	parts = append(parts, v.Name)
+	code := strings.Join(parts, " ")
+
+	if v.InitialValue != nil {
+		code += " = " + p.expression(v.InitialValue)
+	}
+
+	return code + ";"
+}
+
+// function prints a function definition.
+func (p *Printer) function(fn *Function, indent string) string {
+	header := "function " + fn.Name + "(" + p.parameters(fn.Parameters, true) + ")"
+
+	if fn.Visibility != ast_pb.Visibility_V_DEFAULT {
+		header += " " + visibilityKeyword(fn.Visibility)
+	}
+
+	header += p.mutability(fn.StateMutability)
+
+	if fn.Virtual {
+		header += " virtual"
+	}
+
+	header += p.overrides(fn.Overrides) + p.modifierInvocations(fn.Modifiers)
+
+	if fn.ReturnParameters != nil && len(fn.ReturnParameters.Parameters) > 0 {
+		header += " returns (" + p.parameters(fn.ReturnParameters, true) + ")"
+	}
+
+	return p.callable(fn, header, fn.Implemented || (fn.Body != nil && len(fn.Body.Statements) > 0), fn.Body, indent)
+}
+
+// constructorHeader returns the header of the constructor. Internal visibility is assumed by the AST
+// when visibility is not declared, so only public visibility is printed.
+func (p *Printer) constructorHeader(c *Constructor) string {
+	header := "constructor(" + p.parameters(c.Parameters, true) + ")"
+
+	if c.StateMutability == ast_pb.Mutability_PAYABLE {
+		header += " payable"
+	}
+
+	if c.Visibility == ast_pb.Visibility_PUBLIC {
+		header += " public"
+	}
+
+	return header + p.modifierInvocations(c.Modifiers)
+}
+
+// fallbackHeader returns the header of the fallback function.
+func (p *Printer) fallbackHeader(f *Fallback) string {
+	header := "fallback(" + p.parameters(f.Parameters, true) + ") external" + p.mutability(f.StateMutability)
+
+	if f.Virtual {
+		header += " virtual"
+	}
+
+	header += p.overrides(f.Overrides) + p.modifierInvocations(f.Modifiers)
+
+	if f.ReturnParameters != nil && len(f.ReturnParameters.Parameters) > 0 {
+		header += " returns (" + p.parameters(f.ReturnParameters, true) + ")"
+	}
+
+	return header
+}
+
+// receiveHeader returns the header of the receive function.
+func (p *Printer) receiveHeader(r *Receive) string {
+	header := "receive() external payable"
+
+	if r.Virtual {
+		header += " virtual"
+	}
+
+	return header + p.overrides(r.Overrides) + p.modifierInvocations(r.Modifiers)
+}
+
+// modifierHeader returns the header of the modifier definition.
+func (p *Printer) modifierHeader(m *ModifierDefinition) string {
+	header := "modifier " + m.Name
+
+	if m.Parameters != nil && len(m.Parameters.Parameters) > 0 {
+		header += "(" + p.parameters(m.Parameters, true) + ")"
+	}
+
+	if m.Virtual {
+		header += " virtual"
+	}
+
+	return header
+}
+
+// callable prints a function like definition consisting of the header and an optional body.
+func (p *Printer) callable(node Node[NodeType], header string, implemented bool, body *BodyNode, indent string) string {
+	if implemented && body != nil {
+		return header + " " + p.block(body, indent)
+	}
+
+	return header + ";"
+}
+
+// overrides prints the override specifiers.
+func (p *Printer) overrides(overrides []*OverrideSpecifier) string {
+	code := ""
+	for _, override := range overrides {
+		code += " override"
+		if len(override.Overrides) > 0 {
+			names := make([]string, 0, len(override.Overrides))
+			for _, path := range override.Overrides {
+				names = append(names, path.Name)
+			}
+			code += "(" + strings.Join(names, ", ") + ")"
+		}
+	}
+	return code
+}
+
+// modifierInvocations prints the modifier invocations, including base constructor calls.
+func (p *Printer) modifierInvocations(modifiers []*ModifierInvocation) string {
+	code := ""
+	for _, modifier := range modifiers {
+		name := modifier.Name
+		if modifier.ModifierName != nil {
+			name = modifier.ModifierName.Name
+		}
+
+		code += " " + name
+		if len(modifier.Arguments) > 0 {
+			code += "(" + p.expressions(modifier.Arguments) + ")"
+		}
+	}
+	return code
+}
+
+// mutability returns the state mutability keyword prefixed with a space, or an empty string
+// for the default non-payable mutability.
+func (p *Printer) mutability(mutability ast_pb.Mutability) string {
+	switch mutability {
+	case ast_pb.Mutability_PURE:
+		return " pure"
+	case ast_pb.Mutability_VIEW:
+		return " view"
+	case ast_pb.Mutability_PAYABLE:
+		return " payable"
+	default:
+		return ""
+	}
+}
+
+// structDefinition prints a struct definition with each member on its own line.
+func (p *Printer) structDefinition(s *StructDefinition, indent string) string {
+	if len(s.Members) == 0 {
+		return "struct " + s.Name + " {}"
+	}
+
+	inner := indent + p.opts.Indent
+	lines := make([]string, 0, len(s.Members))
+	for _, member := range s.Members {
+		if param, ok := member.(*Parameter); ok {
+			lines = append(lines, inner+p.typeName(param.TypeName)+" "+param.Name+";")
+		}
+	}
+
+	return "struct " + s.Name + " {\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// enumDefinition prints an enum definition with each value on its own line.
+func (p *Printer) enumDefinition(e *EnumDefinition, indent string) string {
+	if len(e.Members) == 0 {
+		return "enum " + e.Name + " {}"
+	}
+
+	inner := indent + p.opts.Indent
+	lines := make([]string, 0, len(e.Members))
+	for _, member := range e.Members {
+		if param, ok := member.(*Parameter); ok {
+			lines = append(lines, inner+param.Name)
+		}
+	}
+
+	return "enum " + e.Name + " {\n" + strings.Join(lines, ",\n") + "\n" + indent + "}"
+}
+
+// usingDirective prints a using for directive.
+func (p *Printer) usingDirective(u *UsingDirective) string {
+	code := "using "
+	if u.LibraryName != nil {
+		code += u.LibraryName.Name
+	} else if len(u.FunctionList) > 0 {
+		functions := make([]string, 0, len(u.FunctionList))
+		for _, function := range u.FunctionList {
+			functions = append(functions, function.Name)
+		}
+		code += "{" + strings.Join(functions, ", ") + "}"
+	}
+
+	code += " for "
+	if u.TypeName != nil {
+		code += p.typeName(u.TypeName)
+	} else {
+		code += "*"
+	}
+
+	return code + ";"
+}
+
+// parameters prints the parameter list, without the surrounding parentheses.
+// Data location is printed only when withLocation is set, as events and errors do not declare it.
+func (p *Printer) parameters(list *ParameterList, withLocation bool) string {
+	if list == nil {
+		return ""
+	}
+
+	params := make([]string, 0, len(list.Parameters))
+	for _, param := range list.Parameters {
+		params = append(params, p.parameter(param, withLocation))
+	}
+
+	return strings.Join(params, ", ")
+}
+
+// parameter prints a single parameter. The AST assumes memory location for parameters that do not
+// declare one, so memory is printed for reference types only.
+func (p *Printer) parameter(param *Parameter, withLocation bool) string {
+	parts := []string{p.typeName(param.TypeName)}
+
+	if param.Indexed {
+		parts = append(parts, "indexed")
+	}
+
+	if withLocation && (isReferenceType(param.TypeName) || param.StorageLocation != ast_pb.StorageLocation_MEMORY) {
+		if location := storageLocationKeyword(param.StorageLocation); location != "" {
+			parts = append(parts, location)
+		}
+	}
+
+	if param.Name != "" {
+		parts = append(parts, param.Name)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// isSimpleMember returns true for single line declarations that are not separated by an empty line
+// when declared one after another.
+func isSimpleMember(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *StateVariableDeclaration, *EventDefinition, *ErrorDefinition, *UsingDirective, *UserDefinedValueTypeDefinition:
+		return true
+	default:
+		return false
+	}
+}
+
+// contractName returns the name of the contract, interface or library node.
+func contractName(node Node[NodeType]) string {
+	switch node := node.(type) {
+	case *Contract:
+		return node.Name
+	case *Interface:
+		return node.Name
+	case *Library:
+		return node.Name
+	default:
+		return ""
+	}
+}
+
+// sortedNodes returns the nodes ordered by their position in the source. Builder appends some of the
+// nodes, such as unchecked blocks, after the rest of the statements.
+func sortedNodes(nodes []Node[NodeType]) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0, len(nodes))
+	for _, node := range nodes {
+		if node != nil {
+			toReturn = append(toReturn, node)
+		}
+	}
+
+	sort.SliceStable(toReturn, func(i, j int) bool {
+		return toReturn[i].GetSrc().GetStart() < toReturn[j].GetSrc().GetStart()
+	})
+
+	return toReturn
+}
+
+// visibilityKeyword returns the Solidity keyword of the visibility.
+func visibilityKeyword(visibility ast_pb.Visibility) string {
+	switch visibility {
+	case ast_pb.Visibility_PUBLIC:
+		return "public"
+	case ast_pb.Visibility_PRIVATE:
+		return "private"
+	case ast_pb.Visibility_EXTERNAL:
+		return "external"
+	default:
+		return "internal"
+	}
+}
+
+// storageLocationKeyword returns the Solidity keyword of the data location or an empty string.
+func storageLocationKeyword(location ast_pb.StorageLocation) string {
+	switch location {
+	case ast_pb.StorageLocation_MEMORY:
+		return "memory"
+	case ast_pb.StorageLocation_STORAGE:
+		return "storage"
+	case ast_pb.StorageLocation_CALLDATA:
+		return "calldata"
+	default:
+		return ""
+	}
+}
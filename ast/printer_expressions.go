@@ -0,0 +1,360 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// numberUnitRegex matches number literals with an ether or time unit the AST keeps attached to the value.
+var numberUnitRegex = regexp.MustCompile(`^(.*[0-9a-fA-F_])(wei|gwei|szabo|finney|ether|seconds|minutes|hours|days|weeks|years)$`)
+
+// addressPayableRegex matches the address payable type name as kept by the AST, without whitespace.
+var addressPayableRegex = regexp.MustCompile(`\baddresspayable\b`)
+
+// isExpressionNode returns true if the node is an expression.
+func isExpressionNode(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *Assignment, *BinaryOperation, *AndOperation, *BitAndOperation, *BitOrOperation,
+		*BitXorOperation, *Conditional, *ExprOperation, *ExpressionContext, *FunctionCall,
+		*FunctionCallOption, *IndexAccess, *IndexRange, *InlineArray, *MemberAccessExpression,
+		*MetaType, *NewExpr, *PayableConversion, *PrimaryExpression, *ShiftOperation,
+		*TupleExpression, *UnaryPrefix, *UnarySuffix:
+		return true
+	default:
+		return false
+	}
+}
+
+// expression prints an expression.
+func (p *Printer) expression(node Node[NodeType]) string {
+	var code string
+
+	switch node := node.(type) {
+	case nil:
+		return ""
+	case *PrimaryExpression:
+		code = p.primaryExpression(node)
+	case *Assignment:
+		switch {
+		case node.Expression != nil:
+			code = p.expression(node.Expression)
+		case node.LeftExpression == nil:
+			code = node.Text
+		default:
+			code = p.expression(node.LeftExpression) + " " + p.operator(assignmentOperator(node.Operator), node.Operator) + " " + p.expression(node.RightExpression)
+		}
+	case *BinaryOperation:
+		code = p.expression(node.LeftExpression) + " " + p.operator(binaryOperator(node.Operator), node.Operator) + " " + p.expression(node.RightExpression)
+	case *AndOperation:
+		code = p.joinExpressions(node.Expressions, " && ")
+	case *BitAndOperation:
+		code = p.joinExpressions(node.Expressions, " & ")
+	case *BitOrOperation:
+		code = p.joinExpressions(node.Expressions, " | ")
+	case *BitXorOperation:
+		code = p.joinExpressions(node.Expressions, " ^ ")
+	case *ShiftOperation:
+		if node.Operator == ast_pb.NodeType_SHIFT_LEFT_OPERATION {
+			code = p.joinExpressions(node.Expressions, " << ")
+		} else {
+			code = p.joinExpressions(node.Expressions, " >> ")
+		}
+	case *ExprOperation:
+		code = p.expression(node.LeftExpression) + " ** " + p.expression(node.RightExpression)
+	case *Conditional:
+		if len(node.Expressions) != 3 {
+			p.fail(fmt.Errorf("conditional %d has %d expressions, expected 3", node.GetId(), len(node.Expressions)))
+			return ""
+		}
+		code = p.expression(node.Expressions[0]) + " ? " + p.expression(node.Expressions[1]) + " : " + p.expression(node.Expressions[2])
+	case *UnaryPrefix:
+		if node.IsDelete() {
+			code = "delete " + p.expression(node.Expression)
+			break
+		}
+		operator, operand := p.operator(unaryOperator(node.Operator), node.Operator), p.expression(node.Expression)
+		if operator != "" && strings.HasPrefix(operand, operator[len(operator)-1:]) {
+			operator += " "
+		}
+		code = operator + operand
+	case *UnarySuffix:
+		code = p.expression(node.Expression) + p.operator(unaryOperator(node.Operator), node.Operator)
+	case *FunctionCall:
+		code = p.expression(node.Expression) + p.callArguments(node.Names, node.Arguments)
+	case *FunctionCallOption:
+		code = p.expression(node.Expression) + "{" + p.namedExpressions(node.Names, node.Options) + "}"
+	case *IndexAccess:
+		code = p.expression(node.BaseExpression) + "[" + p.expression(node.IndexExpression) + "]"
+	case *IndexRange:
+		code = p.expression(node.BaseExpression) + "[" + p.expression(node.LeftExpression) + ":" + p.expression(node.RightExpression) + "]"
+	case *InlineArray:
+		code = "[" + p.expressions(node.Expressions) + "]"
+	case *MemberAccessExpression:
+		code = p.expression(node.Expression) + "." + node.MemberName
+	case *MetaType:
+		if node.TypeName == nil {
+			p.fail(fmt.Errorf("meta type %d has no type name", node.GetId()))
+		}
+		code = "type(" + p.typeName(node.TypeName) + ")"
+	case *NewExpr:
+		code = "new " + p.typeName(node.TypeName)
+	case *PayableConversion:
+		code = "payable(" + p.expressions(node.Arguments) + ")"
+	case *TupleExpression:
+		code = "(" + p.tupleComponents(node) + ")"
+	case *ExpressionContext:
+		code = node.Value
+	case *TypeName:
+		return p.typeName(node)
+	default:
+		return p.unsupported(node)
+	}
+
+	return code
+}
+
+// expressions prints a comma separated list of expressions.
+func (p *Printer) expressions(nodes []Node[NodeType]) string {
+	return p.joinExpressions(nodes, ", ")
+}
+
+// tupleComponents prints the components of the tuple, leaving the omitted ones empty.
+func (p *Printer) tupleComponents(t *TupleExpression) string {
+	total := len(t.Components) + len(t.EmptyComponents)
+	codes := make([]string, 0, total)
+	components, empty := t.Components, t.EmptyComponents
+	for position := 0; position < total; position++ {
+		if len(empty) > 0 && empty[0] == position {
+			codes = append(codes, "")
+			empty = empty[1:]
+			continue
+		}
+		if len(components) == 0 {
+			p.fail(fmt.Errorf("tuple expression %d has an omitted component out of range", t.GetId()))
+			break
+		}
+		codes = append(codes, p.expression(components[0]))
+		components = components[1:]
+	}
+	return strings.Join(codes, ", ")
+}
+
+// callArguments prints the parenthesized arguments of a call, passed by name when names are set.
+func (p *Printer) callArguments(names []string, nodes []Node[NodeType]) string {
+	if len(names) > 0 {
+		return "({" + p.namedExpressions(names, nodes) + "})"
+	}
+	return "(" + p.expressions(nodes) + ")"
+}
+
+// namedExpressions prints a comma separated list of named arguments or call options.
+func (p *Printer) namedExpressions(names []string, nodes []Node[NodeType]) string {
+	if len(names) != len(nodes) {
+		p.fail(fmt.Errorf("expected %d named values, got %d", len(names), len(nodes)))
+	}
+
+	codes := make([]string, 0, len(nodes))
+	for i, node := range nodes {
+		if i < len(names) {
+			codes = append(codes, names[i]+": "+p.expression(node))
+		}
+	}
+	return strings.Join(codes, ", ")
+}
+
+// joinExpressions prints the expressions joined with the separator.
+func (p *Printer) joinExpressions(nodes []Node[NodeType], separator string) string {
+	codes := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		codes = append(codes, p.expression(node))
+	}
+	return strings.Join(codes, separator)
+}
+
+// primaryExpression prints identifiers and literals. Number literals get the unit separated again.
+func (p *Printer) primaryExpression(e *PrimaryExpression) string {
+	code := e.Text
+	if code == "" {
+		code = e.Name
+	}
+	if code == "" {
+		code = e.Value
+	}
+
+	if e.Kind == ast_pb.NodeType_NUMBER && !strings.HasPrefix(code, "0x") {
+		code = numberUnitRegex.ReplaceAllString(code, "$1 $2")
+	}
+
+	return addressPayableRegex.ReplaceAllString(code, "address payable")
+}
+
+// typeName prints a type name.
+func (p *Printer) typeName(t *TypeName) string {
+	if t == nil {
+		return ""
+	}
+
+	var code string
+	switch {
+	case t.GetType() == ast_pb.NodeType_MAPPING_TYPE_NAME && t.KeyType != nil && t.ValueType != nil:
+		code = "mapping(" + p.typeName(t.KeyType) + " => " + p.typeName(t.ValueType) + ")"
+	case t.GetType() == ast_pb.NodeType_FUNCTION_TYPE_NAME && t.Expression != nil:
+		code = p.functionTypeName(t)
+	default:
+		code = t.Name
+		if t.PathNode != nil && t.PathNode.Name != "" && !strings.HasPrefix(code, t.PathNode.Name) {
+			code = t.PathNode.Name
+			if idx := strings.Index(t.Name, "["); idx >= 0 {
+				code += t.Name[idx:]
+			}
+		}
+		code = strings.ReplaceAll(code, "=>", " => ")
+		code = addressPayableRegex.ReplaceAllString(code, "address payable")
+	}
+
+	if code == "" {
+		p.fail(fmt.Errorf("type name %d has no name", t.GetId()))
+	}
+
+	return code
+}
+
+// functionTypeName prints a function type name.
+func (p *Printer) functionTypeName(t *TypeName) string {
+	fn, ok := t.Expression.(*Function)
+	if !ok {
+		return t.Name
+	}
+
+	code := "function(" + p.parameters(fn.Parameters, true) + ")"
+	if fn.Visibility == ast_pb.Visibility_EXTERNAL {
+		code += " external"
+	}
+	code += p.mutability(fn.StateMutability)
+
+	if fn.ReturnParameters != nil && len(fn.ReturnParameters.Parameters) > 0 {
+		code += " returns (" + p.parameters(fn.ReturnParameters, true) + ")"
+	}
+
+	return code
+}
+
+// isReferenceType returns true if the type name requires data location when declared as a parameter.
+func isReferenceType(t *TypeName) bool {
+	if t == nil {
+		return false
+	}
+
+	name := t.Name
+	if t.PathNode != nil && name == "" {
+		name = t.PathNode.Name
+	}
+
+	if strings.Contains(name, "[") || name == "string" || name == "bytes" ||
+		t.GetType() == ast_pb.NodeType_MAPPING_TYPE_NAME || strings.HasPrefix(name, "mapping(") {
+		return true
+	}
+
+	if description := t.GetTypeDescription(); description != nil {
+		return strings.HasPrefix(description.TypeIdentifier, "t_struct")
+	}
+
+	return false
+}
+
+// operator returns the printed operator, recording an error if the operator has no Solidity form.
+func (p *Printer) operator(code string, operator ast_pb.Operator) string {
+	if code == "" {
+		p.fail(fmt.Errorf("printer does not support operator %s", operator))
+	}
+	return code
+}
+
+// assignmentOperator returns the Solidity assignment operator.
+func assignmentOperator(operator ast_pb.Operator) string {
+	switch operator {
+	case ast_pb.Operator_PLUS_EQUAL:
+		return "+="
+	case ast_pb.Operator_MINUS_EQUAL:
+		return "-="
+	case ast_pb.Operator_MUL_EQUAL:
+		return "*="
+	case ast_pb.Operator_DIVISION, ast_pb.Operator_DIV_EQUAL:
+		return "/="
+	case ast_pb.Operator_MOD_EQUAL:
+		return "%="
+	case ast_pb.Operator_AND_EQUAL, ast_pb.Operator_BIT_AND_EQUAL:
+		return "&="
+	case ast_pb.Operator_OR_EQUAL, ast_pb.Operator_BIT_OR_EQUAL:
+		return "|="
+	case ast_pb.Operator_XOR_EQUAL, ast_pb.Operator_BIT_XOR_EQUAL:
+		return "^="
+	case ast_pb.Operator_SHIFT_LEFT_EQUAL:
+		return "<<="
+	case ast_pb.Operator_SHIFT_RIGHT_EQUAL:
+		return ">>="
+	case ast_pb.Operator_POW_EQUAL:
+		return ">>>="
+	case ast_pb.Operator_EQUAL:
+		return "="
+	default:
+		return ""
+	}
+}
+
+// binaryOperator returns the Solidity binary operator.
+func binaryOperator(operator ast_pb.Operator) string {
+	switch operator {
+	case ast_pb.Operator_ADDITION:
+		return "+"
+	case ast_pb.Operator_SUBTRACTION:
+		return "-"
+	case ast_pb.Operator_MULTIPLICATION:
+		return "*"
+	case ast_pb.Operator_DIVISION:
+		return "/"
+	case ast_pb.Operator_MODULO:
+		return "%"
+	case ast_pb.Operator_EXPONENTIATION:
+		return "**"
+	case ast_pb.Operator_GREATER_THAN:
+		return ">"
+	case ast_pb.Operator_GREATER_THAN_OR_EQUAL:
+		return ">="
+	case ast_pb.Operator_LESS_THAN:
+		return "<"
+	case ast_pb.Operator_LESS_THAN_OR_EQUAL:
+		return "<="
+	case ast_pb.Operator_EQUAL:
+		return "=="
+	case ast_pb.Operator_NOT_EQUAL:
+		return "!="
+	case ast_pb.Operator_OR:
+		return "||"
+	case ast_pb.Operator_BIT_AND:
+		return "&"
+	default:
+		return ""
+	}
+}
+
+// unaryOperator returns the Solidity unary operator.
+func unaryOperator(operator ast_pb.Operator) string {
+	switch operator {
+	case ast_pb.Operator_INCREMENT:
+		return "++"
+	case ast_pb.Operator_DECREMENT:
+		return "--"
+	case ast_pb.Operator_NOT:
+		return "!"
+	case ast_pb.Operator_BIT_NOT:
+		return "~"
+	case ast_pb.Operator_SUBTRACT:
+		return "-"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,285 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// printerOperators are the multi-character operators recognised by the printer scanner, longest first.
+var printerOperators = []string{
+	">>>=", ">>>", "<<=", ">>=", "**", "==", "!=", "<=", ">=", "&&", "||", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "<<", ">>", "=>", "->", ":=",
+}
+
+// pragmaVersionRegex matches a version immediately followed by a comparator within the pragma text.
+var pragmaVersionRegex = regexp.MustCompile(`(\d)([<>=^~])`)
+
+// printerToken is a single token of Solidity source code.
+type printerToken struct {
+	text  string
+	start int // start is the offset of the token within the scanned code.
+}
+
+// scanTokens splits the code into tokens, skipping whitespace and comments. It does not validate
+// the code; it only needs to be precise enough to find the code between comments.
+func scanTokens(code []rune) []printerToken {
+	tokens := make([]printerToken, 0, len(code)/3)
+
+	for i := 0; i < len(code); {
+		c := code[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '/' && i+1 < len(code) && code[i+1] == '/':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(code) && code[i+1] == '*':
+			end := i + 2
+			for end < len(code) && !(code[end] == '*' && end+1 < len(code) && code[end+1] == '/') {
+				end++
+			}
+			i = end + 2
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(code) && code[end] != c {
+				if code[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(code))
+			tokens = append(tokens, printerToken{text: string(code[i:end]), start: i})
+			i = end
+		case isIdentifierRune(c, true):
+			end := i + 1
+			for end < len(code) && isIdentifierRune(code[end], false) {
+				end++
+			}
+			tokens = append(tokens, printerToken{text: string(code[i:end]), start: i})
+			i = end
+		case unicode.IsDigit(c):
+			end := i + 1
+			hex := i+1 < len(code) && (code[i+1] == 'x' || code[i+1] == 'X')
+			for end < len(code) {
+				r := code[end]
+				if isIdentifierRune(r, false) || r == '.' && end+1 < len(code) && unicode.IsDigit(code[end+1]) {
+					end++
+				} else if !hex && r == '-' && (code[end-1] == 'e' || code[end-1] == 'E') {
+					end++
+				} else {
+					break
+				}
+			}
+			tokens = append(tokens, printerToken{text: string(code[i:end]), start: i})
+			i = end
+		default:
+			text := string(c)
+			for _, operator := range printerOperators {
+				if strings.HasPrefix(string(code[i:min(i+len(operator), len(code))]), operator) {
+					text = operator
+					break
+				}
+			}
+			tokens = append(tokens, printerToken{text: text, start: i})
+			i += len([]rune(text))
+		}
+	}
+
+	return tokens
+}
+
+// isIdentifierRune returns true if the rune can be part of an identifier.
+func isIdentifierRune(r rune, first bool) bool {
+	if r == '_' || r == '$' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && unicode.IsDigit(r)
+}
+
+// combinedSource returns the combined source the AST of the builder was built from,
+// or nil if sources are not available. Files whose content is missing, such as solc sources that were not
+// provided, are padded to their length so that node locations of the following files still line up.
+func combinedSource(b *ASTBuilder) []rune {
+	if b == nil || b.sources == nil {
+		return nil
+	}
+
+	contents := make([]string, 0, len(b.GetSourceFiles()))
+	for _, file := range b.GetSourceFiles() {
+		content := file.GetContent()
+		if missing := int(file.Length) - utf8.RuneCountInString(content); content == "" && missing > 0 {
+			content = strings.Repeat(" ", missing)
+		}
+		contents = append(contents, content)
+	}
+
+	return []rune(strings.Join(contents, combinedSourceSeparator))
+}
+
+// text returns the original code of the source node.
+func (p *Printer) text(src SrcNode) (string, bool) {
+	start, end := src.GetStart(), src.GetStart()+src.GetLength()
+	if p.source == nil || src.GetLength() <= 0 || start < 0 || end > int64(len(p.source)) {
+		return "", false
+	}
+	return string(p.source[start:end]), true
+}
+
+// notRetained returns the error for code of the sources that the AST does not retain.
+func (p *Printer) notRetained(src SrcNode) error {
+	code, _ := p.text(src)
+	if line, _, found := strings.Cut(code, "\n"); found {
+		code = line + " ..."
+	}
+	return fmt.Errorf("ast does not retain the code at offset %d: %s", src.GetStart(), code)
+}
+
+// srcOf returns the location of the node. Builder locates enum definitions one character short of
+// their closing brace, which is corrected here.
+func (p *Printer) srcOf(node Node[NodeType]) SrcNode {
+	src := node.GetSrc()
+	if _, ok := node.(*EnumDefinition); ok && p.source != nil {
+		end := src.GetStart() + src.GetLength()
+		if end >= 0 && end < int64(len(p.source)) && p.source[end] == '}' {
+			src.Length++
+		}
+	}
+	return src
+}
+
+// verbatim lays out the original code of a comment. Original lines are kept and re-indented
+// relative to the line the comment starts on.
+func (p *Printer) verbatim(src SrcNode, original string, indent string) string {
+	lines := strings.Split(original, "\n")
+	if len(lines) == 1 {
+		return original
+	}
+
+	base := p.lineIndentation(src.GetStart())
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRightFunc(lines[i], unicode.IsSpace)
+		stripped := 0
+		for stripped < base && stripped < len(line) && (line[stripped] == ' ' || line[stripped] == '\t') {
+			stripped++
+		}
+
+		if line[stripped:] == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = indent + line[stripped:]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// lineIndentation returns the number of whitespace characters the source line containing the
+// position starts with.
+func (p *Printer) lineIndentation(pos int64) int {
+	start := min(pos, int64(len(p.source)))
+	for start > 0 && p.source[start-1] != '\n' {
+		start--
+	}
+
+	count := 0
+	for i := start; i < int64(len(p.source)) && (p.source[i] == ' ' || p.source[i] == '\t'); i++ {
+		count++
+	}
+
+	return count
+}
+
+// hasBlankLine returns true if there is an empty line between the end of the source node and the position.
+func (p *Printer) hasBlankLine(src SrcNode, pos int64) bool {
+	if p.source == nil || p.opts.Canonical {
+		return false
+	}
+
+	start := src.GetStart() + src.GetLength()
+	if start < 0 || pos > int64(len(p.source)) || start >= pos {
+		return false
+	}
+
+	between := string(p.source[start:pos])
+	for _, comment := range p.comments {
+		if comment.GetSrc().GetStart() >= start && comment.GetSrc().GetStart() < pos {
+			// Blank lines after leading comments belong to the comments and not to the node.
+			between = string(p.source[start:comment.GetSrc().GetStart()])
+			break
+		}
+	}
+
+	lines := strings.Split(between, "\n")
+	for i := 1; i < len(lines)-1; i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// leadingComments prints the comments that were not printed yet and appear before the position,
+// each one on its own line. Comments within code that was already printed are skipped.
+func (p *Printer) leadingComments(pos int64, indent string) string {
+	var sb strings.Builder
+
+	for p.next < len(p.comments) && p.comments[p.next].GetSrc().GetStart() < pos {
+		comment := p.comments[p.next]
+		p.next++
+
+		if comment.GetSrc().GetStart() < p.pos {
+			continue
+		}
+
+		sb.WriteString(indent)
+		sb.WriteString(p.verbatim(comment.GetSrc(), comment.GetText(), indent))
+		sb.WriteString("\n")
+	}
+
+	if pos > p.pos {
+		p.pos = pos
+	}
+
+	return sb.String()
+}
+
+// trailingComment prints the comment that follows the source node on the same line, if any,
+// and marks everything up to the end of the node as printed. Comments outside of the parent are left
+// to be printed by the parent.
+func (p *Printer) trailingComment(src SrcNode, parent SrcNode) string {
+	end := src.GetStart() + src.GetLength()
+	if end > p.pos {
+		p.pos = end
+	}
+
+	for p.next < len(p.comments) && p.comments[p.next].GetSrc().GetStart() < p.pos {
+		p.next++
+	}
+
+	if p.next >= len(p.comments) || p.source == nil {
+		return ""
+	}
+
+	comment := p.comments[p.next]
+	start := comment.GetSrc().GetStart()
+	if start < p.pos || start >= parent.GetStart()+parent.GetLength() || start > int64(len(p.source)) ||
+		strings.ContainsRune(string(p.source[p.pos:start]), '\n') {
+		return ""
+	}
+
+	// Comments spanning multiple lines are left to be printed as leading comments of the next node.
+	if strings.Contains(comment.GetText(), "\n") {
+		return ""
+	}
+
+	p.next++
+	p.pos = start + comment.GetSrc().GetLength()
+
+	return " " + comment.GetText()
+}
@@ -0,0 +1,401 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// block prints a block of statements, including unchecked blocks, indenting statements
+// one level deeper than the provided indentation.
+func (p *Printer) block(body *BodyNode, indent string) string {
+	prefix := ""
+	if body.GetType() == ast_pb.NodeType_UNCHECKED_BLOCK {
+		prefix = "unchecked "
+	}
+
+	inner := indent + p.opts.Indent
+	lines := make([]string, 0, len(body.Statements))
+
+	var previous Node[NodeType]
+	for _, statement := range sortedNodes(body.Statements) {
+		start := statement.GetSrc().GetStart()
+		comments := p.leadingComments(start, inner)
+		code := p.statement(statement, inner)
+
+		if previous != nil && p.hasBlankLine(previous.GetSrc(), start) {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, comments+inner+code+p.trailingComment(statement.GetSrc(), body.GetSrc()))
+		previous = statement
+	}
+
+	if body.GetSrc().GetLength() > 0 {
+		if trailing := p.leadingComments(body.GetSrc().GetStart()+body.GetSrc().GetLength(), inner); trailing != "" {
+			lines = append(lines, strings.TrimSuffix(trailing, "\n"))
+		}
+	}
+
+	if len(lines) == 0 {
+		return prefix + "{}"
+	}
+
+	return prefix + "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// statement prints a single statement. Statements that end with a semicolon include it.
+func (p *Printer) statement(node Node[NodeType], indent string) string {
+	defer func(previous string) { p.indent = previous }(p.indent)
+	p.indent = indent
+
+	var code string
+
+	switch node := node.(type) {
+	case *BodyNode:
+		code = p.block(node, indent)
+	case *VariableDeclaration:
+		code = p.variableDeclaration(node) + ";"
+	case *IfStatement:
+		code = "if (" + p.expression(node.Condition) + ")" + p.branch(node.Body, indent)
+		if node.FalseBody != nil {
+			code += " else" + p.branch(node.FalseBody, indent)
+		}
+	case *ForStatement:
+		code = p.forStatement(node, indent)
+	case *WhileStatement:
+		code = "while (" + p.expression(node.Condition) + ")" + p.branch(node.Body, indent)
+	case *DoWhileStatement:
+		code = "do" + p.branch(node.Body, indent) + " while (" + p.expression(node.Condition) + ");"
+	case *ReturnStatement:
+		code = "return"
+		if node.Expression != nil {
+			code += " " + p.expression(node.Expression)
+		}
+		code += ";"
+	case *Emit:
+		code = "emit " + p.expression(node.Expression) + p.callArguments(node.Names, node.Arguments) + ";"
+	case *RevertStatement:
+		code = "revert " + p.expression(node.Expression) + p.callArguments(node.Names, node.Arguments) + ";"
+	case *TryStatement:
+		code = p.tryStatement(node, indent)
+	case *BreakStatement:
+		code = "break;"
+	case *ContinueStatement:
+		code = "continue;"
+	case *Yul:
+		code = p.assembly(node, indent)
+	case *PrimaryExpression:
+		if node.GetType() == ast_pb.NodeType_PLACEHOLDER_STATEMENT {
+			code = "_;"
+		} else {
+			code = p.expression(node) + ";"
+		}
+	default:
+		if !isExpressionNode(node) {
+			return p.unsupported(node)
+		}
+		code = p.expression(node) + ";"
+	}
+
+	return code
+}
+
+// branch prints the body of a control statement, prefixed with a space. Bodies that are not blocks
+// do not have a location of their own in the AST and are printed on the same line.
+func (p *Printer) branch(node Node[NodeType], indent string) string {
+	body, ok := node.(*BodyNode)
+	if !ok {
+		if node == nil {
+			return ";"
+		}
+		return " " + p.statement(node, indent)
+	}
+
+	if body.GetSrc().GetLength() == 0 {
+		if len(body.Statements) == 0 {
+			return ";"
+		}
+		if len(body.Statements) == 1 {
+			return " " + p.statement(body.Statements[0], indent)
+		}
+	}
+
+	return " " + p.block(body, indent)
+}
+
+// variableDeclaration prints a local variable declaration without the trailing semicolon.
+func (p *Printer) variableDeclaration(v *VariableDeclaration) string {
+	slots := v.Declarations
+	if len(v.Assignments) > len(v.Declarations) {
+		// Omitted tuple components only exist as zero assignments.
+		slots = make([]*Declaration, 0, len(v.Assignments))
+		for _, id := range v.Assignments {
+			var slot *Declaration
+			for _, declaration := range v.Declarations {
+				if declaration != nil && declaration.GetId() == id {
+					slot = declaration
+				}
+			}
+			slots = append(slots, slot)
+		}
+	}
+
+	declarations := make([]string, 0, len(slots))
+	for _, declaration := range slots {
+		if declaration == nil {
+			declarations = append(declarations, "")
+			continue
+		}
+
+		parts := make([]string, 0, 3)
+		if declaration.TypeName != nil {
+			parts = append(parts, p.typeName(declaration.TypeName))
+		}
+		if location := storageLocationKeyword(declaration.StorageLocation); location != "" {
+			parts = append(parts, location)
+		}
+		parts = append(parts, declaration.Name)
+		declarations = append(declarations, strings.Join(parts, " "))
+	}
+
+	code := strings.Join(declarations, ", ")
+	if len(declarations) > 1 {
+		code = "(" + code + ")"
+	}
+
+	if v.InitialValue != nil {
+		code += " = " + p.expression(v.InitialValue)
+	}
+
+	return code
+}
+
+// forStatement prints the for loop.
+func (p *Printer) forStatement(f *ForStatement, indent string) string {
+	init := ""
+	switch initialiser := f.Initialiser.(type) {
+	case nil:
+	case *VariableDeclaration:
+		init = p.variableDeclaration(initialiser)
+	default:
+		init = p.expression(initialiser)
+	}
+
+	header := "for (" + init + ";"
+	if f.Condition != nil {
+		header += " " + p.expression(f.Condition)
+	}
+	header += ";"
+	if f.Closure != nil {
+		header += " " + p.expression(f.Closure)
+	}
+
+	return header + ")" + p.branch(f.Body, indent)
+}
+
+// tryStatement prints the try statement together with its catch clauses.
+func (p *Printer) tryStatement(t *TryStatement, indent string) string {
+	code := "try " + p.expression(t.Expression)
+
+	if t.ReturnParameters != nil && len(t.ReturnParameters.Parameters) > 0 {
+		code += " returns (" + p.parameters(t.ReturnParameters, true) + ")"
+	}
+
+	code += " " + p.block(t.Body, indent)
+
+	for _, clause := range t.Clauses {
+		catch, ok := clause.(*CatchStatement)
+		if !ok {
+			continue
+		}
+
+		code += " catch "
+		if catch.Name != "" {
+			code += catch.Name
+		}
+		if catch.Parameters != nil && len(catch.Parameters.Parameters) > 0 {
+			code += "(" + p.parameters(catch.Parameters, true) + ") "
+		} else if catch.Name != "" {
+			code += " "
+		}
+		code += p.block(catch.Body, indent)
+	}
+
+	return code
+}
+
+// unsupported records an error for the node the printer cannot represent.
+func (p *Printer) unsupported(node Node[NodeType]) string {
+	p.fail(fmt.Errorf("printer does not support %T", node))
+	return ""
+}
+
+// assembly prints the inline assembly statement.
+func (p *Printer) assembly(y *Yul, indent string) string {
+	header := "assembly"
+	if len(y.Flags) > 0 {
+		header += " (\"" + strings.Join(y.Flags, "\", \"") + "\")"
+	}
+	if y.Body == nil {
+		return header + " {}"
+	}
+
+	return header + " " + p.yulBlock(y.Body.Statements, y.GetSrc(), indent)
+}
+
+// yulBlock prints a block of yul statements.
+func (p *Printer) yulBlock(statements []Node[NodeType], src SrcNode, indent string) string {
+	inner := indent + p.opts.Indent
+	lines := make([]string, 0, len(statements))
+
+	var previous Node[NodeType]
+	for _, statement := range sortedNodes(yulStatements(statements)) {
+		start := statement.GetSrc().GetStart()
+		comments := p.leadingComments(start, inner)
+		code := p.yulStatement(statement, inner)
+
+		if previous != nil && p.hasBlankLine(previous.GetSrc(), start) {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, comments+inner+code+p.trailingComment(statement.GetSrc(), src))
+		previous = statement
+	}
+
+	if src.GetLength() > 0 {
+		if trailing := p.leadingComments(src.GetStart()+src.GetLength(), inner); trailing != "" {
+			lines = append(lines, strings.TrimSuffix(trailing, "\n"))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+}
+
+// yulStatements flattens yul statement wrappers into the statements they hold. The builder shares a
+// single wrapper between all statements of an assembly block, so each wrapper is expanded only once.
+func yulStatements(statements []Node[NodeType]) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0, len(statements))
+	seen := make(map[*YulStatement]bool)
+
+	for _, statement := range statements {
+		wrapper, ok := statement.(*YulStatement)
+		if !ok {
+			toReturn = append(toReturn, statement)
+			continue
+		}
+
+		if !seen[wrapper] {
+			seen[wrapper] = true
+			toReturn = append(toReturn, wrapper.Statements...)
+		}
+	}
+
+	return toReturn
+}
+
+// yulStatement prints a single yul statement.
+func (p *Printer) yulStatement(node Node[NodeType], indent string) string {
+	defer func(previous string) { p.indent = previous }(p.indent)
+	p.indent = indent
+
+	var code string
+
+	switch node := node.(type) {
+	case nil:
+		return "{}"
+	case *YulStatement:
+		if len(node.Statements) != 1 {
+			return p.unsupported(node)
+		}
+		code = p.yulStatement(node.Statements[0], indent)
+	case *YulBlockStatement:
+		code = p.yulBlock(node.Statements, node.GetSrc(), indent)
+	case *YulAssignment:
+		code = yulIdentifiers(node.VariableNames) + " := " + p.yulExpression(node.Value)
+	case *YulVariable:
+		code = "let " + yulIdentifiers(node.Variables)
+		if node.Value != nil {
+			code += " := " + p.yulExpression(node.Value)
+		}
+	case *YulIfStatement:
+		code = "if " + p.yulExpression(node.Condition) + " " + p.yulStatement(node.Body, indent)
+	case *YulForStatement:
+		code = "for " + p.yulStatement(node.Pre, indent) + " " + p.yulExpression(node.Condition) + " " +
+			p.yulStatement(node.Post, indent) + " " + p.yulStatement(node.Body, indent)
+	case *YulSwitchStatement:
+		code = "switch " + p.yulExpression(node.Expression)
+		for _, c := range node.Cases {
+			code += "\n" + indent + p.yulStatement(c, indent)
+		}
+	case *YulSwitchCaseStatement:
+		if node.Case == nil {
+			code = "default " + p.yulStatement(node.Body, indent)
+		} else {
+			code = "case " + p.yulExpression(node.Case) + " " + p.yulStatement(node.Body, indent)
+		}
+	case *YulFunctionDefinition:
+		code = "function " + node.Name + "(" + yulIdentifiers(node.Arguments) + ")"
+		if len(node.ReturnParameters) > 0 {
+			code += " -> " + yulIdentifiers(node.ReturnParameters)
+		}
+		code += " " + p.yulStatement(node.Body, indent)
+	case *YulBreakStatement:
+		code = "break"
+	case *YulContinueStatement:
+		code = "continue"
+	case *YulLeaveStatement:
+		code = "leave"
+	case *YulFunctionCallStatement, *YulExpressionStatement, *YulLiteralStatement, *YulIdentifier:
+		code = p.yulExpression(node)
+	default:
+		return p.unsupported(node)
+	}
+
+	return code
+}
+
+// yulExpression prints a yul expression.
+func (p *Printer) yulExpression(node Node[NodeType]) string {
+	switch node := node.(type) {
+	case nil:
+		return ""
+	case *YulExpressionStatement:
+		return p.yulExpression(node.Expression)
+	case *YulFunctionCallStatement:
+		name := ""
+		if node.FunctionName != nil {
+			name = node.FunctionName.Name
+		}
+
+		arguments := make([]string, 0, len(node.Arguments))
+		for _, argument := range node.Arguments {
+			arguments = append(arguments, p.yulExpression(argument))
+		}
+
+		return name + "(" + strings.Join(arguments, ", ") + ")"
+	case *YulLiteralStatement:
+		if node.Kind == ast_pb.NodeType_HEX_NUMBER || node.Kind == ast_pb.NodeType_HEX_STRING {
+			return node.HexValue
+		}
+		return node.Value
+	case *YulIdentifier:
+		return node.Name
+	default:
+		return p.unsupported(node)
+	}
+}
+
+// yulIdentifiers prints a comma separated list of yul identifiers.
+func yulIdentifiers(identifiers []*YulIdentifier) string {
+	names := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		names = append(names, identifier.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,502 @@
+package ast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+)
+
+func buildPrinterAst(t *testing.T, content string) *ASTBuilder {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name:    "Printer",
+				Path:    "Printer.sol",
+				Content: content,
+			},
+		},
+		EntrySourceUnitName: "Printer",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	parser, err := solgo.NewParserFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(parser.GetParser(), parser.GetSources())
+	require.NoError(t, parser.RegisterListener(solgo.ListenerAst, astBuilder))
+	require.Empty(t, parser.Parse())
+	require.Empty(t, astBuilder.ResolveReferences())
+
+	return astBuilder
+}
+
+func TestPrinter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "Simple Contract",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Counter {
+    uint256 public count;
+
+    event Bumped(address indexed who, uint256 count);
+
+    // Increments the counter.
+    function bump(uint256 by) external returns (uint256) {
+        count += by;
+        emit Bumped(msg.sender, count);
+        return count;
+    }
+}
+`,
+			expected: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Counter {
+    uint256 public count;
+
+    event Bumped(address indexed who, uint256 count);
+
+    // Increments the counter.
+    function bump(uint256 by) external returns (uint256) {
+        count += by;
+        emit Bumped(msg.sender, count);
+        return count;
+    }
+}
+`,
+		},
+		{
+			name: "Reformatted Contract",
+			content: `pragma solidity ^0.8.0;
+library   Math {
+  function max(uint256 a,uint256 b) internal pure returns(uint256){
+    if(a>=b){return a;}
+    return b;
+  }
+}
+`,
+			expected: `pragma solidity ^0.8.0;
+
+library Math {
+    function max(uint256 a, uint256 b) internal pure returns (uint256) {
+        if (a >= b) {
+            return a;
+        }
+        return b;
+    }
+}
+`,
+		},
+		{
+			name: "Inline Assembly",
+			content: `pragma solidity ^0.8.0;
+
+library Bits {
+    function shift(uint256 value) internal pure returns (uint256 result) {
+        assembly {
+            let doubled := add(value, value)
+            if gt(doubled, 10) {
+                doubled := sub(doubled, 1)
+            }
+            result := shl(1, doubled)
+        }
+    }
+}
+`,
+			expected: `pragma solidity ^0.8.0;
+
+library Bits {
+    function shift(uint256 value) internal pure returns (uint256 result) {
+        assembly {
+            let doubled := add(value, value)
+            if gt(doubled, 10) {
+                doubled := sub(doubled, 1)
+            }
+            result := shl(1, doubled)
+        }
+    }
+}
+`,
+		},
+		{
+			name: "Retained Constructs",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import {IERC20, IERC20 as Token} from "./IERC20.sol";
+
+uint256 constant LIMIT = 1e10 ether;
+
+contract Base {
+    function factory() external view virtual returns (address) {}
+}
+
+contract Vault is Base {
+    address public immutable override factory;
+    uint256[3] internal slots;
+    mapping(address => uint256) public balances;
+
+    event Moved(address from, uint256 amount);
+    error Failed(uint256 code);
+
+    constructor(address factory_) payable {
+        factory = factory_;
+    }
+
+    function run(bytes calldata data, uint256 a, uint256 b) external returns (bytes memory) {
+        for (uint256 i = 0; i < 3; i++) slots[i] += i;
+        while (a > 0) a--;
+        do b >>= 1; while (b > 0);
+        if (a != b) {
+            unchecked {
+                b = a - b;
+            }
+        } else if (a == 0) {
+            delete balances[msg.sender];
+        } else {
+            revert Failed({code: a});
+        }
+        (, uint256 c) = pair();
+        (, b) = pair();
+        emit Moved({from: msg.sender, amount: c + type(uint256).max});
+        (bool ok, ) = msg.sender.call{value: a, gas: 5000}("");
+        require(ok);
+        return data[4:];
+    }
+
+    function pair() internal pure returns (bool, uint256) {
+        return (true, 1);
+    }
+
+    function word(uint256 x) internal pure returns (uint256 r) {
+        assembly ("memory-safe") {
+            function twice(v) -> w {
+                w := add(v, v)
+            }
+            switch x
+            case 0 {
+                r := 1
+            }
+            default {
+                r := twice(x)
+            }
+        }
+    }
+}
+`,
+			expected: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import {IERC20, IERC20 as Token} from "./IERC20.sol";
+
+uint256 constant LIMIT = 1e10 ether;
+
+contract Base {
+    function factory() external view virtual returns (address) {}
+}
+
+contract Vault is Base {
+    address public immutable override factory;
+    uint256[3] slots;
+    mapping(address => uint256) public balances;
+
+    event Moved(address from, uint256 amount);
+
+    error Failed(uint256 code);
+
+    constructor(address factory_) payable {
+        factory = factory_;
+    }
+
+    function run(bytes calldata data, uint256 a, uint256 b) external returns (bytes memory) {
+        for (uint256 i = 0; i < 3; i++) slots[i] += i;
+        while (a > 0) a--;
+        do b >>= 1; while (b > 0);
+        if (a != b) {
+            unchecked {
+                b = a - b;
+            }
+        } else if (a == 0) {
+            delete balances[msg.sender];
+        } else {
+            revert Failed({code: a});
+        }
+        (, uint256 c) = pair();
+        (, b) = pair();
+        emit Moved({from: msg.sender, amount: c + type(uint256).max});
+        (bool ok, ) = msg.sender.call{value: a, gas: 5000}("");
+        require(ok);
+        return data[4:];
+    }
+
+    function pair() internal pure returns (bool, uint256) {
+        return (true, 1);
+    }
+
+    function word(uint256 x) internal pure returns (uint256 r) {
+        assembly ("memory-safe") {
+            function twice(v) -> w {
+                w := add(v, v)
+            }
+            switch x
+            case 0 {
+                r := 1
+            }
+            default {
+                r := twice(x)
+            }
+        }
+    }
+}
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			astBuilder := buildPrinterAst(t, testCase.content)
+
+			code, err := astBuilder.ToSource(nil)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, code)
+
+			// Printed code must parse again and print to the same code.
+			reprinted, err := buildPrinterAst(t, code).ToSource(nil)
+			require.NoError(t, err)
+			assert.Equal(t, code, reprinted)
+		})
+	}
+}
+
+func TestPrinterCanonical(t *testing.T) {
+	original := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @title Vault
+contract Vault {
+    mapping(address => uint256) private balances;
+
+    function deposit() external payable {
+        balances[msg.sender] += msg.value; // credit
+    }
+
+    function withdraw(uint256 amount) external {
+        require(balances[msg.sender] >= amount, "insufficient");
+        balances[msg.sender] -= amount;
+        payable(msg.sender).transfer(amount);
+    }
+}
+`
+
+	reformatted := `pragma solidity ^0.8.0;
+contract Vault {
+	mapping (address=>uint256) private balances;
+	function deposit() external payable { balances[msg.sender]+=msg.value; }
+
+	/* Sends funds back. */
+	function withdraw(uint256 amount) external
+	{
+		require(balances[msg.sender]>=amount,"insufficient");
+		balances[msg.sender]-=amount;
+
+		payable(msg.sender).transfer(amount);
+	}
+}
+`
+
+	opts := NewDefaultPrinterOptions()
+	opts.Canonical = true
+
+	first, err := buildPrinterAst(t, original).ToSource(opts)
+	require.NoError(t, err)
+	assert.NotContains(t, first, "//")
+
+	second, err := buildPrinterAst(t, reformatted).ToSource(opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	reprinted, err := buildPrinterAst(t, first).ToSource(opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, reprinted)
+}
+
+func TestPrinterWithoutSources(t *testing.T) {
+	astBuilder := buildPrinterAst(t, `pragma solidity ^0.8.0;
+
+contract Ownable {
+    address public owner;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner);
+        _;
+    }
+
+    function transferOwnership(address next) public onlyOwner {
+        owner = next;
+    }
+}
+`)
+
+	var contract *Contract
+	for _, unit := range astBuilder.GetRoot().GetSourceUnits() {
+		for _, node := range unit.GetNodes() {
+			if c, ok := node.(*Contract); ok {
+				contract = c
+			}
+		}
+	}
+	require.NotNil(t, contract)
+
+	code, err := NewPrinter(nil, nil).Print(contract)
+	require.NoError(t, err)
+	assert.Equal(t, `contract Ownable {
+    address public owner;
+
+    modifier onlyOwner {
+        require(msg.sender == owner);
+        _;
+    }
+
+    function transferOwnership(address next) public onlyOwner {
+        owner = next;
+    }
+}`, code)
+}
+
+func TestPrinterBuiltTree(t *testing.T) {
+	astBuilder := buildPrinterAst(t, `pragma solidity ^0.8.0;
+
+contract Pair {
+    uint256 public b;
+
+    function split(uint256 value) public pure returns (bool, uint256) {
+        return (true, value);
+    }
+
+    function pick(bool flag) public returns (uint256) {
+        return b;
+    }
+}
+`)
+	tree := astBuilder.GetTree()
+
+	b := findNode(t, tree, func(node *StateVariableDeclaration) bool { return node.GetName() == "b" })
+	_, err := tree.Rename(b.GetId(), "total")
+	require.NoError(t, err)
+
+	identifier := func(name string) *PrimaryExpression {
+		return &PrimaryExpression{NodeType: ast_pb.NodeType_IDENTIFIER, Name: name}
+	}
+
+	// Bodies without a location are printed as single statements.
+	statement := &IfStatement{
+		NodeType:  ast_pb.NodeType_IF_STATEMENT,
+		Condition: identifier("flag"),
+		Body: &BodyNode{
+			NodeType: ast_pb.NodeType_BLOCK,
+			Statements: []Node[NodeType]{&UnaryPrefix{
+				NodeType:   ast_pb.NodeType_UNARY_OPERATION,
+				Delete:     true,
+				Expression: identifier("total"),
+			}},
+		},
+		FalseBody: &BodyNode{
+			NodeType: ast_pb.NodeType_BLOCK,
+			Statements: []Node[NodeType]{&Assignment{
+				NodeType: ast_pb.NodeType_ASSIGNMENT,
+				Operator: ast_pb.Operator_EQUAL,
+				LeftExpression: &TupleExpression{
+					NodeType:        ast_pb.NodeType_TUPLE_EXPRESSION,
+					Components:      []Node[NodeType]{identifier("total")},
+					EmptyComponents: []int{0},
+				},
+				RightExpression: &FunctionCall{
+					NodeType:   ast_pb.NodeType_FUNCTION_CALL,
+					Expression: identifier("split"),
+					Arguments:  []Node[NodeType]{&PrimaryExpression{NodeType: ast_pb.NodeType_LITERAL, Kind: ast_pb.NodeType_NUMBER, Value: "2"}},
+					Names:      []string{"value"},
+				},
+			}},
+		},
+	}
+
+	fn := findNode(t, tree, func(node *Function) bool { return node.GetName() == "pick" })
+	require.NoError(t, tree.InsertStatements(fn.GetBody(), 0, statement))
+
+	code, err := astBuilder.ToSource(nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, `    function pick(bool flag) public returns (uint256) {
+        if (flag) delete total; else (, total) = split({value: 2});
+        return total;
+    }`)
+
+	// Printed code must parse again and print to the same code.
+	reprinted, err := buildPrinterAst(t, code).ToSource(nil)
+	require.NoError(t, err)
+	assert.Equal(t, code, reprinted)
+}
+
+func TestPrinterUnrepresentable(t *testing.T) {
+	astBuilder := buildPrinterAst(t, `pragma solidity ^0.8.0;
+
+function helper() pure returns (uint256) {
+    return 1;
+}
+
+contract Helped {}
+`)
+
+	_, err := astBuilder.ToSource(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "function helper()")
+
+	testCases := []struct {
+		name string
+		node Node[NodeType]
+	}{
+		{
+			name: "Unnamed Type",
+			node: &TypeName{NodeType: ast_pb.NodeType_ELEMENTARY_TYPE_NAME},
+		},
+		{
+			name: "Unknown Operator",
+			node: &BinaryOperation{
+				NodeType:        ast_pb.NodeType_BINARY_OPERATION,
+				LeftExpression:  &PrimaryExpression{NodeType: ast_pb.NodeType_IDENTIFIER, Name: "a"},
+				RightExpression: &PrimaryExpression{NodeType: ast_pb.NodeType_IDENTIFIER, Name: "b"},
+			},
+		},
+		{
+			name: "Incomplete Conditional",
+			node: &Conditional{
+				NodeType:    ast_pb.NodeType_CONDITIONAL_EXPRESSION,
+				Expressions: []Node[NodeType]{&PrimaryExpression{NodeType: ast_pb.NodeType_IDENTIFIER, Name: "a"}},
+			},
+		},
+		{
+			name: "Mismatched Named Arguments",
+			node: &FunctionCall{
+				NodeType:   ast_pb.NodeType_FUNCTION_CALL,
+				Expression: &PrimaryExpression{NodeType: ast_pb.NodeType_IDENTIFIER, Name: "f"},
+				Names:      []string{"a", "b"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewPrinter(nil, nil).Print(testCase.node)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -11,20 +11,21 @@ import (
 // It encapsulates information about the characteristics and properties of a receive function within a contract.
 type Receive struct {
 	*ASTBuilder                            // Embedded ASTBuilder for building the AST.
-	Id               int64                 `json:"id"`               // Unique identifier for the Receive node.
-	NodeType         ast_pb.NodeType       `json:"nodeType"`         // Type of the AST node.
-	Kind             ast_pb.NodeType       `json:"kind"`             // Kind of the receive function.
-	Src              SrcNode               `json:"src"`              // Source location information.
-	Implemented      bool                  `json:"implemented"`      // Indicates whether the function is implemented.
-	Visibility       ast_pb.Visibility     `json:"visibility"`       // Visibility of the receive function.
-	StateMutability  ast_pb.Mutability     `json:"stateMutability"`  // State mutability of the receive function.
-	Modifiers        []*ModifierInvocation `json:"modifiers"`        // List of modifier invocations applied to the receive function.
-	Overrides        []*OverrideSpecifier  `json:"overrides"`        // List of override specifiers for the receive function.
-	Parameters       *ParameterList        `json:"parameters"`       // List of parameters for the receive function.
-	ReturnParameters *ParameterList        `json:"returnParameters"` // List of return parameters for the receive function.
-	Body             *BodyNode             `json:"body"`             // Body of the receive function.
-	Virtual          bool                  `json:"virtual"`          // Indicates whether the function is virtual.
-	Payable          bool                  `json:"payable"`          // Indicates whether the function is payable.
+	Id               int64                 `json:"id"`                      // Unique identifier for the Receive node.
+	NodeType         ast_pb.NodeType       `json:"nodeType"`                // Type of the AST node.
+	Kind             ast_pb.NodeType       `json:"kind"`                    // Kind of the receive function.
+	Src              SrcNode               `json:"src"`                     // Source location information.
+	Implemented      bool                  `json:"implemented"`             // Indicates whether the function is implemented.
+	Visibility       ast_pb.Visibility     `json:"visibility"`              // Visibility of the receive function.
+	StateMutability  ast_pb.Mutability     `json:"stateMutability"`         // State mutability of the receive function.
+	Modifiers        []*ModifierInvocation `json:"modifiers"`               // List of modifier invocations applied to the receive function.
+	Overrides        []*OverrideSpecifier  `json:"overrides"`               // List of override specifiers for the receive function.
+	Parameters       *ParameterList        `json:"parameters"`              // List of parameters for the receive function.
+	ReturnParameters *ParameterList        `json:"returnParameters"`        // List of return parameters for the receive function.
+	Body             *BodyNode             `json:"body"`                    // Body of the receive function.
+	Virtual          bool                  `json:"virtual"`                 // Indicates whether the function is virtual.
+	Payable          bool                  `json:"payable"`                 // Indicates whether the function is payable.
+	Documentation    *NatSpec              `json:"documentation,omitempty"` // NatSpec documentation of the receive function.
 }
 
 // NewReceiveDefinition creates a new Receive node with default values and returns it.
@@ -56,6 +57,11 @@ func (f *Receive) GetSrc() SrcNode {
 	return f.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the Receive node or nil if it is not documented.
+func (f *Receive) GetDocumentation() *NatSpec {
+	return f.Documentation
+}
+
 // GetType returns the type of the AST node, which is NodeType_FUNCTION_DEFINITION for a receive function.
 func (f *Receive) GetType() ast_pb.NodeType {
 	return f.NodeType
@@ -154,6 +160,12 @@ func (f *Receive) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &f.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if implemented, ok := tempMap["implemented"]; ok {
 		if err := json.Unmarshal(implemented, &f.Implemented); err != nil {
 			return err
@@ -253,6 +265,7 @@ func (f *Receive) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   f.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	f.Implemented = ctx.Block() != nil && !ctx.Block().IsEmpty()
 
@@ -164,6 +164,7 @@ func (r *ReturnStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fnNode.GetId(),
+		FileIndex:   r.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if fnCtx, ok := fnNode.(*Function); ok {
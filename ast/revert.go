@@ -12,11 +12,12 @@ import (
 type RevertStatement struct {
 	*ASTBuilder
 
-	Id         int64            `json:"id"`         // Unique identifier for the RevertStatement node.
-	NodeType   ast_pb.NodeType  `json:"nodeType"`   // Type of the AST node.
-	Src        SrcNode          `json:"src"`        // Source location information.
-	Arguments  []Node[NodeType] `json:"arguments"`  // List of argument expressions.
-	Expression Node[NodeType]   `json:"expression"` // Expression within the revert statement.
+	Id         int64            `json:"id"`              // Unique identifier for the RevertStatement node.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`        // Type of the AST node.
+	Src        SrcNode          `json:"src"`             // Source location information.
+	Arguments  []Node[NodeType] `json:"arguments"`       // List of argument expressions.
+	Names      []string         `json:"names,omitempty"` // Names of the arguments when they are passed by name.
+	Expression Node[NodeType]   `json:"expression"`      // Expression within the revert statement.
 }
 
 // NewRevertStatement creates a new RevertStatement node with a given ASTBuilder.
@@ -55,6 +56,11 @@ func (r *RevertStatement) GetArguments() []Node[NodeType] {
 	return r.Arguments
 }
 
+// GetNames returns the names of the arguments when they are passed by name, in the order of the arguments.
+func (r *RevertStatement) GetNames() []string {
+	return r.Names
+}
+
 // GetExpression returns the expression within the revert statement.
 func (r *RevertStatement) GetExpression() Node[NodeType] {
 	return r.Expression
@@ -93,6 +99,12 @@ func (r *RevertStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &r.Names); err != nil {
+			return err
+		}
+	}
+
 	if arguments, ok := tempMap["arguments"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(arguments, &nodes); err != nil {
@@ -142,6 +154,7 @@ func (r *RevertStatement) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto returns a protobuf representation of the RevertStatement node.
+// Protocol buffer definitions have no field for argument names, so named arguments are carried as plain arguments.
 func (r *RevertStatement) ToProto() NodeType {
 	proto := ast_pb.Revert{
 		Id:         r.Id,
@@ -181,12 +194,19 @@ func (r *RevertStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: fnNode.GetId(),
+		FileIndex:   r.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(r.ASTBuilder)
 
 	if ctx.CallArgumentList() != nil {
-		for _, expressionCtx := range ctx.CallArgumentList().AllExpression() {
+		expressionCtxs := ctx.CallArgumentList().AllExpression()
+		for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+			r.Names = append(r.Names, namedArgumentCtx.GetName().GetText())
+			expressionCtxs = append(expressionCtxs, namedArgumentCtx.GetValue())
+		}
+
+		for _, expressionCtx := range expressionCtxs {
 			r.Arguments = append(
 				r.Arguments,
 				expression.Parse(
@@ -0,0 +1,784 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+var (
+	// rewriteSkippedTypes are types the rewriter does not descend into, as they never hold child nodes.
+	rewriteSkippedTypes = map[reflect.Type]bool{
+		reflect.TypeOf((*ASTBuilder)(nil)):      true,
+		reflect.TypeOf((*TypeDescription)(nil)): true,
+		reflect.TypeOf((*SourceFile)(nil)):      true,
+		reflect.TypeOf((*Comment)(nil)):         true,
+		reflect.TypeOf((*NatSpec)(nil)):         true,
+		reflect.TypeOf(SrcNode{}):               true,
+		reflect.TypeOf((*SrcNode)(nil)):         true,
+	}
+
+	// srcNodeType is the type of the source location field of nodes.
+	srcNodeType = reflect.TypeOf(SrcNode{})
+)
+
+// identifiable is implemented by every node of the tree, including the ones that do not implement Node.
+type identifiable interface {
+	GetId() int64
+}
+
+// slot is a place in the tree that holds a node, either a struct field or an element of a list.
+type slot struct {
+	owners []identifiable // owners are the nodes enclosing the slot, outermost first.
+	value  reflect.Value  // value is the settable field or list element holding the node.
+	list   reflect.Value  // list is the settable list holding the node, if the node is a list element.
+	index  int            // index is the position of the node within the list.
+}
+
+// owner returns the nearest node enclosing the slot.
+func (s slot) owner() identifiable {
+	if len(s.owners) == 0 {
+		return nil
+	}
+	return s.owners[len(s.owners)-1]
+}
+
+// treeWalker visits every slot reachable from a node by following the exported fields of the nodes.
+// Nodes shared between several slots, such as global definitions, are reported for each slot but
+// descended into only once.
+type treeWalker struct {
+	visited map[uintptr]bool
+	visit   func(s slot, node reflect.Value)
+}
+
+// walkSlots calls visit for every slot reachable from the start node.
+func walkSlots(start any, visit func(s slot, node reflect.Value)) {
+	w := &treeWalker{visited: make(map[uintptr]bool), visit: visit}
+	v := reflect.ValueOf(start)
+	w.value(v, nil, reflect.Value{}, -1)
+}
+
+// value walks the value of a field or of a list element.
+func (w *treeWalker) value(v reflect.Value, owners []identifiable, list reflect.Value, index int) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Ptr {
+			w.pointer(v, v.Elem(), owners, list, index)
+		}
+	case reflect.Ptr:
+		w.pointer(v, v, owners, list, index)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			w.value(v.Index(i), owners, v, i)
+		}
+	case reflect.Struct:
+		if !rewriteSkippedTypes[v.Type()] {
+			w.fields(v, owners)
+		}
+	}
+}
+
+// pointer reports the slot holding the pointer and walks the fields of the struct it points to.
+func (w *treeWalker) pointer(holder reflect.Value, ptr reflect.Value, owners []identifiable, list reflect.Value, index int) {
+	if ptr.IsNil() || rewriteSkippedTypes[ptr.Type()] || ptr.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	w.visit(slot{owners: owners, value: holder, list: list, index: index}, ptr)
+
+	if w.visited[ptr.Pointer()] {
+		return
+	}
+	w.visited[ptr.Pointer()] = true
+
+	if node, ok := ptr.Interface().(identifiable); ok {
+		owners = append(owners[:len(owners):len(owners)], node)
+	}
+
+	w.fields(ptr.Elem(), owners)
+}
+
+// fields walks the exported fields of the struct.
+func (w *treeWalker) fields(v reflect.Value, owners []identifiable) {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() {
+			w.value(v.Field(i), owners, reflect.Value{}, -1)
+		}
+	}
+}
+
+// slotsOf returns all slots of the tree holding the node.
+func (t *Tree) slotsOf(node any) []slot {
+	target := reflect.ValueOf(node)
+	if node == nil || target.Kind() != reflect.Ptr || target.IsNil() {
+		return nil
+	}
+
+	slots := make([]slot, 0, 1)
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if v.Pointer() == target.Pointer() && s.value.CanSet() {
+			slots = append(slots, s)
+		}
+	})
+
+	return slots
+}
+
+// ids returns the identifiers of all nodes in the tree.
+func (t *Tree) ids() map[int64]bool {
+	toReturn := make(map[int64]bool)
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if node, ok := v.Interface().(identifiable); ok {
+			toReturn[node.GetId()] = true
+		}
+	})
+	return toReturn
+}
+
+// IsRewritten returns true if the node with the given ID was changed, or had any of its descendants
+// changed, through the rewrite API of the tree.
+func (t *Tree) IsRewritten(id int64) bool {
+	_, ok := t.rewritten[id]
+	return ok
+}
+
+// markRewritten records the nodes as rewritten.
+func (t *Tree) markRewritten(nodes ...identifiable) {
+	if t.rewritten == nil {
+		t.rewritten = make(map[int64]struct{})
+	}
+
+	for _, node := range nodes {
+		if node != nil {
+			t.rewritten[node.GetId()] = struct{}{}
+		}
+	}
+}
+
+// GetParent returns the nearest node enclosing the provided node, or nil if the node is not part
+// of the tree or it is not enclosed by any other node.
+func (t *Tree) GetParent(node Node[NodeType]) Node[NodeType] {
+	for _, s := range t.slotsOf(node) {
+		for i := len(s.owners) - 1; i >= 0; i-- {
+			if parent, ok := s.owners[i].(Node[NodeType]); ok {
+				return parent
+			}
+		}
+	}
+	return nil
+}
+
+// Replace replaces every occurrence of the node in the tree with the replacement node.
+// Replacement, together with all of its descendants, gets fresh IDs where needed and is placed at
+// the location of the replaced node.
+func (t *Tree) Replace(node Node[NodeType], replacement Node[NodeType]) error {
+	if node == nil || replacement == nil {
+		return fmt.Errorf("node and its replacement must be provided")
+	}
+
+	slots := t.slotsOf(node)
+	if len(slots) == 0 {
+		return fmt.Errorf("node %d is not part of the tree", node.GetId())
+	}
+
+	value := reflect.ValueOf(replacement)
+	for _, s := range slots {
+		if !value.Type().AssignableTo(s.value.Type()) {
+			return fmt.Errorf("node %d of type %T cannot be replaced by %T", node.GetId(), node, replacement)
+		}
+	}
+
+	existing := t.ids()
+	src := node.GetSrc()
+	src.Length = 0
+	t.adopt(replacement, slots[0].owner(), src, existing)
+
+	for _, s := range slots {
+		s.value.Set(value)
+		t.markRewritten(s.owners...)
+	}
+
+	return nil
+}
+
+// InsertBefore inserts the nodes into every list of the tree holding the anchor node, right before it.
+func (t *Tree) InsertBefore(anchor Node[NodeType], nodes ...Node[NodeType]) error {
+	if anchor == nil {
+		return fmt.Errorf("anchor node must be provided")
+	}
+
+	src := anchor.GetSrc()
+	src.Length = 0
+	return t.insert(anchor, 0, src, nodes)
+}
+
+// InsertAfter inserts the nodes into every list of the tree holding the anchor node, right after it.
+func (t *Tree) InsertAfter(anchor Node[NodeType], nodes ...Node[NodeType]) error {
+	if anchor == nil {
+		return fmt.Errorf("anchor node must be provided")
+	}
+
+	src := anchor.GetSrc()
+	src.Start += src.Length
+	src.Length = 0
+	return t.insert(anchor, 1, src, nodes)
+}
+
+// InsertStatements inserts the statements into the body at the given index. Index equal to the
+// number of statements in the body appends the statements at the end of the body.
+func (t *Tree) InsertStatements(body *BodyNode, index int, statements ...Node[NodeType]) error {
+	if body == nil || index < 0 || index > len(body.Statements) {
+		return fmt.Errorf("statements cannot be inserted at index %d", index)
+	}
+
+	if index < len(body.Statements) {
+		return t.InsertBefore(body.Statements[index], statements...)
+	}
+
+	slots := t.slotsOf(body)
+	if len(slots) == 0 {
+		return fmt.Errorf("body %d is not part of the tree", body.GetId())
+	}
+
+	// Statements appended to the body are placed right before its closing brace.
+	src := body.GetSrc()
+	if src.Length > 0 {
+		src.Start += src.Length - 1
+	}
+	src.Length = 0
+
+	existing := t.ids()
+	for _, statement := range statements {
+		t.adopt(statement, body, src, existing)
+	}
+
+	body.Statements = append(body.Statements, statements...)
+	t.markRewritten(append(slots[0].owners, body)...)
+
+	return nil
+}
+
+// insert inserts the nodes into every list holding the anchor node, at the offset from the anchor.
+func (t *Tree) insert(anchor Node[NodeType], offset int, src SrcNode, nodes []Node[NodeType]) error {
+	slots := make([]slot, 0)
+	for _, s := range t.slotsOf(anchor) {
+		if s.list.IsValid() {
+			slots = append(slots, s)
+		}
+	}
+
+	if len(slots) == 0 {
+		return fmt.Errorf("node %d is not an element of a list", anchor.GetId())
+	}
+
+	values := make([]reflect.Value, 0, len(nodes))
+	for _, node := range nodes {
+		if node == nil {
+			return fmt.Errorf("nil node cannot be inserted")
+		}
+
+		value := reflect.ValueOf(node)
+		for _, s := range slots {
+			if !value.Type().AssignableTo(s.list.Type().Elem()) {
+				return fmt.Errorf("node of type %T cannot be inserted next to node %d", node, anchor.GetId())
+			}
+		}
+		values = append(values, value)
+	}
+
+	existing := t.ids()
+	for _, node := range nodes {
+		t.adopt(node, slots[0].owner(), src, existing)
+	}
+
+	for _, s := range slots {
+		at := s.index + offset
+		list := reflect.MakeSlice(s.list.Type(), 0, s.list.Len()+len(values))
+		list = reflect.AppendSlice(list, s.list.Slice(0, at))
+		list = reflect.Append(list, values...)
+		list = reflect.AppendSlice(list, s.list.Slice(at, s.list.Len()))
+		s.list.Set(list)
+		t.markRewritten(s.owners...)
+	}
+
+	return nil
+}
+
+// Remove removes the node from every list of the tree holding it. Fields holding the node outside
+// of lists are cleared.
+func (t *Tree) Remove(node Node[NodeType]) error {
+	slots := t.slotsOf(node)
+
+	listed := false
+	for _, s := range slots {
+		listed = listed || s.list.IsValid()
+	}
+
+	if !listed {
+		return fmt.Errorf("node %d is not an element of a list", node.GetId())
+	}
+
+	// Slots are removed from the back, so indexes of the slots sharing the same list stay valid.
+	for i := len(slots) - 1; i >= 0; i-- {
+		s := slots[i]
+		if s.list.IsValid() {
+			list := reflect.MakeSlice(s.list.Type(), 0, s.list.Len()-1)
+			list = reflect.AppendSlice(list, s.list.Slice(0, s.index))
+			list = reflect.AppendSlice(list, s.list.Slice(s.index+1, s.list.Len()))
+			s.list.Set(list)
+		} else {
+			s.value.Set(reflect.Zero(s.value.Type()))
+		}
+		t.markRewritten(s.owners...)
+	}
+
+	return nil
+}
+
+// Rename renames the declaration with the given ID and updates all of the references to it.
+// It returns the number of updated references.
+// Modifier invocations do not reference their modifier definitions, so they are matched by name.
+func (t *Tree) Rename(id int64, name string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("declaration cannot be renamed to an empty name")
+	}
+
+	var declaration reflect.Value
+	var owners []identifiable
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if node, ok := v.Interface().(identifiable); ok && node.GetId() == id && !declaration.IsValid() {
+			declaration, owners = v, s.owners
+		}
+	})
+
+	if !declaration.IsValid() {
+		return 0, fmt.Errorf("declaration %d is not part of the tree", id)
+	}
+
+	// Local variable declaration statements are referenced by their own ID and declare their
+	// variables within.
+	target := declaration
+	if variable, ok := declaration.Interface().(*VariableDeclaration); ok {
+		if len(variable.Declarations) != 1 || variable.Declarations[0] == nil {
+			return 0, fmt.Errorf("variable declaration %d does not declare exactly one variable", id)
+		}
+		target = reflect.ValueOf(variable.Declarations[0])
+	}
+
+	field := target.Elem().FieldByName("Name")
+	if !field.IsValid() || field.Kind() != reflect.String || field.String() == "" {
+		return 0, fmt.Errorf("node %d of type %s is not a named declaration", id, target.Type())
+	}
+
+	previous := field.String()
+	field.SetString(name)
+	t.markRewritten(append(owners, declaration.Interface().(identifiable))...)
+
+	// Builder keeps copies of declarations among global nodes, with IDs of their own. These are
+	// recognised by their location and renamed together with the declaration.
+	ids := map[int64]bool{id: true}
+	src := declaration.Interface().(Node[NodeType]).GetSrc()
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		twin, ok := v.Interface().(Node[NodeType])
+		if !ok || v.Type() != declaration.Type() || v.Pointer() == declaration.Pointer() ||
+			twin.GetSrc().GetStart() != src.GetStart() || twin.GetSrc().GetLength() != src.GetLength() ||
+			twin.GetSrc().GetFileIndex() != src.GetFileIndex() {
+			return
+		}
+
+		if f := v.Elem().FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String && f.String() == previous {
+			f.SetString(name)
+			ids[twin.GetId()] = true
+			t.markRewritten(append(s.owners, twin)...)
+		}
+	})
+
+	// Contracts are referenced through their source units as well.
+	for _, unit := range t.astRoot.GetSourceUnits() {
+		if unit.GetContract() != nil && unit.GetContract().GetId() == id {
+			ids[unit.GetId()] = true
+			if unit.Name == previous {
+				unit.Name = name
+			}
+		}
+	}
+
+	// Modifier invocations are matched by name, as they do not reference their definition, but only
+	// within the declaring contract and the contracts inheriting from it.
+	var inheriting map[int64]bool
+	if _, isModifier := declaration.Interface().(*ModifierDefinition); isModifier {
+		if contract := enclosingContract(owners); contract != nil {
+			inheriting = t.inheritedBy(contract.GetId())
+		}
+	}
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(previous) + `\b`)
+
+	count := 0
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if v.Pointer() == declaration.Pointer() {
+			return
+		}
+
+		value := v.Elem()
+		referenced := false
+		for _, fieldName := range []string{"ReferencedDeclaration", "ContractReferencedDeclaration"} {
+			if f := value.FieldByName(fieldName); f.IsValid() && f.Kind() == reflect.Int64 && ids[f.Int()] {
+				referenced = true
+			}
+		}
+
+		if invocation, ok := v.Interface().(*ModifierInvocation); ok && inheriting != nil && invocation.Name == previous {
+			if contract := enclosingContract(s.owners); contract != nil && inheriting[contract.GetId()] {
+				referenced = true
+			}
+		}
+
+		if !referenced {
+			return
+		}
+
+		updated := false
+		for _, fieldName := range []string{"Name", "MemberName", "Text"} {
+			if f := value.FieldByName(fieldName); f.IsValid() && f.Kind() == reflect.String && pattern.MatchString(f.String()) {
+				f.SetString(pattern.ReplaceAllString(f.String(), name))
+				updated = true
+			}
+		}
+
+		if invocation, ok := v.Interface().(*ModifierInvocation); ok && invocation.ModifierName != nil {
+			invocation.ModifierName.Name = invocation.Name
+		}
+
+		if updated {
+			count++
+			if node, ok := v.Interface().(identifiable); ok {
+				t.markRewritten(append(s.owners, node)...)
+			}
+		}
+	})
+
+	return count, nil
+}
+
+// enclosingContract returns the innermost contract, library or interface among the owners of a slot.
+func enclosingContract(owners []identifiable) identifiable {
+	for i := len(owners) - 1; i >= 0; i-- {
+		switch owners[i].(type) {
+		case *Contract, *Library, *Interface:
+			return owners[i]
+		}
+	}
+	return nil
+}
+
+// inheritedBy returns the IDs of the contract and of every contract inheriting from it, directly or
+// through other bases. Linearized bases of parsed contracts hold the IDs of the source units of their
+// direct bases, so these are mapped to the contracts the source units declare.
+func (t *Tree) inheritedBy(id int64) map[int64]bool {
+	units := make(map[int64]int64)
+	for _, unit := range t.astRoot.GetSourceUnits() {
+		if unit.GetContract() != nil {
+			units[unit.GetId()] = unit.GetContract().GetId()
+		}
+	}
+
+	bases := make(map[int64][]int64)
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if contract, ok := v.Interface().(interface {
+			GetId() int64
+			GetLinearizedBaseContracts() []int64
+		}); ok {
+			bases[contract.GetId()] = contract.GetLinearizedBaseContracts()
+		}
+	})
+
+	toReturn := map[int64]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for contractId, baseIds := range bases {
+			if toReturn[contractId] {
+				continue
+			}
+
+			for _, baseId := range baseIds {
+				if unitContractId, ok := units[baseId]; ok {
+					baseId = unitContractId
+				}
+
+				if toReturn[baseId] {
+					toReturn[contractId] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// AddModifier appends an invocation of the modifier with the given name to the function or
+// constructor. The modifier must be defined within the tree.
+func (t *Tree) AddModifier(fn Node[NodeType], name string, arguments ...Node[NodeType]) (*ModifierInvocation, error) {
+	var modifiers *[]*ModifierInvocation
+	switch fnCtx := fn.(type) {
+	case *Function:
+		modifiers = &fnCtx.Modifiers
+	case *Constructor:
+		modifiers = &fnCtx.Modifiers
+	default:
+		return nil, fmt.Errorf("modifiers cannot be added to node of type %T", fn)
+	}
+
+	slots := t.slotsOf(fn)
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("node %d is not part of the tree", fn.GetId())
+	}
+
+	var definition *ModifierDefinition
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if modifier, ok := v.Interface().(*ModifierDefinition); ok && modifier.GetName() == name && definition == nil {
+			definition = modifier
+		}
+	})
+
+	if definition == nil {
+		return nil, fmt.Errorf("modifier %s is not defined", name)
+	}
+
+	src := fn.GetSrc()
+	src.Length = 0
+
+	invocation := NewModifierInvocation(t.ASTBuilder)
+	invocation.Name = name
+	invocation.ModifierName = &ModifierName{
+		Id:       t.GetNextID(),
+		Name:     name,
+		NodeType: ast_pb.NodeType_IDENTIFIER,
+	}
+
+	existing := t.ids()
+	for _, argument := range arguments {
+		invocation.Arguments = append(invocation.Arguments, argument)
+		invocation.ArgumentTypes = append(invocation.ArgumentTypes, argument.GetTypeDescription())
+	}
+	t.adopt(invocation, fn, src, existing)
+
+	*modifiers = append(*modifiers, invocation)
+	t.markRewritten(append(slots[0].owners, fn)...)
+
+	return invocation, nil
+}
+
+// Transform visits all nodes of the given type and replaces the ones for which transform returns
+// a different node. It returns the number of replaced nodes.
+func (t *Tree) Transform(nodeType ast_pb.NodeType, transform func(node Node[NodeType]) (Node[NodeType], error)) (int, error) {
+	nodes := make([]Node[NodeType], 0)
+
+	visitor := &NodeVisitor{}
+	visitor.RegisterTypeVisit(nodeType, func(node Node[NodeType]) (bool, error) {
+		nodes = append(nodes, node)
+		return true, nil
+	})
+
+	// Tree is changed only after the walk, so the walk does not visit the replacements.
+	if err := t.Walk(visitor); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, node := range nodes {
+		replacement, err := transform(node)
+		if err != nil {
+			return count, err
+		}
+
+		if replacement == nil || replacement == node {
+			continue
+		}
+
+		if err := t.Replace(node, replacement); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Clone returns a deep copy of the node with fresh IDs assigned to it and all of its descendants.
+// Type descriptions are shared with the original node.
+func (t *Tree) Clone(node Node[NodeType]) Node[NodeType] {
+	if node == nil {
+		return nil
+	}
+
+	clone := cloneValue(reflect.ValueOf(node), make(map[uintptr]reflect.Value)).Interface().(Node[NodeType])
+
+	walkSlots(clone, func(s slot, v reflect.Value) {
+		if field := v.Elem().FieldByName("Id"); field.IsValid() && field.Kind() == reflect.Int64 && field.CanSet() {
+			field.SetInt(t.GetNextID())
+		}
+	})
+
+	return clone
+}
+
+// cloneValue deep copies the value, sharing the builder and type descriptions.
+func cloneValue(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || rewriteSkippedTypes[v.Type()] || v.Elem().Kind() != reflect.Struct {
+			return v
+		}
+
+		if clone, ok := seen[v.Pointer()]; ok {
+			return clone
+		}
+
+		clone := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = clone
+		clone.Elem().Set(cloneValue(v.Elem(), seen))
+		return clone
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(cloneValue(v.Elem(), seen))
+		return clone
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(cloneValue(v.Index(i), seen))
+		}
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				clone.Field(i).Set(cloneValue(v.Field(i), seen))
+			}
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+// adopt prepares the node, and all of its descendants, to become part of the tree under the owner.
+// Nodes without an ID or with an ID already in use get a fresh one, all of them are placed at the
+// source location and linked to their parents. Identifiers are queued for reference resolution.
+func (t *Tree) adopt(node any, owner identifiable, src SrcNode, existing map[int64]bool) {
+	ownerId := int64(0)
+	if owner != nil {
+		ownerId = owner.GetId()
+	}
+
+	start := []identifiable{}
+	if owner != nil {
+		start = append(start, owner)
+	}
+
+	visit := func(s slot, v reflect.Value) {
+		value := v.Elem()
+
+		if field := value.FieldByName("Id"); field.IsValid() && field.Kind() == reflect.Int64 && field.CanSet() {
+			if field.Int() == 0 || existing[field.Int()] {
+				field.SetInt(t.GetNextID())
+			}
+			existing[field.Int()] = true
+		}
+
+		if field := value.FieldByName("Src"); field.IsValid() && field.Type() == srcNodeType && field.CanSet() {
+			location := src
+			location.End = location.Start
+			location.ParentIndex = ownerId
+			if parent := s.owner(); parent != nil && len(s.owners) > len(start) {
+				location.ParentIndex = parent.GetId()
+			}
+			field.Set(reflect.ValueOf(location))
+		}
+
+		switch nodeCtx := v.Interface().(type) {
+		case *PrimaryExpression:
+			if nodeCtx.NodeType == ast_pb.NodeType_IDENTIFIER && nodeCtx.Name != "" && nodeCtx.TypeDescription == nil {
+				t.unresolved = append(t.unresolved, UnprocessedNode{Id: nodeCtx.Id, Name: nodeCtx.Name, Node: nodeCtx})
+			}
+		case *TypeName:
+			if nodeCtx.PathNode != nil && nodeCtx.ReferencedDeclaration == 0 {
+				t.unresolved = append(t.unresolved, UnprocessedNode{Id: nodeCtx.Id, Name: nodeCtx.Name, Node: nodeCtx})
+			}
+		}
+	}
+
+	w := &treeWalker{visited: make(map[uintptr]bool), visit: visit}
+	w.value(reflect.ValueOf(node), start, reflect.Value{}, -1)
+}
+
+// reindex collects declarations of the rewritten tree for the resolver, as builder state used
+// while resolving references is garbage collected once references are resolved. Nodes added to the
+// tree are queued for resolution.
+func (t *Tree) reindex() {
+	b := t.ASTBuilder
+
+	b.currentStateVariables = make([]*StateVariableDeclaration, 0)
+	b.currentUserDefinedVariables = make([]*UserDefinedValueTypeDefinition, 0)
+	b.currentEvents = make([]Node[NodeType], 0)
+	b.currentEnums = make([]Node[NodeType], 0)
+	b.currentStructs = make([]Node[NodeType], 0)
+	b.currentErrors = make([]Node[NodeType], 0)
+	b.currentModifiers = make([]Node[NodeType], 0)
+	b.currentFunctions = make([]Node[NodeType], 0)
+	b.currentVariables = make([]Node[NodeType], 0)
+	b.currentImports = make([]Node[NodeType], 0)
+	b.globalDefinitions = append(make([]Node[NodeType], 0), t.astRoot.GetGlobalNodes()...)
+
+	seen := make(map[uintptr]bool)
+	walkSlots(t.astRoot, func(s slot, v reflect.Value) {
+		if seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+
+		switch nodeCtx := v.Interface().(type) {
+		case *StateVariableDeclaration:
+			b.currentStateVariables = append(b.currentStateVariables, nodeCtx)
+		case *UserDefinedValueTypeDefinition:
+			b.currentUserDefinedVariables = append(b.currentUserDefinedVariables, nodeCtx)
+		case *EventDefinition:
+			b.currentEvents = append(b.currentEvents, nodeCtx)
+		case *EnumDefinition:
+			b.currentEnums = append(b.currentEnums, nodeCtx)
+		case *StructDefinition:
+			b.currentStructs = append(b.currentStructs, nodeCtx)
+		case *ErrorDefinition:
+			b.currentErrors = append(b.currentErrors, nodeCtx)
+		case *ModifierDefinition:
+			b.currentModifiers = append(b.currentModifiers, nodeCtx)
+		case *Function:
+			b.currentFunctions = append(b.currentFunctions, nodeCtx)
+		case *Constructor:
+			b.currentFunctions = append(b.currentFunctions, nodeCtx)
+		case *VariableDeclaration:
+			b.currentVariables = append(b.currentVariables, nodeCtx)
+		case *Parameter:
+			b.currentVariables = append(b.currentVariables, nodeCtx)
+		case *Import:
+			b.currentImports = append(b.currentImports, nodeCtx)
+		}
+	})
+
+	for _, node := range t.unresolved {
+		b.resolver.UnprocessedNodes[node.Id] = node
+	}
+	t.unresolved = nil
+}
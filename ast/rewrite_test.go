@@ -0,0 +1,270 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+const rewriteContract = `pragma solidity ^0.8.0;
+
+contract Counter {
+    address public owner;
+    uint256 public count;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner);
+        _;
+    }
+
+    function bump(uint256 by) public returns (uint256) {
+        count += by;
+        return count + 1;
+    }
+}
+`
+
+// findNode returns the first node of the tree matching the predicate.
+func findNode[T Node[NodeType]](t *testing.T, tree *Tree, match func(node T) bool) T {
+	var found T
+	var ok bool
+
+	walkSlots(tree.GetRoot(), func(s slot, v reflect.Value) {
+		if node, isType := v.Interface().(T); isType && !ok && match(node) {
+			found, ok = node, true
+		}
+	})
+
+	require.True(t, ok, "node not found")
+	return found
+}
+
+func TestTreeRewrite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rewrite  func(t *testing.T, tree *Tree)
+		expected string
+	}{
+		{
+			name: "Replace Expression",
+			rewrite: func(t *testing.T, tree *Tree) {
+				literal := findNode(t, tree, func(node *PrimaryExpression) bool { return node.Value == "1" })
+				replacement := &PrimaryExpression{
+					NodeType: ast_pb.NodeType_LITERAL,
+					Kind:     ast_pb.NodeType_NUMBER,
+					Value:    "2",
+				}
+				require.NoError(t, tree.Replace(literal, replacement))
+
+				assert.NotZero(t, replacement.GetId())
+				assert.Equal(t, tree.GetParent(replacement).GetId(), replacement.GetSrc().GetParentIndex())
+				assert.Nil(t, tree.GetById(literal.GetId()))
+			},
+			expected: `    function bump(uint256 by) public returns (uint256) {
+        count += by;
+        return count + 2;
+    }`,
+		},
+		{
+			name: "Insert And Remove Statements",
+			rewrite: func(t *testing.T, tree *Tree) {
+				fn := findNode(t, tree, func(node *Function) bool { return node.GetName() == "bump" })
+				statements := fn.GetBody().GetStatements()
+				require.Len(t, statements, 2)
+
+				clone := tree.Clone(statements[0])
+				assert.NotEqual(t, statements[0].GetId(), clone.GetId())
+
+				require.NoError(t, tree.InsertAfter(statements[1], tree.Clone(statements[0])))
+				require.NoError(t, tree.InsertBefore(statements[0], clone))
+				require.NoError(t, tree.Remove(statements[1]))
+				require.NoError(t, tree.InsertStatements(fn.GetBody(), 3, tree.Clone(statements[1])))
+				require.Len(t, fn.GetBody().GetStatements(), 4)
+			},
+			expected: `    function bump(uint256 by) public returns (uint256) {
+        count += by;
+        count += by;
+        count += by;
+        return count + 1;
+    }`,
+		},
+		{
+			name: "Rename Declaration",
+			rewrite: func(t *testing.T, tree *Tree) {
+				count := findNode(t, tree, func(node *StateVariableDeclaration) bool { return node.GetName() == "count" })
+				references, err := tree.Rename(count.GetId(), "total")
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, references, 2)
+			},
+			expected: `    function bump(uint256 by) public returns (uint256) {
+        total += by;
+        return total + 1;
+    }`,
+		},
+		{
+			name: "Add Modifier",
+			rewrite: func(t *testing.T, tree *Tree) {
+				fn := findNode(t, tree, func(node *Function) bool { return node.GetName() == "bump" })
+				invocation, err := tree.AddModifier(fn, "onlyOwner")
+				require.NoError(t, err)
+				assert.Equal(t, fn.GetId(), invocation.GetSrc().GetParentIndex())
+
+				_, err = tree.AddModifier(fn, "missing")
+				require.Error(t, err)
+			},
+			expected: `    function bump(uint256 by) public onlyOwner returns (uint256) {
+        count += by;
+        return count + 1;
+    }`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			astBuilder := buildPrinterAst(t, rewriteContract)
+			tree := astBuilder.GetTree()
+
+			testCase.rewrite(t, tree)
+
+			code, err := astBuilder.ToSource(nil)
+			require.NoError(t, err)
+			assert.Contains(t, code, testCase.expected)
+			assert.Contains(t, code, "    modifier onlyOwner {\n        require(msg.sender == owner);")
+
+			// Rewritten code must parse again.
+			buildPrinterAst(t, code)
+		})
+	}
+}
+
+func TestTreeRewriteResolveReferences(t *testing.T) {
+	astBuilder := buildPrinterAst(t, rewriteContract)
+	tree := astBuilder.GetTree()
+
+	count := findNode(t, tree, func(node *StateVariableDeclaration) bool { return node.GetName() == "count" })
+	literal := findNode(t, tree, func(node *PrimaryExpression) bool { return node.Value == "1" })
+
+	identifier := &PrimaryExpression{
+		ASTBuilder: astBuilder,
+		NodeType:   ast_pb.NodeType_IDENTIFIER,
+		Name:       "count",
+	}
+	require.NoError(t, tree.Replace(literal, identifier))
+	assert.Zero(t, identifier.ReferencedDeclaration)
+
+	assert.Empty(t, astBuilder.ResolveReferences())
+	assert.Equal(t, count.GetId(), identifier.ReferencedDeclaration)
+	assert.Equal(t, count.GetTypeDescription(), identifier.GetTypeDescription())
+
+	code, err := astBuilder.ToSource(nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, "return count + count;")
+}
+
+func TestTreeRewriteIfElse(t *testing.T) {
+	astBuilder := buildPrinterAst(t, `pragma solidity ^0.8.0;
+
+contract Branches {
+    uint256 public x;
+
+    function pick(bool flag, uint256 value) public returns (uint256) {
+        if (flag) {
+            x = 1;
+        } else if (value > x) {
+            x = value;
+        } else {
+            x = 2;
+            return 7;
+        }
+        return x;
+    }
+}
+`)
+	tree := astBuilder.GetTree()
+
+	x := findNode(t, tree, func(node *StateVariableDeclaration) bool { return node.GetName() == "x" })
+	references, err := tree.Rename(x.GetId(), "y")
+	require.NoError(t, err)
+	assert.Equal(t, 5, references)
+
+	code, err := astBuilder.ToSource(nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, `        if (flag) {
+            y = 1;
+        } else if (value > y) {
+            y = value;
+        } else {
+            y = 2;
+            return 7;
+        }
+        return y;`)
+	assert.NotRegexp(t, `\bx\b`, code)
+
+	buildPrinterAst(t, code)
+}
+
+func TestTreeRenameModifierScope(t *testing.T) {
+	astBuilder := buildPrinterAst(t, `pragma solidity ^0.8.0;
+
+contract A {
+    address public owner;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner);
+        _;
+    }
+
+    function a() public onlyOwner {}
+}
+
+contract B {
+    address public admin;
+
+    modifier onlyOwner() {
+        require(msg.sender == admin);
+        _;
+    }
+
+    function b() public onlyOwner {}
+}
+
+contract C is A {
+    function c() public onlyOwner {}
+}
+`)
+	tree := astBuilder.GetTree()
+
+	var modifier *ModifierDefinition
+	walkSlots(tree.GetRoot(), func(s slot, v reflect.Value) {
+		if node, ok := v.Interface().(*ModifierDefinition); ok && modifier == nil {
+			if contract, ok := enclosingContract(s.owners).(*Contract); ok && contract.GetName() == "A" {
+				modifier = node
+			}
+		}
+	})
+	require.NotNil(t, modifier)
+
+	references, err := tree.Rename(modifier.GetId(), "onlyAdmin")
+	require.NoError(t, err)
+	assert.Equal(t, 2, references)
+
+	code, err := astBuilder.ToSource(nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, "modifier onlyAdmin {")
+	assert.Contains(t, code, "function a() public onlyAdmin {}")
+	assert.Contains(t, code, "function c() public onlyAdmin {}")
+	assert.Contains(t, code, "modifier onlyOwner {")
+	assert.Contains(t, code, "function b() public onlyOwner {}")
+
+	walkSlots(tree.GetRoot(), func(s slot, v reflect.Value) {
+		if invocation, ok := v.Interface().(*ModifierInvocation); ok {
+			if contract, ok := enclosingContract(s.owners).(*Contract); ok && contract.GetName() == "B" {
+				assert.Equal(t, "onlyOwner", invocation.Name)
+				assert.Equal(t, "onlyOwner", invocation.ModifierName.Name)
+			}
+		}
+	})
+}
@@ -26,6 +26,9 @@ type RootNode struct {
 
 	// Comments is the list of comments.
 	Comments []*Comment `json:"comments"`
+
+	// SourceFiles is the list of source files the AST was built from, indexed by SrcNode.FileIndex.
+	SourceFiles []*SourceFile `json:"sourceFiles"`
 }
 
 // NewRootNode creates a new RootNode with the provided ASTBuilder, entry source unit, source units, and comments.
@@ -37,6 +40,7 @@ func NewRootNode(builder *ASTBuilder, entrySourceUnit int64, sourceUnits []*Sour
 		Comments:        comments,
 		SourceUnits:     sourceUnits,
 		Globals:         make([]Node[NodeType], 0),
+		SourceFiles:     builder.GetSourceFiles(),
 	}
 }
 
@@ -116,6 +120,11 @@ func (r *RootNode) SetEntrySourceUnit(entrySourceUnit int64) {
 	r.EntrySourceUnit = entrySourceUnit
 }
 
+// GetSourceFiles returns the source files the AST was built from.
+func (r *RootNode) GetSourceFiles() []*SourceFile {
+	return r.SourceFiles
+}
+
 // GetComments returns the comments of the root node.
 func (r *RootNode) GetComments() []*Comment {
 	return r.Comments
@@ -199,6 +208,12 @@ func (r *RootNode) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if sourceFiles, ok := tempMap["sourceFiles"]; ok {
+		if err := json.Unmarshal(sourceFiles, &r.SourceFiles); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,573 @@
+package ast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"go.uber.org/zap"
+)
+
+// solcIdRegex matches node identifiers within the solc JSON, used to find the highest identifier in use.
+var solcIdRegex = regexp.MustCompile(`"id"\s*:\s*(\d+)`)
+
+// solcNode is a single node of the solc compact JSON AST. Fields are decoded lazily as the
+// same key can hold different types depending on the node type, e.g. `value` of a Literal
+// is a string while `value` of a VariableDeclaration is an expression.
+type solcNode map[string]json.RawMessage
+
+// solcSource is a single source file taken from the solc output together with its AST.
+type solcSource struct {
+	id      int64    // Source id as assigned by solc, same as the file index in src locations.
+	path    string   // Absolute path of the source file.
+	content string   // Content of the source file, if known.
+	ast     solcNode // SourceUnit node of the source file.
+}
+
+// solcFile keeps what is needed to translate solc byte offsets into solgo locations.
+type solcFile struct {
+	*SourceFile
+	content    []byte  // Content of the source file, empty when not known.
+	lineStarts []int   // Byte offsets at which each line of the content starts.
+	runes      []int32 // Rune offset for each byte offset, nil when content is plain ASCII.
+}
+
+// ImportFromSolcJSON imports the AST produced by the solc compiler, converting it into solgo nodes.
+// Accepted inputs are the `--ast-compact-json` output (including the `======= file =======` headers
+// printed by the command line compiler), standard JSON output, Hardhat build info files and Foundry
+// artifacts. Node ids, referenced declarations and type descriptions are taken over from the compiler.
+//
+// Line and column information requires the content of the sources. It is taken from the Hardhat build
+// info input or from the sources the builder was created with, matched by path.
+func (b *ASTBuilder) ImportFromSolcJSON(ctx context.Context, data []byte) (*RootNode, error) {
+	sources, err := decodeSolcSources(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, errors.New("no solc source units found in provided JSON")
+	}
+
+	// Nodes solc does not assign identifiers to, such as yul nodes, get one above the highest solc id.
+	var maxId int64
+	for _, match := range solcIdRegex.FindAllSubmatch(data, -1) {
+		if id, err := strconv.ParseInt(string(match[1]), 10, 64); err == nil && id > maxId {
+			maxId = id
+		}
+	}
+	b.nextID = maxId + 1
+
+	for _, source := range sources {
+		if source.content == "" && b.sources != nil {
+			source.content = b.solcSourceContent(source.path)
+		}
+	}
+
+	importer := &solcImporter{ASTBuilder: b, files: b.solcSourceFiles(sources)}
+	toReturn := importer.importSources(sources)
+
+	if b.tree == nil {
+		b.tree = NewTree(b)
+	}
+
+	b.sourceUnits = toReturn.SourceUnits
+	b.tree.SetRoot(toReturn)
+
+	// Documentation is taken over from the compiler, only the inherited one has to be resolved.
+	b.attachNatSpec()
+
+	return toReturn, nil
+}
+
+// decodeSolcSources extracts source files and their ASTs from the provided solc output.
+func decodeSolcSources(data []byte) ([]*solcSource, error) {
+	// The command line compiler prints a header in front of each source unit so these are dropped
+	// and remaining JSON objects are decoded one after another.
+	lines := strings.Split(string(data), "\n")
+	filtered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "=======") || strings.HasPrefix(trimmed, "JSON AST") {
+			continue
+		}
+		filtered = append(filtered, line)
+	}
+
+	sources := make(map[int64]*solcSource)
+	add := func(ast solcNode, path string, id int64, content string) {
+		if ast == nil || ast.nodeType() != "SourceUnit" {
+			return
+		}
+
+		if parts := strings.Split(ast.str("src"), ":"); len(parts) == 3 {
+			if index, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+				id = index
+			}
+		}
+
+		if absolutePath := ast.str("absolutePath"); absolutePath != "" {
+			path = absolutePath
+		}
+
+		if _, exists := sources[id]; !exists {
+			sources[id] = &solcSource{id: id, path: path, content: content, ast: ast}
+		}
+	}
+
+	addSources := func(output solcNode, input solcNode) error {
+		var entries map[string]solcNode
+		if err := json.Unmarshal(output["sources"], &entries); err != nil {
+			return err
+		}
+
+		contents := make(map[string]struct {
+			Content string `json:"content"`
+		})
+		if input != nil && input.has("sources") {
+			if err := json.Unmarshal(input["sources"], &contents); err != nil {
+				return err
+			}
+		}
+
+		for path, entry := range entries {
+			add(entry.node("ast"), path, entry.int("id"), contents[path].Content)
+		}
+
+		return nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(strings.Join(filtered, "\n")))
+	for {
+		var object solcNode
+		if err := decoder.Decode(&object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch {
+		case object.nodeType() == "SourceUnit":
+			add(object, "", int64(len(sources)), "")
+		case object.has("output"):
+			if err := addSources(object.node("output"), object.node("input")); err != nil {
+				return nil, err
+			}
+		case object.has("sources"):
+			if err := addSources(object, nil); err != nil {
+				return nil, err
+			}
+		case object.has("ast"):
+			add(object.node("ast"), "", int64(len(sources)), "")
+		}
+	}
+
+	toReturn := make([]*solcSource, 0, len(sources))
+	for _, source := range sources {
+		toReturn = append(toReturn, source)
+	}
+
+	sort.Slice(toReturn, func(i, j int) bool {
+		return toReturn[i].id < toReturn[j].id
+	})
+
+	return toReturn, nil
+}
+
+// solcSourceContent returns the content of the source unit matching the provided path, if any.
+func (b *ASTBuilder) solcSourceContent(path string) string {
+	for _, unit := range b.sources.SourceUnits {
+		if unit.GetPath() == path {
+			return unit.GetContent()
+		}
+	}
+
+	for _, unit := range b.sources.SourceUnits {
+		if filepath.Base(unit.GetPath()) == filepath.Base(path) {
+			return unit.GetContent()
+		}
+	}
+
+	return ""
+}
+
+// solcSourceFiles lays out the source files the same way parsed sources are combined, so that
+// node locations can be resolved back to the files. Files are indexed by the solc source id.
+func (b *ASTBuilder) solcSourceFiles(sources []*solcSource) map[int64]*solcFile {
+	files := make(map[int64]*solcFile, len(sources))
+	b.sourceFiles = make([]*SourceFile, 0, len(sources))
+
+	var start, startLine int64 = 0, 1
+	separatorLength := int64(len(combinedSourceSeparator))
+	separatorLines := int64(strings.Count(combinedSourceSeparator, "\n"))
+
+	for _, source := range sources {
+		// Missing source ids are filled with empty files, so that files can be looked up by index.
+		for int64(len(b.sourceFiles)) < source.id {
+			b.sourceFiles = append(b.sourceFiles, &SourceFile{
+				Index: int64(len(b.sourceFiles)), Start: start, StartLine: startLine,
+			})
+			start += separatorLength
+			startLine += separatorLines
+		}
+
+		file := &solcFile{
+			SourceFile: &SourceFile{
+				Index:     source.id,
+				Name:      strings.TrimSuffix(filepath.Base(source.path), filepath.Ext(source.path)),
+				Path:      source.path,
+				Start:     start,
+				StartLine: startLine,
+				lines:     strings.Split(source.content, "\n"),
+			},
+			content:    []byte(source.content),
+			lineStarts: []int{0},
+		}
+
+		for i, c := range file.content {
+			if c == '\n' {
+				file.lineStarts = append(file.lineStarts, i+1)
+			}
+		}
+
+		if utf8.RuneCount(file.content) != len(file.content) {
+			file.runes = make([]int32, len(file.content)+1)
+			for idx, c := range file.content {
+				file.runes[idx+1] = file.runes[idx]
+				if utf8.RuneStart(c) {
+					file.runes[idx+1]++
+				}
+			}
+		}
+
+		if len(file.content) > 0 {
+			file.Length = int64(utf8.RuneCount(file.content))
+		} else if src := parseSolcSrc(source.ast.str("src")); src != nil {
+			file.Length = src[0] + src[1]
+		}
+
+		files[source.id] = file
+		b.sourceFiles = append(b.sourceFiles, file.SourceFile)
+
+		start += file.Length + separatorLength
+		startLine += int64(strings.Count(source.content, "\n")) + separatorLines
+	}
+
+	return files
+}
+
+// parseSolcSrc parses the solc `start:length:fileIndex` location. It returns nil if the location is malformed.
+func parseSolcSrc(src string) []int64 {
+	parts := strings.Split(src, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	toReturn := make([]int64, 0, 3)
+	for _, part := range parts {
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil
+		}
+		toReturn = append(toReturn, value)
+	}
+
+	return toReturn
+}
+
+// runeOffset converts the byte offset within the file into a rune offset.
+func (f *solcFile) runeOffset(offset int64) int64 {
+	if offset < 0 {
+		return 0
+	}
+
+	if f.runes == nil {
+		return offset
+	}
+
+	if offset >= int64(len(f.runes)) {
+		return int64(f.runes[len(f.runes)-1]) + offset - int64(len(f.runes)-1)
+	}
+
+	return int64(f.runes[offset])
+}
+
+// nodeType returns the solc node type.
+func (n solcNode) nodeType() string {
+	return n.str("nodeType")
+}
+
+// has returns true if the node has the key set to a non null value.
+func (n solcNode) has(key string) bool {
+	raw, ok := n[key]
+	return ok && !bytes.Equal(raw, []byte("null"))
+}
+
+// str returns the string value under the key or an empty string.
+func (n solcNode) str(key string) string {
+	var toReturn string
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &toReturn)
+	}
+	return toReturn
+}
+
+// int returns the integer value under the key or zero.
+func (n solcNode) int(key string) int64 {
+	var toReturn int64
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &toReturn)
+	}
+	return toReturn
+}
+
+// bool returns the boolean value under the key or false.
+func (n solcNode) bool(key string) bool {
+	var toReturn bool
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &toReturn)
+	}
+	return toReturn
+}
+
+// ints returns the list of integers under the key.
+func (n solcNode) ints(key string) []int64 {
+	toReturn := make([]int64, 0)
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &toReturn)
+	}
+	return toReturn
+}
+
+// strs returns the list of strings under the key.
+func (n solcNode) strs(key string) []string {
+	toReturn := make([]string, 0)
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &toReturn)
+	}
+	return toReturn
+}
+
+// node returns the child node under the key or nil if it is not set or is not an object.
+func (n solcNode) node(key string) solcNode {
+	if !n.has(key) {
+		return nil
+	}
+
+	var toReturn solcNode
+	if err := json.Unmarshal(n[key], &toReturn); err != nil {
+		return nil
+	}
+	return toReturn
+}
+
+// nodes returns the list of child nodes under the key. Null entries, such as omitted tuple
+// components, are skipped.
+func (n solcNode) nodes(key string) []solcNode {
+	var all []solcNode
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &all)
+	}
+
+	toReturn := make([]solcNode, 0, len(all))
+	for _, node := range all {
+		if node != nil {
+			toReturn = append(toReturn, node)
+		}
+	}
+	return toReturn
+}
+
+// nulls returns the positions of the null entries in the list under the key, such as omitted tuple components.
+func (n solcNode) nulls(key string) []int {
+	var all []solcNode
+	if n.has(key) {
+		_ = json.Unmarshal(n[key], &all)
+	}
+
+	toReturn := make([]int, 0)
+	for position, node := range all {
+		if node == nil {
+			toReturn = append(toReturn, position)
+		}
+	}
+	return toReturn
+}
+
+// solcImporter converts solc AST nodes into solgo nodes.
+type solcImporter struct {
+	*ASTBuilder
+	files map[int64]*solcFile // Source files by solc source id.
+}
+
+// importSources converts all of the source units and returns the new root node.
+func (i *solcImporter) importSources(sources []*solcSource) *RootNode {
+	root := NewRootNode(i.ASTBuilder, 0, make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0), make([]*Comment, 0))
+	root.SourceFiles = i.sourceFiles
+
+	for _, source := range sources {
+		units, globals := i.sourceUnit(root, source)
+		root.SourceUnits = append(root.SourceUnits, units...)
+		root.Globals = append(root.Globals, globals...)
+	}
+
+	root.EntrySourceUnit = i.entrySourceUnit(root.SourceUnits)
+
+	return root
+}
+
+// entrySourceUnit returns the id of the entry source unit. Unless the entry is named in the sources, it is
+// the last contract that no other contract inherits from, falling back to the last source unit.
+func (i *solcImporter) entrySourceUnit(units []*SourceUnit[Node[ast_pb.SourceUnit]]) int64 {
+	if len(units) == 0 {
+		return 0
+	}
+
+	if i.sources != nil && i.sources.EntrySourceUnitName != "" {
+		for _, unit := range units {
+			if unit.GetName() == i.sources.EntrySourceUnitName {
+				return unit.GetId()
+			}
+		}
+	}
+
+	inherited := make(map[int64]bool)
+	for _, unit := range units {
+		for _, base := range unit.GetBaseContracts() {
+			inherited[base.GetBaseName().GetReferencedDeclaration()] = true
+		}
+	}
+
+	for idx := len(units) - 1; idx >= 0; idx-- {
+		unit := units[idx]
+		if unit.GetKind() == ast_pb.NodeType_KIND_CONTRACT && unit.GetContract() != nil && !inherited[unit.GetContract().GetId()] {
+			return unit.GetId()
+		}
+	}
+
+	return units[len(units)-1].GetId()
+}
+
+// src converts the solc location under the key into a source node.
+func (i *solcImporter) src(n solcNode, key string, parentId int64) SrcNode {
+	location := parseSolcSrc(n.str(key))
+	if location == nil {
+		return SrcNode{ParentIndex: parentId}
+	}
+
+	start, length, fileIndex := location[0], location[1], location[2]
+	toReturn := SrcNode{
+		Start:       start,
+		Length:      length,
+		ParentIndex: parentId,
+		FileIndex:   fileIndex,
+	}
+
+	file, ok := i.files[fileIndex]
+	if !ok {
+		toReturn.End = toReturn.Start + toReturn.Length - 1
+		return toReturn
+	}
+
+	toReturn.Start = file.Start + file.runeOffset(start)
+	toReturn.Length = file.runeOffset(start+length) - file.runeOffset(start)
+	toReturn.End = toReturn.Start + toReturn.Length - 1
+
+	if len(file.content) > 0 {
+		line := sort.Search(len(file.lineStarts), func(idx int) bool {
+			return int64(file.lineStarts[idx]) > start
+		}) - 1
+		if line < 0 {
+			line = 0
+		}
+
+		toReturn.Line = file.StartLine + int64(line)
+		toReturn.Column = file.runeOffset(start) - file.runeOffset(int64(file.lineStarts[line]))
+	}
+
+	return toReturn
+}
+
+// text returns the source code the node was parsed from or an empty string if sources are unknown.
+func (i *solcImporter) text(n solcNode) string {
+	location := parseSolcSrc(n.str("src"))
+	if location == nil {
+		return ""
+	}
+
+	file, ok := i.files[location[2]]
+	if !ok || location[0]+location[1] > int64(len(file.content)) {
+		return ""
+	}
+
+	return string(file.content[location[0] : location[0]+location[1]])
+}
+
+// typeDescription converts the solc type descriptions of the node.
+func (i *solcImporter) typeDescription(n solcNode) *TypeDescription {
+	descriptions := n.node("typeDescriptions")
+	if descriptions == nil {
+		return nil
+	}
+
+	return &TypeDescription{
+		TypeIdentifier: descriptions.str("typeIdentifier"),
+		TypeString:     descriptions.str("typeString"),
+	}
+}
+
+// typeDescriptions returns the type descriptions of the provided nodes.
+func typeDescriptionsOf(nodes []Node[NodeType]) []*TypeDescription {
+	toReturn := make([]*TypeDescription, 0, len(nodes))
+	for _, node := range nodes {
+		toReturn = append(toReturn, node.GetTypeDescription())
+	}
+	return toReturn
+}
+
+// solcVisibility converts the solc visibility.
+func solcVisibility(visibility string) ast_pb.Visibility {
+	if value, ok := ast_pb.Visibility_value[strings.ToUpper(visibility)]; ok {
+		return ast_pb.Visibility(value)
+	}
+	return ast_pb.Visibility_INTERNAL
+}
+
+// solcMutability converts the solc function state mutability or variable mutability.
+func solcMutability(mutability string) ast_pb.Mutability {
+	if mutability == "constant" {
+		return ast_pb.Mutability_MUTABLE
+	}
+
+	if value, ok := ast_pb.Mutability_value[strings.ToUpper(mutability)]; ok {
+		return ast_pb.Mutability(value)
+	}
+	return ast_pb.Mutability_M_DEFAULT
+}
+
+// solcStorageLocation converts the solc storage location.
+func solcStorageLocation(location string) ast_pb.StorageLocation {
+	if value, ok := ast_pb.StorageLocation_value[strings.ToUpper(location)]; ok {
+		return ast_pb.StorageLocation(value)
+	}
+	return ast_pb.StorageLocation_DEFAULT
+}
+
+// unsupported logs the solc node that cannot be represented in the solgo AST.
+func (i *solcImporter) unsupported(n solcNode) {
+	zap.L().Warn(
+		"solc node not supported while importing JSON",
+		zap.String("node_type", n.nodeType()),
+		zap.String("src", n.str("src")),
+	)
+}
@@ -0,0 +1,720 @@
+package ast
+
+import (
+	"fmt"
+	"github.com/goccy/go-json"
+	"sort"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// sourceUnit converts the solc source unit. As in the parser, every contract, interface and library
+// gets its own source unit holding the pragmas and imports of the file, while definitions placed
+// outside of contracts are returned as globals.
+func (i *solcImporter) sourceUnit(root *RootNode, source *solcSource) ([]*SourceUnit[Node[ast_pb.SourceUnit]], []Node[NodeType]) {
+	node := source.ast
+	units := make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0)
+	globals := make([]Node[NodeType], 0)
+
+	var symbols map[string][]int64
+	_ = json.Unmarshal(node["exportedSymbols"], &symbols)
+
+	exportedSymbols := make([]Symbol, 0, len(symbols))
+	for name, ids := range symbols {
+		for _, id := range ids {
+			exportedSymbols = append(exportedSymbols, NewSymbol(id, name, source.path))
+		}
+	}
+	sort.Slice(exportedSymbols, func(a, b int) bool {
+		return exportedSymbols[a].Id < exportedSymbols[b].Id
+	})
+
+	directives := make([]solcNode, 0)
+	for _, child := range node.nodes("nodes") {
+		switch child.nodeType() {
+		case "PragmaDirective", "ImportDirective":
+			directives = append(directives, child)
+		}
+	}
+
+	for _, child := range node.nodes("nodes") {
+		switch child.nodeType() {
+		case "PragmaDirective", "ImportDirective":
+			continue
+		case "ContractDefinition":
+			// First source unit of the file takes over the solc source unit id, so that imports
+			// referencing the file resolve to it.
+			unitId := node.int("id")
+			if len(units) > 0 {
+				unitId = i.GetNextID()
+			}
+
+			unit := &SourceUnit[Node[ast_pb.SourceUnit]]{
+				Id:              unitId,
+				Name:            child.str("name"),
+				License:         node.str("license"),
+				ExportedSymbols: exportedSymbols,
+				AbsolutePath:    source.path,
+				NodeType:        ast_pb.NodeType_SOURCE_UNIT,
+				Nodes:           make([]Node[NodeType], 0),
+				Src:             i.src(child, "src", root.GetId()),
+			}
+
+			for _, directive := range directives {
+				unit.Nodes = append(unit.Nodes, i.directive(unit, directive, len(units) > 0))
+			}
+
+			contract := i.contract(unit, child)
+			unit.Nodes = append(unit.Nodes, contract)
+			unit.Contract = contract
+			units = append(units, unit)
+		case "FunctionDefinition":
+			// Free functions are not part of the solgo AST.
+			continue
+		default:
+			if definition := i.definition(nil, node.int("id"), child); definition != nil {
+				globals = append(globals, definition)
+			}
+		}
+	}
+
+	return units, globals
+}
+
+// directive converts the pragma or import directive of the source unit. Directives repeated for
+// further source units of the same file get new ids.
+func (i *solcImporter) directive(unit *SourceUnit[Node[ast_pb.SourceUnit]], n solcNode, fresh bool) Node[NodeType] {
+	id := n.int("id")
+	if fresh {
+		id = i.GetNextID()
+	}
+
+	if n.nodeType() == "PragmaDirective" {
+		literals := make([]string, 0)
+		_ = json.Unmarshal(n["literals"], &literals)
+
+		text := i.text(n)
+		if text == "" && len(literals) > 0 {
+			text = "pragma " + literals[0] + " " + strings.Join(literals[1:], "") + ";"
+		}
+
+		return &Pragma{
+			Id:       id,
+			NodeType: ast_pb.NodeType_PRAGMA_DIRECTIVE,
+			Src:      i.src(n, "src", unit.GetId()),
+			Literals: getLiterals(text),
+			Text:     text,
+		}
+	}
+
+	toReturn := &Import{
+		Id:           id,
+		NodeType:     ast_pb.NodeType_IMPORT_DIRECTIVE,
+		Src:          i.src(n, "src", unit.GetId()),
+		AbsolutePath: n.str("absolutePath"),
+		File:         n.str("file"),
+		Scope:        unit.GetId(),
+		UnitAlias:    n.str("unitAlias"),
+		UnitAliases:  make([]string, 0),
+		SourceUnit:   n.int("sourceUnit"),
+	}
+
+	if n.has("nameLocation") {
+		nameLocation := i.src(n, "nameLocation", id)
+		toReturn.NameLocation = &nameLocation
+	}
+
+	for _, alias := range n.nodes("symbolAliases") {
+		if local := alias.str("local"); local != "" {
+			toReturn.UnitAliases = append(toReturn.UnitAliases, local)
+		}
+		toReturn.SymbolAliases = append(toReturn.SymbolAliases, &ImportSymbol{
+			Name:  alias.node("foreign").str("name"),
+			Alias: alias.str("local"),
+		})
+	}
+
+	return toReturn
+}
+
+// contract converts the solc contract definition into a contract, interface or library.
+func (i *solcImporter) contract(unit *SourceUnit[Node[ast_pb.SourceUnit]], n solcNode) Node[NodeType] {
+	id := n.int("id")
+
+	baseContracts := make([]*BaseContract, 0)
+	for _, base := range n.nodes("baseContracts") {
+		baseName := base.node("baseName")
+		baseContracts = append(baseContracts, &BaseContract{
+			Id:       base.int("id"),
+			NodeType: ast_pb.NodeType_INHERITANCE_SPECIFIER,
+			Src:      i.src(base, "src", id),
+			BaseName: &BaseContractName{
+				Id:                            baseName.int("id"),
+				NodeType:                      ast_pb.NodeType_IDENTIFIER_PATH,
+				Src:                           i.src(baseName, "src", base.int("id")),
+				Name:                          baseName.str("name"),
+				ReferencedDeclaration:         baseName.int("referencedDeclaration"),
+				ContractReferencedDeclaration: id,
+			},
+		})
+	}
+	unit.BaseContracts = baseContracts
+
+	nodes := make([]Node[NodeType], 0)
+	for _, member := range n.nodes("nodes") {
+		if node := i.definition(unit, id, member); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+
+	src := i.src(n, "src", unit.GetId())
+	nameLocation := i.src(n, "nameLocation", id)
+	linearized := n.ints("linearizedBaseContracts")
+	dependencies := n.ints("contractDependencies")
+	documentation := i.documentation(n, id)
+
+	switch n.str("contractKind") {
+	case "interface":
+		unit.Kind = ast_pb.NodeType_KIND_INTERFACE
+		return &Interface{
+			ASTBuilder: i.ASTBuilder, Id: id, Name: n.str("name"), NodeType: ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src: src, NameLocation: nameLocation, Abstract: n.bool("abstract"), Kind: unit.Kind,
+			FullyImplemented: n.bool("fullyImplemented"), Nodes: nodes, LinearizedBaseContracts: linearized,
+			BaseContracts: baseContracts, ContractDependencies: dependencies, Documentation: documentation,
+		}
+	case "library":
+		unit.Kind = ast_pb.NodeType_KIND_LIBRARY
+		return &Library{
+			ASTBuilder: i.ASTBuilder, Id: id, Name: n.str("name"), NodeType: ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src: src, NameLocation: nameLocation, Abstract: n.bool("abstract"), Kind: unit.Kind,
+			FullyImplemented: n.bool("fullyImplemented"), Nodes: nodes, LinearizedBaseContracts: linearized,
+			BaseContracts: baseContracts, ContractDependencies: dependencies, Documentation: documentation,
+		}
+	default:
+		unit.Kind = ast_pb.NodeType_KIND_CONTRACT
+		return &Contract{
+			ASTBuilder: i.ASTBuilder, Id: id, Name: n.str("name"), NodeType: ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src: src, NameLocation: nameLocation, Abstract: n.bool("abstract"), Kind: unit.Kind,
+			FullyImplemented: n.bool("fullyImplemented"), Nodes: nodes, LinearizedBaseContracts: linearized,
+			BaseContracts: baseContracts, ContractDependencies: dependencies, Documentation: documentation,
+		}
+	}
+}
+
+// definition converts the definition found within a contract or, when unit is nil, at the file level.
+func (i *solcImporter) definition(unit *SourceUnit[Node[ast_pb.SourceUnit]], parentId int64, n solcNode) Node[NodeType] {
+	unitName := ""
+	if unit != nil {
+		unitName = unit.GetName()
+	}
+
+	id := n.int("id")
+
+	switch n.nodeType() {
+	case "FunctionDefinition":
+		return i.function(unit, parentId, n)
+	case "ModifierDefinition":
+		return &ModifierDefinition{
+			ASTBuilder:    i.ASTBuilder,
+			Id:            id,
+			Name:          n.str("name"),
+			NodeType:      ast_pb.NodeType_MODIFIER_DEFINITION,
+			Src:           i.src(n, "src", parentId),
+			NameLocation:  i.src(n, "nameLocation", id),
+			Visibility:    solcVisibility(n.str("visibility")),
+			Virtual:       n.bool("virtual"),
+			Parameters:    i.parameterList(n.node("parameters"), n, id),
+			Body:          i.body(n.node("body"), n, id),
+			Documentation: i.documentation(n, id),
+		}
+	case "VariableDeclaration":
+		return &StateVariableDeclaration{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			Name:            n.str("name"),
+			Constant:        n.bool("constant"),
+			StateVariable:   true,
+			NodeType:        ast_pb.NodeType_VARIABLE_DECLARATION,
+			Src:             i.src(n, "src", parentId),
+			Scope:           n.int("scope"),
+			TypeDescription: i.typeDescription(n),
+			Visibility:      solcVisibility(n.str("visibility")),
+			StorageLocation: solcStorageLocation(n.str("storageLocation")),
+			StateMutability: solcMutability(n.str("mutability")),
+			TypeName:        i.typeName(n.node("typeName"), id),
+			InitialValue:    i.expression(n.node("value"), id),
+			Overrides:       i.overrideSpecifiers(n, id),
+			Documentation:   i.documentation(n, id),
+		}
+	case "EventDefinition":
+		return &EventDefinition{
+			ASTBuilder:     i.ASTBuilder,
+			SourceUnitName: unitName,
+			Id:             id,
+			NodeType:       ast_pb.NodeType_EVENT_DEFINITION,
+			Src:            i.src(n, "src", parentId),
+			Parameters:     i.parameterList(n.node("parameters"), n, id),
+			Name:           n.str("name"),
+			Anonymous:      n.bool("anonymous"),
+			Documentation:  i.documentation(n, id),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_event&_%s_%s_&%d", unitName, n.str("name"), id),
+				TypeString:     fmt.Sprintf("event %s.%s", unitName, n.str("name")),
+			},
+		}
+	case "ErrorDefinition":
+		return &ErrorDefinition{
+			ASTBuilder:     i.ASTBuilder,
+			SourceUnitName: unitName,
+			Id:             id,
+			NodeType:       ast_pb.NodeType_ERROR_DEFINITION,
+			Src:            i.src(n, "src", parentId),
+			Name:           n.str("name"),
+			NameLocation:   i.src(n, "nameLocation", id),
+			Parameters:     i.parameterList(n.node("parameters"), n, id),
+			Documentation:  i.documentation(n, id),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_error$_%s_%s_$%d", unitName, n.str("name"), id),
+				TypeString:     fmt.Sprintf("error %s.%s", unitName, n.str("name")),
+			},
+		}
+	case "StructDefinition":
+		members := make([]Node[NodeType], 0)
+		for _, member := range n.nodes("members") {
+			members = append(members, i.parameter(member, id))
+		}
+
+		return &StructDefinition{
+			ASTBuilder:     i.ASTBuilder,
+			SourceUnitName: unitName,
+			Id:             id,
+			NodeType:       ast_pb.NodeType_STRUCT_DEFINITION,
+			Src:            i.src(n, "src", parentId),
+			Name:           n.str("name"),
+			NameLocation:   i.src(n, "nameLocation", id),
+			CanonicalName:  n.str("canonicalName"),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_struct$_%s_%s_$%d", unitName, n.str("name"), id),
+				TypeString:     fmt.Sprintf("struct %s", n.str("canonicalName")),
+			},
+			Members:         members,
+			Visibility:      solcVisibility(n.str("visibility")),
+			StorageLocation: ast_pb.StorageLocation_DEFAULT,
+		}
+	case "EnumDefinition":
+		members := make([]Node[NodeType], 0)
+		for _, member := range n.nodes("members") {
+			nameLocation := i.src(member, "nameLocation", id)
+			members = append(members, &Parameter{
+				ASTBuilder:   i.ASTBuilder,
+				Id:           member.int("id"),
+				NodeType:     ast_pb.NodeType_ENUM_VALUE,
+				Src:          i.src(member, "src", id),
+				NameLocation: &nameLocation,
+				Name:         member.str("name"),
+				TypeDescription: &TypeDescription{
+					TypeIdentifier: fmt.Sprintf("t_enum_$_%s$_%s_$%d", n.str("name"), member.str("name"), member.int("id")),
+					TypeString:     fmt.Sprintf("enum %s.%s", n.str("canonicalName"), member.str("name")),
+				},
+			})
+		}
+
+		return &EnumDefinition{
+			ASTBuilder:     i.ASTBuilder,
+			SourceUnitName: unitName,
+			Id:             id,
+			NodeType:       ast_pb.NodeType_ENUM_DEFINITION,
+			Src:            i.src(n, "src", parentId),
+			NameLocation:   i.src(n, "nameLocation", id),
+			Name:           n.str("name"),
+			CanonicalName:  n.str("canonicalName"),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_enum_$_%s_$%d", n.str("name"), id),
+				TypeString:     fmt.Sprintf("enum %s", n.str("canonicalName")),
+			},
+			Members: members,
+		}
+	case "UsingForDirective":
+		// Using directives attaching a list of functions have no library to reference. Functions bound to
+		// operators are not attached for member calls and are skipped.
+		libraryName := n.node("libraryName")
+		functions := make([]*LibraryName, 0)
+		for _, entry := range n.nodes("functionList") {
+			if function := entry.node("function"); function != nil {
+				functions = append(functions, i.libraryName(function, id))
+			}
+		}
+
+		if libraryName == nil && len(functions) == 0 {
+			i.unsupported(n)
+			return nil
+		}
+
+		typeName := i.typeName(n.node("typeName"), id)
+		if typeName == nil {
+			typeName = &TypeName{
+				ASTBuilder: i.ASTBuilder,
+				Id:         i.GetNextID(),
+				NodeType:   ast_pb.NodeType_ELEMENTARY_TYPE_NAME,
+				Name:       "*",
+				Src:        i.src(n, "src", id),
+			}
+		}
+
+		toReturn := &UsingDirective{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_USING_FOR_DIRECTIVE,
+			Src:             i.src(n, "src", parentId),
+			TypeDescription: typeName.GetTypeDescription(),
+			TypeName:        typeName,
+		}
+
+		if libraryName != nil {
+			toReturn.LibraryName = i.libraryName(libraryName, id)
+		} else {
+			toReturn.FunctionList = functions
+		}
+
+		return toReturn
+	case "UserDefinedValueTypeDefinition":
+		underlying := i.typeName(n.node("underlyingType"), id)
+
+		toReturn := &UserDefinedValueTypeDefinition{
+			ASTBuilder:   i.ASTBuilder,
+			Id:           id,
+			NodeType:     ast_pb.NodeType_USER_DEFINED_VALUE_TYPE,
+			Src:          i.src(n, "src", parentId),
+			Is:           true,
+			Name:         n.str("name"),
+			NameLocation: i.src(n, "nameLocation", id),
+			TypeName:     underlying,
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_userDefinedValueType$_%s_$%d", n.str("name"), id),
+				TypeString:     n.str("canonicalName"),
+			},
+		}
+
+		if underlying != nil {
+			toReturn.Type = underlying.GetName()
+			toReturn.TypeLocation = underlying.GetSrc()
+		}
+
+		return toReturn
+	default:
+		i.unsupported(n)
+		return nil
+	}
+}
+
+// function converts the solc function definition into a function, constructor, fallback or receive node.
+func (i *solcImporter) function(unit *SourceUnit[Node[ast_pb.SourceUnit]], parentId int64, n solcNode) Node[NodeType] {
+	id := n.int("id")
+	src := i.src(n, "src", parentId)
+	body := i.body(n.node("body"), n, id)
+	parameters := i.parameterList(n.node("parameters"), n, id)
+	returnParameters := i.parameterList(n.node("returnParameters"), n, id)
+	visibility := solcVisibility(n.str("visibility"))
+	mutability := solcMutability(n.str("stateMutability"))
+	documentation := i.documentation(n, id)
+
+	modifiers := make([]*ModifierInvocation, 0)
+	for _, modifier := range n.nodes("modifiers") {
+		modifiers = append(modifiers, i.modifierInvocation(modifier, id))
+	}
+
+	overrides := i.overrideSpecifiers(n, id)
+
+	switch n.str("kind") {
+	case "constructor":
+		return &Constructor{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_FUNCTION_DEFINITION, Src: src,
+			Kind: ast_pb.NodeType_CONSTRUCTOR, StateMutability: mutability, Visibility: visibility,
+			Implemented: n.bool("implemented"), Modifiers: modifiers, Parameters: parameters,
+			ReturnParameters: returnParameters, Scope: n.int("scope"), Body: body, Documentation: documentation,
+		}
+	case "fallback":
+		return &Fallback{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_FUNCTION_DEFINITION,
+			Kind: ast_pb.NodeType_FALLBACK, Src: src, Implemented: n.bool("implemented"), Visibility: visibility,
+			StateMutability: mutability, Modifiers: modifiers, Overrides: overrides, Parameters: parameters,
+			ReturnParameters: returnParameters, Body: body, Virtual: n.bool("virtual"), Documentation: documentation,
+		}
+	case "receive":
+		return &Receive{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_FUNCTION_DEFINITION,
+			Kind: ast_pb.NodeType_RECEIVE, Src: src, Implemented: n.bool("implemented"), Visibility: visibility,
+			StateMutability: mutability, Modifiers: modifiers, Overrides: overrides, Parameters: parameters,
+			ReturnParameters: returnParameters, Body: body, Virtual: n.bool("virtual"), Payable: true,
+			Documentation: documentation,
+		}
+	}
+
+	toReturn := &Function{
+		ASTBuilder:       i.ASTBuilder,
+		Id:               id,
+		Name:             n.str("name"),
+		NodeType:         ast_pb.NodeType_FUNCTION_DEFINITION,
+		Kind:             ast_pb.NodeType_KIND_FUNCTION,
+		Src:              src,
+		NameLocation:     i.src(n, "nameLocation", id),
+		Body:             body,
+		Implemented:      n.bool("implemented"),
+		Visibility:       visibility,
+		StateMutability:  mutability,
+		Virtual:          n.bool("virtual"),
+		Modifiers:        modifiers,
+		Overrides:        overrides,
+		Parameters:       parameters,
+		ReturnParameters: returnParameters,
+		Scope:            n.int("scope"),
+		Documentation:    documentation,
+	}
+
+	toReturn.TypeDescription = toReturn.buildTypeDescription()
+	toReturn.ComputeSignature()
+
+	// Selector computed by the compiler takes precedence as it is based on canonical types.
+	if selector := n.str("functionSelector"); selector != "" {
+		toReturn.Signature = selector
+	}
+
+	return toReturn
+}
+
+// documentation converts the NatSpec documentation of the node, given as a StructuredDocumentation node
+// or, by older compilers, as plain text. It returns nil if the node is not documented.
+func (i *solcImporter) documentation(n solcNode, parentId int64) *NatSpec {
+	if !n.has("documentation") {
+		return nil
+	}
+
+	if documentation := n.node("documentation"); documentation != nil {
+		toReturn := ParseNatSpec(documentation.str("text"))
+		toReturn.Src = i.src(documentation, "src", parentId)
+		return toReturn
+	}
+
+	return ParseNatSpec(n.str("documentation"))
+}
+
+// modifierInvocation converts the solc modifier invocation or base constructor specifier.
+func (i *solcImporter) modifierInvocation(n solcNode, parentId int64) *ModifierInvocation {
+	id := n.int("id")
+	name := n.node("modifierName")
+
+	arguments := make([]Node[NodeType], 0)
+	for _, argument := range n.nodes("arguments") {
+		arguments = append(arguments, i.expression(argument, id))
+	}
+
+	return &ModifierInvocation{
+		ASTBuilder:    i.ASTBuilder,
+		Id:            id,
+		Name:          name.str("name"),
+		NodeType:      ast_pb.NodeType_MODIFIER_INVOCATION,
+		Kind:          ast_pb.NodeType_MODIFIER_INVOCATION,
+		Src:           i.src(n, "src", parentId),
+		ArgumentTypes: typeDescriptionsOf(arguments),
+		Arguments:     arguments,
+		ModifierName: &ModifierName{
+			Id:       name.int("id"),
+			Name:     name.str("name"),
+			NodeType: ast_pb.NodeType_IDENTIFIER,
+			Src:      i.src(name, "src", id),
+		},
+	}
+}
+
+// overrideSpecifiers converts the override specifier of a solc declaration, if it has one.
+func (i *solcImporter) overrideSpecifiers(n solcNode, parentId int64) []*OverrideSpecifier {
+	overrides := make([]*OverrideSpecifier, 0)
+	if override := n.node("overrides"); override != nil {
+		overrides = append(overrides, i.overrideSpecifier(override, parentId))
+	}
+	return overrides
+}
+
+// overrideSpecifier converts the solc override specifier.
+func (i *solcImporter) overrideSpecifier(n solcNode, parentId int64) *OverrideSpecifier {
+	id := n.int("id")
+
+	toReturn := NewOverrideSpecifier(i.ASTBuilder)
+	toReturn.Id = id
+	toReturn.NodeType = ast_pb.NodeType_OVERRIDE_SPECIFIER
+	toReturn.Src = i.src(n, "src", parentId)
+
+	// Override paths always reference contracts or interfaces, which solc gives no type descriptions for.
+	for _, path := range n.nodes("overrides") {
+		toReturn.Overrides = append(toReturn.Overrides, &OverridePath{
+			Id:                    path.int("id"),
+			Name:                  path.str("name"),
+			NodeType:              ast_pb.NodeType_OVERRIDE_PATH,
+			Src:                   i.src(path, "src", id),
+			ReferencedDeclaration: path.int("referencedDeclaration"),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_contract$_%s_$%d", path.str("name"), path.int("referencedDeclaration")),
+				TypeString:     fmt.Sprintf("contract %s", path.str("name")),
+			},
+		})
+	}
+
+	return toReturn
+}
+
+// parameterList converts the solc parameter list. Missing lists are replaced with empty ones
+// located at the owner, the same as the parser does.
+func (i *solcImporter) parameterList(n solcNode, owner solcNode, parentId int64) *ParameterList {
+	if n == nil {
+		return &ParameterList{
+			ASTBuilder:     i.ASTBuilder,
+			Id:             i.GetNextID(),
+			NodeType:       ast_pb.NodeType_PARAMETER_LIST,
+			Src:            i.src(owner, "src", parentId),
+			Parameters:     make([]*Parameter, 0),
+			ParameterTypes: make([]*TypeDescription, 0),
+		}
+	}
+
+	id := n.int("id")
+	toReturn := &ParameterList{
+		ASTBuilder:     i.ASTBuilder,
+		Id:             id,
+		NodeType:       ast_pb.NodeType_PARAMETER_LIST,
+		Src:            i.src(n, "src", parentId),
+		Parameters:     make([]*Parameter, 0),
+		ParameterTypes: make([]*TypeDescription, 0),
+	}
+
+	for _, parameter := range n.nodes("parameters") {
+		param := i.parameter(parameter, id)
+		toReturn.Parameters = append(toReturn.Parameters, param)
+		toReturn.ParameterTypes = append(toReturn.ParameterTypes, param.GetTypeDescription())
+	}
+
+	return toReturn
+}
+
+// parameter converts the solc variable declaration of a parameter or struct member.
+func (i *solcImporter) parameter(n solcNode, parentId int64) *Parameter {
+	id := n.int("id")
+
+	toReturn := &Parameter{
+		ASTBuilder:      i.ASTBuilder,
+		Id:              id,
+		NodeType:        ast_pb.NodeType_VARIABLE_DECLARATION,
+		Src:             i.src(n, "src", parentId),
+		Scope:           n.int("scope"),
+		Name:            n.str("name"),
+		TypeName:        i.typeName(n.node("typeName"), id),
+		StorageLocation: solcStorageLocation(n.str("storageLocation")),
+		Visibility:      solcVisibility(n.str("visibility")),
+		StateMutability: solcMutability(n.str("mutability")),
+		Constant:        n.bool("constant"),
+		StateVariable:   n.bool("stateVariable"),
+		TypeDescription: i.typeDescription(n),
+		Indexed:         n.bool("indexed"),
+	}
+
+	if n.has("nameLocation") {
+		nameLocation := i.src(n, "nameLocation", id)
+		toReturn.NameLocation = &nameLocation
+	}
+
+	return toReturn
+}
+
+// typeName converts the solc type name. It returns nil if the type name is not set.
+func (i *solcImporter) typeName(n solcNode, parentId int64) *TypeName {
+	if n == nil {
+		return nil
+	}
+
+	id := n.int("id")
+	toReturn := &TypeName{
+		ASTBuilder:      i.ASTBuilder,
+		Id:              id,
+		Src:             i.src(n, "src", parentId),
+		TypeDescription: i.typeDescription(n),
+	}
+
+	switch n.nodeType() {
+	case "ElementaryTypeName":
+		toReturn.NodeType = ast_pb.NodeType_ELEMENTARY_TYPE_NAME
+		toReturn.Name = n.str("name")
+		toReturn.StateMutability = solcMutability(n.str("stateMutability"))
+		if toReturn.Name == "address" && toReturn.StateMutability == ast_pb.Mutability_PAYABLE {
+			toReturn.Name = "address payable"
+		}
+	case "UserDefinedTypeName":
+		toReturn.NodeType = ast_pb.NodeType_USER_DEFINED_PATH_NAME
+		toReturn.Name = n.str("name")
+		toReturn.ReferencedDeclaration = n.int("referencedDeclaration")
+
+		if path := n.node("pathNode"); path != nil {
+			nameLocation := i.src(path, "src", path.int("id"))
+			toReturn.Name = path.str("name")
+			toReturn.PathNode = &PathNode{
+				Id:                    path.int("id"),
+				Name:                  path.str("name"),
+				NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+				ReferencedDeclaration: path.int("referencedDeclaration"),
+				Src:                   i.src(path, "src", id),
+				NameLocation:          &nameLocation,
+				TypeDescription:       toReturn.TypeDescription,
+			}
+		}
+	case "ArrayTypeName":
+		// Arrays are represented by the type name of their base type, named after the whole array.
+		base := i.typeName(n.node("baseType"), id)
+		toReturn.NodeType = base.NodeType
+		toReturn.PathNode = base.PathNode
+		toReturn.ReferencedDeclaration = base.ReferencedDeclaration
+
+		length := ""
+		if n.has("length") {
+			if length = i.text(n.node("length")); length == "" {
+				length = n.node("length").str("value")
+			}
+		}
+		toReturn.Name = base.Name + "[" + length + "]"
+	case "Mapping":
+		toReturn.NodeType = ast_pb.NodeType_MAPPING_TYPE_NAME
+		toReturn.KeyType = i.typeName(n.node("keyType"), id)
+		toReturn.ValueType = i.typeName(n.node("valueType"), id)
+
+		if toReturn.KeyType != nil {
+			keyNameLocation := toReturn.KeyType.GetSrc()
+			toReturn.KeyNameLocation = &keyNameLocation
+		}
+
+		if toReturn.ValueType != nil {
+			valueNameLocation := toReturn.ValueType.GetSrc()
+			toReturn.ValueNameLocation = &valueNameLocation
+		}
+	case "FunctionTypeName":
+		toReturn.NodeType = ast_pb.NodeType_FUNCTION_TYPE_NAME
+		if toReturn.Name = i.text(n); toReturn.Name == "" && toReturn.TypeDescription != nil {
+			toReturn.Name = toReturn.TypeDescription.TypeString
+		}
+		toReturn.StateMutability = solcMutability(n.str("stateMutability"))
+	default:
+		i.unsupported(n)
+		toReturn.NodeType = ast_pb.NodeType_UNKNOWN_TYPE_NAME
+	}
+
+	return toReturn
+}
+
+// libraryName converts the identifier path of a using directive.
+func (i *solcImporter) libraryName(n solcNode, parentId int64) *LibraryName {
+	return &LibraryName{
+		ASTBuilder:            i.ASTBuilder,
+		Id:                    n.int("id"),
+		NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+		Src:                   i.src(n, "src", parentId),
+		Name:                  n.str("name"),
+		ReferencedDeclaration: n.int("referencedDeclaration"),
+	}
+}
@@ -0,0 +1,937 @@
+package ast
+
+import (
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// body converts the solc block into a body node. Unimplemented functions and modifiers get an empty
+// body, located at the owner, while statements that are not blocks are wrapped into one.
+func (i *solcImporter) body(n solcNode, owner solcNode, parentId int64) *BodyNode {
+	if n == nil {
+		return &BodyNode{
+			ASTBuilder: i.ASTBuilder,
+			Id:         i.GetNextID(),
+			NodeType:   ast_pb.NodeType_BLOCK,
+			Src:        i.src(owner, "src", parentId),
+			Statements: make([]Node[NodeType], 0),
+		}
+	}
+
+	switch n.nodeType() {
+	case "Block", "UncheckedBlock":
+		id := n.int("id")
+		toReturn := &BodyNode{
+			ASTBuilder:  i.ASTBuilder,
+			Id:          id,
+			NodeType:    ast_pb.NodeType_BLOCK,
+			Src:         i.src(n, "src", parentId),
+			Implemented: true,
+			Statements:  make([]Node[NodeType], 0),
+		}
+
+		if n.nodeType() == "UncheckedBlock" {
+			toReturn.NodeType = ast_pb.NodeType_UNCHECKED_BLOCK
+		}
+
+		for _, statement := range n.nodes("statements") {
+			if node := i.statement(statement, id); node != nil {
+				toReturn.Statements = append(toReturn.Statements, node)
+			}
+		}
+
+		return toReturn
+	default:
+		id := i.GetNextID()
+		toReturn := &BodyNode{
+			ASTBuilder:  i.ASTBuilder,
+			Id:          id,
+			NodeType:    ast_pb.NodeType_BLOCK,
+			Src:         SrcNode{ParentIndex: parentId},
+			Implemented: true,
+			Statements:  make([]Node[NodeType], 0),
+		}
+
+		if node := i.statement(n, id); node != nil {
+			toReturn.Statements = append(toReturn.Statements, node)
+		}
+
+		return toReturn
+	}
+}
+
+// statement converts the solc statement. Expression statements are represented by the expression itself.
+func (i *solcImporter) statement(n solcNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	id := n.int("id")
+	src := i.src(n, "src", parentId)
+
+	switch n.nodeType() {
+	case "Block", "UncheckedBlock":
+		return i.body(n, nil, parentId)
+	case "ExpressionStatement":
+		return i.expression(n.node("expression"), parentId)
+	case "VariableDeclarationStatement":
+		toReturn := &VariableDeclaration{
+			ASTBuilder:   i.ASTBuilder,
+			Id:           id,
+			NodeType:     ast_pb.NodeType_VARIABLE_DECLARATION,
+			Src:          src,
+			Assignments:  n.ints("assignments"),
+			Declarations: make([]*Declaration, 0),
+			InitialValue: i.expression(n.node("initialValue"), id),
+		}
+
+		for _, declaration := range n.nodes("declarations") {
+			declarationId := declaration.int("id")
+			toReturn.Declarations = append(toReturn.Declarations, &Declaration{
+				ASTBuilder:      i.ASTBuilder,
+				Id:              declarationId,
+				StateMutability: solcMutability(declaration.str("mutability")),
+				Name:            declaration.str("name"),
+				NodeType:        ast_pb.NodeType_VARIABLE_DECLARATION,
+				Scope:           declaration.int("scope"),
+				Src:             i.src(declaration, "src", id),
+				NameLocation:    i.src(declaration, "nameLocation", declarationId),
+				IsStateVariable: declaration.bool("stateVariable"),
+				StorageLocation: solcStorageLocation(declaration.str("storageLocation")),
+				TypeName:        i.typeName(declaration.node("typeName"), declarationId),
+				Visibility:      solcVisibility(declaration.str("visibility")),
+			})
+		}
+
+		return toReturn
+	case "Return":
+		return &ReturnStatement{
+			ASTBuilder:               i.ASTBuilder,
+			Id:                       id,
+			NodeType:                 ast_pb.NodeType_RETURN_STATEMENT,
+			Src:                      src,
+			FunctionReturnParameters: n.int("functionReturnParameters"),
+			Expression:               i.expression(n.node("expression"), id),
+		}
+	case "EmitStatement":
+		call := n.node("eventCall")
+		return &Emit{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_EMIT_STATEMENT,
+			Src:        src,
+			Arguments:  i.expressions(call.nodes("arguments"), id),
+			Names:      call.strs("names"),
+			Expression: i.expression(call.node("expression"), id),
+		}
+	case "RevertStatement":
+		call := n.node("errorCall")
+		return &RevertStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_REVERT_STATEMENT,
+			Src:        src,
+			Arguments:  i.expressions(call.nodes("arguments"), id),
+			Names:      call.strs("names"),
+			Expression: i.expression(call.node("expression"), id),
+		}
+	case "IfStatement":
+		toReturn := &IfStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_IF_STATEMENT,
+			Src:        src,
+			Condition:  i.expression(n.node("condition"), id),
+			Body:       i.body(n.node("trueBody"), n, id),
+		}
+
+		if falseBody := n.node("falseBody"); falseBody != nil {
+			toReturn.FalseBody = i.body(falseBody, n, id)
+		}
+
+		return toReturn
+	case "ForStatement":
+		toReturn := &ForStatement{
+			ASTBuilder:  i.ASTBuilder,
+			Id:          id,
+			NodeType:    ast_pb.NodeType_FOR_STATEMENT,
+			Src:         src,
+			Initialiser: i.statement(n.node("initializationExpression"), id),
+			Condition:   i.expression(n.node("condition"), id),
+			Body:        i.body(n.node("body"), n, id),
+		}
+
+		if closure := n.node("loopExpression"); closure != nil {
+			toReturn.Closure = i.expression(closure.node("expression"), id)
+		}
+
+		return toReturn
+	case "WhileStatement":
+		return &WhileStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_WHILE_STATEMENT,
+			Kind:       ast_pb.NodeType_WHILE,
+			Src:        src,
+			Condition:  i.expression(n.node("condition"), id),
+			Body:       i.body(n.node("body"), n, id),
+		}
+	case "DoWhileStatement":
+		return &DoWhileStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_DO_WHILE_STATEMENT,
+			Src:        src,
+			Condition:  i.expression(n.node("condition"), id),
+			Body:       i.body(n.node("body"), n, id),
+		}
+	case "Break":
+		return &BreakStatement{ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_BREAK, Src: src}
+	case "Continue":
+		return &ContinueStatement{ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_CONTINUE, Src: src}
+	case "PlaceholderStatement":
+		return &PrimaryExpression{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_PLACEHOLDER_STATEMENT,
+			Src:        src,
+			Name:       "_",
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: "t_placeholder_literal",
+				TypeString:     "t_placeholder",
+			},
+		}
+	case "TryStatement":
+		toReturn := &TryStatement{
+			ASTBuilder:  i.ASTBuilder,
+			Id:          id,
+			NodeType:    ast_pb.NodeType_TRY_STATEMENT,
+			Kind:        ast_pb.NodeType_TRY,
+			Src:         src,
+			Expression:  i.expression(n.node("externalCall"), id),
+			Clauses:     make([]Node[NodeType], 0),
+			Implemented: true,
+		}
+
+		// First clause is the success block of the try statement and the remaining ones are catch clauses.
+		for idx, clause := range n.nodes("clauses") {
+			clauseId := clause.int("id")
+			if idx == 0 {
+				toReturn.Body = i.body(clause.node("block"), clause, id)
+				toReturn.ReturnParameters = i.parameterList(clause.node("parameters"), clause, id)
+				toReturn.Returns = len(toReturn.ReturnParameters.Parameters) > 0
+				continue
+			}
+
+			toReturn.Clauses = append(toReturn.Clauses, &CatchStatement{
+				ASTBuilder: i.ASTBuilder,
+				Id:         clauseId,
+				Name:       clause.str("errorName"),
+				NodeType:   ast_pb.NodeType_TRY_CATCH_CLAUSE,
+				Kind:       ast_pb.NodeType_CATCH,
+				Src:        i.src(clause, "src", id),
+				Body:       i.body(clause.node("block"), clause, clauseId),
+				Parameters: i.parameterList(clause.node("parameters"), clause, clauseId),
+			})
+		}
+
+		if toReturn.Body == nil {
+			toReturn.Body = i.body(nil, n, id)
+		}
+
+		if toReturn.ReturnParameters == nil {
+			toReturn.ReturnParameters = i.parameterList(nil, n, id)
+		}
+
+		return toReturn
+	case "InlineAssembly":
+		return &Yul{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_ASSEMBLY_STATEMENT,
+			Src:        src,
+			Body:       i.yulBody(n.node("AST"), id),
+			Flags:      n.strs("flags"),
+		}
+	default:
+		i.unsupported(n)
+		return nil
+	}
+}
+
+// expressions converts the list of solc expressions.
+func (i *solcImporter) expressions(nodes []solcNode, parentId int64) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0, len(nodes))
+	for _, node := range nodes {
+		if expression := i.expression(node, parentId); expression != nil {
+			toReturn = append(toReturn, expression)
+		}
+	}
+	return toReturn
+}
+
+// expression converts the solc expression. It returns nil if the expression is not set.
+func (i *solcImporter) expression(n solcNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	id := n.int("id")
+	src := i.src(n, "src", parentId)
+	typeDescription := i.typeDescription(n)
+
+	switch n.nodeType() {
+	case "Identifier":
+		return &PrimaryExpression{
+			ASTBuilder:             i.ASTBuilder,
+			Id:                     id,
+			NodeType:               ast_pb.NodeType_IDENTIFIER,
+			Src:                    src,
+			Name:                   n.str("name"),
+			Text:                   n.str("name"),
+			TypeDescription:        typeDescription,
+			OverloadedDeclarations: n.ints("overloadedDeclarations"),
+			ReferencedDeclaration:  n.int("referencedDeclaration"),
+			Pure:                   n.bool("isPure"),
+		}
+	case "Literal":
+		return i.literal(n, parentId)
+	case "ElementaryTypeNameExpression":
+		typeName := i.typeName(n.node("typeName"), id)
+		return &PrimaryExpression{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_IDENTIFIER,
+			Src:             src,
+			Name:            typeName.GetName(),
+			Text:            typeName.GetName(),
+			TypeName:        typeName,
+			TypeDescription: typeDescription,
+			Pure:            n.bool("isPure"),
+		}
+	case "Assignment":
+		return &Assignment{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_ASSIGNMENT,
+			Src:             src,
+			Operator:        solcAssignmentOperator(n.str("operator")),
+			LeftExpression:  i.expression(n.node("leftHandSide"), id),
+			RightExpression: i.expression(n.node("rightHandSide"), id),
+			TypeDescription: typeDescription,
+		}
+	case "BinaryOperation":
+		return i.binaryOperation(n, parentId)
+	case "UnaryOperation":
+		expression := i.expression(n.node("subExpression"), id)
+		operator := solcUnaryOperator(n.str("operator"))
+
+		if !n.bool("prefix") {
+			return &UnarySuffix{
+				ASTBuilder:      i.ASTBuilder,
+				Id:              id,
+				NodeType:        ast_pb.NodeType_UNARY_OPERATION,
+				Kind:            ast_pb.NodeType_KIND_UNARY_SUFFIX,
+				Src:             src,
+				Operator:        operator,
+				Expression:      expression,
+				TypeDescription: typeDescription,
+				Constant:        n.bool("isConstant"),
+				LValue:          n.bool("isLValue"),
+				Pure:            n.bool("isPure"),
+				LValueRequested: n.bool("lValueRequested"),
+			}
+		}
+
+		return &UnaryPrefix{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_UNARY_OPERATION,
+			Kind:            ast_pb.NodeType_KIND_UNARY_PREFIX,
+			Src:             src,
+			Operator:        operator,
+			Delete:          n.str("operator") == "delete",
+			Prefix:          true,
+			Constant:        n.bool("isConstant"),
+			LValue:          n.bool("isLValue"),
+			Pure:            n.bool("isPure"),
+			LValueRequested: n.bool("lValueRequested"),
+			Expression:      expression,
+			TypeDescription: typeDescription,
+		}
+	case "Conditional":
+		expressions := []Node[NodeType]{
+			i.expression(n.node("condition"), id),
+			i.expression(n.node("trueExpression"), id),
+			i.expression(n.node("falseExpression"), id),
+		}
+
+		return &Conditional{
+			ASTBuilder:       i.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_CONDITIONAL_EXPRESSION,
+			Src:              src,
+			Expressions:      expressions,
+			TypeDescriptions: typeDescriptionsOf(expressions),
+			TypeDescription:  typeDescription,
+		}
+	case "FunctionCall":
+		arguments := i.expressions(n.nodes("arguments"), id)
+		expression := n.node("expression")
+
+		// Conversions to payable address are represented by their own node.
+		if n.str("kind") == "typeConversion" && expression.nodeType() == "ElementaryTypeNameExpression" &&
+			expression.node("typeName").str("stateMutability") == "payable" {
+			return &PayableConversion{
+				ASTBuilder:      i.ASTBuilder,
+				Id:              id,
+				NodeType:        ast_pb.NodeType_PAYABLE_CONVERSION,
+				Src:             src,
+				Arguments:       arguments,
+				ArgumentTypes:   typeDescriptionsOf(arguments),
+				TypeDescription: typeDescription,
+				Payable:         true,
+			}
+		}
+
+		return &FunctionCall{
+			ASTBuilder:            i.ASTBuilder,
+			Id:                    id,
+			NodeType:              ast_pb.NodeType_FUNCTION_CALL,
+			Kind:                  ast_pb.NodeType_FUNCTION_CALL,
+			Src:                   src,
+			ArgumentTypes:         typeDescriptionsOf(arguments),
+			Arguments:             arguments,
+			Names:                 n.strs("names"),
+			Expression:            i.expression(expression, id),
+			ReferencedDeclaration: expression.int("referencedDeclaration"),
+			TypeDescription:       typeDescription,
+		}
+	case "FunctionCallOptions":
+		expression := n.node("expression")
+		return &FunctionCallOption{
+			ASTBuilder:            i.ASTBuilder,
+			Id:                    id,
+			NodeType:              ast_pb.NodeType_FUNCTION_CALL_OPTION,
+			Kind:                  ast_pb.NodeType_FUNCTION_CALL_OPTION,
+			Src:                   src,
+			Expression:            i.expression(expression, id),
+			Names:                 n.strs("names"),
+			Options:               i.expressions(n.nodes("options"), id),
+			ReferencedDeclaration: expression.int("referencedDeclaration"),
+			TypeDescription:       typeDescription,
+		}
+	case "MemberAccess":
+		expression := i.expression(n.node("expression"), id)
+
+		toReturn := &MemberAccessExpression{
+			ASTBuilder:            i.ASTBuilder,
+			Id:                    id,
+			Constant:              n.bool("isConstant"),
+			LValue:                n.bool("isLValue"),
+			Pure:                  n.bool("isPure"),
+			LValueRequested:       n.bool("lValueRequested"),
+			NodeType:              ast_pb.NodeType_MEMBER_ACCESS,
+			Src:                   src,
+			MemberLocation:        i.src(n, "memberLocation", id),
+			Expression:            expression,
+			MemberName:            n.str("memberName"),
+			ArgumentTypes:         make([]*TypeDescription, 0),
+			ReferencedDeclaration: n.int("referencedDeclaration"),
+			TypeDescription:       typeDescription,
+			Text:                  i.text(n),
+		}
+
+		if expression != nil && expression.GetTypeDescription() != nil {
+			toReturn.ArgumentTypes = append(toReturn.ArgumentTypes, expression.GetTypeDescription())
+		}
+
+		return toReturn
+	case "IndexAccess":
+		base := i.expression(n.node("baseExpression"), id)
+		index := i.expression(n.node("indexExpression"), id)
+
+		descriptions := make([]*TypeDescription, 0, 2)
+		for _, node := range []Node[NodeType]{base, index} {
+			if node != nil {
+				descriptions = append(descriptions, node.GetTypeDescription())
+			}
+		}
+
+		return &IndexAccess{
+			ASTBuilder:            i.ASTBuilder,
+			Id:                    id,
+			NodeType:              ast_pb.NodeType_INDEX_ACCESS,
+			Src:                   src,
+			IndexExpression:       index,
+			BaseExpression:        base,
+			TypeDescriptions:      descriptions,
+			ReferencedDeclaration: n.node("baseExpression").int("referencedDeclaration"),
+			TypeDescription:       typeDescription,
+		}
+	case "IndexRangeAccess":
+		toReturn := &IndexRange{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_INDEX_RANGE_ACCESS,
+			Src:             src,
+			BaseExpression:  i.expression(n.node("baseExpression"), id),
+			LeftExpression:  i.expression(n.node("startExpression"), id),
+			RightExpression: i.expression(n.node("endExpression"), id),
+		}
+
+		toReturn.TypeDescriptions = make([]*TypeDescription, 0, 3)
+		for _, node := range []Node[NodeType]{toReturn.BaseExpression, toReturn.LeftExpression, toReturn.RightExpression} {
+			if node != nil {
+				toReturn.TypeDescriptions = append(toReturn.TypeDescriptions, node.GetTypeDescription())
+			}
+		}
+
+		return toReturn
+	case "TupleExpression":
+		components := i.expressions(n.nodes("components"), id)
+
+		if n.bool("isInlineArray") {
+			return &InlineArray{
+				ASTBuilder:       i.ASTBuilder,
+				Id:               id,
+				NodeType:         ast_pb.NodeType_INLINE_ARRAY,
+				Src:              src,
+				TypeDescriptions: typeDescriptionsOf(components),
+				Expressions:      components,
+				Empty:            len(components) == 0,
+				TypeDescription:  typeDescription,
+			}
+		}
+
+		return &TupleExpression{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_TUPLE_EXPRESSION,
+			Src:             src,
+			Constant:        n.bool("isConstant"),
+			Pure:            n.bool("isPure"),
+			Components:      components,
+			EmptyComponents: n.nulls("components"),
+			TypeDescription: typeDescription,
+		}
+	case "NewExpression":
+		typeName := i.typeName(n.node("typeName"), id)
+		toReturn := &NewExpr{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_NEW_EXPRESSION,
+			Src:             src,
+			ArgumentTypes:   make([]*TypeDescription, 0),
+			TypeName:        typeName,
+			TypeDescription: typeDescription,
+		}
+
+		if typeName != nil {
+			toReturn.ReferencedDeclaration = typeName.ReferencedDeclaration
+		}
+
+		return toReturn
+	default:
+		i.unsupported(n)
+		return nil
+	}
+}
+
+// literal converts the solc literal. Literal text is taken from the sources when these are known and
+// rebuilt from the value otherwise.
+func (i *solcImporter) literal(n solcNode, parentId int64) Node[NodeType] {
+	toReturn := &PrimaryExpression{
+		ASTBuilder:      i.ASTBuilder,
+		Id:              n.int("id"),
+		NodeType:        ast_pb.NodeType_LITERAL,
+		Src:             i.src(n, "src", parentId),
+		Value:           n.str("value"),
+		HexValue:        n.str("hexValue"),
+		TypeDescription: i.typeDescription(n),
+		Pure:            true,
+		Text:            i.text(n),
+	}
+
+	switch n.str("kind") {
+	case "bool":
+		toReturn.Kind = ast_pb.NodeType_BOOLEAN
+	case "string":
+		toReturn.Kind = ast_pb.NodeType_STRING
+		if toReturn.Text == "" {
+			toReturn.Text = "\"" + toReturn.Value + "\""
+		}
+	case "hexString":
+		toReturn.Kind = ast_pb.NodeType_HEX_STRING
+		if toReturn.Text == "" {
+			toReturn.Text = "hex\"" + toReturn.HexValue + "\""
+		}
+	case "unicodeString":
+		toReturn.Kind = ast_pb.NodeType_UNICODE_STRING_LITERAL
+		if toReturn.Text == "" {
+			toReturn.Text = "unicode\"" + toReturn.Value + "\""
+		}
+	default:
+		toReturn.Kind = ast_pb.NodeType_NUMBER
+		if subdenomination := n.str("subdenomination"); toReturn.Text == "" && subdenomination != "" {
+			toReturn.Text = toReturn.Value + " " + subdenomination
+		}
+	}
+
+	return toReturn
+}
+
+// binaryOperation converts the solc binary operation into the node the parser uses for the operator.
+func (i *solcImporter) binaryOperation(n solcNode, parentId int64) Node[NodeType] {
+	id := n.int("id")
+	src := i.src(n, "src", parentId)
+	typeDescription := i.typeDescription(n)
+	left := i.expression(n.node("leftExpression"), id)
+	right := i.expression(n.node("rightExpression"), id)
+	expressions := []Node[NodeType]{left, right}
+
+	switch operator := n.str("operator"); operator {
+	case "&&":
+		return &AndOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_AND_OPERATION, Src: src,
+			Expressions: expressions, TypeDescriptions: typeDescriptionsOf(expressions),
+		}
+	case "&":
+		return &BitAndOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_BIT_AND_OPERATION, Src: src,
+			Expressions: expressions, TypeDescriptions: typeDescriptionsOf(expressions),
+		}
+	case "|":
+		return &BitOrOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_BIT_OR_OPERATION, Src: src,
+			Expressions: expressions, TypeDescriptions: typeDescriptionsOf(expressions),
+		}
+	case "^":
+		return &BitXorOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_BIT_XOR_OPERATION, Src: src,
+			Expressions: expressions, TypeDescriptions: typeDescriptionsOf(expressions), TypeDescription: typeDescription,
+		}
+	case "<<", ">>":
+		toReturn := &ShiftOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_SHIFT_OPERATION, Src: src,
+			Operator:    ast_pb.NodeType_SHIFT_LEFT_OPERATION,
+			Expressions: expressions, TypeDescriptions: typeDescriptionsOf(expressions), TypeDescription: typeDescription,
+		}
+		if operator == ">>" {
+			toReturn.Operator = ast_pb.NodeType_SHIFT_RIGHT_OPERATION
+		}
+		return toReturn
+	case "**":
+		return &ExprOperation{
+			ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_EXPRESSION_OPERATION, Src: src,
+			LeftExpression: left, RightExpression: right, TypeDescriptions: typeDescriptionsOf(expressions),
+		}
+	default:
+		return &BinaryOperation{
+			ASTBuilder:      i.ASTBuilder,
+			Id:              id,
+			Constant:        n.bool("isConstant"),
+			Pure:            n.bool("isPure"),
+			NodeType:        ast_pb.NodeType_BINARY_OPERATION,
+			Src:             src,
+			Operator:        solcBinaryOperator(operator),
+			LeftExpression:  left,
+			RightExpression: right,
+			TypeDescription: typeDescription,
+		}
+	}
+}
+
+// solcAssignmentOperator converts the solc assignment operator the same way the parser does.
+func solcAssignmentOperator(operator string) ast_pb.Operator {
+	switch operator {
+	case "=":
+		return ast_pb.Operator_EQUAL
+	case "+=":
+		return ast_pb.Operator_PLUS_EQUAL
+	case "-=":
+		return ast_pb.Operator_MINUS_EQUAL
+	case "*=":
+		return ast_pb.Operator_MUL_EQUAL
+	case "/=":
+		return ast_pb.Operator_DIVISION
+	case "%=":
+		return ast_pb.Operator_MOD_EQUAL
+	case "&=":
+		return ast_pb.Operator_AND_EQUAL
+	case "|=":
+		return ast_pb.Operator_OR_EQUAL
+	case "^=":
+		return ast_pb.Operator_XOR_EQUAL
+	case "<<=":
+		return ast_pb.Operator_SHIFT_LEFT_EQUAL
+	case ">>=":
+		return ast_pb.Operator_SHIFT_RIGHT_EQUAL
+	default:
+		return ast_pb.Operator_O_DEFAULT
+	}
+}
+
+// solcBinaryOperator converts the solc binary operator.
+func solcBinaryOperator(operator string) ast_pb.Operator {
+	switch operator {
+	case "+":
+		return ast_pb.Operator_ADDITION
+	case "-":
+		return ast_pb.Operator_SUBTRACTION
+	case "*":
+		return ast_pb.Operator_MULTIPLICATION
+	case "/":
+		return ast_pb.Operator_DIVISION
+	case "%":
+		return ast_pb.Operator_MODULO
+	case ">":
+		return ast_pb.Operator_GREATER_THAN
+	case ">=":
+		return ast_pb.Operator_GREATER_THAN_OR_EQUAL
+	case "<":
+		return ast_pb.Operator_LESS_THAN
+	case "<=":
+		return ast_pb.Operator_LESS_THAN_OR_EQUAL
+	case "==":
+		return ast_pb.Operator_EQUAL
+	case "!=":
+		return ast_pb.Operator_NOT_EQUAL
+	case "||":
+		return ast_pb.Operator_OR
+	default:
+		return ast_pb.Operator_O_DEFAULT
+	}
+}
+
+// solcUnaryOperator converts the solc unary operator.
+func solcUnaryOperator(operator string) ast_pb.Operator {
+	switch operator {
+	case "++":
+		return ast_pb.Operator_INCREMENT
+	case "--":
+		return ast_pb.Operator_DECREMENT
+	case "!":
+		return ast_pb.Operator_NOT
+	case "~":
+		return ast_pb.Operator_BIT_NOT
+	case "-":
+		return ast_pb.Operator_SUBTRACT
+	default:
+		return ast_pb.Operator_O_DEFAULT
+	}
+}
+
+// yulBody converts the yul block of the inline assembly into the body of the assembly statement.
+func (i *solcImporter) yulBody(n solcNode, parentId int64) *BodyNode {
+	id := i.GetNextID()
+	toReturn := &BodyNode{
+		ASTBuilder: i.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_YUL_BLOCK,
+		Src:        SrcNode{ParentIndex: parentId},
+		Statements: make([]Node[NodeType], 0),
+	}
+
+	if n == nil {
+		return toReturn
+	}
+
+	toReturn.Src = i.src(n, "src", parentId)
+	toReturn.Implemented = true
+	for _, statement := range n.nodes("statements") {
+		if node := i.yul(statement, id); node != nil {
+			toReturn.Statements = append(toReturn.Statements, node)
+		}
+	}
+
+	return toReturn
+}
+
+// yul converts the yul statement or expression. Yul nodes have no ids in the solc output so new ones
+// are assigned.
+func (i *solcImporter) yul(n solcNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	id := i.GetNextID()
+	src := i.src(n, "src", parentId)
+
+	switch n.nodeType() {
+	case "YulBlock":
+		toReturn := &YulBlockStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_BLOCK,
+			Src:        src,
+			Statements: make([]Node[NodeType], 0),
+		}
+
+		for _, statement := range n.nodes("statements") {
+			if node := i.yul(statement, id); node != nil {
+				toReturn.Statements = append(toReturn.Statements, node)
+			}
+		}
+
+		return toReturn
+	case "YulExpressionStatement":
+		return i.yul(n.node("expression"), parentId)
+	case "YulAssignment":
+		return &YulAssignment{
+			ASTBuilder:    i.ASTBuilder,
+			Id:            id,
+			NodeType:      ast_pb.NodeType_YUL_ASSIGNMENT,
+			Src:           src,
+			VariableNames: i.yulIdentifiers(n.nodes("variableNames"), id),
+			Value:         i.yul(n.node("value"), id),
+		}
+	case "YulVariableDeclaration":
+		return &YulVariable{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_VARIABLE_DECLARATION,
+			Src:        src,
+			Let:        true,
+			Value:      i.yul(n.node("value"), id),
+			Variables:  i.yulIdentifiers(n.nodes("variables"), id),
+		}
+	case "YulFunctionCall":
+		toReturn := &YulFunctionCallStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_FUNCTION_CALL,
+			Src:        src,
+			Arguments:  make([]Node[NodeType], 0),
+		}
+
+		if name := n.node("functionName"); name != nil {
+			toReturn.FunctionName = i.yulIdentifier(name, id)
+		}
+
+		for _, argument := range n.nodes("arguments") {
+			if node := i.yul(argument, id); node != nil {
+				toReturn.Arguments = append(toReturn.Arguments, node)
+			}
+		}
+
+		return toReturn
+	case "YulIdentifier":
+		return i.yulIdentifier(n, parentId)
+	case "YulLiteral":
+		toReturn := &YulLiteralStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_LITERAL,
+			Src:        src,
+			Value:      n.str("value"),
+			HexValue:   n.str("hexValue"),
+		}
+
+		switch n.str("kind") {
+		case "number":
+			toReturn.Kind = ast_pb.NodeType_DECIMAL_NUMBER
+			if strings.HasPrefix(toReturn.Value, "0x") {
+				toReturn.Kind = ast_pb.NodeType_HEX_NUMBER
+				toReturn.HexValue = toReturn.Value
+			}
+		case "bool":
+			toReturn.Kind = ast_pb.NodeType_BOOLEAN
+		default:
+			toReturn.Kind = ast_pb.NodeType_STRING
+			toReturn.Value = "\"" + toReturn.Value + "\""
+		}
+
+		return toReturn
+	case "YulIf":
+		return &YulIfStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_IF,
+			Src:        src,
+			Condition:  i.yul(n.node("condition"), id),
+			Body:       i.yul(n.node("body"), id),
+		}
+	case "YulForLoop":
+		return &YulForStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_FOR,
+			Src:        src,
+			Pre:        i.yul(n.node("pre"), id),
+			Condition:  i.yul(n.node("condition"), id),
+			Post:       i.yul(n.node("post"), id),
+			Body:       i.yul(n.node("body"), id),
+		}
+	case "YulSwitch":
+		toReturn := &YulSwitchStatement{
+			ASTBuilder: i.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_SWITCH,
+			Src:        src,
+			Expression: i.yul(n.node("expression"), id),
+			Cases:      make([]Node[NodeType], 0),
+		}
+
+		for _, c := range n.nodes("cases") {
+			caseId := i.GetNextID()
+			ycase := &YulSwitchCaseStatement{
+				ASTBuilder: i.ASTBuilder,
+				Id:         caseId,
+				NodeType:   ast_pb.NodeType_YUL_SWITCH_CASE,
+				Src:        i.src(c, "src", id),
+				Body:       i.yul(c.node("body"), caseId),
+			}
+
+			// Default case has its value set to the `default` string instead of a literal.
+			if value := c.node("value"); value != nil {
+				ycase.Case = i.yul(value, caseId)
+			}
+
+			toReturn.Cases = append(toReturn.Cases, ycase)
+		}
+
+		return toReturn
+	case "YulFunctionDefinition":
+		return &YulFunctionDefinition{
+			ASTBuilder:       i.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_YUL_FUNCTION_DEFINITION,
+			Src:              src,
+			Name:             n.str("name"),
+			Arguments:        i.yulIdentifiers(n.nodes("parameters"), id),
+			Body:             i.yul(n.node("body"), id),
+			ReturnParameters: i.yulIdentifiers(n.nodes("returnVariables"), id),
+		}
+	case "YulBreak":
+		return &YulBreakStatement{ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_BREAK, Src: src}
+	case "YulContinue":
+		return &YulContinueStatement{ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_CONTINUE, Src: src}
+	case "YulLeave":
+		return &YulLeaveStatement{ASTBuilder: i.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_LEAVE, Src: src}
+	default:
+		i.unsupported(n)
+		return nil
+	}
+}
+
+// yulIdentifier converts the yul identifier or typed name.
+func (i *solcImporter) yulIdentifier(n solcNode, parentId int64) *YulIdentifier {
+	return &YulIdentifier{
+		ASTBuilder: i.ASTBuilder,
+		Id:         i.GetNextID(),
+		NodeType:   ast_pb.NodeType_YUL_IDENTIFIER,
+		Src:        i.src(n, "src", parentId),
+		Name:       n.str("name"),
+	}
+}
+
+// yulIdentifiers converts the list of yul identifiers or typed names.
+func (i *solcImporter) yulIdentifiers(nodes []solcNode, parentId int64) []*YulIdentifier {
+	toReturn := make([]*YulIdentifier, 0, len(nodes))
+	for _, node := range nodes {
+		toReturn = append(toReturn, i.yulIdentifier(node, parentId))
+	}
+	return toReturn
+}
@@ -0,0 +1,209 @@
+package ast
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+)
+
+func TestImportFromSolcJSON(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		file                 string
+		sources              []string
+		entry                string
+		contracts            []string
+		functions            []string
+		stateVariables       []string
+		unresolvedReferences int
+		elseBranches         int
+	}{
+		{
+			name:           "Single Source Unit",
+			file:           "SimpleStorage.solc.ast.json",
+			sources:        []string{"SimpleStorage.sol"},
+			entry:          "SimpleStorage",
+			contracts:      []string{"SimpleStorage"},
+			functions:      []string{"increment", "decrement", "get"},
+			stateVariables: []string{"storedData"},
+		},
+		{
+			name:      "Library",
+			file:      "SafeMath.solc.ast.json",
+			sources:   []string{"SafeMath.sol"},
+			entry:     "SafeMath",
+			contracts: []string{"SafeMath"},
+			functions: []string{"add", "sub", "mul", "div", "mod"},
+		},
+		{
+			name:           "Multiple Source Units",
+			file:           "TokenSale.solc.ast.json",
+			sources:        []string{"SafeMath.sol", "TokenSale.sol"},
+			entry:          "TokenSale",
+			contracts:      []string{"SafeMath", "TokenSale"},
+			functions:      []string{"buyTokens"},
+			stateVariables: []string{"token", "tokenPrice"},
+		},
+		{
+			name:           "Missing Source Ids",
+			file:           "ERC20.solc.ast.json",
+			sources:        []string{"ERC20.sol"},
+			entry:          "ERC20",
+			contracts:      []string{"ERC20"},
+			functions:      []string{"transfer", "approve", "_spendAllowance"},
+			stateVariables: []string{"_balances", "_allowances", "_totalSupply"},
+			// Context and interfaces the contract inherits from are not part of the JSON.
+			unresolvedReferences: 9,
+		},
+		{
+			name:           "Else Branches",
+			file:           "Branches.solc.ast.json",
+			sources:        []string{"Branches.sol"},
+			entry:          "Branches",
+			contracts:      []string{"Branches"},
+			functions:      []string{"pick"},
+			stateVariables: []string{"value"},
+			elseBranches:   2,
+		},
+		{
+			name:           "Compiler Headers Without Sources",
+			file:           "Lottery.solc.ast.json",
+			entry:          "Lottery",
+			contracts:      []string{"Lottery"},
+			stateVariables: []string{"players"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := os.ReadFile("../data/tests/ast/" + testCase.file)
+			require.NoError(t, err)
+
+			var sources *solgo.Sources
+			if len(testCase.sources) > 0 {
+				sources = &solgo.Sources{SourceUnits: make([]*solgo.SourceUnit, 0)}
+				for _, source := range testCase.sources {
+					content, err := os.ReadFile("../data/tests/ast/" + source)
+					require.NoError(t, err)
+
+					sources.SourceUnits = append(sources.SourceUnits, &solgo.SourceUnit{
+						Name:    source[:len(source)-len(filepath.Ext(source))],
+						Path:    source,
+						Content: string(content),
+					})
+				}
+			}
+
+			astBuilder := NewAstBuilder(nil, sources)
+			root, err := astBuilder.ImportFromSolcJSON(context.TODO(), data)
+			require.NoError(t, err)
+			require.NotNil(t, root)
+
+			entry := root.GetSourceUnitById(root.GetEntrySourceUnit())
+			require.NotNil(t, entry)
+			assert.Equal(t, testCase.entry, entry.GetName())
+
+			names := make(map[string]bool)
+			for _, unit := range root.GetSourceUnits() {
+				names[unit.GetName()] = true
+				require.NotNil(t, unit.GetContract())
+			}
+			for _, contract := range testCase.contracts {
+				assert.True(t, names[contract], "missing contract %s", contract)
+			}
+
+			ids := make(map[int64]bool)
+			walkSlots(root, func(s slot, v reflect.Value) {
+				if node, ok := v.Interface().(Node[NodeType]); ok {
+					ids[node.GetId()] = true
+				}
+			})
+
+			functions := make(map[string]bool)
+			stateVariables := make(map[string]bool)
+			unresolved := make([]int64, 0)
+			elseBranches := 0
+
+			walkSlots(root, func(s slot, v reflect.Value) {
+				switch node := v.Interface().(type) {
+				case *Function:
+					functions[node.GetName()] = true
+					assert.NotEmpty(t, node.GetSignature())
+				case *StateVariableDeclaration:
+					stateVariables[node.GetName()] = true
+					assert.NotNil(t, node.GetTypeName())
+				case *IfStatement:
+					if node.GetFalseBody() != nil {
+						elseBranches++
+					}
+				case *PrimaryExpression:
+					// Negative ids reference builtins such as msg or require.
+					if id := node.GetReferencedDeclaration(); id > 0 && !ids[id] {
+						unresolved = append(unresolved, id)
+					}
+				}
+			})
+
+			for _, function := range testCase.functions {
+				assert.True(t, functions[function], "missing function %s", function)
+			}
+			for _, variable := range testCase.stateVariables {
+				assert.True(t, stateVariables[variable], "missing state variable %s", variable)
+			}
+			assert.Len(t, unresolved, testCase.unresolvedReferences)
+			assert.Equal(t, testCase.elseBranches, elseBranches)
+
+			assert.NotPanics(t, func() { astBuilder.ResolveReferences() })
+			assert.NotNil(t, root.ToProto())
+
+			if sources != nil {
+				contract := entry.GetContract()
+				location, err := astBuilder.LocateNode(contract.GetId())
+				require.NoError(t, err)
+				assert.Equal(t, testCase.entry+".sol", location.Path)
+				assert.Contains(t, location.Snippet, testCase.entry+" ")
+				assert.Greater(t, location.Line, int64(1))
+
+				code, err := astBuilder.ToSource(nil)
+				require.NoError(t, err)
+				assert.Contains(t, code, testCase.entry+" ")
+				assert.Contains(t, code, "pragma solidity ^0.8")
+			}
+		})
+	}
+}
+
+func TestImportFromSolcJSONErrors(t *testing.T) {
+	astBuilder := NewAstBuilder(nil, nil)
+
+	_, err := astBuilder.ImportFromSolcJSON(context.TODO(), []byte(`{"contracts": {}}`))
+	assert.Error(t, err)
+
+	_, err = astBuilder.ImportFromSolcJSON(context.TODO(), []byte(`{"nodeType": `))
+	assert.Error(t, err)
+}
+
+func TestImportFromSolcJSONElseBranches(t *testing.T) {
+	data, err := os.ReadFile("../data/tests/ast/Branches.solc.ast.json")
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(nil, nil)
+	_, err = astBuilder.ImportFromSolcJSON(context.TODO(), data)
+	require.NoError(t, err)
+
+	code, err := astBuilder.ToSource(nil)
+	require.NoError(t, err)
+	assert.Contains(t, code, `        if (flag) {
+            value = 1;
+        } else if (amount > value) {
+            value = amount;
+        } else {
+            value = 2;
+        }`)
+}
@@ -12,6 +12,7 @@ type SrcNode struct {
 	End         int64 `json:"end"`                   // End position of the source node in the source code.
 	Length      int64 `json:"length"`                // Length of the source node in the source code.
 	ParentIndex int64 `json:"parentIndex,omitempty"` // Index of the parent node in the source code.
+	FileIndex   int64 `json:"fileIndex"`             // Index of the source file (solgo.SourceUnit) the node belongs to.
 }
 
 // GetLine returns the line number of the source node in the source code.
@@ -44,9 +45,16 @@ func (s SrcNode) GetParentIndex() int64 {
 	return s.ParentIndex
 }
 
+// GetFileIndex returns the index of the source file the node belongs to.
+// Indexes follow the order of the source units the AST was built from, same as solc source ids.
+func (s SrcNode) GetFileIndex() int64 {
+	return s.FileIndex
+}
+
 // ToProto converts the SrcNode to a protocol buffer representation.
 func (s SrcNode) ToProto() *ast_pb.Src {
 	return &ast_pb.Src{
+		Id:          s.GetFileIndex(), // Source id, same as the file index in solc.
 		Line:        s.GetLine(),
 		Column:      s.GetColumn(),
 		Start:       s.GetStart(),
@@ -11,19 +11,21 @@ import (
 // StateVariableDeclaration represents a state variable declaration in the Solidity abstract syntax tree (AST).
 type StateVariableDeclaration struct {
 	*ASTBuilder                            // Embedding the ASTBuilder for common functionality
-	Id              int64                  `json:"id"`              // Unique identifier for the state variable declaration
-	Name            string                 `json:"name"`            // Name of the state variable
-	Constant        bool                   `json:"isConstant"`      // Indicates if the state variable is constant
-	StateVariable   bool                   `json:"isStateVariable"` // Indicates if the declaration is a state variable
-	NodeType        ast_pb.NodeType        `json:"nodeType"`        // Type of the node (VARIABLE_DECLARATION for state variable declaration)
-	Src             SrcNode                `json:"src"`             // Source information about the state variable declaration
-	Scope           int64                  `json:"scope"`           // Scope of the state variable declaration
-	TypeDescription *TypeDescription       `json:"typeDescription"` // Type description of the state variable declaration
-	Visibility      ast_pb.Visibility      `json:"visibility"`      // Visibility of the state variable declaration
-	StorageLocation ast_pb.StorageLocation `json:"storageLocation"` // Storage location of the state variable declaration
-	StateMutability ast_pb.Mutability      `json:"mutability"`      // State mutability of the state variable declaration
-	TypeName        *TypeName              `json:"typeName"`        // Type name of the state variable
-	InitialValue    Node[NodeType]         `json:"initialValue"`    // Initial value of the state variable
+	Id              int64                  `json:"id"`                      // Unique identifier for the state variable declaration
+	Name            string                 `json:"name"`                    // Name of the state variable
+	Constant        bool                   `json:"isConstant"`              // Indicates if the state variable is constant
+	StateVariable   bool                   `json:"isStateVariable"`         // Indicates if the declaration is a state variable
+	NodeType        ast_pb.NodeType        `json:"nodeType"`                // Type of the node (VARIABLE_DECLARATION for state variable declaration)
+	Src             SrcNode                `json:"src"`                     // Source information about the state variable declaration
+	Scope           int64                  `json:"scope"`                   // Scope of the state variable declaration
+	TypeDescription *TypeDescription       `json:"typeDescription"`         // Type description of the state variable declaration
+	Visibility      ast_pb.Visibility      `json:"visibility"`              // Visibility of the state variable declaration
+	StorageLocation ast_pb.StorageLocation `json:"storageLocation"`         // Storage location of the state variable declaration
+	StateMutability ast_pb.Mutability      `json:"mutability"`              // State mutability of the state variable declaration
+	TypeName        *TypeName              `json:"typeName"`                // Type name of the state variable
+	InitialValue    Node[NodeType]         `json:"initialValue"`            // Initial value of the state variable
+	Overrides       []*OverrideSpecifier   `json:"overrides,omitempty"`     // Override specifiers of the state variable declaration
+	Documentation   *NatSpec               `json:"documentation,omitempty"` // NatSpec documentation of the state variable declaration
 }
 
 // NewStateVariableDeclaration creates a new StateVariableDeclaration instance.
@@ -75,6 +77,11 @@ func (v *StateVariableDeclaration) GetSrc() SrcNode {
 	return v.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the state variable declaration or nil if it is not documented.
+func (v *StateVariableDeclaration) GetDocumentation() *NatSpec {
+	return v.Documentation
+}
+
 // GetTypeDescription returns the type description of the state variable declaration.
 func (v *StateVariableDeclaration) GetTypeDescription() *TypeDescription {
 	return v.TypeDescription
@@ -105,6 +112,11 @@ func (v *StateVariableDeclaration) GetTypeName() *TypeName {
 	return v.TypeName
 }
 
+// GetOverrides returns the override specifiers of the state variable declaration.
+func (v *StateVariableDeclaration) GetOverrides() []*OverrideSpecifier {
+	return v.Overrides
+}
+
 // GetReferencedDeclaration returns the referenced declaration of the type name in the state variable declaration.
 func (v *StateVariableDeclaration) GetReferencedDeclaration() int64 {
 	return v.TypeName.ReferencedDeclaration
@@ -206,12 +218,24 @@ func (v *StateVariableDeclaration) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if documentation, ok := tempMap["documentation"]; ok {
+		if err := json.Unmarshal(documentation, &v.Documentation); err != nil {
+			return err
+		}
+	}
+
 	if scope, ok := tempMap["scope"]; ok {
 		if err := json.Unmarshal(scope, &v.Scope); err != nil {
 			return err
 		}
 	}
 
+	if overrides, ok := tempMap["overrides"]; ok {
+		if err := json.Unmarshal(overrides, &v.Overrides); err != nil {
+			return err
+		}
+	}
+
 	if expression, ok := tempMap["initialValue"]; ok {
 		if err := json.Unmarshal(expression, &v.InitialValue); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -242,6 +266,7 @@ func (v *StateVariableDeclaration) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto returns the protobuf representation of the state variable declaration.
+// Override specifiers are not carried, as the protobuf state variable has no field for them.
 func (v *StateVariableDeclaration) ToProto() NodeType {
 	proto := ast_pb.StateVariable{
 		Id:              v.GetId(),
@@ -289,6 +314,7 @@ func (v *StateVariableDeclaration) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   v.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	v.Scope = contractNode.GetId()
 	v.Visibility = v.getVisibilityFromCtx(ctx)
@@ -303,6 +329,12 @@ func (v *StateVariableDeclaration) Parse(
 		v.Constant = constantCtx != nil
 	}
 
+	for _, overrideCtx := range ctx.AllOverrideSpecifier() {
+		overrideSpecifier := NewOverrideSpecifier(v.ASTBuilder)
+		overrideSpecifier.Parse(unit, v, overrideCtx)
+		v.Overrides = append(v.Overrides, overrideSpecifier)
+	}
+
 	typeName := NewTypeName(v.ASTBuilder)
 
 	typeName.Parse(unit, nil, v.Id, ctx.GetType_())
@@ -353,6 +385,7 @@ func (v *StateVariableDeclaration) ParseGlobal(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   v.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	v.Visibility = v.getVisibilityFromCtx(ctx)
 
@@ -395,6 +428,7 @@ func (v *StateVariableDeclaration) ParseGlobalConstant(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   v.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	v.Visibility = ast_pb.Visibility_PUBLIC
 	v.Constant = true
@@ -425,6 +459,7 @@ func (v *StateVariableDeclaration) ParseGlobalVariable(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   v.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	v.Visibility = ast_pb.Visibility_PUBLIC
 	v.Constant = true
@@ -246,6 +246,7 @@ func (s *StructDefinition) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: unit.GetId(),
+		FileIndex:   s.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	s.SourceUnitName = unit.GetName()
 
@@ -258,6 +259,7 @@ func (s *StructDefinition) Parse(
 		End:         int64(ctx.GetName().GetStop().GetStop()),
 		Length:      int64(ctx.GetName().GetStop().GetStop() - ctx.GetName().GetStart().GetStart() + 1),
 		ParentIndex: s.GetId(),
+		FileIndex:   s.GetSourceFileIndex(int64(ctx.GetName().GetStart().GetStart())),
 	}
 
 	s.TypeDescription = &TypeDescription{
@@ -290,6 +292,7 @@ func (s *StructDefinition) ParseGlobal(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   s.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 	s.SourceUnitName = "Global"
 
@@ -302,6 +305,7 @@ func (s *StructDefinition) ParseGlobal(
 		End:         int64(ctx.GetName().GetStop().GetStop()),
 		Length:      int64(ctx.GetName().GetStop().GetStop() - ctx.GetName().GetStart().GetStart() + 1),
 		ParentIndex: s.GetId(),
+		FileIndex:   s.GetSourceFileIndex(int64(ctx.GetName().GetStart().GetStart())),
 	}
 
 	s.TypeDescription = &TypeDescription{
@@ -12,6 +12,12 @@ type Tree struct {
 
 	// astRoot is the root node of the Abstract Syntax Tree.
 	astRoot *RootNode
+
+	// rewritten holds the IDs of nodes changed through the rewrite API, together with their ancestors.
+	rewritten map[int64]struct{}
+
+	// unresolved holds the nodes added through the rewrite API that still need their references resolved.
+	unresolved []UnprocessedNode
 }
 
 // NewTree creates a new Tree with the provided ASTBuilder.
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/goccy/go-json"
 	"strings"
 
@@ -18,6 +19,7 @@ type TupleExpression struct {
 	Constant              bool             `json:"isConstant"`                      // Whether the tuple expression is constant
 	Pure                  bool             `json:"isPure"`                          // Whether the tuple expression is pure
 	Components            []Node[NodeType] `json:"components"`                      // Components of the tuple expression
+	EmptyComponents       []int            `json:"emptyComponents,omitempty"`       // Positions of the omitted components, as in (, x) = f()
 	ReferencedDeclaration int64            `json:"referencedDeclaration,omitempty"` // Referenced declaration of the tuple expression
 	TypeDescription       *TypeDescription `json:"typeDescription"`                 // Type description of the tuple expression
 }
@@ -58,6 +60,11 @@ func (t *TupleExpression) GetComponents() []Node[NodeType] {
 	return t.Components
 }
 
+// GetEmptyComponents returns the positions of the omitted components of the tuple expression.
+func (t *TupleExpression) GetEmptyComponents() []int {
+	return t.EmptyComponents
+}
+
 // GetNodes returns the components of the tuple expression.
 func (t *TupleExpression) GetNodes() []Node[NodeType] {
 	return t.Components
@@ -132,6 +139,12 @@ func (t *TupleExpression) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if emptyComponents, ok := tempMap["emptyComponents"]; ok {
+		if err := json.Unmarshal(emptyComponents, &t.EmptyComponents); err != nil {
+			return err
+		}
+	}
+
 	if components, ok := tempMap["components"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(components, &nodes); err != nil {
@@ -206,10 +219,34 @@ func (t *TupleExpression) Parse(
 
 			return bodyNode.GetId()
 		}(),
+		FileIndex: t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	expression := NewExpression(t.ASTBuilder)
-	for _, tupleCtx := range ctx.TupleExpression().AllExpression() {
+	emptySlot, separated := false, false
+	for _, child := range ctx.TupleExpression().GetChildren() {
+		if terminal, ok := child.(antlr.TerminalNode); ok {
+			switch terminal.GetSymbol().GetTokenType() {
+			case parser.SolidityParserComma:
+				if emptySlot {
+					t.EmptyComponents = append(t.EmptyComponents, len(t.Components)+len(t.EmptyComponents))
+				}
+				separated = true
+			case parser.SolidityParserRParen:
+				if emptySlot && separated {
+					t.EmptyComponents = append(t.EmptyComponents, len(t.Components)+len(t.EmptyComponents))
+				}
+			}
+			emptySlot = true
+			continue
+		}
+
+		tupleCtx, ok := child.(parser.IExpressionContext)
+		if !ok {
+			continue
+		}
+
+		emptySlot = false
 		expr := expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, t, t.GetId(), tupleCtx)
 		t.Components = append(
 			t.Components,
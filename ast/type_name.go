@@ -322,6 +322,7 @@ func (t *TypeName) parseTypeName(unit *SourceUnit[Node[ast_pb.SourceUnit]], pare
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if t.GetType() == ast_pb.NodeType_NT_DEFAULT {
@@ -369,6 +370,7 @@ func (t *TypeName) parseTypeName(unit *SourceUnit[Node[ast_pb.SourceUnit]], pare
 				End:         int64(pathCtx.GetStop().GetStop()),
 				Length:      int64(pathCtx.GetStop().GetStop() - pathCtx.GetStart().GetStart() + 1),
 				ParentIndex: t.GetId(),
+				FileIndex:   t.GetSourceFileIndex(int64(pathCtx.GetStart().GetStart())),
 			},
 
 			NodeType: ast_pb.NodeType_IDENTIFIER_PATH,
@@ -483,7 +485,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 		identifierCtx := ctx.Identifier(0)
 		t.PathNode = &PathNode{
 			Id:   t.GetNextID(),
-			Name: identifierCtx.GetText(),
+			Name: ctx.GetText(),
 			Src: SrcNode{
 				Line:        int64(ctx.GetStart().GetLine()),
 				Column:      int64(ctx.GetStart().GetColumn()),
@@ -491,6 +493,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 				End:         int64(ctx.GetStop().GetStop()),
 				Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 				ParentIndex: t.Id,
+				FileIndex:   t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 			},
 			NameLocation: &SrcNode{
 				Line:        int64(identifierCtx.GetStart().GetLine()),
@@ -499,13 +502,14 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 				End:         int64(identifierCtx.GetStop().GetStop()),
 				Length:      int64(identifierCtx.GetStop().GetStop() - identifierCtx.GetStart().GetStart() + 1),
 				ParentIndex: t.Id,
+				FileIndex:   t.GetSourceFileIndex(int64(identifierCtx.GetStart().GetStart())),
 			},
 			NodeType: ast_pb.NodeType_IDENTIFIER_PATH,
 		}
 
-		normalizedTypeName, normalizedTypeIdentifier, found := normalizeTypeDescriptionWithStatus(
-			identifierCtx.GetText(),
-		)
+		// Qualified paths such as Library.Struct are resolved by their last segment.
+		name := ctx.Identifier(len(ctx.AllIdentifier()) - 1).GetText()
+		normalizedTypeName, normalizedTypeIdentifier, found := normalizeTypeDescriptionWithStatus(name)
 
 		switch normalizedTypeIdentifier {
 		case "t_address":
@@ -520,7 +524,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 				TypeString:     normalizedTypeName,
 			}
 		} else {
-			if refId, refTypeDescription := t.GetResolver().ResolveByNode(t, identifierCtx.GetText()); refTypeDescription != nil {
+			if refId, refTypeDescription := t.GetResolver().ResolveByNode(t, name); refTypeDescription != nil {
 				t.PathNode.ReferencedDeclaration = refId
 				t.ReferencedDeclaration = refId
 				t.TypeDescription = refTypeDescription
@@ -533,7 +537,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 func (t *TypeName) parseMappingTypeName(unit *SourceUnit[Node[ast_pb.SourceUnit]], parentNodeId int64, ctx *parser.MappingTypeContext) {
 	keyCtx := ctx.GetKey()
 	valueCtx := ctx.GetValue()
-	t.NodeType = ast_pb.NodeType_MAPPING_TYPE_NAME
+	t.Name = ctx.GetText()
 
 	t.KeyType = t.generateTypeName(unit, keyCtx, t, t)
 	if keyCtx.GetStart().GetLine() > 0 {
@@ -544,10 +548,12 @@ func (t *TypeName) parseMappingTypeName(unit *SourceUnit[Node[ast_pb.SourceUnit]
 			End:         int64(keyCtx.GetStop().GetStop()),
 			Length:      int64(keyCtx.GetStop().GetStop() - keyCtx.GetStart().GetStart() + 1),
 			ParentIndex: t.GetId(),
+			FileIndex:   t.GetSourceFileIndex(int64(keyCtx.GetStart().GetStart())),
 		}
 	}
 
 	t.ValueType = t.generateTypeName(unit, valueCtx, t, t)
+	t.NodeType = ast_pb.NodeType_MAPPING_TYPE_NAME
 	if valueCtx.GetStart().GetLine() > 0 {
 		t.ValueNameLocation = &SrcNode{
 			Line:        int64(valueCtx.GetStart().GetLine()),
@@ -556,6 +562,7 @@ func (t *TypeName) parseMappingTypeName(unit *SourceUnit[Node[ast_pb.SourceUnit]
 			End:         int64(valueCtx.GetStop().GetStop()),
 			Length:      int64(valueCtx.GetStop().GetStop() - valueCtx.GetStart().GetStart() + 1),
 			ParentIndex: t.GetId(),
+			FileIndex:   t.GetSourceFileIndex(int64(valueCtx.GetStart().GetStart())),
 		}
 	}
 
@@ -613,6 +620,7 @@ func (t *TypeName) generateTypeName(sourceUnit *SourceUnit[Node[ast_pb.SourceUni
 			End:         int64(specificCtx.GetStop().GetStop()),
 			Length:      int64(specificCtx.GetStop().GetStop() - specificCtx.GetStart().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   t.GetSourceFileIndex(int64(specificCtx.GetStart().GetStart())),
 		}
 
 		if specificCtx.ElementaryTypeName() != nil {
@@ -641,6 +649,7 @@ func (t *TypeName) generateTypeName(sourceUnit *SourceUnit[Node[ast_pb.SourceUni
 				End:         int64(keyCtx.GetStop().GetStop()),
 				Length:      int64(keyCtx.GetStop().GetStop() - keyCtx.GetStart().GetStart() + 1),
 				ParentIndex: typeNameNode.GetId(),
+				FileIndex:   t.GetSourceFileIndex(int64(keyCtx.GetStart().GetStart())),
 			}
 		}
 
@@ -652,6 +661,7 @@ func (t *TypeName) generateTypeName(sourceUnit *SourceUnit[Node[ast_pb.SourceUni
 				End:         int64(valueCtx.GetStop().GetStop()),
 				Length:      int64(valueCtx.GetStop().GetStop() - valueCtx.GetStart().GetStart() + 1),
 				ParentIndex: typeNameNode.GetId(),
+				FileIndex:   t.GetSourceFileIndex(int64(valueCtx.GetStart().GetStart())),
 			}
 		}
 
@@ -686,6 +696,7 @@ func (t *TypeName) generateTypeName(sourceUnit *SourceUnit[Node[ast_pb.SourceUni
 			End:         int64(specificCtx.GetStop().GetStop()),
 			Length:      int64(specificCtx.GetStop().GetStop() - specificCtx.GetStart().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   t.GetSourceFileIndex(int64(specificCtx.GetStart().GetStart())),
 		}
 
 		if specificCtx.ElementaryTypeName() != nil {
@@ -756,6 +767,7 @@ func (t *TypeName) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node[
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	for _, child := range ctx.GetChildren() {
@@ -773,7 +785,10 @@ func (t *TypeName) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node[
 		case *parser.FunctionTypeNameContext:
 			t.parseFunctionTypeName(unit, parentNodeId, childCtx)
 		case *parser.PrimaryExpressionContext:
-			t.parsePrimaryExpression(unit, fnNode, parentNodeId, childCtx)
+			// Length of a fixed size array type is parsed together with the type name.
+			if ctx.TypeName() == nil {
+				t.parsePrimaryExpression(unit, fnNode, parentNodeId, childCtx)
+			}
 		case *antlr.TerminalNodeImpl:
 			continue
 		default:
@@ -817,6 +832,7 @@ func (t *TypeName) ParseMul(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode No
 		End:         int64(ctx.GetSymbol().GetStop()),
 		Length:      int64(ctx.GetSymbol().GetStop() - ctx.GetSymbol().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   t.GetSourceFileIndex(int64(ctx.GetSymbol().GetStart())),
 	}
 
 	t.TypeDescription = &TypeDescription{
@@ -834,6 +850,7 @@ func (t *TypeName) ParseElementaryType(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNodeId,
+		FileIndex:   t.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	t.Name = ctx.GetText()
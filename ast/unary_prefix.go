@@ -17,6 +17,7 @@ type UnaryPrefix struct {
 	Kind                  ast_pb.NodeType  `json:"kind"`
 	Src                   SrcNode          `json:"src"`
 	Operator              ast_pb.Operator  `json:"operator"`
+	Delete                bool             `json:"isDelete"`
 	Prefix                bool             `json:"prefix"`
 	Constant              bool             `json:"isConstant"`
 	LValue                bool             `json:"isLValue"`
@@ -69,6 +70,12 @@ func (u *UnaryPrefix) GetOperator() ast_pb.Operator {
 	return u.Operator
 }
 
+// IsDelete returns true if the operation is a `delete` of the expression. Protocol buffer
+// definitions do not enumerate the delete operator, so it is not part of the operator.
+func (u *UnaryPrefix) IsDelete() bool {
+	return u.Delete
+}
+
 // GetExpression returns the expression to which the unary operation is applied.
 func (u *UnaryPrefix) GetExpression() Node[NodeType] {
 	return u.Expression
@@ -150,6 +157,12 @@ func (u *UnaryPrefix) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if isDelete, ok := tempMap["isDelete"]; ok {
+		if err := json.Unmarshal(isDelete, &u.Delete); err != nil {
+			return err
+		}
+	}
+
 	if prefix, ok := tempMap["prefix"]; ok {
 		if err := json.Unmarshal(prefix, &u.Prefix); err != nil {
 			return err
@@ -216,6 +229,7 @@ func (u *UnaryPrefix) UnmarshalJSON(data []byte) error {
 }
 
 // ToProto converts the UnaryPrefix instance to its corresponding protocol buffer representation.
+// The protobuf definition has no delete operator, so delete operations carry the default operator.
 func (u *UnaryPrefix) ToProto() NodeType {
 	proto := ast_pb.UnaryPrefix{
 		Id:                    u.GetId(),
@@ -259,6 +273,7 @@ func (u *UnaryPrefix) Parse(
 
 			return expNode.GetId()
 		}(),
+		FileIndex: u.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	u.Operator = ast_pb.Operator_INCREMENT
@@ -270,6 +285,9 @@ func (u *UnaryPrefix) Parse(
 		u.Operator = ast_pb.Operator_BIT_NOT
 	} else if ctx.Sub() != nil {
 		u.Operator = ast_pb.Operator_SUBTRACT
+	} else if ctx.Delete() != nil {
+		u.Operator = ast_pb.Operator_O_DEFAULT
+		u.Delete = true
 	}
 
 	expression := NewExpression(u.ASTBuilder)
@@ -259,6 +259,7 @@ func (u *UnarySuffix) Parse(
 
 			return bodyNode.GetId()
 		}(),
+		FileIndex: u.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	u.Operator = ast_pb.Operator_INCREMENT
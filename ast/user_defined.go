@@ -136,6 +136,7 @@ func (b *UserDefinedValueTypeDefinition) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: contractNode.GetId(),
+		FileIndex:   b.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	b.Is = ctx.Is() != nil
@@ -148,6 +149,7 @@ func (b *UserDefinedValueTypeDefinition) Parse(
 			End:         int64(ctx.Type().GetSymbol().GetStop()),
 			Length:      int64(ctx.Type().GetSymbol().GetStop() - ctx.Type().GetSymbol().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(ctx.Type().GetSymbol().GetStart())),
 		}
 	}
 
@@ -160,6 +162,7 @@ func (b *UserDefinedValueTypeDefinition) Parse(
 			End:         int64(identifier.GetStart().GetStop()),
 			Length:      int64(identifier.GetStart().GetStop() - identifier.GetStart().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(identifier.GetStart().GetStart())),
 		}
 	} else if ctx.GetName() != nil {
 		identifier := ctx.GetName()
@@ -170,6 +173,7 @@ func (b *UserDefinedValueTypeDefinition) Parse(
 			End:         int64(identifier.GetStart().GetStop()),
 			Length:      int64(identifier.GetStart().GetStop() - identifier.GetStart().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(identifier.GetStart().GetStart())),
 		}
 	}
 
@@ -196,6 +200,7 @@ func (b *UserDefinedValueTypeDefinition) ParseGlobal(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: 0,
+		FileIndex:   b.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	b.Is = ctx.Is() != nil
@@ -208,6 +213,7 @@ func (b *UserDefinedValueTypeDefinition) ParseGlobal(
 			End:         int64(ctx.Type().GetSymbol().GetStop()),
 			Length:      int64(ctx.Type().GetSymbol().GetStop() - ctx.Type().GetSymbol().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(ctx.Type().GetSymbol().GetStart())),
 		}
 	}
 
@@ -220,6 +226,7 @@ func (b *UserDefinedValueTypeDefinition) ParseGlobal(
 			End:         int64(identifier.GetStart().GetStop()),
 			Length:      int64(identifier.GetStart().GetStop() - identifier.GetStart().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(identifier.GetStart().GetStart())),
 		}
 	} else if ctx.GetName() != nil {
 		identifier := ctx.GetName()
@@ -230,6 +237,7 @@ func (b *UserDefinedValueTypeDefinition) ParseGlobal(
 			End:         int64(identifier.GetStart().GetStop()),
 			Length:      int64(identifier.GetStart().GetStop() - identifier.GetStart().GetStart() + 1),
 			ParentIndex: b.GetId(),
+			FileIndex:   b.GetSourceFileIndex(int64(identifier.GetStart().GetStart())),
 		}
 	}
 
@@ -15,6 +15,7 @@ type UsingDirective struct {
 	TypeDescription *TypeDescription `json:"typeDescription"`
 	TypeName        *TypeName        `json:"typeName"`
 	LibraryName     *LibraryName     `json:"libraryName"`
+	FunctionList    []*LibraryName   `json:"functionList,omitempty"` // Functions attached by `using {f, L.g} for T`, which names no library.
 }
 
 // LibraryName represents the name of an external library referenced in a using directive.
@@ -51,7 +52,9 @@ func NewUsingDirective(b *ASTBuilder) *UsingDirective {
 // SetReferenceDescriptor sets the reference descriptions of the UsingDirective node.
 func (u *UsingDirective) SetReferenceDescriptor(refId int64, refDesc *TypeDescription) bool {
 	u.TypeDescription = refDesc
-	u.LibraryName.ReferencedDeclaration = refId
+	if u.LibraryName != nil {
+		u.LibraryName.ReferencedDeclaration = refId
+	}
 	return false
 }
 
@@ -85,6 +88,11 @@ func (u *UsingDirective) GetLibraryName() *LibraryName {
 	return u.LibraryName
 }
 
+// GetFunctionList returns the functions attached by the UsingDirective, if it names no library.
+func (u *UsingDirective) GetFunctionList() []*LibraryName {
+	return u.FunctionList
+}
+
 // GetReferencedDeclaration returns the referenced declaration of the UsingDirective.
 func (u *UsingDirective) GetReferencedDeclaration() int64 {
 	return u.TypeName.ReferencedDeclaration
@@ -103,14 +111,18 @@ func (u *UsingDirective) GetNodes() []Node[NodeType] {
 }
 
 // ToProto converts the UsingDirective instance to its corresponding protocol buffer representation.
+// The protocol buffer definition has no field for the function list.
 func (u *UsingDirective) ToProto() NodeType {
 	proto := ast_pb.Using{
-		Id:          u.Id,
-		Name:        u.LibraryName.Name,
-		NodeType:    u.NodeType,
-		Src:         u.Src.ToProto(),
-		LibraryName: u.LibraryName.ToProto(),
-		TypeName:    u.TypeName.ToProto().(*ast_pb.TypeName),
+		Id:       u.Id,
+		NodeType: u.NodeType,
+		Src:      u.Src.ToProto(),
+		TypeName: u.TypeName.ToProto().(*ast_pb.TypeName),
+	}
+
+	if u.LibraryName != nil {
+		proto.Name = u.LibraryName.Name
+		proto.LibraryName = u.LibraryName.ToProto()
 	}
 
 	return NewTypedStruct(&proto, "Using")
@@ -122,16 +134,41 @@ func (u *UsingDirective) Parse(
 	contractNode Node[NodeType],
 	bodyCtx parser.IContractBodyElementContext,
 	ctx *parser.UsingDirectiveContext,
+) {
+	u.parse(unit, contractNode, contractNode.GetId(), ctx)
+}
+
+// ParseGlobal parses a using directive declared at the file level, outside of any contract.
+func (u *UsingDirective) ParseGlobal(ctx *parser.UsingDirectiveContext) Node[NodeType] {
+	u.parse(nil, nil, 0, ctx)
+	u.globalDefinitions = append(u.globalDefinitions, u)
+	return u
+}
+
+// parse populates the UsingDirective from the provided context.
+func (u *UsingDirective) parse(
+	unit *SourceUnit[Node[ast_pb.SourceUnit]],
+	contractNode Node[NodeType],
+	parentId int64,
+	ctx *parser.UsingDirectiveContext,
 ) {
 	u.Src = SrcNode{
 		Line:        int64(ctx.GetStart().GetLine()),
 		Start:       int64(ctx.GetStart().GetStart()),
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
-		ParentIndex: contractNode.GetId(),
+		ParentIndex: parentId,
+		FileIndex:   u.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
-	u.LibraryName = u.getLibraryName(ctx.IdentifierPath(0))
+	if ctx.LBrace() != nil {
+		u.FunctionList = make([]*LibraryName, 0, len(ctx.AllIdentifierPath()))
+		for _, identifierCtx := range ctx.AllIdentifierPath() {
+			u.FunctionList = append(u.FunctionList, u.getLibraryName(identifierCtx))
+		}
+	} else {
+		u.LibraryName = u.getLibraryName(ctx.IdentifierPath(0))
+	}
 
 	if ctx.TypeName() != nil {
 		typeName := NewTypeName(u.ASTBuilder)
@@ -146,6 +183,17 @@ func (u *UsingDirective) Parse(
 	}
 }
 
+// EnterUsingDirective handles using directives declared at the file level. Directives within contracts are
+// parsed with the contract body.
+func (b *ASTBuilder) EnterUsingDirective(ctx *parser.UsingDirectiveContext) {
+	if _, ok := ctx.GetParent().(*parser.SourceUnitContext); !ok {
+		return
+	}
+
+	using := NewUsingDirective(b)
+	using.ParseGlobal(ctx)
+}
+
 // getLibraryName extracts and returns the LibraryName instance from the provided identifier context.
 func (u *UsingDirective) getLibraryName(identifierCtx parser.IIdentifierPathContext) *LibraryName {
 	return &LibraryName{
@@ -157,6 +205,7 @@ func (u *UsingDirective) getLibraryName(identifierCtx parser.IIdentifierPathCont
 			End:         int64(identifierCtx.GetStop().GetStop()),
 			Length:      int64(identifierCtx.GetStop().GetStop() - identifierCtx.GetStart().GetStart() + 1),
 			ParentIndex: u.Id,
+			FileIndex:   u.GetSourceFileIndex(int64(identifierCtx.GetStart().GetStart())),
 		},
 		Name: identifierCtx.GetText(),
 		ReferencedDeclaration: func() int64 {
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/goccy/go-json"
 
 	v3 "github.com/cncf/xds/go/xds/type/v3"
@@ -198,6 +199,7 @@ func (v *VariableDeclaration) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.GetId(),
+		FileIndex:   v.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.VariableDeclaration() != nil {
@@ -208,11 +210,24 @@ func (v *VariableDeclaration) Parse(
 	}
 
 	if ctx.VariableDeclarationTuple() != nil {
-		for _, declarationCtx := range ctx.VariableDeclarationTuple().AllVariableDeclaration() {
-			declaration := NewDeclaration(v.ASTBuilder)
-			declaration.ParseVariableDeclaration(unit, contractNode, fnNode, bodyNode, v, declarationCtx)
-			v.Declarations = append(v.Declarations, declaration)
-			v.Assignments = append(v.Assignments, declaration.GetId())
+		// Omitted tuple components are recorded as zero assignments, the same way solc
+		// records them as null, so the position of every declaration is retained.
+		emptySlot := false
+		for _, child := range ctx.VariableDeclarationTuple().GetChildren() {
+			switch childCtx := child.(type) {
+			case *parser.VariableDeclarationContext:
+				declaration := NewDeclaration(v.ASTBuilder)
+				declaration.ParseVariableDeclaration(unit, contractNode, fnNode, bodyNode, v, childCtx)
+				v.Declarations = append(v.Declarations, declaration)
+				v.Assignments = append(v.Assignments, declaration.GetId())
+				emptySlot = false
+			case antlr.TerminalNode:
+				tokenType := childCtx.GetSymbol().GetTokenType()
+				if emptySlot && (tokenType == parser.SolidityParserComma || tokenType == parser.SolidityParserRParen) {
+					v.Assignments = append(v.Assignments, 0)
+				}
+				emptySlot = tokenType != parser.SolidityParserRParen
+			}
 		}
 	}
 
@@ -164,6 +164,7 @@ func (w *WhileStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.Id,
+		FileIndex:   w.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Parsing the condition expression.
@@ -185,6 +186,9 @@ func (w *WhileStatement) Parse(
 				w.Body.Statements = append(w.Body.Statements, bodyNode)
 			}
 		}
+	} else if ctx.Statement() != nil && ctx.Statement().Block() == nil {
+		// Body that is not a block is a single statement, as in while (...) i--;
+		w.Body.parseStatements(unit, contractNode, w, ctx.Statement().GetChild(0))
 	}
 
 	return w
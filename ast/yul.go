@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"strings"
+
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
 	"github.com/unpackdev/solgo/parser"
 )
@@ -9,10 +11,11 @@ import (
 type Yul struct {
 	*ASTBuilder // Embedded ASTBuilder provides building functionalities for AST nodes.
 
-	Id       int64           `json:"id"`       // Id uniquely identifies the assembly statement.
-	NodeType ast_pb.NodeType `json:"nodeType"` // NodeType specifies the type of the node.
-	Src      SrcNode         `json:"src"`      // Src contains source location details of the node.
-	Body     *BodyNode       `json:"body"`     // Body represents the content of the assembly statement.
+	Id       int64           `json:"id"`              // Id uniquely identifies the assembly statement.
+	NodeType ast_pb.NodeType `json:"nodeType"`        // NodeType specifies the type of the node.
+	Src      SrcNode         `json:"src"`             // Src contains source location details of the node.
+	Body     *BodyNode       `json:"body"`            // Body represents the content of the assembly statement.
+	Flags    []string        `json:"flags,omitempty"` // Flags of the assembly statement, such as memory-safe.
 }
 
 // NewYul creates a new Yul and initializes its fields.
@@ -44,6 +47,11 @@ func (a *Yul) GetBody() *BodyNode {
 	return a.Body
 }
 
+// GetFlags returns the flags of the assembly statement, such as memory-safe.
+func (a *Yul) GetFlags() []string {
+	return a.Flags
+}
+
 // GetNodes retrieves the list of statements present in the assembly statement's body.
 func (a *Yul) GetNodes() []Node[NodeType] {
 	return a.Body.Statements
@@ -56,7 +64,7 @@ func (a *Yul) GetTypeDescription() *TypeDescription {
 }
 
 // ToProto converts the assembly statement into its protobuf representation.
-// Note: Complete implementation is yet to be provided.
+// Note: Complete implementation is yet to be provided. Flags are not carried, as there is no field for them.
 func (a *Yul) ToProto() NodeType {
 	proto := ast_pb.AssemblyStatement{
 		Id:       a.GetId(),
@@ -90,6 +98,13 @@ func (a *Yul) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: bodyNode.GetId(),
+		FileIndex:   a.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
+	}
+
+	if ctx.AssemblyFlags() != nil {
+		for _, flagCtx := range ctx.AssemblyFlags().AllAssemblyFlagString() {
+			a.Flags = append(a.Flags, strings.Trim(flagCtx.GetText(), "\""))
+		}
 	}
 
 	a.Body = NewBodyNode(a.ASTBuilder, false)
@@ -176,25 +176,12 @@ func (y *YulAssignment) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: assemblyNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.AllYulPath() != nil {
 		for _, path := range ctx.AllYulPath() {
-			for _, identifier := range path.AllYulIdentifier() {
-				y.VariableNames = append(y.VariableNames, &YulIdentifier{
-					Id:       y.GetNextID(),
-					NodeType: ast_pb.NodeType_YUL_IDENTIFIER,
-					Src: SrcNode{
-						Line:        int64(identifier.GetSymbol().GetLine()),
-						Column:      int64(identifier.GetSymbol().GetColumn()),
-						Start:       int64(identifier.GetSymbol().GetStart()),
-						End:         int64(identifier.GetSymbol().GetStop()),
-						Length:      int64(identifier.GetSymbol().GetStop() - identifier.GetSymbol().GetStart() + 1),
-						ParentIndex: y.GetId(),
-					},
-					Name: identifier.GetText(),
-				})
-			}
+			y.VariableNames = append(y.VariableNames, NewYulPathIdentifier(y.ASTBuilder, y.GetId(), path))
 		}
 	}
 
@@ -162,6 +162,7 @@ func (y *YulBlockStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.AllYulStatement() != nil {
@@ -86,6 +86,7 @@ func (y *YulBreakStatement) Parse(
 		End:         int64(ctx.GetSymbol().GetStop()),
 		Length:      int64(ctx.GetSymbol().GetStop() - ctx.GetSymbol().GetStart() + 1),
 		ParentIndex: statementNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetSymbol().GetStart())),
 	}
 
 	return y
@@ -152,6 +152,11 @@ func (y *YulExpressionStatement) Parse(
 		End:         int64(ctx.GetStart().GetStop()),
 		Length:      int64(ctx.GetStart().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: parentNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
+	}
+
+	if ctx.YulPath() != nil {
+		y.Expression = NewYulPathIdentifier(y.ASTBuilder, parentNode.GetId(), ctx.YulPath())
 	}
 
 	if ctx.YulLiteral() != nil {
@@ -187,6 +192,10 @@ func ParseYulExpression(
 	parentNode Node[NodeType],
 	ctx parser.IYulExpressionContext,
 ) Node[NodeType] {
+	if ctx.YulPath() != nil {
+		return NewYulPathIdentifier(b, parentNode.GetId(), ctx.YulPath())
+	}
+
 	if ctx.YulLiteral() != nil {
 		literalStatement := NewYulLiteralStatement(b)
 		return literalStatement.Parse(
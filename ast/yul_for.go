@@ -249,6 +249,7 @@ func (y *YulForStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: assemblyNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.GetCond() != nil {
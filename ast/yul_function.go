@@ -21,6 +21,9 @@ type YulFunctionDefinition struct {
 	// Src is the source location information of the YUL function definition.
 	Src SrcNode `json:"src"`
 
+	// Name is the name of the YUL function.
+	Name string `json:"name"`
+
 	// Arguments is a list of YUL identifiers representing function arguments.
 	Arguments []*YulIdentifier `json:"arguments"`
 
@@ -60,6 +63,11 @@ func (y *YulFunctionDefinition) GetSrc() SrcNode {
 	return y.Src
 }
 
+// GetName returns the name of the YUL function.
+func (y *YulFunctionDefinition) GetName() string {
+	return y.Name
+}
+
 // GetNodes returns a list containing the body node.
 func (y *YulFunctionDefinition) GetNodes() []Node[NodeType] {
 	toReturn := make([]Node[NodeType], 0)
@@ -143,6 +151,12 @@ func (f *YulFunctionDefinition) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if name, ok := tempMap["name"]; ok {
+		if err := json.Unmarshal(name, &f.Name); err != nil {
+			return err
+		}
+	}
+
 	if arguments, ok := tempMap["arguments"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(arguments, &nodes); err != nil {
@@ -212,9 +226,14 @@ func (y *YulFunctionDefinition) Parse(
 		Line:        int64(ctx.GetStart().GetLine()),
 		Column:      int64(ctx.GetStart().GetColumn()),
 		Start:       int64(ctx.GetStart().GetStart()),
-		End:         int64(ctx.GetStart().GetStop()),
-		Length:      int64(ctx.GetStart().GetStop() - ctx.GetStart().GetStart() + 1),
+		End:         int64(ctx.GetStop().GetStop()),
+		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: statementNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
+	}
+
+	if len(ctx.AllYulIdentifier()) > 0 {
+		y.Name = ctx.YulIdentifier(0).GetText()
 	}
 
 	for _, argument := range ctx.GetArguments() {
@@ -229,6 +248,7 @@ func (y *YulFunctionDefinition) Parse(
 				End:         int64(argument.GetStop()),
 				Length:      int64(argument.GetStop() - argument.GetStart() + 1),
 				ParentIndex: y.GetId(),
+				FileIndex:   y.GetSourceFileIndex(int64(argument.GetStart())),
 			},
 		})
 	}
@@ -253,6 +273,7 @@ func (y *YulFunctionDefinition) Parse(
 				End:         int64(argument.GetStop()),
 				Length:      int64(argument.GetStop() - argument.GetStart() + 1),
 				ParentIndex: y.GetId(),
+				FileIndex:   y.GetSourceFileIndex(int64(argument.GetStart())),
 			},
 		})
 	}
@@ -178,6 +178,7 @@ func (y *YulFunctionCallStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: assemblyNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	if ctx.YulEVMBuiltin() != nil {
@@ -192,6 +193,7 @@ func (y *YulFunctionCallStatement) Parse(
 				End:         int64(builtin.GetSymbol().GetStop()),
 				Length:      int64(builtin.GetSymbol().GetStop() - builtin.GetSymbol().GetStart() + 1),
 				ParentIndex: y.GetId(),
+				FileIndex:   y.GetSourceFileIndex(int64(builtin.GetSymbol().GetStart())),
 			},
 			Name: builtin.GetText(),
 		}
@@ -207,6 +209,7 @@ func (y *YulFunctionCallStatement) Parse(
 				End:         int64(identifier.GetSymbol().GetStop()),
 				Length:      int64(identifier.GetSymbol().GetStop() - identifier.GetSymbol().GetStart() + 1),
 				ParentIndex: y.GetId(),
+				FileIndex:   y.GetSourceFileIndex(int64(identifier.GetSymbol().GetStart())),
 			},
 			Name: identifier.GetText(),
 		}
@@ -215,22 +218,7 @@ func (y *YulFunctionCallStatement) Parse(
 	if ctx.AllYulExpression() != nil {
 		for _, expression := range ctx.AllYulExpression() {
 			if expression.YulPath() != nil {
-				path := expression.YulPath()
-				for _, identifier := range path.AllYulIdentifier() {
-					y.Arguments = append(y.Arguments, &YulIdentifier{
-						Id:       y.GetNextID(),
-						NodeType: ast_pb.NodeType_YUL_IDENTIFIER,
-						Name:     identifier.GetText(),
-						Src: SrcNode{
-							Line:        int64(identifier.GetSymbol().GetLine()),
-							Column:      int64(identifier.GetSymbol().GetColumn()),
-							Start:       int64(identifier.GetSymbol().GetStart()),
-							End:         int64(identifier.GetSymbol().GetStop()),
-							Length:      int64(identifier.GetSymbol().GetStop() - identifier.GetSymbol().GetStart() + 1),
-							ParentIndex: y.GetId(),
-						},
-					})
-				}
+				y.Arguments = append(y.Arguments, NewYulPathIdentifier(y.ASTBuilder, y.GetId(), expression.YulPath()))
 			}
 
 			if expression.YulFunctionCall() != nil {
@@ -2,6 +2,7 @@ package ast
 
 import (
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/parser"
 )
 
 // YulIdentifier represents a YUL identifier in the abstract syntax tree.
@@ -21,6 +22,26 @@ type YulIdentifier struct {
 	Name string `json:"name"`
 }
 
+// NewYulPathIdentifier creates a YulIdentifier for the provided path. Paths such as x.slot are kept
+// as a single identifier named by the whole path, the same way solc represents them.
+func NewYulPathIdentifier(b *ASTBuilder, parentId int64, ctx parser.IYulPathContext) *YulIdentifier {
+	return &YulIdentifier{
+		ASTBuilder: b,
+		Id:         b.GetNextID(),
+		NodeType:   ast_pb.NodeType_YUL_IDENTIFIER,
+		Name:       ctx.GetText(),
+		Src: SrcNode{
+			Line:        int64(ctx.GetStart().GetLine()),
+			Column:      int64(ctx.GetStart().GetColumn()),
+			Start:       int64(ctx.GetStart().GetStart()),
+			End:         int64(ctx.GetStop().GetStop()),
+			Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
+			ParentIndex: parentId,
+			FileIndex:   b.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
+		},
+	}
+}
+
 // SetReferenceDescriptor sets the reference descriptions of the YulIdentifier node.
 func (y *YulIdentifier) SetReferenceDescriptor(refId int64, refDesc *TypeDescription) bool {
 	return false
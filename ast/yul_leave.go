@@ -85,6 +85,7 @@ func (y *YulLeaveStatement) Parse(
 		End:         int64(ctx.GetSymbol().GetStop()),
 		Length:      int64(ctx.GetSymbol().GetStop() - ctx.GetSymbol().GetStart() + 1),
 		ParentIndex: statementNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetSymbol().GetStart())),
 	}
 
 	return y
@@ -113,6 +113,7 @@ func (y *YulLiteralStatement) Parse(
 			End:         int64(literal.GetStart().GetStop()),
 			Length:      int64(literal.GetStart().GetStop() - literal.GetStart().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(literal.GetStart().GetStart())),
 		}
 	}
 
@@ -128,6 +129,7 @@ func (y *YulLiteralStatement) Parse(
 			End:         int64(literal.GetSymbol().GetStop()),
 			Length:      int64(literal.GetSymbol().GetStop() - literal.GetSymbol().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(literal.GetSymbol().GetStart())),
 		}
 	}
 
@@ -143,6 +145,7 @@ func (y *YulLiteralStatement) Parse(
 			End:         int64(literal.GetSymbol().GetStop()),
 			Length:      int64(literal.GetSymbol().GetStop() - literal.GetSymbol().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(literal.GetSymbol().GetStart())),
 		}
 	}
 
@@ -158,6 +161,7 @@ func (y *YulLiteralStatement) Parse(
 			End:         int64(literal.GetSymbol().GetStop()),
 			Length:      int64(literal.GetSymbol().GetStop() - literal.GetSymbol().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(literal.GetSymbol().GetStart())),
 		}
 
 		bytes, _ := hex.DecodeString(strings.Replace(y.HexValue, "0x", "", -1))
@@ -181,6 +185,7 @@ func (y *YulLiteralStatement) Parse(
 			End:         int64(literal.GetSymbol().GetStop()),
 			Length:      int64(literal.GetSymbol().GetStop() - literal.GetSymbol().GetStart() + 1),
 			ParentIndex: parentNode.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(literal.GetSymbol().GetStart())),
 		}
 	}
 
@@ -11,10 +11,11 @@ import (
 type YulSwitchStatement struct {
 	*ASTBuilder // Embedded ASTBuilder for utility functions.
 
-	Id       int64            `json:"id"`       // Id is the unique identifier for the switch statement.
-	NodeType ast_pb.NodeType  `json:"nodeType"` // NodeType specifies the type of the node.
-	Src      SrcNode          `json:"src"`      // Src provides source location details of the switch statement.
-	Cases    []Node[NodeType] `json:"cases"`    // Cases holds the different cases of the switch statement.
+	Id         int64            `json:"id"`         // Id is the unique identifier for the switch statement.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`   // NodeType specifies the type of the node.
+	Src        SrcNode          `json:"src"`        // Src provides source location details of the switch statement.
+	Expression Node[NodeType]   `json:"expression"` // Expression is the value the cases are matched against.
+	Cases      []Node[NodeType] `json:"cases"`      // Cases holds the different cases of the switch statement, including the default one.
 }
 
 // NewYulSwitchStatement creates and initializes a new YulSwitchStatement.
@@ -51,6 +52,9 @@ func (y *YulSwitchStatement) GetSrc() SrcNode {
 // GetNodes returns a list of nodes associated with the YulSwitchStatement.
 func (y *YulSwitchStatement) GetNodes() []Node[NodeType] {
 	toReturn := make([]Node[NodeType], 0)
+	if y.Expression != nil {
+		toReturn = append(toReturn, y.Expression)
+	}
 	toReturn = append(toReturn, y.Cases...)
 	return toReturn
 }
@@ -61,12 +65,18 @@ func (y *YulSwitchStatement) GetTypeDescription() *TypeDescription {
 	return &TypeDescription{}
 }
 
+// GetExpression returns the value the cases of the YulSwitchStatement are matched against.
+func (y *YulSwitchStatement) GetExpression() Node[NodeType] {
+	return y.Expression
+}
+
+// GetCases returns the cases of the YulSwitchStatement, including the default one.
 func (y *YulSwitchStatement) GetCases() []Node[NodeType] {
 	return y.Cases
 }
 
 // ToProto converts the YulSwitchStatement into its protobuf representation.
-// Note: This method currently returns an empty Statement.
+// Protocol buffer definitions have no field for the switch expression, so only the cases are carried.
 func (y *YulSwitchStatement) ToProto() NodeType {
 	toReturn := ast_pb.YulSwitchStatement{
 		Id:       y.GetId(),
@@ -108,6 +118,26 @@ func (f *YulSwitchStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if expression, ok := tempMap["expression"]; ok {
+		var tempNodeMap map[string]json.RawMessage
+		if err := json.Unmarshal(expression, &tempNodeMap); err != nil {
+			return err
+		}
+
+		if tempNodeMap != nil {
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(expression, tempNodeType)
+			if err != nil {
+				return err
+			}
+			f.Expression = node
+		}
+	}
+
 	if cases, ok := tempMap["cases"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(cases, &nodes); err != nil {
@@ -154,6 +184,14 @@ func (y *YulSwitchStatement) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: statementNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
+	}
+
+	if ctx.YulExpression() != nil {
+		y.Expression = ParseYulExpression(
+			y.ASTBuilder, unit, contractNode, fnNode, bodyNode, assemblyNode, statementNode, nil, nil,
+			y, ctx.YulExpression(),
+		)
 	}
 
 	// Parse all switch cases if present.
@@ -167,5 +205,26 @@ func (y *YulSwitchStatement) Parse(
 		}
 	}
 
+	// Default case is not a switch case in the grammar, it is represented as a case without a value.
+	if ctx.YulDefault() != nil && ctx.YulBlock() != nil {
+		defaultCase := NewYulSwitchCaseStatement(y.ASTBuilder)
+		defaultCase.Src = SrcNode{
+			Line:        int64(ctx.YulDefault().GetSymbol().GetLine()),
+			Column:      int64(ctx.YulDefault().GetSymbol().GetColumn()),
+			Start:       int64(ctx.YulDefault().GetSymbol().GetStart()),
+			End:         int64(ctx.YulBlock().GetStop().GetStop()),
+			Length:      int64(ctx.YulBlock().GetStop().GetStop() - ctx.YulDefault().GetSymbol().GetStart() + 1),
+			ParentIndex: y.GetId(),
+			FileIndex:   y.GetSourceFileIndex(int64(ctx.YulDefault().GetSymbol().GetStart())),
+		}
+
+		block := NewYulBlockStatement(y.ASTBuilder)
+		defaultCase.Body = block.Parse(
+			unit, contractNode, fnNode, bodyNode, assemblyNode, statementNode, nil, defaultCase,
+			ctx.YulBlock().(*parser.YulBlockContext),
+		)
+		y.Cases = append(y.Cases, defaultCase)
+	}
+
 	return y
 }
@@ -193,6 +193,7 @@ func (y *YulVariable) Parse(
 		End:         int64(ctx.GetStop().GetStop()),
 		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
 		ParentIndex: statementNode.GetId(),
+		FileIndex:   y.GetSourceFileIndex(int64(ctx.GetStart().GetStart())),
 	}
 
 	// Determine if "let" keyword is present.
@@ -211,6 +212,7 @@ func (y *YulVariable) Parse(
 				End:         int64(variable.GetStop()),
 				Length:      int64(variable.GetStop() - variable.GetStart() + 1),
 				ParentIndex: y.GetId(),
+				FileIndex:   y.GetSourceFileIndex(int64(variable.GetStart())),
 			},
 		})
 	}
@@ -0,0 +1,383 @@
+package detectors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// Context gives detectors access to the analysed program: the IR, the AST, the call graph and lazily built
+// function control flow graphs. It also provides helpers to describe findings in the audit format.
+type Context struct {
+	ctx       context.Context
+	builder   *ir.Builder
+	root      *ir.RootSourceUnit
+	cfg       *cfg.Builder
+	callGraph *cfg.CallGraph
+	graphs    map[*ir.Function]*cfg.FunctionGraph
+}
+
+// NewContext prepares the analysis context for an IR builder that has already been built.
+func NewContext(ctx context.Context, builder *ir.Builder) (*Context, error) {
+	if builder == nil {
+		return nil, errors.New("builder is not set (at detector context)")
+	}
+
+	root := builder.GetRoot()
+	if root == nil {
+		return nil, errors.New("root node is not set in IR builder, sources have to be built before analysis")
+	}
+
+	cfgBuilder, err := cfg.NewBuilder(ctx, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	callGraph, err := cfgBuilder.BuildCallGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Context{
+		ctx:       ctx,
+		builder:   builder,
+		root:      root,
+		cfg:       cfgBuilder,
+		callGraph: callGraph,
+		graphs:    make(map[*ir.Function]*cfg.FunctionGraph),
+	}, nil
+}
+
+// GetContext returns the context the analysis is running in.
+func (c *Context) GetContext() context.Context {
+	return c.ctx
+}
+
+// GetBuilder returns the IR builder of the analysed sources.
+func (c *Context) GetBuilder() *ir.Builder {
+	return c.builder
+}
+
+// GetRoot returns the IR root of the analysed sources.
+func (c *Context) GetRoot() *ir.RootSourceUnit {
+	return c.root
+}
+
+// GetContracts returns all contracts, libraries and interfaces of the analysed sources.
+func (c *Context) GetContracts() []*ir.Contract {
+	return c.root.GetContracts()
+}
+
+// GetCallGraph returns the whole-program call graph.
+func (c *Context) GetCallGraph() *cfg.CallGraph {
+	return c.callGraph
+}
+
+// GetFunctionGraph returns the control flow graph of the function, building it on first use.
+func (c *Context) GetFunctionGraph(contract *ir.Contract, function *ir.Function) *cfg.FunctionGraph {
+	if graph, ok := c.graphs[function]; ok {
+		return graph
+	}
+
+	graph := cfg.BuildFunctionGraph(contract.GetName(), function)
+	c.graphs[function] = graph
+	return graph
+}
+
+// GetNode returns the AST node with the provided id or nil if it does not exist.
+func (c *Context) GetNode(id int64) ast.Node[ast.NodeType] {
+	tree := c.builder.GetAstBuilder().GetTree()
+	if tree == nil || tree.GetRoot() == nil {
+		return nil
+	}
+	return tree.GetById(id)
+}
+
+// GetText returns the source code of the node or an empty string if the sources are not available.
+func (c *Context) GetText(src ast.SrcNode) string {
+	astBuilder := c.builder.GetAstBuilder()
+	file := astBuilder.GetSourceFile(src.GetFileIndex())
+	if file == nil {
+		return ""
+	}
+
+	content := []rune(file.GetContent())
+	start := src.GetStart() - file.Start
+	end := start + src.GetLength()
+	if start < 0 || end > int64(len(content)) || start > end {
+		return ""
+	}
+
+	return string(content[start:end])
+}
+
+// SourceMapping resolves the source node into the audit source mapping, including all lines it spans.
+func (c *Context) SourceMapping(src ast.SrcNode) audit.SourceMapping {
+	astBuilder := c.builder.GetAstBuilder()
+
+	location, err := astBuilder.LocateSrc(src)
+	if err != nil {
+		return audit.SourceMapping{
+			Start:  int(src.GetStart()),
+			Length: int(src.GetLength()),
+			Lines:  []int32{int32(src.GetLine())},
+		}
+	}
+
+	absolute := location.Path
+	if file := astBuilder.GetSourceFile(location.FileIndex); file != nil && filepath.IsAbs(file.GetDiskPath()) {
+		absolute = file.GetDiskPath()
+	} else if sources := c.builder.GetSources(); sources != nil && !filepath.IsAbs(absolute) && sources.LocalSourcesPath != "" {
+		absolute = filepath.Join(sources.LocalSourcesPath, location.Path)
+	}
+
+	text := []rune(c.GetText(src))
+	lines := make([]int32, 0)
+	for line := location.Line; line <= location.Line+int64(strings.Count(string(text), "\n")); line++ {
+		lines = append(lines, int32(line))
+	}
+
+	endingColumn := int(location.Column) + len(text) + 1
+	if idx := strings.LastIndex(string(text), "\n"); idx >= 0 {
+		endingColumn = len([]rune(string(text)[idx+1:])) + 1
+	}
+
+	return audit.SourceMapping{
+		Start:            int(location.Start),
+		Length:           int(location.Length),
+		FilenameRelative: location.Path,
+		FilenameAbsolute: absolute,
+		FilenameShort:    location.Path,
+		Lines:            lines,
+		StartingColumn:   int(location.Column) + 1,
+		EndingColumn:     endingColumn,
+	}
+}
+
+// ContractElement describes the contract as an element of a finding.
+func (c *Context) ContractElement(contract *ir.Contract) audit.Element {
+	src := contract.GetSrc()
+	if unit := contract.GetAST(); unit != nil && unit.GetContract() != nil {
+		src = unit.GetContract().GetSrc()
+	}
+
+	return audit.Element{
+		Type:          "contract",
+		Name:          contract.GetName(),
+		SourceMapping: c.SourceMapping(src),
+	}
+}
+
+// FunctionElement describes a function, constructor, fallback, receive or modifier as an element of a finding.
+func (c *Context) FunctionElement(contract *ir.Contract, name string, signature string, src ast.SrcNode) audit.Element {
+	parent := c.ContractElement(contract)
+	return audit.Element{
+		Type:               "function",
+		Name:               name,
+		Signature:          signature,
+		SourceMapping:      c.SourceMapping(src),
+		TypeSpecificFields: audit.TypeSpecificFields{Parent: &parent},
+	}
+}
+
+// VariableElement describes a state variable as an element of a finding.
+func (c *Context) VariableElement(contract *ir.Contract, variable *ir.StateVariable) audit.Element {
+	parent := c.ContractElement(contract)
+	return audit.Element{
+		Type:               "variable",
+		Name:               variable.GetName(),
+		SourceMapping:      c.SourceMapping(variable.GetSrc()),
+		TypeSpecificFields: audit.TypeSpecificFields{Parent: &parent},
+	}
+}
+
+// NodeElement describes a single statement or expression within a function as an element of a finding.
+// The underlying type describes the role of the node in the finding, such as `external_calls`.
+func (c *Context) NodeElement(parent audit.Element, src ast.SrcNode, underlyingType string, variableName string) audit.Element {
+	// Statements include the terminating semicolon which is not part of the node itself.
+	if text := c.GetText(src); strings.HasSuffix(text, ";") {
+		src.Length--
+		src.End--
+	}
+
+	toReturn := audit.Element{
+		Type:               "node",
+		Name:               c.GetText(src),
+		SourceMapping:      c.SourceMapping(src),
+		TypeSpecificFields: audit.TypeSpecificFields{Parent: &parent},
+	}
+
+	if underlyingType != "" {
+		toReturn.AdditionalFields = &audit.AdditionalFields{
+			UnderlyingType: underlyingType,
+			VariableName:   variableName,
+		}
+	}
+
+	return toReturn
+}
+
+// Description builds the plain text and markdown descriptions of a finding side by side.
+type Description struct {
+	ctx      *Context
+	plain    strings.Builder
+	markdown strings.Builder
+	first    string
+}
+
+// NewDescription starts a new finding description.
+func (c *Context) NewDescription() *Description {
+	return &Description{ctx: c}
+}
+
+// Text appends text to both descriptions.
+func (d *Description) Text(format string, args ...interface{}) *Description {
+	text := fmt.Sprintf(format, args...)
+	d.plain.WriteString(text)
+	d.markdown.WriteString(text)
+	return d
+}
+
+// Ref appends a reference to the source of an element, rendered as `label (file#lines)` in plain text
+// and as a markdown link. The first reference is used as the first markdown element of the finding.
+func (d *Description) Ref(label string, element audit.Element) *Description {
+	mapping := element.SourceMapping
+	plainLines, markdownLines := formatLines(mapping.Lines)
+
+	d.plain.WriteString(fmt.Sprintf("%s (%s#%s)", label, mapping.FilenameRelative, plainLines))
+	d.markdown.WriteString(fmt.Sprintf("[%s](%s#%s)", label, mapping.FilenameRelative, markdownLines))
+
+	if d.first == "" {
+		d.first = fmt.Sprintf("%s#%s", mapping.FilenameRelative, markdownLines)
+	}
+
+	return d
+}
+
+// formatLines renders the lines of a source mapping the way Slither does, such as `12-21` and `L12-L21`.
+func formatLines(lines []int32) (string, string) {
+	switch len(lines) {
+	case 0:
+		return "", ""
+	case 1:
+		return fmt.Sprintf("%d", lines[0]), fmt.Sprintf("L%d", lines[0])
+	default:
+		first, last := lines[0], lines[len(lines)-1]
+		return fmt.Sprintf("%d-%d", first, last), fmt.Sprintf("L%d-L%d", first, last)
+	}
+}
+
+// NewFinding creates an issue reported by the detector. The id of the issue is derived from its check and
+// description, so the same issue keeps its id across runs.
+func (c *Context) NewFinding(detector Detector, description *Description, elements ...audit.Element) audit.Detector {
+	plain := description.plain.String()
+	hash := sha256.Sum256([]byte(detector.Name() + plain))
+
+	return audit.Detector{
+		Elements:             elements,
+		Description:          plain,
+		Markdown:             description.markdown.String(),
+		FirstMarkdownElement: description.first,
+		ID:                   hex.EncodeToString(hash[:]),
+		Check:                detector.Name(),
+		Impact:               detector.Impact().String(),
+		Confidence:           detector.Confidence().String(),
+	}
+}
+
+// callableScope is a function, constructor, fallback, receive or modifier of a contract together with its
+// AST, which is what most detectors iterate over.
+type callableScope struct {
+	contract  *ir.Contract
+	name      string
+	signature string
+	kind      ast_pb.NodeType
+	src       ast.SrcNode
+	body      *ast.BodyNode
+	modifiers []*ast.ModifierInvocation
+	function  *ir.Function
+	mutable   bool
+}
+
+// label returns the `Contract.signature` label of the callable used within descriptions.
+func (s *callableScope) label() string {
+	return fmt.Sprintf("%s.%s", s.contract.GetName(), s.signature)
+}
+
+// getCallables returns every implemented callable of the contract, including modifiers.
+func (c *Context) getCallables(contract *ir.Contract) []*callableScope {
+	unit, ok := contract.GetAST().GetContract().(ir.ContractNode)
+	if !ok {
+		return nil
+	}
+
+	functions := make(map[int64]*ir.Function)
+	for _, function := range contract.GetFunctions() {
+		functions[function.GetId()] = function
+	}
+
+	isMutable := func(mutability ast_pb.Mutability) bool {
+		return mutability != ast_pb.Mutability_VIEW && mutability != ast_pb.Mutability_PURE
+	}
+
+	toReturn := make([]*callableScope, 0)
+
+	if constructor := unit.GetConstructor(); constructor != nil && constructor.GetBody() != nil {
+		toReturn = append(toReturn, &callableScope{
+			contract: contract, name: "constructor", signature: "constructor()", kind: constructor.GetType(),
+			src: constructor.GetSrc(), body: constructor.GetBody(), modifiers: constructor.GetModifiers(), mutable: true,
+		})
+	}
+
+	for _, function := range unit.GetFunctions() {
+		if function.GetBody() == nil {
+			continue
+		}
+		toReturn = append(toReturn, &callableScope{
+			contract: contract, name: function.GetName(), signature: function.GetSignatureRaw(), kind: function.GetType(),
+			src: function.GetSrc(), body: function.GetBody(), modifiers: function.GetModifiers(),
+			function: functions[function.GetId()], mutable: isMutable(function.GetStateMutability()),
+		})
+	}
+
+	if fallback := unit.GetFallback(); fallback != nil && fallback.GetBody() != nil {
+		toReturn = append(toReturn, &callableScope{
+			contract: contract, name: "fallback", signature: "fallback()", kind: fallback.GetType(),
+			src: fallback.GetSrc(), body: fallback.GetBody(), modifiers: fallback.GetModifiers(),
+			mutable: isMutable(fallback.GetStateMutability()),
+		})
+	}
+
+	if receive := unit.GetReceive(); receive != nil && receive.GetBody() != nil {
+		toReturn = append(toReturn, &callableScope{
+			contract: contract, name: "receive", signature: "receive()", kind: receive.GetType(),
+			src: receive.GetSrc(), body: receive.GetBody(), modifiers: receive.GetModifiers(), mutable: true,
+		})
+	}
+
+	for _, node := range unit.GetNodes() {
+		if modifier, ok := node.(*ast.ModifierDefinition); ok && modifier.GetBody() != nil {
+			toReturn = append(toReturn, &callableScope{
+				contract: contract, name: modifier.GetName(), signature: fmt.Sprintf("%s()", modifier.GetName()), kind: modifier.GetType(),
+				src: modifier.GetSrc(), body: modifier.GetBody(), mutable: true,
+			})
+		}
+	}
+
+	return toReturn
+}
+
+// element describes the callable as an element of a finding.
+func (c *Context) element(scope *callableScope) audit.Element {
+	return c.FunctionElement(scope.contract, scope.name, scope.signature, scope.src)
+}
@@ -0,0 +1,134 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// Detector is the interface every native static analysis check implements.
+type Detector interface {
+	// Name returns the unique name of the check, used as the check of the reported issues.
+	Name() string
+
+	// Impact returns the severity of the issues reported by the check.
+	Impact() audit.ImpactLevel
+
+	// Confidence returns how confident the check is that reported issues are true positives.
+	Confidence() audit.ConfidenceLevel
+
+	// Detect inspects the program and returns the issues found.
+	Detect(ctx *Context) ([]audit.Detector, error)
+}
+
+// DefaultDetectors returns a new instance of every detector shipped with the package.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		&ReentrancyEthDetector{},
+		&ReentrancyNoEthDetector{},
+		&TxOriginDetector{},
+		&UncheckedLowLevelDetector{},
+		&SuicidalDetector{},
+		&UnprotectedDelegatecallDetector{},
+		&UninitializedStorageDetector{},
+		&ShadowingStateDetector{},
+	}
+}
+
+// Engine runs a set of detectors against the IR of the parsed sources.
+type Engine struct {
+	ctx       context.Context
+	builder   *ir.Builder
+	detectors []Detector
+}
+
+// NewEngine creates a new detector engine for the provided IR builder. The builder must be built before
+// calling Analyze. When no detectors are provided, the engine runs DefaultDetectors.
+func NewEngine(ctx context.Context, builder *ir.Builder, detectors ...Detector) (*Engine, error) {
+	if builder == nil {
+		return nil, errors.New("builder is not set (at detector engine)")
+	}
+
+	if len(detectors) == 0 {
+		detectors = DefaultDetectors()
+	}
+
+	return &Engine{
+		ctx:       ctx,
+		builder:   builder,
+		detectors: detectors,
+	}, nil
+}
+
+// GetDetectors returns the detectors registered with the engine.
+func (e *Engine) GetDetectors() []Detector {
+	return e.detectors
+}
+
+// Register adds detectors to the engine. Detectors with a name that is already registered replace the existing ones.
+func (e *Engine) Register(detectors ...Detector) {
+	for _, detector := range detectors {
+		replaced := false
+		for i, existing := range e.detectors {
+			if existing.Name() == detector.Name() {
+				e.detectors[i] = detector
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			e.detectors = append(e.detectors, detector)
+		}
+	}
+}
+
+// Analyze runs every registered detector and returns the combined report. Issues are ordered by impact,
+// from high to informational, and then by the order the detectors were registered in.
+func (e *Engine) Analyze() (*audit.Report, error) {
+	ctx, err := NewContext(e.ctx, e.builder)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]audit.Detector, 0)
+	for _, detector := range e.detectors {
+		if err := e.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		found, err := detector.Detect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return impactRank(audit.ImpactLevel(results[i].Impact)) < impactRank(audit.ImpactLevel(results[j].Impact))
+	})
+
+	return &audit.Report{
+		Success: true,
+		Results: &audit.Results{
+			Detectors: results,
+		},
+	}, nil
+}
+
+// impactRank returns the sort position of the impact level, with the most severe first.
+func impactRank(impact audit.ImpactLevel) int {
+	switch impact {
+	case audit.ImpactHigh:
+		return 0
+	case audit.ImpactMedium:
+		return 1
+	case audit.ImpactLow:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,344 @@
+package detectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// buildIR parses and builds the IR of a single Solidity source.
+func buildIR(t *testing.T, name string, content string) *ir.Builder {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name:    name,
+				Path:    name + ".sol",
+				Content: content,
+			},
+		},
+		EntrySourceUnitName: name,
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	builder, err := ir.NewBuilderFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+	assert.Empty(t, builder.Parse())
+	require.NoError(t, builder.Build())
+	return builder
+}
+
+func TestEngine(t *testing.T) {
+	vulnerableBank, err := os.ReadFile(filepath.Join("..", "..", "data", "tests", "audits", "VulnerableBank.sol"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		contract     string
+		content      string
+		expected     map[string]int
+		descriptions []string
+	}{
+		{
+			name:     "Vulnerable Bank",
+			contract: "VulnerableBank",
+			content:  string(vulnerableBank),
+			expected: map[string]int{"reentrancy-eth": 1},
+			descriptions: []string{
+				"Reentrancy in VulnerableBank.withdraw() (VulnerableBank.sol#12-21):\n" +
+					"\tExternal calls:\n" +
+					"\t- (bool success, ) = msg.sender.call{value: amount}(\"\") (VulnerableBank.sol#17)\n" +
+					"\tState variables written after the call(s):\n" +
+					"\t- balances[msg.sender] = 0 (VulnerableBank.sol#20)\n",
+			},
+		},
+		{
+			name:     "Reentrancy Without Ether",
+			contract: "Staking",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IToken {
+    function transfer(address to, uint256 amount) external returns (bool);
+}
+
+contract Staking {
+    IToken public token;
+    mapping(address => uint256) public stakes;
+    bool private locked;
+
+    modifier nonReentrant() {
+        require(!locked);
+        locked = true;
+        _;
+        locked = false;
+    }
+
+    function unstake() external {
+        uint256 amount = stakes[msg.sender];
+        if (amount > 0) {
+            token.transfer(msg.sender, amount);
+        }
+        stakes[msg.sender] = 0;
+    }
+
+    function unstakeGuarded() external nonReentrant {
+        token.transfer(msg.sender, stakes[msg.sender]);
+        stakes[msg.sender] = 0;
+    }
+
+    function unstakeSafely() external {
+        uint256 amount = stakes[msg.sender];
+        stakes[msg.sender] = 0;
+        token.transfer(msg.sender, amount);
+    }
+}`,
+			expected: map[string]int{"reentrancy-no-eth": 1},
+			descriptions: []string{
+				"Reentrancy in Staking.unstake() (Staking.sol#20-26)",
+			},
+		},
+		{
+			name:     "Reentrancy In Else Branch",
+			contract: "Vault",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IToken {
+    function transfer(address to, uint256 amount) external returns (bool);
+}
+
+contract Vault {
+    IToken public token;
+    mapping(address => uint256) public shares;
+
+    function redeem(bool keep) external {
+        if (keep) {
+            return;
+        } else {
+            token.transfer(msg.sender, shares[msg.sender]);
+            shares[msg.sender] = 0;
+        }
+    }
+}`,
+			expected: map[string]int{"reentrancy-no-eth": 1},
+			descriptions: []string{
+				"Reentrancy in Vault.redeem(bool) (Vault.sol#12-19):\n" +
+					"\tExternal calls:\n" +
+					"\t- token.transfer(msg.sender, shares[msg.sender]) (Vault.sol#16)\n" +
+					"\tState variables written after the call(s):\n" +
+					"\t- shares[msg.sender] = 0 (Vault.sol#17)\n",
+			},
+		},
+		{
+			name:     "Authorization Through Origin",
+			contract: "Wallet",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Wallet {
+    address public owner;
+
+    modifier onlyOwner() {
+        require(tx.origin == owner, "not owner");
+        _;
+    }
+
+    function withdraw(address payable to) external onlyOwner {
+        to.transfer(address(this).balance);
+    }
+
+    function noContracts() external view returns (bool) {
+        return tx.origin == msg.sender;
+    }
+}`,
+			expected: map[string]int{"tx-origin": 1},
+			descriptions: []string{
+				"Wallet.onlyOwner() (Wallet.sol#7-10) uses tx.origin for authorization: tx.origin == owner (Wallet.sol#8)\n",
+			},
+		},
+		{
+			name:     "Unchecked Low Level Calls",
+			contract: "Forwarder",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Forwarder {
+    function forward(address target, bytes memory data) external {
+        target.call(data);
+    }
+
+    function forwardUnused(address target, bytes memory data) external {
+        (bool success, ) = target.call(data);
+    }
+
+    function forwardChecked(address target, bytes memory data) external {
+        (bool success, ) = target.call(data);
+        require(success);
+    }
+}`,
+			expected: map[string]int{"unchecked-lowlevel": 2},
+			descriptions: []string{
+				"Forwarder.forward(address,bytes) (Forwarder.sol#5-7) ignores return value by target.call(data) (Forwarder.sol#6)\n",
+			},
+		},
+		{
+			name:     "Unprotected Selfdestruct And Delegatecall",
+			contract: "Destructible",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Ownable {
+    address public owner;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner);
+        _;
+    }
+}
+
+contract Destructible is Ownable {
+    function kill() external {
+        _destroy();
+    }
+
+    function killGuarded() external onlyOwner {
+        selfdestruct(payable(owner));
+    }
+
+    function execute(address target, bytes memory data) external returns (bool) {
+        (bool success, ) = target.delegatecall(data);
+        return success;
+    }
+
+    function _destroy() internal {
+        selfdestruct(payable(msg.sender));
+    }
+}`,
+			expected: map[string]int{"suicidal": 1, "unprotected-delegatecall": 1},
+			descriptions: []string{
+				"Destructible.kill() (Destructible.sol#14-16) allows anyone to destruct the contract\n",
+			},
+		},
+		{
+			name:     "Uninitialized Storage Pointer",
+			contract: "Registry",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.4.24;
+
+contract Registry {
+    struct Entry {
+        address owner;
+    }
+
+    Entry[] public entries;
+
+    function register() public {
+        Entry storage entry;
+        entry.owner = msg.sender;
+        Entry storage first = entries[0];
+        first.owner = msg.sender;
+    }
+}`,
+			expected: map[string]int{"uninitialized-storage": 1},
+			descriptions: []string{
+				"entry (Registry.sol#12) is a storage variable never initialized\n",
+			},
+		},
+		{
+			name:     "Shadowed State Variable",
+			contract: "Child",
+			content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.4.24;
+
+contract Parent {
+    address public owner;
+}
+
+contract Child is Parent {
+    address public owner;
+    uint256 public value;
+}`,
+			expected: map[string]int{"shadowing-state": 1},
+			descriptions: []string{
+				"Child.owner (Child.sol#9) shadows:\n\t- Parent.owner (Child.sol#5)\n",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			engine, err := NewEngine(context.TODO(), buildIR(t, testCase.contract, testCase.content))
+			require.NoError(t, err)
+			assert.Len(t, engine.GetDetectors(), len(DefaultDetectors()))
+
+			report, err := engine.Analyze()
+			require.NoError(t, err)
+			require.NotNil(t, report)
+			assert.True(t, report.Success)
+
+			checks := make(map[string]int)
+			for _, detector := range report.Results.Detectors {
+				checks[detector.Check]++
+				assert.NotEmpty(t, detector.ID)
+				assert.NotEmpty(t, detector.Elements)
+				assert.True(t, strings.HasPrefix(detector.Markdown, "[") || strings.Contains(detector.Markdown, "]("))
+			}
+			assert.Equal(t, testCase.expected, checks)
+
+			for _, description := range testCase.descriptions {
+				found := false
+				for _, detector := range report.Results.Detectors {
+					if detector.Description == description || strings.HasPrefix(detector.Description, description) {
+						found = true
+						break
+					}
+				}
+				assert.True(t, found, "description not found: %s", description)
+			}
+		})
+	}
+}
+
+func TestEngineReportOrder(t *testing.T) {
+	builder := buildIR(t, "Mixed", `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Mixed {
+    address public owner;
+
+    function call(address target) external {
+        require(tx.origin == owner);
+        target.call("");
+    }
+
+    function kill() external {
+        selfdestruct(payable(msg.sender));
+    }
+}`)
+
+	engine, err := NewEngine(context.TODO(), builder, &TxOriginDetector{})
+	require.NoError(t, err)
+	engine.Register(&SuicidalDetector{}, &TxOriginDetector{})
+	assert.Len(t, engine.GetDetectors(), 2)
+
+	report, err := engine.Analyze()
+	require.NoError(t, err)
+	require.Len(t, report.Results.Detectors, 2)
+
+	assert.Equal(t, "suicidal", report.Results.Detectors[0].Check)
+	assert.Equal(t, audit.ImpactHigh.String(), report.Results.Detectors[0].Impact)
+	assert.Equal(t, audit.ConfidenceHigh.String(), report.Results.Detectors[0].Confidence)
+	assert.Equal(t, "tx-origin", report.Results.Detectors[1].Check)
+	assert.Len(t, report.HighConfidenceDetectors(), 1)
+
+	_, err = NewEngine(context.TODO(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,4 @@
+// Package detectors provides native static analysis detectors built on top of the solgo IR and AST.
+// Detectors report their findings as audit.Detector structures, so the resulting audit.Report is
+// interchangeable with the one produced by Slither.
+package detectors
@@ -0,0 +1,243 @@
+package detectors
+
+import (
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// lowLevelCalls are the members of the address type that perform a message call without checking its result.
+var lowLevelCalls = map[string]bool{
+	"call":         true,
+	"delegatecall": true,
+	"staticcall":   true,
+	"send":         true,
+}
+
+// walk visits the node and all of its descendants in depth first order. Returning false from the visitor
+// skips the descendants of the visited node.
+func walk(node ast.Node[ast.NodeType], visitor func(node ast.Node[ast.NodeType]) bool) {
+	if node == nil || !visitor(node) {
+		return
+	}
+
+	for _, child := range node.GetNodes() {
+		walk(child, visitor)
+	}
+}
+
+// find returns all nodes within the node, including the node itself, for which the predicate holds.
+func find(node ast.Node[ast.NodeType], predicate func(node ast.Node[ast.NodeType]) bool) []ast.Node[ast.NodeType] {
+	toReturn := make([]ast.Node[ast.NodeType], 0)
+	walk(node, func(current ast.Node[ast.NodeType]) bool {
+		if predicate(current) {
+			toReturn = append(toReturn, current)
+		}
+		return true
+	})
+	return toReturn
+}
+
+// typeIdentifier returns the type identifier of the node or an empty string if the node is not typed.
+func typeIdentifier(node ast.Node[ast.NodeType]) string {
+	if node == nil || node.GetTypeDescription() == nil {
+		return ""
+	}
+	return node.GetTypeDescription().GetIdentifier()
+}
+
+// memberCall returns the member access that is being called by the function call, unwrapping call options
+// such as `{value: amount}`. Returns nil if the call is not a member call.
+func memberCall(call *ast.FunctionCall) *ast.MemberAccessExpression {
+	expression := call.GetExpression()
+	if option, ok := expression.(*ast.FunctionCallOption); ok {
+		expression = option.GetExpression()
+	}
+
+	member, _ := expression.(*ast.MemberAccessExpression)
+	return member
+}
+
+// calledName returns the name of the function being called, either a plain identifier or a member name.
+func calledName(call *ast.FunctionCall) string {
+	if member := memberCall(call); member != nil {
+		return member.GetMemberName()
+	}
+
+	if primary, ok := call.GetExpression().(*ast.PrimaryExpression); ok {
+		return primary.GetName()
+	}
+
+	return ""
+}
+
+// isAddressType returns true if the type identifier describes an address or a payable address.
+func isAddressType(identifier string) bool {
+	return strings.HasPrefix(identifier, "t_address")
+}
+
+// isLowLevelCall returns true if the function call is a low-level call on an address, such as `to.call(data)`.
+func isLowLevelCall(call *ast.FunctionCall) bool {
+	member := memberCall(call)
+	if member == nil || !lowLevelCalls[member.GetMemberName()] {
+		return false
+	}
+	return isAddressType(typeIdentifier(member.GetExpression()))
+}
+
+// isDelegatecall returns true if the function call is a `delegatecall` on an address.
+func isDelegatecall(call *ast.FunctionCall) bool {
+	member := memberCall(call)
+	return member != nil && member.GetMemberName() == "delegatecall" && isAddressType(typeIdentifier(member.GetExpression()))
+}
+
+// isSelfdestruct returns true if the function call destroys the contract.
+func isSelfdestruct(call *ast.FunctionCall) bool {
+	primary, ok := call.GetExpression().(*ast.PrimaryExpression)
+	return ok && (primary.GetName() == "selfdestruct" || primary.GetName() == "suicide")
+}
+
+// isTxOrigin returns true if the node is the `tx.origin` member access.
+func isTxOrigin(node ast.Node[ast.NodeType]) bool {
+	member, ok := node.(*ast.MemberAccessExpression)
+	if !ok || member.GetMemberName() != "origin" {
+		return false
+	}
+
+	primary, ok := member.GetExpression().(*ast.PrimaryExpression)
+	return ok && primary.GetName() == "tx"
+}
+
+// isMsgSender returns true if the node is `msg.sender` or a call to the `_msgSender()` context helper.
+func isMsgSender(node ast.Node[ast.NodeType]) bool {
+	switch expression := node.(type) {
+	case *ast.MemberAccessExpression:
+		primary, ok := expression.GetExpression().(*ast.PrimaryExpression)
+		return ok && primary.GetName() == "msg" && expression.GetMemberName() == "sender"
+	case *ast.FunctionCall:
+		return calledName(expression) == "_msgSender"
+	}
+	return false
+}
+
+// accessCheckCalls are the names of well known helpers that restrict access to the caller.
+var accessCheckCalls = map[string]bool{
+	"hasRole":     true,
+	"_checkRole":  true,
+	"_checkOwner": true,
+}
+
+// hasAccessCheck returns true if the node compares the caller against anything, or calls one of the well
+// known access control helpers.
+func hasAccessCheck(node ast.Node[ast.NodeType]) bool {
+	return len(find(node, func(current ast.Node[ast.NodeType]) bool {
+		switch expression := current.(type) {
+		case *ast.BinaryOperation:
+			operator := expression.GetOperator()
+			if operator != ast_pb.Operator_EQUAL && operator != ast_pb.Operator_NOT_EQUAL {
+				return false
+			}
+			return isMsgSender(expression.GetLeftExpression()) || isMsgSender(expression.GetRightExpression())
+		case *ast.FunctionCall:
+			return accessCheckCalls[calledName(expression)]
+		}
+		return false
+	})) > 0
+}
+
+// contractKinds returns the kind of every contract, library and interface known to the IR, keyed by name.
+func (c *Context) contractKinds() map[string]ast_pb.NodeType {
+	toReturn := make(map[string]ast_pb.NodeType)
+	for _, contract := range c.GetContracts() {
+		toReturn[contract.GetName()] = contract.GetKind()
+	}
+	return toReturn
+}
+
+// isExternalCall returns true if the function call is a message call to another contract or to the contract
+// itself through `this`. Calls on a library name and `using for` calls are not message calls.
+func (c *Context) isExternalCall(call *ast.FunctionCall, kinds map[string]ast_pb.NodeType) bool {
+	member := memberCall(call)
+	if member == nil {
+		return false
+	}
+
+	if isLowLevelCall(call) {
+		return member.GetMemberName() != "send"
+	}
+
+	switch base := member.GetExpression().(type) {
+	case *ast.PrimaryExpression:
+		if base.GetName() == "this" {
+			return true
+		}
+		if _, isContractName := kinds[base.GetName()]; isContractName {
+			return false
+		}
+		return strings.HasPrefix(typeIdentifier(base), "t_contract$")
+	case *ast.FunctionCall:
+		// Explicit conversions to a contract type, such as `IERC20(token).transfer(to, amount)`.
+		if primary, ok := base.GetExpression().(*ast.PrimaryExpression); ok {
+			kind, isContractName := kinds[primary.GetName()]
+			return isContractName && kind != ast_pb.NodeType_KIND_LIBRARY
+		}
+		return strings.HasPrefix(typeIdentifier(base), "t_contract$")
+	default:
+		return strings.HasPrefix(typeIdentifier(base), "t_contract$")
+	}
+}
+
+// sendsValue returns true if the function call transfers ether, either through `transfer`, `send`, or a call
+// with the `value` option.
+func (c *Context) sendsValue(call *ast.FunctionCall) bool {
+	member := memberCall(call)
+	if member == nil {
+		return false
+	}
+
+	if (member.GetMemberName() == "transfer" || member.GetMemberName() == "send") && isAddressType(typeIdentifier(member.GetExpression())) {
+		return true
+	}
+
+	if option, ok := call.GetExpression().(*ast.FunctionCallOption); ok {
+		// Call options are not kept in the AST, so they are recovered from the source.
+		text := c.GetText(option.GetSrc())
+		if idx := strings.Index(text, "{"); idx >= 0 {
+			return strings.Contains(text[idx:], "value")
+		}
+	}
+
+	return false
+}
+
+// statementNode returns the AST node the statement was lowered from. For control flow terminators only the
+// part evaluated before branching is returned, such as the condition of an if statement.
+func (c *Context) statementNode(statement ir.Statement, terminator bool) ast.Node[ast.NodeType] {
+	node := c.GetNode(statement.GetId())
+	if !terminator || node == nil {
+		return node
+	}
+
+	switch unit := node.(type) {
+	case *ast.IfStatement:
+		return unit.GetCondition()
+	case *ast.ReturnStatement:
+		return unit.GetExpression()
+	case *ast.FunctionCall, *ast.RevertStatement:
+		return node
+	}
+
+	return nil
+}
+
+// hasNonReentrantGuard returns true if the callable is protected by a reentrancy guard modifier.
+func hasNonReentrantGuard(modifiers []*ast.ModifierInvocation) bool {
+	for _, modifier := range modifiers {
+		if strings.Contains(strings.ToLower(modifier.GetName()), "nonreentrant") {
+			return true
+		}
+	}
+	return false
+}
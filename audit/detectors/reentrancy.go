@@ -0,0 +1,266 @@
+package detectors
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// stateWriter is implemented by IR statements that can write to contract storage.
+type stateWriter interface {
+	IsStateWrite() bool
+	GetTargets() []*ir.AssignmentTarget
+}
+
+// reentrancyStep is a single statement of a function control flow graph together with its external calls.
+type reentrancyStep struct {
+	statement ir.Statement
+	node      ast.Node[ast.NodeType]
+	calls     []*ast.FunctionCall
+}
+
+// reentrancy describes state written after an external call within a single function.
+type reentrancy struct {
+	contract   *ir.Contract
+	function   *ir.Function
+	calls      []*reentrancyStep
+	writes     []*reentrancyStep
+	sendsValue bool
+}
+
+// findReentrancies returns every function of non library contracts that writes to storage after making an
+// external call. Functions that cannot modify state and functions guarded by a reentrancy guard are skipped.
+// Only the function itself is inspected; state written by internal callees is not followed.
+func findReentrancies(ctx *Context) []*reentrancy {
+	kinds := ctx.contractKinds()
+	toReturn := make([]*reentrancy, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		if contract.GetKind() == ast_pb.NodeType_KIND_LIBRARY || contract.GetKind() == ast_pb.NodeType_KIND_INTERFACE {
+			continue
+		}
+
+		for _, function := range contract.GetFunctions() {
+			unit := function.GetAST()
+			if unit == nil || unit.GetBody() == nil || function.GetBody() == nil {
+				continue
+			}
+
+			mutability := unit.GetStateMutability()
+			if mutability == ast_pb.Mutability_VIEW || mutability == ast_pb.Mutability_PURE || hasNonReentrantGuard(unit.GetModifiers()) {
+				continue
+			}
+
+			if found := ctx.findReentrancy(kinds, contract, function); found != nil {
+				toReturn = append(toReturn, found)
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// findReentrancy inspects the control flow graph of the function for storage writes that can execute after
+// an external call. Returns nil if there are none.
+func (c *Context) findReentrancy(kinds map[string]ast_pb.NodeType, contract *ir.Contract, function *ir.Function) *reentrancy {
+	graph := c.GetFunctionGraph(contract, function)
+
+	steps := make(map[int][]*reentrancyStep)
+	for _, block := range graph.GetBlocks() {
+		statements := block.GetStatements()
+		if block.GetTerminator() != nil {
+			statements = append(statements[:len(statements):len(statements)], block.GetTerminator())
+		}
+
+		for i, statement := range statements {
+			node := c.statementNode(statement, i == len(block.GetStatements()))
+			step := &reentrancyStep{statement: statement, node: node}
+			for _, call := range find(node, func(current ast.Node[ast.NodeType]) bool {
+				call, ok := current.(*ast.FunctionCall)
+				return ok && c.isExternalCall(call, kinds)
+			}) {
+				step.calls = append(step.calls, call.(*ast.FunctionCall))
+			}
+			steps[block.GetId()] = append(steps[block.GetId()], step)
+		}
+	}
+
+	found := &reentrancy{contract: contract, function: function}
+	seenCalls := make(map[*reentrancyStep]bool)
+	seenWrites := make(map[*reentrancyStep]bool)
+
+	for _, block := range graph.GetBlocks() {
+		for i, step := range steps[block.GetId()] {
+			if len(step.calls) == 0 {
+				continue
+			}
+
+			// Writes of the statement making the call happen after the call returns, same as the writes of
+			// every statement that follows it within the block or in any block reachable from it.
+			following := steps[block.GetId()][i:]
+			for _, successor := range reachableBlocks(graph, block.GetId()) {
+				following = append(following, steps[successor]...)
+			}
+
+			writes := make([]*reentrancyStep, 0)
+			for _, next := range following {
+				if writer, ok := next.statement.(stateWriter); ok && writer.IsStateWrite() && next.node != nil {
+					writes = append(writes, next)
+				}
+			}
+
+			if len(writes) == 0 {
+				continue
+			}
+
+			if !seenCalls[step] {
+				seenCalls[step] = true
+				found.calls = append(found.calls, step)
+			}
+
+			for _, write := range writes {
+				if !seenWrites[write] {
+					seenWrites[write] = true
+					found.writes = append(found.writes, write)
+				}
+			}
+
+			for _, call := range step.calls {
+				if c.sendsValue(call) {
+					found.sendsValue = true
+				}
+			}
+		}
+	}
+
+	if len(found.writes) == 0 {
+		return nil
+	}
+
+	return found
+}
+
+// reachableBlocks returns the ids of all blocks reachable from the successors of the block, which includes
+// the block itself when it is part of a loop.
+func reachableBlocks(graph *cfg.FunctionGraph, from int) []int {
+	toReturn := make([]int, 0)
+	seen := make(map[int]bool)
+	queue := make([]int, 0)
+
+	for _, edge := range graph.GetSuccessors(from) {
+		queue = append(queue, edge.To)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		toReturn = append(toReturn, current)
+
+		for _, edge := range graph.GetSuccessors(current) {
+			queue = append(queue, edge.To)
+		}
+	}
+
+	return toReturn
+}
+
+// reentrancyFinding describes the reentrancy in the Slither format.
+func (c *Context) reentrancyFinding(detector Detector, found *reentrancy) audit.Detector {
+	unit := found.function.GetAST()
+	function := c.FunctionElement(found.contract, unit.GetName(), unit.GetSignatureRaw(), unit.GetSrc())
+	elements := []audit.Element{function}
+
+	description := c.NewDescription().
+		Text("Reentrancy in ").
+		Ref(found.contract.GetName()+"."+unit.GetSignatureRaw(), function).
+		Text(":\n\tExternal calls:\n")
+
+	for _, call := range found.calls {
+		element := c.NodeElement(function, call.node.GetSrc(), "external_calls", "")
+		elements = append(elements, element)
+		description.Text("\t- ").Ref(element.Name, element).Text("\n")
+	}
+
+	description.Text("\tState variables written after the call(s):\n")
+	for _, write := range found.writes {
+		variable := ""
+		for _, target := range write.statement.(stateWriter).GetTargets() {
+			if target.IsStateWrite() {
+				variable = target.Name
+				break
+			}
+		}
+
+		element := c.NodeElement(function, write.node.GetSrc(), "variables_written", variable)
+		elements = append(elements, element)
+		description.Text("\t- ").Ref(element.Name, element).Text("\n")
+	}
+
+	return c.NewFinding(detector, description, elements...)
+}
+
+// ReentrancyEthDetector reports state written after an external call that sends ether, which allows the
+// callee to re-enter the function and drain funds before the state is updated.
+type ReentrancyEthDetector struct{}
+
+// Name returns the name of the check.
+func (d *ReentrancyEthDetector) Name() string {
+	return "reentrancy-eth"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *ReentrancyEthDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactHigh
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *ReentrancyEthDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceMedium
+}
+
+// Detect returns the reentrancies in which at least one of the external calls sends ether.
+func (d *ReentrancyEthDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+	for _, found := range findReentrancies(ctx) {
+		if found.sendsValue {
+			toReturn = append(toReturn, ctx.reentrancyFinding(d, found))
+		}
+	}
+	return toReturn, nil
+}
+
+// ReentrancyNoEthDetector reports state written after external calls that do not send ether.
+type ReentrancyNoEthDetector struct{}
+
+// Name returns the name of the check.
+func (d *ReentrancyNoEthDetector) Name() string {
+	return "reentrancy-no-eth"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *ReentrancyNoEthDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactMedium
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *ReentrancyNoEthDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceMedium
+}
+
+// Detect returns the reentrancies in which none of the external calls sends ether.
+func (d *ReentrancyNoEthDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+	for _, found := range findReentrancies(ctx) {
+		if !found.sendsValue {
+			toReturn = append(toReturn, ctx.reentrancyFinding(d, found))
+		}
+	}
+	return toReturn, nil
+}
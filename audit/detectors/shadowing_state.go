@@ -0,0 +1,76 @@
+package detectors
+
+import (
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// ShadowingStateDetector reports state variables that redeclare a state variable of a base contract. Functions
+// of the base contract keep using their own variable, which is rarely what the author intended.
+type ShadowingStateDetector struct{}
+
+// Name returns the name of the check.
+func (d *ShadowingStateDetector) Name() string {
+	return "shadowing-state"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *ShadowingStateDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactHigh
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *ShadowingStateDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceHigh
+}
+
+// Detect returns every state variable shadowing state variables of contracts it inherits from, following the
+// linearization of the contract.
+func (d *ShadowingStateDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	contracts := make(map[string]*ir.Contract)
+	for _, contract := range ctx.GetContracts() {
+		contracts[contract.GetName()] = contract
+	}
+
+	toReturn := make([]audit.Detector, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		linearization := ctx.GetCallGraph().GetLinearization(contract.GetName())
+		if len(linearization) < 2 {
+			continue
+		}
+
+		for _, variable := range contract.GetStateVariables() {
+			shadowed := make([]audit.Element, 0)
+			for _, name := range linearization[1:] {
+				base, ok := contracts[name]
+				if !ok {
+					continue
+				}
+
+				for _, baseVariable := range base.GetStateVariables() {
+					if baseVariable.GetName() == variable.GetName() {
+						shadowed = append(shadowed, ctx.VariableElement(base, baseVariable))
+					}
+				}
+			}
+
+			if len(shadowed) == 0 {
+				continue
+			}
+
+			element := ctx.VariableElement(contract, variable)
+			description := ctx.NewDescription().
+				Ref(contract.GetName()+"."+variable.GetName(), element).
+				Text(" shadows:\n")
+
+			for _, base := range shadowed {
+				description.Text("\t- ").Ref(base.TypeSpecificFields.Parent.Name+"."+base.Name, base).Text("\n")
+			}
+
+			toReturn = append(toReturn, ctx.NewFinding(d, description, append([]audit.Element{element}, shadowed...)...))
+		}
+	}
+
+	return toReturn, nil
+}
@@ -0,0 +1,183 @@
+package detectors
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// unprotectedCall is a call made by an entry point that anyone can invoke.
+type unprotectedCall struct {
+	contract *ir.Contract
+	entry    *cfg.CallNode
+	calls    []ast.Node[ast.NodeType]
+}
+
+// findUnprotectedCalls returns the entry points of deployable contracts that reach a call matching the
+// predicate, either directly or through internal, super and library calls, without any of the reached
+// callables or modifiers checking the caller. Constructors are not reported as they can only run once.
+func findUnprotectedCalls(ctx *Context, predicate func(call *ast.FunctionCall) bool) []*unprotectedCall {
+	contracts := make(map[string]*ir.Contract)
+	for _, contract := range ctx.GetContracts() {
+		contracts[contract.GetName()] = contract
+	}
+
+	graph := ctx.GetCallGraph()
+	seen := make(map[string]bool)
+	toReturn := make([]*unprotectedCall, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		if contract.GetKind() != ast_pb.NodeType_KIND_CONTRACT {
+			continue
+		}
+
+		for _, entry := range graph.GetEntryPoints(contract.GetName()) {
+			if entry.GetKind() == cfg.CallNodeConstructor || seen[entry.GetId()] {
+				continue
+			}
+
+			protected := false
+			calls := make([]ast.Node[ast.NodeType], 0)
+			for _, node := range reachableInternally(graph, entry.GetId(), contract.GetName()) {
+				if hasAccessCheck(node.GetAST()) {
+					protected = true
+					break
+				}
+
+				calls = append(calls, find(node.GetAST(), func(current ast.Node[ast.NodeType]) bool {
+					call, ok := current.(*ast.FunctionCall)
+					return ok && predicate(call)
+				})...)
+			}
+
+			if protected || len(calls) == 0 {
+				continue
+			}
+
+			declaring, ok := contracts[entry.GetContract()]
+			if !ok {
+				continue
+			}
+
+			seen[entry.GetId()] = true
+			toReturn = append(toReturn, &unprotectedCall{contract: declaring, entry: entry, calls: calls})
+		}
+	}
+
+	return toReturn
+}
+
+// reachableInternally returns the callables reachable from the callable without leaving the contract, that is
+// following every call except external message calls. Context is the most derived contract being executed.
+func reachableInternally(graph *cfg.CallGraph, id string, context string) []*cfg.CallNode {
+	type visit struct {
+		id      string
+		context string
+	}
+
+	toReturn := make([]*cfg.CallNode, 0)
+	seen := make(map[visit]bool)
+	queue := []visit{{id: id, context: context}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		if node := graph.GetNode(current.id); node != nil && node.GetAST() != nil {
+			toReturn = append(toReturn, node)
+		}
+
+		for _, edge := range graph.GetCallees(current.id) {
+			if edge.Context == current.context && edge.Kind != cfg.CallExternal {
+				queue = append(queue, visit{id: edge.To, context: edge.CalleeContext})
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// SuicidalDetector reports functions that anyone can call to destroy the contract.
+type SuicidalDetector struct{}
+
+// Name returns the name of the check.
+func (d *SuicidalDetector) Name() string {
+	return "suicidal"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *SuicidalDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactHigh
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *SuicidalDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceHigh
+}
+
+// Detect returns the unprotected entry points that reach `selfdestruct`.
+func (d *SuicidalDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+
+	for _, found := range findUnprotectedCalls(ctx, isSelfdestruct) {
+		function := ctx.FunctionElement(found.contract, found.entry.GetName(), found.entry.GetSignature(), found.entry.GetAST().GetSrc())
+
+		description := ctx.NewDescription().
+			Ref(found.entry.GetId(), function).
+			Text(" allows anyone to destruct the contract\n")
+
+		toReturn = append(toReturn, ctx.NewFinding(d, description, function))
+	}
+
+	return toReturn, nil
+}
+
+// UnprotectedDelegatecallDetector reports functions that anyone can call to execute `delegatecall`, which
+// runs foreign code with the storage and balance of the contract.
+type UnprotectedDelegatecallDetector struct{}
+
+// Name returns the name of the check.
+func (d *UnprotectedDelegatecallDetector) Name() string {
+	return "unprotected-delegatecall"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *UnprotectedDelegatecallDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactHigh
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *UnprotectedDelegatecallDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceMedium
+}
+
+// Detect returns the unprotected entry points that reach `delegatecall`.
+func (d *UnprotectedDelegatecallDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+
+	for _, found := range findUnprotectedCalls(ctx, isDelegatecall) {
+		function := ctx.FunctionElement(found.contract, found.entry.GetName(), found.entry.GetSignature(), found.entry.GetAST().GetSrc())
+		elements := []audit.Element{function}
+
+		description := ctx.NewDescription().
+			Ref(found.entry.GetId(), function).
+			Text(" allows anyone to execute delegatecall:\n")
+
+		for _, call := range found.calls {
+			node := ctx.NodeElement(function, call.GetSrc(), "", "")
+			elements = append(elements, node)
+			description.Text("\t- ").Ref(node.Name, node).Text("\n")
+		}
+
+		toReturn = append(toReturn, ctx.NewFinding(d, description, elements...))
+	}
+
+	return toReturn, nil
+}
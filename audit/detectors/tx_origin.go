@@ -0,0 +1,61 @@
+package detectors
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+)
+
+// TxOriginDetector reports authorization based on `tx.origin`, which can be bypassed by a malicious contract
+// the authorized account interacts with.
+type TxOriginDetector struct{}
+
+// Name returns the name of the check.
+func (d *TxOriginDetector) Name() string {
+	return "tx-origin"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *TxOriginDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactMedium
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *TxOriginDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceMedium
+}
+
+// Detect returns every comparison of `tx.origin` within functions and modifiers. Comparing `tx.origin` with
+// `msg.sender`, which is commonly used to reject calls made by contracts, is not reported.
+func (d *TxOriginDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		for _, scope := range ctx.getCallables(contract) {
+			comparisons := find(scope.body, func(node ast.Node[ast.NodeType]) bool {
+				operation, ok := node.(*ast.BinaryOperation)
+				if !ok || (operation.GetOperator() != ast_pb.Operator_EQUAL && operation.GetOperator() != ast_pb.Operator_NOT_EQUAL) {
+					return false
+				}
+
+				left, right := operation.GetLeftExpression(), operation.GetRightExpression()
+				return (isTxOrigin(left) && !isMsgSender(right)) || (isTxOrigin(right) && !isMsgSender(left))
+			})
+
+			for _, comparison := range comparisons {
+				function := ctx.element(scope)
+				node := ctx.NodeElement(function, comparison.GetSrc(), "", "")
+
+				description := ctx.NewDescription().
+					Ref(scope.label(), function).
+					Text(" uses tx.origin for authorization: ").
+					Ref(node.Name, node).
+					Text("\n")
+
+				toReturn = append(toReturn, ctx.NewFinding(d, description, function, node))
+			}
+		}
+	}
+
+	return toReturn, nil
+}
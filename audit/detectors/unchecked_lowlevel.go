@@ -0,0 +1,105 @@
+package detectors
+
+import (
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+)
+
+// UncheckedLowLevelDetector reports low-level calls whose success flag is ignored. Low-level calls do not
+// revert when the callee fails, so ignoring the flag silently continues execution after a failed call.
+type UncheckedLowLevelDetector struct{}
+
+// Name returns the name of the check.
+func (d *UncheckedLowLevelDetector) Name() string {
+	return "unchecked-lowlevel"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *UncheckedLowLevelDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactMedium
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *UncheckedLowLevelDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceMedium
+}
+
+// Detect returns low-level calls used as statements, and low-level calls whose success flag is assigned to
+// a local variable that is never read.
+func (d *UncheckedLowLevelDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		for _, scope := range ctx.getCallables(contract) {
+			for _, statement := range uncheckedLowLevelCalls(scope.body) {
+				function := ctx.element(scope)
+				node := ctx.NodeElement(function, statement.GetSrc(), "", "")
+
+				description := ctx.NewDescription().
+					Ref(scope.label(), function).
+					Text(" ignores return value by ").
+					Ref(node.Name, node).
+					Text("\n")
+
+				toReturn = append(toReturn, ctx.NewFinding(d, description, function, node))
+			}
+		}
+	}
+
+	return toReturn, nil
+}
+
+// uncheckedLowLevelCalls returns the statements of the body, including nested blocks, that make a low-level
+// call without checking whether it succeeded.
+func uncheckedLowLevelCalls(body *ast.BodyNode) []ast.Node[ast.NodeType] {
+	toReturn := make([]ast.Node[ast.NodeType], 0)
+
+	walk(body, func(node ast.Node[ast.NodeType]) bool {
+		block, ok := node.(*ast.BodyNode)
+		if !ok {
+			return true
+		}
+
+		for _, statement := range block.GetStatements() {
+			switch unit := statement.(type) {
+			case *ast.FunctionCall:
+				if isLowLevelCall(unit) {
+					toReturn = append(toReturn, statement)
+				}
+			case *ast.VariableDeclaration:
+				call, ok := unit.GetInitialValue().(*ast.FunctionCall)
+				if !ok || !isLowLevelCall(call) {
+					continue
+				}
+
+				declarations := unit.GetDeclarations()
+				if len(declarations) == 0 || declarations[0] == nil || declarations[0].GetName() == "" ||
+					!isReferenced(body, unit, declarations[0]) {
+					toReturn = append(toReturn, statement)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return toReturn
+}
+
+// isReferenced returns true if the declared local variable is read anywhere within the body. References
+// point either to the declaration itself or to the variable declaration statement introducing it.
+func isReferenced(body *ast.BodyNode, statement *ast.VariableDeclaration, declaration *ast.Declaration) bool {
+	return len(find(body, func(node ast.Node[ast.NodeType]) bool {
+		primary, ok := node.(*ast.PrimaryExpression)
+		if !ok {
+			return false
+		}
+
+		if primary.GetReferencedDeclaration() != 0 {
+			return primary.GetReferencedDeclaration() == declaration.GetId() ||
+				primary.GetReferencedDeclaration() == statement.GetId()
+		}
+
+		return primary.GetName() == declaration.GetName()
+	})) > 0
+}
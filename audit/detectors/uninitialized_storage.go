@@ -0,0 +1,66 @@
+package detectors
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/audit"
+)
+
+// UninitializedStorageDetector reports local storage pointers declared without a value. Such pointers refer
+// to storage slot zero, so writing through them overwrites unrelated state variables.
+type UninitializedStorageDetector struct{}
+
+// Name returns the name of the check.
+func (d *UninitializedStorageDetector) Name() string {
+	return "uninitialized-storage"
+}
+
+// Impact returns the severity of the reported issues.
+func (d *UninitializedStorageDetector) Impact() audit.ImpactLevel {
+	return audit.ImpactHigh
+}
+
+// Confidence returns the confidence of the reported issues.
+func (d *UninitializedStorageDetector) Confidence() audit.ConfidenceLevel {
+	return audit.ConfidenceHigh
+}
+
+// Detect returns every local variable declared with the storage location and no initial value.
+func (d *UninitializedStorageDetector) Detect(ctx *Context) ([]audit.Detector, error) {
+	toReturn := make([]audit.Detector, 0)
+
+	for _, contract := range ctx.GetContracts() {
+		for _, scope := range ctx.getCallables(contract) {
+			walk(scope.body, func(node ast.Node[ast.NodeType]) bool {
+				declaration, ok := node.(*ast.VariableDeclaration)
+				if !ok || declaration.GetInitialValue() != nil {
+					return true
+				}
+
+				for _, variable := range declaration.GetDeclarations() {
+					if variable == nil || variable.GetStorageLocation() != ast_pb.StorageLocation_STORAGE {
+						continue
+					}
+
+					function := ctx.element(scope)
+					element := audit.Element{
+						Type:               "variable",
+						Name:               variable.GetName(),
+						SourceMapping:      ctx.SourceMapping(variable.GetSrc()),
+						TypeSpecificFields: audit.TypeSpecificFields{Parent: &function},
+					}
+
+					description := ctx.NewDescription().
+						Ref(element.Name, element).
+						Text(" is a storage variable never initialized\n")
+
+					toReturn = append(toReturn, ctx.NewFinding(d, description, element))
+				}
+
+				return false
+			})
+		}
+	}
+
+	return toReturn, nil
+}
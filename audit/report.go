@@ -18,6 +18,21 @@ const (
 	ImpactInfo   ImpactLevel = "Informational" // Represents informational findings.
 )
 
+// ConfidenceLevel represents how certain a detector is that a detected issue is a true positive.
+type ConfidenceLevel string
+
+// String returns the string representation of the ConfidenceLevel.
+func (c ConfidenceLevel) String() string {
+	return string(c)
+}
+
+// Predefined confidence levels of detected issues.
+const (
+	ConfidenceHigh   ConfidenceLevel = "High"   // The issue is almost certainly a true positive.
+	ConfidenceMedium ConfidenceLevel = "Medium" // The issue is likely a true positive.
+	ConfidenceLow    ConfidenceLevel = "Low"    // The issue may well be a false positive.
+)
+
 // NewResponse parses the provided JSON data (typically from Slither) and returns
 // a structured Response object. If the data is not valid JSON or does not match
 // the expected structure, an error is returned.
@@ -104,7 +119,7 @@ func (r *Report) CountByImpactLevel() map[ImpactLevel]int {
 func (r *Report) HighConfidenceDetectors() []Detector {
 	var detectors []Detector
 	for _, detector := range r.Results.Detectors {
-		if detector.Confidence == "High" {
+		if ConfidenceLevel(detector.Confidence) == ConfidenceHigh {
 			detectors = append(detectors, detector)
 		}
 	}
@@ -0,0 +1,51 @@
+package bytecode
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// minimalProxyPrefix is the code of EIP-1167 minimal proxies preceding the push of the implementation address.
+	minimalProxyPrefix = common.FromHex("0x363d3d373d3d3d363d")
+
+	// minimalProxySuffix is the code of EIP-1167 minimal proxies following the implementation address, up to the
+	// offset of the return jump destination, which depends on the length of the address push.
+	minimalProxySuffix = common.FromHex("0x5af43d82803e903d91")
+
+	// minimalProxyEnd is the code of EIP-1167 minimal proxies following the return jump destination.
+	minimalProxyEnd = common.FromHex("0x57fd5bf3")
+)
+
+// DecodeMinimalProxy decodes the implementation address of an EIP-1167 minimal proxy from its deployed bytecode.
+// Besides the standard 45 bytes long proxy, variants pushing shorter, vanity, addresses are recognized as well.
+// It returns false if the bytecode is not a minimal proxy.
+func DecodeMinimalProxy(code []byte) (common.Address, bool) {
+	if !bytes.HasPrefix(code, minimalProxyPrefix) || len(code) <= len(minimalProxyPrefix) {
+		return common.Address{}, false
+	}
+
+	// PUSH1 to PUSH20 holding the implementation address.
+	push := code[len(minimalProxyPrefix)]
+	if push < 0x60 || push > 0x73 {
+		return common.Address{}, false
+	}
+
+	size := int(push-0x60) + 1
+	start := len(minimalProxyPrefix) + 1
+	rest := code[start:]
+
+	// The return jump destination is pushed with PUSH1, followed by the end of the proxy.
+	if len(rest) != size+len(minimalProxySuffix)+2+len(minimalProxyEnd) {
+		return common.Address{}, false
+	}
+
+	if !bytes.Equal(rest[size:size+len(minimalProxySuffix)], minimalProxySuffix) ||
+		rest[size+len(minimalProxySuffix)] != 0x60 ||
+		!bytes.Equal(rest[size+len(minimalProxySuffix)+2:], minimalProxyEnd) {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(rest[:size]), true
+}
@@ -0,0 +1,49 @@
+package bytecode
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMinimalProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		bytecode string
+		want     common.Address
+		wantOk   bool
+	}{
+		{
+			name:     "Standard Minimal Proxy",
+			bytecode: "0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3",
+			want:     common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe"),
+			wantOk:   true,
+		},
+		{
+			name:     "Vanity Address Minimal Proxy",
+			bytecode: "0x363d3d373d3d3d363d6f1234567890abcdef1234567890abcdef5af43d82803e903d91602757fd5bf3",
+			want:     common.HexToAddress("0x000000001234567890abcdef1234567890abcdef"),
+			wantOk:   true,
+		},
+		{
+			name:     "Truncated Minimal Proxy",
+			bytecode: "0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd",
+		},
+		{
+			name:     "Regular Contract",
+			bytecode: "0x6080604052348015600f57600080fd5b50603f80601d6000396000f3fe",
+		},
+		{
+			name: "Empty Bytecode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DecodeMinimalProxy(common.FromHex(tt.bytecode))
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
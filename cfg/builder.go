@@ -10,9 +10,10 @@ import (
 // Builder is responsible for constructing the control flow graph (CFG) of Solidity contracts.
 // It utilizes the Intermediate Representation (IR) provided by solgo and Graphviz for graph operations.
 type Builder struct {
-	ctx     context.Context // Context for the builder operations.
-	builder *ir.Builder     // IR builder from solgo, used for generating the IR of the contracts.
-	graph   *Graph          // Internal representation of the CFG.
+	ctx       context.Context // Context for the builder operations.
+	builder   *ir.Builder     // IR builder from solgo, used for generating the IR of the contracts.
+	graph     *Graph          // Internal representation of the CFG.
+	callGraph *CallGraph      // Whole-program call graph, built on demand by BuildCallGraph.
 }
 
 // NewBuilder initializes a new CFG builder with the given context and IR builder.
@@ -29,6 +30,11 @@ func NewBuilder(ctx context.Context, builder *ir.Builder) (*Builder, error) {
 	}, nil
 }
 
+// GetIR returns the IR builder the CFG is constructed from.
+func (b *Builder) GetIR() *ir.Builder {
+	return b.builder
+}
+
 // GetGraph returns the internal Graph instance of the CFG.
 func (b *Builder) GetGraph() *Graph {
 	return b.graph
@@ -56,6 +62,9 @@ func (b *Builder) Build() error {
 	dfs = func(contract *ir.Contract, isEntryContract bool) {
 		if !b.graph.NodeExists(contract.GetName()) {
 			b.graph.AddNode(contract.GetName(), contract, isEntryContract)
+			for _, function := range contract.GetFunctions() {
+				b.graph.AddFunction(contract.GetName(), BuildFunctionGraph(contract.GetName(), function))
+			}
 			allRelatedContracts := make([]*ir.Contract, 0)
 			for _, importStmt := range contract.GetImports() {
 				importedContract := root.GetContractById(importStmt.GetContractId())
@@ -81,3 +90,18 @@ func (b *Builder) Build() error {
 	dfs(entryContract, true)
 	return nil
 }
+
+// GetFunctionGraph returns the control flow graph of a function declared in the provided contract.
+// Returns nil if either the contract or the function is not part of the graph.
+func (b *Builder) GetFunctionGraph(contractName string, functionName string) *FunctionGraph {
+	if b.graph == nil {
+		return nil
+	}
+
+	node := b.graph.GetNode(contractName)
+	if node == nil {
+		return nil
+	}
+
+	return node.GetFunction(functionName)
+}
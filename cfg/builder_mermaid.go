@@ -1,6 +1,7 @@
 package cfg
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -44,3 +45,14 @@ func (b *Builder) ToMermaid() string {
 
 	return mermaidGraph.String()
 }
+
+// ToFunctionMermaid generates a Mermaid flowchart of the control flow graph of a single function.
+// Returns an error if the function cannot be found in the graph.
+func (b *Builder) ToFunctionMermaid(contractName string, functionName string) (string, error) {
+	function := b.GetFunctionGraph(contractName, functionName)
+	if function == nil {
+		return "", errors.New("function not found in the control flow graph")
+	}
+
+	return function.ToMermaid(), nil
+}
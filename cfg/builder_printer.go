@@ -41,6 +41,12 @@ func (b *Builder) printNode(name string, depth int) {
 	output.WriteString(fmt.Sprintf("%sNode %s:\n", indent, node.Name))
 	output.WriteString(fmt.Sprintf("%s  Entry Contract: %v\n", indent, node.EntryContract))
 
+	for _, function := range node.Functions {
+		for _, line := range strings.Split(strings.TrimRight(function.String(), "\n"), "\n") {
+			output.WriteString(fmt.Sprintf("%s  %s\n", indent, line))
+		}
+	}
+
 	if len(node.Imports) == 0 && len(node.Inherits) == 0 {
 		output.WriteString(fmt.Sprintf("%s  No imports or base contracts\n", indent))
 	} else {
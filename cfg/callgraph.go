@@ -0,0 +1,299 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// CallNodeKind describes the kind of callable a call graph node represents.
+type CallNodeKind string
+
+const (
+	CallNodeFunction    CallNodeKind = "function"
+	CallNodeModifier    CallNodeKind = "modifier"
+	CallNodeConstructor CallNodeKind = "constructor"
+	CallNodeFallback    CallNodeKind = "fallback"
+	CallNodeReceive     CallNodeKind = "receive"
+)
+
+// CallKind describes how a callee is reached from its caller.
+type CallKind string
+
+const (
+	// CallInternal is a call to a function of the same contract hierarchy, dispatched virtually.
+	CallInternal CallKind = "internal"
+	// CallSuper is a call through `super`, resolved to the next contract in the linearization.
+	CallSuper CallKind = "super"
+	// CallLibrary is an explicit call to a library function such as `Math.add(a, b)`.
+	CallLibrary CallKind = "library"
+	// CallUsingFor is a library call dispatched through a `using for` directive such as `a.add(b)`.
+	CallUsingFor CallKind = "using_for"
+	// CallModifier is an invocation of a modifier by a function.
+	CallModifier CallKind = "modifier"
+	// CallExternal is a message call to another contract or to the contract itself through `this`.
+	CallExternal CallKind = "external"
+)
+
+// CallNode represents a callable, either a function, constructor, fallback, receive or a modifier.
+type CallNode struct {
+	Id           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Signature    string                 `json:"signature"`
+	Contract     string                 `json:"contract"`
+	ContractKind ast_pb.NodeType        `json:"contractKind"`
+	Kind         CallNodeKind           `json:"kind"`
+	Visibility   ast_pb.Visibility      `json:"visibility"`
+	Implemented  bool                   `json:"implemented"`
+	Unit         ast.Node[ast.NodeType] `json:"-"`
+	Function     *ir.Function           `json:"-"`
+}
+
+// GetId returns the unique identifier of the node in the form of `Contract.signature`.
+func (n *CallNode) GetId() string {
+	return n.Id
+}
+
+// GetName returns the name of the callable.
+func (n *CallNode) GetName() string {
+	return n.Name
+}
+
+// GetSignature returns the raw signature of the callable, such as `transfer(address,uint256)`.
+func (n *CallNode) GetSignature() string {
+	return n.Signature
+}
+
+// GetContract returns the name of the contract declaring the callable.
+func (n *CallNode) GetContract() string {
+	return n.Contract
+}
+
+// GetContractKind returns the kind of the declaring contract, such as contract, library or interface.
+func (n *CallNode) GetContractKind() ast_pb.NodeType {
+	return n.ContractKind
+}
+
+// GetKind returns the kind of the callable.
+func (n *CallNode) GetKind() CallNodeKind {
+	return n.Kind
+}
+
+// GetVisibility returns the visibility of the callable.
+func (n *CallNode) GetVisibility() ast_pb.Visibility {
+	return n.Visibility
+}
+
+// IsImplemented returns true if the callable has a body.
+func (n *CallNode) IsImplemented() bool {
+	return n.Implemented
+}
+
+// GetAST returns the AST node the callable was built from.
+func (n *CallNode) GetAST() ast.Node[ast.NodeType] {
+	return n.Unit
+}
+
+// GetFunction returns the IR of the function or nil if the callable is not a regular function.
+func (n *CallNode) GetFunction() *ir.Function {
+	return n.Function
+}
+
+// IsEntryPoint returns true if the callable can be invoked by a transaction.
+func (n *CallNode) IsEntryPoint() bool {
+	switch n.Kind {
+	case CallNodeFallback, CallNodeReceive, CallNodeConstructor:
+		return true
+	case CallNodeFunction:
+		return n.ContractKind != ast_pb.NodeType_KIND_LIBRARY &&
+			(n.Visibility == ast_pb.Visibility_PUBLIC || n.Visibility == ast_pb.Visibility_EXTERNAL)
+	}
+	return false
+}
+
+// CallEdge represents a call from one callable to another. Because internal calls are dispatched virtually,
+// every edge is only valid within the context of the most derived contract it was resolved for. CalleeContext
+// is the context the callee executes in, which changes on external and library calls.
+type CallEdge struct {
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Kind          CallKind `json:"kind"`
+	Context       string   `json:"context"`
+	CalleeContext string   `json:"calleeContext"`
+}
+
+// CallGraph represents the whole-program call graph of all contracts, libraries and interfaces known to the IR.
+type CallGraph struct {
+	Nodes          []*CallNode         `json:"nodes"`
+	Edges          []*CallEdge         `json:"edges"`
+	Linearizations map[string][]string `json:"linearizations"`
+	nodes          map[string]*CallNode
+	edges          map[string]*CallEdge
+	outgoing       map[string][]*CallEdge
+	incoming       map[string][]*CallEdge
+}
+
+// NewCallGraph creates an empty call graph.
+func NewCallGraph() *CallGraph {
+	return &CallGraph{
+		Nodes:          make([]*CallNode, 0),
+		Edges:          make([]*CallEdge, 0),
+		Linearizations: make(map[string][]string),
+		nodes:          make(map[string]*CallNode),
+		edges:          make(map[string]*CallEdge),
+		outgoing:       make(map[string][]*CallEdge),
+		incoming:       make(map[string][]*CallEdge),
+	}
+}
+
+// AddNode adds a callable to the graph. If a node with the same id exists, the existing node is returned.
+func (g *CallGraph) AddNode(node *CallNode) *CallNode {
+	if existing, ok := g.nodes[node.Id]; ok {
+		return existing
+	}
+	g.nodes[node.Id] = node
+	g.Nodes = append(g.Nodes, node)
+	return node
+}
+
+// AddEdge adds a call between two callables of the graph. Duplicate edges are ignored.
+func (g *CallGraph) AddEdge(edge *CallEdge) {
+	key := fmt.Sprintf("%s|%s|%s|%s|%s", edge.From, edge.To, edge.Kind, edge.Context, edge.CalleeContext)
+	if _, ok := g.edges[key]; ok {
+		return
+	}
+	g.edges[key] = edge
+	g.Edges = append(g.Edges, edge)
+	g.outgoing[edge.From] = append(g.outgoing[edge.From], edge)
+	g.incoming[edge.To] = append(g.incoming[edge.To], edge)
+}
+
+// GetNodes returns all callables of the graph in the order they were added.
+func (g *CallGraph) GetNodes() []*CallNode {
+	return g.Nodes
+}
+
+// GetEdges returns all calls of the graph in the order they were added.
+func (g *CallGraph) GetEdges() []*CallEdge {
+	return g.Edges
+}
+
+// GetNode returns the callable with the provided id or nil if it does not exist.
+func (g *CallGraph) GetNode(id string) *CallNode {
+	return g.nodes[id]
+}
+
+// GetLinearization returns the C3 linearization of the contract, most derived first.
+func (g *CallGraph) GetLinearization(contract string) []string {
+	return g.Linearizations[contract]
+}
+
+// GetCallees returns the calls made by the callable with the provided id, across all contexts.
+func (g *CallGraph) GetCallees(id string) []*CallEdge {
+	return g.outgoing[id]
+}
+
+// GetCallers returns the calls made to the callable with the provided id, across all contexts.
+func (g *CallGraph) GetCallers(id string) []*CallEdge {
+	return g.incoming[id]
+}
+
+// ResolveFunction returns the implementation of the function that is used when it is invoked on the contract,
+// following the linearization of the contract. Returns nil if no contract in the hierarchy declares it.
+func (g *CallGraph) ResolveFunction(contract string, name string) *CallNode {
+	for _, base := range g.Linearizations[contract] {
+		for _, node := range g.Nodes {
+			if node.Contract == base && node.Name == name && node.Kind == CallNodeFunction {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// GetEntryPoints returns the callables of the contract, including inherited ones, that can be invoked by a transaction.
+func (g *CallGraph) GetEntryPoints(contract string) []*CallNode {
+	toReturn := make([]*CallNode, 0)
+	seen := make(map[string]bool)
+
+	for _, base := range g.Linearizations[contract] {
+		for _, node := range g.Nodes {
+			if node.Contract != base || !node.IsEntryPoint() {
+				continue
+			}
+
+			key := node.Signature
+			if node.Kind == CallNodeConstructor {
+				if base != contract {
+					continue
+				}
+				key = string(node.Kind)
+			}
+
+			if !seen[key] {
+				seen[key] = true
+				toReturn = append(toReturn, node)
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// Reachable returns all callables reachable from the function of the provided contract, starting with the
+// function itself. The function is resolved through the linearization of the contract and internal calls are
+// followed within the context of that contract, switching context on external and library calls.
+func (g *CallGraph) Reachable(contract string, function string) ([]*CallNode, error) {
+	start := g.ResolveFunction(contract, function)
+	if start == nil {
+		return nil, fmt.Errorf("function %s not found in contract %s", function, contract)
+	}
+
+	return g.ReachableFrom(start.Id, contract), nil
+}
+
+// ReachableFrom returns all callables reachable from the callable with the provided id when executing in the
+// provided contract context, starting with the callable itself.
+func (g *CallGraph) ReachableFrom(id string, context string) []*CallNode {
+	type visit struct {
+		id      string
+		context string
+	}
+
+	toReturn := make([]*CallNode, 0)
+	seenNodes := make(map[string]bool)
+	seenVisits := make(map[visit]bool)
+	queue := []visit{{id: id, context: context}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if seenVisits[current] {
+			continue
+		}
+		seenVisits[current] = true
+
+		if node := g.nodes[current.id]; node != nil && !seenNodes[current.id] {
+			seenNodes[current.id] = true
+			toReturn = append(toReturn, node)
+		}
+
+		for _, edge := range g.outgoing[current.id] {
+			if edge.Context == current.context {
+				queue = append(queue, visit{id: edge.To, context: edge.CalleeContext})
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// ToJSON converts the call graph into its JSON representation.
+func (g *CallGraph) ToJSON() ([]byte, error) {
+	return json.Marshal(g)
+}
@@ -0,0 +1,508 @@
+package cfg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+	"go.uber.org/zap"
+)
+
+// contractTypeRegex extracts the contract name from type identifiers such as `t_contract$_IERC20_$12`.
+var contractTypeRegex = regexp.MustCompile(`^t_contract\$_(.+)_\$\d+`)
+
+// callable is a function, constructor, fallback, receive or modifier together with the AST needed to resolve its calls.
+type callable struct {
+	node       *CallNode
+	parameters int
+	body       ast.Node[ast.NodeType]
+	modifiers  []*ast.ModifierInvocation
+}
+
+// callee identifies the callable a call is made to. Calls referencing their declaration are matched by its
+// signature, which tells apart overloads taking the same number of arguments. Other calls are matched by name
+// and number of arguments, a negative number matching any overload.
+type callee struct {
+	kind      CallNodeKind
+	name      string
+	signature string
+	arguments int
+}
+
+// matches reports whether the callable is the callee.
+func (c callee) matches(entry *callable) bool {
+	if entry.node.Kind != c.kind || entry.node.Name != c.name {
+		return false
+	}
+	if c.signature != "" {
+		return entry.node.Signature == c.signature
+	}
+	return c.arguments < 0 || entry.parameters == c.arguments
+}
+
+// attachedFunction is a library function attached to a type by a `using {L.f} for T` directive.
+type attachedFunction struct {
+	library string
+	name    string
+}
+
+// contractScope holds everything about a single contract needed to resolve calls made from or into it.
+type contractScope struct {
+	name      string
+	kind      ast_pb.NodeType
+	contract  *ir.Contract
+	callables []*callable
+	libraries []string
+	functions []attachedFunction
+}
+
+// callGraphBuilder constructs a CallGraph from the IR of all contracts.
+type callGraphBuilder struct {
+	root         *ir.RootSourceUnit
+	graph        *CallGraph
+	scopes       map[string]*contractScope
+	contracts    []string
+	declarations map[int64]*callable
+}
+
+// BuildCallGraph constructs the whole-program call graph of all contracts known to the IR builder.
+// Every contract, including abstract ones and libraries, is used as a dispatch context, so virtual
+// internal calls are resolved for each possible most derived contract.
+func (b *Builder) BuildCallGraph() (*CallGraph, error) {
+	root := b.builder.GetRoot()
+	if root == nil {
+		return nil, fmt.Errorf("root node is not set in IR builder")
+	}
+
+	cb := &callGraphBuilder{
+		root:         root,
+		graph:        NewCallGraph(),
+		scopes:       make(map[string]*contractScope),
+		contracts:    make([]string, 0),
+		declarations: make(map[int64]*callable),
+	}
+
+	cb.collect()
+	cb.linearize()
+	for _, contract := range cb.contracts {
+		cb.resolve(contract)
+	}
+
+	b.callGraph = cb.graph
+	return cb.graph, nil
+}
+
+// GetCallGraph returns the call graph built by the last BuildCallGraph call or nil if it was not built yet.
+func (b *Builder) GetCallGraph() *CallGraph {
+	return b.callGraph
+}
+
+// collect registers a node for every callable declared by every contract, along with the `using for`
+// directives in effect within the contract, declared either by the contract or at the level of its file.
+func (cb *callGraphBuilder) collect() {
+	var globals []ast.Node[ast.NodeType]
+	if root := cb.root.GetAST(); root != nil {
+		globals = root.GetGlobalNodes()
+	}
+
+	for _, contract := range cb.root.GetContracts() {
+		if _, exists := cb.scopes[contract.GetName()]; exists {
+			continue
+		}
+
+		unit, ok := contract.GetAST().GetContract().(ir.ContractNode)
+		if !ok {
+			continue
+		}
+
+		scope := &contractScope{
+			name:      contract.GetName(),
+			kind:      contract.GetKind(),
+			contract:  contract,
+			callables: make([]*callable, 0),
+			libraries: make([]string, 0),
+			functions: make([]attachedFunction, 0),
+		}
+		cb.scopes[scope.name] = scope
+		cb.contracts = append(cb.contracts, scope.name)
+
+		functions := make(map[int64]*ir.Function)
+		for _, function := range contract.GetFunctions() {
+			functions[function.GetId()] = function
+		}
+
+		if constructor := unit.GetConstructor(); constructor != nil {
+			cb.addCallable(scope, CallNodeConstructor, "constructor", "constructor", constructor, constructor.GetVisibility(), constructor.GetParameters(), constructor.GetBody(), constructor.GetModifiers(), nil)
+		}
+
+		for _, function := range unit.GetFunctions() {
+			cb.addCallable(scope, CallNodeFunction, function.GetName(), function.GetSignatureRaw(), function, function.GetVisibility(), function.GetParameters(), function.GetBody(), function.GetModifiers(), functions[function.GetId()])
+		}
+
+		if fallback := unit.GetFallback(); fallback != nil {
+			cb.addCallable(scope, CallNodeFallback, "fallback", "fallback", fallback, fallback.GetVisibility(), fallback.GetParameters(), fallback.GetBody(), fallback.GetModifiers(), nil)
+		}
+
+		if receive := unit.GetReceive(); receive != nil {
+			cb.addCallable(scope, CallNodeReceive, "receive", "receive", receive, receive.GetVisibility(), receive.GetParameters(), receive.GetBody(), receive.GetModifiers(), nil)
+		}
+
+		for _, child := range unit.GetNodes() {
+			switch node := child.(type) {
+			case *ast.ModifierDefinition:
+				cb.addCallable(scope, CallNodeModifier, node.GetName(), node.GetName(), node, node.GetVisibility(), node.GetParameters(), node.GetBody(), nil, nil)
+			case *ast.UsingDirective:
+				scope.addUsingDirective(node)
+			}
+		}
+
+		for _, global := range globals {
+			if node, ok := global.(*ast.UsingDirective); ok && node.GetSrc().FileIndex == unit.GetSrc().FileIndex {
+				scope.addUsingDirective(node)
+			}
+		}
+	}
+}
+
+// addUsingDirective registers the library or the library functions the directive attaches. Free functions are
+// not part of the AST, so attaching them has no effect.
+func (scope *contractScope) addUsingDirective(directive *ast.UsingDirective) {
+	if directive.LibraryName != nil && directive.LibraryName.Name != "" {
+		scope.libraries = append(scope.libraries, directive.LibraryName.Name)
+	}
+
+	for _, function := range directive.GetFunctionList() {
+		if library, name, ok := strings.Cut(function.Name, "."); ok {
+			scope.functions = append(scope.functions, attachedFunction{library: library, name: name})
+		}
+	}
+}
+
+// addCallable registers a callable of the contract scope and its node in the graph.
+func (cb *callGraphBuilder) addCallable(scope *contractScope, kind CallNodeKind, name string, signature string, unit ast.Node[ast.NodeType], visibility ast_pb.Visibility, parameters *ast.ParameterList, body *ast.BodyNode, modifiers []*ast.ModifierInvocation, function *ir.Function) {
+	if signature == "" {
+		signature = name
+	}
+
+	node := cb.graph.AddNode(&CallNode{
+		Id:           fmt.Sprintf("%s.%s", scope.name, signature),
+		Name:         name,
+		Signature:    signature,
+		Contract:     scope.name,
+		ContractKind: scope.kind,
+		Kind:         kind,
+		Visibility:   visibility,
+		Implemented:  body != nil,
+		Unit:         unit,
+		Function:     function,
+	})
+
+	entry := &callable{node: node, modifiers: modifiers}
+	if parameters != nil {
+		entry.parameters = len(parameters.GetParameters())
+	}
+	if body != nil {
+		entry.body = body
+	}
+
+	scope.callables = append(scope.callables, entry)
+	if unit != nil {
+		cb.declarations[unit.GetId()] = entry
+	}
+}
+
+// linearize computes the C3 linearization of every contract. Contracts whose hierarchy cannot be linearized
+// fall back to the contract itself followed by its bases in declaration order.
+func (cb *callGraphBuilder) linearize() {
+	for _, contract := range cb.contracts {
+		linearization, err := Linearize(contract, cb.bases)
+		if err != nil {
+			zap.L().Warn(
+				"failed to linearize contract, falling back to declaration order",
+				zap.String("contract", contract),
+				zap.Error(err),
+			)
+			linearization = append([]string{contract}, cb.bases(contract)...)
+		}
+		cb.graph.Linearizations[contract] = linearization
+	}
+}
+
+// bases returns the names of the direct base contracts of the contract in declaration order.
+func (cb *callGraphBuilder) bases(contract string) []string {
+	scope, ok := cb.scopes[contract]
+	if !ok {
+		return nil
+	}
+
+	toReturn := make([]string, 0)
+	for _, base := range scope.contract.GetBaseContracts() {
+		if base.GetBaseName() == nil {
+			continue
+		}
+
+		referenced := base.GetBaseName().GetReferencedDeclaration()
+		if baseContract := cb.root.GetContractBySourceUnitId(referenced); baseContract != nil {
+			toReturn = append(toReturn, baseContract.GetName())
+		} else if baseContract := cb.root.GetContractById(referenced); baseContract != nil {
+			toReturn = append(toReturn, baseContract.GetName())
+		} else if _, exists := cb.scopes[base.GetBaseName().GetName()]; exists {
+			toReturn = append(toReturn, base.GetBaseName().GetName())
+		}
+	}
+
+	return toReturn
+}
+
+// resolve adds the edges of every callable visible in the context contract, resolved for that context.
+func (cb *callGraphBuilder) resolve(context string) {
+	for _, declaring := range cb.graph.Linearizations[context] {
+		scope, ok := cb.scopes[declaring]
+		if !ok {
+			continue
+		}
+
+		for _, entry := range scope.callables {
+			for _, modifier := range entry.modifiers {
+				if target := cb.dispatch(context, callee{kind: CallNodeModifier, name: modifier.GetName(), arguments: -1}); target != nil {
+					cb.addEdge(entry.node, target, CallModifier, context, context)
+				}
+			}
+
+			if entry.body != nil {
+				cb.walk(entry.body, func(call *ast.FunctionCall) {
+					cb.resolveCall(scope, entry.node, call, context)
+				})
+			}
+		}
+	}
+}
+
+// walk invokes the visitor for every function call nested within the node.
+func (cb *callGraphBuilder) walk(node ast.Node[ast.NodeType], visitor func(call *ast.FunctionCall)) {
+	if node == nil {
+		return
+	}
+
+	if call, ok := node.(*ast.FunctionCall); ok && call != nil {
+		visitor(call)
+	}
+
+	for _, child := range node.GetNodes() {
+		cb.walk(child, visitor)
+	}
+}
+
+// resolveCall resolves a single call expression made by the caller declared in scope, executing in the context contract.
+func (cb *callGraphBuilder) resolveCall(scope *contractScope, caller *CallNode, call *ast.FunctionCall, context string) {
+	arguments := len(call.GetArguments())
+
+	switch expression := call.GetExpression().(type) {
+	case *ast.PrimaryExpression:
+		if expression == nil {
+			return
+		}
+
+		// Private functions cannot be overridden, so they always resolve to the declaring contract.
+		called := cb.callee(expression.GetReferencedDeclaration(), expression.GetName(), arguments)
+		if private := cb.lookup(scope.name, called); private != nil && private.Visibility == ast_pb.Visibility_PRIVATE {
+			cb.addEdge(caller, private, CallInternal, context, context)
+			return
+		}
+
+		if target := cb.dispatch(context, called); target != nil {
+			cb.addEdge(caller, target, CallInternal, context, context)
+		}
+
+	case *ast.MemberAccessExpression:
+		if expression == nil {
+			return
+		}
+		cb.resolveMemberCall(scope, caller, expression, arguments, context)
+	}
+}
+
+// callee returns the function a call refers to. The declaration referenced by the call is trusted if it is a
+// callable of the same name taking the number of arguments. Otherwise, such as when the reference is missing,
+// the name and number of arguments are used.
+func (cb *callGraphBuilder) callee(referencedDeclaration int64, name string, arguments int) callee {
+	if entry, ok := cb.declarations[referencedDeclaration]; ok && entry.node.Name == name && entry.parameters == arguments {
+		return callee{kind: entry.node.Kind, name: name, signature: entry.node.Signature, arguments: arguments}
+	}
+	return callee{kind: CallNodeFunction, name: name, arguments: arguments}
+}
+
+// resolveMemberCall resolves calls of the form `base.member(...)`.
+func (cb *callGraphBuilder) resolveMemberCall(scope *contractScope, caller *CallNode, expression *ast.MemberAccessExpression, arguments int, context string) {
+	member := expression.GetMemberName()
+	called := cb.callee(expression.GetReferencedDeclaration(), member, arguments)
+
+	switch base := expression.GetExpression().(type) {
+	case *ast.PrimaryExpression:
+		switch base.GetName() {
+		case "super":
+			if target := cb.dispatchSuper(context, scope.name, called); target != nil {
+				cb.addEdge(caller, target, CallSuper, context, context)
+			}
+			return
+		case "this":
+			if target := cb.dispatch(context, called); target != nil {
+				cb.addEdge(caller, target, CallExternal, context, context)
+			}
+			return
+		}
+
+		// Calls on a contract name are either library calls or explicit calls into a base contract.
+		if named, ok := cb.scopes[base.GetName()]; ok {
+			if target := cb.dispatch(named.name, called); target != nil {
+				if named.kind == ast_pb.NodeType_KIND_LIBRARY {
+					cb.addEdge(caller, target, CallLibrary, context, named.name)
+				} else {
+					cb.addEdge(caller, target, CallInternal, context, context)
+				}
+			}
+			return
+		}
+
+	case *ast.FunctionCall:
+		// Calls on an explicit conversion such as `IERC20(token).transfer(...)`.
+		if primary, ok := base.GetExpression().(*ast.PrimaryExpression); ok && primary != nil {
+			if named, ok := cb.scopes[primary.GetName()]; ok {
+				cb.addExternalEdges(caller, named.name, called, context)
+				return
+			}
+		}
+	}
+
+	if base := expression.GetExpression(); base != nil && base.GetTypeDescription() != nil {
+		if matches := contractTypeRegex.FindStringSubmatch(base.GetTypeDescription().GetIdentifier()); len(matches) == 2 {
+			if _, ok := cb.scopes[matches[1]]; ok {
+				cb.addExternalEdges(caller, matches[1], called, context)
+				return
+			}
+		}
+	}
+
+	// The remaining member calls can only reach user code through `using for` directives, where the base
+	// expression becomes the first argument of the library function.
+	if entry, ok := cb.declarations[expression.GetReferencedDeclaration()]; ok && entry.node.Name == member && entry.parameters == arguments+1 {
+		cb.addEdge(caller, entry.node, CallUsingFor, context, entry.node.Contract)
+		return
+	}
+
+	bound := callee{kind: CallNodeFunction, name: member, arguments: arguments + 1}
+	for _, function := range scope.functions {
+		if function.name != member {
+			continue
+		}
+		if target := cb.lookup(function.library, bound); target != nil {
+			cb.addEdge(caller, target, CallUsingFor, context, function.library)
+			return
+		}
+	}
+
+	for _, library := range scope.libraries {
+		if target := cb.lookup(library, bound); target != nil {
+			cb.addEdge(caller, target, CallUsingFor, context, library)
+			return
+		}
+	}
+}
+
+// addExternalEdges links an external call to the function of the target contract. Calls through an interface
+// are additionally linked to the implementation of every contract inheriting from the interface.
+func (cb *callGraphBuilder) addExternalEdges(caller *CallNode, contract string, called callee, context string) {
+	if target := cb.dispatch(contract, called); target != nil {
+		cb.addEdge(caller, target, CallExternal, context, contract)
+	}
+
+	if cb.scopes[contract].kind != ast_pb.NodeType_KIND_INTERFACE {
+		return
+	}
+
+	for _, implementer := range cb.contracts {
+		if implementer == contract || cb.scopes[implementer].kind == ast_pb.NodeType_KIND_INTERFACE {
+			continue
+		}
+
+		for _, base := range cb.graph.Linearizations[implementer] {
+			if base != contract {
+				continue
+			}
+
+			if target := cb.dispatch(implementer, called); target != nil && target.Implemented {
+				cb.addEdge(caller, target, CallExternal, context, implementer)
+			}
+			break
+		}
+	}
+}
+
+// dispatch resolves the callee through the linearization of the context contract, most derived first.
+// Implemented callables are preferred over declarations without a body.
+func (cb *callGraphBuilder) dispatch(context string, called callee) *CallNode {
+	return cb.dispatchFrom(cb.graph.Linearizations[context], called)
+}
+
+// dispatchSuper resolves a `super` call made by the declaring contract within the context contract.
+func (cb *callGraphBuilder) dispatchSuper(context string, declaring string, called callee) *CallNode {
+	linearization := cb.graph.Linearizations[context]
+	for i, contract := range linearization {
+		if contract == declaring {
+			return cb.dispatchFrom(linearization[i+1:], called)
+		}
+	}
+	return nil
+}
+
+// dispatchFrom returns the first callable matching the callee in the provided contracts.
+func (cb *callGraphBuilder) dispatchFrom(contracts []string, called callee) *CallNode {
+	var declaration *CallNode
+	for _, contract := range contracts {
+		if node := cb.lookup(contract, called); node != nil {
+			if node.Implemented {
+				return node
+			}
+			if declaration == nil {
+				declaration = node
+			}
+		}
+	}
+
+	// References resolved by name alone may point to a declaration no contract dispatches to.
+	if declaration == nil && called.signature != "" {
+		called.signature = ""
+		return cb.dispatchFrom(contracts, called)
+	}
+
+	return declaration
+}
+
+// lookup returns the callable declared by the contract matching the callee.
+func (cb *callGraphBuilder) lookup(contract string, called callee) *CallNode {
+	scope, ok := cb.scopes[contract]
+	if !ok {
+		return nil
+	}
+
+	for _, entry := range scope.callables {
+		if called.matches(entry) {
+			return entry.node
+		}
+	}
+	return nil
+}
+
+// addEdge adds an edge between the caller and the callee.
+func (cb *callGraphBuilder) addEdge(caller *CallNode, callee *CallNode, kind CallKind, context string, calleeContext string) {
+	cb.graph.AddEdge(&CallEdge{
+		From:          caller.Id,
+		To:            callee.Id,
+		Kind:          kind,
+		Context:       context,
+		CalleeContext: calleeContext,
+	})
+}
@@ -0,0 +1,114 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// exportedEdge is a call between two callables regardless of the context it was resolved in.
+type exportedEdge struct {
+	from string
+	to   string
+	kind CallKind
+}
+
+// uniqueEdges returns the edges of the graph with duplicates across dispatch contexts removed.
+func (g *CallGraph) uniqueEdges() []exportedEdge {
+	toReturn := make([]exportedEdge, 0, len(g.Edges))
+	seen := make(map[exportedEdge]bool)
+	for _, edge := range g.Edges {
+		key := exportedEdge{from: edge.From, to: edge.To, kind: edge.Kind}
+		if !seen[key] {
+			seen[key] = true
+			toReturn = append(toReturn, key)
+		}
+	}
+	return toReturn
+}
+
+// contractGroups returns the names of all contracts declaring callables, in order of appearance,
+// together with the callables each of them declares.
+func (g *CallGraph) contractGroups() ([]string, map[string][]*CallNode) {
+	order := make([]string, 0)
+	groups := make(map[string][]*CallNode)
+	for _, node := range g.Nodes {
+		if _, ok := groups[node.Contract]; !ok {
+			order = append(order, node.Contract)
+		}
+		groups[node.Contract] = append(groups[node.Contract], node)
+	}
+	return order, groups
+}
+
+// ToMermaid generates a Mermaid flowchart of the call graph. Callables are grouped into a subgraph per contract,
+// modifier invocations are drawn as dotted edges and every other edge is annotated with its kind.
+func (g *CallGraph) ToMermaid() string {
+	if len(g.Nodes) == 0 {
+		return "graph LR\n    No_Functions[No functions found]"
+	}
+
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.Id] = fmt.Sprintf("F%d", i)
+	}
+
+	var mermaidGraph strings.Builder
+	mermaidGraph.WriteString("graph LR\n")
+
+	order, groups := g.contractGroups()
+	for _, contract := range order {
+		mermaidGraph.WriteString(fmt.Sprintf("    subgraph %s\n", contract))
+		for _, node := range groups[contract] {
+			label := strings.ReplaceAll(node.Signature, "\"", "#quot;")
+			if node.Kind == CallNodeModifier {
+				mermaidGraph.WriteString(fmt.Sprintf("        %s{{\"%s\"}}\n", ids[node.Id], label))
+			} else {
+				mermaidGraph.WriteString(fmt.Sprintf("        %s[\"%s\"]\n", ids[node.Id], label))
+			}
+		}
+		mermaidGraph.WriteString("    end\n")
+	}
+
+	for _, edge := range g.uniqueEdges() {
+		if edge.kind == CallModifier {
+			mermaidGraph.WriteString(fmt.Sprintf("    %s -.-> %s\n", ids[edge.from], ids[edge.to]))
+		} else {
+			mermaidGraph.WriteString(fmt.Sprintf("    %s -->|%s| %s\n", ids[edge.from], edge.kind, ids[edge.to]))
+		}
+	}
+
+	return mermaidGraph.String()
+}
+
+// ToDOT generates a Graphviz DOT representation of the call graph with a cluster per contract.
+func (g *CallGraph) ToDOT() string {
+	var dotGraph strings.Builder
+	dotGraph.WriteString("digraph callgraph {\n")
+	dotGraph.WriteString("    rankdir=LR;\n")
+	dotGraph.WriteString("    node [shape=box];\n")
+
+	order, groups := g.contractGroups()
+	for i, contract := range order {
+		dotGraph.WriteString(fmt.Sprintf("    subgraph cluster_%d {\n", i))
+		dotGraph.WriteString(fmt.Sprintf("        label=%q;\n", contract))
+		for _, node := range groups[contract] {
+			if node.Kind == CallNodeModifier {
+				dotGraph.WriteString(fmt.Sprintf("        %q [label=%q, shape=hexagon];\n", node.Id, node.Signature))
+			} else {
+				dotGraph.WriteString(fmt.Sprintf("        %q [label=%q];\n", node.Id, node.Signature))
+			}
+		}
+		dotGraph.WriteString("    }\n")
+	}
+
+	for _, edge := range g.uniqueEdges() {
+		if edge.kind == CallModifier {
+			dotGraph.WriteString(fmt.Sprintf("    %q -> %q [label=%q, style=dashed];\n", edge.from, edge.to, edge.kind))
+		} else {
+			dotGraph.WriteString(fmt.Sprintf("    %q -> %q [label=%q];\n", edge.from, edge.to, edge.kind))
+		}
+	}
+
+	dotGraph.WriteString("}\n")
+	return dotGraph.String()
+}
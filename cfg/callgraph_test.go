@@ -0,0 +1,322 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/ir"
+	"github.com/unpackdev/solgo/tests"
+)
+
+func TestLinearize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contract string
+		bases    map[string][]string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "No Bases",
+			contract: "A",
+			bases:    map[string][]string{},
+			expected: []string{"A"},
+		},
+		{
+			name:     "Single Chain",
+			contract: "C",
+			bases:    map[string][]string{"C": {"B"}, "B": {"A"}},
+			expected: []string{"C", "B", "A"},
+		},
+		{
+			name:     "Diamond",
+			contract: "D",
+			bases:    map[string][]string{"D": {"B", "C"}, "B": {"A"}, "C": {"A"}},
+			expected: []string{"D", "C", "B", "A"},
+		},
+		{
+			name:     "Solidity Documentation Example",
+			contract: "Final",
+			bases: map[string][]string{
+				"Final":        {"Base2", "Base1"},
+				"Base1":        {"Base"},
+				"Base2":        {"Base"},
+				"Base":         {"Destructible"},
+				"Destructible": {},
+			},
+			expected: []string{"Final", "Base1", "Base2", "Base", "Destructible"},
+		},
+		{
+			name:     "Base Listed Before Derived",
+			contract: "C",
+			bases:    map[string][]string{"C": {"A", "B"}, "B": {"A"}},
+			expected: []string{"C", "B", "A"},
+		},
+		{
+			name:     "Impossible Order",
+			contract: "C",
+			bases:    map[string][]string{"C": {"B", "A"}, "B": {"A"}},
+			wantErr:  true,
+		},
+		{
+			name:     "Cycle",
+			contract: "A",
+			bases:    map[string][]string{"A": {"B"}, "B": {"A"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			linearization, err := Linearize(testCase.contract, func(contract string) []string {
+				return testCase.bases[contract]
+			})
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, linearization)
+		})
+	}
+}
+
+func TestCallGraph(t *testing.T) {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name: "Vault",
+				Path: "Vault.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library MathLib {
+    function add(uint256 a, uint256 b) internal pure returns (uint256) {
+        return a + b;
+    }
+
+    function double(uint256 a) internal pure returns (uint256) {
+        return add(a, a);
+    }
+
+    function triple(uint256 a) internal pure returns (uint256) {
+        return a * 3;
+    }
+}
+
+using {MathLib.triple} for uint256;
+
+interface IToken {
+    function transfer(address to, uint256 amount) external returns (bool);
+}
+
+contract Token is IToken {
+    function transfer(address to, uint256 amount) external returns (bool) {
+        return to != address(0) && amount > 0;
+    }
+}
+
+contract Base {
+    uint256 public total;
+
+    modifier guarded() {
+        _;
+    }
+
+    function hook(uint256 amount) internal virtual {
+        total = amount;
+    }
+
+    function settle(uint256 amount) internal {
+        total = amount.triple();
+    }
+
+    function run(uint256 amount) public virtual guarded {
+        hook(amount);
+        settle(amount);
+    }
+}
+
+contract Middle is Base {
+    function hook(uint256 amount) internal virtual override {
+        super.hook(amount);
+    }
+}
+
+contract Vault is Middle {
+    using MathLib for uint256;
+
+    IToken public token;
+
+    function hook(uint256 amount) internal override {
+        total = amount.double();
+        super.hook(MathLib.add(amount, 1));
+    }
+
+    function pay(address to, uint256 amount) external {
+        run(amount);
+        token.transfer(to, amount);
+        IToken(to).transfer(to, amount);
+        this.run(amount);
+    }
+}`,
+			},
+		},
+		EntrySourceUnitName: "Vault",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	irBuilder, err := ir.NewBuilderFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+	assert.Empty(t, irBuilder.Parse())
+	require.NoError(t, irBuilder.Build())
+
+	builder, err := NewBuilder(context.TODO(), irBuilder)
+	require.NoError(t, err)
+	assert.Nil(t, builder.GetCallGraph())
+
+	graph, err := builder.BuildCallGraph()
+	require.NoError(t, err)
+	require.NotNil(t, graph)
+	assert.Equal(t, graph, builder.GetCallGraph())
+
+	assert.Equal(t, []string{"Vault", "Middle", "Base"}, graph.GetLinearization("Vault"))
+	assert.Equal(t, []string{"Token", "IToken"}, graph.GetLinearization("Token"))
+
+	hasEdge := func(from string, to string, kind CallKind, context string) bool {
+		for _, edge := range graph.GetCallees(from) {
+			if edge.To == to && edge.Kind == kind && edge.Context == context {
+				return true
+			}
+		}
+		return false
+	}
+
+	edgeCases := []struct {
+		name    string
+		from    string
+		to      string
+		kind    CallKind
+		context string
+		want    bool
+	}{
+		{"Virtual Dispatch From Base", "Base.run(uint256)", "Vault.hook(uint256)", CallInternal, "Vault", true},
+		{"No Dispatch Past Most Derived", "Base.run(uint256)", "Base.hook(uint256)", CallInternal, "Vault", false},
+		{"Base Context Dispatch", "Base.run(uint256)", "Base.hook(uint256)", CallInternal, "Base", true},
+		{"Internal Call To Non Virtual", "Base.run(uint256)", "Base.settle(uint256)", CallInternal, "Vault", true},
+		{"File Level Function List", "Base.settle(uint256)", "MathLib.triple(uint256)", CallUsingFor, "Base", true},
+		{"Modifier Invocation", "Base.run(uint256)", "Base.guarded", CallModifier, "Vault", true},
+		{"Super From Most Derived", "Vault.hook(uint256)", "Middle.hook(uint256)", CallSuper, "Vault", true},
+		{"Super From Middle", "Middle.hook(uint256)", "Base.hook(uint256)", CallSuper, "Vault", true},
+		{"Using For", "Vault.hook(uint256)", "MathLib.double(uint256)", CallUsingFor, "Vault", true},
+		{"Library Call", "Vault.hook(uint256)", "MathLib.add(uint256,uint256)", CallLibrary, "Vault", true},
+		{"Internal Library Call", "MathLib.double(uint256)", "MathLib.add(uint256,uint256)", CallInternal, "MathLib", true},
+		{"Internal Call", "Vault.pay(address,uint256)", "Base.run(uint256)", CallInternal, "Vault", true},
+		{"Interface Call", "Vault.pay(address,uint256)", "IToken.transfer(address,uint256)", CallExternal, "Vault", true},
+		{"Interface Implementation", "Vault.pay(address,uint256)", "Token.transfer(address,uint256)", CallExternal, "Vault", true},
+		{"This Call", "Vault.pay(address,uint256)", "Base.run(uint256)", CallExternal, "Vault", true},
+	}
+
+	for _, testCase := range edgeCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, hasEdge(testCase.from, testCase.to, testCase.kind, testCase.context))
+		})
+	}
+
+	t.Run("Reachability", func(t *testing.T) {
+		reachable, err := graph.Reachable("Vault", "pay")
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(reachable))
+		for _, node := range reachable {
+			ids = append(ids, node.GetId())
+		}
+
+		assert.Equal(t, "Vault.pay(address,uint256)", ids[0])
+		assert.ElementsMatch(t, []string{
+			"Vault.pay(address,uint256)",
+			"Base.run(uint256)",
+			"Base.guarded",
+			"Vault.hook(uint256)",
+			"Middle.hook(uint256)",
+			"Base.hook(uint256)",
+			"Base.settle(uint256)",
+			"MathLib.triple(uint256)",
+			"MathLib.double(uint256)",
+			"MathLib.add(uint256,uint256)",
+			"IToken.transfer(address,uint256)",
+			"Token.transfer(address,uint256)",
+		}, ids)
+
+		reachable, err = graph.Reachable("Base", "run")
+		require.NoError(t, err)
+		ids = ids[:0]
+		for _, node := range reachable {
+			ids = append(ids, node.GetId())
+		}
+		assert.ElementsMatch(t, []string{"Base.run(uint256)", "Base.guarded", "Base.hook(uint256)", "Base.settle(uint256)", "MathLib.triple(uint256)"}, ids)
+
+		_, err = graph.Reachable("Vault", "missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("Entry Points", func(t *testing.T) {
+		names := make([]string, 0)
+		for _, node := range graph.GetEntryPoints("Vault") {
+			names = append(names, node.GetId())
+		}
+		assert.ElementsMatch(t, []string{"Vault.pay(address,uint256)", "Base.run(uint256)"}, names)
+		assert.Empty(t, graph.GetEntryPoints("MathLib"))
+	})
+
+	t.Run("Exporters", func(t *testing.T) {
+		mermaid := graph.ToMermaid()
+		assert.True(t, strings.HasPrefix(mermaid, "graph LR\n"))
+		assert.Contains(t, mermaid, "subgraph Vault")
+		assert.Contains(t, mermaid, "-->|super|")
+		assert.Contains(t, mermaid, "-.->")
+
+		dot := graph.ToDOT()
+		assert.True(t, strings.HasPrefix(dot, "digraph callgraph {"))
+		assert.Contains(t, dot, `"Vault.hook(uint256)" -> "MathLib.double(uint256)" [label="using_for"];`)
+
+		data, err := graph.ToJSON()
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Len(t, decoded["nodes"], len(graph.GetNodes()))
+		assert.Len(t, decoded["edges"], len(graph.GetEdges()))
+	})
+}
+
+func TestCallGraphFromSolcJSON(t *testing.T) {
+	irBuilder, err := ir.NewBuilderFromSolcJSON(context.TODO(), tests.ReadJsonBytesForTest(t, "ast/Overloads.solc.ast").Bytes, nil)
+	require.NoError(t, err)
+	assert.Empty(t, irBuilder.Parse())
+	require.NoError(t, irBuilder.Build())
+
+	builder, err := NewBuilder(context.TODO(), irBuilder)
+	require.NoError(t, err)
+
+	graph, err := builder.BuildCallGraph()
+	require.NoError(t, err)
+
+	hasEdge := func(from string, to string, kind CallKind) bool {
+		for _, edge := range graph.GetCallees(from) {
+			if edge.To == to && edge.Kind == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Both overloads take a single argument, only the referenced declaration tells them apart.
+	assert.True(t, hasEdge("Overloads.run(address)", "Overloads.settle(address)", CallInternal))
+	assert.False(t, hasEdge("Overloads.run(address)", "Overloads.settle(uint256)", CallInternal))
+	assert.True(t, hasEdge("Overloads.settle(uint256)", "MathLib.triple(uint256)", CallUsingFor))
+}
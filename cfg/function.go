@@ -0,0 +1,335 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// EdgeKind describes why control flows from one basic block to another.
+type EdgeKind string
+
+const (
+	// EdgeFallthrough is an unconditional edge to the next block.
+	EdgeFallthrough EdgeKind = "fallthrough"
+	// EdgeTrue is taken when the condition terminating the block holds.
+	EdgeTrue EdgeKind = "true"
+	// EdgeFalse is taken when the condition terminating the block does not hold.
+	EdgeFalse EdgeKind = "false"
+	// EdgeLoopBack is the back edge of a loop.
+	EdgeLoopBack EdgeKind = "loop_back"
+	// EdgeBreak is taken by a break statement.
+	EdgeBreak EdgeKind = "break"
+	// EdgeContinue is taken by a continue statement.
+	EdgeContinue EdgeKind = "continue"
+	// EdgeReturn is taken by a return statement.
+	EdgeReturn EdgeKind = "return"
+	// EdgeRevert is taken by a revert statement or a failing require/assert.
+	EdgeRevert EdgeKind = "revert"
+	// EdgeTrySuccess is taken when the external call of a try statement succeeds.
+	EdgeTrySuccess EdgeKind = "try_success"
+	// EdgeTryCatch is taken when the external call of a try statement fails.
+	EdgeTryCatch EdgeKind = "try_catch"
+)
+
+// Edge represents a directed edge between two basic blocks of a function graph.
+type Edge struct {
+	From int      `json:"from"`
+	To   int      `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// BasicBlock represents a sequence of IR statements that are always executed together.
+// Control flow statements such as if, loops and try end a block and are kept as the block terminator.
+type BasicBlock struct {
+	Id         int            `json:"id"`
+	Label      string         `json:"label"`
+	Modifier   string         `json:"modifier,omitempty"` // Name of the modifier the block was inlined from.
+	Statements []ir.Statement `json:"-"`
+	Terminator ir.Statement   `json:"-"`
+}
+
+// GetId returns the identifier of the block, unique within the function graph.
+func (b *BasicBlock) GetId() int {
+	return b.Id
+}
+
+// GetLabel returns the label of the block describing its role, such as `entry` or `for.body`.
+func (b *BasicBlock) GetLabel() string {
+	return b.Label
+}
+
+// GetModifier returns the name of the modifier the block was inlined from or an empty string.
+func (b *BasicBlock) GetModifier() string {
+	return b.Modifier
+}
+
+// GetStatements returns the statements of the block in execution order.
+func (b *BasicBlock) GetStatements() []ir.Statement {
+	return b.Statements
+}
+
+// GetTerminator returns the control flow statement ending the block or nil if the block ends unconditionally.
+func (b *BasicBlock) GetTerminator() ir.Statement {
+	return b.Terminator
+}
+
+// IsEmpty returns true if the block contains no statements.
+func (b *BasicBlock) IsEmpty() bool {
+	return len(b.Statements) == 0 && b.Terminator == nil
+}
+
+// statementRef is the JSON representation of a statement within a basic block.
+type statementRef struct {
+	Id       int64           `json:"id"`
+	NodeType ast_pb.NodeType `json:"nodeType"`
+	Kind     ast_pb.NodeType `json:"kind"`
+	Text     string          `json:"text"`
+}
+
+// MarshalJSON marshals the basic block, describing its statements by reference rather than in full.
+func (b *BasicBlock) MarshalJSON() ([]byte, error) {
+	toStatementRef := func(statement ir.Statement) *statementRef {
+		return &statementRef{
+			Id:       statement.GetId(),
+			NodeType: statement.GetNodeType(),
+			Kind:     statement.GetKind(),
+			Text:     describeStatement(statement),
+		}
+	}
+
+	statements := make([]*statementRef, 0, len(b.Statements))
+	for _, statement := range b.Statements {
+		statements = append(statements, toStatementRef(statement))
+	}
+
+	var terminator *statementRef
+	if b.Terminator != nil {
+		terminator = toStatementRef(b.Terminator)
+	}
+
+	return json.Marshal(struct {
+		Id         int             `json:"id"`
+		Label      string          `json:"label"`
+		Modifier   string          `json:"modifier,omitempty"`
+		Statements []*statementRef `json:"statements"`
+		Terminator *statementRef   `json:"terminator,omitempty"`
+	}{
+		Id:         b.Id,
+		Label:      b.Label,
+		Modifier:   b.Modifier,
+		Statements: statements,
+		Terminator: terminator,
+	})
+}
+
+// FunctionGraph represents the intra-function control flow graph of a single function, with the bodies of
+// its modifiers inlined at their placeholders. Every graph has a single entry block and two sinks, one for
+// normal completion and one for reverts.
+type FunctionGraph struct {
+	Name     string        `json:"name"`
+	Contract string        `json:"contract"`
+	Function *ir.Function  `json:"-"`
+	Entry    int           `json:"entry"`
+	Exit     int           `json:"exit"`
+	Revert   int           `json:"revert"`
+	Blocks   []*BasicBlock `json:"blocks"`
+	Edges    []*Edge       `json:"edges"`
+}
+
+// NewFunctionGraph creates a function graph with the entry, exit and revert blocks in place.
+func NewFunctionGraph(contract string, function *ir.Function) *FunctionGraph {
+	toReturn := &FunctionGraph{
+		Name:     function.GetName(),
+		Contract: contract,
+		Function: function,
+		Blocks:   make([]*BasicBlock, 0),
+		Edges:    make([]*Edge, 0),
+	}
+
+	toReturn.Entry = toReturn.AddBlock("entry", "").GetId()
+	toReturn.Exit = toReturn.AddBlock("exit", "").GetId()
+	toReturn.Revert = toReturn.AddBlock("revert", "").GetId()
+
+	return toReturn
+}
+
+// GetName returns the name of the function.
+func (g *FunctionGraph) GetName() string {
+	return g.Name
+}
+
+// GetContract returns the name of the contract the function belongs to.
+func (g *FunctionGraph) GetContract() string {
+	return g.Contract
+}
+
+// GetFunction returns the IR of the function the graph was built from.
+func (g *FunctionGraph) GetFunction() *ir.Function {
+	return g.Function
+}
+
+// GetBlocks returns all blocks of the graph ordered by their id.
+func (g *FunctionGraph) GetBlocks() []*BasicBlock {
+	return g.Blocks
+}
+
+// GetEdges returns all edges of the graph in the order they were added.
+func (g *FunctionGraph) GetEdges() []*Edge {
+	return g.Edges
+}
+
+// GetBlock returns the block with the provided id or nil if it does not exist.
+func (g *FunctionGraph) GetBlock(id int) *BasicBlock {
+	if id < 0 || id >= len(g.Blocks) {
+		return nil
+	}
+	return g.Blocks[id]
+}
+
+// GetEntry returns the entry block of the graph.
+func (g *FunctionGraph) GetEntry() *BasicBlock {
+	return g.GetBlock(g.Entry)
+}
+
+// GetExit returns the block reached when the function completes normally.
+func (g *FunctionGraph) GetExit() *BasicBlock {
+	return g.GetBlock(g.Exit)
+}
+
+// GetRevert returns the block reached when the function reverts.
+func (g *FunctionGraph) GetRevert() *BasicBlock {
+	return g.GetBlock(g.Revert)
+}
+
+// AddBlock appends a new empty block to the graph and returns it.
+func (g *FunctionGraph) AddBlock(label string, modifier string) *BasicBlock {
+	block := &BasicBlock{
+		Id:         len(g.Blocks),
+		Label:      label,
+		Modifier:   modifier,
+		Statements: make([]ir.Statement, 0),
+	}
+	g.Blocks = append(g.Blocks, block)
+	return block
+}
+
+// AddEdge adds a directed edge between two blocks of the graph.
+func (g *FunctionGraph) AddEdge(from int, to int, kind EdgeKind) {
+	g.Edges = append(g.Edges, &Edge{From: from, To: to, Kind: kind})
+}
+
+// GetSuccessors returns the outgoing edges of the block with the provided id.
+func (g *FunctionGraph) GetSuccessors(id int) []*Edge {
+	toReturn := make([]*Edge, 0)
+	for _, edge := range g.Edges {
+		if edge.From == id {
+			toReturn = append(toReturn, edge)
+		}
+	}
+	return toReturn
+}
+
+// GetPredecessors returns the incoming edges of the block with the provided id.
+func (g *FunctionGraph) GetPredecessors(id int) []*Edge {
+	toReturn := make([]*Edge, 0)
+	for _, edge := range g.Edges {
+		if edge.To == id {
+			toReturn = append(toReturn, edge)
+		}
+	}
+	return toReturn
+}
+
+// IsReachable returns true if the block with the provided id can be reached from the entry block.
+func (g *FunctionGraph) IsReachable(id int) bool {
+	visited := make(map[int]bool)
+	queue := []int{g.Entry}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == id {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for _, edge := range g.GetSuccessors(current) {
+			queue = append(queue, edge.To)
+		}
+	}
+	return false
+}
+
+// ToJSON converts the function graph into its JSON representation.
+func (g *FunctionGraph) ToJSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+// ToMermaid generates a Mermaid flowchart of the function graph. Each block is rendered with its label
+// followed by a short description of its statements, and each edge is annotated with its kind.
+func (g *FunctionGraph) ToMermaid() string {
+	var mermaidGraph strings.Builder
+	mermaidGraph.WriteString("flowchart TD\n")
+
+	for _, block := range g.Blocks {
+		lines := []string{block.Label}
+		if block.Modifier != "" {
+			lines[0] = fmt.Sprintf("%s (%s)", block.Label, block.Modifier)
+		}
+		for _, statement := range block.Statements {
+			lines = append(lines, describeStatement(statement))
+		}
+		if block.Terminator != nil {
+			lines = append(lines, describeStatement(block.Terminator))
+		}
+
+		label := strings.ReplaceAll(strings.Join(lines, "<br/>"), "\"", "#quot;")
+		switch block.Id {
+		case g.Entry, g.Exit, g.Revert:
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d([\"%s\"])\n", block.Id, label))
+		default:
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d[\"%s\"]\n", block.Id, label))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Kind == EdgeFallthrough {
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d --> B%d\n", edge.From, edge.To))
+		} else {
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d -->|%s| B%d\n", edge.From, edge.Kind, edge.To))
+		}
+	}
+
+	return mermaidGraph.String()
+}
+
+// String returns a plain text listing of the blocks of the function graph and their successors.
+func (g *FunctionGraph) String() string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Function %s.%s:\n", g.Contract, g.Name))
+
+	for _, block := range g.Blocks {
+		if block.Modifier != "" {
+			output.WriteString(fmt.Sprintf("  Block %d (%s, modifier %s):\n", block.Id, block.Label, block.Modifier))
+		} else {
+			output.WriteString(fmt.Sprintf("  Block %d (%s):\n", block.Id, block.Label))
+		}
+		for _, statement := range block.Statements {
+			output.WriteString(fmt.Sprintf("    %s\n", describeStatement(statement)))
+		}
+		if block.Terminator != nil {
+			output.WriteString(fmt.Sprintf("    %s\n", describeStatement(block.Terminator)))
+		}
+		for _, edge := range g.GetSuccessors(block.Id) {
+			output.WriteString(fmt.Sprintf("    -> %d (%s)\n", edge.To, edge.Kind))
+		}
+	}
+
+	return output.String()
+}
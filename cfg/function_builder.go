@@ -0,0 +1,394 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// loopTargets holds the blocks break and continue statements jump to within a loop.
+type loopTargets struct {
+	continueTarget int
+	breakTarget    int
+}
+
+// functionGraphBuilder lowers the IR of a single function into a FunctionGraph.
+// It keeps track of the block currently being filled, enclosing loops and the block
+// a return statement jumps to, which differs once modifiers are inlined.
+type functionGraphBuilder struct {
+	graph        *FunctionGraph
+	modifiers    []*ir.Modifier
+	current      *BasicBlock
+	modifier     string
+	depth        int
+	loops        []loopTargets
+	returnTarget int
+}
+
+// BuildFunctionGraph constructs the control flow graph of the provided function.
+// Bodies of the invoked modifiers are inlined in invocation order, with the `_` placeholder
+// of each modifier replaced by the next modifier or, for the last one, by the function body.
+// Modifiers without a resolved body are skipped.
+func BuildFunctionGraph(contract string, function *ir.Function) *FunctionGraph {
+	graph := NewFunctionGraph(contract, function)
+
+	fb := &functionGraphBuilder{
+		graph:        graph,
+		modifiers:    function.GetModifiers(),
+		current:      graph.GetEntry(),
+		returnTarget: graph.Exit,
+	}
+
+	fb.lowerModifier(0)
+	fb.jump(graph.Exit, EdgeFallthrough)
+
+	return graph
+}
+
+// lowerModifier lowers the modifier at the provided index or the function body once all modifiers are lowered.
+func (fb *functionGraphBuilder) lowerModifier(index int) {
+	if index >= len(fb.modifiers) {
+		fb.lowerBody(fb.graph.GetFunction().GetBody())
+		return
+	}
+
+	modifier := fb.modifiers[index]
+	if modifier.GetBody() == nil {
+		fb.lowerModifier(index + 1)
+		return
+	}
+
+	previousModifier, previousDepth, previousReturn := fb.modifier, fb.depth, fb.returnTarget
+	fb.modifier, fb.depth = modifier.GetName(), index
+
+	start := fb.graph.AddBlock(fmt.Sprintf("modifier.%s", modifier.GetName()), fb.modifier)
+	fb.jump(start.GetId(), EdgeFallthrough)
+	fb.current = start
+
+	// A return within the modifier body ends the modifier and resumes the enclosing code.
+	end := fb.graph.AddBlock(fmt.Sprintf("modifier.%s.end", modifier.GetName()), fb.modifier)
+	fb.returnTarget = end.GetId()
+
+	fb.lowerBody(modifier.GetBody())
+	fb.jump(end.GetId(), EdgeFallthrough)
+	fb.current = end
+
+	fb.modifier, fb.depth, fb.returnTarget = previousModifier, previousDepth, previousReturn
+}
+
+// lowerPlaceholder inlines the code wrapped by the modifier currently being lowered.
+func (fb *functionGraphBuilder) lowerPlaceholder() {
+	previousModifier, previousDepth, previousReturn, previousLoops := fb.modifier, fb.depth, fb.returnTarget, fb.loops
+
+	// A return within the wrapped code resumes the modifier right after the placeholder.
+	after := fb.graph.AddBlock(fmt.Sprintf("modifier.%s.resume", fb.modifier), fb.modifier)
+	fb.returnTarget = after.GetId()
+	fb.loops = nil
+	fb.modifier = ""
+
+	fb.lowerModifier(previousDepth + 1)
+	fb.jump(after.GetId(), EdgeFallthrough)
+	fb.current = after
+
+	fb.modifier, fb.depth, fb.returnTarget, fb.loops = previousModifier, previousDepth, previousReturn, previousLoops
+}
+
+// lowerBody lowers all statements of the provided body into the current block.
+func (fb *functionGraphBuilder) lowerBody(body *ir.Body) {
+	if body == nil {
+		return
+	}
+
+	for _, statement := range body.GetStatements() {
+		fb.lowerStatement(statement)
+	}
+}
+
+// lowerStatements lowers the provided statements into the current block.
+func (fb *functionGraphBuilder) lowerStatements(statements []ir.Statement) {
+	for _, statement := range statements {
+		fb.lowerStatement(statement)
+	}
+}
+
+// lowerStatement lowers a single statement, splitting blocks and adding edges for control flow statements.
+func (fb *functionGraphBuilder) lowerStatement(statement ir.Statement) {
+	switch s := statement.(type) {
+	case *ir.If:
+		fb.lowerStatements(s.GetCondition())
+		head := fb.terminate(s)
+
+		then := fb.newBlock("if.then")
+		fb.graph.AddEdge(head, then.GetId(), EdgeTrue)
+		fb.current = then
+		fb.lowerBody(s.GetBody())
+		pending := []*BasicBlock{fb.current}
+
+		if s.GetFalseBody() != nil {
+			otherwise := fb.newBlock("if.else")
+			fb.graph.AddEdge(head, otherwise.GetId(), EdgeFalse)
+			fb.current = otherwise
+			fb.lowerBody(s.GetFalseBody())
+			pending = append(pending, fb.current)
+		}
+
+		// Without an else branch the false edge goes straight to the join block.
+		join := fb.newBlock("if.end")
+		if s.GetFalseBody() == nil {
+			fb.graph.AddEdge(head, join.GetId(), EdgeFalse)
+		}
+		for _, block := range pending {
+			fb.current = block
+			fb.jump(join.GetId(), EdgeFallthrough)
+		}
+		fb.current = join
+
+	case *ir.For:
+		fb.lowerStatements(s.GetInitialiser())
+
+		condition := fb.newBlock("for.cond")
+		fb.jump(condition.GetId(), EdgeFallthrough)
+		fb.current = condition
+		fb.lowerStatements(s.GetCondition())
+		head := fb.terminate(s)
+
+		body := fb.newBlock("for.body")
+		closure := fb.newBlock("for.closure")
+		exit := fb.newBlock("for.end")
+		fb.graph.AddEdge(head, body.GetId(), EdgeTrue)
+		fb.graph.AddEdge(head, exit.GetId(), EdgeFalse)
+
+		fb.pushLoop(closure.GetId(), exit.GetId())
+		fb.current = body
+		fb.lowerBody(s.GetBody())
+		fb.jump(closure.GetId(), EdgeFallthrough)
+		fb.popLoop()
+
+		fb.current = closure
+		fb.lowerStatements(s.GetClosure())
+		fb.jump(condition.GetId(), EdgeLoopBack)
+		fb.current = exit
+
+	case *ir.While:
+		condition := fb.newBlock("while.cond")
+		fb.jump(condition.GetId(), EdgeFallthrough)
+		fb.current = condition
+		fb.lowerStatements(s.GetCondition())
+		head := fb.terminate(s)
+
+		body := fb.newBlock("while.body")
+		exit := fb.newBlock("while.end")
+		fb.graph.AddEdge(head, body.GetId(), EdgeTrue)
+		fb.graph.AddEdge(head, exit.GetId(), EdgeFalse)
+
+		fb.pushLoop(condition.GetId(), exit.GetId())
+		fb.current = body
+		fb.lowerBody(s.GetBody())
+		fb.jump(condition.GetId(), EdgeLoopBack)
+		fb.popLoop()
+		fb.current = exit
+
+	case *ir.DoWhile:
+		body := fb.newBlock("do.body")
+		fb.jump(body.GetId(), EdgeFallthrough)
+		condition := fb.newBlock("do.cond")
+		exit := fb.newBlock("do.end")
+
+		fb.pushLoop(condition.GetId(), exit.GetId())
+		fb.current = body
+		fb.lowerBody(s.GetBody())
+		fb.jump(condition.GetId(), EdgeFallthrough)
+		fb.popLoop()
+
+		fb.current = condition
+		fb.lowerStatements(s.GetCondition())
+		head := fb.terminate(s)
+		fb.graph.AddEdge(head, body.GetId(), EdgeLoopBack)
+		fb.graph.AddEdge(head, exit.GetId(), EdgeFalse)
+		fb.current = exit
+
+	case *ir.Try:
+		fb.lowerStatements(s.GetCall())
+		head := fb.terminate(s)
+
+		pending := make([]*BasicBlock, 0, len(s.GetClauses())+1)
+
+		success := fb.newBlock("try.success")
+		fb.graph.AddEdge(head, success.GetId(), EdgeTrySuccess)
+		fb.current = success
+		fb.lowerBody(s.GetBody())
+		pending = append(pending, fb.current)
+
+		for _, clause := range s.GetClauses() {
+			label := "catch"
+			if clause.GetName() != "" {
+				label = fmt.Sprintf("catch.%s", clause.GetName())
+			}
+
+			catch := fb.newBlock(label)
+			fb.graph.AddEdge(head, catch.GetId(), EdgeTryCatch)
+			fb.current = catch
+			fb.lowerBody(clause.GetBody())
+			pending = append(pending, fb.current)
+		}
+
+		join := fb.newBlock("try.end")
+		for _, block := range pending {
+			fb.current = block
+			fb.jump(join.GetId(), EdgeFallthrough)
+		}
+		fb.current = join
+
+	case *ir.Block:
+		fb.lowerBody(s.GetBody())
+
+	case *ir.Break:
+		fb.append(s)
+		if len(fb.loops) > 0 {
+			fb.jump(fb.loops[len(fb.loops)-1].breakTarget, EdgeBreak)
+		}
+		fb.current = nil
+
+	case *ir.Continue:
+		fb.append(s)
+		if len(fb.loops) > 0 {
+			fb.jump(fb.loops[len(fb.loops)-1].continueTarget, EdgeContinue)
+		}
+		fb.current = nil
+
+	case *ir.Return:
+		fb.append(s)
+		fb.jump(fb.returnTarget, EdgeReturn)
+
+	case *ir.Revert:
+		fb.append(s)
+		fb.jump(fb.graph.Revert, EdgeRevert)
+
+	case *ir.FunctionCall:
+		switch s.GetName() {
+		case "revert":
+			fb.append(s)
+			fb.jump(fb.graph.Revert, EdgeRevert)
+		case "require", "assert":
+			head := fb.terminate(s)
+			fb.graph.AddEdge(head, fb.graph.Revert, EdgeRevert)
+			next := fb.newBlock(fmt.Sprintf("%s.pass", s.GetName()))
+			fb.graph.AddEdge(head, next.GetId(), EdgeTrue)
+			fb.current = next
+		default:
+			fb.append(s)
+		}
+
+	case *ir.ExpressionStatement:
+		if s.IsPlaceholder() && fb.modifier != "" {
+			fb.lowerPlaceholder()
+			return
+		}
+		fb.append(s)
+
+	default:
+		fb.append(s)
+	}
+}
+
+// newBlock adds a new block tagged with the modifier currently being lowered.
+func (fb *functionGraphBuilder) newBlock(label string) *BasicBlock {
+	return fb.graph.AddBlock(label, fb.modifier)
+}
+
+// ensure makes sure there is a block to append statements to. Statements following a jump
+// are placed into a fresh block without predecessors, keeping unreachable code visible.
+func (fb *functionGraphBuilder) ensure() *BasicBlock {
+	if fb.current == nil {
+		fb.current = fb.newBlock("unreachable")
+	}
+	return fb.current
+}
+
+// append adds the statement to the current block.
+func (fb *functionGraphBuilder) append(statement ir.Statement) {
+	block := fb.ensure()
+	block.Statements = append(block.Statements, statement)
+}
+
+// terminate ends the current block with the provided statement and returns the id of the block.
+func (fb *functionGraphBuilder) terminate(statement ir.Statement) int {
+	block := fb.ensure()
+	block.Terminator = statement
+	fb.current = nil
+	return block.GetId()
+}
+
+// jump adds an edge from the current block to the target and closes the current block.
+// It is a no-op when the current block was already closed by an earlier jump.
+func (fb *functionGraphBuilder) jump(to int, kind EdgeKind) {
+	if fb.current != nil {
+		fb.graph.AddEdge(fb.current.GetId(), to, kind)
+	}
+	fb.current = nil
+}
+
+// pushLoop registers the break and continue targets of a loop being lowered.
+func (fb *functionGraphBuilder) pushLoop(continueTarget int, breakTarget int) {
+	fb.loops = append(fb.loops, loopTargets{continueTarget: continueTarget, breakTarget: breakTarget})
+}
+
+// popLoop removes the innermost loop once its body is lowered.
+func (fb *functionGraphBuilder) popLoop() {
+	fb.loops = fb.loops[:len(fb.loops)-1]
+}
+
+// describeStatement returns a short human readable description of the statement, used by the exporters.
+func describeStatement(statement ir.Statement) string {
+	switch s := statement.(type) {
+	case *ir.FunctionCall:
+		name := s.GetName()
+		if member, ok := s.GetAST().GetExpression().(*ast.MemberAccessExpression); ok && name == "" {
+			name = member.GetMemberName()
+		}
+		return fmt.Sprintf("call %s", name)
+	case *ir.Assignment:
+		names := make([]string, 0, len(s.GetTargets()))
+		for _, target := range s.GetTargets() {
+			names = append(names, target.Name)
+		}
+		return fmt.Sprintf("assign %s", strings.Join(names, ", "))
+	case *ir.VariableDeclaration:
+		names := make([]string, 0, len(s.GetDeclarations()))
+		for _, declaration := range s.GetDeclarations() {
+			names = append(names, declaration.Name)
+		}
+		return fmt.Sprintf("declare %s", strings.Join(names, ", "))
+	case *ir.If:
+		return "if"
+	case *ir.For:
+		return "for"
+	case *ir.While:
+		return "while"
+	case *ir.DoWhile:
+		return "do while"
+	case *ir.Try:
+		return "try"
+	case *ir.Break:
+		return "break"
+	case *ir.Continue:
+		return "continue"
+	case *ir.Return:
+		return "return"
+	case *ir.Revert:
+		return fmt.Sprintf("revert %s", s.GetErrorName())
+	case *ir.Emit:
+		return fmt.Sprintf("emit %s", s.GetEventName())
+	case *ir.InlineAssembly:
+		return "assembly"
+	case *ir.ExpressionStatement:
+		if s.IsPlaceholder() {
+			return "_"
+		}
+		return strings.ToLower(s.GetKind().String())
+	default:
+		return strings.ToLower(statement.GetNodeType().String())
+	}
+}
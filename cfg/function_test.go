@@ -0,0 +1,288 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// findBlocks returns all blocks of the graph carrying the provided label.
+func findBlocks(graph *FunctionGraph, label string) []*BasicBlock {
+	toReturn := make([]*BasicBlock, 0)
+	for _, block := range graph.GetBlocks() {
+		if block.GetLabel() == label {
+			toReturn = append(toReturn, block)
+		}
+	}
+	return toReturn
+}
+
+// hasEdge returns true if an edge of the provided kind connects blocks carrying the provided labels.
+func hasEdge(graph *FunctionGraph, from string, to string, kind EdgeKind) bool {
+	for _, edge := range graph.GetEdges() {
+		if graph.GetBlock(edge.From).GetLabel() == from && graph.GetBlock(edge.To).GetLabel() == to && edge.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFunctionGraph(t *testing.T) {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name: "Treasury",
+				Path: "Treasury.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IReceiver {
+    function receiveFunds(uint256 amount) external returns (bool);
+}
+
+contract Treasury {
+    address public owner;
+    bool private locked;
+    uint256 public total;
+
+    error Empty();
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+    }
+
+    modifier nonReentrant() {
+        locked = true;
+        _;
+        locked = false;
+    }
+
+    function payout(uint256 amount) public onlyOwner nonReentrant returns (uint256) {
+        if (amount == 0) {
+            revert Empty();
+        }
+
+        for (uint256 i = 0; i < 3; i++) {
+            if (i == amount) {
+                break;
+            }
+            continue;
+        }
+
+        uint256 j = amount;
+        while (j > 0) {
+            j--;
+        }
+
+        try IReceiver(msg.sender).receiveFunds(amount) returns (bool ok) {
+            total = total - amount;
+        } catch {
+            return 0;
+        }
+
+        return total;
+    }
+
+    function loop(uint256 amount) public pure returns (uint256) {
+        do {
+            amount--;
+        } while (amount > 10);
+        return amount;
+    }
+
+    function classify(uint256 amount) public returns (uint256) {
+        if (amount > 100) {
+            total = amount;
+        } else {
+            total = 0;
+        }
+
+        if (amount == 1) {
+            return 1;
+        } else if (amount == 2) {
+            return 2;
+        } else {
+            amount = 3;
+        }
+        return amount;
+    }
+}`,
+			},
+		},
+		EntrySourceUnitName: "Treasury",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	irBuilder, err := ir.NewBuilderFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+	assert.Empty(t, irBuilder.Parse())
+	require.NoError(t, irBuilder.Build())
+
+	builder, err := NewBuilder(context.TODO(), irBuilder)
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+
+	payout := builder.GetFunctionGraph("Treasury", "payout")
+	require.NotNil(t, payout)
+	loop := builder.GetFunctionGraph("Treasury", "loop")
+	require.NotNil(t, loop)
+	classify := builder.GetFunctionGraph("Treasury", "classify")
+	require.NotNil(t, classify)
+	assert.Nil(t, builder.GetFunctionGraph("Treasury", "missing"))
+
+	testCases := []struct {
+		name  string
+		graph *FunctionGraph
+		check func(t *testing.T, graph *FunctionGraph)
+	}{
+		{
+			name:  "Modifier Inlining",
+			graph: payout,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "entry", "modifier.onlyOwner", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "modifier.onlyOwner", "revert", EdgeRevert))
+				assert.True(t, hasEdge(graph, "modifier.onlyOwner", "require.pass", EdgeTrue))
+				assert.True(t, hasEdge(graph, "require.pass", "modifier.nonReentrant", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "modifier.nonReentrant.end", "modifier.onlyOwner.resume", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "modifier.onlyOwner.end", "exit", EdgeFallthrough))
+
+				// Returns of the function body resume the innermost modifier after its placeholder.
+				resume := findBlocks(graph, "modifier.nonReentrant.resume")
+				require.Len(t, resume, 1)
+				assert.Equal(t, "nonReentrant", resume[0].GetModifier())
+				require.Len(t, resume[0].GetStatements(), 1)
+				assert.Equal(t, "assign locked", describeStatement(resume[0].GetStatements()[0]))
+				assert.Len(t, graph.GetPredecessors(resume[0].GetId()), 2)
+			},
+		},
+		{
+			name:  "Branches And Revert",
+			graph: payout,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "modifier.nonReentrant", "if.then", EdgeTrue))
+				assert.True(t, hasEdge(graph, "modifier.nonReentrant", "if.end", EdgeFalse))
+				assert.True(t, hasEdge(graph, "if.then", "revert", EdgeRevert))
+			},
+		},
+		{
+			name:  "If Else",
+			graph: classify,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "entry", "if.then", EdgeTrue))
+				assert.True(t, hasEdge(graph, "entry", "if.else", EdgeFalse))
+				assert.False(t, hasEdge(graph, "entry", "if.end", EdgeFalse))
+
+				otherwise := findBlocks(graph, "if.else")
+				require.Len(t, otherwise, 3)
+				require.Len(t, otherwise[0].GetStatements(), 1)
+				assert.Equal(t, "assign total", describeStatement(otherwise[0].GetStatements()[0]))
+
+				join := findBlocks(graph, "if.end")
+				require.Len(t, join, 3)
+				assert.Len(t, graph.GetPredecessors(join[0].GetId()), 2)
+			},
+		},
+		{
+			name:  "Else If",
+			graph: classify,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				otherwise := findBlocks(graph, "if.else")
+				require.Len(t, otherwise, 3)
+
+				// The else branch of the second if statement holds the nested one as its terminator.
+				nested := otherwise[1]
+				require.NotNil(t, nested.Terminator)
+				assert.Equal(t, "if", describeStatement(nested.Terminator))
+				assert.True(t, hasEdge(graph, "if.else", "if.then", EdgeTrue))
+				assert.True(t, hasEdge(graph, "if.else", "if.else", EdgeFalse))
+
+				// Both returns leave the function and the final else branch falls through to the shared return.
+				assert.Len(t, graph.GetPredecessors(graph.Exit), 3)
+				require.Len(t, otherwise[2].GetStatements(), 1)
+				assert.Equal(t, "assign amount", describeStatement(otherwise[2].GetStatements()[0]))
+				assert.True(t, hasEdge(graph, "if.else", "if.end", EdgeFallthrough))
+				assert.True(t, graph.IsReachable(otherwise[2].GetId()))
+			},
+		},
+		{
+			name:  "Loops",
+			graph: payout,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "for.cond", "for.body", EdgeTrue))
+				assert.True(t, hasEdge(graph, "for.cond", "for.end", EdgeFalse))
+				assert.True(t, hasEdge(graph, "for.closure", "for.cond", EdgeLoopBack))
+				assert.True(t, hasEdge(graph, "if.then", "for.end", EdgeBreak))
+				assert.True(t, hasEdge(graph, "if.end", "for.closure", EdgeContinue))
+				assert.True(t, hasEdge(graph, "while.cond", "while.body", EdgeTrue))
+				assert.True(t, hasEdge(graph, "while.cond", "while.end", EdgeFalse))
+				assert.True(t, hasEdge(graph, "while.body", "while.cond", EdgeLoopBack))
+			},
+		},
+		{
+			name:  "Try Catch",
+			graph: payout,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "while.end", "try.success", EdgeTrySuccess))
+				assert.True(t, hasEdge(graph, "while.end", "catch", EdgeTryCatch))
+				assert.True(t, hasEdge(graph, "try.success", "try.end", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "catch", "modifier.nonReentrant.resume", EdgeReturn))
+				assert.True(t, hasEdge(graph, "try.end", "modifier.nonReentrant.resume", EdgeReturn))
+			},
+		},
+		{
+			name:  "Do While",
+			graph: loop,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, hasEdge(graph, "entry", "do.body", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "do.body", "do.cond", EdgeFallthrough))
+				assert.True(t, hasEdge(graph, "do.cond", "do.body", EdgeLoopBack))
+				assert.True(t, hasEdge(graph, "do.cond", "do.end", EdgeFalse))
+				assert.True(t, hasEdge(graph, "do.end", "exit", EdgeReturn))
+				assert.False(t, graph.IsReachable(graph.Revert))
+			},
+		},
+		{
+			name:  "Exporters",
+			graph: payout,
+			check: func(t *testing.T, graph *FunctionGraph) {
+				assert.True(t, graph.IsReachable(graph.Exit))
+				assert.True(t, graph.IsReachable(graph.Revert))
+
+				mermaid, err := builder.ToFunctionMermaid("Treasury", "payout")
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(mermaid, "flowchart TD\n"))
+				assert.Contains(t, mermaid, "-->|try_catch|")
+				assert.Contains(t, mermaid, "call receiveFunds")
+
+				_, err = builder.ToFunctionMermaid("Treasury", "missing")
+				assert.Error(t, err)
+
+				data, err := graph.ToJSON()
+				require.NoError(t, err)
+				var decoded map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &decoded))
+				assert.Equal(t, "payout", decoded["name"])
+				assert.Len(t, decoded["blocks"], len(graph.GetBlocks()))
+
+				contractJson, err := builder.ToJSON("Treasury")
+				require.NoError(t, err)
+				assert.Contains(t, string(contractJson), `"functions"`)
+
+				assert.Contains(t, graph.String(), "Function Treasury.payout:")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.check(t, testCase.graph)
+		})
+	}
+}
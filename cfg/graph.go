@@ -28,6 +28,7 @@ func (g *Graph) AddNode(name string, contract *ir.Contract, isEntryContract bool
 			EntryContract: isEntryContract,
 			Imports:       []*ir.Import{},
 			Inherits:      []*ast.BaseContract{},
+			Functions:     []*FunctionGraph{},
 		}
 	}
 }
@@ -74,6 +75,17 @@ func (g *Graph) AddInheritance(from string, to *ast.BaseContract) {
 	fromNode.Inherits = append(fromNode.Inherits, to)
 }
 
+// AddFunction attaches the control flow graph of a function to the node of the contract declaring it.
+func (g *Graph) AddFunction(from string, function *FunctionGraph) {
+	fromNode, exists := g.Nodes[from]
+	if !exists {
+		fromNode = &Node{Name: from}
+		g.Nodes[from] = fromNode
+	}
+
+	fromNode.Functions = append(fromNode.Functions, function)
+}
+
 // CountNodes returns the total number of nodes in the Graph.
 func (g *Graph) CountNodes() int {
 	return len(g.Nodes)
@@ -0,0 +1,104 @@
+package cfg
+
+import (
+	"fmt"
+)
+
+// Linearize computes the C3 linearization of a contract the way the Solidity compiler does, most derived
+// contract first. The bases function returns the direct base contracts in the order they are listed after
+// the `is` keyword, which Solidity treats from the most base-like to the most derived.
+//
+// An error is returned when the inheritance graph contains a cycle or cannot be linearized consistently.
+func Linearize(contract string, bases func(contract string) []string) ([]string, error) {
+	return linearize(contract, bases, make(map[string][]string), make(map[string]bool))
+}
+
+// linearize is the memoized recursive implementation of Linearize.
+func linearize(contract string, bases func(contract string) []string, cache map[string][]string, visiting map[string]bool) ([]string, error) {
+	if linearization, ok := cache[contract]; ok {
+		return linearization, nil
+	}
+
+	if visiting[contract] {
+		return nil, fmt.Errorf("cyclic inheritance detected at contract %s", contract)
+	}
+	visiting[contract] = true
+	defer delete(visiting, contract)
+
+	direct := bases(contract)
+
+	// Solidity merges the linearizations of the bases from right to left, so the rightmost base is the most derived.
+	sequences := make([][]string, 0, len(direct)+1)
+	reversed := make([]string, 0, len(direct))
+	for i := len(direct) - 1; i >= 0; i-- {
+		linearization, err := linearize(direct[i], bases, cache, visiting)
+		if err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, append([]string{}, linearization...))
+		reversed = append(reversed, direct[i])
+	}
+	sequences = append(sequences, reversed)
+
+	merged, err := mergeLinearizations(sequences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to linearize contract %s: %w", contract, err)
+	}
+
+	toReturn := append([]string{contract}, merged...)
+	cache[contract] = toReturn
+	return toReturn, nil
+}
+
+// mergeLinearizations performs the C3 merge step. It repeatedly picks the first head of a sequence that does not
+// appear in the tail of any other sequence.
+func mergeLinearizations(sequences [][]string) ([]string, error) {
+	toReturn := make([]string, 0)
+
+	for {
+		remaining := make([][]string, 0, len(sequences))
+		for _, sequence := range sequences {
+			if len(sequence) > 0 {
+				remaining = append(remaining, sequence)
+			}
+		}
+		sequences = remaining
+
+		if len(sequences) == 0 {
+			return toReturn, nil
+		}
+
+		var candidate string
+		found := false
+		for _, sequence := range sequences {
+			head := sequence[0]
+			if !inTail(head, sequences) {
+				candidate, found = head, true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("inconsistent inheritance order")
+		}
+
+		toReturn = append(toReturn, candidate)
+		for i, sequence := range sequences {
+			if sequence[0] == candidate {
+				sequences[i] = sequence[1:]
+			}
+		}
+	}
+}
+
+// inTail returns true if the contract appears in the tail of any of the sequences.
+func inTail(contract string, sequences [][]string) bool {
+	for _, sequence := range sequences {
+		for _, name := range sequence[1:] {
+			if name == contract {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -16,6 +16,7 @@ type Node struct {
 	Imports       []*ir.Import        `json:"imports"`
 	Inherits      []*ast.BaseContract `json:"inherits"`
 	EntryContract bool                `json:"entry_contract"`
+	Functions     []*FunctionGraph    `json:"functions"`
 }
 
 // IsEntryContract returns true if the node represents an entry contract in the graph.
@@ -38,6 +39,22 @@ func (n *Node) GetInherits() []*ast.BaseContract {
 	return n.Inherits
 }
 
+// GetFunctions returns the control flow graphs of all functions declared in the contract.
+func (n *Node) GetFunctions() []*FunctionGraph {
+	return n.Functions
+}
+
+// GetFunction returns the control flow graph of the function with the provided name or nil if it does not exist.
+// For overloaded functions the first declared overload is returned.
+func (n *Node) GetFunction(name string) *FunctionGraph {
+	for _, function := range n.Functions {
+		if function.GetName() == name {
+			return function
+		}
+	}
+	return nil
+}
+
 // GetName returns the name of the Solidity contract.
 func (n *Node) GetName() string {
 	return n.Name
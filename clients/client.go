@@ -12,8 +12,9 @@ import (
 // Client wraps the Ethereum client with additional context and options.
 // It provides methods to retrieve client-specific configurations and to close the client connection.
 type Client struct {
-	ctx  context.Context
-	opts *Node
+	ctx    context.Context
+	opts   *Node
+	health health
 	*ethclient.Client
 }
 
@@ -25,15 +26,26 @@ func NewClient(ctx context.Context, opts *Node) (*Client, error) {
 		return nil, errors.New("endpoint URL not set")
 	}
 
-	ethClient, err := ethclient.DialContext(ctx, opts.Endpoint)
+	rpcClient, err := rpc.DialContext(ctx, opts.Endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Ethereum client: %v", err)
 	}
 
+	return NewClientFromRPC(ctx, opts, rpcClient)
+}
+
+// NewClientFromRPC initializes a new Ethereum client on top of an existing RPC client, such as an in-process
+// client of a local simulator. The network ID of the RPC endpoint is checked against the options the same way
+// NewClient does, and the RPC client is closed on failure.
+func NewClientFromRPC(ctx context.Context, opts *Node, rpcClient *rpc.Client) (*Client, error) {
+	ethClient := ethclient.NewClient(rpcClient)
+
 	if networkId, err := ethClient.NetworkID(ctx); err != nil {
+		ethClient.Close()
 		return nil, fmt.Errorf("failed to initialize Ethereum client: %v", err)
 	} else {
 		if networkId.Int64() != opts.GetNetworkID() {
+			ethClient.Close()
 			return nil, fmt.Errorf(
 				"failed to initialize Ethereum client due to network IDs mismatch %d->%d",
 				opts.GetNetworkID(), networkId.Int64(),
@@ -41,11 +53,14 @@ func NewClient(ctx context.Context, opts *Node) (*Client, error) {
 		}
 	}
 
-	return &Client{
+	toReturn := &Client{
 		ctx:    ctx,
 		opts:   opts,
 		Client: ethClient,
-	}, nil
+	}
+	toReturn.health.healthy.Store(true)
+
+	return toReturn, nil
 }
 
 // GetNetworkID retrieves the network ID for the client.
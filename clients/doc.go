@@ -5,6 +5,11 @@
 // on various criteria, such as group and type, in a round-robin fashion. It also provides
 // functionality to close all clients in the pool.
 //
+// Clients are health checked periodically, verifying the chain ID and the freshness of the
+// latest block. Unhealthy clients are evicted until they recover, calls fail over to the
+// configured failover group and type, and read calls can be retried with exponential backoff
+// through Retry and the ClientPool read call wrappers. Pool metrics are exposed through GetMetrics.
+//
 // Additionally, the package provides a Client structure that wraps the Ethereum client
 // with additional context and options. This structure offers methods to retrieve various
 // details about the client, such as its network ID, group, type, and endpoint.
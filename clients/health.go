@@ -0,0 +1,139 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// health tracks the health of a client, as determined by health checks and by the outcome of calls.
+type health struct {
+	healthy             atomic.Bool   // Whether the client is used to serve calls.
+	consecutiveFailures atomic.Int64  // Number of failures since the latest success.
+	requests            atomic.Uint64 // Number of calls and health checks made.
+	failures            atomic.Uint64 // Number of failed calls and health checks.
+	evictions           atomic.Uint64 // Number of times the client was evicted.
+	latestBlock         atomic.Uint64 // Latest block number seen by health checks.
+
+	mu        sync.RWMutex
+	lastError error     // The latest failure.
+	lastCheck time.Time // Time of the latest health check.
+}
+
+// IsHealthy checks if the client is healthy, unhealthy clients are not handed out by the pool
+// until a health check reinstates them.
+func (c *Client) IsHealthy() bool {
+	return c.health.healthy.Load()
+}
+
+// CheckHealth checks that the node serves the expected chain and that its latest block is not older than the
+// maximum head age. The client is evicted once the failure threshold is reached and reinstated on the first
+// successful check or call.
+func (c *Client) CheckHealth(ctx context.Context, opts *HealthCheckOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.GetTimeout())
+	defer cancel()
+
+	c.health.mu.Lock()
+	c.health.lastCheck = time.Now()
+	c.health.mu.Unlock()
+
+	err := c.checkHealth(ctx, opts)
+	if err != nil {
+		c.markFailure(err, opts.GetFailureThreshold())
+		return err
+	}
+
+	c.markSuccess()
+	return nil
+}
+
+// checkHealth runs the health check, returning the reason the client is unhealthy.
+func (c *Client) checkHealth(ctx context.Context, opts *HealthCheckOptions) error {
+	chainId, err := c.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain id: %w", err)
+	}
+
+	if chainId.Int64() != c.GetNetworkID() {
+		return fmt.Errorf("chain id mismatch %d->%d", c.GetNetworkID(), chainId.Int64())
+	}
+
+	header, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	c.health.latestBlock.Store(header.Number.Uint64())
+
+	if age := time.Since(time.Unix(int64(header.Time), 0)); age > opts.GetMaxHeadAge() {
+		return fmt.Errorf("latest block %d is %s old", header.Number.Uint64(), age.Round(time.Second))
+	}
+
+	return nil
+}
+
+// markSuccess records a successful call or health check, resetting the consecutive failures and reinstating
+// the client if it was evicted.
+func (c *Client) markSuccess() {
+	c.health.requests.Add(1)
+	c.health.consecutiveFailures.Store(0)
+
+	if c.health.healthy.CompareAndSwap(false, true) {
+		zap.L().Info(
+			"reinstating healthy ethereum client",
+			zap.String("group", c.GetGroup()),
+			zap.String("type", c.GetType()),
+			zap.String("endpoint", c.GetEndpoint()),
+		)
+	}
+}
+
+// markFailure records a failed call, evicting the client once the consecutive failures reach the threshold.
+// Clients are never evicted if the threshold is not set.
+func (c *Client) markFailure(err error, threshold int) {
+	c.health.requests.Add(1)
+	c.health.failures.Add(1)
+
+	c.health.mu.Lock()
+	c.health.lastError = err
+	c.health.mu.Unlock()
+
+	if c.health.consecutiveFailures.Add(1) >= int64(threshold) && threshold > 0 && c.health.healthy.CompareAndSwap(true, false) {
+		c.health.evictions.Add(1)
+		zap.L().Warn(
+			"evicting unhealthy ethereum client",
+			zap.String("group", c.GetGroup()),
+			zap.String("type", c.GetType()),
+			zap.String("endpoint", c.GetEndpoint()),
+			zap.Error(err),
+		)
+	}
+}
+
+// GetMetrics returns a snapshot of the health and usage of the client.
+func (c *Client) GetMetrics() ClientMetrics {
+	c.health.mu.RLock()
+	defer c.health.mu.RUnlock()
+
+	toReturn := ClientMetrics{
+		Group:               c.GetGroup(),
+		Type:                c.GetType(),
+		Endpoint:            c.GetEndpoint(),
+		Healthy:             c.health.healthy.Load(),
+		Requests:            c.health.requests.Load(),
+		Failures:            c.health.failures.Load(),
+		ConsecutiveFailures: c.health.consecutiveFailures.Load(),
+		Evictions:           c.health.evictions.Load(),
+		LatestBlock:         c.health.latestBlock.Load(),
+		LastCheck:           c.health.lastCheck,
+	}
+
+	if c.health.lastError != nil {
+		toReturn.LastError = c.health.lastError.Error()
+	}
+
+	return toReturn
+}
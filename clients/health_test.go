@@ -0,0 +1,209 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNode serves the JSON-RPC methods used by clients and health checks, with a configurable behaviour.
+type testNode struct {
+	mu       sync.RWMutex
+	chainId  int64         // The chain id returned by eth_chainId.
+	headAge  time.Duration // The age of the latest block.
+	failures atomic.Int32  // Number of upcoming block requests answered with an internal server error.
+	rpcError string        // If set, block requests are answered with the JSON-RPC error.
+	requests atomic.Int32  // Number of block requests received.
+}
+
+// setChainId sets the chain id returned by eth_chainId.
+func (n *testNode) setChainId(chainId int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.chainId = chainId
+}
+
+// setHeadAge sets the age of the latest block.
+func (n *testNode) setHeadAge(age time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.headAge = age
+}
+
+// ServeHTTP implements the JSON-RPC methods used by clients and health checks.
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	response := map[string]any{"jsonrpc": "2.0", "id": request.ID}
+	switch request.Method {
+	case "net_version":
+		response["result"] = "1"
+	case "eth_chainId":
+		response["result"] = (*hexBig)(big.NewInt(n.chainId))
+	case "eth_getBlockByNumber":
+		n.requests.Add(1)
+		if n.failures.Load() > 0 {
+			n.failures.Add(-1)
+			http.Error(w, "node unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		if n.rpcError != "" {
+			response["error"] = map[string]any{"code": -32000, "message": n.rpcError}
+			break
+		}
+
+		header := &types.Header{
+			Number:     big.NewInt(100),
+			Difficulty: big.NewInt(1),
+			Time:       uint64(time.Now().Add(-n.headAge).Unix()),
+		}
+		response["result"] = header
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// hexBig encodes big integers as JSON-RPC quantities.
+type hexBig big.Int
+
+// MarshalJSON encodes the integer as a hex quantity.
+func (h *hexBig) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + (*big.Int)(h).Text(16))
+}
+
+func TestClientPoolHealth(t *testing.T) {
+	fullnode := &testNode{chainId: 1}
+	fullnodeServer := httptest.NewServer(fullnode)
+	defer fullnodeServer.Close()
+
+	archive := &testNode{chainId: 1}
+	archiveServer := httptest.NewServer(archive)
+	defer archiveServer.Close()
+
+	pool, err := NewClientPool(context.Background(), &Options{
+		Nodes: []Node{
+			{
+				Group:             "ethereum",
+				Type:              "fullnode",
+				FailoverGroup:     "ethereum",
+				FailoverType:      "archive",
+				NetworkId:         1,
+				Endpoint:          fullnodeServer.URL,
+				ConcurrentClients: 2,
+			},
+			{
+				Group:             "ethereum",
+				Type:              "archive",
+				NetworkId:         1,
+				Endpoint:          archiveServer.URL,
+				ConcurrentClients: 1,
+			},
+		},
+		HealthCheck: HealthCheckOptions{
+			MaxHeadAge:       time.Minute,
+			FailureThreshold: 1,
+		},
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Clients are returned as a copy of the pool state.
+	clients := pool.GetClients()
+	require.Len(t, clients["ethereum_fullnode"], 2)
+	clients["ethereum_fullnode"] = nil
+	assert.Len(t, pool.GetClients()["ethereum_fullnode"], 2)
+
+	tests := []struct {
+		name          string
+		setup         func()
+		wantType      string
+		wantHealthy   int
+		wantFailovers uint64
+	}{
+		{
+			name:        "Healthy Nodes",
+			setup:       func() {},
+			wantType:    "fullnode",
+			wantHealthy: 3,
+		},
+		{
+			name:          "Stale Head Fails Over",
+			setup:         func() { fullnode.setHeadAge(time.Hour) },
+			wantType:      "archive",
+			wantHealthy:   1,
+			wantFailovers: 1,
+		},
+		{
+			name: "Chain Id Mismatch Falls Back To Evicted Nodes",
+			setup: func() {
+				archive.setChainId(56)
+			},
+			wantType:      "fullnode",
+			wantHealthy:   0,
+			wantFailovers: 1,
+		},
+		{
+			name: "Recovered Nodes Reinstated",
+			setup: func() {
+				fullnode.setHeadAge(0)
+				archive.setChainId(1)
+			},
+			wantType:      "fullnode",
+			wantHealthy:   3,
+			wantFailovers: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			pool.CheckHealth(context.Background())
+
+			client := pool.GetClient("ethereum", "fullnode")
+			require.NotNil(t, client)
+			assert.Equal(t, tt.wantType, client.GetType())
+
+			metrics := pool.GetMetrics()
+			assert.Len(t, metrics.Clients, 3)
+			assert.Equal(t, tt.wantHealthy, metrics.Healthy)
+			assert.Equal(t, 3-tt.wantHealthy, metrics.Unhealthy)
+			assert.Equal(t, tt.wantFailovers, metrics.Failovers)
+		})
+	}
+
+	// Round-robin advances across the clients of the group and type as well as of the group.
+	first, second := pool.GetClient("ethereum", "fullnode"), pool.GetClient("ethereum", "fullnode")
+	assert.NotSame(t, first, second)
+	assert.Same(t, first, pool.GetClient("ethereum", "fullnode"))
+
+	seen := make(map[*Client]bool)
+	for i := 0; i < 3; i++ {
+		seen[pool.GetClientByGroup("ethereum")] = true
+	}
+	assert.Len(t, seen, 3)
+
+	assert.Nil(t, pool.GetClient("ethereum", "light"))
+	assert.Nil(t, pool.GetClientByGroup("bsc"))
+}
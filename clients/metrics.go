@@ -0,0 +1,27 @@
+package clients
+
+import "time"
+
+// ClientMetrics represents a snapshot of the health and usage of a client.
+type ClientMetrics struct {
+	Group               string    `json:"group"`                // The group of the client.
+	Type                string    `json:"type"`                 // The type of the client.
+	Endpoint            string    `json:"endpoint"`             // The endpoint of the client.
+	Healthy             bool      `json:"healthy"`              // Whether the client is handed out by the pool.
+	Requests            uint64    `json:"requests"`             // Number of tracked calls and health checks.
+	Failures            uint64    `json:"failures"`             // Number of failed calls and health checks.
+	ConsecutiveFailures int64     `json:"consecutive_failures"` // Number of failures since the latest success.
+	Evictions           uint64    `json:"evictions"`            // Number of times the client was evicted.
+	LatestBlock         uint64    `json:"latest_block"`         // Latest block number seen by health checks.
+	LastCheck           time.Time `json:"last_check"`           // Time of the latest health check.
+	LastError           string    `json:"last_error"`           // The latest failure.
+}
+
+// PoolMetrics represents a snapshot of the health and usage of the client pool.
+type PoolMetrics struct {
+	Clients   []ClientMetrics `json:"clients"`   // Metrics of every client within the pool.
+	Healthy   int             `json:"healthy"`   // Number of healthy clients.
+	Unhealthy int             `json:"unhealthy"` // Number of evicted clients.
+	Failovers uint64          `json:"failovers"` // Number of clients handed out from failover groups and types.
+	Retries   uint64          `json:"retries"`   // Number of retried calls.
+}
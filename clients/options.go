@@ -1,9 +1,37 @@
 package clients
 
+import "time"
+
+const (
+	// DefaultHealthCheckTimeout is the time a single node health check is given when no timeout is set.
+	DefaultHealthCheckTimeout = 10 * time.Second
+
+	// DefaultMaxHeadAge is the age of the latest block above which a node is considered out of sync when no age is set.
+	DefaultMaxHeadAge = 5 * time.Minute
+
+	// DefaultFailureThreshold is the number of consecutive failures after which a node is evicted when no threshold is set.
+	DefaultFailureThreshold = 3
+
+	// DefaultRetryAttempts is the number of attempts of read calls when no number of attempts is set.
+	DefaultRetryAttempts = 3
+
+	// DefaultRetryBackoff is the wait before the first retry of read calls when no backoff is set.
+	DefaultRetryBackoff = 100 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the longest wait between retries of read calls when no maximum backoff is set.
+	DefaultRetryMaxBackoff = 5 * time.Second
+)
+
 // Options represents the configuration options for network nodes.
 type Options struct {
 	// Nodes is a slice of Node representing the network nodes.
 	Nodes []Node `mapstructure:"nodes" yaml:"nodes" json:"nodes"`
+
+	// HealthCheck represents the configuration of the periodic node health checks.
+	HealthCheck HealthCheckOptions `mapstructure:"healthCheck" yaml:"healthCheck" json:"healthCheck"`
+
+	// Retry represents the configuration of retries of read calls.
+	Retry RetryOptions `mapstructure:"retry" yaml:"retry" json:"retry"`
 }
 
 // GetNodes returns the slice of network nodes from the Options.
@@ -11,6 +39,88 @@ func (o *Options) GetNodes() []Node {
 	return o.Nodes
 }
 
+// HealthCheckOptions represents the configuration of the periodic node health checks.
+type HealthCheckOptions struct {
+	// Interval represents the time between health checks, health checks are disabled if it is not set.
+	Interval time.Duration `mapstructure:"interval" yaml:"interval" json:"interval"`
+
+	// Timeout represents the time a single node health check is given.
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+
+	// MaxHeadAge represents the age of the latest block above which a node is considered out of sync.
+	MaxHeadAge time.Duration `mapstructure:"maxHeadAge" yaml:"maxHeadAge" json:"maxHeadAge"`
+
+	// FailureThreshold represents the number of consecutive failures, of health checks or calls, after which a node is evicted.
+	// Failed calls only evict nodes when health checks are enabled, as nothing else would reinstate them.
+	FailureThreshold int `mapstructure:"failureThreshold" yaml:"failureThreshold" json:"failureThreshold"`
+}
+
+// IsEnabled checks if the periodic health checks are enabled.
+func (o *HealthCheckOptions) IsEnabled() bool {
+	return o.Interval > 0
+}
+
+// GetTimeout returns the time a single node health check is given.
+func (o *HealthCheckOptions) GetTimeout() time.Duration {
+	if o.Timeout <= 0 {
+		return DefaultHealthCheckTimeout
+	}
+	return o.Timeout
+}
+
+// GetMaxHeadAge returns the age of the latest block above which a node is considered out of sync.
+func (o *HealthCheckOptions) GetMaxHeadAge() time.Duration {
+	if o.MaxHeadAge <= 0 {
+		return DefaultMaxHeadAge
+	}
+	return o.MaxHeadAge
+}
+
+// GetFailureThreshold returns the number of consecutive failures after which a node is evicted.
+func (o *HealthCheckOptions) GetFailureThreshold() int {
+	if o.FailureThreshold <= 0 {
+		return DefaultFailureThreshold
+	}
+	return o.FailureThreshold
+}
+
+// RetryOptions represents the configuration of retries of read calls.
+type RetryOptions struct {
+	// MaxAttempts represents the number of attempts, including the first one.
+	MaxAttempts int `mapstructure:"maxAttempts" yaml:"maxAttempts" json:"maxAttempts"`
+
+	// Backoff represents the wait before the first retry, doubled for every subsequent retry.
+	Backoff time.Duration `mapstructure:"backoff" yaml:"backoff" json:"backoff"`
+
+	// MaxBackoff represents the longest wait between retries.
+	MaxBackoff time.Duration `mapstructure:"maxBackoff" yaml:"maxBackoff" json:"maxBackoff"`
+}
+
+// GetMaxAttempts returns the number of attempts, including the first one.
+func (o *RetryOptions) GetMaxAttempts() int {
+	if o.MaxAttempts <= 0 {
+		return DefaultRetryAttempts
+	}
+	return o.MaxAttempts
+}
+
+// GetBackoff returns the wait before the retry, given the number of the failed attempt, starting from 1.
+func (o *RetryOptions) GetBackoff(attempt int) time.Duration {
+	backoff, maxBackoff := o.Backoff, o.MaxBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
 // Node represents the configuration and details of a network node.
 type Node struct {
 	// Group represents the group name of the node.
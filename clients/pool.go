@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"math/big"
-	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -14,30 +14,104 @@ import (
 
 // ClientPool manages a pool of Ethereum clients for different networks and types.
 // It provides methods to retrieve clients based on various criteria and to close all clients in the pool.
+// Clients failing health checks, or calls while health checks are enabled, are evicted until a successful health
+// check or call reinstates them, and when every client of a group and type is evicted, clients of the configured
+// failover group and type are handed out instead.
 type ClientPool struct {
-	ctx           context.Context
-	opts          *Options
-	clients       map[string][]*Client
-	next          uint32
-	groupTypeNext map[string]uint32
+	ctx       context.Context
+	cancel    context.CancelFunc
+	opts      *Options
+	mu        sync.RWMutex
+	clients   map[string][]*Client
+	groups    map[string][]*Client
+	counters  map[string]*atomic.Uint32
+	failovers atomic.Uint64
+	retries   atomic.Uint64
 }
 
 // Len returns the number of clients in the pool.
 func (c *ClientPool) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return len(c.clients)
 }
 
-// GetClients returns all clients in the pool.
+// GetClients returns all clients in the pool. The returned map is a copy, so it is safe to use while
+// clients are added to the pool.
 func (c *ClientPool) GetClients() map[string][]*Client {
-	return c.clients
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	toReturn := make(map[string][]*Client, len(c.clients))
+	for key, clients := range c.clients {
+		toReturn[key] = append([]*Client(nil), clients...)
+	}
+	return toReturn
 }
 
-// GetClient retrieves a client based on the group and type in a round-robin fashion.
+// GetClient retrieves a healthy client based on the group and type in a round-robin fashion.
+// If every client of the group and type is evicted, a healthy client of the failover group and type is returned,
+// following failover groups and types of the failover clients as well. If no healthy client is found, one of the
+// evicted clients of the group and type is returned, and nil is returned only if the pool has no such clients.
 func (c *ClientPool) GetClient(group, typ string) *Client {
 	key := group + "_" + typ
-	current := c.groupTypeNext[key]
-	n := atomic.AddUint32(&current, 1)
-	return c.clients[key][(int(n)-1)%len(c.clients[key])]
+
+	c.mu.RLock()
+	candidates := c.clients[key]
+	c.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if client := c.next(key, candidates, true); client != nil {
+		return client
+	}
+
+	visited := map[string]bool{key: true}
+	for failover := candidates[0]; ; {
+		failoverGroup, failoverType := failover.GetFailoverGroup(), failover.GetFailoverType()
+		if failoverGroup == "" && failoverType == "" {
+			break
+		}
+
+		if failoverGroup == "" {
+			failoverGroup = failover.GetGroup()
+		}
+		if failoverType == "" {
+			failoverType = failover.GetType()
+		}
+
+		failoverKey := failoverGroup + "_" + failoverType
+		if visited[failoverKey] {
+			break
+		}
+		visited[failoverKey] = true
+
+		c.mu.RLock()
+		failoverCandidates := c.clients[failoverKey]
+		c.mu.RUnlock()
+
+		if len(failoverCandidates) == 0 {
+			break
+		}
+
+		if client := c.next(failoverKey, failoverCandidates, true); client != nil {
+			c.failovers.Add(1)
+			zap.L().Debug(
+				"failing over to ethereum client",
+				zap.String("group", group),
+				zap.String("type", typ),
+				zap.String("failover_group", failoverGroup),
+				zap.String("failover_type", failoverType),
+			)
+			return client
+		}
+
+		failover = failoverCandidates[0]
+	}
+
+	return c.next(key, candidates, false)
 }
 
 // GetClientByGroupAndType retrieves a client based on the group and type in a round-robin fashion.
@@ -46,32 +120,52 @@ func (c *ClientPool) GetClientByGroupAndType(group, typ string) *Client {
 	return c.GetClient(group, typ)
 }
 
-// GetClientByGroup retrieves a client based on the group in a round-robin fashion.
-// It aggregates all clients within the specified group and returns one of them.
+// GetClientByGroup retrieves a healthy client based on the group in a round-robin fashion, across all clients
+// within the group. If every client of the group is evicted, one of them is returned nevertheless.
 func (c *ClientPool) GetClientByGroup(group string) *Client {
-	var allClientsInGroup []*Client
-
-	// Aggregate all clients within the specified group
-	for key, clients := range c.clients {
-		if strings.HasPrefix(key, group+"_") {
-			allClientsInGroup = append(allClientsInGroup, clients...)
-		}
-	}
+	c.mu.RLock()
+	candidates := c.groups[group]
+	c.mu.RUnlock()
 
 	// If no clients are found for the group, return nil
-	if len(allClientsInGroup) == 0 {
+	if len(candidates) == 0 {
 		return nil
 	}
 
-	// Use atomic operation to get the next client index for the group in a round-robin fashion
-	current := c.groupTypeNext[group]
-	n := atomic.AddUint32(&current, 1)
+	// Group counters are kept apart from the group and type counters.
+	key := "group::" + group
+	if client := c.next(key, candidates, true); client != nil {
+		return client
+	}
+
+	return c.next(key, candidates, false)
+}
+
+// next returns the next client of the candidates in a round-robin fashion, skipping evicted clients if requested.
+// It returns nil if every candidate is skipped.
+func (c *ClientPool) next(key string, candidates []*Client, healthyOnly bool) *Client {
+	c.mu.RLock()
+	counter, ok := c.counters[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		c.mu.Lock()
+		if counter, ok = c.counters[key]; !ok {
+			counter = new(atomic.Uint32)
+			c.counters[key] = counter
+		}
+		c.mu.Unlock()
+	}
 
-	// Calculate the client index based on the current value of n
-	clientIndex := (int(n) - 1) % len(allClientsInGroup)
+	n := int(counter.Add(1) - 1)
+	for i := 0; i < len(candidates); i++ {
+		client := candidates[(n+i)%len(candidates)]
+		if !healthyOnly || client.IsHealthy() {
+			return client
+		}
+	}
 
-	// Return the client at the calculated index
-	return allClientsInGroup[clientIndex]
+	return nil
 }
 
 // GetClientDescriptionByNetworkId retrieves the group and type of a client based on the network ID.
@@ -85,8 +179,68 @@ func (c *ClientPool) GetClientDescriptionByNetworkId(networkId *big.Int) (string
 	return "", ""
 }
 
-// Close gracefully closes all the clients in the pool.
+// CheckHealth runs the health checks of every client within the pool concurrently, evicting unhealthy clients
+// and reinstating the ones that recovered.
+func (c *ClientPool) CheckHealth(ctx context.Context) {
+	c.mu.RLock()
+	clients := make([]*Client, 0)
+	for _, keyClients := range c.clients {
+		clients = append(clients, keyClients...)
+	}
+	c.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client *Client) {
+			defer wg.Done()
+			if err := client.CheckHealth(ctx, &c.opts.HealthCheck); err != nil {
+				zap.L().Debug(
+					"ethereum client health check failed",
+					zap.String("group", client.GetGroup()),
+					zap.String("type", client.GetType()),
+					zap.String("endpoint", client.GetEndpoint()),
+					zap.Error(err),
+				)
+			}
+		}(client)
+	}
+	wg.Wait()
+}
+
+// GetMetrics returns a snapshot of the health and usage of the clients within the pool.
+func (c *ClientPool) GetMetrics() *PoolMetrics {
+	toReturn := &PoolMetrics{
+		Clients:   make([]ClientMetrics, 0),
+		Failovers: c.failovers.Load(),
+		Retries:   c.retries.Load(),
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, clients := range c.clients {
+		for _, client := range clients {
+			metrics := client.GetMetrics()
+			if metrics.Healthy {
+				toReturn.Healthy++
+			} else {
+				toReturn.Unhealthy++
+			}
+			toReturn.Clients = append(toReturn.Clients, metrics)
+		}
+	}
+
+	return toReturn
+}
+
+// Close gracefully closes all the clients in the pool and stops the health checks.
 func (c *ClientPool) Close() {
+	c.cancel()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	for _, clients := range c.clients {
 		for _, client := range clients {
 			client.Close()
@@ -104,9 +258,7 @@ func (c *ClientPool) RegisterClient(ctx context.Context, networkId uint64, group
 		return errors.New("concurrentClientsNumber must be greater than 0")
 	}
 
-	mutex := sync.Mutex{}
 	g, ctx := errgroup.WithContext(ctx)
-	key := group + "_" + typ
 
 	for i := 0; i < concurrentClientsNumber; i++ {
 		g.Go(func() error {
@@ -118,9 +270,7 @@ func (c *ClientPool) RegisterClient(ctx context.Context, networkId uint64, group
 
 			// Additional checks or configurations for the client can be added here
 
-			mutex.Lock()
-			c.clients[key] = append(c.clients[key], client)
-			mutex.Unlock()
+			c.AddClient(client)
 			return nil
 		})
 	}
@@ -132,35 +282,72 @@ func (c *ClientPool) RegisterClient(ctx context.Context, networkId uint64, group
 	return nil
 }
 
+// AddClient adds the client to the pool, indexing it by its group and type as well as by its group.
+// It allows clients created outside of the pool, such as simulator clients, to be served by it.
+func (c *ClientPool) AddClient(client *Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := client.GetGroup() + "_" + client.GetType()
+	c.clients[key] = append(c.clients[key], client)
+	c.groups[client.GetGroup()] = append(c.groups[client.GetGroup()], client)
+}
+
+// healthCheck runs the health checks of the pool at the configured interval, until the pool is closed.
+func (c *ClientPool) healthCheck() {
+	ticker := time.NewTicker(c.opts.HealthCheck.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.CheckHealth(c.ctx)
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
 // NewClientPool initializes a new ClientPool with the given options.
 // It returns an error if the options are not set, if there are no nodes specified in the options,
 // or if there's an issue with any of the nodes' configurations.
+// If a health check interval is set, clients are health checked periodically until the pool is closed.
 func NewClientPool(ctx context.Context, opts *Options) (*ClientPool, error) {
-	clients := make(map[string][]*Client)
-	mutex := sync.Mutex{}
-	g, ctx := errgroup.WithContext(ctx)
-
 	if opts == nil {
 		return nil, ErrOptionsNotSet
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	pool := &ClientPool{
+		ctx:      ctx,
+		cancel:   cancel,
+		opts:     opts,
+		clients:  make(map[string][]*Client),
+		groups:   make(map[string][]*Client),
+		counters: make(map[string]*atomic.Uint32),
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
 	/* 	if len(opts.GetNodes()) == 0 {
 		return nil, ErrNodesNotSet
 	} */
 
 	for _, node := range opts.GetNodes() {
 		if node.GetEndpoint() == "" {
+			cancel()
 			return nil, ErrClientURLNotSet
 		}
 
 		if node.GetConcurrentClientsNumber() == 0 {
+			cancel()
 			return nil, ErrConcurrentClientsNotSet
 		}
 
 		for i := 0; i < node.GetConcurrentClientsNumber(); i++ {
 			node := node // Shadow variable for goroutine
 			g.Go(func() error {
-				client, err := NewClient(ctx, &node)
+				client, err := NewClient(gctx, &node)
 				if err != nil {
 					return err
 				}
@@ -171,10 +358,7 @@ func NewClientPool(ctx context.Context, opts *Options) (*ClientPool, error) {
 					return errors.New("network ID mismatch")
 				}
 
-				key := node.GetGroup() + "_" + node.GetType()
-				mutex.Lock()
-				clients[key] = append(clients[key], client)
-				mutex.Unlock()
+				pool.AddClient(client)
 				return nil
 			})
 		}
@@ -182,14 +366,13 @@ func NewClientPool(ctx context.Context, opts *Options) (*ClientPool, error) {
 
 	if err := g.Wait(); err != nil {
 		zap.L().Error("failed to initialize ethereum client", zap.Error(err))
+		pool.Close()
 		return nil, err
 	}
 
-	return &ClientPool{
-		ctx:           ctx,
-		opts:          opts,
-		clients:       clients,
-		next:          0,
-		groupTypeNext: make(map[string]uint32),
-	}, nil
+	if opts.HealthCheck.Interval > 0 {
+		go pool.healthCheck()
+	}
+
+	return pool, nil
 }
@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rateLimitErrorCode is the JSON-RPC error code returned by providers when the request limit is exceeded.
+const rateLimitErrorCode = -32005
+
+// Retry calls the function with a client of the group and type, retrying failed calls with exponential backoff
+// as configured by the pool retry options. Every attempt asks the pool for a client, so retries are spread across
+// the clients of the group and type, failing over once they are all evicted. Failures count towards the eviction
+// of the client when health checks are enabled, and successful calls reinstate evicted clients. Errors which would
+// not go away by retrying, such as JSON-RPC errors returned by the node, not found errors and context errors, are
+// returned right away.
+func Retry[T any](ctx context.Context, pool *ClientPool, group, typ string, fn func(client *Client) (T, error)) (T, error) {
+	var toReturn T
+	var err error
+
+	for attempt := 1; attempt <= pool.opts.Retry.GetMaxAttempts(); attempt++ {
+		if attempt > 1 {
+			pool.retries.Add(1)
+
+			select {
+			case <-time.After(pool.opts.Retry.GetBackoff(attempt - 1)):
+			case <-ctx.Done():
+				return toReturn, errors.Join(err, ctx.Err())
+			}
+		}
+
+		client := pool.GetClient(group, typ)
+		if client == nil {
+			return toReturn, fmt.Errorf("client not found for group %s and type %s", group, typ)
+		}
+
+		toReturn, err = fn(client)
+		if err == nil {
+			client.markSuccess()
+			return toReturn, nil
+		}
+
+		if !isRetryable(ctx, err) {
+			return toReturn, err
+		}
+
+		// Evicted clients are only handed out again once every other client is evicted as well, so without health
+		// checks reinstating them, failed calls are recorded without evicting the client.
+		threshold := 0
+		if pool.opts.HealthCheck.IsEnabled() {
+			threshold = pool.opts.HealthCheck.GetFailureThreshold()
+		}
+		client.markFailure(err, threshold)
+	}
+
+	return toReturn, fmt.Errorf("failed after %d attempts: %w", pool.opts.Retry.GetMaxAttempts(), err)
+}
+
+// isRetryable checks if the error might go away by retrying the call, possibly with another client.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == rateLimitErrorCode
+	}
+
+	return true
+}
+
+// Do calls the function with a client of the group and type, retrying failed calls, see Retry.
+func (c *ClientPool) Do(ctx context.Context, group, typ string, fn func(client *Client) error) error {
+	_, err := Retry(ctx, c, group, typ, func(client *Client) (struct{}, error) {
+		return struct{}{}, fn(client)
+	})
+	return err
+}
+
+// HeaderByNumber returns the block header of the number, or the latest one if the number is nil, retrying failed calls.
+func (c *ClientPool) HeaderByNumber(ctx context.Context, group, typ string, number *big.Int) (*types.Header, error) {
+	return Retry(ctx, c, group, typ, func(client *Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+// BlockByNumber returns the block of the number, or the latest one if the number is nil, retrying failed calls.
+func (c *ClientPool) BlockByNumber(ctx context.Context, group, typ string, number *big.Int) (*types.Block, error) {
+	return Retry(ctx, c, group, typ, func(client *Client) (*types.Block, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+}
+
+// TransactionReceipt returns the receipt of the transaction, retrying failed calls.
+func (c *ClientPool) TransactionReceipt(ctx context.Context, group, typ string, txHash common.Hash) (*types.Receipt, error) {
+	return Retry(ctx, c, group, typ, func(client *Client) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// CodeAt returns the code of the account at the block number, or the latest block if the number is nil,
+// retrying failed calls.
+func (c *ClientPool) CodeAt(ctx context.Context, group, typ string, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return Retry(ctx, c, group, typ, func(client *Client) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// CallContract executes the message call at the block number, or the latest block if the number is nil,
+// retrying failed calls. Reverted calls are not retried.
+func (c *ClientPool) CallContract(ctx context.Context, group, typ string, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return Retry(ctx, c, group, typ, func(client *Client) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
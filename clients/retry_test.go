@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPoolRetry(t *testing.T) {
+	node := &testNode{chainId: 1}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		failures     int32
+		rpcError     string
+		maxAttempts  int
+		interval     time.Duration
+		wantErr      bool
+		wantRequests int32
+		wantRetries  uint64
+		wantHealthy  bool
+	}{
+		{
+			name:         "Successful Call",
+			wantRequests: 1,
+			wantHealthy:  true,
+		},
+		{
+			name:         "Recovered After Retries",
+			failures:     2,
+			maxAttempts:  3,
+			wantRequests: 3,
+			wantRetries:  2,
+			wantHealthy:  true,
+		},
+		{
+			name:         "Attempts Exhausted Evicts Client",
+			failures:     5,
+			maxAttempts:  3,
+			interval:     time.Hour,
+			wantErr:      true,
+			wantRequests: 3,
+			wantRetries:  2,
+			wantHealthy:  false,
+		},
+		{
+			name:         "Attempts Exhausted Without Health Checks Keeps Client",
+			failures:     5,
+			maxAttempts:  3,
+			wantErr:      true,
+			wantRequests: 3,
+			wantRetries:  2,
+			wantHealthy:  true,
+		},
+		{
+			name:         "JSON-RPC Error Not Retried",
+			rpcError:     "execution reverted",
+			maxAttempts:  3,
+			wantErr:      true,
+			wantRequests: 1,
+			wantHealthy:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node.failures.Store(tt.failures)
+			node.rpcError = tt.rpcError
+			node.requests.Store(0)
+
+			pool, err := NewClientPool(context.Background(), &Options{
+				Nodes: []Node{
+					{
+						Group:             "ethereum",
+						Type:              "archive",
+						NetworkId:         1,
+						Endpoint:          server.URL,
+						ConcurrentClients: 1,
+					},
+				},
+				HealthCheck: HealthCheckOptions{Interval: tt.interval, FailureThreshold: 3},
+				Retry:       RetryOptions{MaxAttempts: tt.maxAttempts, Backoff: time.Millisecond},
+			})
+			require.NoError(t, err)
+			defer pool.Close()
+
+			header, err := pool.HeaderByNumber(context.Background(), "ethereum", "archive", nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uint64(100), header.Number.Uint64())
+			}
+
+			assert.Equal(t, tt.wantRequests, node.requests.Load())
+			assert.Equal(t, tt.wantRetries, pool.GetMetrics().Retries)
+			assert.Equal(t, tt.wantHealthy, pool.GetClient("ethereum", "archive").IsHealthy())
+		})
+	}
+
+	_, err := Retry(context.Background(), &ClientPool{opts: &Options{}, clients: map[string][]*Client{}}, "ethereum", "archive", func(client *Client) (int, error) {
+		return 0, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestClientPoolRetryReinstatesClient(t *testing.T) {
+	node := &testNode{chainId: 1}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	pool, err := NewClientPool(context.Background(), &Options{
+		Nodes: []Node{
+			{
+				Group:             "ethereum",
+				Type:              "archive",
+				NetworkId:         1,
+				Endpoint:          server.URL,
+				ConcurrentClients: 1,
+			},
+		},
+		HealthCheck: HealthCheckOptions{Interval: time.Hour, FailureThreshold: 2},
+		Retry:       RetryOptions{MaxAttempts: 2, Backoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	node.failures.Store(2)
+	_, err = pool.HeaderByNumber(context.Background(), "ethereum", "archive", nil)
+	require.Error(t, err)
+
+	client := pool.GetClient("ethereum", "archive")
+	require.NotNil(t, client)
+	assert.False(t, client.IsHealthy())
+
+	// The evicted client is still handed out as no other client is available, and the successful call reinstates
+	// it before the next health check.
+	header, err := pool.HeaderByNumber(context.Background(), "ethereum", "archive", nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), header.Number.Uint64())
+	assert.True(t, client.IsHealthy())
+	assert.Equal(t, uint64(1), client.GetMetrics().Evictions)
+	assert.Equal(t, int64(0), client.GetMetrics().ConsecutiveFailures)
+}
+
+func TestRetryOptionsBackoff(t *testing.T) {
+	opts := &RetryOptions{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, opts.GetBackoff(1))
+	assert.Equal(t, 200*time.Millisecond, opts.GetBackoff(2))
+	assert.Equal(t, 800*time.Millisecond, opts.GetBackoff(4))
+	assert.Equal(t, time.Second, opts.GetBackoff(10))
+	assert.Equal(t, DefaultRetryAttempts, opts.GetMaxAttempts())
+}
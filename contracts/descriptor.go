@@ -36,7 +36,8 @@ type Descriptor struct {
 	EVMVersion        string             `json:"evm_version,omitempty"`
 
 	// Identity related fields
-	Owner common.Address `json:"owner,omitempty"`
+	Owner      common.Address `json:"owner,omitempty"`
+	Privileges *Privileges    `json:"privileges,omitempty"`
 
 	// SourcesRaw is the raw sources from Etherscan|BscScan|etc. Should not be used anywhere except in
 	// the contract discovery process.
@@ -60,6 +61,10 @@ type Descriptor struct {
 	// Proxy
 	Proxy           bool             `json:"proxy"`
 	Implementations []common.Address `json:"implementations"`
+	ProxyDescriptor *ProxyDescriptor `json:"proxy_descriptor,omitempty"`
+
+	// Descriptors of the proxy implementations, with their sources and parsed details.
+	ImplementationDescriptors []*Descriptor `json:"implementation_descriptors,omitempty"`
 
 	// Token related fields.
 	Token *tokens.Descriptor `json:"token,omitempty"`
@@ -75,6 +80,21 @@ func (d *Descriptor) HasConstructor() bool {
 	return d.Constructor != nil
 }
 
+// IsProxy checks if the contract was detected as a proxy.
+func (d *Descriptor) IsProxy() bool {
+	return d.Proxy
+}
+
+// GetProxyDescriptor returns the proxy details of the contract, nil if it is not a proxy.
+func (d *Descriptor) GetProxyDescriptor() *ProxyDescriptor {
+	return d.ProxyDescriptor
+}
+
+// GetImplementationDescriptors returns the descriptors of the proxy implementations.
+func (d *Descriptor) GetImplementationDescriptors() []*Descriptor {
+	return d.ImplementationDescriptors
+}
+
 // HasSources checks if source code details are available for the contract.
 func (d *Descriptor) HasSources() bool {
 	return d.Sources != nil && d.Sources.HasUnits()
@@ -265,6 +285,11 @@ func (d *Descriptor) HasAuditReport() bool {
 	return d.Audit != nil
 }
 
+// GetPrivileges returns the privileges within the contract, who holds them and which functions they can call.
+func (d *Descriptor) GetPrivileges() *Privileges {
+	return d.Privileges
+}
+
 // GetOwner returns the address recognized as the owner of the contract, who has administrative privileges.
 func (d *Descriptor) GetOwner() common.Address {
 	return d.Owner
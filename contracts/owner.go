@@ -37,31 +37,37 @@ func (c *Contract) DiscoverOwner(ctx context.Context) error {
 	return nil
 }
 
+// renouncedOwners are the addresses ownership is transferred to when renouncing it, as nobody holds their keys.
+var renouncedOwners = []common.Address{
+	common.HexToAddress("0x0000000000000000000000000000000000000000"),
+	common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+	common.HexToAddress("0x0000000000000000000000000000000000000001"),
+	common.HexToAddress("0x0000000000000000000000000000000000000002"),
+	common.HexToAddress("0x0000000000000000000000000000000000000003"),
+	common.HexToAddress("0x0000000000000000000000000000000000000004"),
+	common.HexToAddress("0x0000000000000000000000000000000000000005"),
+	common.HexToAddress("0x0000000000000000000000000000000000000006"),
+	common.HexToAddress("0x0000000000000000000000000000000000000007"),
+	common.HexToAddress("0x0000000000000000000000000000000000000008"),
+	common.HexToAddress("0x0000000000000000000000000000000000000009"),
+}
+
 // IsRenounced checks if the contract's ownership has been renounced by comparing the owner's address
 // against a predefined list of "zero" addresses, which represent renounced ownership in various contexts.
 // This method helps in identifying contracts that are deliberately made ownerless, usually as a measure
 // of decentralization or to prevent further administrative intervention.
+//
+// Once the privileges are discovered, ownership is only considered renounced if nobody can take it back,
+// see Privileges.IsRenounced.
 func (c *Contract) IsRenounced() bool {
-	zeroAddresses := []common.Address{
-		common.HexToAddress("0x0000000000000000000000000000000000000000"),
-		common.HexToAddress("0x000000000000000000000000000000000000dead"),
-		common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
-		common.HexToAddress("0x0000000000000000000000000000000000000001"),
-		common.HexToAddress("0x0000000000000000000000000000000000000002"),
-		common.HexToAddress("0x0000000000000000000000000000000000000003"),
-		common.HexToAddress("0x0000000000000000000000000000000000000004"),
-		common.HexToAddress("0x0000000000000000000000000000000000000005"),
-		common.HexToAddress("0x0000000000000000000000000000000000000006"),
-		common.HexToAddress("0x0000000000000000000000000000000000000007"),
-		common.HexToAddress("0x0000000000000000000000000000000000000008"),
-		common.HexToAddress("0x0000000000000000000000000000000000000009"),
+	if c.descriptor.Privileges != nil {
+		return c.descriptor.Privileges.Renounced
 	}
 
-	for _, address := range zeroAddresses {
-		if c.descriptor.Owner == address {
-			return true
-		}
-	}
+	return isRenouncedOwner(c.descriptor.Owner)
+}
 
-	return false
+// isRenouncedOwner checks if the address is one of the addresses ownership is renounced to.
+func isRenouncedOwner(addr common.Address) bool {
+	return containsAddress(renouncedOwners, addr)
 }
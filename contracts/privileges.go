@@ -0,0 +1,620 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+	"go.uber.org/zap"
+)
+
+// PrivilegeKind defines the kind of privilege a role grants.
+type PrivilegeKind string
+
+// PrivilegeSource defines where the members of a role were discovered.
+type PrivilegeSource string
+
+const (
+	// PrivilegeOwner is the Ownable owner of the contract.
+	PrivilegeOwner PrivilegeKind = "owner"
+
+	// PrivilegePendingOwner is the Ownable2Step pending owner, who becomes the owner once accepting the ownership.
+	PrivilegePendingOwner PrivilegeKind = "pending_owner"
+
+	// PrivilegeRole is an AccessControl role.
+	PrivilegeRole PrivilegeKind = "role"
+
+	// PrivilegeVariable is an address state variable the caller is compared against, such as `admin`.
+	PrivilegeVariable PrivilegeKind = "variable"
+
+	// PrivilegeModifier is a custom access modifier, such as `onlyMinter`, whose members are not known.
+	PrivilegeModifier PrivilegeKind = "modifier"
+
+	// SourceGetter is used for members returned by the getter of the contract.
+	SourceGetter PrivilegeSource = "getter"
+
+	// SourceStorage is used for members read straight from the contract storage.
+	SourceStorage PrivilegeSource = "storage"
+
+	// SourceEvents is used for members found by replaying RoleGranted and RoleRevoked events.
+	SourceEvents PrivilegeSource = "events"
+)
+
+// DefaultAdminRole is the name of the AccessControl role administering every other role by default.
+const DefaultAdminRole = "DEFAULT_ADMIN_ROLE"
+
+var (
+	// ownerSelector is the selector of owner().
+	ownerSelector = common.FromHex("0x8da5cb5b")
+
+	// pendingOwnerSelector is the selector of pendingOwner(), exposed by Ownable2Step contracts.
+	pendingOwnerSelector = common.FromHex("0xe30c3978")
+
+	// roleGrantedTopic is the topic of RoleGranted(bytes32,address,address).
+	roleGrantedTopic = crypto.Keccak256Hash([]byte("RoleGranted(bytes32,address,address)"))
+
+	// roleRevokedTopic is the topic of RoleRevoked(bytes32,address,address).
+	roleRevokedTopic = crypto.Keccak256Hash([]byte("RoleRevoked(bytes32,address,address)"))
+
+	// ownerVariables are the names of the state variables holding the owner when no getter exists.
+	ownerVariables = []string{"_owner", "owner"}
+
+	// pendingOwnerVariables are the names of the state variables holding the pending owner when no getter exists.
+	pendingOwnerVariables = []string{"_pendingOwner", "pendingOwner"}
+)
+
+// Privilege describes a role within the contract, who holds it and which functions it is allowed to call.
+type Privilege struct {
+	Name      string           `json:"name"`
+	Kind      PrivilegeKind    `json:"kind"`
+	Role      common.Hash      `json:"role,omitempty"`
+	Source    PrivilegeSource  `json:"source,omitempty"`
+	Members   []common.Address `json:"members"`
+	Functions []string         `json:"functions"`
+}
+
+// HasMembers checks if anyone holds the privilege.
+func (p *Privilege) HasMembers() bool {
+	return len(p.Members) > 0
+}
+
+// Privileges is the report of the privileges within the contract, as needed for risk reviews.
+type Privileges struct {
+	Owner        common.Address `json:"owner"`
+	PendingOwner common.Address `json:"pending_owner"`
+	Renounced    bool           `json:"renounced"`
+	Roles        []*Privilege   `json:"roles"`
+}
+
+// GetRole returns the privilege with the name, nil if it is not found.
+func (p *Privileges) GetRole(name string) *Privilege {
+	for _, role := range p.Roles {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// IsRenounced checks if the ownership is renounced and nobody is able to take it back or administer the contract
+// in place of the owner. The owner has to be one of the addresses ownership is renounced to, there must not be a
+// pending owner able to accept the ownership, and nobody but such addresses may hold the DEFAULT_ADMIN_ROLE.
+func (p *Privileges) IsRenounced() bool {
+	if owner := p.GetRole("owner"); owner == nil || owner.Source == "" || !isRenouncedOwner(p.Owner) {
+		return false
+	}
+
+	if !isRenouncedOwner(p.PendingOwner) {
+		return false
+	}
+
+	if admin := p.GetRole(DefaultAdminRole); admin != nil {
+		for _, member := range admin.Members {
+			if !isRenouncedOwner(member) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// GetFunctionRoles returns the privileges allowed to call the function with the signature, such as `mint(address,uint256)`.
+func (p *Privileges) GetFunctionRoles(signature string) []*Privilege {
+	toReturn := make([]*Privilege, 0)
+	for _, role := range p.Roles {
+		for _, function := range role.Functions {
+			if function == signature {
+				toReturn = append(toReturn, role)
+				break
+			}
+		}
+	}
+	return toReturn
+}
+
+// DiscoverPrivileges discovers who holds the privileges of the contract and, when the sources are parsed, which
+// functions each of them is allowed to call. It covers Ownable owners and Ownable2Step pending owners, read through
+// their getters or straight from storage when no getter exists, AccessControl role members found by replaying the
+// RoleGranted and RoleRevoked events, and address state variables the caller is compared against.
+//
+// The owner of the descriptor is updated with the discovered owner.
+func (c *Contract) DiscoverPrivileges(ctx context.Context) error {
+	privileges := &Privileges{Roles: make([]*Privilege, 0)}
+	functions := c.getPrivilegedFunctions()
+
+	owner := &Privilege{Name: "owner", Kind: PrivilegeOwner, Members: make([]common.Address, 0)}
+	if addr, source, ok := c.getPrivilegedAddress(ctx, ownerSelector, ownerVariables); ok {
+		owner.Source = source
+		owner.Members = append(owner.Members, addr)
+		privileges.Owner = addr
+		c.descriptor.Owner = addr
+	}
+
+	pendingOwner := &Privilege{Name: "pendingOwner", Kind: PrivilegePendingOwner, Members: make([]common.Address, 0)}
+	if addr, source, ok := c.getPrivilegedAddress(ctx, pendingOwnerSelector, pendingOwnerVariables); ok {
+		pendingOwner.Source = source
+		if addr != (common.Address{}) {
+			pendingOwner.Members = append(pendingOwner.Members, addr)
+		}
+		privileges.PendingOwner = addr
+	}
+
+	if owner.Source != "" || len(functions[owner.Name]) > 0 {
+		privileges.Roles = append(privileges.Roles, owner)
+	}
+
+	if pendingOwner.HasMembers() || len(functions[pendingOwner.Name]) > 0 {
+		privileges.Roles = append(privileges.Roles, pendingOwner)
+	}
+
+	roles, err := c.discoverRoleMembers(ctx)
+	if err != nil {
+		zap.L().Debug(
+			"failed to discover role members",
+			zap.Error(err),
+			zap.String("network", c.network.String()),
+			zap.String("contract_address", c.addr.String()),
+		)
+	}
+
+	privileges.Roles = append(privileges.Roles, roles...)
+
+	// Roles found within the sources without any granted members, and address variables the caller is compared
+	// against, are reported as well, as they are needed to know who can call the privileged functions.
+	for _, name := range sortedKeys(functions) {
+		if privileges.GetRole(name) != nil {
+			continue
+		}
+
+		privilege := &Privilege{Name: name, Members: make([]common.Address, 0)}
+		switch {
+		case strings.HasPrefix(name, "only"):
+			privilege.Kind = PrivilegeModifier
+		case c.isAddressVariable(name):
+			privilege.Kind = PrivilegeVariable
+			if addr, err := c.readAddressVariable(ctx, name); err == nil {
+				privilege.Source = SourceStorage
+				privilege.Members = append(privilege.Members, addr)
+			}
+		case c.isRoleVariable(name):
+			privilege.Kind = PrivilegeRole
+			privilege.Role = roleHash(name)
+		default:
+			// Roles that are not declared within the sources have an unknown hash, so they are not reported.
+			continue
+		}
+		privileges.Roles = append(privileges.Roles, privilege)
+	}
+
+	for _, role := range privileges.Roles {
+		role.Functions = functions[role.Name]
+		if role.Functions == nil {
+			role.Functions = make([]string, 0)
+		}
+	}
+
+	privileges.Renounced = privileges.IsRenounced()
+	c.descriptor.Privileges = privileges
+	return nil
+}
+
+// getPrivilegedAddress returns the address returned by the getter, or stored within the first of the state
+// variables found within the storage layout when the getter does not exist.
+func (c *Contract) getPrivilegedAddress(ctx context.Context, selector []byte, variables []string) (common.Address, PrivilegeSource, bool) {
+	if result, err := c.call(ctx, c.addr, selector); err == nil {
+		return common.BytesToAddress(result), SourceGetter, true
+	}
+
+	for _, variable := range variables {
+		if !c.isAddressVariable(variable) {
+			continue
+		}
+
+		addr, err := c.readAddressVariable(ctx, variable)
+		if err != nil {
+			zap.L().Debug(
+				"failed to read privileged storage variable",
+				zap.Error(err),
+				zap.String("network", c.network.String()),
+				zap.String("contract_address", c.addr.String()),
+				zap.String("variable", variable),
+			)
+			continue
+		}
+
+		return addr, SourceStorage, true
+	}
+
+	return common.Address{}, "", false
+}
+
+// readAddressVariable reads the address stored within the state variable, through the storage layout.
+func (c *Contract) readAddressVariable(ctx context.Context, variable string) (common.Address, error) {
+	if c.stor == nil {
+		return common.Address{}, fmt.Errorf("storage is not set")
+	}
+
+	if !c.descriptor.HasDetector() || c.descriptor.Detector.GetCFG() == nil {
+		return common.Address{}, fmt.Errorf("contract sources are not parsed")
+	}
+
+	// The control flow graph of the detector is only built on demand, the storage layout is resolved through it.
+	cfgBuilder := c.descriptor.Detector.GetCFG()
+	if cfgBuilder.GetGraph().CountNodes() == 0 {
+		if err := cfgBuilder.Build(); err != nil {
+			return common.Address{}, fmt.Errorf("failed to build control flow graph: %w", err)
+		}
+	}
+
+	reader, err := c.stor.DescribeLayout(ctx, c.addr, c.descriptor.Detector, cfgBuilder, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to describe storage layout: %w", err)
+	}
+
+	entry, err := reader.ReadEntry(variable)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	addr, ok := entry.GetValue().(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("state variable %s is not an address", variable)
+	}
+
+	return addr, nil
+}
+
+// discoverRoleMembers replays the RoleGranted and RoleRevoked events of the contract to find the current members
+// of every AccessControl role. Role names are recovered from the role hashes using the names found within the
+// sources, following the `keccak256("NAME")` convention, and roles of unknown names are named by their hash.
+func (c *Contract) discoverRoleMembers(ctx context.Context) ([]*Privilege, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{c.addr},
+		Topics:    [][]common.Hash{{roleGrantedTopic, roleRevokedTopic}},
+	}
+
+	if c.descriptor.Block != nil {
+		query.FromBlock = c.descriptor.Block.Number
+	}
+
+	logs, err := c.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter role logs: %w", err)
+	}
+
+	return replayRoleLogs(logs, c.getRoleNames()), nil
+}
+
+// replayRoleLogs applies the RoleGranted and RoleRevoked logs in the order they were emitted, returning the roles
+// in the order they were first granted.
+func replayRoleLogs(logs []types.Log, names map[common.Hash]string) []*Privilege {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	toReturn := make([]*Privilege, 0)
+	roles := make(map[common.Hash]*Privilege)
+	for _, log := range logs {
+		if len(log.Topics) < 3 || log.Removed {
+			continue
+		}
+
+		hash, account := log.Topics[1], common.BytesToAddress(log.Topics[2].Bytes())
+		role, ok := roles[hash]
+		if !ok {
+			name, found := names[hash]
+			if !found {
+				name = hash.Hex()
+			}
+			role = &Privilege{Name: name, Kind: PrivilegeRole, Role: hash, Source: SourceEvents, Members: make([]common.Address, 0)}
+			roles[hash] = role
+			toReturn = append(toReturn, role)
+		}
+
+		if log.Topics[0] == roleGrantedTopic {
+			if !containsAddress(role.Members, account) {
+				role.Members = append(role.Members, account)
+			}
+			continue
+		}
+
+		for i, member := range role.Members {
+			if member == account {
+				role.Members = append(role.Members[:i], role.Members[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// getRoleNames returns the names of the roles found within the sources, keyed by their hash. These are the
+// constant bytes32 state variables as well as the roles required by the privileged functions.
+func (c *Contract) getRoleNames() map[common.Hash]string {
+	toReturn := map[common.Hash]string{roleHash(DefaultAdminRole): DefaultAdminRole}
+	if !c.descriptor.HasDetector() {
+		return toReturn
+	}
+
+	for _, contract := range c.descriptor.Detector.GetIR().GetRoot().GetContracts() {
+		for _, variable := range contract.GetStateVariables() {
+			if variable.IsConstant() && variable.GetType() == "bytes32" {
+				toReturn[roleHash(variable.GetName())] = variable.GetName()
+			}
+		}
+	}
+
+	for name := range c.getPrivilegedFunctions() {
+		toReturn[roleHash(name)] = name
+	}
+
+	return toReturn
+}
+
+// roleHash returns the hash of the role name, following the `keccak256("NAME")` convention of AccessControl.
+func roleHash(name string) common.Hash {
+	if name == DefaultAdminRole {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash([]byte(name))
+}
+
+// isAddressVariable checks if the contract declares an address state variable with the name.
+func (c *Contract) isAddressVariable(name string) bool {
+	return c.hasStateVariable(name, func(variable *ir.StateVariable) bool {
+		return !variable.IsConstant() && strings.HasPrefix(variable.GetType(), "address")
+	})
+}
+
+// isRoleVariable checks if the contracts declare a bytes32 state variable with the name, which is how
+// AccessControl roles are declared.
+func (c *Contract) isRoleVariable(name string) bool {
+	return c.hasStateVariable(name, func(variable *ir.StateVariable) bool {
+		return variable.GetType() == "bytes32"
+	})
+}
+
+// hasStateVariable checks if the contracts declare a state variable with the name that matches.
+func (c *Contract) hasStateVariable(name string, match func(variable *ir.StateVariable) bool) bool {
+	if !c.descriptor.HasDetector() {
+		return false
+	}
+
+	for _, contract := range c.descriptor.Detector.GetIR().GetRoot().GetContracts() {
+		for _, variable := range contract.GetStateVariables() {
+			if variable.GetName() == name && match(variable) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getPrivilegedFunctions returns the signatures of the functions restricted to each privilege, keyed by the name
+// of the privilege. Functions are restricted through the onlyOwner and onlyRole modifiers, through custom modifiers
+// starting with `only`, and through checks within their bodies: `_checkOwner()`, `_checkRole(ROLE)`,
+// `hasRole(ROLE, msg.sender)` and comparisons of the caller against a state variable or owner().
+func (c *Contract) getPrivilegedFunctions() map[string][]string {
+	toReturn := make(map[string][]string)
+	if !c.descriptor.HasDetector() {
+		return toReturn
+	}
+
+	for _, contract := range c.descriptor.Detector.GetIR().GetRoot().GetContracts() {
+		if contract.GetKind() != ast_pb.NodeType_KIND_CONTRACT {
+			continue
+		}
+
+		for _, function := range contract.GetFunctions() {
+			if !isCallableFunction(function) {
+				continue
+			}
+
+			for _, privilege := range c.getFunctionPrivileges(function) {
+				if !containsString(toReturn[privilege], function.GetAST().GetSignatureRaw()) {
+					toReturn[privilege] = append(toReturn[privilege], function.GetAST().GetSignatureRaw())
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// isCallableFunction checks if the function can be called from outside and modifies the state.
+func isCallableFunction(function *ir.Function) bool {
+	if !function.IsImplemented() || function.GetAST() == nil {
+		return false
+	}
+
+	switch function.GetVisibility() {
+	case ast_pb.Visibility_PUBLIC, ast_pb.Visibility_EXTERNAL:
+	default:
+		return false
+	}
+
+	switch function.GetStateMutability() {
+	case ast_pb.Mutability_VIEW, ast_pb.Mutability_PURE:
+		return false
+	}
+
+	return true
+}
+
+// getFunctionPrivileges returns the names of the privileges the function is restricted to. The caller compared
+// against a name is only a privilege when the name is an address state variable, and not a parameter or local.
+func (c *Contract) getFunctionPrivileges(function *ir.Function) []string {
+	toReturn := make([]string, 0)
+	add := func(name string) {
+		if name != "" && !containsString(toReturn, name) {
+			toReturn = append(toReturn, name)
+		}
+	}
+
+	for _, modifier := range function.GetModifiers() {
+		switch name := modifier.GetName(); {
+		case name == "onlyOwner":
+			add("owner")
+		case name == "onlyRole":
+			if arguments := modifier.GetAST().GetArguments(); len(arguments) > 0 {
+				add(nodeName(arguments[0]))
+			}
+		case strings.HasPrefix(name, "only"):
+			add(name)
+		}
+	}
+
+	if function.GetAST().GetBody() == nil {
+		return toReturn
+	}
+
+	walkNodes(function.GetAST().GetBody(), func(node ast.Node[ast.NodeType]) {
+		switch expression := node.(type) {
+		case *ast.FunctionCall:
+			arguments := expression.GetArguments()
+			switch callName(expression) {
+			case "_checkOwner":
+				add("owner")
+			case "_checkRole":
+				if len(arguments) > 0 {
+					add(nodeName(arguments[0]))
+				}
+			case "hasRole":
+				if len(arguments) > 1 && isCaller(arguments[1]) {
+					add(nodeName(arguments[0]))
+				}
+			}
+		case *ast.BinaryOperation:
+			if expression.GetOperator() != ast_pb.Operator_EQUAL {
+				return
+			}
+
+			other := expression.GetRightExpression()
+			if isCaller(other) {
+				other = expression.GetLeftExpression()
+			} else if !isCaller(expression.GetLeftExpression()) {
+				return
+			}
+
+			switch name := nodeName(other); name {
+			case "owner", "_owner":
+				add("owner")
+			case "pendingOwner", "_pendingOwner":
+				add("pendingOwner")
+			default:
+				if _, ok := other.(*ast.PrimaryExpression); ok && c.isAddressVariable(name) {
+					add(name)
+				}
+			}
+		}
+	})
+
+	return toReturn
+}
+
+// walkNodes calls the visitor for the node and all of its descendants.
+func walkNodes(node ast.Node[ast.NodeType], visitor func(node ast.Node[ast.NodeType])) {
+	if node == nil {
+		return
+	}
+
+	visitor(node)
+	for _, child := range node.GetNodes() {
+		walkNodes(child, visitor)
+	}
+}
+
+// callName returns the name of the function being called, either a plain identifier or a member name.
+func callName(call *ast.FunctionCall) string {
+	switch expression := call.GetExpression().(type) {
+	case *ast.PrimaryExpression:
+		return expression.GetName()
+	case *ast.MemberAccessExpression:
+		return expression.GetMemberName()
+	}
+	return ""
+}
+
+// nodeName returns the name of an identifier, member access or called function, such as the role of
+// `onlyRole(MINTER_ROLE)`, `onlyRole(Roles.MINTER_ROLE)` or the owner of `msg.sender == owner()`.
+func nodeName(node ast.Node[ast.NodeType]) string {
+	switch expression := node.(type) {
+	case *ast.PrimaryExpression:
+		return expression.GetName()
+	case *ast.MemberAccessExpression:
+		return expression.GetMemberName()
+	case *ast.FunctionCall:
+		if len(expression.GetArguments()) == 0 {
+			return callName(expression)
+		}
+	}
+	return ""
+}
+
+// isCaller checks if the node is `msg.sender` or a call to the `_msgSender()` context helper.
+func isCaller(node ast.Node[ast.NodeType]) bool {
+	switch expression := node.(type) {
+	case *ast.MemberAccessExpression:
+		primary, ok := expression.GetExpression().(*ast.PrimaryExpression)
+		return ok && primary.GetName() == "msg" && expression.GetMemberName() == "sender"
+	case *ast.FunctionCall:
+		return callName(expression) == "_msgSender"
+	}
+	return false
+}
+
+// containsString checks if the value is part of the values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys(m map[string][]string) []string {
+	toReturn := make([]string, 0, len(m))
+	for key := range m {
+		toReturn = append(toReturn, key)
+	}
+	sort.Strings(toReturn)
+	return toReturn
+}
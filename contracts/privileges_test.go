@@ -0,0 +1,370 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/detector"
+	"github.com/unpackdev/solgo/ir"
+	"github.com/unpackdev/solgo/simulator"
+	"github.com/unpackdev/solgo/storage"
+	"github.com/unpackdev/solgo/utils"
+)
+
+const vaultContract = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Vault {
+    bytes32 public constant MINTER_ROLE = keccak256("MINTER_ROLE");
+    bytes32 public constant PAUSER_ROLE = keccak256("PAUSER_ROLE");
+    bytes32 public constant BURNER_ROLE = keccak256("BURNER_ROLE");
+
+    address private _owner;
+    address private _pendingOwner;
+    address public treasury;
+    uint256 public total;
+
+    modifier onlyOwner() {
+        require(msg.sender == _owner);
+        _;
+    }
+
+    modifier onlyRole(bytes32 role) {
+        _checkRole(role);
+        _;
+    }
+
+    modifier onlyKeeper() {
+        _;
+    }
+
+    function hasRole(bytes32 role, address account) public view returns (bool) {
+        return role == bytes32(0) && account == address(0);
+    }
+
+    function _checkRole(bytes32 role) internal view {
+        require(hasRole(role, msg.sender));
+    }
+
+    function _checkOwner() internal view {
+        require(msg.sender == _owner);
+    }
+
+    function mint(address to) external onlyRole(MINTER_ROLE) {
+        total += uint160(to);
+    }
+
+    function pause() external {
+        _checkRole(PAUSER_ROLE);
+        total = 0;
+    }
+
+    function burn() external {
+        require(hasRole(BURNER_ROLE, msg.sender));
+        total -= 1;
+    }
+
+    function withdraw() external onlyOwner {
+        total = 0;
+    }
+
+    function sweep() external {
+        _checkOwner();
+        total = 0;
+    }
+
+    function acceptOwnership() external {
+        require(msg.sender == _pendingOwner);
+        _owner = _pendingOwner;
+    }
+
+    function setTreasury(address account) external {
+        require(msg.sender == treasury);
+        treasury = account;
+    }
+
+    function relay(address signer) external {
+        require(msg.sender == signer);
+        total += 1;
+    }
+
+    function harvest() external onlyKeeper {
+        total += 1;
+    }
+
+    function deposit() external payable {
+        total += msg.value;
+    }
+
+    function balance() external view onlyOwner returns (uint256) {
+        return total;
+    }
+}
+`
+
+// parseContract returns the detector of the contract, with its sources parsed and built.
+func parseContract(t *testing.T, name string, content string) *detector.Detector {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name:    name,
+				Path:    name + ".sol",
+				Content: content,
+			},
+		},
+		EntrySourceUnitName: name,
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	parser, err := detector.NewDetectorFromSources(context.Background(), nil, sources)
+	require.NoError(t, err)
+	require.Empty(t, parser.Parse())
+	require.NoError(t, parser.Build())
+	return parser
+}
+
+// getFunction returns the function with the name of the detector contracts.
+func getFunction(t *testing.T, parser *detector.Detector, name string) *ir.Function {
+	for _, contract := range parser.GetIR().GetRoot().GetContracts() {
+		for _, function := range contract.GetFunctions() {
+			if function.GetName() == name {
+				return function
+			}
+		}
+	}
+
+	require.Failf(t, "function not found", "function %s not found", name)
+	return nil
+}
+
+// roleLog returns a RoleGranted or RoleRevoked log of the role and account.
+func roleLog(topic common.Hash, role common.Hash, account common.Address, block uint64, index uint) types.Log {
+	return types.Log{
+		Topics:      []common.Hash{topic, role, common.BytesToHash(account.Bytes()), common.BytesToHash(adminAddr.Bytes())},
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func TestRoleHash(t *testing.T) {
+	assert.Equal(t, common.Hash{}, roleHash(DefaultAdminRole))
+	assert.Equal(t, common.HexToHash("0x9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6"), roleHash("MINTER_ROLE"))
+	assert.Equal(t, common.HexToHash("0x65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a"), roleHash("PAUSER_ROLE"))
+}
+
+func TestReplayRoleLogs(t *testing.T) {
+	minter := roleHash("MINTER_ROLE")
+	unknown := roleHash("UNKNOWN_ROLE")
+	alice := common.HexToAddress("0x0000000000000000000000000000000000000a11")
+	bob := common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+
+	names := map[common.Hash]string{
+		roleHash(DefaultAdminRole): DefaultAdminRole,
+		minter:                     "MINTER_ROLE",
+	}
+
+	// Logs are given out of order, they are replayed by block and index.
+	logs := []types.Log{
+		roleLog(roleRevokedTopic, minter, alice, 5, 0),
+		roleLog(roleGrantedTopic, minter, alice, 7, 1),
+		roleLog(roleGrantedTopic, roleHash(DefaultAdminRole), bob, 1, 0),
+		roleLog(roleGrantedTopic, minter, alice, 2, 3),
+		roleLog(roleGrantedTopic, minter, bob, 2, 4),
+		roleLog(roleGrantedTopic, minter, bob, 3, 0),
+		roleLog(roleRevokedTopic, roleHash(DefaultAdminRole), bob, 7, 0),
+		roleLog(roleGrantedTopic, unknown, alice, 4, 0),
+		{
+			// Logs removed by a reorg are ignored.
+			Topics:      []common.Hash{roleGrantedTopic, unknown, common.BytesToHash(bob.Bytes())},
+			BlockNumber: 4,
+			Index:       1,
+			Removed:     true,
+		},
+		{
+			// Logs without the account are not role logs.
+			Topics:      []common.Hash{roleGrantedTopic, unknown},
+			BlockNumber: 4,
+			Index:       2,
+		},
+	}
+
+	roles := replayRoleLogs(logs, names)
+	require.Len(t, roles, 3)
+
+	assert.Equal(t, DefaultAdminRole, roles[0].Name)
+	assert.Equal(t, common.Hash{}, roles[0].Role)
+	assert.Empty(t, roles[0].Members)
+
+	assert.Equal(t, "MINTER_ROLE", roles[1].Name)
+	assert.Equal(t, minter, roles[1].Role)
+	assert.Equal(t, SourceEvents, roles[1].Source)
+	assert.Equal(t, []common.Address{bob, alice}, roles[1].Members)
+
+	assert.Equal(t, unknown.Hex(), roles[2].Name)
+	assert.Equal(t, PrivilegeRole, roles[2].Kind)
+	assert.Equal(t, []common.Address{alice}, roles[2].Members)
+}
+
+func TestGetFunctionPrivileges(t *testing.T) {
+	parser := parseContract(t, "Vault", vaultContract)
+
+	testCases := []struct {
+		function string
+		expected []string
+	}{
+		{function: "mint", expected: []string{"MINTER_ROLE"}},
+		{function: "pause", expected: []string{"PAUSER_ROLE"}},
+		{function: "burn", expected: []string{"BURNER_ROLE"}},
+		{function: "withdraw", expected: []string{"owner"}},
+		{function: "sweep", expected: []string{"owner"}},
+		{function: "acceptOwnership", expected: []string{"pendingOwner"}},
+		{function: "setTreasury", expected: []string{"treasury"}},
+		{function: "relay", expected: []string{}},
+		{function: "harvest", expected: []string{"onlyKeeper"}},
+		{function: "deposit", expected: []string{}},
+		{function: "hasRole", expected: []string{}},
+	}
+
+	contract := &Contract{descriptor: &Descriptor{Detector: parser}}
+	for _, tc := range testCases {
+		t.Run(tc.function, func(t *testing.T) {
+			assert.Equal(t, tc.expected, contract.getFunctionPrivileges(getFunction(t, parser, tc.function)))
+		})
+	}
+
+	assert.Equal(t, map[string][]string{
+		"MINTER_ROLE":  {"mint(address)"},
+		"PAUSER_ROLE":  {"pause()"},
+		"BURNER_ROLE":  {"burn()"},
+		"owner":        {"withdraw()", "sweep()"},
+		"pendingOwner": {"acceptOwnership()"},
+		"treasury":     {"setTreasury(address)"},
+		"onlyKeeper":   {"harvest()"},
+	}, contract.getPrivilegedFunctions())
+
+	names := contract.getRoleNames()
+	assert.Equal(t, DefaultAdminRole, names[common.Hash{}])
+	assert.Equal(t, "MINTER_ROLE", names[roleHash("MINTER_ROLE")])
+	assert.Equal(t, "PAUSER_ROLE", names[roleHash("PAUSER_ROLE")])
+	assert.Equal(t, "BURNER_ROLE", names[roleHash("BURNER_ROLE")])
+	assert.Equal(t, "treasury", names[roleHash("treasury")])
+	assert.NotContains(t, names, roleHash("total"))
+
+	assert.Equal(t, map[common.Hash]string{common.Hash{}: DefaultAdminRole}, (&Contract{descriptor: &Descriptor{}}).getRoleNames())
+}
+
+func TestDiscoverPrivilegesFromStorage(t *testing.T) {
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	pendingOwner := common.HexToAddress("0x00000000000000000000000000000000000000f2")
+	treasury := common.HexToAddress("0x00000000000000000000000000000000000000f3")
+	burned := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	parser := parseContract(t, "Vault", vaultContract)
+
+	testCases := []struct {
+		name         string
+		owner        common.Address
+		pendingOwner common.Address
+		renounced    bool
+	}{
+		{name: "Owned", owner: owner, renounced: false},
+		{name: "Renounced", owner: burned, renounced: true},
+		{name: "Renounced With Pending Owner", owner: burned, pendingOwner: pendingOwner, renounced: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sim, err := simulator.NewSimulator(ctx, utils.Ethereum, nil, simulator.NewDefaultOptions())
+			require.NoError(t, err)
+			defer sim.Close()
+
+			// The contract has no getters, so privileged addresses are read from the storage layout.
+			require.NoError(t, sim.SetCode(proxyAddr, []byte{0x00}))
+			require.NoError(t, sim.SetStorageAt(proxyAddr, common.HexToHash("0x00"), common.BytesToHash(tc.owner.Bytes())))
+			require.NoError(t, sim.SetStorageAt(proxyAddr, common.HexToHash("0x01"), common.BytesToHash(tc.pendingOwner.Bytes())))
+			require.NoError(t, sim.SetStorageAt(proxyAddr, common.HexToHash("0x02"), common.BytesToHash(treasury.Bytes())))
+
+			stor, err := storage.NewStorage(ctx, utils.Ethereum, nil, sim, storage.NewSimulatorOptions())
+			require.NoError(t, err)
+
+			contract := &Contract{
+				ctx:        ctx,
+				client:     sim.GetClient(),
+				addr:       proxyAddr,
+				network:    utils.Ethereum,
+				stor:       stor,
+				descriptor: &Descriptor{Detector: parser},
+			}
+
+			require.NoError(t, contract.DiscoverPrivileges(ctx))
+
+			privileges := contract.GetDescriptor().GetPrivileges()
+			require.NotNil(t, privileges)
+			assert.Equal(t, tc.owner, privileges.Owner)
+			assert.Equal(t, tc.owner, contract.GetDescriptor().Owner)
+			assert.Equal(t, tc.pendingOwner, privileges.PendingOwner)
+			assert.Equal(t, tc.renounced, privileges.Renounced)
+			assert.Equal(t, tc.renounced, contract.IsRenounced())
+
+			ownerRole := privileges.GetRole("owner")
+			require.NotNil(t, ownerRole)
+			assert.Equal(t, SourceStorage, ownerRole.Source)
+			assert.Equal(t, []common.Address{tc.owner}, ownerRole.Members)
+			assert.Equal(t, []string{"withdraw()", "sweep()"}, ownerRole.Functions)
+
+			treasuryRole := privileges.GetRole("treasury")
+			require.NotNil(t, treasuryRole)
+			assert.Equal(t, PrivilegeVariable, treasuryRole.Kind)
+			assert.Equal(t, []common.Address{treasury}, treasuryRole.Members)
+
+			minterRole := privileges.GetRole("MINTER_ROLE")
+			require.NotNil(t, minterRole)
+			assert.Equal(t, PrivilegeRole, minterRole.Kind)
+			assert.Equal(t, roleHash("MINTER_ROLE"), minterRole.Role)
+			assert.Empty(t, minterRole.Members)
+
+			assert.Equal(t, PrivilegeModifier, privileges.GetRole("onlyKeeper").Kind)
+			assert.Equal(t, []*Privilege{minterRole}, privileges.GetFunctionRoles("mint(address)"))
+		})
+	}
+}
+
+func TestPrivilegesIsRenounced(t *testing.T) {
+	burned := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	admin := common.HexToAddress("0x0000000000000000000000000000000000000a11")
+
+	owned := func(owner common.Address, roles ...*Privilege) *Privileges {
+		return &Privileges{
+			Owner: owner,
+			Roles: append([]*Privilege{{Name: "owner", Kind: PrivilegeOwner, Source: SourceGetter, Members: []common.Address{owner}}}, roles...),
+		}
+	}
+
+	assert.True(t, owned(common.Address{}).IsRenounced())
+	assert.True(t, owned(burned).IsRenounced())
+	assert.False(t, owned(admin).IsRenounced())
+
+	// Without an owner getter or variable there is no ownership to renounce.
+	assert.False(t, (&Privileges{}).IsRenounced())
+
+	withPending := owned(burned)
+	withPending.PendingOwner = admin
+	assert.False(t, withPending.IsRenounced())
+
+	withAdmin := owned(burned, &Privilege{Name: DefaultAdminRole, Kind: PrivilegeRole, Members: []common.Address{admin}})
+	assert.False(t, withAdmin.IsRenounced())
+
+	withoutAdmin := owned(burned, &Privilege{Name: DefaultAdminRole, Kind: PrivilegeRole, Members: []common.Address{}})
+	assert.True(t, withoutAdmin.IsRenounced())
+
+	withMinter := owned(burned, &Privilege{Name: "MINTER_ROLE", Kind: PrivilegeRole, Members: []common.Address{admin}})
+	assert.True(t, withMinter.IsRenounced())
+}
@@ -0,0 +1,416 @@
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/unpackdev/solgo/bytecode"
+	"go.uber.org/zap"
+)
+
+// ProxyType defines the proxy pattern a contract implements.
+type ProxyType string
+
+const (
+	// ProxyTypeMinimal is used for EIP-1167 minimal proxies, whose implementation is part of their bytecode.
+	ProxyTypeMinimal ProxyType = "eip1167"
+
+	// ProxyTypeEip1967 is used for proxies storing their implementation within the EIP-1967 implementation slot.
+	ProxyTypeEip1967 ProxyType = "eip1967"
+
+	// ProxyTypeBeacon is used for EIP-1967 beacon proxies, whose implementation is provided by the beacon.
+	ProxyTypeBeacon ProxyType = "eip1967_beacon"
+
+	// ProxyTypeTransparent is used for OpenZeppelin transparent proxies, EIP-1967 proxies with an admin.
+	ProxyTypeTransparent ProxyType = "transparent"
+
+	// ProxyTypeUups is used for UUPS proxies, whose implementation is ERC-1822 proxiable.
+	ProxyTypeUups ProxyType = "eip1822"
+
+	// ProxyTypeZeppelinOS is used for legacy ZeppelinOS proxies, preceding EIP-1967.
+	ProxyTypeZeppelinOS ProxyType = "zeppelinos"
+
+	// ProxyTypeDiamond is used for EIP-2535 diamonds, whose facets are returned by facets().
+	ProxyTypeDiamond ProxyType = "eip2535"
+)
+
+var (
+	// eip1967ImplementationSlot is the storage slot of the implementation, keccak256("eip1967.proxy.implementation") - 1.
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// eip1967BeaconSlot is the storage slot of the beacon, keccak256("eip1967.proxy.beacon") - 1.
+	eip1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// eip1967AdminSlot is the storage slot of the admin, keccak256("eip1967.proxy.admin") - 1.
+	eip1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	// eip1822ProxiableSlot is the storage slot of the implementation of ERC-1822 proxies, keccak256("PROXIABLE").
+	eip1822ProxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+
+	// zeppelinOSImplementationSlot is the storage slot of the implementation, keccak256("org.zeppelinos.proxy.implementation").
+	zeppelinOSImplementationSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+
+	// upgradedTopic is the topic of the Upgraded(address) event emitted by EIP-1967 proxies.
+	upgradedTopic = common.HexToHash("0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b")
+
+	// beaconUpgradedTopic is the topic of the BeaconUpgraded(address) event emitted by EIP-1967 beacon proxies.
+	beaconUpgradedTopic = common.HexToHash("0x1cf3b03a6cf19fa2baba4df148e9dcabedea7f8a5c07840e207e5c089be95d3e")
+
+	// implementationSelector is the selector of implementation(), exposed by beacons.
+	implementationSelector = common.FromHex("0x5c60da1b")
+
+	// proxiableUUIDSelector is the selector of proxiableUUID(), exposed by UUPS implementations.
+	proxiableUUIDSelector = common.FromHex("0x52d1902d")
+
+	// facetsSelector is the selector of facets(), exposed by EIP-2535 diamonds.
+	facetsSelector = common.FromHex("0x7a0ed627")
+
+	// facetsABI is the ABI of facets(), used to decode diamond facets.
+	facetsABI = `[{"inputs":[],"name":"facets","outputs":[{"components":[{"internalType":"address","name":"facetAddress","type":"address"},{"internalType":"bytes4[]","name":"functionSelectors","type":"bytes4[]"}],"internalType":"struct IDiamondLoupe.Facet[]","name":"facets_","type":"tuple[]"}],"stateMutability":"view","type":"function"}]`
+)
+
+// ProxyFacet represents a facet of an EIP-2535 diamond, along with the function selectors it implements.
+type ProxyFacet struct {
+	Address   common.Address `json:"address"`
+	Selectors []string       `json:"selectors"`
+}
+
+// ProxyUpgrade represents an upgrade of the proxy implementation, or beacon, as found within the proxy events.
+type ProxyUpgrade struct {
+	Implementation common.Address `json:"implementation"`
+	Beacon         bool           `json:"beacon"`
+	BlockNumber    uint64         `json:"block_number"`
+	TxHash         common.Hash    `json:"tx_hash"`
+}
+
+// ProxyDescriptor encapsulates the details of the proxy pattern the contract implements.
+type ProxyDescriptor struct {
+	Type           ProxyType      `json:"type"`
+	Implementation common.Address `json:"implementation"`
+	Beacon         common.Address `json:"beacon,omitempty"`
+	Admin          common.Address `json:"admin,omitempty"`
+	Facets         []ProxyFacet   `json:"facets,omitempty"`
+	Upgrades       []ProxyUpgrade `json:"upgrades,omitempty"`
+}
+
+// GetImplementations returns the current implementations of the proxy, the facets for diamonds.
+func (p *ProxyDescriptor) GetImplementations() []common.Address {
+	if p.Type == ProxyTypeDiamond {
+		toReturn := make([]common.Address, 0, len(p.Facets))
+		for _, facet := range p.Facets {
+			toReturn = append(toReturn, facet.Address)
+		}
+		return toReturn
+	}
+
+	return []common.Address{p.Implementation}
+}
+
+// DiscoverProxy analyzes the deployed bytecode and storage of the contract to detect whether it is a proxy,
+// and resolves its current implementation. EIP-1167 minimal proxies are detected from the bytecode; EIP-1967,
+// beacon, transparent, UUPS, ERC-1822 and ZeppelinOS proxies from their storage slots; and EIP-2535 diamonds
+// through facets(). The upgrade history is collected from Upgraded and BeaconUpgraded events on a best effort
+// basis, as not every node serves logs across the whole chain.
+//
+// The descriptor is updated with the proxy details and the implementations. Contracts not implementing any
+// of the proxy patterns are left untouched.
+func (c *Contract) DiscoverProxy(ctx context.Context) error {
+	if len(c.descriptor.DeployedBytecode) == 0 {
+		if err := c.DiscoverDeployedBytecode(); err != nil {
+			return err
+		}
+	}
+
+	proxy, err := c.detectProxy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover proxy of contract %s: %w", c.addr.Hex(), err)
+	}
+
+	if proxy == nil {
+		return nil
+	}
+
+	if proxy.Type != ProxyTypeMinimal && proxy.Type != ProxyTypeDiamond {
+		upgrades, err := c.discoverProxyUpgrades(ctx)
+		if err != nil {
+			zap.L().Debug(
+				"failed to discover proxy upgrades",
+				zap.Error(err),
+				zap.String("network", c.network.String()),
+				zap.String("contract_address", c.addr.String()),
+			)
+		}
+		proxy.Upgrades = upgrades
+	}
+
+	c.descriptor.Proxy = true
+	c.descriptor.ProxyDescriptor = proxy
+
+	for _, implementation := range proxy.GetImplementations() {
+		if !containsAddress(c.descriptor.Implementations, implementation) {
+			c.descriptor.Implementations = append(c.descriptor.Implementations, implementation)
+		}
+	}
+
+	return nil
+}
+
+// DiscoverImplementations runs source discovery and parsing on the current implementations of the proxy, so that
+// the descriptor exposes the logic contracts behind it. Failures of individual implementations are logged and
+// skipped, as implementations are not necessarily verified.
+func (c *Contract) DiscoverImplementations(ctx context.Context) error {
+	if c.descriptor.ProxyDescriptor == nil {
+		return fmt.Errorf("failed to discover implementations of contract %s: contract is not a proxy", c.addr.Hex())
+	}
+
+	c.descriptor.ImplementationDescriptors = make([]*Descriptor, 0)
+	for _, addr := range c.descriptor.ProxyDescriptor.GetImplementations() {
+		implementation, err := NewContract(
+			ctx, c.network, c.clientPool, c.stor, c.bqp, c.etherscan, c.compiler, c.bindings, c.ipfsProvider, addr,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create implementation %s of contract %s: %w", addr.Hex(), c.addr.Hex(), err)
+		}
+
+		if err := implementation.DiscoverDeployedBytecode(); err != nil {
+			return err
+		}
+
+		if c.etherscan != nil {
+			if err := implementation.DiscoverSourceCode(ctx); err != nil {
+				zap.L().Debug(
+					"failed to discover implementation source code",
+					zap.Error(err),
+					zap.String("network", c.network.String()),
+					zap.String("contract_address", c.addr.String()),
+					zap.String("implementation_address", addr.String()),
+				)
+			}
+		}
+
+		if implementation.GetDescriptor().HasSources() {
+			if err := implementation.Parse(ctx); err != nil {
+				zap.L().Debug(
+					"failed to parse implementation sources",
+					zap.Error(err),
+					zap.String("network", c.network.String()),
+					zap.String("contract_address", c.addr.String()),
+					zap.String("implementation_address", addr.String()),
+				)
+			}
+		}
+
+		c.descriptor.ImplementationDescriptors = append(c.descriptor.ImplementationDescriptors, implementation.GetDescriptor())
+	}
+
+	return nil
+}
+
+// detectProxy detects the proxy pattern of the contract, returning nil if it does not implement any.
+func (c *Contract) detectProxy(ctx context.Context) (*ProxyDescriptor, error) {
+	if implementation, ok := bytecode.DecodeMinimalProxy(c.descriptor.DeployedBytecode); ok {
+		return &ProxyDescriptor{Type: ProxyTypeMinimal, Implementation: implementation}, nil
+	}
+
+	implementation, err := c.getAddressAt(ctx, eip1967ImplementationSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	if implementation != (common.Address{}) {
+		toReturn := &ProxyDescriptor{Type: ProxyTypeEip1967, Implementation: implementation}
+
+		if toReturn.Admin, err = c.getAddressAt(ctx, eip1967AdminSlot); err != nil {
+			return nil, err
+		}
+
+		if toReturn.Admin != (common.Address{}) {
+			toReturn.Type = ProxyTypeTransparent
+		} else if uuid, err := c.call(ctx, implementation, proxiableUUIDSelector); err == nil && common.BytesToHash(uuid) == eip1967ImplementationSlot {
+			toReturn.Type = ProxyTypeUups
+		}
+
+		return toReturn, nil
+	}
+
+	beacon, err := c.getAddressAt(ctx, eip1967BeaconSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	if beacon != (common.Address{}) {
+		result, err := c.call(ctx, beacon, implementationSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get implementation of beacon %s: %w", beacon.Hex(), err)
+		}
+
+		return &ProxyDescriptor{Type: ProxyTypeBeacon, Implementation: common.BytesToAddress(result), Beacon: beacon}, nil
+	}
+
+	legacySlots := []struct {
+		slot      common.Hash
+		proxyType ProxyType
+	}{
+		{eip1822ProxiableSlot, ProxyTypeUups},
+		{zeppelinOSImplementationSlot, ProxyTypeZeppelinOS},
+	}
+
+	for _, legacy := range legacySlots {
+		implementation, err := c.getAddressAt(ctx, legacy.slot)
+		if err != nil {
+			return nil, err
+		}
+
+		if implementation != (common.Address{}) {
+			return &ProxyDescriptor{Type: legacy.proxyType, Implementation: implementation}, nil
+		}
+	}
+
+	// Calls into contracts that are not diamonds might succeed through their fallback function, so the facets
+	// are only trusted if they are a canonical encoding of facets with an address and selectors each.
+	facets, err := c.getFacets(ctx)
+	if err != nil {
+		zap.L().Debug(
+			"failed to get diamond facets",
+			zap.Error(err),
+			zap.String("network", c.network.String()),
+			zap.String("contract_address", c.addr.String()),
+		)
+		return nil, nil
+	}
+
+	if len(facets) > 0 {
+		return &ProxyDescriptor{Type: ProxyTypeDiamond, Facets: facets}, nil
+	}
+
+	return nil, nil
+}
+
+// getFacets returns the facets of the diamond, failing if the result of facets() is not a valid list of facets.
+func (c *Contract) getFacets(ctx context.Context) ([]ProxyFacet, error) {
+	result, err := c.call(ctx, c.addr, facetsSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(facetsABI))
+	if err != nil {
+		return nil, err
+	}
+
+	unpacked, err := parsed.Unpack("facets", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode facets: %w", err)
+	}
+
+	// Unpacking tolerates trailing data and non canonical offsets, which the result of a fallback function
+	// returning arbitrary data might decode as, hence the facets have to encode back to the same result.
+	packed, err := parsed.Methods["facets"].Outputs.Pack(unpacked...)
+	if err != nil || !bytes.Equal(packed, result) {
+		return nil, fmt.Errorf("failed to decode facets: result is not a canonical encoding of facets")
+	}
+
+	decoded := *abi.ConvertType(unpacked[0], new([]struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	})).(*[]struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	})
+
+	toReturn := make([]ProxyFacet, 0, len(decoded))
+	for _, facet := range decoded {
+		if facet.FacetAddress == (common.Address{}) || len(facet.FunctionSelectors) == 0 {
+			return nil, fmt.Errorf("failed to decode facets: facet %s has no selectors or address", facet.FacetAddress.Hex())
+		}
+
+		selectors := make([]string, 0, len(facet.FunctionSelectors))
+		for _, selector := range facet.FunctionSelectors {
+			selectors = append(selectors, common.Bytes2Hex(selector[:]))
+		}
+		toReturn = append(toReturn, ProxyFacet{Address: facet.FacetAddress, Selectors: selectors})
+	}
+
+	return toReturn, nil
+}
+
+// discoverProxyUpgrades returns the implementation and beacon upgrades of the proxy, in the order they happened.
+// Logs are looked up from the deployment block of the contract, if known, and from genesis otherwise.
+func (c *Contract) discoverProxyUpgrades(ctx context.Context) ([]ProxyUpgrade, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{c.addr},
+		Topics:    [][]common.Hash{{upgradedTopic, beaconUpgradedTopic}},
+	}
+
+	if c.descriptor.Block != nil {
+		query.FromBlock = c.descriptor.Block.Number
+	}
+
+	logs, err := c.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter upgrade logs: %w", err)
+	}
+
+	return decodeProxyUpgrades(logs), nil
+}
+
+// decodeProxyUpgrades decodes the Upgraded and BeaconUpgraded logs, whose single argument is indexed.
+func decodeProxyUpgrades(logs []types.Log) []ProxyUpgrade {
+	toReturn := make([]ProxyUpgrade, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) < 2 || log.Removed {
+			continue
+		}
+
+		toReturn = append(toReturn, ProxyUpgrade{
+			Implementation: common.BytesToAddress(log.Topics[1].Bytes()),
+			Beacon:         log.Topics[0] == beaconUpgradedTopic,
+			BlockNumber:    log.BlockNumber,
+			TxHash:         log.TxHash,
+		})
+	}
+
+	return toReturn
+}
+
+// getAddressAt returns the address stored within the storage slot of the contract.
+func (c *Contract) getAddressAt(ctx context.Context, slot common.Hash) (common.Address, error) {
+	value, err := c.client.StorageAt(ctx, c.addr, slot, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get storage slot %s: %w", slot.Hex(), err)
+	}
+
+	return common.BytesToAddress(value), nil
+}
+
+// call calls the function of the contract at the address, without arguments.
+func (c *Contract) call(ctx context.Context, addr common.Address, selector []byte) ([]byte, error) {
+	result, err := c.client.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: selector}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) < 32 {
+		return nil, fmt.Errorf("unexpected result of call to %s: %x", addr.Hex(), result)
+	}
+
+	return result, nil
+}
+
+// containsAddress checks if the address is part of the addresses.
+func containsAddress(addresses []common.Address, addr common.Address) bool {
+	for _, address := range addresses {
+		if address == addr {
+			return true
+		}
+	}
+	return false
+}
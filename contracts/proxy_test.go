@@ -0,0 +1,232 @@
+package contracts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/simulator"
+	"github.com/unpackdev/solgo/utils"
+)
+
+var (
+	proxyAddr          = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	implementationAddr = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	beaconAddr         = common.HexToAddress("0x00000000000000000000000000000000000000c3")
+	adminAddr          = common.HexToAddress("0x00000000000000000000000000000000000000d4")
+	facetAddr          = common.HexToAddress("0x00000000000000000000000000000000000000e5")
+)
+
+// returning returns the runtime code of a contract returning the payload, whatever it is called with.
+func returning(payload []byte) []byte {
+	size := []byte{byte(len(payload) >> 8), byte(len(payload))}
+
+	code := []byte{0x61, size[0], size[1], 0x60, 0x0e, 0x60, 0x00, 0x39, 0x61, size[0], size[1], 0x60, 0x00, 0xf3}
+	return append(code, payload...)
+}
+
+// encodeFacets returns the result of facets() returning the facets.
+func encodeFacets(t *testing.T, facets map[common.Address][][4]byte) []byte {
+	parsed, err := abi.JSON(strings.NewReader(facetsABI))
+	require.NoError(t, err)
+
+	type facet struct {
+		FacetAddress      common.Address
+		FunctionSelectors [][4]byte
+	}
+
+	values := make([]facet, 0, len(facets))
+	for addr, selectors := range facets {
+		values = append(values, facet{FacetAddress: addr, FunctionSelectors: selectors})
+	}
+
+	result, err := parsed.Methods["facets"].Outputs.Pack(values)
+	require.NoError(t, err)
+	return result
+}
+
+func TestDiscoverProxy(t *testing.T) {
+	minimalProxy := common.FromHex("0x363d3d373d3d3d363d73" + strings.TrimPrefix(implementationAddr.Hex(), "0x") + "5af43d82803e903d91602b57fd5bf3")
+	diamond := encodeFacets(t, map[common.Address][][4]byte{facetAddr: {{0x7a, 0x0e, 0xd6, 0x27}, {0xcd, 0xff, 0xac, 0xc6}}})
+
+	testCases := []struct {
+		name     string
+		code     []byte
+		storage  map[common.Hash]common.Address
+		accounts map[common.Address][]byte
+		expected *ProxyDescriptor
+	}{
+		{
+			name:     "Not A Proxy",
+			code:     []byte{0x00},
+			expected: nil,
+		},
+		{
+			name:     "Minimal Proxy",
+			code:     minimalProxy,
+			expected: &ProxyDescriptor{Type: ProxyTypeMinimal, Implementation: implementationAddr},
+		},
+		{
+			name:     "EIP-1967 Proxy",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{eip1967ImplementationSlot: implementationAddr},
+			expected: &ProxyDescriptor{Type: ProxyTypeEip1967, Implementation: implementationAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name: "Transparent Proxy",
+			code: []byte{0x00},
+			storage: map[common.Hash]common.Address{
+				eip1967ImplementationSlot: implementationAddr,
+				eip1967AdminSlot:          adminAddr,
+			},
+			expected: &ProxyDescriptor{Type: ProxyTypeTransparent, Implementation: implementationAddr, Admin: adminAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name:     "UUPS Proxy",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{eip1967ImplementationSlot: implementationAddr},
+			accounts: map[common.Address][]byte{implementationAddr: returning(eip1967ImplementationSlot.Bytes())},
+			expected: &ProxyDescriptor{Type: ProxyTypeUups, Implementation: implementationAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name:     "Implementation With Other Proxiable UUID",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{eip1967ImplementationSlot: implementationAddr},
+			accounts: map[common.Address][]byte{implementationAddr: returning(eip1822ProxiableSlot.Bytes())},
+			expected: &ProxyDescriptor{Type: ProxyTypeEip1967, Implementation: implementationAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name:     "Beacon Proxy",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{eip1967BeaconSlot: beaconAddr},
+			accounts: map[common.Address][]byte{beaconAddr: returning(common.LeftPadBytes(implementationAddr.Bytes(), 32))},
+			expected: &ProxyDescriptor{Type: ProxyTypeBeacon, Implementation: implementationAddr, Beacon: beaconAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name:     "ERC-1822 Proxy",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{eip1822ProxiableSlot: implementationAddr},
+			expected: &ProxyDescriptor{Type: ProxyTypeUups, Implementation: implementationAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name:     "ZeppelinOS Proxy",
+			code:     []byte{0x00},
+			storage:  map[common.Hash]common.Address{zeppelinOSImplementationSlot: implementationAddr},
+			expected: &ProxyDescriptor{Type: ProxyTypeZeppelinOS, Implementation: implementationAddr, Upgrades: []ProxyUpgrade{}},
+		},
+		{
+			name: "Diamond",
+			code: returning(diamond),
+			expected: &ProxyDescriptor{
+				Type:   ProxyTypeDiamond,
+				Facets: []ProxyFacet{{Address: facetAddr, Selectors: []string{"7a0ed627", "cdffacc6"}}},
+			},
+		},
+		{
+			name:     "Fallback Returning A Word",
+			code:     returning(common.LeftPadBytes([]byte{0x01}, 32)),
+			expected: nil,
+		},
+		{
+			name:     "Fallback Returning No Facets",
+			code:     returning(encodeFacets(t, map[common.Address][][4]byte{})),
+			expected: nil,
+		},
+		{
+			name:     "Facet Without Address",
+			code:     returning(encodeFacets(t, map[common.Address][][4]byte{{}: {{0x7a, 0x0e, 0xd6, 0x27}}})),
+			expected: nil,
+		},
+		{
+			name:     "Facet Without Selectors",
+			code:     returning(encodeFacets(t, map[common.Address][][4]byte{facetAddr: {}})),
+			expected: nil,
+		},
+		{
+			name:     "Facets With Trailing Data",
+			code:     returning(append(common.CopyBytes(diamond), make([]byte, 32)...)),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sim, err := simulator.NewSimulator(ctx, utils.Ethereum, nil, simulator.NewDefaultOptions())
+			require.NoError(t, err)
+			defer sim.Close()
+
+			require.NoError(t, sim.SetCode(proxyAddr, tc.code))
+			for slot, value := range tc.storage {
+				require.NoError(t, sim.SetStorageAt(proxyAddr, slot, common.BytesToHash(value.Bytes())))
+			}
+			for addr, code := range tc.accounts {
+				require.NoError(t, sim.SetCode(addr, code))
+			}
+
+			contract := &Contract{
+				ctx:        ctx,
+				client:     sim.GetClient(),
+				addr:       proxyAddr,
+				network:    utils.Ethereum,
+				descriptor: &Descriptor{DeployedBytecode: tc.code},
+			}
+
+			require.NoError(t, contract.DiscoverProxy(ctx))
+
+			descriptor := contract.GetDescriptor()
+			if tc.expected == nil {
+				assert.False(t, descriptor.IsProxy())
+				assert.Nil(t, descriptor.ProxyDescriptor)
+				return
+			}
+
+			assert.True(t, descriptor.IsProxy())
+			assert.Equal(t, tc.expected, descriptor.ProxyDescriptor)
+			assert.Equal(t, tc.expected.GetImplementations(), descriptor.Implementations)
+		})
+	}
+}
+
+func TestDecodeProxyUpgrades(t *testing.T) {
+	first := common.HexToHash("0x01")
+	second := common.HexToHash("0x02")
+
+	logs := []types.Log{
+		{
+			Topics:      []common.Hash{upgradedTopic, common.BytesToHash(implementationAddr.Bytes())},
+			BlockNumber: 10,
+			TxHash:      first,
+		},
+		{
+			// Logs without the indexed argument are not upgrades.
+			Topics:      []common.Hash{upgradedTopic},
+			BlockNumber: 11,
+		},
+		{
+			// Logs removed by a reorg are ignored.
+			Topics:      []common.Hash{upgradedTopic, common.BytesToHash(adminAddr.Bytes())},
+			BlockNumber: 12,
+			Removed:     true,
+		},
+		{
+			Topics:      []common.Hash{beaconUpgradedTopic, common.BytesToHash(beaconAddr.Bytes())},
+			BlockNumber: 13,
+			TxHash:      second,
+		},
+	}
+
+	assert.Equal(t, []ProxyUpgrade{
+		{Implementation: implementationAddr, BlockNumber: 10, TxHash: first},
+		{Implementation: beaconAddr, Beacon: true, BlockNumber: 13, TxHash: second},
+	}, decodeProxyUpgrades(logs))
+
+	assert.Empty(t, decodeProxyUpgrades(nil))
+}
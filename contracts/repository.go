@@ -0,0 +1,73 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/metadata"
+	"go.uber.org/zap"
+)
+
+// repositoryProviderName is the name of the source provider for contracts loaded from a local repository.
+const repositoryProviderName = "sourcify"
+
+// DiscoverSourceCodeFromRepository loads the source code and metadata of the contract from a Sourcify compatible
+// local repository instead of Etherscan (or another configured source code provider). It enriches the contract's
+// descriptor the same way DiscoverSourceCode does, using the compiler settings found within the metadata.
+func (c *Contract) DiscoverSourceCodeFromRepository(ctx context.Context, repository *metadata.Repository) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		if repository == nil {
+			return fmt.Errorf("repository is nil")
+		}
+
+		entry, err := repository.Get(c.descriptor.NetworkID, c.addr)
+		if err != nil {
+			return fmt.Errorf("failed to get contract source code from repository: %w", err)
+		}
+
+		sources, err := solgo.NewSourcesFromRepository(repository, c.descriptor.NetworkID, c.addr)
+		if err != nil {
+			zap.L().Error(
+				"failed to create new sources from repository",
+				zap.Error(err),
+				zap.String("network", c.network.String()),
+				zap.String("contract_address", c.addr.String()),
+			)
+			return fmt.Errorf("failed to create new sources from repository: %s", err)
+		}
+
+		md := entry.GetMetadata()
+		abi, err := md.AbiToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to encode contract abi from repository metadata: %s", err)
+		}
+
+		unsorted, err := solgo.NewSourcesFromMetadataWithErrors(md)
+		if err != nil {
+			return fmt.Errorf("failed to create new sources from repository metadata: %s", err)
+		}
+
+		c.descriptor.Sources = sources
+		c.descriptor.SourcesUnsorted = unsorted
+		c.descriptor.Name = sources.EntrySourceUnitName
+		c.descriptor.CompilerVersion = "v" + strings.TrimPrefix(md.Compiler.Version, "v")
+		c.descriptor.Optimized = md.Settings.Optimizer.Enabled
+		c.descriptor.OptimizationRuns = uint64(md.Settings.Optimizer.Runs)
+		c.descriptor.EVMVersion = md.Settings.EvmVersion
+		c.descriptor.ABI = abi
+		c.descriptor.SourceProvider = repositoryProviderName
+		c.descriptor.Verified = true
+		c.descriptor.VerificationProvider = repositoryProviderName
+
+		for path := range md.Settings.CompilationTarget {
+			c.descriptor.License = md.Sources[path].License
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Branches {
+    uint256 value;
+
+    function pick(bool flag, uint256 amount) public {
+        if (flag) {
+            value = 1;
+        } else if (amount > value) {
+            value = amount;
+        } else {
+            value = 2;
+        }
+    }
+}
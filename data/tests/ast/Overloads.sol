@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library MathLib {
+    function triple(uint256 a) internal pure returns (uint256) {
+        return a * 3;
+    }
+}
+
+using {MathLib.triple} for uint256;
+
+contract Overloads {
+    uint256 total;
+
+    function settle(uint256 amount) internal {
+        total = amount.triple();
+    }
+
+    function settle(address account) internal {}
+
+    function run(address account) public {
+        settle(account);
+    }
+}
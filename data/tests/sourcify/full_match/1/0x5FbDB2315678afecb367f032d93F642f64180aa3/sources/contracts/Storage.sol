@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+import "./Ownable.sol";
+
+contract Storage is Ownable {
+    uint256 private number;
+
+    event NumberStored(uint256 number);
+
+    function store(uint256 num) public onlyOwner {
+        number = num;
+        emit NumberStored(num);
+    }
+
+    function retrieve() public view returns (uint256) {
+        return number;
+    }
+}
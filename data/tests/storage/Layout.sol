@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IERC20 {
+    function balanceOf(address account) external view returns (uint256);
+}
+
+contract Ownable {
+    address private _owner;
+    bool private _paused;
+}
+
+contract Layout is Ownable {
+    enum Status {
+        Pending,
+        Active,
+        Closed
+    }
+
+    struct Position {
+        uint128 amount;
+        uint64 since;
+        address owner;
+        uint256[] history;
+    }
+
+    uint8 public decimals;
+    Status public status;
+    IERC20 public token;
+    uint256 public totalSupply;
+    mapping(address => uint256) public balances;
+    mapping(address => mapping(address => uint256)) public allowances;
+    address[] public holders;
+    Position public position;
+    bool public initialized;
+    uint64[] public checkpoints;
+    mapping(uint256 => Position) public positions;
+    string public name;
+    bytes32 public root;
+    uint16 public fee;
+    address public treasury;
+}
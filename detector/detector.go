@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
-	"github.com/unpackdev/solgo/cfg"
+	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/0x19/solc-switch"
 	"github.com/unpackdev/solgo"
 	"github.com/unpackdev/solgo/abi"
 	"github.com/unpackdev/solgo/ast"
 	"github.com/unpackdev/solgo/audit"
+	"github.com/unpackdev/solgo/audit/detectors"
+	"github.com/unpackdev/solgo/cfg"
 	"github.com/unpackdev/solgo/ir"
 	"github.com/unpackdev/solgo/opcode"
 	"github.com/unpackdev/solgo/standards"
@@ -143,11 +146,71 @@ func (d *Detector) Build() error {
 	return d.builder.Build()
 }
 
-// Compile compiles the Solidity source code using the solc compiler and returns the errors encountered during the process.
+// Analyze runs the native static analysis detectors against the built IR and returns the audit report.
+// When the slither-analyzer package is installed, Slither is run as well and its issues are merged into the
+// report, taking precedence over native issues of the same check reported at the same location.
 func (d *Detector) Analyze() (*audit.Report, error) {
+	engine, err := detectors.NewEngine(d.ctx, d.GetIR())
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := engine.Analyze()
+	if err != nil {
+		return nil, err
+	}
+
 	if !d.auditor.IsReady() {
-		return nil, errors.New("auditor not ready - missing slither-analyzer pip3 python package")
+		return report, nil
+	}
+
+	slitherReport, err := d.auditor.Analyze()
+	if err != nil {
+		return nil, err
+	}
+
+	if slitherReport == nil || slitherReport.Results == nil {
+		return report, nil
+	}
+
+	slitherReport.Results.Detectors = mergeDetectors(slitherReport.Results.Detectors, report.Results.Detectors)
+	return slitherReport, nil
+}
+
+// mergeDetectors appends the native issues to the Slither ones, skipping those Slither already reports for the
+// same check in the same source unit and line range.
+func mergeDetectors(slither []audit.Detector, native []audit.Detector) []audit.Detector {
+	reported := make(map[string]bool)
+	for _, detector := range slither {
+		reported[detectorKey(detector)] = true
+	}
+
+	for _, detector := range native {
+		if !reported[detectorKey(detector)] {
+			slither = append(slither, detector)
+		}
+	}
+
+	return slither
+}
+
+// detectorKey identifies the issue by its check and the location of its first element. Slither and the native
+// detectors name source files differently, so only the base name of the source unit is used.
+func detectorKey(detector audit.Detector) string {
+	if len(detector.Elements) == 0 {
+		return detector.Check
+	}
+
+	mapping := detector.Elements[0].SourceMapping
+	filename := mapping.FilenameRelative
+	if filename == "" {
+		filename = mapping.FilenameShort
+	}
+
+	first, last := int32(0), int32(0)
+	if len(mapping.Lines) > 0 {
+		first, last = mapping.Lines[0], mapping.Lines[len(mapping.Lines)-1]
 	}
 
-	return d.auditor.Analyze()
+	return fmt.Sprintf("%s:%s:%d-%d", detector.Check, filepath.Base(filename), first, last)
 }
@@ -267,6 +267,14 @@ func TestDetectorFromSources(t *testing.T) {
 			err = detector.Build()
 			assert.NoError(t, err)
 
+			// Native detectors run without slither-analyzer being installed.
+			if !detector.GetAuditor().IsReady() {
+				report, err := detector.Analyze()
+				assert.NoError(t, err)
+				assert.NotNil(t, report)
+				assert.True(t, report.Success)
+			}
+
 			if testCase.opcodeTest {
 				opcodeData := []byte{0x60, 0x01, 0x60, 0x10, 0x01} // PUSH1 0x01 PUSH1 0x10 ADD
 				opcode, err := detector.GetOpcodes(opcodeData)
@@ -292,6 +300,31 @@ func TestDetectorFromSources(t *testing.T) {
 	}
 }
 
+func TestMergeDetectors(t *testing.T) {
+	finding := func(check string, filename string, lines ...int32) audit.Detector {
+		return audit.Detector{
+			Check: check,
+			Elements: []audit.Element{{
+				SourceMapping: audit.SourceMapping{FilenameRelative: filename, Lines: lines},
+			}},
+		}
+	}
+
+	slither := []audit.Detector{
+		finding("reentrancy-eth", "contracts/Vault.sol", 10, 11, 12),
+	}
+
+	native := []audit.Detector{
+		finding("reentrancy-eth", "Vault.sol", 10, 11, 12),
+		finding("reentrancy-eth", "Vault.sol", 20, 21),
+		finding("reentrancy-eth", "Token.sol", 10, 11, 12),
+		finding("tx-origin", "Vault.sol", 10, 11, 12),
+	}
+
+	merged := mergeDetectors(slither, native)
+	assert.Equal(t, []audit.Detector{slither[0], native[1], native[2], native[3]}, merged)
+}
+
 func buildFullPath(relativePath string) string {
 	absPath, _ := filepath.Abs(relativePath)
 	return absPath
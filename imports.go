@@ -0,0 +1,130 @@
+package solgo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// importRegex matches every import directive form supported by Solidity:
+//
+//	import "path";
+//	import "path" as Alias;
+//	import * as Alias from "path";
+//	import {A, B as C} from "path";
+var importRegex = regexp.MustCompile(
+	`\bimport\s+(?:` +
+		`["']([^"']+)["'](?:\s+as\s+([A-Za-z_$][\w$]*))?` +
+		`|\*\s*as\s+([A-Za-z_$][\w$]*)\s+from\s+["']([^"']+)["']` +
+		`|\{([^}]*)\}\s*from\s+["']([^"']+)["']` +
+		`)\s*;`,
+)
+
+// ImportSymbol represents a single symbol imported through `import {A as B} from "path";`.
+type ImportSymbol struct {
+	Name  string `yaml:"name" json:"name"`
+	Alias string `yaml:"alias,omitempty" json:"alias,omitempty"`
+}
+
+// ImportDirective represents a single import statement found in a source unit.
+// Path is the import path exactly as written in the source, before any remapping is applied.
+type ImportDirective struct {
+	Path      string          `yaml:"path" json:"path"`
+	UnitAlias string          `yaml:"unit_alias,omitempty" json:"unit_alias,omitempty"`
+	Symbols   []*ImportSymbol `yaml:"symbols,omitempty" json:"symbols,omitempty"`
+}
+
+// IsRelative returns true if the import path is relative to the importing source unit.
+func (i *ImportDirective) IsRelative() bool {
+	return strings.HasPrefix(i.Path, "./") || strings.HasPrefix(i.Path, "../")
+}
+
+// ExtractImportDirectives extracts all import directives from the provided Solidity source code.
+// Imports placed within comments are ignored.
+func ExtractImportDirectives(content string) []*ImportDirective {
+	matches := importRegex.FindAllStringSubmatch(stripComments(content), -1)
+
+	directives := make([]*ImportDirective, 0, len(matches))
+	for _, match := range matches {
+		switch {
+		case match[1] != "":
+			directives = append(directives, &ImportDirective{
+				Path:      match[1],
+				UnitAlias: match[2],
+			})
+		case match[4] != "":
+			directives = append(directives, &ImportDirective{
+				Path:      match[4],
+				UnitAlias: match[3],
+			})
+		case match[6] != "":
+			directives = append(directives, &ImportDirective{
+				Path:    match[6],
+				Symbols: parseImportSymbols(match[5]),
+			})
+		}
+	}
+
+	return directives
+}
+
+// parseImportSymbols parses the symbol list of an `import {A, B as C} from "path";` directive.
+func parseImportSymbols(list string) []*ImportSymbol {
+	var symbols []*ImportSymbol
+	for _, part := range strings.Split(list, ",") {
+		fields := strings.Fields(part)
+		switch {
+		case len(fields) == 1:
+			symbols = append(symbols, &ImportSymbol{Name: fields[0]})
+		case len(fields) == 3 && fields[1] == "as":
+			symbols = append(symbols, &ImportSymbol{Name: fields[0], Alias: fields[2]})
+		}
+	}
+	return symbols
+}
+
+// stripComments removes single-line and multi-line comments from the source code while
+// leaving string literals untouched. Line breaks are preserved.
+func stripComments(content string) string {
+	var builder strings.Builder
+	builder.Grow(len(content))
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '"' || c == '\'':
+			// Copy string literals verbatim, including escaped quotes.
+			builder.WriteByte(c)
+			for i++; i < len(content); i++ {
+				builder.WriteByte(content[i])
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+					builder.WriteByte(content[i])
+					continue
+				}
+				if content[i] == c || content[i] == '\n' {
+					break
+				}
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				builder.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i < len(content) && !(content[i] == '*' && i+1 < len(content) && content[i+1] == '/') {
+				if content[i] == '\n' {
+					builder.WriteByte('\n')
+				}
+				i++
+			}
+			i++
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,69 @@
+package solgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractImportDirectives(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected []*ImportDirective
+	}{
+		{
+			name:    "Plain Import",
+			content: `import "./Token.sol";`,
+			expected: []*ImportDirective{
+				{Path: "./Token.sol"},
+			},
+		},
+		{
+			name:    "Unit Alias Import",
+			content: `import '@solmate/tokens/ERC20.sol' as Solmate;`,
+			expected: []*ImportDirective{
+				{Path: "@solmate/tokens/ERC20.sol", UnitAlias: "Solmate"},
+			},
+		},
+		{
+			name:    "Wildcard Import",
+			content: `import * as Test from "forge-std/Test.sol";`,
+			expected: []*ImportDirective{
+				{Path: "forge-std/Test.sol", UnitAlias: "Test"},
+			},
+		},
+		{
+			name: "Symbol Import",
+			content: `import {
+				ERC20,
+				SafeTransferLib as STL
+			} from "solmate/utils/SafeTransferLib.sol";`,
+			expected: []*ImportDirective{
+				{
+					Path: "solmate/utils/SafeTransferLib.sol",
+					Symbols: []*ImportSymbol{
+						{Name: "ERC20"},
+						{Name: "SafeTransferLib", Alias: "STL"},
+					},
+				},
+			},
+		},
+		{
+			name: "Commented Imports",
+			content: `// import "./Commented.sol";
+			/* import {A} from "./Block.sol"; */
+			import "./Active.sol";
+			string constant PATH = "import \"./String.sol\";";`,
+			expected: []*ImportDirective{
+				{Path: "./Active.sol"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, ExtractImportDirectives(testCase.content))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// InlineAssembly represents an inline assembly statement in the IR.
+// Yul is not lowered any further, instead the functions called within the assembly block are collected
+// so that analyses can reason about opcodes such as sstore, call or delegatecall.
+type InlineAssembly struct {
+	Unit            *ast.Yul                `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Calls           []string                `json:"calls"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the inline assembly statement.
+func (e *InlineAssembly) GetAST() *ast.Yul {
+	return e.Unit
+}
+
+// GetId returns the ID of the inline assembly statement.
+func (e *InlineAssembly) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the inline assembly statement.
+func (e *InlineAssembly) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the inline assembly statement.
+func (e *InlineAssembly) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the inline assembly statement.
+func (e *InlineAssembly) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetCalls returns the names of the Yul functions and builtins called within the assembly block, in order of appearance.
+func (e *InlineAssembly) GetCalls() []string {
+	return e.Calls
+}
+
+// HasCall returns true if the Yul function or builtin with the provided name is called within the assembly block.
+func (e *InlineAssembly) HasCall(name string) bool {
+	for _, call := range e.Calls {
+		if call == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodes returns the nodes of the statement.
+func (e *InlineAssembly) GetNodes() []Statement {
+	return nil
+}
+
+// GetTypeDescription returns the type description of the inline assembly statement.
+func (e *InlineAssembly) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the inline assembly statement.
+func (e *InlineAssembly) ToProto() *v3.TypedStruct {
+	calls := make([]interface{}, 0, len(e.GetCalls()))
+	for _, call := range e.GetCalls() {
+		calls = append(calls, call)
+	}
+
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"calls":           calls,
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processInlineAssembly processes the inline assembly statement and returns the InlineAssembly.
+func (b *Builder) processInlineAssembly(unit *ast.Yul) *InlineAssembly {
+	toReturn := &InlineAssembly{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Calls:           make([]string, 0),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	if unit.GetBody() != nil {
+		toReturn.Calls = collectYulCalls(unit.GetNodes(), toReturn.Calls)
+	}
+
+	return toReturn
+}
+
+// collectYulCalls recursively collects the names of the Yul functions called within the nodes.
+func collectYulCalls(nodes []ast.Node[ast.NodeType], calls []string) []string {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		if call, ok := node.(*ast.YulFunctionCallStatement); ok && call.GetFunctionName() != nil {
+			calls = append(calls, call.GetFunctionName().GetName())
+		}
+
+		calls = collectYulCalls(node.GetNodes(), calls)
+	}
+
+	return calls
+}
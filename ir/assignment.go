@@ -0,0 +1,187 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// AssignmentTarget represents a variable written to by an assignment or by an increment/decrement operation.
+type AssignmentTarget struct {
+	Name                    string `json:"name"`
+	ReferencedDeclarationId int64  `json:"referencedDeclarationId"`
+	StateVariable           bool   `json:"stateVariable"`  // Target is a contract state variable.
+	StoragePointer          bool   `json:"storagePointer"` // Target is a local variable pointing to storage.
+}
+
+// IsStateWrite returns true if writing to the target modifies contract storage.
+func (t *AssignmentTarget) IsStateWrite() bool {
+	return t.StateVariable || t.StoragePointer
+}
+
+// toValue converts the assignment target into a value that can be used as a field of NewTypedStructFromMap.
+func (t *AssignmentTarget) toValue() map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    t.Name,
+		"referencedDeclarationId": t.ReferencedDeclarationId,
+		"stateVariable":           t.StateVariable,
+		"storagePointer":          t.StoragePointer,
+	}
+}
+
+// Assignment represents an assignment statement in the IR.
+type Assignment struct {
+	Unit            *ast.Assignment         `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Operator        ast_pb.Operator         `json:"operator"`
+	Targets         []*AssignmentTarget     `json:"targets"`
+	Statements      []Statement             `json:"statements"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the assignment statement.
+func (e *Assignment) GetAST() *ast.Assignment {
+	return e.Unit
+}
+
+// GetId returns the ID of the assignment statement.
+func (e *Assignment) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the assignment statement.
+func (e *Assignment) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the assignment statement.
+func (e *Assignment) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the assignment statement.
+func (e *Assignment) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetOperator returns the operator of the assignment statement.
+func (e *Assignment) GetOperator() ast_pb.Operator {
+	return e.Operator
+}
+
+// GetTargets returns the variables the assignment statement writes to.
+func (e *Assignment) GetTargets() []*AssignmentTarget {
+	return e.Targets
+}
+
+// IsStateWrite returns true if the assignment statement modifies contract storage.
+func (e *Assignment) IsStateWrite() bool {
+	for _, target := range e.Targets {
+		if target.IsStateWrite() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodes returns the function calls made while evaluating the assignment statement.
+func (e *Assignment) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the assignment statement.
+func (e *Assignment) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the assignment statement.
+func (e *Assignment) ToProto() *v3.TypedStruct {
+	targets := make([]interface{}, 0, len(e.GetTargets()))
+	for _, target := range e.GetTargets() {
+		targets = append(targets, target.toValue())
+	}
+
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"operator":        e.GetOperator().String(),
+		"targets":         targets,
+		"statements":      statementsToValue(e.GetNodes()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processAssignment processes the assignment statement and returns the Assignment.
+// Assignments used as statements wrap the actual assignment expression, which is unwrapped here.
+func (b *Builder) processAssignment(fn *Function, unit *ast.Assignment) *Assignment {
+	expr := unit
+	for {
+		inner, ok := expr.GetExpression().(*ast.Assignment)
+		if !ok || inner == nil {
+			break
+		}
+		expr = inner
+	}
+
+	toReturn := &Assignment{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            ast_pb.NodeType_ASSIGNMENT,
+		Operator:        expr.GetOperator(),
+		Targets:         b.resolveAssignmentTargets(expr.GetLeftExpression()),
+		Statements:      b.processExpressionCalls(fn, expr.GetRightExpression(), expr.GetLeftExpression()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	return toReturn
+}
+
+// resolveAssignmentTargets resolves the variables the left hand side expression of an assignment refers to.
+// Index and member accesses resolve to their base variable, while tuples resolve to each of their components.
+func (b *Builder) resolveAssignmentTargets(node ast.Node[ast.NodeType]) []*AssignmentTarget {
+	toReturn := make([]*AssignmentTarget, 0)
+
+	switch expr := node.(type) {
+	case *ast.PrimaryExpression:
+		if expr == nil || expr.GetName() == "" {
+			return toReturn
+		}
+
+		target := &AssignmentTarget{
+			Name:                    expr.GetName(),
+			ReferencedDeclarationId: expr.GetReferencedDeclaration(),
+		}
+
+		if target.ReferencedDeclarationId > 0 && b.astBuilder.GetTree().GetRoot() != nil {
+			switch declaration := b.astBuilder.GetTree().GetById(target.ReferencedDeclarationId).(type) {
+			case *ast.StateVariableDeclaration:
+				target.StateVariable = true
+			case *ast.Declaration:
+				target.StoragePointer = declaration.GetStorageLocation() == ast_pb.StorageLocation_STORAGE
+			case *ast.VariableDeclaration:
+				// Local variables reference the declaration statement rather than the declaration itself.
+				for _, local := range declaration.GetDeclarations() {
+					if local != nil && local.GetName() == target.Name {
+						target.StoragePointer = local.GetStorageLocation() == ast_pb.StorageLocation_STORAGE
+					}
+				}
+			}
+		}
+
+		toReturn = append(toReturn, target)
+	case *ast.IndexAccess:
+		toReturn = append(toReturn, b.resolveAssignmentTargets(expr.GetBaseExpression())...)
+	case *ast.MemberAccessExpression:
+		toReturn = append(toReturn, b.resolveAssignmentTargets(expr.GetExpression())...)
+	case *ast.TupleExpression:
+		for _, component := range expr.GetComponents() {
+			toReturn = append(toReturn, b.resolveAssignmentTargets(component)...)
+		}
+	}
+
+	return toReturn
+}
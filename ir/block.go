@@ -0,0 +1,88 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Block represents a nested block statement in the IR, including unchecked blocks.
+type Block struct {
+	Unit            *ast.BodyNode           `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Unchecked       bool                    `json:"unchecked"`
+	Body            *Body                   `json:"body"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the block statement.
+func (e *Block) GetAST() *ast.BodyNode {
+	return e.Unit
+}
+
+// GetId returns the ID of the block statement.
+func (e *Block) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the block statement.
+func (e *Block) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the block statement.
+func (e *Block) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the block statement.
+func (e *Block) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// IsUnchecked returns true if arithmetic within the block is unchecked.
+func (e *Block) IsUnchecked() bool {
+	return e.Unchecked
+}
+
+// GetBody returns the body of the block statement.
+func (e *Block) GetBody() *Body {
+	return e.Body
+}
+
+// GetNodes returns the statements of the block.
+func (e *Block) GetNodes() []Statement {
+	return e.Body.GetStatements()
+}
+
+// GetTypeDescription returns the type description of the block statement.
+func (e *Block) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the block statement.
+func (e *Block) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"unchecked":       e.IsUnchecked(),
+		"body":            bodyToValue(e.GetBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processBlock processes the nested block statement and returns the Block.
+func (b *Builder) processBlock(fn *Function, unit *ast.BodyNode) *Block {
+	return &Block{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Unchecked:       unit.GetType() == ast_pb.NodeType_UNCHECKED_BLOCK,
+		Body:            b.processFunctionBody(fn, unit),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
@@ -1,6 +1,8 @@
 package ir
 
 import (
+	"sort"
+
 	v3 "github.com/cncf/xds/go/xds/type/v3"
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
 	ir_pb "github.com/unpackdev/protos/dist/go/ir"
@@ -88,8 +90,10 @@ func (e *Body) ToProto() *ir_pb.Body {
 	return proto
 }
 
-// processFunctionBody processes the body of a function and returns
-// its intermediate representation. Currently, it only processes function call statements.
+// processFunctionBody processes the body of a function and returns its intermediate representation.
+// Every statement of the body, including nested blocks and bodies of control flow statements, is lowered
+// into its IR counterpart. Statements the IR does not model explicitly are kept as expression statements.
+// Statements are lowered in source order, as the AST appends unchecked blocks after the remaining statements.
 func (b *Builder) processFunctionBody(fn *Function, unit *ast.BodyNode) *Body {
 	body := &Body{
 		Unit:       unit,
@@ -99,12 +103,144 @@ func (b *Builder) processFunctionBody(fn *Function, unit *ast.BodyNode) *Body {
 		Statements: make([]Statement, 0),
 	}
 
+	statements := make([]ast.Node[ast.NodeType], 0, len(unit.GetNodes()))
 	for _, statement := range unit.GetNodes() {
-		switch stmt := statement.(type) {
-		case *ast.FunctionCall:
-			body.Statements = append(body.Statements, b.processFunctionCall(fn, stmt))
+		if statement != nil {
+			statements = append(statements, statement)
 		}
 	}
 
+	sort.SliceStable(statements, func(i, j int) bool {
+		return statements[i].GetSrc().GetStart() < statements[j].GetSrc().GetStart()
+	})
+
+	for _, statement := range statements {
+		body.Statements = append(body.Statements, b.processStatement(fn, statement)...)
+	}
+
 	return body
 }
+
+// processStatement lowers a single AST statement into its IR counterpart. Function calls nested within
+// the arguments of a function call statement are lowered before the call itself, in evaluation order,
+// which is why more than one statement can be returned.
+func (b *Builder) processStatement(fn *Function, unit ast.Node[ast.NodeType]) []Statement {
+	if unit == nil {
+		return nil
+	}
+
+	switch stmt := unit.(type) {
+	case *ast.FunctionCall:
+		toReturn := b.processExpressionCalls(fn, stmt.GetNodes()...)
+		return append(toReturn, b.processFunctionCall(fn, stmt))
+	case *ast.Assignment:
+		return []Statement{b.processAssignment(fn, stmt)}
+	case *ast.VariableDeclaration:
+		return []Statement{b.processVariableDeclaration(fn, stmt)}
+	case *ast.IfStatement:
+		return []Statement{b.processIf(fn, stmt)}
+	case *ast.ForStatement:
+		return []Statement{b.processFor(fn, stmt)}
+	case *ast.WhileStatement:
+		return []Statement{b.processWhile(fn, stmt)}
+	case *ast.DoWhileStatement:
+		return []Statement{b.processDoWhile(fn, stmt)}
+	case *ast.Emit:
+		return []Statement{b.processEmit(fn, stmt)}
+	case *ast.RevertStatement:
+		return []Statement{b.processRevert(fn, stmt)}
+	case *ast.ReturnStatement:
+		return []Statement{b.processReturn(fn, stmt)}
+	case *ast.TryStatement:
+		return []Statement{b.processTry(fn, stmt)}
+	case *ast.BodyNode:
+		return []Statement{b.processBlock(fn, stmt)}
+	case *ast.Yul:
+		return []Statement{b.processInlineAssembly(stmt)}
+	case *ast.BreakStatement:
+		return []Statement{b.processBreak(stmt)}
+	case *ast.ContinueStatement:
+		return []Statement{b.processContinue(stmt)}
+	default:
+		return []Statement{b.processExpressionStatement(fn, stmt)}
+	}
+}
+
+// processExpressionCalls lowers all function calls found within the provided expression nodes.
+// Calls are returned in evaluation order, meaning that calls nested within arguments come before the call itself.
+func (b *Builder) processExpressionCalls(fn *Function, nodes ...ast.Node[ast.NodeType]) []Statement {
+	toReturn := make([]Statement, 0)
+
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		toReturn = append(toReturn, b.processExpressionCalls(fn, node.GetNodes()...)...)
+
+		if call, ok := node.(*ast.FunctionCall); ok {
+			toReturn = append(toReturn, b.processFunctionCall(fn, call))
+		}
+	}
+
+	return toReturn
+}
+
+// processNestedBody lowers the body of a control flow statement, returning nil if the statement has no body.
+func (b *Builder) processNestedBody(fn *Function, unit ast.Node[ast.NodeType]) *Body {
+	if body, ok := unit.(*ast.BodyNode); ok && body != nil {
+		return b.processFunctionBody(fn, body)
+	}
+	return nil
+}
+
+// typeDescriptionToProto converts the type description into its protocol buffer representation,
+// returning nil if the type description is not set.
+func typeDescriptionToProto(td *ast.TypeDescription) *ast_pb.TypeDescription {
+	if td == nil {
+		return nil
+	}
+	return td.ToProto()
+}
+
+// resolveExpressionName resolves the name and the referenced declaration of an identifier or a member access
+// expression, such as the event of an emit statement or the error of a revert statement.
+func resolveExpressionName(node ast.Node[ast.NodeType]) (string, int64) {
+	switch expr := node.(type) {
+	case *ast.PrimaryExpression:
+		if expr != nil {
+			return expr.GetName(), expr.GetReferencedDeclaration()
+		}
+	case *ast.MemberAccessExpression:
+		if expr != nil {
+			return expr.GetMemberName(), expr.GetReferencedDeclaration()
+		}
+	}
+	return "", 0
+}
+
+// argumentTypesToProto converts the type descriptions of the arguments into their protocol buffer representation.
+func argumentTypesToProto(arguments []ast.Node[ast.NodeType]) []*ast_pb.TypeDescription {
+	toReturn := make([]*ast_pb.TypeDescription, 0, len(arguments))
+	for _, arg := range arguments {
+		if arg == nil {
+			continue
+		}
+		if td := typeDescriptionToProto(arg.GetTypeDescription()); td != nil {
+			toReturn = append(toReturn, td)
+		}
+	}
+	return toReturn
+}
+
+// typeDescriptionsToValue converts the type descriptions into a list value that can be used as a field of
+// NewTypedStructFromMap.
+func typeDescriptionsToValue(tds []*ast_pb.TypeDescription) []interface{} {
+	toReturn := make([]interface{}, 0, len(tds))
+	for _, td := range tds {
+		if value := protoToValue(td); value != nil {
+			toReturn = append(toReturn, value)
+		}
+	}
+	return toReturn
+}
@@ -0,0 +1,283 @@
+package ir
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestBodyStatementLowering(t *testing.T) {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name: "Vault",
+				Path: "Vault.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IReceiver {
+    function receiveFunds(uint256 amount) external returns (bool);
+}
+
+contract Vault {
+    struct Account {
+        uint256 balance;
+    }
+
+    mapping(address => Account) public accounts;
+    uint256 public total;
+    uint256 public counter;
+
+    event Withdrawn(address indexed owner, uint256 amount);
+    error InsufficientBalance(uint256 available);
+
+    function withdraw(uint256 amount) public returns (uint256) {
+        Account storage account = accounts[msg.sender];
+        if (account.balance < amount) {
+            revert InsufficientBalance(account.balance);
+        }
+
+        for (uint256 i = 0; i < 3; i++) {
+            if (i == 2) {
+                break;
+            }
+            continue;
+        }
+
+        uint256 j = 0;
+        while (j < 2) {
+            j++;
+        }
+
+        do {
+            j--;
+        } while (j > 0);
+
+        unchecked {
+            account.balance -= amount;
+        }
+
+        total = total - amount;
+        counter++;
+
+        try IReceiver(msg.sender).receiveFunds(amount) returns (bool ok) {
+            require(ok, "not received");
+        } catch {
+            total = total + amount;
+        }
+
+        assembly {
+            sstore(0, 1)
+        }
+
+        emit Withdrawn(msg.sender, amount);
+        delete accounts[msg.sender];
+        return total;
+    }
+}`,
+			},
+		},
+		EntrySourceUnitName: "Vault",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	builder, err := NewBuilderFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+	assert.Empty(t, builder.Parse())
+	require.NoError(t, builder.Build())
+
+	contract := builder.GetRoot().GetContractByName("Vault")
+	require.NotNil(t, contract)
+
+	var withdraw *Function
+	for _, fn := range contract.GetFunctions() {
+		if fn.GetName() == "withdraw" {
+			withdraw = fn
+		}
+	}
+	require.NotNil(t, withdraw)
+
+	statements := withdraw.GetBody().GetStatements()
+
+	testCases := []struct {
+		name  string
+		index int
+		check func(t *testing.T, statement Statement)
+	}{
+		{
+			name:  "Storage Pointer Declaration",
+			index: 0,
+			check: func(t *testing.T, statement Statement) {
+				declaration, ok := statement.(*VariableDeclaration)
+				require.True(t, ok)
+				assert.True(t, declaration.IsInitialized())
+				require.Len(t, declaration.GetDeclarations(), 1)
+				assert.Equal(t, "account", declaration.GetDeclarations()[0].Name)
+				assert.Equal(t, ast_pb.StorageLocation_STORAGE, declaration.GetDeclarations()[0].StorageLocation)
+			},
+		},
+		{
+			name:  "If With Revert",
+			index: 1,
+			check: func(t *testing.T, statement Statement) {
+				ifStmt, ok := statement.(*If)
+				require.True(t, ok)
+				require.NotNil(t, ifStmt.GetBody())
+				require.Len(t, ifStmt.GetBody().GetStatements(), 1)
+				revert, ok := ifStmt.GetBody().GetStatements()[0].(*Revert)
+				require.True(t, ok)
+				assert.Equal(t, "InsufficientBalance", revert.GetErrorName())
+				assert.Len(t, revert.GetArgumentTypes(), 1)
+				assert.Nil(t, ifStmt.GetFalseBody())
+			},
+		},
+		{
+			name:  "For With Break And Continue",
+			index: 2,
+			check: func(t *testing.T, statement Statement) {
+				forStmt, ok := statement.(*For)
+				require.True(t, ok)
+				require.Len(t, forStmt.GetInitialiser(), 1)
+				assert.IsType(t, &VariableDeclaration{}, forStmt.GetInitialiser()[0])
+				require.Len(t, forStmt.GetClosure(), 1)
+				assert.IsType(t, &ExpressionStatement{}, forStmt.GetClosure()[0])
+				require.Len(t, forStmt.GetBody().GetStatements(), 2)
+				nested, ok := forStmt.GetBody().GetStatements()[0].(*If)
+				require.True(t, ok)
+				assert.IsType(t, &Break{}, nested.GetBody().GetStatements()[0])
+				assert.IsType(t, &Continue{}, forStmt.GetBody().GetStatements()[1])
+			},
+		},
+		{
+			name:  "While Loop",
+			index: 4,
+			check: func(t *testing.T, statement Statement) {
+				whileStmt, ok := statement.(*While)
+				require.True(t, ok)
+				assert.Len(t, whileStmt.GetBody().GetStatements(), 1)
+			},
+		},
+		{
+			name:  "Do While Loop",
+			index: 5,
+			check: func(t *testing.T, statement Statement) {
+				doWhile, ok := statement.(*DoWhile)
+				require.True(t, ok)
+				assert.Len(t, doWhile.GetBody().GetStatements(), 1)
+			},
+		},
+		{
+			name:  "Unchecked Block With Storage Pointer Write",
+			index: 6,
+			check: func(t *testing.T, statement Statement) {
+				block, ok := statement.(*Block)
+				require.True(t, ok)
+				assert.True(t, block.IsUnchecked())
+				require.Len(t, block.GetNodes(), 1)
+				assignment, ok := block.GetNodes()[0].(*Assignment)
+				require.True(t, ok)
+				assert.Equal(t, ast_pb.Operator_MINUS_EQUAL, assignment.GetOperator())
+				require.Len(t, assignment.GetTargets(), 1)
+				assert.True(t, assignment.GetTargets()[0].StoragePointer)
+				assert.True(t, assignment.IsStateWrite())
+			},
+		},
+		{
+			name:  "State Variable Assignment",
+			index: 7,
+			check: func(t *testing.T, statement Statement) {
+				assignment, ok := statement.(*Assignment)
+				require.True(t, ok)
+				require.Len(t, assignment.GetTargets(), 1)
+				assert.Equal(t, "total", assignment.GetTargets()[0].Name)
+				assert.True(t, assignment.GetTargets()[0].StateVariable)
+			},
+		},
+		{
+			name:  "State Variable Increment",
+			index: 8,
+			check: func(t *testing.T, statement Statement) {
+				expr, ok := statement.(*ExpressionStatement)
+				require.True(t, ok)
+				assert.Equal(t, ast_pb.NodeType_EXPRESSION_STATEMENT, expr.GetNodeType())
+				assert.True(t, expr.IsStateWrite())
+			},
+		},
+		{
+			name:  "Try Catch",
+			index: 9,
+			check: func(t *testing.T, statement Statement) {
+				try, ok := statement.(*Try)
+				require.True(t, ok)
+				assert.NotEmpty(t, try.GetCall())
+				require.NotNil(t, try.GetBody())
+				assert.IsType(t, &FunctionCall{}, try.GetBody().GetStatements()[0])
+				require.Len(t, try.GetClauses(), 1)
+				assert.IsType(t, &Assignment{}, try.GetClauses()[0].GetNodes()[0])
+			},
+		},
+		{
+			name:  "Inline Assembly",
+			index: 10,
+			check: func(t *testing.T, statement Statement) {
+				assembly, ok := statement.(*InlineAssembly)
+				require.True(t, ok)
+				assert.True(t, assembly.HasCall("sstore"))
+			},
+		},
+		{
+			name:  "Emit",
+			index: 11,
+			check: func(t *testing.T, statement Statement) {
+				emit, ok := statement.(*Emit)
+				require.True(t, ok)
+				assert.Equal(t, "Withdrawn", emit.GetEventName())
+				assert.Len(t, emit.GetArgumentTypes(), 2)
+			},
+		},
+		{
+			name:  "Delete",
+			index: 12,
+			check: func(t *testing.T, statement Statement) {
+				expr, ok := statement.(*ExpressionStatement)
+				require.True(t, ok)
+				require.Len(t, expr.GetTargets(), 1)
+				assert.Equal(t, "accounts", expr.GetTargets()[0].Name)
+				assert.True(t, expr.IsStateWrite())
+			},
+		},
+		{
+			name:  "Return",
+			index: 13,
+			check: func(t *testing.T, statement Statement) {
+				ret, ok := statement.(*Return)
+				require.True(t, ok)
+				assert.Empty(t, ret.GetNodes())
+			},
+		},
+	}
+
+	require.Len(t, statements, 14)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statement := statements[testCase.index]
+			testCase.check(t, statement)
+
+			proto := statement.ToProto()
+			require.NotNil(t, proto)
+
+			// Type URLs name registered messages.
+			_, err := protoregistry.GlobalTypes.FindMessageByURL(proto.GetTypeUrl())
+			assert.NoError(t, err, proto.GetTypeUrl())
+			assert.Equal(t, float64(statement.GetId()), proto.GetValue().GetFields()["id"].GetNumberValue())
+		})
+	}
+
+	assert.NotNil(t, withdraw.GetBody().ToProto())
+}
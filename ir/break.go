@@ -0,0 +1,72 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Break represents a break statement in the IR.
+type Break struct {
+	Unit            *ast.BreakStatement     `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the break statement.
+func (e *Break) GetAST() *ast.BreakStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the break statement.
+func (e *Break) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the break statement.
+func (e *Break) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the break statement.
+func (e *Break) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the break statement.
+func (e *Break) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetNodes returns the nodes of the statement.
+func (e *Break) GetNodes() []Statement {
+	return nil
+}
+
+// GetTypeDescription returns the type description of the break statement.
+func (e *Break) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the break statement.
+func (e *Break) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processBreak processes the break statement and returns the Break.
+func (b *Builder) processBreak(unit *ast.BreakStatement) *Break {
+	return &Break{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
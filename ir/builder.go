@@ -74,6 +74,29 @@ func NewBuilderFromJSON(ctx context.Context, data []byte) (*Builder, error) {
 	}, nil
 }
 
+// NewBuilderFromSolcJSON creates a new IR builder from the AST produced by the solc compiler, such as the
+// `--ast-compact-json` output, standard JSON output, Hardhat build info or Foundry artifacts. Sources are
+// optional and, when provided, are used to resolve line and column information of the nodes.
+func NewBuilderFromSolcJSON(ctx context.Context, data []byte, sources *solgo.Sources) (*Builder, error) {
+	if !standards.StandardsLoaded() {
+		if err := standards.LoadStandards(); err != nil {
+			return nil, err
+		}
+	}
+
+	astBuilder := ast.NewAstBuilder(nil, sources)
+
+	if _, err := astBuilder.ImportFromSolcJSON(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		ctx:        ctx,
+		sources:    sources,
+		astBuilder: astBuilder,
+	}, nil
+}
+
 // GetParser returns the underlying solgo parser.
 func (b *Builder) GetParser() *solgo.Parser {
 	return b.parser
@@ -89,9 +112,30 @@ func (b *Builder) GetSources() *solgo.Sources {
 	return b.sources
 }
 
+// LocateNode resolves the AST node with the provided id, as referenced by IR nodes, back to the
+// source file, line, column and snippet it came from.
+func (b *Builder) LocateNode(id int64) (*ast.SourceLocation, error) {
+	return b.astBuilder.LocateNode(id)
+}
+
+// GetUserDoc returns the solc compatible user documentation of the contract with the provided id.
+func (b *Builder) GetUserDoc(contractId int64) (*ast.UserDoc, error) {
+	return b.astBuilder.GetUserDoc(contractId)
+}
+
+// GetDevDoc returns the solc compatible developer documentation of the contract with the provided id.
+func (b *Builder) GetDevDoc(contractId int64) (*ast.DevDoc, error) {
+	return b.astBuilder.GetDevDoc(contractId)
+}
+
 // Parse processes the sources using the parser and the AST builder and returns
 // any encountered errors.
 func (b *Builder) Parse() (errs []error) {
+	// Builders created from JSON have no parser as the AST is already built.
+	if b.parser == nil {
+		return errs
+	}
+
 	if syntaxErrs := b.parser.Parse(); syntaxErrs != nil {
 		for _, syntaxErr := range syntaxErrs {
 			errs = append(errs, syntaxErr.Error())
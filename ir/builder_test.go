@@ -863,3 +863,118 @@ func buildFullPath(relativePath string) string {
 	absPath, _ := filepath.Abs(relativePath)
 	return absPath
 }
+
+func TestIrBuilderFromSolcJSON(t *testing.T) {
+	testCases := []struct {
+		name           string
+		file           string
+		entryContract  string
+		functions      []string
+		stateVariables int
+	}{
+		{
+			name:           "Lottery Contract Test",
+			file:           "ast/Lottery.solc.ast",
+			entryContract:  "Lottery",
+			functions:      []string{"join", "finishLottery", "owner", "callExternalFunction"},
+			stateVariables: 4,
+		},
+		{
+			name:           "Token Sale Contract Test",
+			file:           "ast/TokenSale.solc.ast",
+			entryContract:  "TokenSale",
+			functions:      []string{"buyTokens"},
+			stateVariables: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder, err := NewBuilderFromSolcJSON(context.TODO(), tests.ReadJsonBytesForTest(t, testCase.file).Bytes, nil)
+			assert.NoError(t, err)
+			assert.NotNil(t, builder)
+
+			assert.Empty(t, builder.Parse())
+			assert.NoError(t, builder.Build())
+
+			contract := builder.GetRoot().GetEntryContract()
+			assert.NotNil(t, contract)
+			assert.Equal(t, testCase.entryContract, contract.GetName())
+			assert.Len(t, contract.GetStateVariables(), testCase.stateVariables)
+
+			functions := make([]string, 0)
+			for _, function := range contract.GetFunctions() {
+				functions = append(functions, function.GetName())
+			}
+			assert.Subset(t, functions, testCase.functions)
+		})
+	}
+}
+
+func TestIrBuilderNatSpec(t *testing.T) {
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name: "Vault",
+				Path: "Vault.sol",
+				Content: `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @title Vault
+/// @notice Holds deposits.
+contract Vault {
+    /// @notice Balance of each depositor.
+    mapping(address => uint256) public balances;
+
+    /// @notice Emitted on deposit.
+    event Deposited(address indexed account, uint256 amount);
+
+    /// @notice Thrown when the amount is zero.
+    error ZeroAmount();
+
+    /// @dev Rejects zero amounts.
+    modifier nonZero() {
+        if (msg.value == 0) revert ZeroAmount();
+        _;
+    }
+
+    /// @notice Deposits the sent value.
+    function deposit() public payable nonZero {
+        balances[msg.sender] += msg.value;
+        emit Deposited(msg.sender, msg.value);
+    }
+}
+`,
+			},
+		},
+		EntrySourceUnitName: "Vault",
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	builder, err := NewBuilderFromSources(context.TODO(), sources)
+	assert.NoError(t, err)
+	assert.Empty(t, builder.Parse())
+	assert.NoError(t, builder.Build())
+
+	contract := builder.GetRoot().GetEntryContract()
+	assert.NotNil(t, contract)
+	assert.Equal(t, "Vault", contract.GetDocumentation().GetTitle())
+	assert.Equal(t, "Balance of each depositor.", contract.GetStateVariables()[0].GetDocumentation().GetNotice())
+	assert.Equal(t, "Emitted on deposit.", contract.GetEvents()[0].GetDocumentation().GetNotice())
+	assert.Equal(t, "Thrown when the amount is zero.", contract.GetErrors()[0].GetDocumentation().GetNotice())
+
+	function := contract.GetFunctions()[0]
+	assert.Equal(t, "Deposits the sent value.", function.GetDocumentation().GetNotice())
+	assert.Equal(t, "Rejects zero amounts.", function.GetModifiers()[0].GetDocumentation().GetDev())
+
+	userDoc, err := builder.GetUserDoc(contract.GetId())
+	assert.NoError(t, err)
+	assert.Equal(t, "Holds deposits.", userDoc.Notice)
+	assert.Equal(t, "Balance of each depositor.", userDoc.Methods["balances(address)"].Notice)
+	assert.Equal(t, "Deposits the sent value.", userDoc.Methods["deposit()"].Notice)
+
+	devDoc, err := builder.GetDevDoc(contract.GetId())
+	assert.NoError(t, err)
+	assert.Equal(t, "Vault", devDoc.Title)
+	assert.Empty(t, devDoc.Methods)
+}
@@ -0,0 +1,91 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Catch represents a catch clause of a try statement in the IR.
+type Catch struct {
+	Unit            *ast.CatchStatement     `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Name            string                  `json:"name"`
+	Body            *Body                   `json:"body"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the catch clause.
+func (e *Catch) GetAST() *ast.CatchStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the catch clause.
+func (e *Catch) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the catch clause.
+func (e *Catch) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the catch clause.
+func (e *Catch) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the catch clause.
+func (e *Catch) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetName returns the name of the catch clause, such as `Error` or `Panic`, or an empty string for a catch-all clause.
+func (e *Catch) GetName() string {
+	return e.Name
+}
+
+// GetBody returns the body of the catch clause.
+func (e *Catch) GetBody() *Body {
+	return e.Body
+}
+
+// GetNodes returns the statements of the catch clause body.
+func (e *Catch) GetNodes() []Statement {
+	if e.Body == nil {
+		return nil
+	}
+	return e.Body.GetStatements()
+}
+
+// GetTypeDescription returns the type description of the catch clause.
+func (e *Catch) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the catch clause.
+func (e *Catch) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"name":            e.GetName(),
+		"body":            bodyToValue(e.GetBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processCatch processes the catch clause and returns the Catch.
+func (b *Builder) processCatch(fn *Function, unit *ast.CatchStatement) *Catch {
+	return &Catch{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetKind(),
+		Name:            unit.GetName(),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
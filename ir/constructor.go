@@ -21,6 +21,7 @@ type Constructor struct {
 	Modifiers        []*Modifier       `json:"modifiers"`
 	Parameters       []*Parameter      `json:"parameters"`
 	ReturnStatements []*Parameter      `json:"return"`
+	Documentation    *ast.NatSpec      `json:"documentation,omitempty"`
 }
 
 // GetAST returns the underlying ast.Constructor.
@@ -88,6 +89,11 @@ func (f *Constructor) GetSrc() ast.SrcNode {
 	return f.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the constructor or nil if it is not documented.
+func (f *Constructor) GetDocumentation() *ast.NatSpec {
+	return f.Documentation
+}
+
 // ToProto converts the constructor to its protobuf representation.
 func (f *Constructor) ToProto() *ir_pb.Constructor {
 	proto := &ir_pb.Constructor{
@@ -133,6 +139,7 @@ func (b *Builder) processConstructor(unit *ast.Constructor) *Constructor {
 		Modifiers:        make([]*Modifier, 0),
 		Parameters:       make([]*Parameter, 0),
 		ReturnStatements: make([]*Parameter, 0),
+		Documentation:    unit.GetDocumentation(),
 	}
 
 	for _, modifier := range unit.GetModifiers() {
@@ -142,6 +149,7 @@ func (b *Builder) processConstructor(unit *ast.Constructor) *Constructor {
 			NodeType:      modifier.GetType(),
 			Name:          modifier.GetName(),
 			ArgumentTypes: modifier.GetArgumentTypes(),
+			Documentation: b.modifierDocumentation(modifier.GetName(), unit.GetScope()),
 		})
 	}
 
@@ -0,0 +1,72 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Continue represents a continue statement in the IR.
+type Continue struct {
+	Unit            *ast.ContinueStatement  `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the continue statement.
+func (e *Continue) GetAST() *ast.ContinueStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the continue statement.
+func (e *Continue) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the continue statement.
+func (e *Continue) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the continue statement.
+func (e *Continue) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the continue statement.
+func (e *Continue) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetNodes returns the nodes of the statement.
+func (e *Continue) GetNodes() []Statement {
+	return nil
+}
+
+// GetTypeDescription returns the type description of the continue statement.
+func (e *Continue) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the continue statement.
+func (e *Continue) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processContinue processes the continue statement and returns the Continue.
+func (b *Builder) processContinue(unit *ast.ContinueStatement) *Continue {
+	return &Continue{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
@@ -26,6 +26,7 @@ type ContractNode interface {
 	GetEnums() []*ast.EnumDefinition
 	GetEvents() []*ast.EventDefinition
 	GetErrors() []*ast.ErrorDefinition
+	GetDocumentation() *ast.NatSpec
 }
 
 // Contract represents a contract in the Intermediate Representation (IR).
@@ -52,6 +53,7 @@ type Contract struct {
 	Functions      []*Function                                  `json:"functions"`
 	Fallback       *Fallback                                    `json:"fallback,omitempty"`
 	Receive        *Receive                                     `json:"receive,omitempty"`
+	Documentation  *ast.NatSpec                                 `json:"documentation,omitempty"`
 }
 
 // GetAST returns the AST (Abstract Syntax Tree) for the contract.
@@ -104,6 +106,11 @@ func (c *Contract) GetSrc() ast.SrcNode {
 	return c.Unit.GetContract().GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the contract or nil if it is not documented.
+func (c *Contract) GetDocumentation() *ast.NatSpec {
+	return c.Documentation
+}
+
 // GetKind returns the kind of the contract.
 func (c *Contract) GetKind() ast_pb.NodeType {
 	return c.Kind
@@ -270,6 +277,7 @@ func (b *Builder) processContract(unit *ast.SourceUnit[ast.Node[ast_pb.SourceUni
 		Events:         make([]*Event, 0),
 		Errors:         make([]*Error, 0),
 		Functions:      make([]*Function, 0),
+		Documentation:  contract.GetDocumentation(),
 	}
 
 	for _, pragma := range unit.GetPragmas() {
@@ -0,0 +1,129 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Declaration represents a single local variable declared by a variable declaration statement.
+type Declaration struct {
+	Id              int64                   `json:"id"`
+	Name            string                  `json:"name"`
+	StorageLocation ast_pb.StorageLocation  `json:"storageLocation"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// toValue converts the declaration into a value that can be used as a field of NewTypedStructFromMap.
+func (d *Declaration) toValue() map[string]interface{} {
+	return map[string]interface{}{
+		"id":              d.Id,
+		"name":            d.Name,
+		"storageLocation": d.StorageLocation.String(),
+		"typeDescription": protoToValue(d.TypeDescription),
+	}
+}
+
+// VariableDeclaration represents a local variable declaration statement in the IR.
+type VariableDeclaration struct {
+	Unit            *ast.VariableDeclaration `json:"-"`
+	Id              int64                    `json:"id"`
+	NodeType        ast_pb.NodeType          `json:"nodeType"`
+	Kind            ast_pb.NodeType          `json:"kind"`
+	Declarations    []*Declaration           `json:"declarations"`
+	Initialized     bool                     `json:"initialized"`
+	Statements      []Statement              `json:"statements"`
+	TypeDescription *ast_pb.TypeDescription  `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the variable declaration statement.
+func (e *VariableDeclaration) GetAST() *ast.VariableDeclaration {
+	return e.Unit
+}
+
+// GetId returns the ID of the variable declaration statement.
+func (e *VariableDeclaration) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the variable declaration statement.
+func (e *VariableDeclaration) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the variable declaration statement.
+func (e *VariableDeclaration) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the variable declaration statement.
+func (e *VariableDeclaration) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetDeclarations returns the variables declared by the statement.
+func (e *VariableDeclaration) GetDeclarations() []*Declaration {
+	return e.Declarations
+}
+
+// IsInitialized returns true if the declared variables are assigned an initial value.
+func (e *VariableDeclaration) IsInitialized() bool {
+	return e.Initialized
+}
+
+// GetNodes returns the function calls made while evaluating the initial value.
+func (e *VariableDeclaration) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the variable declaration statement.
+func (e *VariableDeclaration) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the variable declaration statement.
+func (e *VariableDeclaration) ToProto() *v3.TypedStruct {
+	declarations := make([]interface{}, 0, len(e.GetDeclarations()))
+	for _, declaration := range e.GetDeclarations() {
+		declarations = append(declarations, declaration.toValue())
+	}
+
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"declarations":    declarations,
+		"initialized":     e.IsInitialized(),
+		"statements":      statementsToValue(e.GetNodes()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processVariableDeclaration processes the variable declaration statement and returns the VariableDeclaration.
+func (b *Builder) processVariableDeclaration(fn *Function, unit *ast.VariableDeclaration) *VariableDeclaration {
+	toReturn := &VariableDeclaration{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Declarations:    make([]*Declaration, 0),
+		Initialized:     unit.GetInitialValue() != nil,
+		Statements:      b.processExpressionCalls(fn, unit.GetInitialValue()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	for _, declaration := range unit.GetDeclarations() {
+		if declaration == nil {
+			continue
+		}
+
+		toReturn.Declarations = append(toReturn.Declarations, &Declaration{
+			Id:              declaration.GetId(),
+			Name:            declaration.GetName(),
+			StorageLocation: declaration.GetStorageLocation(),
+			TypeDescription: typeDescriptionToProto(declaration.GetTypeDescription()),
+		})
+	}
+
+	return toReturn
+}
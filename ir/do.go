@@ -0,0 +1,92 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// DoWhile represents a do-while loop statement in the IR.
+type DoWhile struct {
+	Unit            *ast.DoWhileStatement   `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Condition       []Statement             `json:"condition"`
+	Body            *Body                   `json:"body"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the do-while statement.
+func (e *DoWhile) GetAST() *ast.DoWhileStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the do-while statement.
+func (e *DoWhile) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the do-while statement.
+func (e *DoWhile) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the do-while statement.
+func (e *DoWhile) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the do-while statement.
+func (e *DoWhile) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetCondition returns the function calls made while evaluating the loop condition.
+func (e *DoWhile) GetCondition() []Statement {
+	return e.Condition
+}
+
+// GetBody returns the body of the do-while statement.
+func (e *DoWhile) GetBody() *Body {
+	return e.Body
+}
+
+// GetNodes returns the statements of the body followed by the condition function calls.
+func (e *DoWhile) GetNodes() []Statement {
+	toReturn := make([]Statement, 0)
+	if e.Body != nil {
+		toReturn = append(toReturn, e.Body.GetStatements()...)
+	}
+	return append(toReturn, e.Condition...)
+}
+
+// GetTypeDescription returns the type description of the do-while statement.
+func (e *DoWhile) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the do-while statement.
+func (e *DoWhile) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"condition":       statementsToValue(e.GetCondition()),
+		"body":            bodyToValue(e.GetBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processDoWhile processes the do-while statement and returns the DoWhile.
+func (b *Builder) processDoWhile(fn *Function, unit *ast.DoWhileStatement) *DoWhile {
+	return &DoWhile{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Condition:       b.processExpressionCalls(fn, unit.GetCondition()),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
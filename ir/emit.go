@@ -0,0 +1,101 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Emit represents an emit statement in the IR.
+type Emit struct {
+	Unit                    *ast.Emit                 `json:"-"`
+	Id                      int64                     `json:"id"`
+	NodeType                ast_pb.NodeType           `json:"nodeType"`
+	Kind                    ast_pb.NodeType           `json:"kind"`
+	EventName               string                    `json:"eventName"`
+	ReferencedDeclarationId int64                     `json:"referencedDeclarationId"`
+	ArgumentTypes           []*ast_pb.TypeDescription `json:"argumentTypes"`
+	Statements              []Statement               `json:"statements"`
+	TypeDescription         *ast_pb.TypeDescription   `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the emit statement.
+func (e *Emit) GetAST() *ast.Emit {
+	return e.Unit
+}
+
+// GetId returns the ID of the emit statement.
+func (e *Emit) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the emit statement.
+func (e *Emit) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the emit statement.
+func (e *Emit) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the emit statement.
+func (e *Emit) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetEventName returns the name of the emitted event.
+func (e *Emit) GetEventName() string {
+	return e.EventName
+}
+
+// GetReferencedDeclarationId returns the referenced declaration id of the emit statement.
+func (e *Emit) GetReferencedDeclarationId() int64 {
+	return e.ReferencedDeclarationId
+}
+
+// GetArgumentTypes returns the argument types of the emit statement.
+func (e *Emit) GetArgumentTypes() []*ast_pb.TypeDescription {
+	return e.ArgumentTypes
+}
+
+// GetNodes returns the function calls made while evaluating the arguments.
+func (e *Emit) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the emit statement.
+func (e *Emit) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the emit statement.
+func (e *Emit) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":                      e.GetId(),
+		"nodeType":                e.GetNodeType().String(),
+		"kind":                    e.GetKind().String(),
+		"eventName":               e.GetEventName(),
+		"referencedDeclarationId": e.GetReferencedDeclarationId(),
+		"argumentTypes":           typeDescriptionsToValue(e.GetArgumentTypes()),
+		"statements":              statementsToValue(e.GetNodes()),
+		"typeDescription":         protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processEmit processes the emit statement and returns the Emit.
+func (b *Builder) processEmit(fn *Function, unit *ast.Emit) *Emit {
+	toReturn := &Emit{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		ArgumentTypes:   argumentTypesToProto(unit.GetArguments()),
+		Statements:      b.processExpressionCalls(fn, unit.GetArguments()...),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	toReturn.EventName, toReturn.ReferencedDeclarationId = resolveExpressionName(unit.GetExpression())
+
+	return toReturn
+}
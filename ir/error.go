@@ -14,6 +14,7 @@ type Error struct {
 	Name            string               `json:"name"`
 	Parameters      []*Parameter         `json:"parameters"`
 	TypeDescription *ast.TypeDescription `json:"type_description"`
+	Documentation   *ast.NatSpec         `json:"documentation,omitempty"`
 }
 
 // GetAST returns the AST (Abstract Syntax Tree) for the error definition.
@@ -51,6 +52,11 @@ func (e *Error) GetSrc() ast.SrcNode {
 	return e.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the error definition or nil if it is not documented.
+func (e *Error) GetDocumentation() *ast.NatSpec {
+	return e.Documentation
+}
+
 // ToProto converts the Error to its protobuf representation.
 func (e *Error) ToProto() *ir_pb.Error {
 	proto := &ir_pb.Error{
@@ -77,6 +83,7 @@ func (b *Builder) processError(unit *ast.ErrorDefinition) *Error {
 		Name:            unit.GetName(),
 		Parameters:      make([]*Parameter, 0),
 		TypeDescription: unit.GetTypeDescription(),
+		Documentation:   unit.GetDocumentation(),
 	}
 
 	for _, parameter := range unit.GetParameters().GetParameters() {
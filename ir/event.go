@@ -13,12 +13,13 @@ import (
 
 // Event represents an event definition in the IR.
 type Event struct {
-	Unit       *ast.EventDefinition `json:"ast"`
-	Id         int64                `json:"id"`
-	NodeType   ast_pb.NodeType      `json:"nodeType"`
-	Name       string               `json:"name"`
-	Anonymous  bool                 `json:"anonymous"`
-	Parameters []*Parameter         `json:"parameters"`
+	Unit          *ast.EventDefinition `json:"ast"`
+	Id            int64                `json:"id"`
+	NodeType      ast_pb.NodeType      `json:"nodeType"`
+	Name          string               `json:"name"`
+	Anonymous     bool                 `json:"anonymous"`
+	Parameters    []*Parameter         `json:"parameters"`
+	Documentation *ast.NatSpec         `json:"documentation,omitempty"`
 }
 
 // GetAST returns the AST (Abstract Syntax Tree) for the event definition.
@@ -56,6 +57,11 @@ func (e *Event) GetSrc() ast.SrcNode {
 	return e.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the event or nil if it is not documented.
+func (e *Event) GetDocumentation() *ast.NatSpec {
+	return e.Documentation
+}
+
 // GetSignature computes the Keccak-256 hash of the event signature to generate the 'topic0' hash.
 // This method calls GetSignatureRaw to obtain the raw event signature string and then applies
 // the Keccak-256 hash function to it. The resulting hash is commonly used in Ethereum as the
@@ -100,12 +106,13 @@ func (e *Event) ToProto() *ir_pb.Event {
 // processEvent processes the event definition unit and returns the Event.
 func (b *Builder) processEvent(unit *ast.EventDefinition) *Event {
 	toReturn := &Event{
-		Unit:       unit,
-		Id:         unit.GetId(),
-		NodeType:   unit.GetType(),
-		Name:       unit.GetName(),
-		Anonymous:  unit.IsAnonymous(),
-		Parameters: make([]*Parameter, 0),
+		Unit:          unit,
+		Id:            unit.GetId(),
+		NodeType:      unit.GetType(),
+		Name:          unit.GetName(),
+		Anonymous:     unit.IsAnonymous(),
+		Parameters:    make([]*Parameter, 0),
+		Documentation: unit.GetDocumentation(),
 	}
 
 	for _, parameter := range unit.GetParameters().GetParameters() {
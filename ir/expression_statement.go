@@ -0,0 +1,118 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// ExpressionStatement represents any expression used as a statement that has no dedicated IR counterpart,
+// such as increments, decrements, deletes or modifier placeholders. The kind of the statement is the node type of
+// the expression itself.
+type ExpressionStatement struct {
+	Unit            ast.Node[ast.NodeType]  `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Targets         []*AssignmentTarget     `json:"targets"`
+	Statements      []Statement             `json:"statements"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the expression statement.
+func (e *ExpressionStatement) GetAST() ast.Node[ast.NodeType] {
+	return e.Unit
+}
+
+// GetId returns the ID of the expression statement.
+func (e *ExpressionStatement) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the expression statement.
+func (e *ExpressionStatement) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the expression statement.
+func (e *ExpressionStatement) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the expression statement.
+func (e *ExpressionStatement) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetTargets returns the variables written to by increment, decrement and delete operations.
+func (e *ExpressionStatement) GetTargets() []*AssignmentTarget {
+	return e.Targets
+}
+
+// IsStateWrite returns true if the expression statement modifies contract storage.
+func (e *ExpressionStatement) IsStateWrite() bool {
+	for _, target := range e.Targets {
+		if target.IsStateWrite() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPlaceholder returns true if the expression statement is the `_` placeholder of a modifier.
+func (e *ExpressionStatement) IsPlaceholder() bool {
+	return e.Kind == ast_pb.NodeType_PLACEHOLDER_STATEMENT
+}
+
+// GetNodes returns the function calls made while evaluating the expression.
+func (e *ExpressionStatement) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the expression.
+func (e *ExpressionStatement) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the expression statement.
+func (e *ExpressionStatement) ToProto() *v3.TypedStruct {
+	targets := make([]interface{}, 0, len(e.GetTargets()))
+	for _, target := range e.GetTargets() {
+		targets = append(targets, target.toValue())
+	}
+
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"targets":         targets,
+		"statements":      statementsToValue(e.GetNodes()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processExpressionStatement processes the expression used as a statement and returns the ExpressionStatement.
+func (b *Builder) processExpressionStatement(fn *Function, unit ast.Node[ast.NodeType]) *ExpressionStatement {
+	toReturn := &ExpressionStatement{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        ast_pb.NodeType_EXPRESSION_STATEMENT,
+		Kind:            unit.GetType(),
+		Targets:         make([]*AssignmentTarget, 0),
+		Statements:      b.processExpressionCalls(fn, unit.GetNodes()...),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	switch expr := unit.(type) {
+	case *ast.UnaryPrefix:
+		if expr.IsDelete() || expr.GetOperator() == ast_pb.Operator_INCREMENT || expr.GetOperator() == ast_pb.Operator_DECREMENT {
+			toReturn.Targets = b.resolveAssignmentTargets(expr.GetExpression())
+		}
+	case *ast.UnarySuffix:
+		if expr.GetOperator() == ast_pb.Operator_INCREMENT || expr.GetOperator() == ast_pb.Operator_DECREMENT {
+			toReturn.Targets = b.resolveAssignmentTargets(expr.GetExpression())
+		}
+	}
+
+	return toReturn
+}
@@ -21,6 +21,7 @@ type Fallback struct {
 	Overrides        []*Override       `json:"overrides"`
 	Parameters       []*Parameter      `json:"parameters"`
 	ReturnStatements []*Parameter      `json:"return"`
+	Documentation    *ast.NatSpec      `json:"documentation,omitempty"`
 }
 
 // GetAST returns the AST (Abstract Syntax Tree) for the fallback function definition.
@@ -93,6 +94,11 @@ func (f *Fallback) GetSrc() ast.SrcNode {
 	return f.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the fallback function or nil if it is not documented.
+func (f *Fallback) GetDocumentation() *ast.NatSpec {
+	return f.Documentation
+}
+
 // ToProto converts the Fallback to its protobuf representation.
 func (f *Fallback) ToProto() *ir_pb.Fallback {
 	proto := &ir_pb.Fallback{
@@ -145,6 +151,7 @@ func (b *Builder) processFallback(unit *ast.Fallback) *Fallback {
 		Overrides:        make([]*Override, 0),
 		Parameters:       make([]*Parameter, 0),
 		ReturnStatements: make([]*Parameter, 0),
+		Documentation:    unit.GetDocumentation(),
 	}
 
 	for _, modifier := range unit.GetModifiers() {
@@ -154,6 +161,7 @@ func (b *Builder) processFallback(unit *ast.Fallback) *Fallback {
 			NodeType:      modifier.GetType(),
 			Name:          modifier.GetName(),
 			ArgumentTypes: modifier.GetArgumentTypes(),
+			Documentation: b.modifierDocumentation(modifier.GetName(), unit.GetSrc().GetParentIndex()),
 		})
 	}
 
@@ -0,0 +1,109 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// For represents a for loop statement in the IR.
+type For struct {
+	Unit            *ast.ForStatement       `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Initialiser     []Statement             `json:"initialiser"`
+	Condition       []Statement             `json:"condition"`
+	Closure         []Statement             `json:"closure"`
+	Body            *Body                   `json:"body"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the for statement.
+func (e *For) GetAST() *ast.ForStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the for statement.
+func (e *For) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the for statement.
+func (e *For) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the for statement.
+func (e *For) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the for statement.
+func (e *For) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetInitialiser returns the lowered initialiser of the for statement.
+func (e *For) GetInitialiser() []Statement {
+	return e.Initialiser
+}
+
+// GetCondition returns the function calls made while evaluating the loop condition.
+func (e *For) GetCondition() []Statement {
+	return e.Condition
+}
+
+// GetClosure returns the lowered closure (loop expression) of the for statement.
+func (e *For) GetClosure() []Statement {
+	return e.Closure
+}
+
+// GetBody returns the body of the for statement.
+func (e *For) GetBody() *Body {
+	return e.Body
+}
+
+// GetNodes returns the initialiser, condition, body and closure statements in execution order.
+func (e *For) GetNodes() []Statement {
+	toReturn := append([]Statement{}, e.Initialiser...)
+	toReturn = append(toReturn, e.Condition...)
+	if e.Body != nil {
+		toReturn = append(toReturn, e.Body.GetStatements()...)
+	}
+	return append(toReturn, e.Closure...)
+}
+
+// GetTypeDescription returns the type description of the for statement.
+func (e *For) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the for statement.
+func (e *For) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"initialiser":     statementsToValue(e.GetInitialiser()),
+		"condition":       statementsToValue(e.GetCondition()),
+		"closure":         statementsToValue(e.GetClosure()),
+		"body":            bodyToValue(e.GetBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processFor processes the for statement and returns the For.
+func (b *Builder) processFor(fn *Function, unit *ast.ForStatement) *For {
+	return &For{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Initialiser:     b.processStatement(fn, unit.GetInitialiser()),
+		Condition:       b.processExpressionCalls(fn, unit.GetCondition()),
+		Closure:         b.processStatement(fn, unit.GetClosure()),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
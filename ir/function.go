@@ -25,6 +25,7 @@ type Function struct {
 	Body                    *Body             `json:"body"`
 	ReturnStatements        []*Parameter      `json:"return"`
 	Src                     ast.SrcNode       `json:"src"`
+	Documentation           *ast.NatSpec      `json:"documentation,omitempty"`
 }
 
 // GetAST returns the AST (Abstract Syntax Tree) for the function declaration.
@@ -112,6 +113,11 @@ func (f *Function) GetSrc() ast.SrcNode {
 	return f.Src
 }
 
+// GetDocumentation returns the NatSpec documentation of the function or nil if it is not documented.
+func (f *Function) GetDocumentation() *ast.NatSpec {
+	return f.Documentation
+}
+
 // ToProto returns the protocol buffer version of the function.
 func (f *Function) ToProto() *ir_pb.Function {
 	proto := &ir_pb.Function{
@@ -170,6 +176,7 @@ func (b *Builder) processFunction(unit *ast.Function, parseBody bool) *Function
 		Parameters:              make([]*Parameter, 0),
 		ReturnStatements:        make([]*Parameter, 0),
 		Src:                     unit.GetSrc(),
+		Documentation:           unit.GetDocumentation(),
 	}
 
 	for _, modifier := range unit.GetModifiers() {
@@ -179,6 +186,7 @@ func (b *Builder) processFunction(unit *ast.Function, parseBody bool) *Function
 			NodeType:      modifier.GetType(),
 			Name:          modifier.GetName(),
 			ArgumentTypes: modifier.GetArgumentTypes(),
+			Documentation: b.modifierDocumentation(modifier.GetName(), unit.GetScope()),
 		})
 	}
 
@@ -215,6 +223,13 @@ func (b *Builder) processFunction(unit *ast.Function, parseBody bool) *Function
 
 	if parseBody {
 		toReturn.Body = b.processFunctionBody(toReturn, unit.GetBody())
+
+		// Modifier bodies are lowered in the context of the function they are applied to.
+		for _, modifier := range toReturn.Modifiers {
+			if definition := b.byModifier(modifier.GetName(), unit.GetScope()); definition != nil && definition.GetBody() != nil {
+				modifier.Body = b.processFunctionBody(toReturn, definition.GetBody())
+			}
+		}
 	}
 
 	for _, returnStatement := range unit.GetReturnParameters().GetParameters() {
@@ -0,0 +1,103 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// If represents an if statement in the IR.
+type If struct {
+	Unit            *ast.IfStatement        `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Condition       []Statement             `json:"condition"`
+	Body            *Body                   `json:"body"`
+	FalseBody       *Body                   `json:"falseBody,omitempty"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the if statement.
+func (e *If) GetAST() *ast.IfStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the if statement.
+func (e *If) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the if statement.
+func (e *If) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the if statement.
+func (e *If) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the if statement.
+func (e *If) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetCondition returns the function calls made while evaluating the condition.
+func (e *If) GetCondition() []Statement {
+	return e.Condition
+}
+
+// GetBody returns the body of the if statement.
+func (e *If) GetBody() *Body {
+	return e.Body
+}
+
+// GetFalseBody returns the body of the else branch, or nil if the if statement has none.
+func (e *If) GetFalseBody() *Body {
+	return e.FalseBody
+}
+
+// GetNodes returns the condition function calls followed by the statements of the body and of the else branch.
+func (e *If) GetNodes() []Statement {
+	toReturn := append([]Statement{}, e.Condition...)
+	if e.Body != nil {
+		toReturn = append(toReturn, e.Body.GetStatements()...)
+	}
+	if e.FalseBody != nil {
+		toReturn = append(toReturn, e.FalseBody.GetStatements()...)
+	}
+	return toReturn
+}
+
+// GetTypeDescription returns the type description of the if statement.
+func (e *If) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the if statement.
+func (e *If) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"condition":       statementsToValue(e.GetCondition()),
+		"body":            bodyToValue(e.GetBody()),
+		"falseBody":       bodyToValue(e.GetFalseBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processIf processes the if statement and returns the If.
+func (b *Builder) processIf(fn *Function, unit *ast.IfStatement) *If {
+	return &If{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		Condition:       b.processExpressionCalls(fn, unit.GetCondition()),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		FalseBody:       b.processNestedBody(fn, unit.GetFalseBody()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
@@ -3,6 +3,8 @@ package ir
 import (
 	"fmt"
 
+	"github.com/goccy/go-json"
+
 	v3 "github.com/cncf/xds/go/xds/type/v3"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -32,3 +34,61 @@ func NewTypedStruct(m protoreflect.ProtoMessage, protoType string) *v3.TypedStru
 		Value:   s,
 	}
 }
+
+// NewTypedStructFromMap creates a v3.TypedStruct from the given fields. It is used by statements that do not have
+// a dedicated protocol buffer message, which is why the type URL names the google.protobuf.Struct message holding
+// the fields. The kind of the statement is given by its nodeType field.
+func NewTypedStructFromMap(fields map[string]interface{}) *v3.TypedStruct {
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		zap.L().Error("failed to convert fields to structpb", zap.Error(err))
+		return nil
+	}
+
+	return &v3.TypedStruct{
+		TypeUrl: fmt.Sprintf("type.googleapis.com/%s", s.ProtoReflect().Descriptor().FullName()),
+		Value:   s,
+	}
+}
+
+// protoToValue converts the proto message into a value that can be used as a field of
+// NewTypedStructFromMap. It returns nil if the message cannot be converted.
+func protoToValue(m protoreflect.ProtoMessage) interface{} {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	jsonBytes, err := protojson.Marshal(m)
+	if err != nil {
+		zap.L().Error("failed to marshal proto to json", zap.Error(err))
+		return nil
+	}
+
+	var toReturn interface{}
+	if err := json.Unmarshal(jsonBytes, &toReturn); err != nil {
+		zap.L().Error("failed to unmarshal json to value", zap.Error(err))
+		return nil
+	}
+
+	return toReturn
+}
+
+// statementsToValue converts the statements into a list value that can be used as a field of
+// NewTypedStructFromMap.
+func statementsToValue(statements []Statement) []interface{} {
+	toReturn := make([]interface{}, 0, len(statements))
+	for _, statement := range statements {
+		if value := protoToValue(statement.ToProto()); value != nil {
+			toReturn = append(toReturn, value)
+		}
+	}
+	return toReturn
+}
+
+// bodyToValue converts the body into a value that can be used as a field of NewTypedStructFromMap.
+func bodyToValue(body *Body) interface{} {
+	if body == nil {
+		return nil
+	}
+	return protoToValue(body.ToProto())
+}
@@ -13,6 +13,8 @@ type Modifier struct {
 	NodeType      ast_pb.NodeType         `json:"nodeType"`
 	Name          string                  `json:"name"`
 	ArgumentTypes []*ast.TypeDescription  `json:"argumentTypes"`
+	Body          *Body                   `json:"body,omitempty"`          // Lowered body of the invoked modifier definition, if resolved.
+	Documentation *ast.NatSpec            `json:"documentation,omitempty"` // NatSpec documentation of the invoked modifier definition, if resolved.
 }
 
 // GetAST returns the underlying AST node for the Modifier.
@@ -40,11 +42,22 @@ func (m *Modifier) GetArgumentTypes() []*ast.TypeDescription {
 	return m.ArgumentTypes
 }
 
+// GetBody returns the lowered body of the invoked modifier definition or nil if the definition
+// could not be resolved or the function body was not processed.
+func (m *Modifier) GetBody() *Body {
+	return m.Body
+}
+
 // GetSrc returns the source code location for the Modifier.
 func (m *Modifier) GetSrc() ast.SrcNode {
 	return m.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the invoked modifier definition or nil if it is not documented.
+func (m *Modifier) GetDocumentation() *ast.NatSpec {
+	return m.Documentation
+}
+
 // ToProto converts the Modifier to its corresponding protobuf representation.
 func (m *Modifier) ToProto() *ir_pb.Modifier {
 	proto := &ir_pb.Modifier{
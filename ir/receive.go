@@ -9,17 +9,18 @@ import (
 // Receive represents a receive function in the Intermediate Representation (IR) of Solidity contracts' Abstract Syntax Tree (AST).
 type Receive struct {
 	Unit            *ast.Receive      `json:"ast"`
-	Id              int64             `json:"id"`              // Id is the unique identifier of the receive function.
-	NodeType        ast_pb.NodeType   `json:"nodeType"`        // NodeType is the type of the receive function node in the AST.
-	Name            string            `json:"name"`            // Name is the name of the receive function (always "receive" for Solidity receive functions).
-	Kind            ast_pb.NodeType   `json:"kind"`            // Kind is the kind of the receive function node (e.g., FunctionDefinition, FunctionType).
-	Implemented     bool              `json:"implemented"`     // Implemented is true if the receive function is implemented in the contract, false otherwise.
-	Visibility      ast_pb.Visibility `json:"visibility"`      // Visibility represents the visibility of the receive function (e.g., public, private, internal, external).
-	StateMutability ast_pb.Mutability `json:"stateMutability"` // StateMutability represents the mutability of the receive function (e.g., pure, view, nonpayable, payable).
-	Virtual         bool              `json:"virtual"`         // Virtual is true if the receive function is virtual, false otherwise.
-	Modifiers       []*Modifier       `json:"modifiers"`       // Modifiers is a list of modifiers applied to the receive function.
-	Overrides       []*Override       `json:"overrides"`       // Overrides is a list of functions overridden by the receive function.
-	Parameters      []*Parameter      `json:"parameters"`      // Parameters is a list of parameters of the receive function.
+	Id              int64             `json:"id"`                      // Id is the unique identifier of the receive function.
+	NodeType        ast_pb.NodeType   `json:"nodeType"`                // NodeType is the type of the receive function node in the AST.
+	Name            string            `json:"name"`                    // Name is the name of the receive function (always "receive" for Solidity receive functions).
+	Kind            ast_pb.NodeType   `json:"kind"`                    // Kind is the kind of the receive function node (e.g., FunctionDefinition, FunctionType).
+	Implemented     bool              `json:"implemented"`             // Implemented is true if the receive function is implemented in the contract, false otherwise.
+	Visibility      ast_pb.Visibility `json:"visibility"`              // Visibility represents the visibility of the receive function (e.g., public, private, internal, external).
+	StateMutability ast_pb.Mutability `json:"stateMutability"`         // StateMutability represents the mutability of the receive function (e.g., pure, view, nonpayable, payable).
+	Virtual         bool              `json:"virtual"`                 // Virtual is true if the receive function is virtual, false otherwise.
+	Modifiers       []*Modifier       `json:"modifiers"`               // Modifiers is a list of modifiers applied to the receive function.
+	Overrides       []*Override       `json:"overrides"`               // Overrides is a list of functions overridden by the receive function.
+	Parameters      []*Parameter      `json:"parameters"`              // Parameters is a list of parameters of the receive function.
+	Documentation   *ast.NatSpec      `json:"documentation,omitempty"` // Documentation is the NatSpec documentation of the receive function.
 }
 
 // GetAST returns the underlying AST node of the receive function.
@@ -87,6 +88,11 @@ func (f *Receive) GetSrc() ast.SrcNode {
 	return f.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the receive function or nil if it is not documented.
+func (f *Receive) GetDocumentation() *ast.NatSpec {
+	return f.Documentation
+}
+
 // ToProto is a function that converts the Receive to a protobuf message.
 func (f *Receive) ToProto() *ir_pb.Receive {
 	proto := &ir_pb.Receive{
@@ -133,6 +139,7 @@ func (b *Builder) processReceive(unit *ast.Receive) *Receive {
 		Modifiers:       make([]*Modifier, 0),
 		Overrides:       make([]*Override, 0),
 		Parameters:      make([]*Parameter, 0),
+		Documentation:   unit.GetDocumentation(),
 	}
 
 	for _, modifier := range unit.GetModifiers() {
@@ -142,6 +149,7 @@ func (b *Builder) processReceive(unit *ast.Receive) *Receive {
 			NodeType:      modifier.GetType(),
 			Name:          modifier.GetName(),
 			ArgumentTypes: modifier.GetArgumentTypes(),
+			Documentation: b.modifierDocumentation(modifier.GetName(), unit.GetSrc().GetParentIndex()),
 		})
 	}
 
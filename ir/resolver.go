@@ -21,6 +21,41 @@ func (b *Builder) byFunction(name string) *Function {
 	return nil
 }
 
+// byModifier searches for a modifier definition by its name in the contract's AST and returns it if found.
+// Modifiers defined within the contract with the provided id take precedence over the ones with the same
+// name defined in other contracts.
+func (b *Builder) byModifier(name string, contractId int64) *ast.ModifierDefinition {
+	var toReturn *ast.ModifierDefinition
+	for _, unit := range b.astBuilder.GetRoot().GetSourceUnits() {
+		contract := unit.GetContract()
+		if contract == nil {
+			continue
+		}
+
+		for _, node := range contract.GetNodes() {
+			if modifier, ok := node.(*ast.ModifierDefinition); ok && modifier.GetName() == name {
+				if contract.GetId() == contractId {
+					return modifier
+				}
+				if toReturn == nil {
+					toReturn = modifier
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// modifierDocumentation returns the documentation of the modifier definition invoked by name, or nil if
+// the definition could not be resolved or is not documented.
+func (b *Builder) modifierDocumentation(name string, contractId int64) *ast.NatSpec {
+	if definition := b.byModifier(name, contractId); definition != nil {
+		return definition.GetDocumentation()
+	}
+	return nil
+}
+
 // LookupReferencedFunctionsByNode searches for referenced functions in the given AST nodes and returns a slice of functions.
 // It searches for referenced functions in member access expressions and function calls within the AST nodes recursively.
 func (b *Builder) LookupReferencedFunctionsByNode(nodes ast.Node[ast.NodeType]) []*Function {
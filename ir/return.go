@@ -0,0 +1,83 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Return represents a return statement in the IR.
+type Return struct {
+	Unit                     *ast.ReturnStatement    `json:"-"`
+	Id                       int64                   `json:"id"`
+	NodeType                 ast_pb.NodeType         `json:"nodeType"`
+	Kind                     ast_pb.NodeType         `json:"kind"`
+	FunctionReturnParameters int64                   `json:"functionReturnParameters"`
+	Statements               []Statement             `json:"statements"`
+	TypeDescription          *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the return statement.
+func (e *Return) GetAST() *ast.ReturnStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the return statement.
+func (e *Return) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the return statement.
+func (e *Return) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the return statement.
+func (e *Return) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the return statement.
+func (e *Return) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetFunctionReturnParameters returns the id of the return parameter list of the function.
+func (e *Return) GetFunctionReturnParameters() int64 {
+	return e.FunctionReturnParameters
+}
+
+// GetNodes returns the function calls made while evaluating the returned expression.
+func (e *Return) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the returned expression.
+func (e *Return) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the return statement.
+func (e *Return) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":                       e.GetId(),
+		"nodeType":                 e.GetNodeType().String(),
+		"kind":                     e.GetKind().String(),
+		"functionReturnParameters": e.GetFunctionReturnParameters(),
+		"statements":               statementsToValue(e.GetNodes()),
+		"typeDescription":          protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processReturn processes the return statement and returns the Return.
+func (b *Builder) processReturn(fn *Function, unit *ast.ReturnStatement) *Return {
+	return &Return{
+		Unit:                     unit,
+		Id:                       unit.GetId(),
+		NodeType:                 unit.GetType(),
+		Kind:                     unit.GetType(),
+		FunctionReturnParameters: unit.GetFunctionReturnParameters(),
+		Statements:               b.processExpressionCalls(fn, unit.GetExpression()),
+		TypeDescription:          typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
@@ -0,0 +1,101 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Revert represents a revert statement in the IR.
+type Revert struct {
+	Unit                    *ast.RevertStatement      `json:"-"`
+	Id                      int64                     `json:"id"`
+	NodeType                ast_pb.NodeType           `json:"nodeType"`
+	Kind                    ast_pb.NodeType           `json:"kind"`
+	ErrorName               string                    `json:"errorName"`
+	ReferencedDeclarationId int64                     `json:"referencedDeclarationId"`
+	ArgumentTypes           []*ast_pb.TypeDescription `json:"argumentTypes"`
+	Statements              []Statement               `json:"statements"`
+	TypeDescription         *ast_pb.TypeDescription   `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the revert statement.
+func (e *Revert) GetAST() *ast.RevertStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the revert statement.
+func (e *Revert) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the revert statement.
+func (e *Revert) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the revert statement.
+func (e *Revert) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the revert statement.
+func (e *Revert) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetErrorName returns the name of the custom error the statement reverts with.
+func (e *Revert) GetErrorName() string {
+	return e.ErrorName
+}
+
+// GetReferencedDeclarationId returns the referenced declaration id of the revert statement.
+func (e *Revert) GetReferencedDeclarationId() int64 {
+	return e.ReferencedDeclarationId
+}
+
+// GetArgumentTypes returns the argument types of the revert statement.
+func (e *Revert) GetArgumentTypes() []*ast_pb.TypeDescription {
+	return e.ArgumentTypes
+}
+
+// GetNodes returns the function calls made while evaluating the arguments.
+func (e *Revert) GetNodes() []Statement {
+	return e.Statements
+}
+
+// GetTypeDescription returns the type description of the revert statement.
+func (e *Revert) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the revert statement.
+func (e *Revert) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":                      e.GetId(),
+		"nodeType":                e.GetNodeType().String(),
+		"kind":                    e.GetKind().String(),
+		"errorName":               e.GetErrorName(),
+		"referencedDeclarationId": e.GetReferencedDeclarationId(),
+		"argumentTypes":           typeDescriptionsToValue(e.GetArgumentTypes()),
+		"statements":              statementsToValue(e.GetNodes()),
+		"typeDescription":         protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processRevert processes the revert statement and returns the Revert.
+func (b *Builder) processRevert(fn *Function, unit *ast.RevertStatement) *Revert {
+	toReturn := &Revert{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetType(),
+		ArgumentTypes:   argumentTypesToProto(unit.GetArguments()),
+		Statements:      b.processExpressionCalls(fn, unit.GetArguments()...),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	toReturn.ErrorName, toReturn.ReferencedDeclarationId = resolveExpressionName(unit.GetExpression())
+
+	return toReturn
+}
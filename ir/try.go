@@ -0,0 +1,116 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// Try represents a try statement in the IR.
+type Try struct {
+	Unit            *ast.TryStatement       `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Call            []Statement             `json:"call"`
+	Body            *Body                   `json:"body"`
+	Clauses         []*Catch                `json:"clauses"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the try statement.
+func (e *Try) GetAST() *ast.TryStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the try statement.
+func (e *Try) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the try statement.
+func (e *Try) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the try statement.
+func (e *Try) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the try statement.
+func (e *Try) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetCall returns the lowered external call of the try statement. The call being tried is the last statement.
+func (e *Try) GetCall() []Statement {
+	return e.Call
+}
+
+// GetBody returns the body executed when the call succeeds.
+func (e *Try) GetBody() *Body {
+	return e.Body
+}
+
+// GetClauses returns the catch clauses of the try statement.
+func (e *Try) GetClauses() []*Catch {
+	return e.Clauses
+}
+
+// GetNodes returns the call, the statements of the success body and the catch clauses.
+func (e *Try) GetNodes() []Statement {
+	toReturn := append([]Statement{}, e.Call...)
+	if e.Body != nil {
+		toReturn = append(toReturn, e.Body.GetStatements()...)
+	}
+	for _, clause := range e.Clauses {
+		toReturn = append(toReturn, clause)
+	}
+	return toReturn
+}
+
+// GetTypeDescription returns the type description of the try statement.
+func (e *Try) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the try statement.
+func (e *Try) ToProto() *v3.TypedStruct {
+	clauses := make([]Statement, 0, len(e.GetClauses()))
+	for _, clause := range e.GetClauses() {
+		clauses = append(clauses, clause)
+	}
+
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"call":            statementsToValue(e.GetCall()),
+		"body":            bodyToValue(e.GetBody()),
+		"clauses":         statementsToValue(clauses),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processTry processes the try statement and returns the Try.
+func (b *Builder) processTry(fn *Function, unit *ast.TryStatement) *Try {
+	toReturn := &Try{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetKind(),
+		Call:            b.processExpressionCalls(fn, unit.GetExpression()),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		Clauses:         make([]*Catch, 0),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+
+	for _, clause := range unit.GetClauses() {
+		if catch, ok := clause.(*ast.CatchStatement); ok && catch != nil {
+			toReturn.Clauses = append(toReturn.Clauses, b.processCatch(fn, catch))
+		}
+	}
+
+	return toReturn
+}
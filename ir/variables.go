@@ -11,16 +11,17 @@ import (
 // StateVariable represents a state variable in the Intermediate Representation (IR) of Solidity contracts' Abstract Syntax Tree (AST).
 type StateVariable struct {
 	Unit            *ast.StateVariableDeclaration `json:"ast"`
-	Id              int64                         `json:"id"`              // Id is the unique identifier of the state variable.
-	ContractId      int64                         `json:"contractId"`      // ContractId is the unique identifier of the contract containing the state variable.
-	Name            string                        `json:"name"`            // Name is the name of the state variable.
-	NodeType        ast_pb.NodeType               `json:"nodeType"`        // NodeType is the type of the state variable node in the AST.
-	Visibility      ast_pb.Visibility             `json:"visibility"`      // Visibility represents the visibility of the state variable (e.g., public, private, internal, external).
-	Constant        bool                          `json:"isConstant"`      // Constant is true if the state variable is constant, false otherwise.
-	StorageLocation ast_pb.StorageLocation        `json:"storageLocation"` // StorageLocation represents the storage location of the state variable.
-	StateMutability ast_pb.Mutability             `json:"stateMutability"` // StateMutability represents the mutability of the state variable (e.g., pure, view, nonpayable, payable).
-	Type            string                        `json:"type"`            // Type is the type of the state variable.
-	TypeDescription *ast.TypeDescription          `json:"typeDescription"` // TypeDescription is the description of the type of the state variable.
+	Id              int64                         `json:"id"`                      // Id is the unique identifier of the state variable.
+	ContractId      int64                         `json:"contractId"`              // ContractId is the unique identifier of the contract containing the state variable.
+	Name            string                        `json:"name"`                    // Name is the name of the state variable.
+	NodeType        ast_pb.NodeType               `json:"nodeType"`                // NodeType is the type of the state variable node in the AST.
+	Visibility      ast_pb.Visibility             `json:"visibility"`              // Visibility represents the visibility of the state variable (e.g., public, private, internal, external).
+	Constant        bool                          `json:"isConstant"`              // Constant is true if the state variable is constant, false otherwise.
+	StorageLocation ast_pb.StorageLocation        `json:"storageLocation"`         // StorageLocation represents the storage location of the state variable.
+	StateMutability ast_pb.Mutability             `json:"stateMutability"`         // StateMutability represents the mutability of the state variable (e.g., pure, view, nonpayable, payable).
+	Type            string                        `json:"type"`                    // Type is the type of the state variable.
+	TypeDescription *ast.TypeDescription          `json:"typeDescription"`         // TypeDescription is the description of the type of the state variable.
+	Documentation   *ast.NatSpec                  `json:"documentation,omitempty"` // Documentation is the NatSpec documentation of the state variable.
 }
 
 // GetAST returns the underlying AST node of the state variable.
@@ -83,6 +84,11 @@ func (v *StateVariable) GetSrc() ast.SrcNode {
 	return v.Unit.GetSrc()
 }
 
+// GetDocumentation returns the NatSpec documentation of the state variable or nil if it is not documented.
+func (v *StateVariable) GetDocumentation() *ast.NatSpec {
+	return v.Documentation
+}
+
 func (v *StateVariable) GetStorageSize() (int64, bool) {
 	return v.Unit.GetTypeName().StorageSize()
 }
@@ -119,6 +125,7 @@ func (b *Builder) processStateVariables(unit *ast.StateVariableDeclaration) *Sta
 		StateMutability: unit.GetStateMutability(),
 		Type:            unit.GetTypeName().GetName(),
 		TypeDescription: unit.GetTypeName().GetTypeDescription(),
+		Documentation:   unit.GetDocumentation(),
 	}
 
 	if strings.HasPrefix(unit.GetTypeName().GetName(), "contract") {
@@ -0,0 +1,92 @@
+package ir
+
+import (
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// While represents a while loop statement in the IR.
+type While struct {
+	Unit            *ast.WhileStatement     `json:"-"`
+	Id              int64                   `json:"id"`
+	NodeType        ast_pb.NodeType         `json:"nodeType"`
+	Kind            ast_pb.NodeType         `json:"kind"`
+	Condition       []Statement             `json:"condition"`
+	Body            *Body                   `json:"body"`
+	TypeDescription *ast_pb.TypeDescription `json:"typeDescription"`
+}
+
+// GetAST returns the AST (Abstract Syntax Tree) for the while statement.
+func (e *While) GetAST() *ast.WhileStatement {
+	return e.Unit
+}
+
+// GetId returns the ID of the while statement.
+func (e *While) GetId() int64 {
+	return e.Id
+}
+
+// GetNodeType returns the NodeType of the while statement.
+func (e *While) GetNodeType() ast_pb.NodeType {
+	return e.NodeType
+}
+
+// GetKind returns the kind of the while statement.
+func (e *While) GetKind() ast_pb.NodeType {
+	return e.Kind
+}
+
+// GetSrc returns the source location of the while statement.
+func (e *While) GetSrc() ast.SrcNode {
+	return e.Unit.GetSrc()
+}
+
+// GetCondition returns the function calls made while evaluating the loop condition.
+func (e *While) GetCondition() []Statement {
+	return e.Condition
+}
+
+// GetBody returns the body of the while statement.
+func (e *While) GetBody() *Body {
+	return e.Body
+}
+
+// GetNodes returns the condition function calls followed by the statements of the body.
+func (e *While) GetNodes() []Statement {
+	toReturn := append([]Statement{}, e.Condition...)
+	if e.Body != nil {
+		toReturn = append(toReturn, e.Body.GetStatements()...)
+	}
+	return toReturn
+}
+
+// GetTypeDescription returns the type description of the while statement.
+func (e *While) GetTypeDescription() *ast_pb.TypeDescription {
+	return e.TypeDescription
+}
+
+// ToProto returns the protocol buffer version of the while statement.
+func (e *While) ToProto() *v3.TypedStruct {
+	return NewTypedStructFromMap(map[string]interface{}{
+		"id":              e.GetId(),
+		"nodeType":        e.GetNodeType().String(),
+		"kind":            e.GetKind().String(),
+		"condition":       statementsToValue(e.GetCondition()),
+		"body":            bodyToValue(e.GetBody()),
+		"typeDescription": protoToValue(e.GetTypeDescription()),
+	})
+}
+
+// processWhile processes the while statement and returns the While.
+func (b *Builder) processWhile(fn *Function, unit *ast.WhileStatement) *While {
+	return &While{
+		Unit:            unit,
+		Id:              unit.GetId(),
+		NodeType:        unit.GetType(),
+		Kind:            unit.GetKind(),
+		Condition:       b.processExpressionCalls(fn, unit.GetCondition()),
+		Body:            b.processNestedBody(fn, unit.GetBody()),
+		TypeDescription: typeDescriptionToProto(unit.GetTypeDescription()),
+	}
+}
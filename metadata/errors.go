@@ -5,4 +5,10 @@ import "errors"
 var (
 	ErrInvalidIpfsClient      = errors.New("invalid ipfs client provided")
 	ErrIpfsClientNotAvailable = errors.New("ipfs client seems not to be available. please check your ipfs daemon")
+	ErrContractNotFound       = errors.New("contract not found in repository")
+	ErrContractFullyVerified  = errors.New("contract is already stored as a full match")
+	ErrUnsupportedCID         = errors.New("content identifier is not supported by the provider")
+	ErrHashMismatch           = errors.New("metadata does not hash to the content identifier")
+	ErrSourceHashMismatch     = errors.New("source does not hash to the keccak256 hash of the metadata")
+	ErrMetadataNotFound       = errors.New("metadata not found")
 )
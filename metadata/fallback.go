@@ -0,0 +1,166 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unpackdev/solgo/bytecode"
+	"go.uber.org/zap"
+)
+
+// DefaultProviderTimeout is the time each provider of the FallbackProvider is given when no timeout is provided.
+const DefaultProviderTimeout = 10 * time.Second
+
+// FallbackProvider tries a chain of providers in order until one of them returns metadata that hashes back to the
+// requested content identifier. Each provider is given its own timeout, and accepted metadata is written into the
+// cache, when one is set, which is consulted before any of the providers.
+type FallbackProvider struct {
+	ctx       context.Context // The context to be used in provider lookups.
+	providers []Provider      // The providers, in the order they are tried.
+	timeout   time.Duration   // The time each provider is given to return the metadata.
+	cache     *LocalProvider  // The optional cache of verified metadata.
+}
+
+// NewFallbackProvider creates a new instance of FallbackProvider trying the providers in order.
+// If the timeout is not positive, DefaultProviderTimeout is used.
+func NewFallbackProvider(ctx context.Context, timeout time.Duration, providers ...Provider) (*FallbackProvider, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("at least one metadata provider must be set")
+	}
+
+	for _, provider := range providers {
+		if provider == nil {
+			return nil, errors.New("metadata provider must not be nil")
+		}
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+
+	return &FallbackProvider{
+		ctx:       ctx,
+		providers: providers,
+		timeout:   timeout,
+	}, nil
+}
+
+// SetCache sets the local provider verified metadata is cached in.
+func (p *FallbackProvider) SetCache(cache *LocalProvider) {
+	p.cache = cache
+}
+
+// GetProviders returns the providers, in the order they are tried.
+func (p *FallbackProvider) GetProviders() []Provider {
+	return p.providers
+}
+
+// GetMetadataByCID retrieves the metadata of a contract by its content identifier from the first provider returning
+// metadata that hashes back to it. Providers that do not support the content identifier are skipped.
+func (p *FallbackProvider) GetMetadataByCID(cid string) (*ContractMetadata, error) {
+	return p.GetMetadataByCIDWithContext(p.ctx, cid)
+}
+
+// GetMetadataByCIDWithContext retrieves the metadata of a contract by its content identifier from the first provider
+// returning metadata that hashes back to it, giving up once the context is done.
+func (p *FallbackProvider) GetMetadataByCIDWithContext(ctx context.Context, cid string) (*ContractMetadata, error) {
+	if _, _, err := parseCID(cid); err != nil {
+		return nil, err
+	}
+
+	if p.cache != nil {
+		if toReturn, err := p.cache.GetMetadataByCID(cid); err == nil {
+			return toReturn, nil
+		}
+	}
+
+	errs := make([]error, 0, len(p.providers))
+	for i, provider := range p.providers {
+		toReturn, err := p.lookup(ctx, provider, cid)
+		if err == nil {
+			err = VerifyMetadataHash(cid, []byte(toReturn.Raw))
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("context cancelled while fetching metadata %s: %w", cid, ctx.Err())
+			}
+
+			if !errors.Is(err, ErrUnsupportedCID) {
+				zap.L().Debug(
+					"failed to fetch metadata from provider",
+					zap.Int("provider_index", i),
+					zap.String("cid", cid),
+					zap.Error(err),
+				)
+			}
+			errs = append(errs, fmt.Errorf("provider %d: %w", i, err))
+			continue
+		}
+
+		if p.cache != nil {
+			if err := p.cache.Store(cid, []byte(toReturn.Raw)); err != nil {
+				zap.L().Warn(
+					"failed to cache metadata",
+					zap.String("cid", cid),
+					zap.Error(err),
+				)
+			}
+		}
+
+		return toReturn, nil
+	}
+
+	return nil, fmt.Errorf("failed to fetch metadata %s from any provider: %w", cid, errors.Join(errs...))
+}
+
+// GetMetadataByBytecode retrieves the metadata of a contract by any of the content identifiers found within its
+// bytecode metadata, trying IPFS first and Swarm after.
+func (p *FallbackProvider) GetMetadataByBytecode(md *bytecode.Metadata) (*ContractMetadata, error) {
+	cids := GetCIDsFromBytecode(md)
+	if len(cids) == 0 {
+		return nil, errors.New("bytecode metadata does not reference contract metadata")
+	}
+
+	errs := make([]error, 0, len(cids))
+	for _, cid := range cids {
+		toReturn, err := p.GetMetadataByCID(cid)
+		if err == nil {
+			return toReturn, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// lookup retrieves the metadata from the provider within the timeout. Providers that cannot be cancelled through
+// the context are left running in the background once the timeout passes.
+func (p *FallbackProvider) lookup(ctx context.Context, provider Provider, cid string) (*ContractMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	if provider, ok := provider.(ContextProvider); ok {
+		return provider.GetMetadataByCIDWithContext(ctx, cid)
+	}
+
+	type response struct {
+		metadata *ContractMetadata
+		err      error
+	}
+
+	result := make(chan response, 1)
+	go func() {
+		toReturn, err := provider.GetMetadataByCID(cid)
+		result <- response{metadata: toReturn, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timeout while fetching metadata %s: %w", cid, ctx.Err())
+	case res := <-result:
+		return res.metadata, res.err
+	}
+}
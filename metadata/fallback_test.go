@@ -0,0 +1,243 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticProvider returns the same metadata for every content identifier, after the delay.
+type staticProvider struct {
+	data  []byte
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (p *staticProvider) GetMetadataByCID(cid string) (*ContractMetadata, error) {
+	p.calls.Add(1)
+	time.Sleep(p.delay)
+	return decodeMetadata(p.data)
+}
+
+func TestGatewayProviders(t *testing.T) {
+	raw := readSushiXSwapMetadata(t)
+	swarmData := []byte(`{"compiler":{"version":"0.5.17+commit.d19bba13"},"language":"Solidity"}`)
+	swarmHex := hex.EncodeToString(swarmHash(swarmData, bzzr1ChunkHash))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmPL7gzcnyeyKUqQCJsvc5qbc9hqaopuRLtfuyLNsgn5oS":
+			_, _ = w.Write(raw)
+		case "/bzz-raw:/" + swarmHex:
+			_, _ = w.Write(swarmData)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ipfsProvider, err := NewHttpProvider(context.Background(), server.URL, server.Client())
+	require.NoError(t, err)
+
+	swarmProvider, err := NewSwarmProvider(context.Background(), server.URL+"/bzz-raw:", server.Client())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		provider Provider
+		cid      string
+		wantErr  error
+		wantAny  bool
+	}{
+		{
+			name:     "Http IPFS Metadata",
+			provider: ipfsProvider,
+			cid:      sushiXSwapCID,
+		},
+		{
+			name:     "Http Missing IPFS Metadata",
+			provider: ipfsProvider,
+			cid:      "ipfs://QmPJGZeDt3Jq65zJitQK8mJbmRrF3tKH64mAv4TLV6ELzW",
+			wantErr:  ErrMetadataNotFound,
+		},
+		{
+			name:     "Http Swarm Metadata",
+			provider: ipfsProvider,
+			cid:      "bzzr1://" + swarmHex,
+			wantErr:  ErrUnsupportedCID,
+		},
+		{
+			name:     "Swarm Metadata",
+			provider: swarmProvider,
+			cid:      "bzzr1://" + swarmHex,
+		},
+		{
+			name:     "Swarm IPFS Metadata",
+			provider: swarmProvider,
+			cid:      sushiXSwapCID,
+			wantErr:  ErrUnsupportedCID,
+		},
+		{
+			name:     "Swarm Invalid Hash",
+			provider: swarmProvider,
+			cid:      "bzzr0://1234",
+			wantAny:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metadata, err := testCase.provider.GetMetadataByCID(testCase.cid)
+			switch {
+			case testCase.wantErr != nil:
+				assert.ErrorIs(t, err, testCase.wantErr)
+			case testCase.wantAny:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.NoError(t, VerifyMetadataHash(testCase.cid, []byte(metadata.Raw)))
+			}
+		})
+	}
+
+	_, err = NewHttpProvider(context.Background(), "ipfs.io", nil)
+	assert.Error(t, err)
+}
+
+func TestHttpProviderSources(t *testing.T) {
+	content := "pragma solidity ^0.8.0;\ncontract Token {}"
+	keccak256 := "0x" + hex.EncodeToString(crypto.Keccak256([]byte(content)))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmSource":
+			_, _ = w.Write([]byte(content))
+		case "/ipfs/QmTampered":
+			_, _ = w.Write([]byte(content + "\n"))
+		case "/ipfs/QmMetadata", "/ipfs/QmTamperedMetadata":
+			url := "dweb:/ipfs/QmSource"
+			if r.URL.Path == "/ipfs/QmTamperedMetadata" {
+				url = "dweb:/ipfs/QmTampered"
+			}
+			_, _ = w.Write([]byte(`{"language":"Solidity","sources":{"Token.sol":{"keccak256":"` + keccak256 + `","urls":["bzz-raw://00","` + url + `"]}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewHttpProvider(context.Background(), server.URL, server.Client())
+	require.NoError(t, err)
+
+	metadata, err := provider.GetMetadataByCID("ipfs://QmMetadata")
+	require.NoError(t, err)
+	assert.Equal(t, content, metadata.Sources["Token.sol"].Content)
+
+	_, err = provider.GetMetadataByCID("ipfs://QmTamperedMetadata")
+	assert.ErrorIs(t, err, ErrSourceHashMismatch)
+}
+
+func TestLocalProvider(t *testing.T) {
+	raw := readSushiXSwapMetadata(t)
+
+	provider, err := NewLocalProvider(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = provider.GetMetadataByCID(sushiXSwapCID)
+	assert.ErrorIs(t, err, ErrMetadataNotFound)
+
+	assert.ErrorIs(t, provider.Store(sushiXSwapCID, append(raw, ' ')), ErrHashMismatch)
+	require.NoError(t, provider.Store(sushiXSwapCID, raw))
+
+	metadata, err := provider.GetMetadataByCID(sushiXSwapCID)
+	require.NoError(t, err)
+	assert.Equal(t, string(raw), metadata.Raw)
+	assert.Equal(t, "0.8.11+commit.d7f03943", metadata.Compiler.Version)
+
+	_, err = NewLocalProvider("")
+	assert.Error(t, err)
+}
+
+func TestFallbackProvider(t *testing.T) {
+	raw := readSushiXSwapMetadata(t)
+	tampered := []byte(strings.Replace(string(raw), "0.8.11", "0.8.12", 1))
+
+	testCases := []struct {
+		name      string
+		providers []*staticProvider
+		wantErr   bool
+		wantCalls []int32
+	}{
+		{
+			name:      "First Provider",
+			providers: []*staticProvider{{data: raw}, {data: raw}},
+			wantCalls: []int32{1, 0},
+		},
+		{
+			name:      "Tampered Provider Skipped",
+			providers: []*staticProvider{{data: tampered}, {data: raw}},
+			wantCalls: []int32{1, 1},
+		},
+		{
+			name:      "Slow Provider Skipped",
+			providers: []*staticProvider{{data: raw, delay: time.Second}, {data: raw}},
+			wantCalls: []int32{1, 1},
+		},
+		{
+			name:      "No Provider",
+			providers: []*staticProvider{{data: tampered}, {data: []byte("not json")}},
+			wantErr:   true,
+			wantCalls: []int32{1, 1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			providers := make([]Provider, 0, len(testCase.providers))
+			for _, provider := range testCase.providers {
+				providers = append(providers, provider)
+			}
+
+			fallback, err := NewFallbackProvider(context.Background(), 100*time.Millisecond, providers...)
+			require.NoError(t, err)
+
+			cache, err := NewLocalProvider(t.TempDir())
+			require.NoError(t, err)
+			fallback.SetCache(cache)
+
+			metadata, err := fallback.GetMetadataByCID(sushiXSwapCID)
+			for i, provider := range testCase.providers {
+				assert.Equal(t, testCase.wantCalls[i], provider.calls.Load())
+			}
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+				_, err = cache.GetMetadataByCID(sushiXSwapCID)
+				assert.ErrorIs(t, err, ErrMetadataNotFound)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(raw), metadata.Raw)
+
+			// Subsequent lookups are served from the cache.
+			_, err = fallback.GetMetadataByCID(sushiXSwapCID)
+			require.NoError(t, err)
+			for i, provider := range testCase.providers {
+				assert.Equal(t, testCase.wantCalls[i], provider.calls.Load())
+			}
+		})
+	}
+
+	_, err := NewFallbackProvider(context.Background(), time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package metadata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	cid "github.com/ipfs/go-cid"
+	"github.com/mr-tron/base58"
+	"github.com/unpackdev/solgo/bytecode"
+)
+
+const (
+	// ipfsChunkSize is the largest file, in bytes, stored by IPFS within a single block.
+	ipfsChunkSize = 256 * 1024
+
+	// swarmChunkSize is the size, in bytes, of Swarm chunks.
+	swarmChunkSize = 4096
+
+	// swarmBranches is the number of references held by intermediate Swarm chunks.
+	swarmBranches = swarmChunkSize / 32
+)
+
+// Schemes of the content identifiers accepted by providers.
+const (
+	SchemeIpfs  = "ipfs"
+	SchemeBzz   = "bzz"
+	SchemeBzzr0 = "bzzr0"
+	SchemeBzzr1 = "bzzr1"
+)
+
+// GetCIDsFromBytecode returns the content identifiers of the metadata found within the bytecode metadata,
+// in the ipfs://<cid>, bzzr1://<hex> and bzzr0://<hex> forms accepted by providers.
+func GetCIDsFromBytecode(md *bytecode.Metadata) []string {
+	toReturn := make([]string, 0)
+	if md == nil {
+		return toReturn
+	}
+
+	if len(md.Ipfs) > 0 {
+		toReturn = append(toReturn, fmt.Sprintf("%s://%s", SchemeIpfs, base58.Encode(md.Ipfs)))
+	}
+
+	if len(md.Bzzr1) > 0 {
+		toReturn = append(toReturn, fmt.Sprintf("%s://%s", SchemeBzzr1, hex.EncodeToString(md.Bzzr1)))
+	}
+
+	if len(md.Bzzr0) > 0 {
+		toReturn = append(toReturn, fmt.Sprintf("%s://%s", SchemeBzzr0, hex.EncodeToString(md.Bzzr0)))
+	}
+
+	return toReturn
+}
+
+// parseCID splits the content identifier into its scheme and hash.
+func parseCID(contentId string) (string, string, error) {
+	scheme, hash, found := strings.Cut(contentId, "://")
+	if !found || hash == "" {
+		return "", "", fmt.Errorf("invalid content identifier %q: expected <scheme>://<hash>", contentId)
+	}
+
+	switch scheme {
+	case SchemeIpfs, SchemeBzz, SchemeBzzr0, SchemeBzzr1:
+		return scheme, hash, nil
+	default:
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedCID, contentId)
+	}
+}
+
+// decodeSwarmHash decodes the Swarm hash, which is either hex encoded or, as returned by
+// bytecode.Metadata.GetBzzr0 and GetBzzr1, base58 encoded.
+func decodeSwarmHash(hash string) ([]byte, error) {
+	hash = strings.TrimPrefix(hash, "0x")
+	if decoded, err := hex.DecodeString(hash); err == nil && len(decoded) == 32 {
+		return decoded, nil
+	}
+
+	if decoded, err := base58.Decode(hash); err == nil && len(decoded) == 32 {
+		return decoded, nil
+	}
+
+	return nil, fmt.Errorf("invalid swarm hash %q", hash)
+}
+
+// VerifyMetadataHash checks that the metadata hashes back to the content identifier, so that metadata fetched from
+// untrusted sources can be matched against the hash found within the contract bytecode. IPFS identifiers are
+// checked against the CID of the metadata stored as a single file, while Swarm identifiers are checked against
+// the chunk tree hash, keccak256 based for bzzr0 and binary merkle tree based for bzzr1. The bzz scheme accepts both.
+func VerifyMetadataHash(contentId string, data []byte) error {
+	scheme, hash, err := parseCID(contentId)
+	if err != nil {
+		return err
+	}
+
+	switch scheme {
+	case SchemeIpfs:
+		expected, err := cid.Decode(hash)
+		if err != nil {
+			return fmt.Errorf("invalid IPFS hash: %w", err)
+		}
+
+		if len(data) > ipfsChunkSize {
+			return fmt.Errorf("metadata of %d bytes is too large to verify against %s", len(data), contentId)
+		}
+
+		if !bytes.Equal(expected.Hash(), ipfsHash(data)) {
+			return fmt.Errorf("%w: %s", ErrHashMismatch, contentId)
+		}
+	default:
+		expected, err := decodeSwarmHash(hash)
+		if err != nil {
+			return err
+		}
+
+		bzzr0 := scheme != SchemeBzzr1 && bytes.Equal(expected, swarmHash(data, bzzr0ChunkHash))
+		bzzr1 := scheme != SchemeBzzr0 && bytes.Equal(expected, swarmHash(data, bzzr1ChunkHash))
+		if !bzzr0 && !bzzr1 {
+			return fmt.Errorf("%w: %s", ErrHashMismatch, contentId)
+		}
+	}
+
+	return nil
+}
+
+// VerifySourceHash checks that the content of the source hashes to the keccak256 hash listed for it within the
+// metadata, so that sources fetched from untrusted sources can be matched against the metadata.
+func VerifySourceHash(keccak256 string, content []byte) error {
+	expected, err := hex.DecodeString(strings.TrimPrefix(keccak256, "0x"))
+	if err != nil || len(expected) != 32 {
+		return fmt.Errorf("invalid keccak256 hash %q", keccak256)
+	}
+
+	if !bytes.Equal(expected, crypto.Keccak256(content)) {
+		return fmt.Errorf("%w: %s", ErrSourceHashMismatch, keccak256)
+	}
+
+	return nil
+}
+
+// ipfsHash returns the sha256 multihash of the file as stored by IPFS within a single UnixFS block,
+// which is the hash referenced by CIDv0 identifiers found within the bytecode.
+func ipfsHash(data []byte) []byte {
+	// UnixFS data of type file (2), holding the content and the size of the file.
+	unixfs := []byte{0x08, 0x02}
+	if len(data) > 0 {
+		unixfs = appendProtobufBytes(unixfs, 2, data)
+	}
+	unixfs = binary.AppendUvarint(append(unixfs, 0x18), uint64(len(data)))
+
+	// DAG-PB node without links, holding the UnixFS data.
+	digest := sha256.Sum256(appendProtobufBytes(nil, 1, unixfs))
+	return append([]byte{0x12, 0x20}, digest[:]...)
+}
+
+// appendProtobufBytes appends the length delimited protobuf field.
+func appendProtobufBytes(buf []byte, field byte, data []byte) []byte {
+	buf = binary.AppendUvarint(append(buf, field<<3|2), uint64(len(data)))
+	return append(buf, data...)
+}
+
+// swarmHash returns the root hash of the Swarm chunk tree of the data. Data larger than a chunk is split
+// into subtrees, whose hashes form the content of intermediate chunks.
+func swarmHash(data []byte, chunkHash func(span uint64, payload []byte) []byte) []byte {
+	if len(data) <= swarmChunkSize {
+		return chunkHash(uint64(len(data)), data)
+	}
+
+	size := swarmChunkSize
+	for size*swarmBranches < len(data) {
+		size *= swarmBranches
+	}
+
+	references := make([]byte, 0, swarmChunkSize)
+	for offset := 0; offset < len(data); offset += size {
+		end := offset + size
+		if end > len(data) {
+			end = len(data)
+		}
+		references = append(references, swarmHash(data[offset:end], chunkHash)...)
+	}
+
+	return chunkHash(uint64(len(data)), references)
+}
+
+// bzzr0ChunkHash hashes the chunk the way legacy Swarm did, as keccak256 of the span and the payload.
+func bzzr0ChunkHash(span uint64, payload []byte) []byte {
+	return crypto.Keccak256(binary.LittleEndian.AppendUint64(nil, span), payload)
+}
+
+// bzzr1ChunkHash hashes the chunk as keccak256 of the span and the binary merkle tree root of the payload,
+// zero padded to the chunk size.
+func bzzr1ChunkHash(span uint64, payload []byte) []byte {
+	level := make([]byte, swarmChunkSize)
+	copy(level, payload)
+
+	for len(level) > 32 {
+		next := make([]byte, 0, len(level)/2)
+		for i := 0; i < len(level); i += 64 {
+			next = append(next, crypto.Keccak256(level[i:i+64])...)
+		}
+		level = next
+	}
+
+	return crypto.Keccak256(binary.LittleEndian.AppendUint64(nil, span), level)
+}
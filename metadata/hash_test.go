@@ -0,0 +1,190 @@
+package metadata
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/bytecode"
+	"github.com/unpackdev/solgo/tests"
+)
+
+// sushiXSwapCID is the IPFS content identifier of the SushiXSwap metadata test fixture.
+const sushiXSwapCID = "ipfs://QmPL7gzcnyeyKUqQCJsvc5qbc9hqaopuRLtfuyLNsgn5oS"
+
+// readSushiXSwapMetadata returns the raw SushiXSwap metadata, as it was hashed by the compiler.
+func readSushiXSwapMetadata(t *testing.T) []byte {
+	var fixture struct {
+		Raw string `json:"raw"`
+	}
+	require.NoError(t, json.Unmarshal(tests.ReadJsonBytesForTest(t, "SushiXSwapMetadata").Bytes, &fixture))
+	require.NotEmpty(t, fixture.Raw)
+	return []byte(fixture.Raw)
+}
+
+func TestVerifyMetadataHash(t *testing.T) {
+	raw := readSushiXSwapMetadata(t)
+	swarmData := []byte(`{"compiler":{"version":"0.5.17+commit.d19bba13"},"language":"Solidity"}`)
+	largeSwarmData := make([]byte, 3*swarmChunkSize+17)
+	for i := range largeSwarmData {
+		largeSwarmData[i] = byte(i)
+	}
+
+	testCases := []struct {
+		name    string
+		cid     string
+		data    []byte
+		wantErr error
+		wantAny bool
+	}{
+		{
+			name: "IPFS Metadata",
+			cid:  sushiXSwapCID,
+			data: raw,
+		},
+		{
+			name:    "IPFS Tampered Metadata",
+			cid:     sushiXSwapCID,
+			data:    append([]byte{' '}, raw...),
+			wantErr: ErrHashMismatch,
+		},
+		{
+			name: "Bzzr0 Metadata",
+			cid:  "bzzr0://" + hex.EncodeToString(swarmHash(swarmData, bzzr0ChunkHash)),
+			data: swarmData,
+		},
+		{
+			name: "Bzzr1 Metadata",
+			cid:  "bzzr1://" + hex.EncodeToString(swarmHash(swarmData, bzzr1ChunkHash)),
+			data: swarmData,
+		},
+		{
+			name: "Bzz Metadata Matching Bzzr1",
+			cid:  "bzz://" + hex.EncodeToString(swarmHash(swarmData, bzzr1ChunkHash)),
+			data: swarmData,
+		},
+		{
+			name: "Bzzr1 Multi Chunk Metadata",
+			cid:  "bzzr1://" + hex.EncodeToString(swarmHash(largeSwarmData, bzzr1ChunkHash)),
+			data: largeSwarmData,
+		},
+		{
+			name:    "Bzzr1 Hash Of Bzzr0 Metadata",
+			cid:     "bzzr1://" + hex.EncodeToString(swarmHash(swarmData, bzzr0ChunkHash)),
+			data:    swarmData,
+			wantErr: ErrHashMismatch,
+		},
+		{
+			name:    "Unsupported Scheme",
+			cid:     "ar://QmPL7gzcnyeyKUqQCJsvc5qbc9hqaopuRLtfuyLNsgn5oS",
+			data:    raw,
+			wantErr: ErrUnsupportedCID,
+		},
+		{
+			name:    "Missing Scheme",
+			cid:     "QmPL7gzcnyeyKUqQCJsvc5qbc9hqaopuRLtfuyLNsgn5oS",
+			data:    raw,
+			wantAny: true,
+		},
+		{
+			name:    "Invalid Swarm Hash",
+			cid:     "bzzr1://abcd",
+			data:    swarmData,
+			wantAny: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := VerifyMetadataHash(testCase.cid, testCase.data)
+			switch {
+			case testCase.wantErr != nil:
+				assert.ErrorIs(t, err, testCase.wantErr)
+			case testCase.wantAny:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestSwarmHashKnownAnswers checks the swarm hashes against the vectors the Solidity compiler tests the hashes
+// it embeds into the CBOR metadata against, so that both schemes are pinned independently of swarmHash itself.
+func TestSwarmHashKnownAnswers(t *testing.T) {
+	zeros := func(n int) []byte {
+		return make([]byte, n)
+	}
+
+	testCases := []struct {
+		name string
+		cid  string
+		data []byte
+	}{
+		{
+			name: "Bzzr0 Empty",
+			cid:  "bzzr0://011b4d03dd8c01f1049143cf9c4c817e4b167f1d1b83e5c6f0f10d89ba1e7bce",
+			data: zeros(0),
+		},
+		{
+			name: "Bzzr0 Chunk Minus One",
+			cid:  "bzzr0://32f0faabc4265ac238cd945087133ce3d7e9bb2e536053a812b5373c54043adb",
+			data: zeros(0x1000 - 1),
+		},
+		{
+			name: "Bzzr0 Chunk",
+			cid:  "bzzr0://411dd45de7246e94589ff5888362c41e85bd3e582a92d0fda8f0e90b76439bec",
+			data: zeros(0x1000),
+		},
+		{
+			name: "Bzzr1 Empty",
+			cid:  "bzzr1://b34ca8c22b9e982354f9c7f50b470d66db428d880c8a904d5fe4ec9713171526",
+			data: zeros(0),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.NoError(t, VerifyMetadataHash(testCase.cid, testCase.data))
+		})
+	}
+}
+
+func TestGetCIDsFromBytecode(t *testing.T) {
+	bzzr1, err := hex.DecodeString("64f4e3ff7a9e0a5b0b5a1bd2cb5e1c6b1c07b3d2a7c1b5e4fb0e8c3f2d1a0b9c")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		metadata *bytecode.Metadata
+		want     []string
+	}{
+		{
+			name:     "Nil Metadata",
+			metadata: nil,
+			want:     []string{},
+		},
+		{
+			name: "IPFS Metadata",
+			metadata: &bytecode.Metadata{
+				Ipfs: []byte{0x12, 0x20, 0x0e, 0x3f, 0x7c, 0x1d, 0x5a, 0x8b, 0x3e, 0x4f, 0x6a, 0x2b, 0x9c, 0x0d, 0x1e, 0x2f, 0x3a, 0x4b, 0x5c, 0x6d, 0x7e, 0x8f, 0x90, 0xa1, 0xb2, 0xc3, 0xd4, 0xe5, 0xf6, 0x07, 0x18, 0x29, 0x3a, 0x4b},
+			},
+			want: []string{"ipfs://QmPJGZeDt3Jq65zJitQK8mJbmRrF3tKH64mAv4TLV6ELzW"},
+		},
+		{
+			name: "Swarm Metadata",
+			metadata: &bytecode.Metadata{
+				Bzzr1: bzzr1,
+			},
+			want: []string{"bzzr1://64f4e3ff7a9e0a5b0b5a1bd2cb5e1c6b1c07b3d2a7c1b5e4fb0e8c3f2d1a0b9c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, GetCIDsFromBytecode(testCase.metadata))
+		})
+	}
+}
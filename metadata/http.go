@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// DefaultIpfsGateway is the public IPFS HTTP gateway used when no gateway is provided.
+const DefaultIpfsGateway = "https://ipfs.io"
+
+// maxMetadataSize is the largest metadata, or source, in bytes, read from HTTP gateways.
+const maxMetadataSize = 16 * 1024 * 1024
+
+// HttpProvider retrieves contract metadata from an IPFS HTTP gateway, such as ipfs.io or a local IPFS node gateway.
+type HttpProvider struct {
+	ctx     context.Context // The context to be used in gateway requests.
+	client  *http.Client    // The HTTP client used to reach the gateway.
+	gateway string          // The base URL of the gateway, without the /ipfs path.
+}
+
+// NewHttpProvider creates a new instance of HttpProvider for the gateway.
+// If the gateway is empty, DefaultIpfsGateway is used, and if the client is nil, http.DefaultClient is used.
+func NewHttpProvider(ctx context.Context, gateway string, client *http.Client) (Provider, error) {
+	if gateway == "" {
+		gateway = DefaultIpfsGateway
+	}
+
+	if !strings.HasPrefix(gateway, "http://") && !strings.HasPrefix(gateway, "https://") {
+		return nil, fmt.Errorf("invalid gateway %q: expected http or https url", gateway)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return Provider(&HttpProvider{
+		ctx:     ctx,
+		client:  client,
+		gateway: strings.TrimSuffix(gateway, "/"),
+	}), nil
+}
+
+// GetMetadataByCID retrieves the metadata of a contract by its ipfs://<cid> content identifier.
+func (p *HttpProvider) GetMetadataByCID(cid string) (*ContractMetadata, error) {
+	return p.GetMetadataByCIDWithContext(p.ctx, cid)
+}
+
+// GetMetadataByCIDWithContext retrieves the metadata of a contract by its ipfs://<cid> content identifier.
+// Sources whose content is not part of the metadata are fetched from the gateway as well, through their
+// dweb:/ipfs/ urls, and rejected unless they hash to the keccak256 hash listed for them within the metadata.
+func (p *HttpProvider) GetMetadataByCIDWithContext(ctx context.Context, cid string) (*ContractMetadata, error) {
+	scheme, hash, err := parseCID(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheme != SchemeIpfs {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCID, cid)
+	}
+
+	data, err := httpGet(ctx, p.client, fmt.Sprintf("%s/ipfs/%s", p.gateway, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn, err := decodeMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for sourceName, source := range toReturn.Sources {
+		if len(source.Content) >= 10 {
+			continue
+		}
+
+		for _, url := range source.Urls {
+			if !strings.HasPrefix(url, "dweb:/ipfs/") {
+				continue
+			}
+
+			content, err := httpGet(ctx, p.client, fmt.Sprintf("%s/ipfs/%s", p.gateway, strings.TrimPrefix(url, "dweb:/ipfs/")))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch source %s: %w", sourceName, err)
+			}
+
+			if err := VerifySourceHash(source.Keccak256, content); err != nil {
+				return nil, fmt.Errorf("failed to verify source %s: %w", sourceName, err)
+			}
+
+			source.Content = string(content)
+			toReturn.Sources[sourceName] = source
+			break
+		}
+	}
+
+	return toReturn, nil
+}
+
+// decodeMetadata decodes the metadata, keeping the raw metadata around as its hash is calculated from it.
+func decodeMetadata(data []byte) (*ContractMetadata, error) {
+	var toReturn ContractMetadata
+	if err := json.Unmarshal(data, &toReturn); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	toReturn.Raw = string(data)
+	return &toReturn, nil
+}
+
+// httpGet returns the body of the response to the GET request, failing on non successful responses.
+func httpGet(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrMetadataNotFound, url)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", url, response.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	if len(data) > maxMetadataSize {
+		return nil, errors.New("response exceeds the maximum metadata size")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,101 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cid "github.com/ipfs/go-cid"
+)
+
+// LocalProvider retrieves contract metadata from a local content addressed directory, where metadata is stored
+// under <scheme>/<hash> with hashes being CIDs for IPFS and hex encoded hashes for Swarm. It is usually used as a
+// cache in front of network providers, see FallbackProvider.
+type LocalProvider struct {
+	dir string // The root of the content addressed directory.
+}
+
+// NewLocalProvider creates a new instance of LocalProvider, creating the directory if it does not exist.
+func NewLocalProvider(dir string) (*LocalProvider, error) {
+	if dir == "" {
+		return nil, errors.New("local provider directory must be set")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create local provider directory %s: %w", dir, err)
+	}
+
+	return &LocalProvider{dir: dir}, nil
+}
+
+// GetMetadataByCID retrieves the metadata of a contract stored under the content identifier.
+func (p *LocalProvider) GetMetadataByCID(cid string) (*ContractMetadata, error) {
+	return p.GetMetadataByCIDWithContext(context.Background(), cid)
+}
+
+// GetMetadataByCIDWithContext retrieves the metadata of a contract stored under the content identifier.
+func (p *LocalProvider) GetMetadataByCIDWithContext(_ context.Context, cid string) (*ContractMetadata, error) {
+	path, err := p.getPath(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrMetadataNotFound, cid)
+		}
+		return nil, fmt.Errorf("failed to read metadata %s: %w", cid, err)
+	}
+
+	return decodeMetadata(data)
+}
+
+// Store writes the raw metadata under the content identifier, after checking that it hashes back to it.
+func (p *LocalProvider) Store(cid string, data []byte) error {
+	if err := VerifyMetadataHash(cid, data); err != nil {
+		return err
+	}
+
+	path, err := p.getPath(cid)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata %s: %w", cid, err)
+	}
+
+	return nil
+}
+
+// getPath returns the path of the content identifier. Hashes are normalized, so that the same content is stored
+// once regardless of the encoding of its identifier, with bzz, bzzr0 and bzzr1 sharing the Swarm directory.
+func (p *LocalProvider) getPath(contentId string) (string, error) {
+	scheme, hash, err := parseCID(contentId)
+	if err != nil {
+		return "", err
+	}
+
+	if scheme == SchemeIpfs {
+		decoded, err := cid.Decode(hash)
+		if err != nil {
+			return "", fmt.Errorf("invalid IPFS hash: %w", err)
+		}
+		return filepath.Join(p.dir, SchemeIpfs, decoded.String()), nil
+	}
+
+	decoded, err := decodeSwarmHash(hash)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(p.dir, SchemeBzz, hex.EncodeToString(decoded)), nil
+}
@@ -1,8 +1,20 @@
 package metadata
 
+import "context"
+
 // Provider is the interface that wraps the basic interaction with contract metadata from different
 // sources such as IPFS, SWARM, etc....
 type Provider interface {
 	// GetMetadataByCID returns the metadata of a contract by the CID (Content Identifier) of the contract
 	GetMetadataByCID(cid string) (*ContractMetadata, error)
 }
+
+// ContextProvider is implemented by providers whose lookups can be cancelled through the context,
+// which is used by the FallbackProvider to enforce per-provider timeouts.
+type ContextProvider interface {
+	Provider
+
+	// GetMetadataByCIDWithContext returns the metadata of a contract by the CID (Content Identifier) of the contract,
+	// giving up once the context is done.
+	GetMetadataByCIDWithContext(ctx context.Context, cid string) (*ContractMetadata, error)
+}
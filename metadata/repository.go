@@ -0,0 +1,308 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/goccy/go-json"
+	"github.com/unpackdev/solgo/utils"
+)
+
+// MatchType is the kind of match a contract is stored under within the repository.
+type MatchType string
+
+const (
+	// FullMatch is used for contracts whose bytecode, including the metadata hash, matches the compiled bytecode.
+	FullMatch MatchType = "full_match"
+	// PartialMatch is used for contracts whose bytecode matches the compiled bytecode apart from the metadata hash.
+	PartialMatch MatchType = "partial_match"
+)
+
+const (
+	metadataFileName        = "metadata.json"
+	sourcesDirName          = "sources"
+	constructorArgsFileName = "constructor-args.txt"
+	libraryMapFileName      = "library-map.json"
+)
+
+// RepositoryEntry is a verified contract stored within the repository.
+type RepositoryEntry struct {
+	Match                MatchType                 `json:"match"`
+	ChainID              utils.NetworkID           `json:"chain_id"`
+	Address              common.Address            `json:"address"`
+	Path                 string                    `json:"path"`
+	Metadata             *ContractMetadata         `json:"metadata"`
+	ConstructorArguments []byte                    `json:"constructor_arguments,omitempty"`
+	Libraries            map[string]common.Address `json:"libraries,omitempty"`
+}
+
+// IsFullMatch returns true if the contract is stored as a full match.
+func (e *RepositoryEntry) IsFullMatch() bool {
+	return e.Match == FullMatch
+}
+
+// GetMetadata returns the metadata of the contract, with the content of every source.
+func (e *RepositoryEntry) GetMetadata() *ContractMetadata {
+	return e.Metadata
+}
+
+// Repository reads and writes verified contracts on the local filesystem, using the directory structure of
+// Sourcify repositories: <match>/<chainId>/<address>/metadata.json along with the sources under the sources
+// directory, keyed by their source unit names. The root of the repository is the directory holding the
+// full_match and partial_match directories, which is the contracts directory of a Sourcify repository.
+type Repository struct {
+	root string
+}
+
+// NewRepository creates a repository at the root directory, creating the directory if it does not exist.
+func NewRepository(root string) (*Repository, error) {
+	if root == "" {
+		return nil, errors.New("repository root must be set")
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create repository root %s: %w", root, err)
+	}
+
+	return &Repository{root: root}, nil
+}
+
+// GetRoot returns the root directory of the repository.
+func (r *Repository) GetRoot() string {
+	return r.root
+}
+
+// GetContractPath returns the directory of the contract under the match type.
+func (r *Repository) GetContractPath(match MatchType, chainID utils.NetworkID, address common.Address) string {
+	return filepath.Join(r.root, string(match), chainID.String(), address.Hex())
+}
+
+// Has returns true if the contract is stored within the repository, either as a full or a partial match.
+func (r *Repository) Has(chainID utils.NetworkID, address common.Address) bool {
+	for _, match := range []MatchType{FullMatch, PartialMatch} {
+		if _, err := os.Stat(filepath.Join(r.GetContractPath(match, chainID, address), metadataFileName)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Get reads the contract from the repository, preferring full matches over partial ones.
+// Content of sources is read from the sources directory whenever it is not part of the metadata.
+func (r *Repository) Get(chainID utils.NetworkID, address common.Address) (*RepositoryEntry, error) {
+	for _, match := range []MatchType{FullMatch, PartialMatch} {
+		path := r.GetContractPath(match, chainID, address)
+		data, err := os.ReadFile(filepath.Join(path, metadataFileName))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read metadata of %s: %w", address.Hex(), err)
+		}
+
+		var md ContractMetadata
+		if err := json.Unmarshal(data, &md); err != nil {
+			return nil, fmt.Errorf("failed to decode metadata of %s: %w", address.Hex(), err)
+		}
+		md.Raw = string(data)
+
+		for name, source := range md.Sources {
+			if source.Content != "" {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(path, sourcesDirName, sanitizeSourcePath(name)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read source %s of %s: %w", name, address.Hex(), err)
+			}
+			source.Content = string(content)
+			md.Sources[name] = source
+		}
+
+		toReturn := &RepositoryEntry{
+			Match:    match,
+			ChainID:  chainID,
+			Address:  address,
+			Path:     path,
+			Metadata: &md,
+		}
+
+		if args, err := os.ReadFile(filepath.Join(path, constructorArgsFileName)); err == nil {
+			if toReturn.ConstructorArguments, err = hexutil.Decode(strings.TrimSpace(string(args))); err != nil {
+				return nil, fmt.Errorf("failed to decode constructor arguments of %s: %w", address.Hex(), err)
+			}
+		}
+
+		if libraries, err := os.ReadFile(filepath.Join(path, libraryMapFileName)); err == nil {
+			if err := json.Unmarshal(libraries, &toReturn.Libraries); err != nil {
+				return nil, fmt.Errorf("failed to decode library map of %s: %w", address.Hex(), err)
+			}
+		}
+
+		return toReturn, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s on chain %s", ErrContractNotFound, address.Hex(), chainID.String())
+}
+
+// Store writes the contract into the repository. Every source of the metadata must have its content set.
+// Storing a full match replaces an existing partial match, while a full match is never replaced by a partial one.
+func (r *Repository) Store(entry *RepositoryEntry) error {
+	if entry == nil || entry.Metadata == nil {
+		return errors.New("repository entry and its metadata must be set")
+	}
+
+	if entry.Match != FullMatch && entry.Match != PartialMatch {
+		return fmt.Errorf("invalid match type %q", entry.Match)
+	}
+
+	if entry.Match == PartialMatch {
+		if _, err := os.Stat(r.GetContractPath(FullMatch, entry.ChainID, entry.Address)); err == nil {
+			return fmt.Errorf("%w: %s on chain %s", ErrContractFullyVerified, entry.Address.Hex(), entry.ChainID.String())
+		}
+	}
+
+	for name, source := range entry.Metadata.Sources {
+		if source.Content == "" {
+			return fmt.Errorf("content of source %s is missing", name)
+		}
+	}
+
+	data, err := entry.Metadata.toRepositoryJSON()
+	if err != nil {
+		return err
+	}
+
+	path := r.GetContractPath(entry.Match, entry.ChainID, entry.Address)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	// The entry is written into a temporary directory next to its final location first, so that a failed
+	// store never leaves behind a partially written contract or removes the one stored previously.
+	tmp, err := os.MkdirTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory for %s: %w", path, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := writeRepositoryFile(filepath.Join(tmp, metadataFileName), data); err != nil {
+		return err
+	}
+
+	for name, source := range entry.Metadata.Sources {
+		if err := writeRepositoryFile(filepath.Join(tmp, sourcesDirName, sanitizeSourcePath(name)), []byte(source.Content)); err != nil {
+			return err
+		}
+	}
+
+	if len(entry.ConstructorArguments) > 0 {
+		if err := writeRepositoryFile(filepath.Join(tmp, constructorArgsFileName), []byte(hexutil.Encode(entry.ConstructorArguments))); err != nil {
+			return err
+		}
+	}
+
+	if len(entry.Libraries) > 0 {
+		libraries, err := json.Marshal(entry.Libraries)
+		if err != nil {
+			return fmt.Errorf("failed to encode library map: %w", err)
+		}
+		if err := writeRepositoryFile(filepath.Join(tmp, libraryMapFileName), libraries); err != nil {
+			return err
+		}
+	}
+
+	if err := replaceDirectory(tmp, path); err != nil {
+		return err
+	}
+
+	if entry.Match == FullMatch {
+		if err := os.RemoveAll(r.GetContractPath(PartialMatch, entry.ChainID, entry.Address)); err != nil {
+			return fmt.Errorf("failed to remove partial match of %s: %w", entry.Address.Hex(), err)
+		}
+	}
+
+	entry.Path = path
+	return nil
+}
+
+// toRepositoryJSON returns the metadata as stored within the repository, which is the raw metadata whenever
+// available as the metadata hash within the bytecode is calculated from it.
+func (c *ContractMetadata) toRepositoryJSON() ([]byte, error) {
+	if c.Raw != "" {
+		return []byte(c.Raw), nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	delete(fields, "raw")
+
+	// Content of sources is stored within the sources directory instead.
+	if sources, ok := fields["sources"].(map[string]interface{}); ok {
+		for _, source := range sources {
+			if source, ok := source.(map[string]interface{}); ok {
+				delete(source, "content")
+			}
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// sanitizeSourcePath turns the source unit name into a path relative to the sources directory, so that names
+// such as absolute paths or ones containing parent directory references cannot escape the contract directory.
+func sanitizeSourcePath(name string) string {
+	return strings.TrimPrefix(filepath.Clean("/"+filepath.ToSlash(name)), "/")
+}
+
+// replaceDirectory renames the src directory into dst. An existing dst is moved aside first, as directories
+// cannot be renamed over non-empty ones, and is restored whenever the rename fails.
+func replaceDirectory(src, dst string) error {
+	backup := ""
+	if _, err := os.Stat(dst); err == nil {
+		backup = src + ".old"
+		if err := os.Rename(dst, backup); err != nil {
+			return fmt.Errorf("failed to move aside contract directory %s: %w", dst, err)
+		}
+	}
+
+	if err := os.Rename(src, dst); err != nil {
+		if backup != "" {
+			_ = os.Rename(backup, dst)
+		}
+		return fmt.Errorf("failed to move contract directory into %s: %w", dst, err)
+	}
+
+	if backup != "" {
+		if err := os.RemoveAll(backup); err != nil {
+			return fmt.Errorf("failed to remove previous contract directory %s: %w", backup, err)
+		}
+	}
+
+	return nil
+}
+
+// writeRepositoryFile writes the file, creating any parent directories.
+func writeRepositoryFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
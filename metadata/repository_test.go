@@ -0,0 +1,126 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/utils"
+)
+
+func TestRepository(t *testing.T) {
+	fixture, err := NewRepository(filepath.Join("..", "data", "tests", "sourcify"))
+	require.NoError(t, err)
+
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	require.True(t, fixture.Has(utils.EthereumNetworkID, address))
+	assert.False(t, fixture.Has(utils.BscNetworkID, address))
+
+	entry, err := fixture.Get(utils.EthereumNetworkID, address)
+	require.NoError(t, err)
+	assert.True(t, entry.IsFullMatch())
+	assert.Equal(t, "0.8.19+commit.7dd6d404", entry.GetMetadata().Compiler.Version)
+	assert.Equal(t, map[string]string{"contracts/Storage.sol": "Storage"}, entry.GetMetadata().Settings.CompilationTarget)
+	require.Len(t, entry.GetMetadata().Sources, 2)
+	assert.Contains(t, entry.GetMetadata().Sources["contracts/Storage.sol"].Content, "contract Storage is Ownable")
+	assert.Contains(t, entry.GetMetadata().Sources["contracts/Ownable.sol"].Content, "contract Ownable")
+
+	_, err = fixture.Get(utils.BscNetworkID, address)
+	assert.ErrorIs(t, err, ErrContractNotFound)
+
+	t.Run("Store And Upgrade Partial Match", func(t *testing.T) {
+		repository, err := NewRepository(t.TempDir())
+		require.NoError(t, err)
+
+		md := entry.GetMetadata()
+		stored := &RepositoryEntry{
+			Match:                PartialMatch,
+			ChainID:              utils.BscNetworkID,
+			Address:              address,
+			Metadata:             md,
+			ConstructorArguments: common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002a"),
+			Libraries:            map[string]common.Address{"contracts/Math.sol:Math": common.HexToAddress("0x1111111111111111111111111111111111111111")},
+		}
+		require.NoError(t, repository.Store(stored))
+		assert.Equal(t, repository.GetContractPath(PartialMatch, utils.BscNetworkID, address), stored.Path)
+
+		loaded, err := repository.Get(utils.BscNetworkID, address)
+		require.NoError(t, err)
+		assert.Equal(t, PartialMatch, loaded.Match)
+		assert.Equal(t, md.Raw, loaded.GetMetadata().Raw)
+		assert.Equal(t, md.Sources, loaded.GetMetadata().Sources)
+		assert.Equal(t, stored.ConstructorArguments, loaded.ConstructorArguments)
+		assert.Equal(t, stored.Libraries, loaded.Libraries)
+
+		// Full matches replace partial ones and are never replaced by these.
+		stored.Match = FullMatch
+		stored.ConstructorArguments = nil
+		require.NoError(t, repository.Store(stored))
+		_, err = os.Stat(repository.GetContractPath(PartialMatch, utils.BscNetworkID, address))
+		assert.True(t, os.IsNotExist(err))
+
+		loaded, err = repository.Get(utils.BscNetworkID, address)
+		require.NoError(t, err)
+		assert.True(t, loaded.IsFullMatch())
+		assert.Empty(t, loaded.ConstructorArguments)
+
+		stored.Match = PartialMatch
+		assert.ErrorIs(t, repository.Store(stored), ErrContractFullyVerified)
+
+		// Storing again replaces the entry without leaving any temporary directories behind.
+		stored.Match = FullMatch
+		stored.Libraries = nil
+		require.NoError(t, repository.Store(stored))
+		loaded, err = repository.Get(utils.BscNetworkID, address)
+		require.NoError(t, err)
+		assert.Empty(t, loaded.Libraries)
+
+		entries, err := os.ReadDir(filepath.Dir(stored.Path))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, filepath.Base(stored.Path), entries[0].Name())
+	})
+
+	t.Run("Store Metadata Without Raw", func(t *testing.T) {
+		repository, err := NewRepository(t.TempDir())
+		require.NoError(t, err)
+
+		md := &ContractMetadata{Version: 1, Language: "Solidity", Sources: map[string]ContractSource{
+			"/../../outside/Token.sol": {Content: "contract Token {}", Keccak256: "0x01"},
+		}}
+		md.Compiler.Version = "0.8.19+commit.7dd6d404"
+		require.NoError(t, repository.Store(&RepositoryEntry{Match: FullMatch, ChainID: utils.EthereumNetworkID, Address: address, Metadata: md}))
+
+		path := repository.GetContractPath(FullMatch, utils.EthereumNetworkID, address)
+		content, err := os.ReadFile(filepath.Join(path, "sources", "outside", "Token.sol"))
+		require.NoError(t, err)
+		assert.Equal(t, "contract Token {}", string(content))
+
+		raw, err := os.ReadFile(filepath.Join(path, "metadata.json"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), `"raw"`)
+		assert.NotContains(t, string(raw), `"content"`)
+
+		loaded, err := repository.Get(utils.EthereumNetworkID, address)
+		require.NoError(t, err)
+		assert.Equal(t, "0.8.19+commit.7dd6d404", loaded.GetMetadata().Compiler.Version)
+		assert.Equal(t, "contract Token {}", loaded.GetMetadata().Sources["/../../outside/Token.sol"].Content)
+	})
+
+	t.Run("Invalid Entries", func(t *testing.T) {
+		repository, err := NewRepository(t.TempDir())
+		require.NoError(t, err)
+
+		assert.Error(t, repository.Store(nil))
+		assert.Error(t, repository.Store(&RepositoryEntry{Match: "exact_match", Metadata: &ContractMetadata{}}))
+		assert.Error(t, repository.Store(&RepositoryEntry{Match: FullMatch, Metadata: &ContractMetadata{
+			Sources: map[string]ContractSource{"Token.sol": {Keccak256: "0x01"}},
+		}}))
+
+		_, err = NewRepository("")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package metadata
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultSwarmGateway is the public Swarm HTTP gateway used when no gateway is provided.
+const DefaultSwarmGateway = "https://swarm-gateways.net/bzz-raw:"
+
+// SwarmProvider retrieves contract metadata referenced by bzzr0 and bzzr1 hashes from a Swarm HTTP gateway.
+type SwarmProvider struct {
+	ctx     context.Context // The context to be used in gateway requests.
+	client  *http.Client    // The HTTP client used to reach the gateway.
+	gateway string          // The base URL raw content is requested from, followed by the hex encoded hash.
+}
+
+// NewSwarmProvider creates a new instance of SwarmProvider for the gateway.
+// If the gateway is empty, DefaultSwarmGateway is used, and if the client is nil, http.DefaultClient is used.
+func NewSwarmProvider(ctx context.Context, gateway string, client *http.Client) (Provider, error) {
+	if gateway == "" {
+		gateway = DefaultSwarmGateway
+	}
+
+	if !strings.HasPrefix(gateway, "http://") && !strings.HasPrefix(gateway, "https://") {
+		return nil, fmt.Errorf("invalid gateway %q: expected http or https url", gateway)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return Provider(&SwarmProvider{
+		ctx:     ctx,
+		client:  client,
+		gateway: strings.TrimSuffix(gateway, "/"),
+	}), nil
+}
+
+// GetMetadataByCID retrieves the metadata of a contract by its bzz://, bzzr0:// or bzzr1:// content identifier.
+func (p *SwarmProvider) GetMetadataByCID(cid string) (*ContractMetadata, error) {
+	return p.GetMetadataByCIDWithContext(p.ctx, cid)
+}
+
+// GetMetadataByCIDWithContext retrieves the metadata of a contract by its bzz://, bzzr0:// or bzzr1:// content
+// identifier. Hashes can be hex encoded or base58 encoded, as returned by bytecode.Metadata.
+func (p *SwarmProvider) GetMetadataByCIDWithContext(ctx context.Context, cid string) (*ContractMetadata, error) {
+	scheme, hash, err := parseCID(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheme == SchemeIpfs {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCID, cid)
+	}
+
+	decoded, err := decodeSwarmHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := httpGet(ctx, p.client, fmt.Sprintf("%s/%s", p.gateway, hex.EncodeToString(decoded)))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMetadata(data)
+}
@@ -64,7 +64,7 @@ type ContractMetadata struct {
 	} `json:"settings"`
 	Output struct {
 		Abi []interface{} `json:"abi"`
-	}
+	} `json:"output"`
 	Sources map[string]ContractSource `json:"sources"`
 }
 
@@ -3,6 +3,7 @@ package observers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync/atomic"
 
@@ -19,6 +20,37 @@ type BlockSubscriberOptions struct {
 	Head             bool     `mapstructure:"head" yaml:"head" json:"head"`                                           // Flag to indicate if subscribing to the latest block.
 	StartBlockNumber *big.Int `mapstructure:"start_block_number" yaml:"start_block_number" json:"start_block_number"` // The starting block number for the subscription.
 	EndBlockNumber   *big.Int `mapstructure:"end_block_number" yaml:"end_block_number" json:"end_block_number"`       // The ending block number for the subscription.
+	Confirmations    uint64   `mapstructure:"confirmations" yaml:"confirmations" json:"confirmations"`                // The number of blocks built on top of a block before it is emitted by SubscribeEvents.
+	ReorgDepth       int      `mapstructure:"reorg_depth" yaml:"reorg_depth" json:"reorg_depth"`                      // The number of recent blocks tracked for reorganizations, defaults to DefaultReorgDepth.
+	CheckpointKey    string   `mapstructure:"checkpoint_key" yaml:"checkpoint_key" json:"checkpoint_key"`             // The key checkpoints are stored under, defaults to the group and type.
+	Concurrency      int      `mapstructure:"concurrency" yaml:"concurrency" json:"concurrency"`                      // The maximum number of blocks fetched concurrently, defaults to DefaultBlockConcurrency.
+}
+
+// DefaultBlockConcurrency is the maximum number of blocks fetched concurrently when no concurrency is set.
+const DefaultBlockConcurrency = 10
+
+// GetConcurrency returns the maximum number of blocks fetched concurrently.
+func (o *BlockSubscriberOptions) GetConcurrency() int {
+	if o.Concurrency <= 0 {
+		return DefaultBlockConcurrency
+	}
+	return o.Concurrency
+}
+
+// GetReorgDepth returns the number of recent blocks tracked for reorganizations.
+func (o *BlockSubscriberOptions) GetReorgDepth() int {
+	if o.ReorgDepth <= 0 {
+		return DefaultReorgDepth
+	}
+	return o.ReorgDepth
+}
+
+// GetCheckpointKey returns the key checkpoints are stored under.
+func (o *BlockSubscriberOptions) GetCheckpointKey() string {
+	if o.CheckpointKey == "" {
+		return fmt.Sprintf("%d_%s_%s", o.NetworkID, o.Group, o.Type)
+	}
+	return o.CheckpointKey
 }
 
 // BlockSubscriber provides methods to subscribe to blockchain block headers.
@@ -27,6 +59,7 @@ type BlockSubscriber struct {
 	client *clients.ClientPool   // The client pool for accessing blockchain clients.
 	active atomic.Bool           // Flag to indicate if the subscriber is active.
 	sub    ethereum.Subscription // The Ethereum subscription object.
+	store  CheckpointStore       // The optional store checkpoints of SubscribeEvents are persisted in.
 }
 
 // NewBlockSubscriber creates a new block subscriber with the given context and client pool.
@@ -74,7 +107,7 @@ func (b *BlockSubscriber) SubscribeHeader(opts *BlockSubscriberOptions, blockCh
 			}
 		}
 	} else {
-		if opts.StartBlockNumber == nil && opts.EndBlockNumber == nil {
+		if opts.StartBlockNumber == nil || opts.EndBlockNumber == nil {
 			return errors.New("start and end block numbers are not set")
 		}
 
@@ -84,13 +117,10 @@ func (b *BlockSubscriber) SubscribeHeader(opts *BlockSubscriberOptions, blockCh
 
 		b.active.Store(true)
 
-		for i := opts.StartBlockNumber.Int64(); i <= opts.EndBlockNumber.Int64(); i++ {
-			block, err := client.BlockByNumber(b.ctx, big.NewInt(i))
-			if err != nil {
-				return err
-			}
-
+		if err := b.rangeBlocks(opts, func(block *types.Block) {
 			blockCh <- block.Header()
+		}); err != nil {
+			return err
 		}
 
 		b.active.Store(false)
@@ -138,7 +168,7 @@ func (b *BlockSubscriber) Subscribe(opts *BlockSubscriberOptions, blockCh chan *
 			}
 		}
 	} else {
-		if opts.StartBlockNumber == nil && opts.EndBlockNumber == nil {
+		if opts.StartBlockNumber == nil || opts.EndBlockNumber == nil {
 			return errors.New("start and end block numbers are not set")
 		}
 
@@ -148,13 +178,10 @@ func (b *BlockSubscriber) Subscribe(opts *BlockSubscriberOptions, blockCh chan *
 
 		b.active.Store(true)
 
-		for i := opts.StartBlockNumber.Int64(); i <= opts.EndBlockNumber.Int64(); i++ {
-			block, err := client.BlockByNumber(b.ctx, big.NewInt(i))
-			if err != nil {
-				return err
-			}
-
+		if err := b.rangeBlocks(opts, func(block *types.Block) {
 			blockCh <- block
+		}); err != nil {
+			return err
 		}
 
 		b.active.Store(false)
@@ -163,6 +190,24 @@ func (b *BlockSubscriber) Subscribe(opts *BlockSubscriberOptions, blockCh chan *
 	return nil
 }
 
+// rangeBlocks fetches the blocks of the range concurrently, in batches bounded by the concurrency option,
+// and hands them over in ascending order.
+func (b *BlockSubscriber) rangeBlocks(opts *BlockSubscriberOptions, fn func(block *types.Block)) error {
+	end := opts.EndBlockNumber.Uint64()
+	for start := opts.StartBlockNumber.Uint64(); start <= end; start += uint64(opts.GetConcurrency()) {
+		blocks, err := fetchBlocks(b.ctx, b.client, opts, start, min(start+uint64(opts.GetConcurrency())-1, end))
+		if err != nil {
+			return err
+		}
+
+		for _, block := range blocks {
+			fn(block)
+		}
+	}
+
+	return nil
+}
+
 // Close terminates the block subscription and releases any associated resources.
 func (b *BlockSubscriber) Close() error {
 	if b.active.Load() {
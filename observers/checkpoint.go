@@ -0,0 +1,186 @@
+package observers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/redis/go-redis/v9"
+)
+
+// BlockRef identifies a block by its number and hash.
+type BlockRef struct {
+	Number uint64      `json:"number"` // The number of the block.
+	Hash   common.Hash `json:"hash"`   // The hash of the block.
+}
+
+// Checkpoint represents the progress of a block subscription, holding the most recently emitted blocks so that a
+// restarted subscription resumes after the latest of them and can detect reorganizations that happened meanwhile.
+type Checkpoint struct {
+	Blocks []BlockRef `json:"blocks"` // The most recently emitted blocks, in ascending order.
+}
+
+// GetLatest returns the most recently emitted block, or nil if the checkpoint is empty.
+func (c *Checkpoint) GetLatest() *BlockRef {
+	if c == nil || len(c.Blocks) == 0 {
+		return nil
+	}
+	return &c.Blocks[len(c.Blocks)-1]
+}
+
+// CheckpointStore is the interface that wraps the persistence of block subscription checkpoints.
+type CheckpointStore interface {
+	// Load returns the checkpoint stored under the key, or nil if there is none.
+	Load(ctx context.Context, key string) (*Checkpoint, error)
+
+	// Save stores the checkpoint under the key, replacing any previous checkpoint.
+	Save(ctx context.Context, key string, checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory. It is mostly useful for tests and short lived processes.
+type MemoryCheckpointStore struct {
+	mu          sync.RWMutex
+	checkpoints map[string]*Checkpoint
+}
+
+// NewMemoryCheckpointStore creates a new instance of MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		checkpoints: make(map[string]*Checkpoint),
+	}
+}
+
+// Load returns the checkpoint stored under the key, or nil if there is none.
+func (s *MemoryCheckpointStore) Load(_ context.Context, key string) (*Checkpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	checkpoint, ok := s.checkpoints[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &Checkpoint{Blocks: append([]BlockRef{}, checkpoint.Blocks...)}, nil
+}
+
+// Save stores the checkpoint under the key, replacing any previous checkpoint.
+func (s *MemoryCheckpointStore) Save(_ context.Context, key string, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[key] = &Checkpoint{Blocks: append([]BlockRef{}, checkpoint.Blocks...)}
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints as JSON files within a directory, one file per key.
+type FileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore creates a new instance of FileCheckpointStore, creating the directory if it does not exist.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if dir == "" {
+		return nil, errors.New("checkpoint directory must be set")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory %s: %w", dir, err)
+	}
+
+	return &FileCheckpointStore{dir: dir}, nil
+}
+
+// Load returns the checkpoint stored under the key, or nil if there is none.
+func (s *FileCheckpointStore) Load(_ context.Context, key string) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.getPath(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", key, err)
+	}
+
+	var toReturn Checkpoint
+	if err := json.Unmarshal(data, &toReturn); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", key, err)
+	}
+
+	return &toReturn, nil
+}
+
+// Save stores the checkpoint under the key, replacing any previous checkpoint. The checkpoint is written to a
+// temporary file first, so that a process stopped mid write does not leave a corrupted checkpoint behind.
+func (s *FileCheckpointStore) Save(_ context.Context, key string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint %s: %w", key, err)
+	}
+
+	path := s.getPath(key)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", key, err)
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// getPath returns the path of the checkpoint file of the key.
+func (s *FileCheckpointStore) getPath(key string) string {
+	return filepath.Join(s.dir, strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(key)+".json")
+}
+
+// RedisCheckpointStore keeps checkpoints in Redis, under the key prefixed with "checkpoint::".
+type RedisCheckpointStore struct {
+	client *redis.Client
+}
+
+// NewRedisCheckpointStore creates a new instance of RedisCheckpointStore.
+func NewRedisCheckpointStore(client *redis.Client) (*RedisCheckpointStore, error) {
+	if client == nil {
+		return nil, errors.New("redis client must be set")
+	}
+
+	return &RedisCheckpointStore{client: client}, nil
+}
+
+// Load returns the checkpoint stored under the key, or nil if there is none.
+func (s *RedisCheckpointStore) Load(ctx context.Context, key string) (*Checkpoint, error) {
+	data, err := s.client.Get(ctx, "checkpoint::"+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", key, err)
+	}
+
+	var toReturn Checkpoint
+	if err := json.Unmarshal(data, &toReturn); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", key, err)
+	}
+
+	return &toReturn, nil
+}
+
+// Save stores the checkpoint under the key, replacing any previous checkpoint.
+func (s *RedisCheckpointStore) Save(ctx context.Context, key string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint %s: %w", key, err)
+	}
+
+	if err := s.client.Set(ctx, "checkpoint::"+key, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package observers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStores(t *testing.T) {
+	fileStore, err := NewFileCheckpointStore(t.TempDir())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		store CheckpointStore
+	}{
+		{
+			name:  "Memory Checkpoint Store",
+			store: NewMemoryCheckpointStore(),
+		},
+		{
+			name:  "File Checkpoint Store",
+			store: fileStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			checkpoint, err := tt.store.Load(ctx, "56_bsc/archive")
+			require.NoError(t, err)
+			assert.Nil(t, checkpoint)
+			assert.Nil(t, checkpoint.GetLatest())
+
+			want := &Checkpoint{Blocks: []BlockRef{
+				{Number: 31913866, Hash: common.HexToHash("0x01")},
+				{Number: 31913867, Hash: common.HexToHash("0x02")},
+			}}
+			require.NoError(t, tt.store.Save(ctx, "56_bsc/archive", want))
+
+			checkpoint, err = tt.store.Load(ctx, "56_bsc/archive")
+			require.NoError(t, err)
+			assert.Equal(t, want, checkpoint)
+			assert.Equal(t, &want.Blocks[1], checkpoint.GetLatest())
+
+			require.NoError(t, tt.store.Save(ctx, "56_bsc/archive", &Checkpoint{Blocks: want.Blocks[:1]}))
+			checkpoint, err = tt.store.Load(ctx, "56_bsc/archive")
+			require.NoError(t, err)
+			assert.Equal(t, uint64(31913866), checkpoint.GetLatest().Number)
+		})
+	}
+
+	_, err = NewFileCheckpointStore("")
+	assert.Error(t, err)
+}
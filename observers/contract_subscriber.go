@@ -3,36 +3,56 @@ package observers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
-	"sync"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/unpackdev/solgo/clients"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 // Contract represents a blockchain contract with its associated details.
 type Contract struct {
-	NetworkID       int64              `json:"network_id"`    // The network ID of the Ethereum blockchain.
-	NetworkGroup    string             `json:"network_group"` // The group identifier for the blockchain client.
-	NetworkType     string             `json:"network_type"`  // The type of the blockchain client.
-	ContractAddress common.Address     `json:"contract_addr"` // Ethereum address of the contract.
-	Block           *types.Block       `json:"block"`         // Block in which the contract transaction was included.
-	Transaction     *types.Transaction `json:"transaction"`   // Transaction that created the contract.
-	Receipt         *types.Receipt     `json:"receipt"`       // Receipt of the contract creation transaction.
+	NetworkID       int64              `json:"network_id"`     // The network ID of the Ethereum blockchain.
+	NetworkGroup    string             `json:"network_group"`  // The group identifier for the blockchain client.
+	NetworkType     string             `json:"network_type"`   // The type of the blockchain client.
+	ContractAddress common.Address     `json:"contract_addr"`  // Ethereum address of the contract.
+	Block           *types.Block       `json:"block"`          // Block in which the contract transaction was included.
+	Transaction     *types.Transaction `json:"transaction"`    // Transaction that created the contract.
+	Receipt         *types.Receipt     `json:"receipt"`        // Receipt of the contract creation transaction.
+	Deployer        common.Address     `json:"deployer"`       // Transaction sender, or the factory contract for internal deployments.
+	Internal        bool               `json:"internal"`       // If true, the contract was deployed by another contract.
+	CreationType    CreationType       `json:"creation_type"`  // The opcode the contract was deployed with.
+	Salt            *common.Hash       `json:"salt"`           // The CREATE2 salt, if it could be recovered.
+	InitCodeHash    common.Hash        `json:"init_code_hash"` // Keccak256 hash of the code executed to deploy the contract.
 }
 
+// DefaultContractConcurrency is the maximum number of receipts fetched concurrently when no concurrency is set.
+const DefaultContractConcurrency = 10
+
 // ContractSubscriberOptions defines the options for configuring a contract subscriber.
 type ContractSubscriberOptions struct {
-	NetworkID        int64    `mapstructure:"network_id" yaml:"network_id" json:"network_id"`                         // The network ID of the Ethereum blockchain.
-	Group            string   `mapstructure:"group" yaml:"group" json:"group"`                                        // The group identifier for the blockchain client.
-	Type             string   `mapstructure:"type" yaml:"type" json:"type"`                                           // The type of the blockchain client.
-	Head             bool     `mapstructure:"head" yaml:"head" json:"head"`                                           // If true, subscribes to the latest block. Otherwise, subscribes to a range.
-	StartBlockNumber *big.Int `mapstructure:"start_block_number" yaml:"start_block_number" json:"start_block_number"` // Starting block number for the subscription.
-	EndBlockNumber   *big.Int `mapstructure:"end_block_number" yaml:"end_block_number" json:"end_block_number"`       // Ending block number for the subscription.
+	NetworkID        int64      `mapstructure:"network_id" yaml:"network_id" json:"network_id"`                         // The network ID of the Ethereum blockchain.
+	Group            string     `mapstructure:"group" yaml:"group" json:"group"`                                        // The group identifier for the blockchain client.
+	Type             string     `mapstructure:"type" yaml:"type" json:"type"`                                           // The type of the blockchain client.
+	Head             bool       `mapstructure:"head" yaml:"head" json:"head"`                                           // If true, subscribes to the latest block. Otherwise, subscribes to a range.
+	StartBlockNumber *big.Int   `mapstructure:"start_block_number" yaml:"start_block_number" json:"start_block_number"` // Starting block number for the subscription.
+	EndBlockNumber   *big.Int   `mapstructure:"end_block_number" yaml:"end_block_number" json:"end_block_number"`       // Ending block number for the subscription.
+	Tracer           TracerType `mapstructure:"tracer" yaml:"tracer" json:"tracer"`                                     // The tracing API used to discover contracts deployed by other contracts, disabled if empty.
+	Concurrency      int        `mapstructure:"concurrency" yaml:"concurrency" json:"concurrency"`                      // Maximum number of receipts fetched concurrently, defaults to DefaultContractConcurrency.
+}
+
+// GetConcurrency returns the maximum number of receipts fetched concurrently.
+func (o *ContractSubscriberOptions) GetConcurrency() int {
+	if o.Concurrency <= 0 {
+		return DefaultContractConcurrency
+	}
+	return o.Concurrency
 }
 
 // ContractSubscriber provides methods to subscribe to and interact with Ethereum contracts.
@@ -87,7 +107,7 @@ func (b *ContractSubscriber) Subscribe(opts *ContractSubscriberOptions, contract
 					zap.L().Error(
 						"failure while searching for block",
 						zap.Error(err),
-						zap.Int64("block_number", block.Number().Int64()),
+						zap.Int64("block_number", header.Number.Int64()),
 					)
 					continue
 				}
@@ -173,46 +193,100 @@ func (b *ContractSubscriber) Subscribe(opts *ContractSubscriberOptions, contract
 }
 
 // discoverContracts searches for contracts within a given block based on the provided options.
-// It returns a list of discovered contracts.
+// Contracts deployed by transactions are discovered through their receipts, while, when a tracer is set, contracts
+// deployed by other contracts are discovered through the block traces. Receipts are fetched concurrently, bounded
+// by the concurrency option. It returns a list of discovered contracts, in the order they were deployed.
 func (b *ContractSubscriber) discoverContracts(block *types.Block, opts *ContractSubscriberOptions) ([]*Contract, error) {
-	contracts := make([]*Contract, 0)
+	var creations map[common.Hash][]*creation
+	if opts.Tracer != TracerNone {
+		client := b.client.GetClientByGroupAndType(opts.Group, opts.Type)
+		if client == nil {
+			return nil, errors.New("client not found")
+		}
+
+		var err error
+		if creations, err = traceBlockCreations(b.ctx, client, block, opts.Tracer); err != nil {
+			return nil, err
+		}
+	}
 
-	errCh := make(chan error)
+	txs := block.Transactions()
+	results := make([][]*Contract, len(txs))
 
-	var wg sync.WaitGroup
+	g, ctx := errgroup.WithContext(b.ctx)
+	g.SetLimit(opts.GetConcurrency())
+
+	for i, tx := range txs {
+		// Only transactions without a recipient, or with contracts deployed within them, can deploy contracts.
+		if tx.To() != nil && len(creations[tx.Hash()]) == 0 {
+			continue
+		}
 
-	for _, tx := range block.Transactions() {
-		wg.Add(1)
-		go func(tx *types.Transaction) {
-			defer wg.Done()
+		g.Go(func() error {
 			client := b.client.GetClientByGroupAndType(opts.Group, opts.Type)
+			if client == nil {
+				return errors.New("client not found")
+			}
 
-			receipt, err := client.TransactionReceipt(b.ctx, tx.Hash())
+			receipt, err := client.TransactionReceipt(ctx, tx.Hash())
 			if err != nil {
-				errCh <- err
-				return
+				return fmt.Errorf("failed to get receipt of transaction %s: %w", tx.Hash().Hex(), err)
+			}
+
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return nil
 			}
 
-			if receipt.Status == types.ReceiptStatusSuccessful && receipt.ContractAddress != (common.Address{}) {
-				contracts = append(contracts, &Contract{
+			if receipt.ContractAddress != (common.Address{}) {
+				results[i] = append(results[i], &Contract{
 					NetworkID:       opts.NetworkID,
 					NetworkGroup:    opts.Group,
 					NetworkType:     opts.Type,
 					ContractAddress: receipt.ContractAddress,
+					Deployer:        getTransactionSender(tx),
+					CreationType:    CreationCreate,
+					InitCodeHash:    crypto.Keccak256Hash(tx.Data()),
 					Block:           block,
 					Transaction:     tx,
 					Receipt:         receipt,
 				})
 			}
-		}(tx)
-	}
 
-	wg.Wait()
+			for _, created := range creations[tx.Hash()] {
+				contract := &Contract{
+					NetworkID:       opts.NetworkID,
+					NetworkGroup:    opts.Group,
+					NetworkType:     opts.Type,
+					ContractAddress: created.Address,
+					Deployer:        created.Deployer,
+					Internal:        true,
+					CreationType:    created.Type,
+					InitCodeHash:    crypto.Keccak256Hash(created.InitCode),
+					Block:           block,
+					Transaction:     tx,
+					Receipt:         receipt,
+				}
+
+				// Clients not reporting the creation method are covered by the salt recovery as well.
+				if salt := discoverCreationSalt(created.Deployer, created.Address, contract.InitCodeHash, created.CallerInput); salt != nil {
+					contract.CreationType = CreationCreate2
+					contract.Salt = salt
+				}
+
+				results[i] = append(results[i], contract)
+			}
+
+			return nil
+		})
+	}
 
-	close(errCh)
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
 
-	if len(errCh) > 0 {
-		return contracts, <-errCh
+	contracts := make([]*Contract, 0)
+	for _, result := range results {
+		contracts = append(contracts, result...)
 	}
 
 	return contracts, nil
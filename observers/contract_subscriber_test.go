@@ -2,12 +2,22 @@ package observers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"encoding/json"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/unpackdev/solgo/clients"
 )
 
@@ -118,3 +128,203 @@ func TestNewContractSubscriber(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscoverContracts(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+
+	factory := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	tokenA := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	tokenB := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	// UniswapV2 style factory call, deploying the pair through CREATE2 with the sorted tokens as salt.
+	createPairInput := append(common.FromHex("0xc9c65396"), append(common.LeftPadBytes(tokenA.Bytes(), 32), common.LeftPadBytes(tokenB.Bytes(), 32)...)...)
+	pairInitCode := common.FromHex("0x6080604052348015600f57600080fd5b50603f80601d6000396000f3fe")
+	pairSalt := crypto.Keccak256Hash(tokenB.Bytes(), tokenA.Bytes())
+	pairAddress := crypto.CreateAddress2(factory, pairSalt, crypto.Keccak256(pairInitCode))
+
+	// Clone deployed through CREATE within a call that reverted, which reverts the clone as well.
+	cloneAddress := crypto.CreateAddress(factory, 7)
+
+	deployTx := signTestTransaction(t, key, signer, 0, nil, common.FromHex("0x6080604052"))
+	factoryTx := signTestTransaction(t, key, signer, 1, &factory, createPairInput)
+	revertedTx := signTestTransaction(t, key, signer, 2, &factory, common.FromHex("0x8124b78e"))
+	transferTx := signTestTransaction(t, key, signer, 3, &tokenA, nil)
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(100)}).WithBody(
+		[]*types.Transaction{deployTx, factoryTx, revertedTx, transferTx}, nil,
+	)
+
+	receipts := map[common.Hash]*types.Receipt{
+		deployTx.Hash():   {Status: types.ReceiptStatusSuccessful, TxHash: deployTx.Hash(), ContractAddress: crypto.CreateAddress(sender, 0), Logs: []*types.Log{}},
+		factoryTx.Hash():  {Status: types.ReceiptStatusSuccessful, TxHash: factoryTx.Hash(), Logs: []*types.Log{}},
+		revertedTx.Hash(): {Status: types.ReceiptStatusSuccessful, TxHash: revertedTx.Hash(), Logs: []*types.Log{}},
+		transferTx.Hash(): {Status: types.ReceiptStatusSuccessful, TxHash: transferTx.Hash(), Logs: []*types.Log{}},
+	}
+
+	callTraces := []map[string]any{
+		{"txHash": deployTx.Hash(), "result": map[string]any{"type": "CREATE", "from": sender, "to": crypto.CreateAddress(sender, 0), "input": hexutil.Bytes(deployTx.Data())}},
+		{"txHash": factoryTx.Hash(), "result": map[string]any{
+			"type": "CALL", "from": sender, "to": factory, "input": hexutil.Bytes(createPairInput),
+			"calls": []map[string]any{
+				{"type": "CREATE2", "from": factory, "to": pairAddress, "input": hexutil.Bytes(pairInitCode)},
+			},
+		}},
+		{"txHash": revertedTx.Hash(), "result": map[string]any{
+			"type": "CALL", "from": sender, "to": factory, "input": hexutil.Bytes(revertedTx.Data()),
+			"calls": []map[string]any{
+				{"type": "CALL", "from": factory, "to": tokenA, "input": "0x", "error": "execution reverted", "calls": []map[string]any{
+					{"type": "CREATE", "from": tokenA, "to": cloneAddress, "input": "0x3d602d80600a3d3981f3"},
+				}},
+			},
+		}},
+		{"txHash": transferTx.Hash(), "result": map[string]any{"type": "CALL", "from": sender, "to": tokenA, "input": "0x"}},
+	}
+
+	parityTraces := []map[string]any{
+		{"type": "create", "action": map[string]any{"from": sender, "init": hexutil.Bytes(deployTx.Data())}, "result": map[string]any{"address": crypto.CreateAddress(sender, 0)}, "traceAddress": []int{}, "transactionHash": deployTx.Hash()},
+		{"type": "call", "action": map[string]any{"from": sender, "to": factory, "input": hexutil.Bytes(createPairInput)}, "result": map[string]any{}, "traceAddress": []int{}, "transactionHash": factoryTx.Hash()},
+		{"type": "create", "action": map[string]any{"from": factory, "init": hexutil.Bytes(pairInitCode)}, "result": map[string]any{"address": pairAddress}, "traceAddress": []int{0}, "transactionHash": factoryTx.Hash()},
+		{"type": "call", "action": map[string]any{"from": sender, "to": factory, "input": hexutil.Bytes(revertedTx.Data())}, "result": map[string]any{}, "traceAddress": []int{}, "transactionHash": revertedTx.Hash()},
+		{"type": "call", "action": map[string]any{"from": factory, "to": tokenA, "input": "0x"}, "error": "Reverted", "traceAddress": []int{0}, "transactionHash": revertedTx.Hash()},
+		{"type": "create", "action": map[string]any{"from": tokenA, "init": "0x3d602d80600a3d3981f3"}, "result": map[string]any{"address": cloneAddress}, "traceAddress": []int{0, 0}, "transactionHash": revertedTx.Hash()},
+		{"type": "call", "action": map[string]any{"from": sender, "to": tokenA, "input": "0x"}, "result": map[string]any{}, "traceAddress": []int{}, "transactionHash": transferTx.Hash()},
+	}
+
+	var receiptRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []any           `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch request.Method {
+		case "net_version":
+			result = "1"
+		case "eth_getTransactionReceipt":
+			receiptRequests.Add(1)
+			result = receipts[common.HexToHash(request.Params[0].(string))]
+		case "debug_traceBlockByNumber":
+			result = callTraces
+		case "trace_block":
+			result = parityTraces
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	}))
+	defer server.Close()
+
+	client, err := clients.NewClientPool(context.Background(), &clients.Options{
+		Nodes: []clients.Node{
+			{
+				Group:             "ethereum",
+				Type:              "archive",
+				NetworkId:         1,
+				Endpoint:          server.URL,
+				ConcurrentClients: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	deployed := &Contract{
+		ContractAddress: crypto.CreateAddress(sender, 0),
+		Deployer:        sender,
+		CreationType:    CreationCreate,
+		InitCodeHash:    crypto.Keccak256Hash(deployTx.Data()),
+	}
+
+	pair := &Contract{
+		ContractAddress: pairAddress,
+		Deployer:        factory,
+		Internal:        true,
+		CreationType:    CreationCreate2,
+		Salt:            &pairSalt,
+		InitCodeHash:    crypto.Keccak256Hash(pairInitCode),
+	}
+
+	tests := []struct {
+		name             string
+		tracer           TracerType
+		want             []*Contract
+		wantReceipts     int32
+		wantTransactions []common.Hash
+	}{
+		{
+			name:             "Receipts Only",
+			tracer:           TracerNone,
+			want:             []*Contract{deployed},
+			wantReceipts:     1,
+			wantTransactions: []common.Hash{deployTx.Hash()},
+		},
+		{
+			name:             "Call Tracer",
+			tracer:           TracerCall,
+			want:             []*Contract{deployed, pair},
+			wantReceipts:     2,
+			wantTransactions: []common.Hash{deployTx.Hash(), factoryTx.Hash()},
+		},
+		{
+			name:             "Parity Tracer Without Creation Method",
+			tracer:           TracerParity,
+			want:             []*Contract{deployed, pair},
+			wantReceipts:     2,
+			wantTransactions: []common.Hash{deployTx.Hash(), factoryTx.Hash()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiptRequests.Store(0)
+
+			subscriber, err := NewContractSubscriber(context.Background(), client)
+			require.NoError(t, err)
+
+			contracts, err := subscriber.discoverContracts(block, &ContractSubscriberOptions{
+				NetworkID:   1,
+				Group:       "ethereum",
+				Type:        "archive",
+				Tracer:      tt.tracer,
+				Concurrency: 2,
+			})
+			require.NoError(t, err)
+			require.Len(t, contracts, len(tt.want))
+			assert.Equal(t, tt.wantReceipts, receiptRequests.Load())
+
+			for i, contract := range contracts {
+				assert.Equal(t, tt.want[i].ContractAddress, contract.ContractAddress)
+				assert.Equal(t, tt.want[i].Deployer, contract.Deployer)
+				assert.Equal(t, tt.want[i].Internal, contract.Internal)
+				assert.Equal(t, tt.want[i].CreationType, contract.CreationType)
+				assert.Equal(t, tt.want[i].Salt, contract.Salt)
+				assert.Equal(t, tt.want[i].InitCodeHash, contract.InitCodeHash)
+				assert.Equal(t, tt.wantTransactions[i], contract.Transaction.Hash())
+				assert.Equal(t, block, contract.Block)
+				assert.NotNil(t, contract.Receipt)
+			}
+		})
+	}
+}
+
+// signTestTransaction signs a legacy transaction with the key, deploying a contract if to is nil.
+func signTestTransaction(t *testing.T, key *ecdsa.PrivateKey, signer types.Signer, nonce uint64, to *common.Address, data []byte) *types.Transaction {
+	tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Gas:      1000000,
+		GasPrice: big.NewInt(1),
+		Data:     data,
+	})
+	require.NoError(t, err)
+	return tx
+}
@@ -1,5 +1,7 @@
 // Package observers provides tools for managing and interacting with Ethereum onchain data such as blocks, transactions, events and logs.
 // It provides a BlockSubscriber structure that allows for subscribing to block headers based on various criteria, such as the latest block or a range of blocks.
 // It also provides a ContractSubscriber structure that allows for subscribing to old or new contracts based on various criteria, such as the latest blocks or a range of blocks.
+// Contracts deployed by other contracts, such as factory pairs and clones, are discovered through block traces, either debug_traceBlockByNumber with the callTracer or Parity style trace_block, reporting their deployer, CREATE2 salt and init code hash.
+// Block subscriptions can also be reorg aware, emitting reverted events for orphaned blocks, waiting for a confirmations threshold and resuming from checkpoints persisted through a pluggable CheckpointStore.
 // The package is designed to be flexible and efficient, ensuring that Ethereum onchain data can be easily accessed based on the specific needs of the application.
 package observers
@@ -0,0 +1,327 @@
+package observers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/unpackdev/solgo/clients"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultReorgDepth is the number of recent blocks tracked for reorganizations when no depth is set.
+const DefaultReorgDepth = 64
+
+// ErrReorgTooDeep is returned when a chain reorganization goes deeper than the tracked blocks.
+var ErrReorgTooDeep = errors.New("chain reorganization is deeper than the tracked blocks")
+
+// BlockEventType defines the type of the block event.
+type BlockEventType string
+
+const (
+	// BlockEventNew is emitted for blocks that became part of the canonical chain.
+	BlockEventNew BlockEventType = "new"
+
+	// BlockEventReverted is emitted for previously emitted blocks orphaned by a chain reorganization.
+	BlockEventReverted BlockEventType = "reverted"
+)
+
+// BlockEvent represents a change of the canonical chain, as emitted by BlockSubscriber.SubscribeEvents.
+type BlockEvent struct {
+	Type  BlockEventType `json:"type"`  // The type of the event.
+	Ref   BlockRef       `json:"ref"`   // The number and hash of the block.
+	Block *types.Block   `json:"block"` // The block, nil for reverted blocks emitted before the subscription was restarted.
+}
+
+// trackedBlock represents a recently emitted block, the block being nil if it is only known from a checkpoint.
+type trackedBlock struct {
+	ref   BlockRef
+	block *types.Block
+}
+
+// SetCheckpointStore sets the store checkpoints of SubscribeEvents are persisted in.
+func (b *BlockSubscriber) SetCheckpointStore(store CheckpointStore) {
+	b.store = store
+}
+
+// SubscribeEvents subscribes to changes of the canonical chain based on the provided options.
+//
+// Blocks are emitted once they are as deep as the confirmations threshold. Recently emitted blocks are tracked, and
+// when a chain reorganization orphans any of them, reverted events are emitted for them, newest first, before the
+// blocks of the new canonical chain. If a checkpoint store is set, progress is persisted after every event, and a
+// restarted subscription resumes right after the latest emitted block, without gaps, regardless of the start block.
+//
+// It can either follow the head of the chain, or process a range of blocks and return.
+func (b *BlockSubscriber) SubscribeEvents(opts *BlockSubscriberOptions, eventsCh chan *BlockEvent) error {
+	if b.active.Load() {
+		return errors.New("block subscriber is already active")
+	}
+
+	client := b.client.GetClientByGroupAndType(opts.Group, opts.Type)
+	if client == nil {
+		return errors.New("client not found")
+	}
+
+	tracked, err := b.loadCheckpoint(opts)
+	if err != nil {
+		return err
+	}
+
+	head, err := client.HeaderByNumber(b.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var next uint64
+	switch {
+	case len(tracked) > 0:
+		next = tracked[len(tracked)-1].ref.Number + 1
+	case opts.StartBlockNumber != nil:
+		next = opts.StartBlockNumber.Uint64()
+	default:
+		next = confirmedNumber(head.Number.Uint64(), opts.Confirmations) + 1
+	}
+
+	if !opts.Head {
+		end := confirmedNumber(head.Number.Uint64(), opts.Confirmations)
+		if opts.EndBlockNumber != nil {
+			end = opts.EndBlockNumber.Uint64()
+		}
+
+		if opts.StartBlockNumber != nil && end < opts.StartBlockNumber.Uint64() {
+			return errors.New("end block number is less than start block number")
+		}
+
+		b.active.Store(true)
+		defer b.active.Store(false)
+
+		_, _, err := b.syncEvents(client, opts, tracked, next, end, eventsCh)
+		return err
+	}
+
+	headerCh := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(b.ctx, headerCh)
+	if err != nil {
+		return err
+	}
+	b.sub = sub
+
+	// Set subscriber as active
+	b.active.Store(true)
+	defer func() {
+		sub.Unsubscribe()
+		b.active.Store(false)
+	}()
+
+	// Catch up with the head first, as the next header might take a while to arrive.
+	if tracked, next, err = b.syncEvents(client, opts, tracked, next, confirmedNumber(head.Number.Uint64(), opts.Confirmations), eventsCh); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case header := <-headerCh:
+			if tracked, next, err = b.syncEvents(client, opts, tracked, next, confirmedNumber(header.Number.Uint64(), opts.Confirmations), eventsCh); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-b.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// syncEvents emits the events of the blocks from next up to and including the target block, rewinding the tracked
+// blocks whenever a block does not extend the latest of them. It returns the tracked blocks and the next block
+// number to be processed.
+func (b *BlockSubscriber) syncEvents(client *clients.Client, opts *BlockSubscriberOptions, tracked []*trackedBlock, next, target uint64, eventsCh chan *BlockEvent) ([]*trackedBlock, uint64, error) {
+	for next <= target {
+		end := next + uint64(opts.GetConcurrency()) - 1
+		if end > target {
+			end = target
+		}
+
+		blocks, err := fetchBlocks(b.ctx, b.client, opts, next, end)
+		if err != nil {
+			return tracked, next, err
+		}
+
+		for _, block := range blocks {
+			if len(tracked) > 0 && block.ParentHash() != tracked[len(tracked)-1].ref.Hash {
+				if tracked, err = b.rewind(client, opts, tracked, eventsCh); err != nil {
+					return tracked, next, err
+				}
+
+				// Blocks of the new canonical chain are fetched again from the fork point.
+				next = tracked[len(tracked)-1].ref.Number + 1
+				break
+			}
+
+			toTrack := &trackedBlock{
+				ref:   BlockRef{Number: block.NumberU64(), Hash: block.Hash()},
+				block: block,
+			}
+
+			if err := b.emit(eventsCh, &BlockEvent{Type: BlockEventNew, Ref: toTrack.ref, Block: block}); err != nil {
+				return tracked, next, err
+			}
+
+			tracked = append(tracked, toTrack)
+			if len(tracked) > opts.GetReorgDepth() {
+				tracked = tracked[len(tracked)-opts.GetReorgDepth():]
+			}
+
+			if err := b.saveCheckpoint(opts, tracked); err != nil {
+				return tracked, next, err
+			}
+
+			next = block.NumberU64() + 1
+		}
+	}
+
+	return tracked, next, nil
+}
+
+// rewind emits reverted events for the tracked blocks which are no longer part of the canonical chain, newest first,
+// and returns the tracked blocks up to and including the fork point. The fork point is located before anything is
+// emitted or persisted, so a reorganization deeper than the tracked blocks leaves them and the checkpoint untouched.
+func (b *BlockSubscriber) rewind(client *clients.Client, opts *BlockSubscriberOptions, tracked []*trackedBlock, eventsCh chan *BlockEvent) ([]*trackedBlock, error) {
+	fork := len(tracked)
+	for fork > 0 {
+		latest := tracked[fork-1]
+
+		header, err := client.HeaderByNumber(b.ctx, new(big.Int).SetUint64(latest.ref.Number))
+		if err != nil {
+			return tracked, err
+		}
+
+		if header.Hash() == latest.ref.Hash {
+			break
+		}
+
+		zap.L().Warn(
+			"block reverted by chain reorganization",
+			zap.Uint64("block_number", latest.ref.Number),
+			zap.String("block_hash", latest.ref.Hash.Hex()),
+			zap.String("canonical_hash", header.Hash().Hex()),
+		)
+
+		fork--
+	}
+
+	if fork == 0 {
+		return tracked, ErrReorgTooDeep
+	}
+
+	for i := len(tracked) - 1; i >= fork; i-- {
+		if err := b.emit(eventsCh, &BlockEvent{Type: BlockEventReverted, Ref: tracked[i].ref, Block: tracked[i].block}); err != nil {
+			return tracked, err
+		}
+	}
+
+	tracked = tracked[:fork]
+	if err := b.saveCheckpoint(opts, tracked); err != nil {
+		return tracked, err
+	}
+
+	return tracked, nil
+}
+
+// emit sends the event, giving up once the subscriber context is done.
+func (b *BlockSubscriber) emit(eventsCh chan *BlockEvent, event *BlockEvent) error {
+	select {
+	case eventsCh <- event:
+		return nil
+	case <-b.ctx.Done():
+		return b.ctx.Err()
+	}
+}
+
+// loadCheckpoint returns the blocks tracked by the stored checkpoint, if a checkpoint store is set.
+func (b *BlockSubscriber) loadCheckpoint(opts *BlockSubscriberOptions) ([]*trackedBlock, error) {
+	if b.store == nil {
+		return nil, nil
+	}
+
+	checkpoint, err := b.store.Load(b.ctx, opts.GetCheckpointKey())
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint == nil {
+		return nil, nil
+	}
+
+	toReturn := make([]*trackedBlock, 0, len(checkpoint.Blocks))
+	for _, ref := range checkpoint.Blocks {
+		toReturn = append(toReturn, &trackedBlock{ref: ref})
+	}
+
+	return toReturn, nil
+}
+
+// saveCheckpoint persists the tracked blocks, if a checkpoint store is set.
+func (b *BlockSubscriber) saveCheckpoint(opts *BlockSubscriberOptions, tracked []*trackedBlock) error {
+	if b.store == nil {
+		return nil
+	}
+
+	checkpoint := &Checkpoint{Blocks: make([]BlockRef, 0, len(tracked))}
+	for _, block := range tracked {
+		checkpoint.Blocks = append(checkpoint.Blocks, block.ref)
+	}
+
+	if err := b.store.Save(b.ctx, opts.GetCheckpointKey(), checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// confirmedNumber returns the number of the latest block with enough confirmations, given the head block number.
+func confirmedNumber(head uint64, confirmations uint64) uint64 {
+	if head < confirmations {
+		return 0
+	}
+	return head - confirmations
+}
+
+// fetchBlocks fetches the blocks from start up to and including end concurrently, bounded by the concurrency
+// option, and returns them in ascending order.
+func fetchBlocks(ctx context.Context, pool *clients.ClientPool, opts *BlockSubscriberOptions, start, end uint64) ([]*types.Block, error) {
+	if end < start {
+		return nil, nil
+	}
+
+	blocks := make([]*types.Block, end-start+1)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(opts.GetConcurrency())
+
+	for i := range blocks {
+		g.Go(func() error {
+			client := pool.GetClientByGroupAndType(opts.Group, opts.Type)
+			if client == nil {
+				return errors.New("client not found")
+			}
+
+			block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(start+uint64(i)))
+			if err != nil {
+				return err
+			}
+
+			blocks[i] = block
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,456 @@
+package observers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/clients"
+)
+
+// testChain serves the canonical chain over JSON-RPC, allowing it to be reorganized between subscriptions.
+type testChain struct {
+	mu      sync.RWMutex
+	headers []*types.Header
+	onFetch map[uint64]func() // Called once, before the block of the number is served for the first time.
+}
+
+// newTestChain creates a chain of empty blocks from genesis up to and including the head number.
+func newTestChain(head uint64) *testChain {
+	chain := &testChain{onFetch: make(map[uint64]func())}
+	chain.reorg(0, head, "a")
+	return chain
+}
+
+// reorg replaces the blocks from the fork number onwards with a fork up to and including the head number.
+func (c *testChain) reorg(fork, head uint64, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.headers = c.headers[:fork]
+	for number := fork; number <= head; number++ {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Difficulty: big.NewInt(1),
+			UncleHash:  types.EmptyUncleHash,
+			TxHash:     types.EmptyTxsHash,
+			Extra:      []byte(name),
+		}
+		if number > 0 {
+			header.ParentHash = c.headers[number-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+// getHeader returns the canonical header of the block number.
+func (c *testChain) getHeader(number uint64) *types.Header {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.headers[number]
+}
+
+// getBlock returns the JSON-RPC representation of the canonical block of the hex encoded number, or of the head
+// for "latest", and nil if there is no such block.
+func (c *testChain) getBlock(param string) map[string]any {
+	if number, err := hexutil.DecodeUint64(param); err == nil {
+		c.mu.Lock()
+		onFetch := c.onFetch[number]
+		delete(c.onFetch, number)
+		c.mu.Unlock()
+
+		if onFetch != nil {
+			onFetch()
+		}
+	}
+
+	c.mu.RLock()
+	header := c.headers[len(c.headers)-1]
+	if param != "latest" {
+		number, err := hexutil.DecodeUint64(param)
+		if err == nil && number < uint64(len(c.headers)) {
+			header = c.headers[number]
+		} else {
+			header = nil
+		}
+	}
+	c.mu.RUnlock()
+
+	if header == nil {
+		return nil
+	}
+
+	encoded, _ := json.Marshal(header)
+	block := make(map[string]any)
+	_ = json.Unmarshal(encoded, &block)
+	block["transactions"] = []any{}
+	block["uncles"] = []any{}
+	return block
+}
+
+// ServeHTTP implements the JSON-RPC methods required by block subscriptions.
+func (c *testChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params []any           `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result any
+	switch request.Method {
+	case "net_version":
+		result = "1"
+	case "eth_getBlockByNumber":
+		if block := c.getBlock(request.Params[0].(string)); block != nil {
+			result = block
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result})
+}
+
+// testChainService serves the chain over a websocket JSON-RPC server, which unlike the HTTP one supports head
+// subscriptions. Subscriptions never deliver headers, so subscribers only catch up with the head.
+type testChainService struct {
+	chain        *testChain
+	mu           sync.Mutex
+	failures     map[string]bool // Block numbers whose next fetch fails.
+	unsubscribed int             // Number of head subscriptions the clients have closed.
+}
+
+// GetBlockByNumber implements eth_getBlockByNumber.
+func (s *testChainService) GetBlockByNumber(ctx context.Context, number string, full bool) (map[string]any, error) {
+	s.mu.Lock()
+	failure := s.failures[number]
+	delete(s.failures, number)
+	s.mu.Unlock()
+
+	if failure {
+		return nil, fmt.Errorf("block %s is not available", number)
+	}
+	return s.chain.getBlock(number), nil
+}
+
+// NewHeads implements eth_subscribe of newHeads.
+func (s *testChainService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+	go func() {
+		<-sub.Err()
+		s.mu.Lock()
+		s.unsubscribed++
+		s.mu.Unlock()
+	}()
+	return sub, nil
+}
+
+// getUnsubscribed returns the number of head subscriptions the clients have closed.
+func (s *testChainService) getUnsubscribed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unsubscribed
+}
+
+// testNetService implements the net namespace of the websocket JSON-RPC server.
+type testNetService struct{}
+
+// Version implements net_version.
+func (testNetService) Version() string {
+	return "1"
+}
+
+// newTestChainServer serves the chain service over websocket, returning the server and its endpoint.
+func newTestChainServer(t *testing.T, service *testChainService) (*httptest.Server, string) {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", service))
+	require.NoError(t, server.RegisterName("net", testNetService{}))
+
+	httpServer := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	return httpServer, "ws://" + strings.TrimPrefix(httpServer.URL, "http://")
+}
+
+// newTestClientPool creates a client pool of the ethereum archive group and type, served by the endpoint.
+func newTestClientPool(t *testing.T, endpoint string) *clients.ClientPool {
+	client, err := clients.NewClientPool(context.Background(), &clients.Options{
+		Nodes: []clients.Node{
+			{
+				Group:             "ethereum",
+				Type:              "archive",
+				NetworkId:         1,
+				Endpoint:          endpoint,
+				ConcurrentClients: 2,
+			},
+		},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	chain := newTestChain(10)
+	server := httptest.NewServer(chain)
+	defer server.Close()
+
+	client := newTestClientPool(t, server.URL)
+	defer client.Close()
+
+	store := NewMemoryCheckpointStore()
+
+	type event struct {
+		Type   BlockEventType
+		Number uint64
+		Fork   string
+	}
+
+	tests := []struct {
+		name       string
+		reorg      func()
+		reorgDepth int
+		want       []event
+		wantErr    error
+	}{
+		{
+			name: "Confirmed Blocks",
+			want: []event{
+				{BlockEventNew, 1, "a"}, {BlockEventNew, 2, "a"}, {BlockEventNew, 3, "a"}, {BlockEventNew, 4, "a"},
+				{BlockEventNew, 5, "a"}, {BlockEventNew, 6, "a"}, {BlockEventNew, 7, "a"}, {BlockEventNew, 8, "a"},
+			},
+		},
+		{
+			name:  "Resume After Reorganization",
+			reorg: func() { chain.reorg(7, 12, "b") },
+			want: []event{
+				{BlockEventReverted, 8, ""}, {BlockEventReverted, 7, ""},
+				{BlockEventNew, 7, "b"}, {BlockEventNew, 8, "b"}, {BlockEventNew, 9, "b"}, {BlockEventNew, 10, "b"},
+			},
+		},
+		{
+			name:       "Resume Without Gaps",
+			reorg:      func() { chain.reorg(13, 14, "b") },
+			reorgDepth: 2,
+			want: []event{
+				{BlockEventNew, 11, "b"}, {BlockEventNew, 12, "b"},
+			},
+		},
+		{
+			name:       "Reorganization Deeper Than Tracked",
+			reorg:      func() { chain.reorg(5, 15, "c") },
+			reorgDepth: 2,
+			want:       []event{},
+			wantErr:    ErrReorgTooDeep,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.reorg != nil {
+				tt.reorg()
+			}
+
+			subscriber, err := NewBlockSubscriber(context.Background(), client)
+			require.NoError(t, err)
+			subscriber.SetCheckpointStore(store)
+
+			eventsCh := make(chan *BlockEvent)
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- subscriber.SubscribeEvents(&BlockSubscriberOptions{
+					NetworkID:        1,
+					Group:            "ethereum",
+					Type:             "archive",
+					StartBlockNumber: big.NewInt(1),
+					Confirmations:    2,
+					ReorgDepth:       tt.reorgDepth,
+					Concurrency:      3,
+				}, eventsCh)
+				close(eventsCh)
+			}()
+
+			events := make([]event, 0)
+			for received := range eventsCh {
+				events = append(events, event{received.Type, received.Ref.Number, ""})
+				if received.Block != nil {
+					events[len(events)-1].Fork = string(received.Block.Extra())
+					assert.Equal(t, received.Ref.Hash, received.Block.Hash())
+				} else {
+					// Blocks known only from the checkpoint are reverted without their block.
+					assert.Equal(t, BlockEventReverted, received.Type)
+				}
+
+				if received.Type == BlockEventNew {
+					assert.Equal(t, chain.getHeader(received.Ref.Number).Hash(), received.Ref.Hash)
+				}
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, <-errCh, tt.wantErr)
+			} else {
+				assert.NoError(t, <-errCh)
+			}
+
+			assert.Equal(t, tt.want, events)
+			assert.False(t, subscriber.IsActive())
+		})
+	}
+
+	checkpoint, err := store.Load(context.Background(), "1_ethereum_archive")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+
+	// The last good checkpoint is kept, as no fork point was found.
+	numbers := make([]uint64, 0, len(checkpoint.Blocks))
+	for _, ref := range checkpoint.Blocks {
+		numbers = append(numbers, ref.Number)
+	}
+	assert.Equal(t, []uint64{11, 12}, numbers)
+}
+
+func TestSubscribeEventsReorganization(t *testing.T) {
+	chain := newTestChain(10)
+	chain.onFetch[7] = func() { chain.reorg(5, 12, "b") }
+
+	server := httptest.NewServer(chain)
+	defer server.Close()
+
+	client := newTestClientPool(t, server.URL)
+	defer client.Close()
+
+	subscriber, err := NewBlockSubscriber(context.Background(), client)
+	require.NoError(t, err)
+
+	eventsCh := make(chan *BlockEvent)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- subscriber.SubscribeEvents(&BlockSubscriberOptions{
+			Group:            "ethereum",
+			Type:             "archive",
+			StartBlockNumber: big.NewInt(1),
+			EndBlockNumber:   big.NewInt(9),
+			Concurrency:      3,
+		}, eventsCh)
+		close(eventsCh)
+	}()
+
+	events := make([]string, 0)
+	for received := range eventsCh {
+		require.NotNil(t, received.Block)
+		events = append(events, fmt.Sprintf("%s %d%s", received.Type, received.Ref.Number, received.Block.Extra()))
+	}
+
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, []string{
+		"new 1a", "new 2a", "new 3a", "new 4a", "new 5a", "new 6a",
+		"reverted 6a", "reverted 5a",
+		"new 5b", "new 6b", "new 7b", "new 8b", "new 9b",
+	}, events)
+}
+
+func TestSubscribeRange(t *testing.T) {
+	chain := newTestChain(20)
+	server := httptest.NewServer(chain)
+	defer server.Close()
+
+	client := newTestClientPool(t, server.URL)
+	defer client.Close()
+
+	subscriber, err := NewBlockSubscriber(context.Background(), client)
+	require.NoError(t, err)
+
+	blockCh := make(chan *types.Block)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- subscriber.Subscribe(&BlockSubscriberOptions{
+			Group:            "ethereum",
+			Type:             "archive",
+			StartBlockNumber: big.NewInt(3),
+			EndBlockNumber:   big.NewInt(17),
+			Concurrency:      4,
+		}, blockCh)
+		close(blockCh)
+	}()
+
+	want := uint64(3)
+	for block := range blockCh {
+		assert.Equal(t, want, block.NumberU64())
+		want++
+	}
+
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, uint64(18), want)
+}
+
+func TestSubscribeEventsHeadRetry(t *testing.T) {
+	service := &testChainService{chain: newTestChain(10), failures: map[string]bool{"0x4": true}}
+	server, endpoint := newTestChainServer(t, service)
+	defer server.Close()
+
+	client := newTestClientPool(t, endpoint)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber, err := NewBlockSubscriber(ctx, client)
+	require.NoError(t, err)
+	subscriber.SetCheckpointStore(NewMemoryCheckpointStore())
+
+	opts := &BlockSubscriberOptions{
+		NetworkID:        1,
+		Group:            "ethereum",
+		Type:             "archive",
+		Head:             true,
+		StartBlockNumber: big.NewInt(1),
+		Confirmations:    2,
+		Concurrency:      3,
+	}
+
+	// Catching up with the head fails on the unavailable block, releasing the subscription.
+	eventsCh := make(chan *BlockEvent, 16)
+	assert.ErrorContains(t, subscriber.SubscribeEvents(opts, eventsCh), "block 0x4 is not available")
+	assert.False(t, subscriber.IsActive())
+	assert.Eventually(t, func() bool { return service.getUnsubscribed() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	numbers := make([]uint64, 0)
+	for len(eventsCh) > 0 {
+		numbers = append(numbers, (<-eventsCh).Ref.Number)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, numbers)
+
+	// Retrying the same subscriber resumes from the checkpoint and follows the head until cancelled.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- subscriber.SubscribeEvents(opts, eventsCh)
+	}()
+
+	numbers = numbers[:0]
+	for len(numbers) == 0 || numbers[len(numbers)-1] < 8 {
+		numbers = append(numbers, (<-eventsCh).Ref.Number)
+	}
+	assert.Equal(t, []uint64{4, 5, 6, 7, 8}, numbers)
+
+	cancel()
+	assert.NoError(t, <-errCh)
+	assert.False(t, subscriber.IsActive())
+	assert.Eventually(t, func() bool { return service.getUnsubscribed() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
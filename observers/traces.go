@@ -0,0 +1,301 @@
+package observers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/unpackdev/solgo/clients"
+)
+
+// TracerType defines the tracing API used to discover contracts deployed by other contracts.
+type TracerType string
+
+const (
+	// TracerNone disables tracing, only contracts deployed by transactions are discovered.
+	TracerNone TracerType = ""
+
+	// TracerCall uses debug_traceBlockByNumber with the built-in callTracer, as supported by geth and most of its forks.
+	TracerCall TracerType = "callTracer"
+
+	// TracerParity uses trace_block, as supported by Erigon, Nethermind and OpenEthereum.
+	TracerParity TracerType = "parity"
+)
+
+// CreationType defines the opcode a contract was deployed with.
+type CreationType string
+
+const (
+	// CreationCreate is used for contracts deployed by transactions or by the CREATE opcode.
+	CreationCreate CreationType = "CREATE"
+
+	// CreationCreate2 is used for contracts deployed by the CREATE2 opcode.
+	CreationCreate2 CreationType = "CREATE2"
+)
+
+// creation represents a contract deployed by another contract, as found within the transaction traces.
+type creation struct {
+	Deployer    common.Address // The contract executing the CREATE or CREATE2 opcode.
+	Address     common.Address // The address of the deployed contract.
+	Type        CreationType   // The opcode the contract was deployed with.
+	InitCode    []byte         // The code executed to deploy the contract.
+	CallerInput []byte         // The input of the call into the deployer, salts are usually derived from it.
+}
+
+// callFrame represents a single frame of the callTracer output.
+type callFrame struct {
+	Type  string         `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+	Error string         `json:"error,omitempty"`
+	Calls []callFrame    `json:"calls,omitempty"`
+}
+
+// callTraceResult represents the trace of a single transaction as returned by debug_traceBlockByNumber.
+type callTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result *callFrame  `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// parityTrace represents a single trace as returned by trace_block.
+type parityTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		From           common.Address `json:"from"`
+		To             common.Address `json:"to"`
+		Input          hexutil.Bytes  `json:"input"`
+		Init           hexutil.Bytes  `json:"init"`
+		CreationMethod string         `json:"creationMethod"`
+	} `json:"action"`
+	Result *struct {
+		Address common.Address `json:"address"`
+	} `json:"result"`
+	Error               string       `json:"error,omitempty"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     *common.Hash `json:"transactionHash"`
+	TransactionPosition *int         `json:"transactionPosition"`
+}
+
+// traceBlockCreations returns the contracts deployed by other contracts within the block, grouped by the hash of
+// the transaction deploying them. Contracts deployed within reverted frames are not returned.
+func traceBlockCreations(ctx context.Context, client *clients.Client, block *types.Block, tracer TracerType) (map[common.Hash][]*creation, error) {
+	switch tracer {
+	case TracerCall:
+		var results []callTraceResult
+		if err := client.GetRpcClient().CallContext(
+			ctx, &results, "debug_traceBlockByNumber",
+			hexutil.EncodeBig(block.Number()), map[string]string{"tracer": "callTracer"},
+		); err != nil {
+			return nil, fmt.Errorf("failed to trace block %d: %w", block.NumberU64(), err)
+		}
+		return parseCallTraces(block, results)
+	case TracerParity:
+		var traces []parityTrace
+		if err := client.GetRpcClient().CallContext(
+			ctx, &traces, "trace_block", hexutil.EncodeBig(block.Number()),
+		); err != nil {
+			return nil, fmt.Errorf("failed to trace block %d: %w", block.NumberU64(), err)
+		}
+		return parseParityTraces(traces), nil
+	default:
+		return nil, fmt.Errorf("unsupported tracer %q", tracer)
+	}
+}
+
+// parseCallTraces collects the contracts deployed by other contracts from the callTracer block traces.
+// Older clients do not return the transaction hash, in which case traces are matched to transactions by position.
+func parseCallTraces(block *types.Block, results []callTraceResult) (map[common.Hash][]*creation, error) {
+	txs := block.Transactions()
+	if len(results) != len(txs) {
+		return nil, fmt.Errorf(
+			"failed to trace block %d: got %d traces for %d transactions",
+			block.NumberU64(), len(results), len(txs),
+		)
+	}
+
+	toReturn := make(map[common.Hash][]*creation)
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction %s: %s", txs[i].Hash().Hex(), result.Error)
+		}
+
+		if result.Result == nil {
+			continue
+		}
+
+		txHash := result.TxHash
+		if txHash == (common.Hash{}) {
+			txHash = txs[i].Hash()
+		}
+
+		var creations []*creation
+		for _, call := range result.Result.Calls {
+			creations = collectCallFrameCreations(result.Result, &call, creations)
+		}
+
+		if len(creations) > 0 {
+			toReturn[txHash] = creations
+		}
+	}
+
+	return toReturn, nil
+}
+
+// collectCallFrameCreations walks the frame and its subcalls, collecting the contracts deployed within them.
+func collectCallFrameCreations(parent *callFrame, frame *callFrame, creations []*creation) []*creation {
+	// Reverted frames revert every contract deployed within them as well.
+	if frame.Error != "" || parent.Error != "" {
+		return creations
+	}
+
+	switch strings.ToUpper(frame.Type) {
+	case string(CreationCreate), string(CreationCreate2):
+		creations = append(creations, &creation{
+			Deployer:    frame.From,
+			Address:     frame.To,
+			Type:        CreationType(strings.ToUpper(frame.Type)),
+			InitCode:    frame.Input,
+			CallerInput: parent.Input,
+		})
+	}
+
+	for _, call := range frame.Calls {
+		creations = collectCallFrameCreations(frame, &call, creations)
+	}
+
+	return creations
+}
+
+// parseParityTraces collects the contracts deployed by other contracts from the trace_block traces.
+// Clients not reporting the creation method report CREATE2 deployments as CREATE ones, unless the salt can be
+// recovered, see discoverCreationSalt.
+func parseParityTraces(traces []parityTrace) map[common.Hash][]*creation {
+	// Traces of each transaction are indexed by their trace address, so that reverted ancestors and the input of
+	// the call into the deployer can be looked up.
+	byAddress := make(map[common.Hash]map[string]*parityTrace)
+	for i := range traces {
+		trace := &traces[i]
+		if trace.TransactionHash == nil {
+			continue
+		}
+
+		if _, ok := byAddress[*trace.TransactionHash]; !ok {
+			byAddress[*trace.TransactionHash] = make(map[string]*parityTrace)
+		}
+		byAddress[*trace.TransactionHash][traceAddressKey(trace.TraceAddress)] = trace
+	}
+
+	toReturn := make(map[common.Hash][]*creation)
+	for i := range traces {
+		trace := &traces[i]
+		if trace.TransactionHash == nil || trace.Type != "create" || len(trace.TraceAddress) == 0 {
+			continue
+		}
+
+		if trace.Error != "" || trace.Result == nil {
+			continue
+		}
+
+		txTraces := byAddress[*trace.TransactionHash]
+
+		reverted := false
+		for depth := 0; depth < len(trace.TraceAddress); depth++ {
+			if ancestor, ok := txTraces[traceAddressKey(trace.TraceAddress[:depth])]; ok && ancestor.Error != "" {
+				reverted = true
+				break
+			}
+		}
+		if reverted {
+			continue
+		}
+
+		toAppend := &creation{
+			Deployer: trace.Action.From,
+			Address:  trace.Result.Address,
+			Type:     CreationCreate,
+			InitCode: trace.Action.Init,
+		}
+
+		if strings.EqualFold(trace.Action.CreationMethod, string(CreationCreate2)) {
+			toAppend.Type = CreationCreate2
+		}
+
+		if parent, ok := txTraces[traceAddressKey(trace.TraceAddress[:len(trace.TraceAddress)-1])]; ok {
+			toAppend.CallerInput = parent.Action.Input
+		}
+
+		toReturn[*trace.TransactionHash] = append(toReturn[*trace.TransactionHash], toAppend)
+	}
+
+	return toReturn
+}
+
+// traceAddressKey returns the map key of the trace address.
+func traceAddressKey(traceAddress []int) string {
+	return fmt.Sprint(traceAddress)
+}
+
+// discoverCreationSalt recovers the salt a contract was deployed with through CREATE2. The salt is not part of the
+// traces, so candidates are derived from the input of the call into the deployer, the way factories usually derive
+// them, and each is checked against the deployed address. It returns nil if none of the candidates match.
+//
+// Candidates are the arguments themselves, the hash of the arguments, and the hashes of the arguments with the
+// leading pair of addresses sorted, either packed (UniswapV2 style pairs) or ABI encoded (UniswapV3 style pools).
+func discoverCreationSalt(deployer, address common.Address, initCodeHash common.Hash, input []byte) *common.Hash {
+	if len(input) < 4 {
+		return nil
+	}
+
+	args := input[4:]
+	words := make([][]byte, 0, len(args)/32)
+	for offset := 0; offset+32 <= len(args); offset += 32 {
+		words = append(words, args[offset:offset+32])
+	}
+
+	candidates := make([][]byte, 0, len(words)+4)
+	candidates = append(candidates, words...)
+	candidates = append(candidates, crypto.Keccak256(args))
+
+	if len(words) >= 2 && isAddressWord(words[0]) && isAddressWord(words[1]) {
+		first, second := words[0], words[1]
+		if bytes.Compare(first, second) > 0 {
+			first, second = second, first
+		}
+
+		candidates = append(candidates, crypto.Keccak256(first[12:], second[12:]))
+
+		encoded := append(append(append([]byte{}, first...), second...), bytes.Join(words[2:], nil)...)
+		candidates = append(candidates, crypto.Keccak256(encoded))
+	}
+
+	for _, candidate := range candidates {
+		salt := common.BytesToHash(candidate)
+		if crypto.CreateAddress2(deployer, salt, initCodeHash.Bytes()) == address {
+			return &salt
+		}
+	}
+
+	return nil
+}
+
+// isAddressWord checks if the ABI encoded word holds a non zero address.
+func isAddressWord(word []byte) bool {
+	return len(word) == 32 && bytes.Equal(word[:12], make([]byte, 12)) && !bytes.Equal(word[12:], make([]byte, 20))
+}
+
+// getTransactionSender returns the sender of the transaction, or the zero address if it cannot be recovered.
+func getTransactionSender(tx *types.Transaction) common.Address {
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return common.Address{}
+	}
+
+	return sender
+}
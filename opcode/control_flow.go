@@ -0,0 +1,314 @@
+package opcode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// EdgeKind describes how execution moves from one basic block to another.
+type EdgeKind string
+
+const (
+	// EdgeFallthrough is taken when execution continues with the next instruction, either because the block
+	// ends before a JUMPDEST or because the condition of a JUMPI is zero.
+	EdgeFallthrough EdgeKind = "fallthrough"
+	// EdgeJump is an unconditional JUMP.
+	EdgeJump EdgeKind = "jump"
+	// EdgeConditional is a JUMPI whose condition is not zero.
+	EdgeConditional EdgeKind = "conditional"
+)
+
+// BlockEdge represents a transition between two basic blocks, identified by their start offsets.
+type BlockEdge struct {
+	From int      `json:"from"`
+	To   int      `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// BasicBlock represents a sequence of instructions that are always executed together. Blocks start at offset
+// zero, at every JUMPDEST and after every JUMPI or terminating instruction.
+type BasicBlock struct {
+	Start        int           `json:"start"`
+	End          int           `json:"end"`
+	Instructions []Instruction `json:"instructions"`
+	Reachable    bool          `json:"reachable"`
+	Selectors    []string      `json:"selectors,omitempty"`
+}
+
+// GetStart returns the offset of the first instruction of the block, which also identifies the block.
+func (b *BasicBlock) GetStart() int {
+	return b.Start
+}
+
+// GetEnd returns the offset of the last instruction of the block.
+func (b *BasicBlock) GetEnd() int {
+	return b.End
+}
+
+// GetInstructions returns the instructions of the block.
+func (b *BasicBlock) GetInstructions() []Instruction {
+	return b.Instructions
+}
+
+// GetLastInstruction returns the instruction that ends the block.
+func (b *BasicBlock) GetLastInstruction() Instruction {
+	return b.Instructions[len(b.Instructions)-1]
+}
+
+// IsReachable returns true if the block can be reached from the first instruction of the bytecode.
+func (b *BasicBlock) IsReachable() bool {
+	return b.Reachable
+}
+
+// GetSelectors returns the 4-byte function selectors the dispatcher routes to this block.
+func (b *BasicBlock) GetSelectors() []string {
+	return b.Selectors
+}
+
+// FunctionEntry maps a 4-byte function selector to the block the dispatcher jumps to when it is called.
+type FunctionEntry struct {
+	Selector string `json:"selector"` // Hex encoded selector, such as 0xa9059cbb.
+	Entry    int    `json:"entry"`    // Start offset of the entry block.
+	Dispatch int    `json:"dispatch"` // Offset of the JUMPI comparing the selector.
+}
+
+// GetSelector returns the hex encoded 4-byte function selector.
+func (f *FunctionEntry) GetSelector() string {
+	return f.Selector
+}
+
+// GetEntry returns the start offset of the function entry block.
+func (f *FunctionEntry) GetEntry() int {
+	return f.Entry
+}
+
+// GetDispatch returns the offset of the JUMPI instruction that dispatches to the function.
+func (f *FunctionEntry) GetDispatch() int {
+	return f.Dispatch
+}
+
+// ControlFlowGraph represents the basic block graph of EVM bytecode, including the function entries found
+// in the dispatcher and jumps whose targets could not be resolved statically.
+type ControlFlowGraph struct {
+	Blocks          []*BasicBlock    `json:"blocks"`
+	Edges           []*BlockEdge     `json:"edges"`
+	Functions       []*FunctionEntry `json:"functions"`
+	UnresolvedJumps []int            `json:"unresolvedJumps"`
+	blocks          map[int]*BasicBlock
+	edges           map[BlockEdge]bool
+}
+
+// NewControlFlowGraph creates an empty control flow graph.
+func NewControlFlowGraph() *ControlFlowGraph {
+	return &ControlFlowGraph{
+		Blocks:          make([]*BasicBlock, 0),
+		Edges:           make([]*BlockEdge, 0),
+		Functions:       make([]*FunctionEntry, 0),
+		UnresolvedJumps: make([]int, 0),
+		blocks:          make(map[int]*BasicBlock),
+		edges:           make(map[BlockEdge]bool),
+	}
+}
+
+// AddBlock adds a block to the graph. Blocks are kept ordered by their start offset.
+func (g *ControlFlowGraph) AddBlock(block *BasicBlock) {
+	g.blocks[block.Start] = block
+	g.Blocks = append(g.Blocks, block)
+	if last := len(g.Blocks) - 1; last > 0 && g.Blocks[last-1].Start > block.Start {
+		sort.SliceStable(g.Blocks, func(i, j int) bool {
+			return g.Blocks[i].Start < g.Blocks[j].Start
+		})
+	}
+}
+
+// AddEdge adds a transition between two blocks. Duplicate edges are ignored.
+func (g *ControlFlowGraph) AddEdge(from int, to int, kind EdgeKind) {
+	edge := BlockEdge{From: from, To: to, Kind: kind}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, &edge)
+}
+
+// GetBlocks returns all blocks of the graph ordered by their start offset.
+func (g *ControlFlowGraph) GetBlocks() []*BasicBlock {
+	return g.Blocks
+}
+
+// GetEdges returns all transitions of the graph.
+func (g *ControlFlowGraph) GetEdges() []*BlockEdge {
+	return g.Edges
+}
+
+// GetBlock returns the block starting at the provided offset or nil if it does not exist.
+func (g *ControlFlowGraph) GetBlock(start int) *BasicBlock {
+	return g.blocks[start]
+}
+
+// GetBlockAt returns the block containing the instruction at the provided offset or nil if it does not exist.
+func (g *ControlFlowGraph) GetBlockAt(offset int) *BasicBlock {
+	idx := sort.Search(len(g.Blocks), func(i int) bool {
+		return g.Blocks[i].End >= offset
+	})
+
+	if idx < len(g.Blocks) && g.Blocks[idx].Start <= offset {
+		return g.Blocks[idx]
+	}
+
+	return nil
+}
+
+// GetSuccessors returns the transitions leaving the block starting at the provided offset.
+func (g *ControlFlowGraph) GetSuccessors(start int) []*BlockEdge {
+	toReturn := make([]*BlockEdge, 0)
+	for _, edge := range g.Edges {
+		if edge.From == start {
+			toReturn = append(toReturn, edge)
+		}
+	}
+	return toReturn
+}
+
+// GetPredecessors returns the transitions entering the block starting at the provided offset.
+func (g *ControlFlowGraph) GetPredecessors(start int) []*BlockEdge {
+	toReturn := make([]*BlockEdge, 0)
+	for _, edge := range g.Edges {
+		if edge.To == start {
+			toReturn = append(toReturn, edge)
+		}
+	}
+	return toReturn
+}
+
+// GetFunctions returns the function entries found in the dispatcher, ordered by the offset of their dispatch.
+func (g *ControlFlowGraph) GetFunctions() []*FunctionEntry {
+	return g.Functions
+}
+
+// GetFunction returns the function entry of the hex encoded selector, with or without the 0x prefix.
+// Returns nil if the dispatcher does not route the selector.
+func (g *ControlFlowGraph) GetFunction(selector string) *FunctionEntry {
+	selector = "0x" + strings.TrimPrefix(strings.ToLower(selector), "0x")
+	for _, function := range g.Functions {
+		if function.Selector == selector {
+			return function
+		}
+	}
+	return nil
+}
+
+// GetUnresolvedJumps returns the offsets of JUMP and JUMPI instructions whose target could not be resolved.
+func (g *ControlFlowGraph) GetUnresolvedJumps() []int {
+	return g.UnresolvedJumps
+}
+
+// ToJSON converts the control flow graph into its JSON representation.
+func (g *ControlFlowGraph) ToJSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+// ToMermaid converts the control flow graph into a Mermaid flowchart. Unreachable blocks are omitted.
+func (g *ControlFlowGraph) ToMermaid() string {
+	var builder strings.Builder
+	builder.WriteString("flowchart TD\n")
+
+	for _, block := range g.Blocks {
+		if !block.Reachable {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", blockId(block.Start), strings.Join(g.blockLines(block), "<br/>")))
+	}
+
+	for _, edge := range g.Edges {
+		builder.WriteString(fmt.Sprintf("    %s -->|%s| %s\n", blockId(edge.From), edge.Kind, blockId(edge.To)))
+	}
+
+	return builder.String()
+}
+
+// ToDOT converts the control flow graph into the Graphviz DOT format. Unreachable blocks are omitted.
+func (g *ControlFlowGraph) ToDOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph bytecode {\n")
+	builder.WriteString("    node [shape=box, fontname=\"monospace\"];\n")
+
+	for _, block := range g.Blocks {
+		if !block.Reachable {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("    %s [label=\"%s\\l\"];\n", blockId(block.Start), strings.Join(g.blockLines(block), "\\l")))
+	}
+
+	for _, edge := range g.Edges {
+		builder.WriteString(fmt.Sprintf("    %s -> %s [label=\"%s\"];\n", blockId(edge.From), blockId(edge.To), edge.Kind))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// String returns the disassembly of the bytecode grouped into blocks, with resolved jump targets and function
+// selectors annotated.
+func (g *ControlFlowGraph) String() string {
+	var builder strings.Builder
+
+	for _, block := range g.Blocks {
+		header := fmt.Sprintf("block 0x%04x", block.Start)
+		if len(block.Selectors) > 0 {
+			header += " // function " + strings.Join(block.Selectors, ", ")
+		}
+		if !block.Reachable {
+			header += " // unreachable"
+		}
+		builder.WriteString(header + ":\n")
+
+		for _, instruction := range block.Instructions {
+			builder.WriteString("    " + formatInstruction(instruction))
+			if instruction.OpCode == JUMP || instruction.OpCode == JUMPI {
+				targets := make([]string, 0)
+				for _, edge := range g.GetSuccessors(block.Start) {
+					if edge.Kind != EdgeFallthrough {
+						targets = append(targets, fmt.Sprintf("0x%04x", edge.To))
+					}
+				}
+				if len(targets) > 0 {
+					builder.WriteString(" // -> " + strings.Join(targets, ", "))
+				}
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// blockLines returns the label lines of the block used by the graph exporters.
+func (g *ControlFlowGraph) blockLines(block *BasicBlock) []string {
+	lines := make([]string, 0, len(block.Instructions)+1)
+	if len(block.Selectors) > 0 {
+		lines = append(lines, "function "+strings.Join(block.Selectors, ", "))
+	}
+	for _, instruction := range block.Instructions {
+		lines = append(lines, formatInstruction(instruction))
+	}
+	return lines
+}
+
+// blockId returns the identifier of the block within Mermaid and DOT graphs.
+func blockId(start int) string {
+	return fmt.Sprintf("b%04x", start)
+}
+
+// formatInstruction returns the `offset OPCODE args` representation of the instruction.
+func formatInstruction(instruction Instruction) string {
+	toReturn := fmt.Sprintf("0x%04x %s", instruction.Offset, instruction.OpCode.String())
+	if len(instruction.Args) > 0 {
+		toReturn += " 0x" + common.Bytes2Hex(instruction.Args)
+	}
+	return toReturn
+}
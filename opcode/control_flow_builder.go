@@ -0,0 +1,383 @@
+package opcode
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// maxStackDepth is the depth of the EVM stack, items pushed beyond it are dropped from the bottom.
+	maxStackDepth = 1024
+	// maxStatesPerBlock bounds the number of distinct entry stacks a block is analysed with. Blocks shared by
+	// many callers, such as internal functions, are entered with a different return address by each caller.
+	maxStatesPerBlock = 256
+)
+
+// uint256Mask is used to wrap the results of abstract arithmetic to 256 bits.
+var uint256Mask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// stackValue is an abstract value on the EVM stack. Only constants pushed by the bytecode and values derived
+// from them are known. Unknown values remember whether they derive from the calldata, and the result of
+// comparing calldata with a constant remembers the constant, which is how the dispatcher matches selectors.
+type stackValue struct {
+	value    *big.Int
+	calldata bool
+	selector string
+}
+
+// known returns true if the value is a constant.
+func (v *stackValue) known() bool {
+	return v != nil && v.value != nil
+}
+
+// key returns the representation of the value used to tell apart the abstract states of a block.
+func (v *stackValue) key() string {
+	if v.known() {
+		return v.value.Text(16)
+	}
+	return "?"
+}
+
+// abstractState is the abstract stack a block is entered with, top of the stack last.
+type abstractState struct {
+	block int
+	stack []*stackValue
+}
+
+// key returns the representation of the state used to avoid analysing a block twice with the same stack.
+func (s *abstractState) key() string {
+	parts := make([]string, len(s.stack))
+	for i, value := range s.stack {
+		parts[i] = value.key()
+	}
+	return strings.Join(parts, ",")
+}
+
+// BuildControlFlowGraph splits the decompiled instructions into basic blocks and connects them. Jump targets
+// are resolved by tracking constants on an abstract stack from the first instruction onward, so only blocks
+// reachable from it are connected. Jumps with targets that depend on runtime values are reported as unresolved.
+func (d *Decompiler) BuildControlFlowGraph() (*ControlFlowGraph, error) {
+	if d.bytecodeSize < 1 {
+		return nil, ErrEmptyBytecode
+	}
+
+	if len(d.instructions) == 0 {
+		return nil, ErrNotDecompiled
+	}
+
+	graph := NewControlFlowGraph()
+	for _, block := range d.splitBlocks() {
+		graph.AddBlock(block)
+	}
+
+	d.resolveJumps(graph)
+	d.controlFlowGraph = graph
+
+	return graph, nil
+}
+
+// GetControlFlowGraph returns the control flow graph built by BuildControlFlowGraph or nil if it was not built.
+func (d *Decompiler) GetControlFlowGraph() *ControlFlowGraph {
+	return d.controlFlowGraph
+}
+
+// splitBlocks splits the instructions into basic blocks. A new block starts at every JUMPDEST and after every
+// JUMPI or terminating instruction.
+func (d *Decompiler) splitBlocks() []*BasicBlock {
+	blocks := make([]*BasicBlock, 0)
+
+	var current *BasicBlock
+	for _, instruction := range d.instructions {
+		if current != nil && instruction.OpCode == JUMPDEST {
+			blocks = append(blocks, current)
+			current = nil
+		}
+
+		if current == nil {
+			current = &BasicBlock{Start: instruction.Offset, Instructions: make([]Instruction, 0)}
+		}
+
+		current.Instructions = append(current.Instructions, instruction)
+		current.End = instruction.Offset
+
+		if instruction.OpCode == JUMPI || instruction.OpCode.IsTerminator() {
+			blocks = append(blocks, current)
+			current = nil
+		}
+	}
+
+	if current != nil {
+		blocks = append(blocks, current)
+	}
+
+	return blocks
+}
+
+// resolveJumps walks the blocks reachable from the first instruction with an abstract stack, adding the edges
+// between blocks, marking reachable blocks and recording the function selectors matched by the dispatcher.
+func (d *Decompiler) resolveJumps(graph *ControlFlowGraph) {
+	if len(graph.Blocks) == 0 {
+		return
+	}
+
+	nextBlock := make(map[int]int)
+	for i := 0; i < len(graph.Blocks)-1; i++ {
+		nextBlock[graph.Blocks[i].Start] = graph.Blocks[i+1].Start
+	}
+
+	visited := make(map[int]map[string]bool)
+	unresolved := make(map[int]bool)
+	selectors := make(map[string]bool)
+	queue := []*abstractState{{block: graph.Blocks[0].Start, stack: make([]*stackValue, 0)}}
+
+	// enqueue schedules the block to be analysed with the provided entry stack unless it already was, or the
+	// block was analysed with too many different stacks already.
+	enqueue := func(block int, stack []*stackValue) {
+		state := &abstractState{block: block, stack: stack}
+		if visited[block] == nil {
+			visited[block] = make(map[string]bool)
+		}
+
+		key := state.key()
+		if visited[block][key] || len(visited[block]) >= maxStatesPerBlock {
+			return
+		}
+		visited[block][key] = true
+		queue = append(queue, state)
+	}
+	visited[graph.Blocks[0].Start] = map[string]bool{"": true}
+
+	// jumpTarget returns the block the value jumps to, or false if the value is unknown or not a JUMPDEST.
+	jumpTarget := func(value *stackValue) (int, bool) {
+		if !value.known() || !value.value.IsInt64() {
+			return 0, false
+		}
+
+		target := graph.GetBlock(int(value.value.Int64()))
+		if target == nil || target.Instructions[0].OpCode != JUMPDEST {
+			return 0, false
+		}
+
+		return target.Start, true
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		block := graph.GetBlock(state.block)
+		block.Reachable = true
+
+		stack := append(make([]*stackValue, 0, len(state.stack)+len(block.Instructions)), state.stack...)
+		for _, instruction := range block.Instructions[:len(block.Instructions)-1] {
+			stack = execute(stack, instruction)
+		}
+
+		last := block.GetLastInstruction()
+		switch last.OpCode {
+		case JUMP:
+			target, stack := pop(stack)
+			if to, ok := jumpTarget(target); ok {
+				graph.AddEdge(block.Start, to, EdgeJump)
+				enqueue(to, stack)
+			} else {
+				unresolved[last.Offset] = true
+			}
+		case JUMPI:
+			target, stack := pop(stack)
+			condition, stack := pop(stack)
+
+			if to, ok := jumpTarget(target); ok {
+				graph.AddEdge(block.Start, to, EdgeConditional)
+				enqueue(to, stack)
+
+				if condition != nil && condition.selector != "" && !selectors[condition.selector] {
+					selectors[condition.selector] = true
+					graph.Functions = append(graph.Functions, &FunctionEntry{
+						Selector: condition.selector,
+						Entry:    to,
+						Dispatch: last.Offset,
+					})
+				}
+			} else {
+				unresolved[last.Offset] = true
+			}
+
+			if next, ok := nextBlock[block.Start]; ok {
+				graph.AddEdge(block.Start, next, EdgeFallthrough)
+				enqueue(next, stack)
+			}
+		default:
+			if last.OpCode.IsTerminator() {
+				continue
+			}
+
+			stack = execute(stack, last)
+			if next, ok := nextBlock[block.Start]; ok {
+				graph.AddEdge(block.Start, next, EdgeFallthrough)
+				enqueue(next, stack)
+			}
+		}
+	}
+
+	// A jump is only unresolved if none of the stacks it was reached with resolved it.
+	for offset := range unresolved {
+		resolved := false
+		for _, edge := range graph.GetSuccessors(graph.GetBlockAt(offset).Start) {
+			if edge.Kind != EdgeFallthrough {
+				resolved = true
+				break
+			}
+		}
+
+		if !resolved {
+			graph.UnresolvedJumps = append(graph.UnresolvedJumps, offset)
+		}
+	}
+	sort.Ints(graph.UnresolvedJumps)
+
+	sort.SliceStable(graph.Functions, func(i, j int) bool {
+		return graph.Functions[i].Dispatch < graph.Functions[j].Dispatch
+	})
+
+	for _, function := range graph.Functions {
+		if block := graph.GetBlock(function.Entry); block != nil {
+			block.Selectors = append(block.Selectors, function.Selector)
+		}
+	}
+}
+
+// pop removes the top of the stack. Popping an empty stack returns an unknown value, as the abstract stack
+// does not know what the block was entered with beyond what it tracked.
+func pop(stack []*stackValue) (*stackValue, []*stackValue) {
+	if len(stack) == 0 {
+		return nil, stack
+	}
+	return stack[len(stack)-1], stack[:len(stack)-1]
+}
+
+// push adds the value on top of the stack, dropping the bottom of the stack once it exceeds the EVM limit.
+func push(stack []*stackValue, value *stackValue) []*stackValue {
+	stack = append(stack, value)
+	if len(stack) > maxStackDepth {
+		stack = stack[1:]
+	}
+	return stack
+}
+
+// execute applies the instruction to the abstract stack. Stack manipulation is tracked exactly, constants are
+// folded for the operations the compilers use to compute jump targets, and everything else pushes unknowns.
+func execute(stack []*stackValue, instruction Instruction) []*stackValue {
+	op := instruction.OpCode
+
+	switch {
+	case op == PUSH0:
+		return push(stack, &stackValue{value: new(big.Int)})
+	case op.IsPush():
+		return push(stack, &stackValue{value: new(big.Int).SetBytes(instruction.Args)})
+	case op >= DUP1 && op <= DUP16:
+		n := int(op) - DUP1 + 1
+		var value *stackValue
+		if len(stack) >= n {
+			value = stack[len(stack)-n]
+		}
+		return push(stack, value)
+	case op >= SWAP1 && op <= SWAP16:
+		n := int(op) - SWAP1 + 1
+		if len(stack) <= n {
+			// The swapped item is below what the abstract stack tracks, so the top becomes unknown.
+			if len(stack) > 0 {
+				stack[len(stack)-1] = nil
+			}
+			return stack
+		}
+		top, other := len(stack)-1, len(stack)-1-n
+		stack[top], stack[other] = stack[other], stack[top]
+		return stack
+	}
+
+	pops, pushes := op.StackEffect()
+	operands := make([]*stackValue, pops)
+	for i := 0; i < pops; i++ {
+		operands[i], stack = pop(stack)
+	}
+
+	if pushes == 0 {
+		return stack
+	}
+
+	return push(stack, evaluate(op, operands))
+}
+
+// evaluate computes the abstract result of the opcode from its operands, first operand being the top of the stack.
+func evaluate(op OpCode, operands []*stackValue) *stackValue {
+	if op == CALLDATALOAD {
+		return &stackValue{calldata: true}
+	}
+
+	fromCalldata := false
+	allKnown := true
+	for _, operand := range operands {
+		if operand != nil && operand.calldata {
+			fromCalldata = true
+		}
+		if !operand.known() {
+			allKnown = false
+		}
+	}
+
+	switch op {
+	case EQ:
+		// The dispatcher compares the selector extracted from the calldata with each of the function
+		// selectors. Selectors with leading zero bytes are pushed with less than four bytes.
+		a, b := operands[0], operands[1]
+		if a.known() && b != nil && b.calldata {
+			a, b = b, a
+		}
+		if a != nil && a.calldata && !a.known() && b.known() && b.value.BitLen() <= 32 {
+			return &stackValue{selector: fmt.Sprintf("0x%s", common.Bytes2Hex(common.LeftPadBytes(b.value.Bytes(), 4)))}
+		}
+	case ADD, SUB, AND, OR, SHL, SHR:
+		if allKnown {
+			return &stackValue{value: fold(op, operands[0].value, operands[1].value)}
+		}
+	}
+
+	switch op {
+	case SHR, SHL, DIV, AND, OR:
+		// Extracting the selector from the calldata shifts, divides or masks it.
+		return &stackValue{calldata: fromCalldata}
+	}
+
+	return &stackValue{}
+}
+
+// fold computes the result of the binary opcode on known operands, wrapped to 256 bits.
+func fold(op OpCode, a *big.Int, b *big.Int) *big.Int {
+	result := new(big.Int)
+
+	switch op {
+	case ADD:
+		result.Add(a, b)
+	case SUB:
+		result.Sub(a, b)
+	case AND:
+		result.And(a, b)
+	case OR:
+		result.Or(a, b)
+	case SHL:
+		if a.Cmp(big.NewInt(256)) < 0 {
+			result.Lsh(b, uint(a.Uint64()))
+		}
+	case SHR:
+		if a.Cmp(big.NewInt(256)) < 0 {
+			result.Rsh(b, uint(a.Uint64()))
+		}
+	}
+
+	return result.And(result, uint256Mask)
+}
@@ -0,0 +1,204 @@
+package opcode
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dispatcherBytecode routes two selectors, one pushed with PUSH3 due to its leading zero byte. The first
+// function calls an internal function that returns through a jump to the return address it was given.
+const dispatcherBytecode = "600035" + "60e01c" +
+	"80" + "63a9059cbb" + "14" + "601c" + "57" +
+	"80" + "62fdd58e" + "14" + "6022" + "57" +
+	"5f80fd" +
+	"5b" + "6024" + "6026" + "56" +
+	"5b00" +
+	"5b00" +
+	"5b56" +
+	"fe"
+
+func TestControlFlowGraph(t *testing.T) {
+	tests := []struct {
+		name            string
+		bytecode        string
+		decompile       bool
+		expectErr       error
+		blocks          []int
+		reachable       int
+		edges           []BlockEdge
+		functions       []FunctionEntry
+		unresolvedJumps []int
+	}{
+		{
+			name:      "Empty Bytecode",
+			bytecode:  "",
+			expectErr: ErrEmptyBytecode,
+		},
+		{
+			name:      "Not Decompiled",
+			bytecode:  "600160100100",
+			decompile: false,
+			expectErr: ErrNotDecompiled,
+		},
+		{
+			name:      "Dispatcher With Internal Call",
+			bytecode:  dispatcherBytecode,
+			decompile: true,
+			blocks:    []int{0x00, 0x10, 0x19, 0x1c, 0x22, 0x24, 0x26, 0x28},
+			reachable: 7,
+			edges: []BlockEdge{
+				{From: 0x00, To: 0x1c, Kind: EdgeConditional},
+				{From: 0x00, To: 0x10, Kind: EdgeFallthrough},
+				{From: 0x10, To: 0x22, Kind: EdgeConditional},
+				{From: 0x10, To: 0x19, Kind: EdgeFallthrough},
+				{From: 0x1c, To: 0x26, Kind: EdgeJump},
+				{From: 0x26, To: 0x24, Kind: EdgeJump},
+			},
+			functions: []FunctionEntry{
+				{Selector: "0xa9059cbb", Entry: 0x1c, Dispatch: 0x0f},
+				{Selector: "0x00fdd58e", Entry: 0x22, Dispatch: 0x18},
+			},
+			unresolvedJumps: []int{},
+		},
+		{
+			name:            "Dynamic Jump",
+			bytecode:        "6000355600",
+			decompile:       true,
+			blocks:          []int{0x00, 0x04},
+			reachable:       1,
+			edges:           []BlockEdge{},
+			functions:       []FunctionEntry{},
+			unresolvedJumps: []int{0x03},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bytecode, err := hex.DecodeString(tt.bytecode)
+			require.NoError(t, err)
+
+			decompiler, err := NewDecompiler(context.TODO(), bytecode)
+			require.NoError(t, err)
+
+			if tt.decompile {
+				require.NoError(t, decompiler.Decompile())
+			}
+
+			graph, err := decompiler.BuildControlFlowGraph()
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				assert.Nil(t, graph)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, graph, decompiler.GetControlFlowGraph())
+
+			starts := make([]int, 0)
+			reachable := 0
+			for _, block := range graph.GetBlocks() {
+				starts = append(starts, block.GetStart())
+				if block.IsReachable() {
+					reachable++
+				}
+			}
+			assert.Equal(t, tt.blocks, starts)
+			assert.Equal(t, tt.reachable, reachable)
+
+			edges := make([]BlockEdge, 0)
+			for _, edge := range graph.GetEdges() {
+				edges = append(edges, *edge)
+			}
+			assert.ElementsMatch(t, tt.edges, edges)
+
+			functions := make([]FunctionEntry, 0)
+			for _, function := range graph.GetFunctions() {
+				functions = append(functions, *function)
+				assert.Contains(t, graph.GetBlock(function.GetEntry()).GetSelectors(), function.GetSelector())
+			}
+			assert.Equal(t, tt.functions, functions)
+			assert.Equal(t, tt.unresolvedJumps, graph.GetUnresolvedJumps())
+
+			jsonData, err := graph.ToJSON()
+			require.NoError(t, err)
+			var decoded ControlFlowGraph
+			require.NoError(t, json.Unmarshal(jsonData, &decoded))
+			assert.Len(t, decoded.Blocks, len(tt.blocks))
+
+			assert.Contains(t, graph.ToMermaid(), "flowchart TD")
+			assert.Contains(t, graph.ToDOT(), "digraph bytecode")
+			for _, edge := range tt.edges {
+				assert.Contains(t, graph.ToMermaid(), blockId(edge.From)+" -->|"+string(edge.Kind)+"| "+blockId(edge.To))
+				assert.Contains(t, graph.ToDOT(), blockId(edge.From)+" -> "+blockId(edge.To))
+			}
+		})
+	}
+}
+
+func TestControlFlowGraphLookups(t *testing.T) {
+	bytecode, err := hex.DecodeString(dispatcherBytecode)
+	require.NoError(t, err)
+
+	decompiler, err := NewDecompiler(context.TODO(), bytecode)
+	require.NoError(t, err)
+	require.NoError(t, decompiler.Decompile())
+
+	graph, err := decompiler.BuildControlFlowGraph()
+	require.NoError(t, err)
+
+	assert.Equal(t, 0x00, graph.GetBlockAt(0x05).GetStart())
+	assert.Equal(t, 0x1c, graph.GetBlockAt(0x21).GetStart())
+	assert.Nil(t, graph.GetBlockAt(0x100))
+
+	assert.Equal(t, 0x1c, graph.GetFunction("A9059CBB").GetEntry())
+	assert.Equal(t, 0x22, graph.GetFunction("0x00fdd58e").GetEntry())
+	assert.Nil(t, graph.GetFunction("0xdeadbeef"))
+
+	assert.Len(t, graph.GetSuccessors(0x00), 2)
+	assert.Len(t, graph.GetPredecessors(0x24), 1)
+
+	disassembly := graph.String()
+	assert.Contains(t, disassembly, "block 0x001c // function 0xa9059cbb:")
+	assert.Contains(t, disassembly, "0x0027 JUMP // -> 0x0024")
+	assert.Contains(t, disassembly, "block 0x0028 // unreachable:")
+}
+
+func TestStackEffect(t *testing.T) {
+	tests := []struct {
+		op     OpCode
+		pops   int
+		pushes int
+	}{
+		{ADD, 2, 1},
+		{CALL, 7, 1},
+		{PUSH0, 0, 1},
+		{PUSH32, 0, 1},
+		{DUP1, 1, 2},
+		{DUP16, 16, 17},
+		{SWAP1, 2, 2},
+		{SWAP16, 17, 17},
+		{LOG0, 2, 0},
+		{LOG4, 6, 0},
+		{MCOPY, 3, 0},
+		{BLOBBASEFEE, 0, 1},
+		{OpCode(0x0c), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op.String(), func(t *testing.T) {
+			pops, pushes := tt.op.StackEffect()
+			assert.Equal(t, tt.pops, pops)
+			assert.Equal(t, tt.pushes, pushes)
+		})
+	}
+
+	assert.True(t, JUMP.IsTerminator())
+	assert.True(t, INVALID.IsTerminator())
+	assert.False(t, OpCode(0x0c).IsTerminator())
+	assert.False(t, JUMPI.IsTerminator())
+	assert.False(t, OpCode(0x0c).IsDefined())
+}
@@ -8,15 +8,17 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	opcode_pb "github.com/unpackdev/protos/dist/go/opcode"
+	"go.uber.org/zap"
 )
 
 // Decompiler is responsible for decompiling Ethereum bytecode into a set of instructions.
 type Decompiler struct {
-	ctx                 context.Context // The context for the decompiler.
-	bytecode            []byte          // The bytecode to be decompiled.
-	bytecodeSize        uint64          // The size of the bytecode.
-	instructions        []Instruction   // The resulting set of instructions after decompilation.
-	functionEntryPoints []int           // Slice to store function entry points.
+	ctx                 context.Context   // The context for the decompiler.
+	bytecode            []byte            // The bytecode to be decompiled.
+	bytecodeSize        uint64            // The size of the bytecode.
+	instructions        []Instruction     // The resulting set of instructions after decompilation.
+	functionEntryPoints []int             // Slice to store function entry points.
+	controlFlowGraph    *ControlFlowGraph // Basic block graph built from the instructions.
 }
 
 // NewDecompiler initializes a new Decompiler with the given bytecode.
@@ -78,7 +80,10 @@ func (d *Decompiler) Decompile() error {
 		offset++
 	}
 
-	fmt.Printf("Total instructions processed: %d\n", len(d.instructions))
+	zap.L().Debug(
+		"decompiled bytecode",
+		zap.Int("instructions", len(d.instructions)),
+	)
 	return nil
 }
 
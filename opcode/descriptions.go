@@ -40,6 +40,7 @@ var descriptions = map[OpCode]string{
 	CHAINID:        "Get the chain ID of the current chain.",
 	BASEFEE:        "Get the base fee of the current block.",
 	BLOBHASH:       "Get the hash of the current blob.",
+	BLOBBASEFEE:    "Get the blob base fee of the current block.",
 	DELEGATECALL:   "Message-call into this account with an alternative account’s code, but persisting the current values for `sender` and `value`.",
 	STATICCALL:     "Static message-call into an account.",
 	CODESIZE:       "Get size of code running in current environment.",
@@ -69,6 +70,7 @@ var descriptions = map[OpCode]string{
 	MSIZE:          "Get size of active memory in bytes.",
 	GAS:            "Get the amount of available gas, including the corresponding reduction the amount of available gas.",
 	JUMPDEST:       "Mark a valid destination for jumps.",
+	MCOPY:          "Copy memory areas.",
 	PUSH0:          "Push 0 bytes onto the stack.",
 	PUSH1:          "Push 1 byte onto the stack.",
 	PUSH2:          "Push 2 bytes onto the stack.",
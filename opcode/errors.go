@@ -6,4 +6,5 @@ import (
 
 var (
 	ErrEmptyBytecode = errors.New("bytecode is not set or empty bytecode provided")
+	ErrNotDecompiled = errors.New("bytecode has to be decompiled first")
 )
@@ -2,7 +2,9 @@ package opcode
 
 import (
 	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
 )
 
 // FunctionTreeNode represents a node in the opcode execution tree that represents a function.
@@ -51,13 +53,19 @@ func (d *Decompiler) buildFunctions(stack []*FunctionTreeNode, functions *[]*Fun
 			stack = append(stack, functionNode)
 		} else if instruction.OpCode.IsFunctionEnd() {
 			if len(stack) == 0 {
-				fmt.Println("Error: Found function end without corresponding start.")
+				zap.L().Debug(
+					"found function end without corresponding start",
+					zap.Int("offset", instruction.Offset),
+				)
 				continue
 			}
 			stack = stack[:len(stack)-1]
 		} else {
 			if len(stack) == 0 {
-				fmt.Println("Error: Found instruction outside of a function.")
+				zap.L().Debug(
+					"found instruction outside of a function",
+					zap.Int("offset", instruction.Offset),
+				)
 				continue
 			}
 
@@ -0,0 +1,68 @@
+package opcode
+
+// stackEffects describes how many items each opcode pops from and pushes onto the stack.
+// PUSH, DUP, SWAP and LOG opcodes are computed from their position within their range.
+var stackEffects = map[OpCode][2]int{
+	STOP: {0, 0}, ADD: {2, 1}, MUL: {2, 1}, SUB: {2, 1}, DIV: {2, 1}, SDIV: {2, 1}, MOD: {2, 1}, SMOD: {2, 1},
+	ADDMOD: {3, 1}, MULMOD: {3, 1}, EXP: {2, 1}, SIGNEXTEND: {2, 1},
+
+	LT: {2, 1}, GT: {2, 1}, SLT: {2, 1}, SGT: {2, 1}, EQ: {2, 1}, ISZERO: {1, 1}, AND: {2, 1}, OR: {2, 1},
+	XOR: {2, 1}, NOT: {1, 1}, BYTE: {2, 1}, SHL: {2, 1}, SHR: {2, 1}, SAR: {2, 1},
+
+	KECCAK256: {2, 1},
+
+	ADDRESS: {0, 1}, BALANCE: {1, 1}, ORIGIN: {0, 1}, CALLER: {0, 1}, CALLVALUE: {0, 1}, CALLDATALOAD: {1, 1},
+	CALLDATASIZE: {0, 1}, CALLDATACOPY: {3, 0}, CODESIZE: {0, 1}, CODECOPY: {3, 0}, GASPRICE: {0, 1},
+	EXTCODESIZE: {1, 1}, EXTCODECOPY: {4, 0}, RETURNDATASIZE: {0, 1}, RETURNDATACOPY: {3, 0}, EXTCODEHASH: {1, 1},
+
+	BLOCKHASH: {1, 1}, COINBASE: {0, 1}, TIMESTAMP: {0, 1}, NUMBER: {0, 1}, DIFFICULTY: {0, 1}, GASLIMIT: {0, 1},
+	CHAINID: {0, 1}, SELFBALANCE: {0, 1}, BASEFEE: {0, 1}, BLOBHASH: {1, 1},
+	BLOBBASEFEE: {0, 1},
+
+	POP: {1, 0}, MLOAD: {1, 1}, MSTORE: {2, 0}, MSTORE8: {2, 0}, SLOAD: {1, 1}, SSTORE: {2, 0}, JUMP: {1, 0},
+	JUMPI: {2, 0}, PC: {0, 1}, MSIZE: {0, 1}, GAS: {0, 1}, JUMPDEST: {0, 0}, MCOPY: {3, 0},
+	PUSH0: {0, 1},
+
+	TLOAD: {1, 1}, TSTORE: {2, 0},
+
+	CREATE: {3, 1}, CALL: {7, 1}, CALLCODE: {7, 1}, RETURN: {2, 0}, DELEGATECALL: {6, 1}, CREATE2: {4, 1},
+	STATICCALL: {6, 1}, REVERT: {2, 0}, INVALID: {0, 0}, SELFDESTRUCT: {1, 0},
+}
+
+// IsDefined checks if the opcode is part of the instruction set. Executing an undefined opcode halts
+// execution the same way INVALID does.
+func (op OpCode) IsDefined() bool {
+	return len(opCodeToString[op]) > 0
+}
+
+// StackEffect returns the number of items the opcode pops from the stack and the number of items it pushes
+// onto the stack. Undefined opcodes have no stack effect.
+func (op OpCode) StackEffect() (pops int, pushes int) {
+	switch {
+	case op.IsPush():
+		return 0, 1
+	case op >= DUP1 && op <= DUP16:
+		n := int(op) - DUP1 + 1
+		return n, n + 1
+	case op >= SWAP1 && op <= SWAP16:
+		n := int(op) - SWAP1 + 2
+		return n, n
+	case op.IsLog():
+		return int(op-LOG0) + 2, 0
+	}
+
+	effect := stackEffects[op]
+	return effect[0], effect[1]
+}
+
+// IsTerminator checks if the opcode ends a basic block without falling through to the next instruction.
+// These are the halting opcodes and unconditional jumps. Undefined opcodes are not terminators, as they are
+// mostly data, such as metadata or immutables, disassembled as instructions.
+func (op OpCode) IsTerminator() bool {
+	switch op {
+	case STOP, RETURN, REVERT, INVALID, SELFDESTRUCT, JUMP:
+		return true
+	default:
+		return false
+	}
+}
@@ -128,6 +128,8 @@ const (
 	BASEFEE OpCode = 0x48
 	// BLOBHASH retrieves the blob hash.
 	BLOBHASH OpCode = 0x49
+	// BLOBBASEFEE retrieves the blob base fee for the block.
+	BLOBBASEFEE OpCode = 0x4a
 )
 
 // Storage and execution operations (0x50).
@@ -156,6 +158,8 @@ const (
 	GAS OpCode = 0x5a
 	// JUMPDEST marks a valid destination for jumps.
 	JUMPDEST OpCode = 0x5b
+	// MCOPY copies an area of memory to another.
+	MCOPY OpCode = 0x5e
 	// PUSH0 pushes a zero byte onto the stack.
 	PUSH0 OpCode = 0x5f
 )
@@ -411,6 +415,7 @@ var opCodeToString = map[OpCode]string{
 	SELFBALANCE: "SELFBALANCE",
 	BASEFEE:     "BASEFEE",
 	BLOBHASH:    "BLOBHASH",
+	BLOBBASEFEE: "BLOBBASEFEE",
 
 	// 0x50 range - 'storage' and execution.
 	POP:      "POP",
@@ -425,6 +430,7 @@ var opCodeToString = map[OpCode]string{
 	MSIZE:    "MSIZE",
 	GAS:      "GAS",
 	JUMPDEST: "JUMPDEST",
+	MCOPY:    "MCOPY",
 	PUSH0:    "PUSH0",
 
 	// 0x60 range - pushes.
@@ -560,6 +566,7 @@ var stringToOp = map[string]OpCode{
 	"CHAINID":        CHAINID,
 	"BASEFEE":        BASEFEE,
 	"BLOBHASH":       BLOBHASH,
+	"BLOBBASEFEE":    BLOBBASEFEE,
 	"DELEGATECALL":   DELEGATECALL,
 	"STATICCALL":     STATICCALL,
 	"CODESIZE":       CODESIZE,
@@ -589,6 +596,7 @@ var stringToOp = map[string]OpCode{
 	"MSIZE":          MSIZE,
 	"GAS":            GAS,
 	"JUMPDEST":       JUMPDEST,
+	"MCOPY":          MCOPY,
 	"PUSH0":          PUSH0,
 	"PUSH1":          PUSH1,
 	"PUSH2":          PUSH2,
@@ -50,6 +50,7 @@ func TestStringToOp(t *testing.T) {
 		{"CHAINID", "CHAINID", CHAINID},
 		{"BASEFEE", "BASEFEE", BASEFEE},
 		{"BLOBHASH", "BLOBHASH", BLOBHASH},
+		{"BLOBBASEFEE", "BLOBBASEFEE", BLOBBASEFEE},
 		{"DELEGATECALL", "DELEGATECALL", DELEGATECALL},
 		{"STATICCALL", "STATICCALL", STATICCALL},
 		{"CODESIZE", "CODESIZE", CODESIZE},
@@ -79,6 +80,7 @@ func TestStringToOp(t *testing.T) {
 		{"MSIZE", "MSIZE", MSIZE},
 		{"GAS", "GAS", GAS},
 		{"JUMPDEST", "JUMPDEST", JUMPDEST},
+		{"MCOPY", "MCOPY", MCOPY},
 		{"PUSH0", "PUSH0", PUSH0},
 		{"PUSH1", "PUSH1", PUSH1},
 		{"PUSH2", "PUSH2", PUSH2},
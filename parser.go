@@ -88,6 +88,7 @@ func NewParserFromSources(ctx context.Context, sources *Sources) (*Parser, error
 
 	// Create a new SyntaxErrorListener
 	errListener := syntaxerrors.NewSyntaxErrorListener()
+	errListener.SetSourceFiles(sources.getSourceFiles()...)
 
 	// Create a new Solidity lexer with the input stream
 	lexer := parser.NewSolidityLexer(inputStream)
@@ -157,6 +158,12 @@ func (s *Parser) GetTree() antlr.ParseTree {
 	return s.solidityParser.SourceUnit()
 }
 
+// GetDiagnostics returns the structured diagnostics of the syntax errors encountered during parsing,
+// located relative to the source file they occurred in.
+func (s *Parser) GetDiagnostics() syntaxerrors.Diagnostics {
+	return s.errListener.GetDiagnostics()
+}
+
 // Parse initiates the parsing process. It walks the parse tree with all registered listeners
 // and returns any syntax errors that were encountered during parsing.
 func (s *Parser) Parse() []syntaxerrors.SyntaxError {
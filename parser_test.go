@@ -2,6 +2,7 @@ package solgo
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -170,3 +171,38 @@ func TestNew_SyntaxErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDiagnostics(t *testing.T) {
+	basePath := t.TempDir()
+	sources := &Sources{
+		SourceUnits: []*SourceUnit{
+			{
+				Name:    "Token",
+				Path:    "contracts/Token.sol",
+				Content: "contract Token {}",
+			},
+			{
+				Name:    "Vault",
+				Path:    filepath.Join(basePath, "contracts", "Vault.sol"),
+				Content: "contract Vault {\n    uint public count\n}\n",
+			},
+		},
+		EntrySourceUnitName: "Vault",
+		BasePath:            basePath,
+	}
+
+	parser, err := NewParserFromSources(context.Background(), sources)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, parser.Parse())
+
+	diagnostics := parser.GetDiagnostics()
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, syntaxerrors.CodeMissingSemicolon, diagnostics[0].Code)
+	assert.Equal(t, "contracts/Vault.sol", diagnostics[0].Range.File)
+	assert.Equal(t, 3, diagnostics[0].Range.Line)
+	assert.Equal(t, "contracts/Vault.sol:3:1: error[SG2001]: mismatched input '}' expecting {';', '='}", diagnostics[0].Error())
+
+	fixed, err := diagnostics[0].Fixes[0].Apply(sources.SourceUnits[1].Content)
+	assert.NoError(t, err)
+	assert.Equal(t, "contract Vault {\n    uint public count;\n}\n", fixed)
+}
@@ -0,0 +1,234 @@
+package solgo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// foundryRemappingsRegex matches the remappings array within foundry.toml.
+var foundryRemappingsRegex = regexp.MustCompile(`(?s)\bremappings\s*=\s*\[(.*?)\]`)
+
+// quotedStringRegex matches a single or double quoted TOML string.
+var quotedStringRegex = regexp.MustCompile(`["']([^"']*)["']`)
+
+// Remapping represents a solc import remapping in the `context:prefix=target` format.
+// Context is optional and restricts the remapping to source units whose path starts with it.
+// See https://docs.soliditylang.org/en/latest/path-resolution.html#import-remapping
+type Remapping struct {
+	Context string `yaml:"context,omitempty" json:"context,omitempty"`
+	Prefix  string `yaml:"prefix" json:"prefix"`
+	Target  string `yaml:"target" json:"target"`
+}
+
+// String returns the remapping in the solc `context:prefix=target` format.
+func (r *Remapping) String() string {
+	if r.Context != "" {
+		return fmt.Sprintf("%s:%s=%s", r.Context, r.Prefix, r.Target)
+	}
+	return fmt.Sprintf("%s=%s", r.Prefix, r.Target)
+}
+
+// Matches returns true if the remapping applies to the import path within the importing source unit.
+func (r *Remapping) Matches(importPath string, importer string) bool {
+	if !strings.HasPrefix(importPath, r.Prefix) {
+		return false
+	}
+	return r.Context == "" || strings.HasPrefix(importer, r.Context)
+}
+
+// ParseRemapping parses a single remapping in the `context:prefix=target` format.
+func ParseRemapping(remapping string) (*Remapping, error) {
+	remapping = strings.TrimSpace(remapping)
+
+	eq := strings.Index(remapping, "=")
+	if eq <= 0 {
+		return nil, fmt.Errorf("invalid remapping %q: expected format [context:]prefix=target", remapping)
+	}
+
+	toReturn := &Remapping{
+		Prefix: remapping[:eq],
+		Target: remapping[eq+1:],
+	}
+
+	if colon := strings.Index(toReturn.Prefix, ":"); colon >= 0 {
+		toReturn.Context = toReturn.Prefix[:colon]
+		toReturn.Prefix = toReturn.Prefix[colon+1:]
+	}
+
+	if toReturn.Prefix == "" {
+		return nil, fmt.Errorf("invalid remapping %q: prefix cannot be empty", remapping)
+	}
+
+	return toReturn, nil
+}
+
+// ParseRemappings parses a list of remappings, skipping empty entries.
+func ParseRemappings(remappings []string) ([]*Remapping, error) {
+	var toReturn []*Remapping
+	for _, remapping := range remappings {
+		if strings.TrimSpace(remapping) == "" {
+			continue
+		}
+
+		parsed, err := ParseRemapping(remapping)
+		if err != nil {
+			return nil, err
+		}
+		toReturn = append(toReturn, parsed)
+	}
+	return toReturn, nil
+}
+
+// LoadRemappingsFromFile loads remappings from a remappings.txt file, one remapping per line.
+// Empty lines and lines starting with `#` are ignored.
+func LoadRemappingsFromFile(filePath string) ([]*Remapping, error) {
+	file, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failure while reading remappings file %s: %w", filePath, err)
+	}
+
+	return ParseRemappings(lines)
+}
+
+// LoadRemappingsFromFoundryToml loads remappings declared in the `remappings` array of a foundry.toml file.
+func LoadRemappingsFromFoundryToml(filePath string) ([]*Remapping, error) {
+	content, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	var remappings []string
+	for _, match := range foundryRemappingsRegex.FindAllStringSubmatch(string(content), -1) {
+		for _, entry := range quotedStringRegex.FindAllStringSubmatch(match[1], -1) {
+			remappings = append(remappings, entry[1])
+		}
+	}
+
+	return ParseRemappings(remappings)
+}
+
+// DiscoverRemappings discovers remappings of a Foundry or Hardhat project located at the root path.
+// Remappings are collected, in order of precedence, from remappings.txt, foundry.toml and finally
+// from libraries installed under lib/ the same way Foundry auto-detects them (`name/=lib/name/src/`).
+func DiscoverRemappings(root string) ([]*Remapping, error) {
+	var remappings []*Remapping
+
+	appendUnique := func(toAppend []*Remapping) {
+		for _, remapping := range toAppend {
+			exists := false
+			for _, existing := range remappings {
+				if existing.Context == remapping.Context && existing.Prefix == remapping.Prefix {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				remappings = append(remappings, remapping)
+			}
+		}
+	}
+
+	if found, err := LoadRemappingsFromFile(filepath.Join(root, "remappings.txt")); err == nil {
+		appendUnique(found)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if found, err := LoadRemappingsFromFoundryToml(filepath.Join(root, "foundry.toml")); err == nil {
+		appendUnique(found)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	libs, err := os.ReadDir(filepath.Join(root, "lib"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, lib := range libs {
+		if !lib.IsDir() {
+			continue
+		}
+
+		target := path.Join("lib", lib.Name()) + "/"
+		if info, err := os.Stat(filepath.Join(root, "lib", lib.Name(), "src")); err == nil && info.IsDir() {
+			target = path.Join("lib", lib.Name(), "src") + "/"
+		}
+
+		appendUnique([]*Remapping{{Prefix: lib.Name() + "/", Target: target}})
+	}
+
+	return remappings, nil
+}
+
+// FindProjectRoot walks up from the provided directory looking for a Foundry or Hardhat project root,
+// identified by remappings.txt, foundry.toml or a hardhat configuration file.
+// If no project root is found, the provided directory is returned.
+func FindProjectRoot(dir string) string {
+	markers := []string{"remappings.txt", "foundry.toml", "hardhat.config.js", "hardhat.config.ts"}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+
+	for current := absDir; ; {
+		for _, marker := range markers {
+			if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+				return current
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return absDir
+		}
+		current = parent
+	}
+}
+
+// ApplyRemappings applies the best matching remapping to the import path as solc does: the remapping
+// with the longest context wins, and among those the one with the longest prefix.
+// If no remapping matches, the import path is returned unchanged.
+func ApplyRemappings(remappings []*Remapping, importPath string, importer string) string {
+	var best *Remapping
+	for _, remapping := range remappings {
+		if !remapping.Matches(importPath, importer) {
+			continue
+		}
+
+		// Longer context wins, then longer prefix. Solc gives precedence to the last
+		// remapping when both are of the same length.
+		if best == nil ||
+			len(remapping.Context) > len(best.Context) ||
+			(len(remapping.Context) == len(best.Context) && len(remapping.Prefix) >= len(best.Prefix)) {
+			best = remapping
+		}
+	}
+
+	if best == nil {
+		return importPath
+	}
+
+	return best.Target + strings.TrimPrefix(importPath, best.Prefix)
+}
@@ -0,0 +1,123 @@
+package solgo
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemapping(t *testing.T) {
+	testCases := []struct {
+		name      string
+		remapping string
+		expected  *Remapping
+		wantErr   bool
+	}{
+		{
+			name:      "Prefix And Target",
+			remapping: "@solmate/=lib/solmate/src/",
+			expected:  &Remapping{Prefix: "@solmate/", Target: "lib/solmate/src/"},
+		},
+		{
+			name:      "With Context",
+			remapping: "src/legacy:@openzeppelin/=lib/openzeppelin-v3/",
+			expected:  &Remapping{Context: "src/legacy", Prefix: "@openzeppelin/", Target: "lib/openzeppelin-v3/"},
+		},
+		{
+			name:      "Missing Target Separator",
+			remapping: "@solmate/",
+			wantErr:   true,
+		},
+		{
+			name:      "Empty Prefix",
+			remapping: "context:=lib/",
+			wantErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			remapping, err := ParseRemapping(testCase.remapping)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, remapping)
+			assert.Equal(t, testCase.remapping, remapping.String())
+		})
+	}
+}
+
+func TestApplyRemappings(t *testing.T) {
+	remappings, err := ParseRemappings([]string{
+		"@openzeppelin/=lib/openzeppelin-contracts/",
+		"@openzeppelin/contracts/=lib/openzeppelin-contracts/contracts/",
+		"src/legacy:@openzeppelin/=lib/openzeppelin-v3/",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "lib/openzeppelin-contracts/contracts/token/ERC20/ERC20.sol",
+		ApplyRemappings(remappings, "@openzeppelin/contracts/token/ERC20/ERC20.sol", "src/Token.sol"))
+	assert.Equal(t, "lib/openzeppelin-v3/contracts/token/ERC20/ERC20.sol",
+		ApplyRemappings(remappings, "@openzeppelin/contracts/token/ERC20/ERC20.sol", "src/legacy/Token.sol"))
+	assert.Equal(t, "./Local.sol", ApplyRemappings(remappings, "./Local.sol", "src/Token.sol"))
+}
+
+func TestNewSourcesFromPathWithRemappings(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"foundry.toml":   "[profile.default]\nsrc = \"src\"\nremappings = [\n\t\"@solmate/=lib/solmate/src/\",\n]\n",
+		"remappings.txt": "# custom prefix\n@custom/=vendor/custom/\n",
+		"src/Token.sol": `pragma solidity ^0.8.0;
+import {ERC20 as SolmateERC20} from "@solmate/tokens/ERC20.sol";
+import * as Std from "forge-std/Test.sol";
+import "@custom/Ownable.sol" as Own;
+import "./helpers/Math.sol";
+contract Token is SolmateERC20 {}`,
+		"src/helpers/Math.sol":             "pragma solidity ^0.8.0;\nlibrary Math {}",
+		"lib/solmate/src/tokens/ERC20.sol": "pragma solidity ^0.8.0;\nimport {Auth} from \"../auth/Auth.sol\";\nabstract contract ERC20 {}",
+		"lib/solmate/src/auth/Auth.sol":    "pragma solidity ^0.8.0;\nabstract contract Auth {}",
+		"lib/forge-std/src/Test.sol":       "pragma solidity ^0.8.0;\nabstract contract Test {}",
+		"vendor/custom/Ownable.sol":        "pragma solidity ^0.8.0;\nimport \"./Math.sol\";\nabstract contract Ownable {}",
+		"vendor/custom/Math.sol":           "pragma solidity ^0.8.0;\nlibrary OwnableMath {}",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	sources, err := NewSourcesFromPath("Token", filepath.Join(root, "src"))
+	require.NoError(t, err)
+	require.NotNil(t, sources)
+
+	assert.Equal(t, root, sources.BasePath)
+	assert.Equal(t, []string{filepath.Join(root, "lib")}, sources.IncludePaths)
+
+	var prefixes []string
+	for _, remapping := range sources.Remappings {
+		prefixes = append(prefixes, remapping.Prefix)
+	}
+	assert.Equal(t, []string{"@custom/", "@solmate/", "forge-std/", "solmate/"}, prefixes)
+
+	var names []string
+	for _, unit := range sources.SourceUnits {
+		names = append(names, unit.Name)
+	}
+	// Files sharing a base name are both resolved, the latter one is named after its source unit name.
+	assert.ElementsMatch(t, []string{"Token", "ERC20", "Auth", "Test", "Ownable", "Math", "src/helpers/Math"}, names)
+	assert.Equal(t, filepath.Join(root, "vendor", "custom", "Math.sol"), sources.GetSourceUnitByName("Math").GetPath())
+	assert.Equal(t, filepath.Join(root, "src", "helpers", "Math.sol"), sources.GetSourceUnitByName("src/helpers/Math").GetPath())
+	assert.Less(t, slices.Index(names, "Math"), slices.Index(names, "Ownable"))
+
+	// Dependencies must come before the units importing them.
+	assert.Equal(t, "Token", names[len(names)-1])
+	assert.Equal(t, filepath.Join(root, "lib", "solmate", "src", "auth", "Auth.sol"), sources.GetSourceUnitByName("Auth").GetPath())
+}
@@ -0,0 +1,796 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/goccy/go-json"
+)
+
+// transactionArgs are the arguments of calls, gas estimations and transactions.
+type transactionArgs struct {
+	From                 *common.Address   `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  *hexutil.Uint64   `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big      `json:"value"`
+	Nonce                *hexutil.Uint64   `json:"nonce"`
+	Data                 *hexutil.Bytes    `json:"data"`
+	Input                *hexutil.Bytes    `json:"input"`
+	AccessList           *types.AccessList `json:"accessList"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+}
+
+// data returns the input of the call, preferring input over the legacy data field.
+func (a *transactionArgs) data() []byte {
+	if a.Input != nil {
+		return *a.Input
+	}
+	if a.Data != nil {
+		return *a.Data
+	}
+	return nil
+}
+
+// from returns the sender of the call, the zero address if not set.
+func (a *transactionArgs) from() common.Address {
+	if a.From == nil {
+		return common.Address{}
+	}
+	return *a.From
+}
+
+// toMessage converts the arguments into a message.
+func (a *transactionArgs) toMessage() *message {
+	msg := &message{
+		From:     a.from(),
+		To:       a.To,
+		Value:    (*big.Int)(a.Value),
+		GasPrice: (*big.Int)(a.GasPrice),
+		Data:     a.data(),
+	}
+
+	if a.Gas != nil {
+		msg.GasLimit = uint64(*a.Gas)
+	}
+	if a.Nonce != nil {
+		msg.Nonce = uint64(*a.Nonce)
+	}
+	if a.AccessList != nil {
+		msg.AccessList = *a.AccessList
+	}
+	if a.MaxFeePerGas != nil || a.MaxPriorityFeePerGas != nil {
+		msg.GasFeeCap = (*big.Int)(a.MaxFeePerGas)
+		msg.GasTipCap = (*big.Int)(a.MaxPriorityFeePerGas)
+		if msg.GasFeeCap == nil {
+			msg.GasFeeCap = new(big.Int)
+		}
+	}
+
+	return msg
+}
+
+// revertError is returned for reverted calls, carrying the revert data as its JSON-RPC error data.
+type revertError struct {
+	error
+	data string
+}
+
+// ErrorCode returns the JSON-RPC error code of reverted calls.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert data.
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// newRevertError creates the error of the reverted execution, unpacking its revert reason if any.
+func newRevertError(result *executionResult) error {
+	data := result.Revert()
+	if data == nil {
+		return result.Err
+	}
+
+	err := vm.ErrExecutionReverted
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		err = fmt.Errorf("%w: %v", vm.ErrExecutionReverted, reason)
+	}
+
+	return &revertError{error: err, data: hexutil.Encode(data)}
+}
+
+// ethAPI implements the eth namespace of the simulator JSON-RPC endpoint.
+type ethAPI struct {
+	sim *Simulator
+}
+
+// ChainId returns the chain ID of the simulated chain.
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.sim.config.ChainID)
+}
+
+// BlockNumber returns the number of the latest simulated block.
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.sim.BlockNumber())
+}
+
+// GasPrice returns the base fee, as simulated transactions do not compete for inclusion.
+func (api *ethAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(api.sim.opts.GetBaseFee())
+}
+
+// MaxPriorityFeePerGas returns zero, as simulated transactions do not compete for inclusion.
+func (api *ethAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int))
+}
+
+// GetBalance returns the balance of the account at the block.
+func (api *ethAPI) GetBalance(ctx context.Context, addr common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if api.sim.isForkedBlock(&blockNrOrHash) {
+		var toReturn hexutil.Big
+		return &toReturn, api.sim.forward(ctx, &toReturn, "eth_getBalance", addr, blockNrOrHash)
+	}
+
+	balance := api.sim.state.GetBalance(addr)
+	return (*hexutil.Big)(balance.ToBig()), api.sim.state.Error()
+}
+
+// GetTransactionCount returns the nonce of the account at the block.
+func (api *ethAPI) GetTransactionCount(ctx context.Context, addr common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if api.sim.isForkedBlock(&blockNrOrHash) {
+		var toReturn hexutil.Uint64
+		return &toReturn, api.sim.forward(ctx, &toReturn, "eth_getTransactionCount", addr, blockNrOrHash)
+	}
+
+	nonce := hexutil.Uint64(api.sim.state.GetNonce(addr))
+	return &nonce, api.sim.state.Error()
+}
+
+// GetCode returns the code of the account at the block.
+func (api *ethAPI) GetCode(ctx context.Context, addr common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if api.sim.isForkedBlock(&blockNrOrHash) {
+		var toReturn hexutil.Bytes
+		return toReturn, api.sim.forward(ctx, &toReturn, "eth_getCode", addr, blockNrOrHash)
+	}
+
+	return common.CopyBytes(api.sim.state.GetCode(addr)), api.sim.state.Error()
+}
+
+// GetStorageAt returns the value of the storage slot of the account at the block.
+func (api *ethAPI) GetStorageAt(ctx context.Context, addr common.Address, key string, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if api.sim.isForkedBlock(&blockNrOrHash) {
+		var toReturn hexutil.Bytes
+		return toReturn, api.sim.forward(ctx, &toReturn, "eth_getStorageAt", addr, key, blockNrOrHash)
+	}
+
+	value := api.sim.state.GetState(addr, common.HexToHash(key))
+	return value.Bytes(), api.sim.state.Error()
+}
+
+// Call executes the call on top of the state at the block, without committing it.
+func (api *ethAPI) Call(ctx context.Context, args transactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if api.sim.isForkedBlock(blockNrOrHash) {
+		var toReturn hexutil.Bytes
+		return toReturn, api.sim.forward(ctx, &toReturn, "eth_call", args, blockNrOrHash)
+	}
+
+	result, err := api.sim.call(args.toMessage())
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Failed() {
+		return nil, newRevertError(result)
+	}
+
+	return result.ReturnData, nil
+}
+
+// EstimateGas returns the lowest gas limit the call succeeds with, searching between the gas used by the call
+// and the gas cap, which is the gas of the call if set and the block gas limit otherwise.
+func (api *ethAPI) EstimateGas(ctx context.Context, args transactionArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	hi := api.sim.opts.GetGasLimit()
+	if args.Gas != nil && uint64(*args.Gas) < hi {
+		hi = uint64(*args.Gas)
+	}
+
+	// The gas cap is lowered to what the sender can afford, if fees are paid.
+	if feeCap := args.toMessage().feeCap(); feeCap.Sign() > 0 {
+		balance := api.sim.state.GetBalance(args.from()).ToBig()
+		if args.Value != nil {
+			balance.Sub(balance, (*big.Int)(args.Value))
+		}
+		if allowance := new(big.Int).Div(balance, feeCap); allowance.IsUint64() && allowance.Uint64() < hi {
+			hi = allowance.Uint64()
+		}
+	}
+
+	execute := func(gas uint64) (*executionResult, error) {
+		msg := args.toMessage()
+		msg.GasLimit = gas
+		return api.sim.call(msg)
+	}
+
+	result, err := execute(hi)
+	if err != nil {
+		return 0, err
+	}
+	if result.Failed() {
+		return 0, newRevertError(result)
+	}
+
+	lo := result.UsedGas - 1
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		result, err := execute(mid)
+		if err != nil && !errors.Is(err, ErrIntrinsicGas) {
+			return 0, err
+		}
+
+		if err != nil || result.Failed() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hexutil.Uint64(hi), nil
+}
+
+// SendRawTransaction executes the signed transaction and mines it into a new block.
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if _, err := api.sim.sendTransaction(tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), nil
+}
+
+// signTransactionResult is the result of eth_signTransaction.
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// SignTransaction signs the transaction on behalf of the sender, which is impersonated, so any account can send
+// transactions through the simulator. Missing nonce, gas and fees are filled in.
+func (api *ethAPI) SignTransaction(ctx context.Context, args transactionArgs) (*signTransactionResult, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	tx, err := api.sim.signTransaction(args)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &signTransactionResult{Raw: raw, Tx: tx}, nil
+}
+
+// SendTransaction executes the transaction on behalf of the sender, which is impersonated, and mines it into a
+// new block. Missing nonce, gas and fees are filled in.
+func (api *ethAPI) SendTransaction(ctx context.Context, args transactionArgs) (common.Hash, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	tx, err := api.sim.signTransaction(args)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if _, err := api.sim.sendTransaction(tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), nil
+}
+
+// GetTransactionByHash returns the transaction, forwarding the lookup to the fork source if it is not simulated.
+func (api *ethAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (json.RawMessage, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	entry, ok := api.sim.transactions[hash]
+	if !ok {
+		var toReturn json.RawMessage
+		return toReturn, api.sim.forward(ctx, &toReturn, "eth_getTransactionByHash", hash)
+	}
+
+	return marshalTransaction(entry)
+}
+
+// GetTransactionReceipt returns the receipt of the transaction, forwarding the lookup to the fork source if it
+// is not simulated.
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (json.RawMessage, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	receipt, ok := api.sim.receipts[hash]
+	if !ok {
+		var toReturn json.RawMessage
+		return toReturn, api.sim.forward(ctx, &toReturn, "eth_getTransactionReceipt", hash)
+	}
+
+	return json.Marshal(receipt)
+}
+
+// GetBlockByNumber returns the block, forwarding the lookup to the fork source if it is not simulated.
+func (api *ethAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (json.RawMessage, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	head := api.sim.head().Number.Uint64()
+	if number < 0 {
+		number = rpc.BlockNumber(head)
+	}
+
+	if uint64(number) > head {
+		return nil, nil
+	}
+
+	if block := api.sim.getBlock(uint64(number)); block != nil {
+		return api.sim.marshalBlock(block, fullTx)
+	}
+
+	var toReturn json.RawMessage
+	return toReturn, api.sim.forward(ctx, &toReturn, "eth_getBlockByNumber", number, fullTx)
+}
+
+// GetBlockByHash returns the block, forwarding the lookup to the fork source if it is not simulated.
+func (api *ethAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (json.RawMessage, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	if block, ok := api.sim.blocksByHash[hash]; ok {
+		return api.sim.marshalBlock(block, fullTx)
+	}
+
+	var toReturn json.RawMessage
+	return toReturn, api.sim.forward(ctx, &toReturn, "eth_getBlockByHash", hash, fullTx)
+}
+
+// GetLogs returns the logs matching the filter, forwarding the part of the range preceding the simulated chain
+// to the fork source.
+func (api *ethAPI) GetLogs(ctx context.Context, query filterQuery) ([]*types.Log, error) {
+	api.sim.mu.Lock()
+	defer api.sim.mu.Unlock()
+
+	return api.sim.filterLogs(ctx, &query)
+}
+
+// netAPI implements the net namespace of the simulator JSON-RPC endpoint.
+type netAPI struct {
+	sim *Simulator
+}
+
+// Version returns the network ID of the simulated chain.
+func (api *netAPI) Version() string {
+	return api.sim.config.ChainID.String()
+}
+
+// anvilAPI implements the subset of the anvil namespace used to prepare simulations, so code written against
+// anvil runs against the simulator as well.
+type anvilAPI struct {
+	sim *Simulator
+}
+
+// SetBalance sets the balance of the account. The balance is hex encoded, with or without the 0x prefix.
+func (api *anvilAPI) SetBalance(addr common.Address, balance string) error {
+	value, ok := new(big.Int).SetString(strings.TrimPrefix(balance, "0x"), 16)
+	if !ok {
+		return fmt.Errorf("invalid balance %s", balance)
+	}
+	return api.sim.SetBalance(addr, value)
+}
+
+// SetNonce sets the nonce of the account.
+func (api *anvilAPI) SetNonce(addr common.Address, nonce hexutil.Uint64) error {
+	return api.sim.SetNonce(addr, uint64(nonce))
+}
+
+// SetCode sets the code of the account.
+func (api *anvilAPI) SetCode(addr common.Address, code hexutil.Bytes) error {
+	return api.sim.SetCode(addr, code)
+}
+
+// SetStorageAt sets the value of the storage slot of the account.
+func (api *anvilAPI) SetStorageAt(addr common.Address, slot common.Hash, value common.Hash) (bool, error) {
+	if err := api.sim.SetStorageAt(addr, slot, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ImpersonateAccount is a no-op, as every account is impersonated by the simulator.
+func (api *anvilAPI) ImpersonateAccount(addr common.Address) error {
+	return nil
+}
+
+// StopImpersonatingAccount is a no-op, as every account is impersonated by the simulator.
+func (api *anvilAPI) StopImpersonatingAccount(addr common.Address) error {
+	return nil
+}
+
+// forward forwards the JSON-RPC call to the fork source.
+func (s *Simulator) forward(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if s.source == nil {
+		return nil
+	}
+	return s.source.GetRpcClient().CallContext(ctx, result, method, args...)
+}
+
+// signTransaction builds the transaction from the arguments, filling in missing fields, and signs it on behalf
+// of the impersonated sender.
+func (s *Simulator) signTransaction(args transactionArgs) (*types.Transaction, error) {
+	if args.From == nil {
+		return nil, errors.New("missing transaction sender")
+	}
+
+	if args.ChainID != nil && args.ChainID.ToInt().Cmp(s.config.ChainID) != 0 {
+		return nil, fmt.Errorf("invalid chain id %s, expected %s", args.ChainID.ToInt(), s.config.ChainID)
+	}
+
+	nonce := s.state.GetNonce(*args.From)
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+
+	var gas uint64
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	} else {
+		result, err := s.call(args.toMessage())
+		if err != nil {
+			return nil, err
+		}
+		if result.Failed() {
+			return nil, newRevertError(result)
+		}
+		// The used gas does not account for the gas withheld from nested calls, so a margin is added.
+		gas = result.UsedGas + result.UsedGas/4
+		if limit := s.opts.GetGasLimit(); gas > limit {
+			gas = limit
+		}
+	}
+
+	var accessList types.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+
+	value := (*big.Int)(args.Value)
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	var data types.TxData
+	if args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+		tip := (*big.Int)(args.MaxPriorityFeePerGas)
+		if tip == nil {
+			tip = new(big.Int)
+		}
+		feeCap := (*big.Int)(args.MaxFeePerGas)
+		if feeCap == nil {
+			feeCap = new(big.Int).Add(tip, new(big.Int).Mul(s.opts.GetBaseFee(), common.Big2))
+		}
+		data = &types.DynamicFeeTx{
+			ChainID:    s.config.ChainID,
+			Nonce:      nonce,
+			GasTipCap:  tip,
+			GasFeeCap:  feeCap,
+			Gas:        gas,
+			To:         args.To,
+			Value:      value,
+			Data:       args.data(),
+			AccessList: accessList,
+		}
+	} else {
+		gasPrice := (*big.Int)(args.GasPrice)
+		if gasPrice == nil {
+			gasPrice = new(big.Int).Set(s.opts.GetBaseFee())
+		}
+		data = &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       args.To,
+			Value:    value,
+			Data:     args.data(),
+		}
+	}
+
+	return s.sign(types.NewTx(data), *args.From)
+}
+
+// marshalBlock returns the JSON-RPC representation of the simulated block.
+func (s *Simulator) marshalBlock(block *types.Block, fullTx bool) (json.RawMessage, error) {
+	encoded, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	transactions := make([]interface{}, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		if !fullTx {
+			transactions = append(transactions, tx.Hash())
+			continue
+		}
+
+		encoded, err := marshalTransaction(s.transactions[tx.Hash()])
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, encoded)
+	}
+
+	fields["hash"] = block.Hash()
+	fields["size"] = hexutil.Uint64(block.Size())
+	fields["totalDifficulty"] = (*hexutil.Big)(new(big.Int))
+	fields["transactions"] = transactions
+	fields["uncles"] = make([]common.Hash, 0)
+
+	return json.Marshal(fields)
+}
+
+// marshalTransaction returns the JSON-RPC representation of the simulated transaction.
+func marshalTransaction(entry *transactionEntry) (json.RawMessage, error) {
+	encoded, err := entry.tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["from"] = entry.from
+	fields["blockHash"] = entry.blockHash
+	fields["blockNumber"] = hexutil.Uint64(entry.blockNumber)
+	fields["transactionIndex"] = hexutil.Uint(entry.index)
+
+	return json.Marshal(fields)
+}
+
+// filterQuery is the filter of eth_getLogs.
+type filterQuery struct {
+	BlockHash *common.Hash
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// UnmarshalJSON decodes the filter, where the address is either a single address or a list of addresses, and
+// each topic position is either null, a single topic or a list of alternative topics.
+func (q *filterQuery) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Addresses json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	q.BlockHash, q.FromBlock, q.ToBlock = raw.BlockHash, raw.FromBlock, raw.ToBlock
+
+	if len(raw.Addresses) > 0 && string(raw.Addresses) != "null" {
+		var addr common.Address
+		if err := json.Unmarshal(raw.Addresses, &addr); err == nil {
+			q.Addresses = []common.Address{addr}
+		} else if err := json.Unmarshal(raw.Addresses, &q.Addresses); err != nil {
+			return fmt.Errorf("invalid address filter: %w", err)
+		}
+	}
+
+	for _, rawTopic := range raw.Topics {
+		if len(rawTopic) == 0 || string(rawTopic) == "null" {
+			q.Topics = append(q.Topics, nil)
+			continue
+		}
+
+		var topic common.Hash
+		if err := json.Unmarshal(rawTopic, &topic); err == nil {
+			q.Topics = append(q.Topics, []common.Hash{topic})
+			continue
+		}
+
+		var topics []*common.Hash
+		if err := json.Unmarshal(rawTopic, &topics); err != nil {
+			return fmt.Errorf("invalid topic filter: %w", err)
+		}
+
+		alternatives := make([]common.Hash, 0, len(topics))
+		for _, topic := range topics {
+			if topic == nil {
+				alternatives = nil
+				break
+			}
+			alternatives = append(alternatives, *topic)
+		}
+		q.Topics = append(q.Topics, alternatives)
+	}
+
+	return nil
+}
+
+// matches checks if the log matches the addresses and topics of the filter.
+func (q *filterQuery) matches(log *types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, addr := range q.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		found := false
+		for _, topic := range alternatives {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterLogs returns the logs matching the filter within the simulated blocks, along with the logs of the
+// blocks preceding them as returned by the fork source.
+func (s *Simulator) filterLogs(ctx context.Context, query *filterQuery) ([]*types.Log, error) {
+	toReturn := make([]*types.Log, 0)
+
+	if query.BlockHash != nil {
+		block, ok := s.blocksByHash[*query.BlockHash]
+		if !ok {
+			if s.source == nil {
+				return toReturn, nil
+			}
+			logs, err := s.source.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: query.BlockHash, Addresses: query.Addresses, Topics: query.Topics})
+			if err != nil {
+				return nil, err
+			}
+			for i := range logs {
+				toReturn = append(toReturn, &logs[i])
+			}
+			return toReturn, nil
+		}
+		return s.blockLogs(block, query), nil
+	}
+
+	head := s.head().Number.Uint64()
+	resolve := func(number *rpc.BlockNumber) uint64 {
+		if number == nil || *number < 0 {
+			return head
+		}
+		return uint64(*number)
+	}
+
+	from, to := resolve(query.FromBlock), resolve(query.ToBlock)
+	if from > to {
+		return toReturn, nil
+	}
+
+	first := head + 1
+	if len(s.blocks) > 0 {
+		first = s.blocks[0].NumberU64()
+	}
+
+	if from < first && s.source != nil {
+		forkedTo := to
+		if forkedTo >= first {
+			forkedTo = first - 1
+		}
+
+		logs, err := s.source.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(forkedTo),
+			Addresses: query.Addresses,
+			Topics:    query.Topics,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range logs {
+			toReturn = append(toReturn, &logs[i])
+		}
+	}
+
+	for number := max(from, first); number <= to; number++ {
+		if block := s.getBlock(number); block != nil {
+			toReturn = append(toReturn, s.blockLogs(block, query)...)
+		}
+	}
+
+	return toReturn, nil
+}
+
+// blockLogs returns the logs of the simulated block matching the filter.
+func (s *Simulator) blockLogs(block *types.Block, query *filterQuery) []*types.Log {
+	toReturn := make([]*types.Log, 0)
+	for _, tx := range block.Transactions() {
+		receipt, ok := s.receipts[tx.Hash()]
+		if !ok {
+			continue
+		}
+
+		for _, log := range receipt.Logs {
+			if query.matches(log) {
+				toReturn = append(toReturn, log)
+			}
+		}
+	}
+	return toReturn
+}
@@ -0,0 +1,12 @@
+// Package simulator provides a local EVM simulator built on top of the go-ethereum EVM and an in-memory state.
+//
+// The state is either lazily forked from a client, fetching accounts and storage slots the first time they are
+// accessed at the fork block, or loaded from a JSON state dump, or both, with the dump applied on top of the
+// forked state. The simulator state can be dumped back into a JSON file, so prepared states can be reused.
+//
+// The simulator serves the Ethereum JSON-RPC API through an in-process endpoint, exposed as a regular client and
+// as a client pool holding it. Accounts, bindings, storage and tokens therefore run against the simulator the
+// same way they run against a real chain. Every transaction is mined into its own block right away, and any
+// sender can be impersonated through eth_signTransaction, which accounts use for simulated transact options.
+// The subset of the anvil namespace used to prepare simulations, such as anvil_setBalance, is supported too.
+package simulator
@@ -0,0 +1,111 @@
+package simulator
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+)
+
+// DumpAccount is the state of an account within a state dump.
+type DumpAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   hexutil.Uint64              `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// StateDump is a JSON state dump, keyed by account address, in the format of a genesis allocation.
+type StateDump map[common.Address]*DumpAccount
+
+// LoadStateDump reads the state dump from the JSON file.
+func LoadStateDump(path string) (StateDump, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state dump: %w", err)
+	}
+
+	var toReturn StateDump
+	if err := json.Unmarshal(data, &toReturn); err != nil {
+		return nil, fmt.Errorf("failed to decode state dump: %w", err)
+	}
+
+	return toReturn, nil
+}
+
+// SaveStateDump writes the state dump to the JSON file.
+func SaveStateDump(path string, dump StateDump) error {
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state dump: %w", err)
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// Load sets the state of the accounts within the dump, replacing any state they had. Loaded accounts are not
+// forked, slots missing from the dump are empty.
+func (s *StateDB) Load(dump StateDump) {
+	for addr, dumped := range dump {
+		account := &stateAccount{
+			balance:   new(uint256.Int),
+			nonce:     uint64(dumped.Nonce),
+			code:      common.CopyBytes(dumped.Code),
+			codeHash:  crypto.Keccak256Hash(dumped.Code),
+			committed: make(map[common.Hash]common.Hash),
+			dirty:     make(map[common.Hash]common.Hash),
+			cleared:   true,
+			exists:    true,
+		}
+
+		if dumped.Balance != nil {
+			account.balance, _ = uint256.FromBig((*big.Int)(dumped.Balance))
+		}
+
+		for key, value := range dumped.Storage {
+			account.committed[key] = value
+		}
+
+		s.accounts[addr] = account
+	}
+}
+
+// Dump returns the state of every account known to the StateDB, including the forked accounts fetched so far.
+// Storage slots holding zero are left out.
+func (s *StateDB) Dump() StateDump {
+	toReturn := make(StateDump)
+	for addr, account := range s.accounts {
+		if !account.exists {
+			continue
+		}
+
+		dumped := &DumpAccount{
+			Balance: (*hexutil.Big)(account.balance.ToBig()),
+			Nonce:   hexutil.Uint64(account.nonce),
+			Code:    common.CopyBytes(account.code),
+			Storage: make(map[common.Hash]common.Hash),
+		}
+
+		for key, value := range account.committed {
+			if value != (common.Hash{}) {
+				dumped.Storage[key] = value
+			}
+		}
+		for key, value := range account.dirty {
+			if value != (common.Hash{}) {
+				dumped.Storage[key] = value
+			} else {
+				delete(dumped.Storage, key)
+			}
+		}
+
+		toReturn[addr] = dumped
+	}
+
+	return toReturn
+}
@@ -0,0 +1,73 @@
+package simulator
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultClientType is the client type the simulator client is registered with.
+	DefaultClientType = "simulator"
+
+	// DefaultGasLimit is the gas limit of simulated blocks, also used as the gas cap of calls and estimations.
+	DefaultGasLimit = uint64(30_000_000)
+)
+
+// Options defines the configuration parameters of the simulator.
+type Options struct {
+	// ChainID is the chain ID of the simulated chain. Defaults to the network ID of the fork source, or to the
+	// ID of the network the simulator is created for.
+	ChainID *big.Int `json:"chain_id"`
+
+	// ForkBlock is the block the state is forked at. Defaults to the latest block of the fork source.
+	ForkBlock *big.Int `json:"fork_block"`
+
+	// StateDumpPath is the path of a JSON state dump loaded on start, applied on top of the forked state if any.
+	StateDumpPath string `json:"state_dump_path"`
+
+	// BaseFee is the base fee of simulated blocks. Defaults to zero, so transactions do not need to pay for gas.
+	BaseFee *big.Int `json:"base_fee"`
+
+	// GasLimit is the gas limit of simulated blocks. Defaults to DefaultGasLimit.
+	GasLimit uint64 `json:"gas_limit"`
+
+	// Coinbase is the beneficiary of simulated blocks.
+	Coinbase common.Address `json:"coinbase"`
+
+	// ClientType is the type the simulator client is registered with. Defaults to DefaultClientType.
+	ClientType string `json:"client_type"`
+}
+
+// GetBaseFee returns the base fee of simulated blocks.
+func (o *Options) GetBaseFee() *big.Int {
+	if o.BaseFee == nil {
+		return new(big.Int)
+	}
+	return o.BaseFee
+}
+
+// GetGasLimit returns the gas limit of simulated blocks.
+func (o *Options) GetGasLimit() uint64 {
+	if o.GasLimit == 0 {
+		return DefaultGasLimit
+	}
+	return o.GasLimit
+}
+
+// GetClientType returns the type the simulator client is registered with.
+func (o *Options) GetClientType() string {
+	if o.ClientType == "" {
+		return DefaultClientType
+	}
+	return o.ClientType
+}
+
+// NewDefaultOptions creates and returns a new instance of Options with default settings, forking the latest
+// block of the fork source if any.
+func NewDefaultOptions() *Options {
+	return &Options{
+		GasLimit:   DefaultGasLimit,
+		ClientType: DefaultClientType,
+	}
+}
@@ -0,0 +1,512 @@
+package simulator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/unpackdev/solgo/clients"
+	"github.com/unpackdev/solgo/utils"
+	"go.uber.org/zap"
+)
+
+// transactionEntry is a transaction included within a simulated block.
+type transactionEntry struct {
+	tx          *types.Transaction
+	from        common.Address
+	blockHash   common.Hash
+	blockNumber uint64
+	index       uint
+}
+
+// Simulator is a local EVM backed by an in-memory state, lazily forked from a client or loaded from a JSON state
+// dump. It is exposed through an in-process JSON-RPC endpoint, so the simulator client, and the client pool
+// holding it, can be used anywhere a regular client is, such as by accounts, bindings and storage.
+//
+// Every transaction is mined into its own block right away. State queries are served from the current state for
+// any block since the fork, and forwarded to the fork source for blocks preceding it.
+type Simulator struct {
+	ctx     context.Context
+	network utils.Network
+	opts    *Options
+	source  *clients.Client
+	config  *params.ChainConfig
+
+	mu             sync.Mutex
+	state          *StateDB
+	forkHeader     *types.Header
+	blocks         []*types.Block
+	blocksByHash   map[common.Hash]*types.Block
+	receipts       map[common.Hash]*types.Receipt
+	transactions   map[common.Hash]*transactionEntry
+	impersonations map[common.Hash]common.Address
+	signingKey     *ecdsa.PrivateKey
+
+	server *rpc.Server
+	pool   *clients.ClientPool
+	client *clients.Client
+}
+
+// NewSimulator creates a new simulator for the network. If the source client is set, state is lazily forked from
+// it at the configured fork block; otherwise the simulator starts from an empty genesis block. A state dump, if
+// configured, is loaded on top of either.
+func NewSimulator(ctx context.Context, network utils.Network, source *clients.Client, opts *Options) (*Simulator, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("options cannot be nil")
+	}
+
+	chainID := opts.ChainID
+	if chainID == nil {
+		switch {
+		case source != nil:
+			chainID = big.NewInt(source.GetNetworkID())
+		case utils.GetNetworkID(network).IsValid():
+			chainID = utils.GetNetworkID(network).ToBig()
+		default:
+			chainID = utils.AnvilNetworkID.ToBig()
+		}
+	}
+
+	config := *params.AllDevChainProtocolChanges
+	config.ChainID = chainID
+	config.CancunTime = new(uint64)
+
+	signingKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate simulator signing key: %w", err)
+	}
+
+	toReturn := &Simulator{
+		ctx:            ctx,
+		network:        network,
+		opts:           opts,
+		source:         source,
+		config:         &config,
+		blocksByHash:   make(map[common.Hash]*types.Block),
+		receipts:       make(map[common.Hash]*types.Receipt),
+		transactions:   make(map[common.Hash]*transactionEntry),
+		impersonations: make(map[common.Hash]common.Address),
+		signingKey:     signingKey,
+	}
+
+	if source != nil {
+		header, err := source.HeaderByNumber(ctx, opts.ForkBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fork block header: %w", err)
+		}
+		toReturn.forkHeader = header
+		toReturn.state = NewStateDB(ctx, source, header.Number)
+	} else {
+		toReturn.state = NewStateDB(ctx, nil, nil)
+		toReturn.addBlock(types.NewBlockWithHeader(&types.Header{
+			ParentHash:  common.Hash{},
+			UncleHash:   types.EmptyUncleHash,
+			Coinbase:    opts.Coinbase,
+			Root:        types.EmptyRootHash,
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+			Difficulty:  new(big.Int),
+			Number:      new(big.Int),
+			GasLimit:    opts.GetGasLimit(),
+			Time:        uint64(time.Now().Unix()),
+			BaseFee:     new(big.Int).Set(opts.GetBaseFee()),
+		}))
+	}
+
+	if opts.StateDumpPath != "" {
+		dump, err := LoadStateDump(opts.StateDumpPath)
+		if err != nil {
+			return nil, err
+		}
+		toReturn.state.Load(dump)
+	}
+
+	toReturn.server = rpc.NewServer()
+	for namespace, service := range map[string]interface{}{
+		"eth":   &ethAPI{sim: toReturn},
+		"net":   &netAPI{sim: toReturn},
+		"anvil": &anvilAPI{sim: toReturn},
+	} {
+		if err := toReturn.server.RegisterName(namespace, service); err != nil {
+			return nil, fmt.Errorf("failed to register simulator %s api: %w", namespace, err)
+		}
+	}
+
+	node := &clients.Node{
+		Group:             network.String(),
+		Type:              opts.GetClientType(),
+		NetworkId:         int(chainID.Int64()),
+		Endpoint:          "inproc://simulator",
+		ConcurrentClients: 1,
+	}
+
+	toReturn.client, err = clients.NewClientFromRPC(ctx, node, rpc.DialInProc(toReturn.server))
+	if err != nil {
+		toReturn.server.Stop()
+		return nil, err
+	}
+
+	toReturn.pool, err = clients.NewClientPool(ctx, &clients.Options{})
+	if err != nil {
+		toReturn.Close()
+		return nil, err
+	}
+	toReturn.pool.AddClient(toReturn.client)
+
+	zap.L().Debug(
+		"started simulator",
+		zap.String("network", network.String()),
+		zap.Int64("chain_id", chainID.Int64()),
+		zap.Uint64("block_number", toReturn.head().Number.Uint64()),
+		zap.Bool("forked", source != nil),
+	)
+
+	return toReturn, nil
+}
+
+// GetNetwork returns the network the simulator is created for.
+func (s *Simulator) GetNetwork() utils.Network {
+	return s.network
+}
+
+// GetChainConfig returns the chain configuration of the simulated chain.
+func (s *Simulator) GetChainConfig() *params.ChainConfig {
+	return s.config
+}
+
+// GetClient returns the client connected to the simulator.
+func (s *Simulator) GetClient() *clients.Client {
+	return s.client
+}
+
+// GetClientPool returns a client pool holding only the simulator client, grouped by the simulator network, to be
+// used by components working with a client pool, such as the bindings manager or the storage.
+func (s *Simulator) GetClientPool() *clients.ClientPool {
+	return s.pool
+}
+
+// IsForked checks if the simulator state is forked from a client.
+func (s *Simulator) IsForked() bool {
+	return s.source != nil
+}
+
+// BlockNumber returns the number of the latest simulated block.
+func (s *Simulator) BlockNumber() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.head().Number.Uint64()
+}
+
+// SetBalance sets the balance of the account.
+func (s *Simulator) SetBalance(addr common.Address, balance *big.Int) error {
+	amount, overflow := uint256.FromBig(balance)
+	if overflow {
+		return fmt.Errorf("balance %s overflows 256 bits", balance)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.setBalance(s.state.getAccount(addr), amount)
+	return s.commitState()
+}
+
+// SetNonce sets the nonce of the account.
+func (s *Simulator) SetNonce(addr common.Address, nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SetNonce(addr, nonce)
+	return s.commitState()
+}
+
+// SetCode sets the code of the account.
+func (s *Simulator) SetCode(addr common.Address, code []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SetCode(addr, common.CopyBytes(code))
+	return s.commitState()
+}
+
+// SetStorageAt sets the value of the storage slot of the account.
+func (s *Simulator) SetStorageAt(addr common.Address, slot, value common.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SetState(addr, slot, value)
+	return s.commitState()
+}
+
+// Dump returns the state dump of every account known to the simulator, including forked accounts fetched so far.
+func (s *Simulator) Dump() StateDump {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Dump()
+}
+
+// SaveState writes the state dump of the simulator to the JSON file, to be loaded through Options.StateDumpPath.
+func (s *Simulator) SaveState(path string) error {
+	return SaveStateDump(path, s.Dump())
+}
+
+// Close closes the simulator client and stops the JSON-RPC endpoint.
+func (s *Simulator) Close() {
+	if s.pool != nil {
+		s.pool.Close()
+	} else if s.client != nil {
+		s.client.Close()
+	}
+	s.server.Stop()
+}
+
+// commitState finalises state changes made outside of transactions, discarding them if forking state failed.
+func (s *Simulator) commitState() error {
+	if err := s.state.Error(); err != nil {
+		s.state.Discard()
+		return err
+	}
+	s.state.Finalise()
+	return nil
+}
+
+// head returns the header of the latest block, which is the fork block until a transaction is simulated.
+func (s *Simulator) head() *types.Header {
+	if len(s.blocks) == 0 {
+		return s.forkHeader
+	}
+	return s.blocks[len(s.blocks)-1].Header()
+}
+
+// pendingHeader returns the header of the block the next transaction is included in.
+func (s *Simulator) pendingHeader() *types.Header {
+	parent := s.head()
+
+	timestamp := uint64(time.Now().Unix())
+	if timestamp <= parent.Time {
+		timestamp = parent.Time + 1
+	}
+
+	return &types.Header{
+		ParentHash:  parent.Hash(),
+		UncleHash:   types.EmptyUncleHash,
+		Coinbase:    s.opts.Coinbase,
+		Root:        types.EmptyRootHash,
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.EmptyReceiptsHash,
+		Difficulty:  new(big.Int),
+		Number:      new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:    s.opts.GetGasLimit(),
+		Time:        timestamp,
+		BaseFee:     new(big.Int).Set(s.opts.GetBaseFee()),
+	}
+}
+
+// addBlock appends the block to the simulated chain.
+func (s *Simulator) addBlock(block *types.Block) {
+	s.blocks = append(s.blocks, block)
+	s.blocksByHash[block.Hash()] = block
+}
+
+// getBlock returns the simulated block of the number, nil if it precedes the simulated chain.
+func (s *Simulator) getBlock(number uint64) *types.Block {
+	if len(s.blocks) == 0 {
+		return nil
+	}
+
+	first := s.blocks[0].NumberU64()
+	if number < first || number-first >= uint64(len(s.blocks)) {
+		return nil
+	}
+
+	return s.blocks[number-first]
+}
+
+// getHash returns the hash of the block of the number, as needed by the BLOCKHASH opcode.
+func (s *Simulator) getHash(number uint64) common.Hash {
+	if block := s.getBlock(number); block != nil {
+		return block.Hash()
+	}
+
+	if s.forkHeader == nil || number > s.forkHeader.Number.Uint64() {
+		return common.Hash{}
+	}
+
+	if number == s.forkHeader.Number.Uint64() {
+		return s.forkHeader.Hash()
+	}
+
+	header, err := s.source.HeaderByNumber(s.ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		zap.L().Debug("failed to get forked block hash", zap.Uint64("block_number", number), zap.Error(err))
+		return common.Hash{}
+	}
+
+	return header.Hash()
+}
+
+// isForkedBlock checks if the block precedes the fork block, in which case queries at it are forwarded to the
+// fork source.
+func (s *Simulator) isForkedBlock(blockNrOrHash *rpc.BlockNumberOrHash) bool {
+	if s.forkHeader == nil || blockNrOrHash == nil {
+		return false
+	}
+
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		_, local := s.blocksByHash[hash]
+		return !local && hash != s.forkHeader.Hash()
+	}
+
+	number, ok := blockNrOrHash.Number()
+	return ok && number >= 0 && uint64(number) < s.forkHeader.Number.Uint64()
+}
+
+// sender returns the sender of the transaction, either impersonated through eth_signTransaction or recovered
+// from its signature. Impersonated senders may be contracts, such as liquidity pairs.
+func (s *Simulator) sender(tx *types.Transaction) (common.Address, error) {
+	if from, ok := s.impersonations[tx.Hash()]; ok {
+		return from, nil
+	}
+	return types.Sender(types.LatestSignerForChainID(s.config.ChainID), tx)
+}
+
+// sign signs the transaction with the simulator key, impersonating the sender.
+func (s *Simulator) sign(tx *types.Transaction, from common.Address) (*types.Transaction, error) {
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(s.config.ChainID), s.signingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	s.impersonations[signed.Hash()] = from
+	return signed, nil
+}
+
+// sendTransaction executes the transaction and mines it into a new block. Transactions that could never be
+// included in a block are rejected, while reverted transactions are mined with a failed receipt.
+func (s *Simulator) sendTransaction(tx *types.Transaction) (*types.Receipt, error) {
+	if tx.Protected() && tx.ChainId().Cmp(s.config.ChainID) != 0 {
+		return nil, fmt.Errorf("invalid chain id %s, expected %s", tx.ChainId(), s.config.ChainID)
+	}
+
+	if _, ok := s.transactions[tx.Hash()]; ok {
+		return nil, fmt.Errorf("already known transaction %s", tx.Hash().Hex())
+	}
+
+	from, err := s.sender(tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	_, impersonated := s.impersonations[tx.Hash()]
+
+	header := s.pendingHeader()
+	if tx.Gas() > header.GasLimit {
+		return nil, fmt.Errorf("transaction gas %d exceeds block gas limit %d", tx.Gas(), header.GasLimit)
+	}
+
+	msg := &message{
+		From:       from,
+		To:         tx.To(),
+		Nonce:      tx.Nonce(),
+		Value:      tx.Value(),
+		GasLimit:   tx.Gas(),
+		GasPrice:   tx.GasPrice(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+
+		AllowContractSender: impersonated,
+	}
+
+	if tx.Type() != types.LegacyTxType && tx.Type() != types.AccessListTxType {
+		msg.GasFeeCap, msg.GasTipCap = tx.GasFeeCap(), tx.GasTipCap()
+	}
+
+	result, err := s.applyMessage(s.state, header, msg, false)
+	if err == nil {
+		err = s.state.Error()
+	}
+	if err != nil {
+		s.state.Discard()
+		return nil, err
+	}
+
+	logs := s.state.Logs()
+	s.state.Finalise()
+
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: result.UsedGas,
+		Logs:              make([]*types.Log, 0, len(logs)),
+		TxHash:            tx.Hash(),
+		GasUsed:           result.UsedGas,
+		EffectiveGasPrice: result.GasPrice,
+		BlockNumber:       header.Number,
+		TransactionIndex:  0,
+	}
+
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+
+	if msg.To == nil && !result.Failed() {
+		receipt.ContractAddress = result.ContractAddress
+	}
+
+	for i, log := range logs {
+		log.BlockNumber = header.Number.Uint64()
+		log.TxHash = tx.Hash()
+		log.TxIndex = 0
+		log.Index = uint(i)
+		receipt.Logs = append(receipt.Logs, log)
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	// Roots are not trie roots, they only identify the content of the block, as the simulator keeps no tries.
+	encodedReceipt, err := receipt.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode receipt: %w", err)
+	}
+	header.TxHash = crypto.Keccak256Hash(tx.Hash().Bytes())
+	header.ReceiptHash = crypto.Keccak256Hash(encodedReceipt)
+	header.Bloom = receipt.Bloom
+	header.GasUsed = result.UsedGas
+
+	block := types.NewBlockWithHeader(header).WithBody([]*types.Transaction{tx}, nil)
+	s.addBlock(block)
+
+	receipt.BlockHash = block.Hash()
+	for _, log := range receipt.Logs {
+		log.BlockHash = block.Hash()
+	}
+
+	s.receipts[tx.Hash()] = receipt
+	s.transactions[tx.Hash()] = &transactionEntry{
+		tx:          tx,
+		from:        from,
+		blockHash:   block.Hash(),
+		blockNumber: block.NumberU64(),
+	}
+
+	return receipt, nil
+}
+
+// call executes the message on top of the current state without committing it.
+func (s *Simulator) call(msg *message) (*executionResult, error) {
+	defer s.state.Discard()
+
+	header := s.pendingHeader()
+	if msg.GasLimit == 0 || msg.GasLimit > header.GasLimit {
+		msg.GasLimit = header.GasLimit
+	}
+	msg.SkipAccountChecks = true
+
+	result, err := s.applyMessage(s.state, header, msg, true)
+	if err == nil {
+		err = s.state.Error()
+	}
+	return result, err
+}
@@ -0,0 +1,385 @@
+package simulator
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/accounts"
+	"github.com/unpackdev/solgo/bindings"
+	"github.com/unpackdev/solgo/clients"
+	"github.com/unpackdev/solgo/utils"
+)
+
+// storedValueInitCode deploys a contract storing 42 at slot 0, whose runtime code returns the value of slot 0
+// for any call.
+var storedValueInitCode = common.FromHex("602a600055600b6011600039600b6000f3600054600052602060" + "00f3")
+
+// storedValueABI is the ABI of the contract deployed by storedValueInitCode.
+const storedValueABI = `[{"type":"function","name":"value","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`
+
+func newTestSimulator(t *testing.T, source *clients.Client, opts *Options) *Simulator {
+	sim, err := NewSimulator(context.Background(), utils.AnvilNetwork, source, opts)
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+	return sim
+}
+
+func sendSigned(t *testing.T, sim *Simulator, key []byte, to *common.Address, value *big.Int, gas uint64, data []byte) *types.Receipt {
+	ctx := context.Background()
+	client := sim.GetClient()
+
+	privateKey, err := crypto.ToECDSA(key)
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	nonce, err := client.PendingNonceAt(ctx, from)
+	require.NoError(t, err)
+
+	tx, err := types.SignNewTx(privateKey, types.LatestSignerForChainID(sim.GetChainConfig().ChainID), &types.DynamicFeeTx{
+		ChainID:   sim.GetChainConfig().ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1_000_000_000),
+		Gas:       gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	return receipt
+}
+
+func TestSimulatorTransfer(t *testing.T) {
+	ctx := context.Background()
+	sim := newTestSimulator(t, nil, &Options{BaseFee: big.NewInt(1_000)})
+	client := sim.GetClient()
+
+	key := crypto.Keccak256([]byte("sender"))
+	privateKey, err := crypto.ToECDSA(key)
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	require.NoError(t, sim.SetBalance(from, big.NewInt(1e18)))
+
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, utils.GetNetworkID(utils.AnvilNetwork).ToBig(), chainID)
+
+	receipt := sendSigned(t, sim, key, &to, big.NewInt(1_000), 21_000, nil)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, uint64(21_000), receipt.GasUsed)
+	assert.Equal(t, uint64(1), sim.BlockNumber())
+
+	balance, err := client.BalanceAt(ctx, to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1_000), balance)
+
+	// The sender pays the value and the base fee and priority fee of the used gas.
+	balance, err = client.BalanceAt(ctx, from, nil)
+	require.NoError(t, err)
+	expected := new(big.Int).Sub(big.NewInt(1e18), big.NewInt(1_000+21_000*1_001))
+	assert.Equal(t, expected, balance)
+
+	block, err := client.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1)
+	assert.Equal(t, receipt.TxHash, block.Transactions()[0].Hash())
+	assert.Equal(t, receipt.BlockHash, block.Hash())
+
+	tx, pending, err := client.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+	assert.False(t, pending)
+	assert.Equal(t, &to, tx.To())
+
+	// Transactions replaying a nonce are rejected without mining a block.
+	privateKeyNonce, err := types.SignNewTx(privateKey, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		Nonce:    0,
+		GasPrice: big.NewInt(1_000),
+		Gas:      21_000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	})
+	require.NoError(t, err)
+	err = client.SendTransaction(ctx, privateKeyNonce)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrNonceMismatch.Error())
+	assert.Equal(t, uint64(1), sim.BlockNumber())
+}
+
+func TestSimulatorContract(t *testing.T) {
+	ctx := context.Background()
+	sim := newTestSimulator(t, nil, NewDefaultOptions())
+	client := sim.GetClient()
+
+	key := crypto.Keccak256([]byte("deployer"))
+	privateKey, err := crypto.ToECDSA(key)
+	require.NoError(t, err)
+	require.NoError(t, sim.SetBalance(crypto.PubkeyToAddress(privateKey.PublicKey), big.NewInt(1e18)))
+
+	receipt := sendSigned(t, sim, key, nil, nil, 200_000, storedValueInitCode)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	contract := receipt.ContractAddress
+	require.NotEqual(t, common.Address{}, contract)
+
+	code, err := client.CodeAt(ctx, contract, nil)
+	require.NoError(t, err)
+	assert.Equal(t, storedValueInitCode[17:], code)
+
+	value, err := client.StorageAt(ctx, contract, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(42)).Bytes(), value)
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(42)).Bytes(), result)
+
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{To: &contract})
+	require.NoError(t, err)
+	assert.Greater(t, gas, uint64(21_000))
+	assert.Less(t, gas, uint64(30_000))
+
+	// Storage overrides are visible to calls.
+	require.NoError(t, sim.SetStorageAt(contract, common.Hash{}, common.BigToHash(big.NewInt(7))))
+
+	manager, err := bindings.NewManager(ctx, sim.GetClientPool())
+	require.NoError(t, err)
+	_, err = manager.RegisterBinding(utils.AnvilNetwork, utils.GetNetworkID(utils.AnvilNetwork), bindings.BindingType("StoredValue"), contract, storedValueABI)
+	require.NoError(t, err)
+
+	stored, err := manager.CallContractMethod(ctx, utils.AnvilNetwork, bindings.BindingType("StoredValue"), contract, "value")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), stored)
+
+	// Reverting code reports the revert through the call error.
+	reverting := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	require.NoError(t, sim.SetCode(reverting, common.FromHex("60006000fd")))
+	_, err = client.CallContract(ctx, ethereum.CallMsg{To: &reverting}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution reverted")
+}
+
+func TestSimulatorImpersonation(t *testing.T) {
+	ctx := context.Background()
+	sim := newTestSimulator(t, nil, NewDefaultOptions())
+	client := sim.GetClient()
+
+	from := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.NoError(t, sim.SetBalance(from, big.NewInt(1e18)))
+
+	account := &accounts.Account{Address: from}
+	opts, err := account.TransactOpts(client, big.NewInt(500), true)
+	require.NoError(t, err)
+	require.NotNil(t, opts.Signer)
+
+	tx, err := opts.Signer(from, types.NewTransaction(opts.Nonce.Uint64(), to, opts.Value, 21_000, opts.GasPrice, nil))
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+
+	balance, err := client.BalanceAt(ctx, to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(500), balance)
+
+	nonce, err := client.NonceAt(ctx, from, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+}
+
+func TestSimulatorStateDump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	sim := newTestSimulator(t, nil, NewDefaultOptions())
+	require.NoError(t, sim.SetBalance(addr, big.NewInt(123)))
+	require.NoError(t, sim.SetNonce(addr, 4))
+	require.NoError(t, sim.SetCode(addr, common.FromHex("600054")))
+	require.NoError(t, sim.SetStorageAt(addr, common.Hash{1}, common.Hash{2}))
+	require.NoError(t, sim.SaveState(path))
+
+	dump, err := LoadStateDump(path)
+	require.NoError(t, err)
+	require.Contains(t, dump, addr)
+	assert.Equal(t, big.NewInt(123), dump[addr].Balance.ToInt())
+	assert.Equal(t, hexutil.Uint64(4), dump[addr].Nonce)
+	assert.Equal(t, map[common.Hash]common.Hash{{1}: {2}}, dump[addr].Storage)
+
+	loaded := newTestSimulator(t, nil, &Options{StateDumpPath: path})
+	ctx := context.Background()
+	client := loaded.GetClient()
+
+	balance, err := client.BalanceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(123), balance)
+
+	nonce, err := client.NonceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), nonce)
+
+	value, err := client.StorageAt(ctx, addr, common.Hash{1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{2}.Bytes(), value)
+}
+
+// newForkSource serves the subset of the JSON-RPC API needed to fork the state of a single account at block 100,
+// counting the requests it receives.
+func newForkSource(t *testing.T, addr common.Address, requests *atomic.Int32) *clients.Client {
+	header := map[string]interface{}{
+		"parentHash":       common.Hash{},
+		"sha3Uncles":       types.EmptyUncleHash,
+		"miner":            common.Address{},
+		"stateRoot":        types.EmptyRootHash,
+		"transactionsRoot": types.EmptyTxsHash,
+		"receiptsRoot":     types.EmptyReceiptsHash,
+		"logsBloom":        types.Bloom{},
+		"difficulty":       "0x0",
+		"number":           "0x64",
+		"gasLimit":         "0x1c9c380",
+		"gasUsed":          "0x0",
+		"timestamp":        "0x65000000",
+		"extraData":        "0x",
+		"mixHash":          common.Hash{},
+		"nonce":            types.BlockNonce{},
+		"baseFeePerGas":    "0x0",
+		"transactions":     []interface{}{},
+		"uncles":           []interface{}{},
+	}
+
+	handle := func(method string, params []json.RawMessage) interface{} {
+		switch method {
+		case "net_version":
+			return "1"
+		case "eth_getBlockByNumber":
+			return header
+		case "eth_getBalance":
+			return "0x3e8"
+		case "eth_getTransactionCount":
+			return "0x2"
+		case "eth_getCode":
+			return "0x600054"
+		case "eth_getStorageAt":
+			var slot string
+			require.NoError(t, json.Unmarshal(params[1], &slot))
+			if common.HexToHash(slot) == (common.Hash{}) {
+				return common.BigToHash(big.NewInt(9))
+			}
+			return common.Hash{}
+		}
+		return nil
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		respond := func(req request) map[string]interface{} {
+			requests.Add(1)
+			return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": handle(req.Method, req.Params)}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+			var batch []request
+			require.NoError(t, json.Unmarshal(body, &batch))
+			responses := make([]map[string]interface{}, 0, len(batch))
+			for _, req := range batch {
+				responses = append(responses, respond(req))
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(responses))
+			return
+		}
+
+		var req request
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.NoError(t, json.NewEncoder(w).Encode(respond(req)))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := clients.NewClient(context.Background(), &clients.Node{
+		Group:             utils.Ethereum.String(),
+		Type:              "mainnet",
+		Endpoint:          server.URL,
+		NetworkId:         1,
+		ConcurrentClients: 1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestSimulatorFork(t *testing.T) {
+	ctx := context.Background()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000dd")
+
+	var requests atomic.Int32
+	source := newForkSource(t, addr, &requests)
+
+	sim, err := NewSimulator(ctx, utils.Ethereum, source, NewDefaultOptions())
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+
+	assert.True(t, sim.IsForked())
+	assert.Equal(t, uint64(100), sim.BlockNumber())
+	assert.Equal(t, big.NewInt(1), sim.GetChainConfig().ChainID)
+
+	client := sim.GetClient()
+
+	balance, err := client.BalanceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1_000), balance)
+
+	nonce, err := client.NonceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+
+	value, err := client.StorageAt(ctx, addr, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(9)).Bytes(), value)
+
+	// Forked state is fetched once and served from memory afterwards.
+	fetched := requests.Load()
+	_, err = client.BalanceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	_, err = client.StorageAt(ctx, addr, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fetched, requests.Load())
+
+	// Local changes are applied on top of the forked state.
+	require.NoError(t, sim.SetStorageAt(addr, common.Hash{}, common.BigToHash(big.NewInt(10))))
+	value, err = client.StorageAt(ctx, addr, common.Hash{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(10)).Bytes(), value)
+
+	block, err := client.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), block.NumberU64())
+}
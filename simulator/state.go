@@ -0,0 +1,491 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/unpackdev/solgo/clients"
+)
+
+// stateAccount is the state of an account held by the StateDB.
+type stateAccount struct {
+	balance  *uint256.Int
+	nonce    uint64
+	code     []byte
+	codeHash common.Hash
+
+	// committed holds the storage as of the beginning of the current transaction, dirty the changes made since.
+	committed map[common.Hash]common.Hash
+	dirty     map[common.Hash]common.Hash
+
+	// cleared is set once the storage of the account no longer reflects the forked state, as the account was
+	// created or destroyed, so missing slots are empty rather than fetched.
+	cleared bool
+
+	// exists is set for accounts found within the forked state or touched by the simulation.
+	exists bool
+
+	selfDestructed bool
+}
+
+// StateDB is an in-memory implementation of the go-ethereum vm.StateDB. When a fork source is set, accounts and
+// storage slots missing from memory are lazily fetched from the source at the fork block, so only the state a
+// simulation touches is ever downloaded.
+//
+// Failures to fetch forked state can not be surfaced through the vm.StateDB interface; the first one is recorded
+// and returned by Error, and the results of an execution during which it occurred must be discarded.
+type StateDB struct {
+	ctx       context.Context
+	source    *clients.Client
+	forkBlock *big.Int
+	accounts  map[common.Address]*stateAccount
+
+	journal   []func()
+	refund    uint64
+	logs      []*types.Log
+	transient map[common.Address]map[common.Hash]common.Hash
+	created   map[common.Address]bool
+
+	accessAddresses map[common.Address]bool
+	accessSlots     map[common.Address]map[common.Hash]bool
+
+	err error
+}
+
+// NewStateDB creates an empty StateDB. If the source is set, missing state is lazily forked from it at the
+// fork block, or at the latest block if the fork block is nil.
+func NewStateDB(ctx context.Context, source *clients.Client, forkBlock *big.Int) *StateDB {
+	return &StateDB{
+		ctx:             ctx,
+		source:          source,
+		forkBlock:       forkBlock,
+		accounts:        make(map[common.Address]*stateAccount),
+		transient:       make(map[common.Address]map[common.Hash]common.Hash),
+		created:         make(map[common.Address]bool),
+		accessAddresses: make(map[common.Address]bool),
+		accessSlots:     make(map[common.Address]map[common.Hash]bool),
+	}
+}
+
+// Error returns the first failure to fetch forked state, if any.
+func (s *StateDB) Error() error {
+	return s.err
+}
+
+// setError records the failure, keeping the first one.
+func (s *StateDB) setError(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// blockArg returns the block the forked state is fetched at.
+func (s *StateDB) blockArg() string {
+	if s.forkBlock == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(s.forkBlock)
+}
+
+// getAccount returns the account, fetching it from the fork source if it is not yet known.
+func (s *StateDB) getAccount(addr common.Address) *stateAccount {
+	if account, ok := s.accounts[addr]; ok {
+		return account
+	}
+
+	account := &stateAccount{
+		balance:   new(uint256.Int),
+		codeHash:  types.EmptyCodeHash,
+		committed: make(map[common.Hash]common.Hash),
+		dirty:     make(map[common.Hash]common.Hash),
+	}
+	s.accounts[addr] = account
+
+	if s.source == nil {
+		account.cleared = true
+		return account
+	}
+
+	var balance hexutil.Big
+	var nonce hexutil.Uint64
+	var code hexutil.Bytes
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBalance", Args: []interface{}{addr, s.blockArg()}, Result: &balance},
+		{Method: "eth_getTransactionCount", Args: []interface{}{addr, s.blockArg()}, Result: &nonce},
+		{Method: "eth_getCode", Args: []interface{}{addr, s.blockArg()}, Result: &code},
+	}
+
+	if err := s.source.GetRpcClient().BatchCallContext(s.ctx, batch); err != nil {
+		s.setError(fmt.Errorf("failed to fork account %s: %w", addr.Hex(), err))
+		delete(s.accounts, addr)
+		return account
+	}
+
+	for _, elem := range batch {
+		if elem.Error != nil {
+			s.setError(fmt.Errorf("failed to fork account %s: %w", addr.Hex(), elem.Error))
+			delete(s.accounts, addr)
+			return account
+		}
+	}
+
+	account.balance, _ = uint256.FromBig((*big.Int)(&balance))
+	if account.balance == nil {
+		account.balance = new(uint256.Int)
+	}
+	account.nonce = uint64(nonce)
+	if len(code) > 0 {
+		account.code = code
+		account.codeHash = crypto.Keccak256Hash(code)
+	}
+	account.exists = !account.balance.IsZero() || account.nonce > 0 || len(account.code) > 0
+
+	return account
+}
+
+// getCommittedState returns the value of the slot as of the beginning of the transaction, fetching it from the
+// fork source if it is not yet known.
+func (s *StateDB) getCommittedState(account *stateAccount, addr common.Address, key common.Hash) common.Hash {
+	if value, ok := account.committed[key]; ok {
+		return value
+	}
+
+	var value common.Hash
+	if !account.cleared && s.source != nil {
+		var result hexutil.Bytes
+		if err := s.source.GetRpcClient().CallContext(s.ctx, &result, "eth_getStorageAt", addr, key, s.blockArg()); err != nil {
+			s.setError(fmt.Errorf("failed to fork storage slot %s of %s: %w", key.Hex(), addr.Hex(), err))
+			return value
+		}
+		value = common.BytesToHash(result)
+	}
+
+	account.committed[key] = value
+	return value
+}
+
+// CreateAccount creates the account, keeping its balance.
+func (s *StateDB) CreateAccount(addr common.Address) {
+	account := s.getAccount(addr)
+	previous := *account
+	s.journal = append(s.journal, func() { *account = previous })
+
+	*account = stateAccount{
+		balance:   account.balance,
+		codeHash:  types.EmptyCodeHash,
+		committed: make(map[common.Hash]common.Hash),
+		dirty:     make(map[common.Hash]common.Hash),
+		cleared:   true,
+		exists:    true,
+	}
+
+	if !s.created[addr] {
+		s.created[addr] = true
+		s.journal = append(s.journal, func() { delete(s.created, addr) })
+	}
+}
+
+// SubBalance subtracts the amount from the balance of the account.
+func (s *StateDB) SubBalance(addr common.Address, amount *uint256.Int) {
+	account := s.getAccount(addr)
+	s.setBalance(account, new(uint256.Int).Sub(account.balance, amount))
+}
+
+// AddBalance adds the amount to the balance of the account.
+func (s *StateDB) AddBalance(addr common.Address, amount *uint256.Int) {
+	account := s.getAccount(addr)
+	s.setBalance(account, new(uint256.Int).Add(account.balance, amount))
+}
+
+// setBalance sets the balance of the account, touching it.
+func (s *StateDB) setBalance(account *stateAccount, balance *uint256.Int) {
+	previous, exists := account.balance, account.exists
+	s.journal = append(s.journal, func() { account.balance, account.exists = previous, exists })
+	account.balance = balance
+	account.exists = true
+}
+
+// GetBalance returns the balance of the account.
+func (s *StateDB) GetBalance(addr common.Address) *uint256.Int {
+	return new(uint256.Int).Set(s.getAccount(addr).balance)
+}
+
+// GetNonce returns the nonce of the account.
+func (s *StateDB) GetNonce(addr common.Address) uint64 {
+	return s.getAccount(addr).nonce
+}
+
+// SetNonce sets the nonce of the account.
+func (s *StateDB) SetNonce(addr common.Address, nonce uint64) {
+	account := s.getAccount(addr)
+	previous, exists := account.nonce, account.exists
+	s.journal = append(s.journal, func() { account.nonce, account.exists = previous, exists })
+	account.nonce = nonce
+	account.exists = true
+}
+
+// GetCodeHash returns the code hash of the account, or the zero hash if the account does not exist.
+func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
+	account := s.getAccount(addr)
+	if !account.exists {
+		return common.Hash{}
+	}
+	return account.codeHash
+}
+
+// GetCode returns the code of the account.
+func (s *StateDB) GetCode(addr common.Address) []byte {
+	return s.getAccount(addr).code
+}
+
+// SetCode sets the code of the account.
+func (s *StateDB) SetCode(addr common.Address, code []byte) {
+	account := s.getAccount(addr)
+	previousCode, previousHash, exists := account.code, account.codeHash, account.exists
+	s.journal = append(s.journal, func() { account.code, account.codeHash, account.exists = previousCode, previousHash, exists })
+	account.code = code
+	account.codeHash = crypto.Keccak256Hash(code)
+	account.exists = true
+}
+
+// GetCodeSize returns the size of the code of the account.
+func (s *StateDB) GetCodeSize(addr common.Address) int {
+	return len(s.getAccount(addr).code)
+}
+
+// AddRefund adds gas to the refund counter.
+func (s *StateDB) AddRefund(gas uint64) {
+	previous := s.refund
+	s.journal = append(s.journal, func() { s.refund = previous })
+	s.refund += gas
+}
+
+// SubRefund removes gas from the refund counter.
+func (s *StateDB) SubRefund(gas uint64) {
+	previous := s.refund
+	s.journal = append(s.journal, func() { s.refund = previous })
+	if gas > s.refund {
+		panic(fmt.Sprintf("refund counter below zero (gas: %d > refund: %d)", gas, s.refund))
+	}
+	s.refund -= gas
+}
+
+// GetRefund returns the current value of the refund counter.
+func (s *StateDB) GetRefund() uint64 {
+	return s.refund
+}
+
+// GetCommittedState returns the value of the slot as of the beginning of the transaction.
+func (s *StateDB) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	return s.getCommittedState(s.getAccount(addr), addr, key)
+}
+
+// GetState returns the current value of the slot.
+func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	account := s.getAccount(addr)
+	if value, ok := account.dirty[key]; ok {
+		return value
+	}
+	return s.getCommittedState(account, addr, key)
+}
+
+// SetState sets the value of the slot.
+func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
+	account := s.getAccount(addr)
+	previous, dirty := account.dirty[key]
+	s.journal = append(s.journal, func() {
+		if dirty {
+			account.dirty[key] = previous
+		} else {
+			delete(account.dirty, key)
+		}
+	})
+	account.dirty[key] = value
+}
+
+// GetTransientState returns the value of the transient storage slot.
+func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return s.transient[addr][key]
+}
+
+// SetTransientState sets the value of the transient storage slot.
+func (s *StateDB) SetTransientState(addr common.Address, key, value common.Hash) {
+	previous := s.GetTransientState(addr, key)
+	s.journal = append(s.journal, func() { s.setTransientState(addr, key, previous) })
+	s.setTransientState(addr, key, value)
+}
+
+// setTransientState sets the value of the transient storage slot, without journaling it.
+func (s *StateDB) setTransientState(addr common.Address, key, value common.Hash) {
+	if _, ok := s.transient[addr]; !ok {
+		s.transient[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.transient[addr][key] = value
+}
+
+// SelfDestruct marks the account as destroyed and clears its balance. The account is removed once the
+// transaction is finalised.
+func (s *StateDB) SelfDestruct(addr common.Address) {
+	account := s.getAccount(addr)
+	previous, balance := account.selfDestructed, account.balance
+	s.journal = append(s.journal, func() { account.selfDestructed, account.balance = previous, balance })
+	account.selfDestructed = true
+	account.balance = new(uint256.Int)
+}
+
+// HasSelfDestructed checks if the account was destroyed within the transaction.
+func (s *StateDB) HasSelfDestructed(addr common.Address) bool {
+	return s.getAccount(addr).selfDestructed
+}
+
+// Selfdestruct6780 destroys the account only if it was created within the same transaction, as of EIP-6780.
+func (s *StateDB) Selfdestruct6780(addr common.Address) {
+	if s.created[addr] {
+		s.SelfDestruct(addr)
+	}
+}
+
+// Exist checks if the account exists, including accounts destroyed within the transaction.
+func (s *StateDB) Exist(addr common.Address) bool {
+	account := s.getAccount(addr)
+	return account.exists || account.selfDestructed
+}
+
+// Empty checks if the account is empty as defined by EIP-161, with a zero balance, nonce and no code.
+func (s *StateDB) Empty(addr common.Address) bool {
+	account := s.getAccount(addr)
+	return account.balance.IsZero() && account.nonce == 0 && len(account.code) == 0
+}
+
+// AddressInAccessList checks if the address is within the access list.
+func (s *StateDB) AddressInAccessList(addr common.Address) bool {
+	return s.accessAddresses[addr]
+}
+
+// SlotInAccessList checks if the address and the slot are within the access list.
+func (s *StateDB) SlotInAccessList(addr common.Address, slot common.Hash) (bool, bool) {
+	return s.accessAddresses[addr], s.accessSlots[addr][slot]
+}
+
+// AddAddressToAccessList adds the address to the access list.
+func (s *StateDB) AddAddressToAccessList(addr common.Address) {
+	if s.accessAddresses[addr] {
+		return
+	}
+	s.accessAddresses[addr] = true
+	s.journal = append(s.journal, func() { delete(s.accessAddresses, addr) })
+}
+
+// AddSlotToAccessList adds the address and the slot to the access list.
+func (s *StateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	s.AddAddressToAccessList(addr)
+	if _, ok := s.accessSlots[addr]; !ok {
+		s.accessSlots[addr] = make(map[common.Hash]bool)
+	}
+	if s.accessSlots[addr][slot] {
+		return
+	}
+	s.accessSlots[addr][slot] = true
+	s.journal = append(s.journal, func() { delete(s.accessSlots[addr], slot) })
+}
+
+// Prepare resets the access list and transient storage for a new transaction, warming up the sender, the
+// destination, the precompiles and the transaction access list as of EIP-2929, EIP-2930 and EIP-3651.
+func (s *StateDB) Prepare(rules params.Rules, sender, coinbase common.Address, dest *common.Address, precompiles []common.Address, txAccesses types.AccessList) {
+	s.accessAddresses = make(map[common.Address]bool)
+	s.accessSlots = make(map[common.Address]map[common.Hash]bool)
+	s.transient = make(map[common.Address]map[common.Hash]common.Hash)
+
+	if !rules.IsBerlin {
+		return
+	}
+
+	s.AddAddressToAccessList(sender)
+	if dest != nil {
+		s.AddAddressToAccessList(*dest)
+	}
+	for _, addr := range precompiles {
+		s.AddAddressToAccessList(addr)
+	}
+	for _, tuple := range txAccesses {
+		s.AddAddressToAccessList(tuple.Address)
+		for _, key := range tuple.StorageKeys {
+			s.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+	if rules.IsShanghai {
+		s.AddAddressToAccessList(coinbase)
+	}
+}
+
+// Snapshot returns an identifier of the current state, to be reverted to with RevertToSnapshot.
+func (s *StateDB) Snapshot() int {
+	return len(s.journal)
+}
+
+// RevertToSnapshot reverts every change made since the snapshot was taken.
+func (s *StateDB) RevertToSnapshot(id int) {
+	for i := len(s.journal) - 1; i >= id; i-- {
+		s.journal[i]()
+	}
+	s.journal = s.journal[:id]
+}
+
+// AddLog records the log emitted by the transaction.
+func (s *StateDB) AddLog(log *types.Log) {
+	previous := len(s.logs)
+	s.journal = append(s.journal, func() { s.logs = s.logs[:previous] })
+	s.logs = append(s.logs, log)
+}
+
+// AddPreimage is a no-op, as preimages are not recorded.
+func (s *StateDB) AddPreimage(common.Hash, []byte) {}
+
+// Logs returns the logs emitted since the latest Finalise.
+func (s *StateDB) Logs() []*types.Log {
+	return s.logs
+}
+
+// Finalise commits the changes of the transaction, removing destroyed accounts and clearing the journal, the
+// refund counter, the logs and the accounts created within the transaction.
+func (s *StateDB) Finalise() {
+	for _, account := range s.accounts {
+		if account.selfDestructed {
+			*account = stateAccount{
+				balance:   new(uint256.Int),
+				codeHash:  types.EmptyCodeHash,
+				committed: make(map[common.Hash]common.Hash),
+				dirty:     make(map[common.Hash]common.Hash),
+				cleared:   true,
+			}
+			continue
+		}
+
+		for key, value := range account.dirty {
+			account.committed[key] = value
+		}
+		account.dirty = make(map[common.Hash]common.Hash)
+	}
+
+	s.journal = nil
+	s.refund = 0
+	s.logs = nil
+	s.created = make(map[common.Address]bool)
+}
+
+// Discard reverts every change made since the latest Finalise, clearing any failure to fetch forked state.
+func (s *StateDB) Discard() {
+	s.RevertToSnapshot(0)
+	s.err = nil
+	s.refund = 0
+	s.logs = nil
+	s.created = make(map[common.Address]bool)
+}
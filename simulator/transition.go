@@ -0,0 +1,254 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var (
+	// ErrNonceMismatch is returned when the nonce of the transaction does not match the nonce of the sender.
+	ErrNonceMismatch = errors.New("nonce mismatch")
+
+	// ErrSenderNoEOA is returned when the sender of the transaction is a contract.
+	ErrSenderNoEOA = errors.New("sender not an eoa")
+
+	// ErrInsufficientFunds is returned when the sender can not pay for the gas and the value of the transaction.
+	ErrInsufficientFunds = errors.New("insufficient funds for gas * price + value")
+
+	// ErrIntrinsicGas is returned when the gas limit of the transaction is below its intrinsic gas.
+	ErrIntrinsicGas = errors.New("intrinsic gas too low")
+
+	// ErrFeeCapTooLow is returned when the fee cap of the transaction is below the base fee.
+	ErrFeeCapTooLow = errors.New("max fee per gas less than block base fee")
+
+	// ErrMaxInitCodeSizeExceeded is returned when the init code of the transaction exceeds the EIP-3860 limit.
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+)
+
+// message is a call or a transaction to be executed by the simulator.
+type message struct {
+	From       common.Address
+	To         *common.Address
+	Nonce      uint64
+	Value      *big.Int
+	GasLimit   uint64
+	GasPrice   *big.Int
+	GasFeeCap  *big.Int
+	GasTipCap  *big.Int
+	Data       []byte
+	AccessList types.AccessList
+
+	// SkipAccountChecks disables the nonce and EOA checks of the sender, as done for calls.
+	SkipAccountChecks bool
+
+	// AllowContractSender disables the EOA check of the sender, so impersonated contracts can send transactions.
+	AllowContractSender bool
+}
+
+// executionResult is the outcome of an executed message.
+type executionResult struct {
+	UsedGas         uint64
+	Err             error
+	ReturnData      []byte
+	ContractAddress common.Address
+	GasPrice        *big.Int
+}
+
+// Failed checks if the execution failed, in which case its state changes were reverted.
+func (r *executionResult) Failed() bool {
+	return r.Err != nil
+}
+
+// Revert returns the revert data of the execution, if it was reverted.
+func (r *executionResult) Revert() []byte {
+	if !errors.Is(r.Err, vm.ErrExecutionReverted) {
+		return nil
+	}
+	return common.CopyBytes(r.ReturnData)
+}
+
+// gasPrice returns the price paid per unit of gas by the message within the block.
+func (m *message) gasPrice(baseFee *big.Int) *big.Int {
+	if m.GasFeeCap == nil {
+		if m.GasPrice == nil {
+			return new(big.Int)
+		}
+		return m.GasPrice
+	}
+
+	tip := m.GasTipCap
+	if tip == nil {
+		tip = new(big.Int)
+	}
+
+	price := new(big.Int).Add(tip, baseFee)
+	if price.Cmp(m.GasFeeCap) > 0 {
+		return m.GasFeeCap
+	}
+	return price
+}
+
+// feeCap returns the maximum price per unit of gas the sender is willing to pay.
+func (m *message) feeCap() *big.Int {
+	if m.GasFeeCap != nil {
+		return m.GasFeeCap
+	}
+	if m.GasPrice != nil {
+		return m.GasPrice
+	}
+	return new(big.Int)
+}
+
+// intrinsicGas returns the gas consumed by the message before any code is executed.
+func (m *message) intrinsicGas() uint64 {
+	gas := params.TxGas
+	if m.To == nil {
+		gas = params.TxGasContractCreation
+	}
+
+	for _, b := range m.Data {
+		if b != 0 {
+			gas += params.TxDataNonZeroGasEIP2028
+		} else {
+			gas += params.TxDataZeroGas
+		}
+	}
+
+	if m.To == nil {
+		gas += uint64((len(m.Data)+31)/32) * params.InitCodeWordGas
+	}
+
+	gas += uint64(len(m.AccessList)) * params.TxAccessListAddressGas
+	gas += uint64(m.AccessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+
+	return gas
+}
+
+// applyMessage executes the message on top of the state within the block, following the go-ethereum state
+// transition: the sender buys the gas, the message is executed, unused gas and refunds are returned to the sender
+// and the priority fee is paid to the coinbase. Errors are returned for messages that could never be included in
+// a block, while execution failures are reported through the result.
+//
+// The state is left modified on errors, callers are expected to revert it.
+func (s *Simulator) applyMessage(state *StateDB, header *types.Header, msg *message, noBaseFee bool) (*executionResult, error) {
+	rules := s.config.Rules(header.Number, true, header.Time)
+
+	if !msg.SkipAccountChecks {
+		if nonce := state.GetNonce(msg.From); nonce != msg.Nonce {
+			return nil, fmt.Errorf("%w: address %s, tx: %d state: %d", ErrNonceMismatch, msg.From.Hex(), msg.Nonce, nonce)
+		}
+
+		if !msg.AllowContractSender && state.GetCodeSize(msg.From) > 0 {
+			return nil, fmt.Errorf("%w: address %s", ErrSenderNoEOA, msg.From.Hex())
+		}
+	}
+
+	feeCap := msg.feeCap()
+	skipFees := noBaseFee && feeCap.Sign() == 0 && (msg.GasTipCap == nil || msg.GasTipCap.Sign() == 0)
+	if !skipFees && feeCap.Cmp(header.BaseFee) < 0 {
+		return nil, fmt.Errorf("%w: address %s, maxFeePerGas: %s, baseFee: %s", ErrFeeCapTooLow, msg.From.Hex(), feeCap, header.BaseFee)
+	}
+
+	value := msg.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	gasPrice := msg.gasPrice(header.BaseFee)
+	if skipFees {
+		gasPrice = new(big.Int)
+	}
+
+	// The balance is checked against the fee cap, while only the effective gas price is charged.
+	required := new(big.Int).Mul(new(big.Int).SetUint64(msg.GasLimit), feeCap)
+	required.Add(required, value)
+	if state.GetBalance(msg.From).ToBig().Cmp(required) < 0 {
+		return nil, fmt.Errorf("%w: address %s have %s want %s", ErrInsufficientFunds, msg.From.Hex(), state.GetBalance(msg.From), required)
+	}
+
+	cost, _ := uint256.FromBig(new(big.Int).Mul(new(big.Int).SetUint64(msg.GasLimit), gasPrice))
+	state.SubBalance(msg.From, cost)
+
+	intrinsic := msg.intrinsicGas()
+	if msg.GasLimit < intrinsic {
+		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, msg.GasLimit, intrinsic)
+	}
+
+	if msg.To == nil && len(msg.Data) > params.MaxInitCodeSize {
+		return nil, fmt.Errorf("%w: code size %d limit %d", ErrMaxInitCodeSizeExceeded, len(msg.Data), params.MaxInitCodeSize)
+	}
+
+	transfer, _ := uint256.FromBig(value)
+	if transfer == nil {
+		return nil, fmt.Errorf("value %s overflows 256 bits", value)
+	}
+
+	state.Prepare(rules, msg.From, header.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
+	if transfer.Sign() > 0 && !canTransfer(state, msg.From, transfer) {
+		return nil, fmt.Errorf("%w: address %s", ErrInsufficientFunds, msg.From.Hex())
+	}
+
+	evm := vm.NewEVM(s.blockContext(header), vm.TxContext{Origin: msg.From, GasPrice: gasPrice}, state, s.config, vm.Config{NoBaseFee: noBaseFee})
+
+	result := &executionResult{GasPrice: gasPrice}
+	gas := msg.GasLimit - intrinsic
+	if msg.To == nil {
+		result.ReturnData, result.ContractAddress, gas, result.Err = evm.Create(vm.AccountRef(msg.From), msg.Data, gas, transfer)
+	} else {
+		state.SetNonce(msg.From, state.GetNonce(msg.From)+1)
+		result.ReturnData, gas, result.Err = evm.Call(vm.AccountRef(msg.From), *msg.To, msg.Data, gas, transfer)
+	}
+
+	// Refunds are capped to a fifth of the used gas as of EIP-3529.
+	refund := state.GetRefund()
+	if limit := (msg.GasLimit - gas) / params.RefundQuotientEIP3529; refund > limit {
+		refund = limit
+	}
+	gas += refund
+	result.UsedGas = msg.GasLimit - gas
+
+	remaining, _ := uint256.FromBig(new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice))
+	state.AddBalance(msg.From, remaining)
+
+	if tip := new(big.Int).Sub(gasPrice, header.BaseFee); !skipFees && tip.Sign() > 0 {
+		fee, _ := uint256.FromBig(new(big.Int).Mul(new(big.Int).SetUint64(result.UsedGas), tip))
+		state.AddBalance(header.Coinbase, fee)
+	}
+
+	return result, nil
+}
+
+// blockContext returns the EVM block context of the header.
+func (s *Simulator) blockContext(header *types.Header) vm.BlockContext {
+	return vm.BlockContext{
+		CanTransfer: canTransfer,
+		Transfer:    transfer,
+		GetHash:     s.getHash,
+		Coinbase:    header.Coinbase,
+		GasLimit:    header.GasLimit,
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        header.Time,
+		Difficulty:  new(big.Int),
+		BaseFee:     new(big.Int).Set(header.BaseFee),
+		BlobBaseFee: big.NewInt(1),
+		Random:      &common.Hash{},
+	}
+}
+
+// canTransfer checks if the account holds enough ether to transfer the amount.
+func canTransfer(db vm.StateDB, addr common.Address, amount *uint256.Int) bool {
+	return db.GetBalance(addr).Cmp(amount) >= 0
+}
+
+// transfer moves the amount of ether between the accounts.
+func transfer(db vm.StateDB, sender, recipient common.Address, amount *uint256.Int) {
+	db.SubBalance(sender, amount)
+	db.AddBalance(recipient, amount)
+}
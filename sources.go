@@ -12,9 +12,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	sources_pb "github.com/unpackdev/protos/dist/go/sources"
 	"github.com/unpackdev/solgo/metadata"
+	"github.com/unpackdev/solgo/syntaxerrors"
 	"github.com/unpackdev/solgo/utils"
+	"go.uber.org/zap"
 )
 
 var (
@@ -44,10 +47,13 @@ func GetLocalSourcesPath() string {
 }
 
 // SourceUnit represents a unit of source code in Solidity. It includes the name, path, and content of the source code.
+// Keccak256 and Urls are kept for sources created from the standard JSON input so that they can be exported back.
 type SourceUnit struct {
-	Name    string `yaml:"name" json:"name"`
-	Path    string `yaml:"path" json:"path"`
-	Content string `yaml:"content" json:"content"`
+	Name      string   `yaml:"name" json:"name"`
+	Path      string   `yaml:"path" json:"path"`
+	Content   string   `yaml:"content" json:"content"`
+	Keccak256 string   `yaml:"keccak256,omitempty" json:"keccak256,omitempty"`
+	Urls      []string `yaml:"urls,omitempty" json:"urls,omitempty"`
 }
 
 // String returns a string representation of the SourceUnit.
@@ -75,7 +81,18 @@ func (s *SourceUnit) GetContent() string {
 	return s.Content
 }
 
+// GetKeccak256 returns the keccak256 hash of the SourceUnit content, if it is known.
+func (s *SourceUnit) GetKeccak256() string {
+	return s.Keccak256
+}
+
+// GetUrls returns the urls the content of the SourceUnit can be fetched from, if they are known.
+func (s *SourceUnit) GetUrls() []string {
+	return s.Urls
+}
+
 // ToProto converts a SourceUnit to a protocol buffer SourceUnit.
+// The protobuf definition has no keccak256 hash or urls, so they are not part of it.
 func (s *SourceUnit) ToProto() *sources_pb.SourceUnit {
 	return &sources_pb.SourceUnit{
 		Name:    s.Name,
@@ -87,12 +104,16 @@ func (s *SourceUnit) ToProto() *sources_pb.SourceUnit {
 // Sources represent a collection of SourceUnit.
 // It includes a slice of SourceUnit and the name of the entry source unit.
 type Sources struct {
-	prepared             bool          `yaml:"-" json:"-"`
-	SourceUnits          []*SourceUnit `yaml:"source_units" json:"source_units"`
-	EntrySourceUnitName  string        `yaml:"entry_source_unit" json:"base_source_unit"`
-	LocalSources         bool          `yaml:"local_sources" json:"local_sources"`
-	MaskLocalSourcesPath bool          `yaml:"mask_local_sources_path" json:"mask_local_sources_path"`
-	LocalSourcesPath     string        `yaml:"local_sources_path" json:"local_sources_path"`
+	prepared             bool                  `yaml:"-" json:"-"`
+	SourceUnits          []*SourceUnit         `yaml:"source_units" json:"source_units"`
+	EntrySourceUnitName  string                `yaml:"entry_source_unit" json:"base_source_unit"`
+	LocalSources         bool                  `yaml:"local_sources" json:"local_sources"`
+	MaskLocalSourcesPath bool                  `yaml:"mask_local_sources_path" json:"mask_local_sources_path"`
+	LocalSourcesPath     string                `yaml:"local_sources_path" json:"local_sources_path"`
+	BasePath             string                `yaml:"base_path" json:"base_path"`
+	IncludePaths         []string              `yaml:"include_paths" json:"include_paths"`
+	Remappings           []*Remapping          `yaml:"remappings" json:"remappings"`
+	CompilerSettings     *StandardJSONSettings `yaml:"compiler_settings,omitempty" json:"compiler_settings,omitempty"`
 }
 
 // ArePrepared returns true if the Sources has been prepared.
@@ -140,11 +161,20 @@ func NewSourcesFromPath(entrySourceUnitName, path string) (*Sources, error) {
 		sourcesDir = GetLocalSourcesPath()
 	}
 
+	projectRoot := FindProjectRoot(path)
+	remappings, err := DiscoverRemappings(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failure while discovering remappings: %w", err)
+	}
+
 	sources := &Sources{
 		MaskLocalSourcesPath: true,
 		LocalSourcesPath:     sourcesDir,
 		LocalSources:         false,
 		EntrySourceUnitName:  entrySourceUnitName,
+		BasePath:             projectRoot,
+		IncludePaths:         defaultIncludePaths(projectRoot),
+		Remappings:           remappings,
 	}
 
 	files, err := os.ReadDir(path)
@@ -174,6 +204,10 @@ func NewSourcesFromPath(entrySourceUnitName, path string) (*Sources, error) {
 		}
 	}
 
+	if err := sources.ResolveImports(); err != nil {
+		return nil, err
+	}
+
 	if err := sources.SortContracts(); err != nil {
 		return nil, fmt.Errorf("failure while doing topological contract sorting: %s", err.Error())
 	}
@@ -201,11 +235,20 @@ func NewUnsortedSourcesFromPath(entrySourceUnitName, path string) (*Sources, err
 		sourcesDir = GetLocalSourcesPath()
 	}
 
+	projectRoot := FindProjectRoot(path)
+	remappings, err := DiscoverRemappings(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failure while discovering remappings: %w", err)
+	}
+
 	sources := &Sources{
 		MaskLocalSourcesPath: true,
 		LocalSourcesPath:     sourcesDir,
 		LocalSources:         false,
 		EntrySourceUnitName:  entrySourceUnitName,
+		BasePath:             projectRoot,
+		IncludePaths:         defaultIncludePaths(projectRoot),
+		Remappings:           remappings,
 	}
 
 	files, err := os.ReadDir(path)
@@ -235,6 +278,10 @@ func NewUnsortedSourcesFromPath(entrySourceUnitName, path string) (*Sources, err
 		}
 	}
 
+	if err := sources.ResolveImports(); err != nil {
+		return nil, err
+	}
+
 	if err := sources.SortContracts(); err != nil {
 		return nil, fmt.Errorf("failure while doing topological contract sorting: %s", err.Error())
 	}
@@ -244,7 +291,42 @@ func NewUnsortedSourcesFromPath(entrySourceUnitName, path string) (*Sources, err
 
 // NewSourcesFromMetadata creates a Sources from a metadata package ContractMetadata.
 // This is a helper function that ensures easier integration when working with the metadata package.
+// Remappings of the metadata that cannot be parsed are skipped, use NewSourcesFromMetadataWithErrors to reject them.
 func NewSourcesFromMetadata(md *metadata.ContractMetadata) *Sources {
+	var remappings []*Remapping
+	for _, remapping := range md.Settings.Remappings {
+		if strings.TrimSpace(remapping) == "" {
+			continue
+		}
+
+		parsed, err := ParseRemapping(remapping)
+		if err != nil {
+			zap.L().Warn(
+				"skipping metadata remapping that cannot be parsed",
+				zap.String("remapping", remapping),
+				zap.Error(err),
+			)
+			continue
+		}
+		remappings = append(remappings, parsed)
+	}
+
+	return newSourcesFromMetadata(md, remappings)
+}
+
+// NewSourcesFromMetadataWithErrors creates a Sources from a metadata package ContractMetadata, same as
+// NewSourcesFromMetadata, except that it returns an error if the remappings of the metadata cannot be parsed.
+func NewSourcesFromMetadataWithErrors(md *metadata.ContractMetadata) (*Sources, error) {
+	remappings, err := ParseRemappings(md.Settings.Remappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSourcesFromMetadata(md, remappings), nil
+}
+
+// newSourcesFromMetadata creates a Sources from a metadata package ContractMetadata with already parsed remappings.
+func newSourcesFromMetadata(md *metadata.ContractMetadata, remappings []*Remapping) *Sources {
 	var sourcesDir string
 
 	if GetLocalSourcesPath() == "" {
@@ -255,10 +337,17 @@ func NewSourcesFromMetadata(md *metadata.ContractMetadata) *Sources {
 		sourcesDir = GetLocalSourcesPath()
 	}
 
+	// Remappings are already applied to the source unit names found in the metadata, however,
+	// we keep them around so that they can be passed back to the compiler.
 	sources := &Sources{
 		MaskLocalSourcesPath: true,
 		LocalSourcesPath:     sourcesDir,
 		LocalSources:         false,
+		Remappings:           remappings,
+	}
+
+	if settings, err := NewStandardJSONSettingsFromMetadata(md); err == nil {
+		sources.CompilerSettings = settings
 	}
 
 	// First target is the target of the entry source unit...
@@ -280,6 +369,31 @@ func NewSourcesFromMetadata(md *metadata.ContractMetadata) *Sources {
 	return sources
 }
 
+// NewSourcesFromRepository creates a Sources from a contract stored within a Sourcify compatible local repository.
+// This allows loading verified contracts offline, as an alternative to Etherscan and similar providers.
+func NewSourcesFromRepository(repository *metadata.Repository, chainID utils.NetworkID, address common.Address) (*Sources, error) {
+	entry, err := repository.Get(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := NewSourcesFromMetadataWithErrors(entry.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	// Metadata sources are not ordered, so units are ordered by their paths before sorting them by dependencies.
+	sort.SliceStable(sources.SourceUnits, func(i, j int) bool {
+		return sources.SourceUnits[i].Path < sources.SourceUnits[j].Path
+	})
+
+	if err := sources.SortContracts(); err != nil {
+		return nil, fmt.Errorf("failure while doing topological contract sorting: %s", err.Error())
+	}
+
+	return sources, nil
+}
+
 func NewSourcesFromProto(entryContractName string, sc *sources_pb.Sources) (*Sources, error) {
 	var sourcesDir string
 
@@ -565,7 +679,11 @@ func (s *Sources) Prepare() error {
 			return err
 		}
 
-		s.SourceUnits = append(s.SourceUnits, importUnits...)
+		for _, importUnit := range importUnits {
+			if !s.SourceUnitExists(importUnit.Name) {
+				s.SourceUnits = append(s.SourceUnits, importUnit)
+			}
+		}
 	}
 
 	if err := s.SortContracts(); err != nil {
@@ -594,14 +712,57 @@ func (s *Sources) GetCombinedSource() string {
 	return builder.String()
 }
 
+// getSourceFiles returns the location of every SourceUnit within the combined source, so syntax errors
+// can be reported relative to the file they occurred in. Files are named the same way as within the AST.
+func (s *Sources) getSourceFiles() []syntaxerrors.SourceFile {
+	toReturn := make([]syntaxerrors.SourceFile, 0, len(s.SourceUnits))
+	offset := 0
+	for i, sourceUnit := range s.SourceUnits {
+		if i > 0 {
+			offset += len("\n\n")
+		}
+
+		toReturn = append(toReturn, syntaxerrors.SourceFile{
+			Path:   s.GetSourceUnitPath(sourceUnit),
+			Offset: offset,
+			Length: len(sourceUnit.Content),
+		})
+		offset += len(sourceUnit.Content)
+	}
+	return toReturn
+}
+
 // GetSourceUnitByName returns the SourceUnit with the given name from the Sources. If no such SourceUnit exists, it returns nil.
+// Source units named after their full path, such as `src/Token`, are found by their base name as well, as long as
+// the base name is unique or only one of the source units declares a contract, library or interface with the name.
 func (s *Sources) GetSourceUnitByName(name string) *SourceUnit {
+	candidates := make([]*SourceUnit, 0)
 	for _, sourceUnit := range s.SourceUnits {
 		if sourceUnit.Name == name {
 			return sourceUnit
 		}
+
+		if filepath.Base(sourceUnit.Name) == name {
+			candidates = append(candidates, sourceUnit)
+		}
 	}
-	return nil
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	declaration := regexp.MustCompile(fmt.Sprintf(`\b(contract|library|interface)\s+%s\b`, regexp.QuoteMeta(name)))
+	var toReturn *SourceUnit
+	for _, sourceUnit := range candidates {
+		if declaration.MatchString(sourceUnit.Content) {
+			if toReturn != nil {
+				return nil
+			}
+			toReturn = sourceUnit
+		}
+	}
+
+	return toReturn
 }
 
 // GetSourceUnitByNameAndSize returns the SourceUnit with the given name and size from the Sources. If no such SourceUnit exists, it returns nil.
@@ -648,8 +809,9 @@ func (s *Sources) HasUnits() bool {
 // If the walk function encounters an error other than ErrPathFound, it returns the error.
 // If the source is still nil after the walk, it returns nil.
 func (s *Sources) GetLocalSource(partialPath string, relativeTo string) (*SourceUnit, error) {
-	// Replace @openzeppelin with the actual path to the openzeppelin-contracts repository
-	partialPath = replaceOpenZeppelin(partialPath)
+	// Apply remappings first and replace @openzeppelin with the actual path to the
+	// openzeppelin-contracts repository if none of them matched.
+	partialPath = replaceOpenZeppelin(ApplyRemappings(s.Remappings, partialPath, s.relativeToBasePath(relativeTo)))
 	relativeTo = replaceOpenZeppelin(relativeTo)
 	var source *SourceUnit
 	errWalk := filepath.Walk(s.LocalSourcesPath, func(partialWalkPath string, info os.FileInfo, err error) error {
@@ -754,6 +916,11 @@ func (s *Sources) WriteToDir(path string) error {
 
 		filePath := filepath.Join(path, sourceUnit.Name+".sol")
 
+		// Source units named after their full source unit name are written into their directories.
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			return fmt.Errorf("failed to create directory for source unit %s: %v", sourceUnit.Name, err)
+		}
+
 		if err := utils.WriteToFile(filePath, []byte(content)); err != nil {
 			return fmt.Errorf("failed to write source unit %s to file: %v", sourceUnit.Name, err)
 		}
@@ -825,63 +992,188 @@ func (s *Sources) GetSolidityVersion() (string, error) {
 }
 
 // handleImports extracts import statements from the source unit and adds them to the sources.
+// Imported source units are added as soon as they are resolved, so that imports resolved later on
+// are matched against them, and are returned as well.
 func (s *Sources) handleImports(sourceUnit *SourceUnit) ([]*SourceUnit, error) {
-	imports := extractImports(sourceUnit.Content)
 	var sourceUnits []*SourceUnit
 
-	if !s.LocalSources {
+	if !s.LocalSources && !s.resolvesFromDisk() {
 		return sourceUnits, nil
 	}
 
-	for _, imp := range imports {
-		baseName := filepath.Base(imp)
+	for _, imp := range extractImports(sourceUnit.Content) {
+		source, err := s.ResolveImport(imp, sourceUnit.Path)
+		if err != nil {
+			return nil, err
+		}
 
-		if !s.SourceUnitExists(baseName) {
-			source, err := s.GetLocalSource(imp, sourceUnit.Path)
-			if err != nil {
-				return nil, err
+		// Source may not be found and no errors and that's ok, however, we don't want to append
+		// nil source to the sources.
+		if source == nil || s.SourceUnitExists(source.Name) {
+			continue
+		}
+
+		s.SourceUnits = append(s.SourceUnits, source)
+		sourceUnits = append(sourceUnits, source)
+
+		subUnits, err := s.handleImports(source)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceUnits = append(sourceUnits, subUnits...)
+	}
+
+	return sourceUnits, nil
+}
+
+// ResolveImports resolves imports of all source units from the disk, using base path, include paths
+// and remappings, and appends discovered source units to the sources.
+func (s *Sources) ResolveImports() error {
+	for _, sourceUnit := range s.SourceUnits {
+		importUnits, err := s.handleImports(sourceUnit)
+		if err != nil {
+			return err
+		}
+
+		for _, importUnit := range importUnits {
+			if !s.SourceUnitExists(importUnit.Name) {
+				s.SourceUnits = append(s.SourceUnits, importUnit)
 			}
+		}
+	}
+
+	return nil
+}
+
+// ResolveImportPath resolves the import path, as written within the importing source unit, into the
+// source unit name the compiler would use. Relative imports are resolved against the importer path
+// and remappings are applied afterwards.
+func (s *Sources) ResolveImportPath(importPath string, importer string) string {
+	if strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+		importPath = filepath.ToSlash(filepath.Join(filepath.Dir(importer), importPath))
+	}
+
+	return ApplyRemappings(s.Remappings, importPath, s.relativeToBasePath(importer))
+}
+
+// ResolveImport resolves the import path into a SourceUnit, reading its content from the disk.
+// Paths are looked up, in order, as absolute paths, relative to the base path, relative to each
+// of the include paths and finally within the local sources path.
+// It returns nil if the source unit is already part of the sources or if it cannot be found. Source units are
+// matched by the source unit name the import resolves to, so that files sharing a base name in different
+// directories are both resolved. The latter of them is named after its source unit name to keep names unique.
+func (s *Sources) ResolveImport(importPath string, importer string) (*SourceUnit, error) {
+	resolved := s.ResolveImportPath(importPath, importer)
+	if s.sourceUnitNameExists(s.relativeToBasePath(resolved)) {
+		return nil, nil
+	}
+
+	if s.resolvesFromDisk() {
 
-			// Source may not be found and no errors and that's ok, however, we don't want to append
-			// nil source to the sources.
-			if source == nil {
+		candidates := []string{resolved}
+		if !filepath.IsAbs(resolved) {
+			candidates = nil
+			if s.BasePath != "" {
+				candidates = append(candidates, filepath.Join(s.BasePath, resolved))
+			}
+			for _, includePath := range s.IncludePaths {
+				candidates = append(candidates, filepath.Join(includePath, resolved))
+			}
+		}
+
+		for _, candidate := range candidates {
+			info, err := os.Stat(candidate)
+			if err != nil || info.IsDir() {
 				continue
 			}
 
-			if !s.SourceUnitExistsIn(source.Name, sourceUnits) {
-				sourceUnits = append(sourceUnits, source)
+			if s.sourceUnitNameExists(s.relativeToBasePath(candidate)) {
+				return nil, nil
 			}
 
-			subUnits, err := s.handleImports(source)
+			content, err := os.ReadFile(filepath.Clean(candidate))
 			if err != nil {
 				return nil, err
 			}
 
-			for _, subUnit := range subUnits {
-				if !s.SourceUnitExistsIn(subUnit.Name, sourceUnits) {
-					sourceUnits = append(sourceUnits, subUnit)
-				}
+			name := strings.TrimSuffix(filepath.Base(candidate), ".sol")
+			if s.SourceUnitExists(name) {
+				name = strings.TrimSuffix(s.relativeToBasePath(candidate), ".sol")
 			}
+
+			return &SourceUnit{
+				Name:    name,
+				Path:    candidate,
+				Content: string(content),
+			}, nil
 		}
 	}
 
-	return sourceUnits, nil
+	if s.LocalSources {
+		return s.GetLocalSource(importPath, importer)
+	}
+
+	return nil, nil
+}
+
+// sourceUnitNameExists returns true if a SourceUnit known to the compiler under the source unit name exists.
+func (s *Sources) sourceUnitNameExists(name string) bool {
+	for _, sourceUnit := range s.SourceUnits {
+		if filepath.ToSlash(s.GetSourceUnitName(sourceUnit)) == filepath.ToSlash(name) {
+			return true
+		}
+	}
+	return false
 }
 
-// extractImports extracts import statements from the source unit.
+// resolvesFromDisk returns true if the sources have enough information to resolve imports from the disk.
+func (s *Sources) resolvesFromDisk() bool {
+	return s.BasePath != "" || len(s.IncludePaths) > 0
+}
+
+// relativeToBasePath returns the path relative to the base path, which is what remapping contexts
+// are matched against. If the path is not within the base path, it is returned unchanged.
+func (s *Sources) relativeToBasePath(path string) string {
+	if s.BasePath == "" || !filepath.IsAbs(path) {
+		return path
+	}
+
+	rel, err := filepath.Rel(s.BasePath, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// defaultIncludePaths returns the directories where dependencies are commonly installed for
+// Foundry (lib) and Hardhat (node_modules) projects located at the root path.
+func defaultIncludePaths(root string) []string {
+	var includePaths []string
+	for _, dir := range []string{"lib", "node_modules"} {
+		includePath := filepath.Join(root, dir)
+		if info, err := os.Stat(includePath); err == nil && info.IsDir() {
+			includePaths = append(includePaths, includePath)
+		}
+	}
+	return includePaths
+}
+
+// extractImports extracts import paths from the source unit, covering every import directive form.
 func extractImports(content string) []string {
-	re := regexp.MustCompile(`import "(.*?)";`)
-	matches := re.FindAllStringSubmatch(content, -1)
+	directives := ExtractImportDirectives(content)
 
-	imports := make([]string, 0)
-	for _, match := range matches {
-		imports = append(imports, match[1])
+	imports := make([]string, 0, len(directives))
+	for _, directive := range directives {
+		imports = append(imports, directive.Path)
 	}
 
 	return imports
 }
 
 // replaceOpenZeppelin replaces the @openzeppelin path with the actual path to the openzeppelin-contracts repository.
+// It is used as a fallback when none of the remappings apply to the path.
 func replaceOpenZeppelin(path string) string {
 	return strings.Replace(path, "@openzeppelin", filepath.Join("./sources/", "openzeppelin"), 1)
 }
@@ -901,8 +1193,7 @@ func (s *Sources) SortContracts() error {
 		imports := extractImports(sourceUnit.Content)
 		var dependencies []string
 		for _, imp := range imports {
-			baseName := filepath.Base(imp)
-			dependencies = append(dependencies, strings.TrimSuffix(baseName, ".sol"))
+			dependencies = append(dependencies, s.dependencyName(imp, sourceUnit))
 		}
 		nodes = append(nodes, Node{
 			Name:         sourceUnit.Name,
@@ -949,6 +1240,20 @@ func (s *Sources) SortContracts() error {
 	return nil
 }
 
+// dependencyName returns the name of the source unit the import refers to. Source units named after their
+// full source unit name, as these are when created from the standard JSON input, are matched by the resolved
+// import path, while others are matched by the base name of the import.
+func (s *Sources) dependencyName(importPath string, importer *SourceUnit) string {
+	if importer.Path != "" {
+		resolved := strings.TrimSuffix(s.relativeToBasePath(s.ResolveImportPath(importPath, importer.Path)), ".sol")
+		if s.SourceUnitExists(resolved) {
+			return resolved
+		}
+	}
+
+	return strings.TrimSuffix(filepath.Base(importPath), ".sol")
+}
+
 // topologicalSort performs a topological sort on the given nodes based on their dependencies.
 // It returns a slice of nodes sorted in a way that for every directed edge U -> V,
 // node U comes before V in the ordering. If a cycle is detected, the function will
@@ -6,7 +6,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/metadata"
 	"github.com/unpackdev/solgo/utils"
 )
 
@@ -146,3 +149,30 @@ func buildFullPath(relativePath string) string {
 	absPath, _ := filepath.Abs(relativePath)
 	return absPath
 }
+
+func TestNewSourcesFromRepository(t *testing.T) {
+	repository, err := metadata.NewRepository(buildFullPath("./data/tests/sourcify/"))
+	require.NoError(t, err)
+
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	sources, err := NewSourcesFromRepository(repository, utils.EthereumNetworkID, address)
+	require.NoError(t, err)
+	assert.Equal(t, "Storage", sources.EntrySourceUnitName)
+	require.Len(t, sources.GetUnits(), 2)
+	assert.Equal(t, "contracts/Ownable.sol", sources.GetUnits()[0].GetPath())
+	assert.Equal(t, "contracts/Storage.sol", sources.GetUnits()[1].GetPath())
+	assert.NotNil(t, sources.CompilerSettings)
+
+	_, err = NewSourcesFromRepository(repository, utils.BscNetworkID, address)
+	assert.ErrorIs(t, err, metadata.ErrContractNotFound)
+
+	md := &metadata.ContractMetadata{}
+	md.Settings.Remappings = []string{"@solmate/", "@openzeppelin/=lib/openzeppelin/"}
+	_, err = NewSourcesFromMetadataWithErrors(md)
+	assert.Error(t, err)
+
+	// Remappings that cannot be parsed are skipped when errors are not requested.
+	sources = NewSourcesFromMetadata(md)
+	require.Len(t, sources.Remappings, 1)
+	assert.Equal(t, "@openzeppelin/", sources.Remappings[0].Prefix)
+}
@@ -0,0 +1,353 @@
+package solgo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/unpackdev/solgo/metadata"
+)
+
+// DefaultOutputSelection is the output selection used when exporting sources into the standard JSON
+// input format without explicit output selection. It covers everything solgo needs from the compiler.
+var DefaultOutputSelection = map[string]map[string][]string{
+	"*": {
+		"*": {
+			"abi",
+			"metadata",
+			"evm.bytecode",
+			"evm.deployedBytecode",
+			"evm.methodIdentifiers",
+			"storageLayout",
+		},
+		"": {"ast"},
+	},
+}
+
+// StandardJSONSource represents a single source entry of the solc standard JSON input.
+type StandardJSONSource struct {
+	Content   string   `json:"content,omitempty"`
+	Keccak256 string   `json:"keccak256,omitempty"`
+	Urls      []string `json:"urls,omitempty"`
+}
+
+// StandardJSONOptimizer represents the optimizer settings of the solc standard JSON input.
+// Details are kept as raw JSON so that they are passed back to the compiler exactly as received.
+type StandardJSONOptimizer struct {
+	Enabled bool            `json:"enabled"`
+	Runs    int             `json:"runs"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// StandardJSONMetadata represents the metadata settings of the solc standard JSON input.
+type StandardJSONMetadata struct {
+	AppendCBOR        *bool  `json:"appendCBOR,omitempty"`
+	UseLiteralContent bool   `json:"useLiteralContent,omitempty"`
+	BytecodeHash      string `json:"bytecodeHash,omitempty"`
+}
+
+// StandardJSONSettings represents the settings of the solc standard JSON input.
+// Libraries are keyed by source unit name and then by library name, with the address as value.
+// Settings solgo does not model, such as debug, modelChecker or stopAfter, are kept as raw JSON in Extra
+// so that they are passed back to the compiler exactly as received.
+type StandardJSONSettings struct {
+	Remappings      []string                       `json:"remappings,omitempty"`
+	Optimizer       *StandardJSONOptimizer         `json:"optimizer,omitempty"`
+	EvmVersion      string                         `json:"evmVersion,omitempty"`
+	ViaIR           bool                           `json:"viaIR,omitempty"`
+	Metadata        *StandardJSONMetadata          `json:"metadata,omitempty"`
+	Libraries       map[string]map[string]string   `json:"libraries,omitempty"`
+	OutputSelection map[string]map[string][]string `json:"outputSelection,omitempty"`
+	Extra           map[string]json.RawMessage     `json:"-"`
+}
+
+// standardJSONSettings has the fields of StandardJSONSettings without its JSON methods.
+type standardJSONSettings StandardJSONSettings
+
+// UnmarshalJSON decodes the settings, keeping the settings solgo does not model in Extra.
+func (s *StandardJSONSettings) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var settings standardJSONSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	for _, name := range standardJSONSettingsFields() {
+		delete(fields, name)
+	}
+
+	if len(fields) > 0 {
+		settings.Extra = fields
+	}
+
+	*s = StandardJSONSettings(settings)
+	return nil
+}
+
+// MarshalJSON encodes the settings along with the settings kept in Extra.
+func (s StandardJSONSettings) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(standardJSONSettings(s))
+	if err != nil || len(s.Extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range s.Extra {
+		if _, exists := fields[name]; !exists {
+			fields[name] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// standardJSONSettingsFields returns the JSON names of the settings modelled by StandardJSONSettings.
+func standardJSONSettingsFields() []string {
+	toReturn := make([]string, 0)
+	settings := reflect.TypeOf(StandardJSONSettings{})
+	for i := 0; i < settings.NumField(); i++ {
+		name, _, _ := strings.Cut(settings.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			toReturn = append(toReturn, name)
+		}
+	}
+	return toReturn
+}
+
+// StandardJSONInput represents the solc standard JSON input.
+// See https://docs.soliditylang.org/en/latest/using-the-compiler.html#input-description
+type StandardJSONInput struct {
+	Language string                        `json:"language"`
+	Sources  map[string]StandardJSONSource `json:"sources"`
+	Settings *StandardJSONSettings         `json:"settings,omitempty"`
+}
+
+// ToJSON converts the StandardJSONInput into its JSON representation that can be passed to solc.
+func (i *StandardJSONInput) ToJSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// GetSourceNames returns the sorted source unit names of the StandardJSONInput.
+func (i *StandardJSONInput) GetSourceNames() []string {
+	names := make([]string, 0, len(i.Sources))
+	for name := range i.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewStandardJSONSettingsFromMetadata creates StandardJSONSettings from a metadata package ContractMetadata.
+// This is a helper function that ensures contracts verified with metadata can be recompiled with the same settings.
+func NewStandardJSONSettingsFromMetadata(md *metadata.ContractMetadata) (*StandardJSONSettings, error) {
+	if md == nil {
+		return nil, errors.New("metadata must be set")
+	}
+
+	settings := &StandardJSONSettings{
+		Remappings: md.Settings.Remappings,
+		EvmVersion: md.Settings.EvmVersion,
+		Optimizer: &StandardJSONOptimizer{
+			Enabled: md.Settings.Optimizer.Enabled,
+			Runs:    md.Settings.Optimizer.Runs,
+		},
+		Metadata: &StandardJSONMetadata{
+			UseLiteralContent: md.Settings.Metadata.UseLiteralContent,
+			BytecodeHash:      md.Settings.Metadata.BytecodeHash,
+		},
+	}
+
+	// Metadata lists libraries as a flat `path:Library => address` map while standard JSON
+	// expects them keyed by the source unit name.
+	if libraries, ok := md.Settings.Libraries.(map[string]interface{}); ok && len(libraries) > 0 {
+		settings.Libraries = make(map[string]map[string]string)
+		for key, address := range libraries {
+			addr, ok := address.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid library address for %s: %v", key, address)
+			}
+
+			sourceName, libraryName := "", key
+			if idx := strings.LastIndex(key, ":"); idx >= 0 {
+				sourceName, libraryName = key[:idx], key[idx+1:]
+			}
+
+			if settings.Libraries[sourceName] == nil {
+				settings.Libraries[sourceName] = make(map[string]string)
+			}
+			settings.Libraries[sourceName][libraryName] = addr
+		}
+	}
+
+	return settings, nil
+}
+
+// NewSourcesFromStandardJSON creates a Sources from the solc standard JSON input.
+// Source units are named after their full source unit name, without the extension, so that files sharing
+// a base name in different directories stay apart, and their paths are kept so that the sources can be
+// exported back into the standard JSON input without losing the directory structure. Settings are available through
+// Sources.CompilerSettings and remappings are additionally parsed into Sources.Remappings.
+func NewSourcesFromStandardJSON(entrySourceUnitName string, input []byte) (*Sources, error) {
+	var standardInput StandardJSONInput
+	if err := json.Unmarshal(input, &standardInput); err != nil {
+		return nil, fmt.Errorf("error unmarshalling standard json input: %w", err)
+	}
+
+	if standardInput.Language != "" && standardInput.Language != "Solidity" {
+		return nil, fmt.Errorf("unsupported standard json input language: %s", standardInput.Language)
+	}
+
+	if len(standardInput.Sources) == 0 {
+		return nil, errors.New("standard json input does not contain any sources")
+	}
+
+	var sourcesDir string
+
+	if GetLocalSourcesPath() == "" {
+		_, filename, _, _ := runtime.Caller(0)
+		dir := filepath.Dir(filename)
+		sourcesDir = filepath.Clean(filepath.Join(dir, "sources"))
+	} else {
+		sourcesDir = GetLocalSourcesPath()
+	}
+
+	sources := &Sources{
+		MaskLocalSourcesPath: true,
+		LocalSourcesPath:     sourcesDir,
+		EntrySourceUnitName:  entrySourceUnitName,
+		LocalSources:         false,
+		CompilerSettings:     standardInput.Settings,
+	}
+
+	if standardInput.Settings != nil {
+		remappings, err := ParseRemappings(standardInput.Settings.Remappings)
+		if err != nil {
+			return nil, err
+		}
+		sources.Remappings = remappings
+	}
+
+	for _, name := range standardInput.GetSourceNames() {
+		source := standardInput.Sources[name]
+		if source.Content == "" {
+			return nil, fmt.Errorf("source %s does not contain content, fetching sources from urls is not supported", name)
+		}
+
+		sources.AppendSource(&SourceUnit{
+			Name:      strings.TrimSuffix(name, ".sol"),
+			Path:      name,
+			Content:   source.Content,
+			Keccak256: source.Keccak256,
+			Urls:      source.Urls,
+		})
+	}
+
+	if err := sources.SortContracts(); err != nil {
+		return nil, fmt.Errorf("failure while doing topological contract sorting: %s", err.Error())
+	}
+
+	return sources, nil
+}
+
+// ToStandardJSON converts the Sources into the solc standard JSON input.
+// If settings are nil, settings the sources were created with are used. Remappings of the sources are
+// used when settings do not define any and output selection defaults to DefaultOutputSelection.
+func (s *Sources) ToStandardJSON(settings *StandardJSONSettings) (*StandardJSONInput, error) {
+	if !s.HasUnits() {
+		return nil, errors.New("no source units found")
+	}
+
+	toReturn := &StandardJSONInput{
+		Language: "Solidity",
+		Sources:  make(map[string]StandardJSONSource),
+		Settings: &StandardJSONSettings{},
+	}
+
+	if settings == nil {
+		settings = s.CompilerSettings
+	}
+
+	if settings != nil {
+		*toReturn.Settings = *settings
+	}
+
+	if len(toReturn.Settings.Remappings) == 0 {
+		for _, remapping := range s.Remappings {
+			toReturn.Settings.Remappings = append(toReturn.Settings.Remappings, remapping.String())
+		}
+	}
+
+	if toReturn.Settings.OutputSelection == nil {
+		toReturn.Settings.OutputSelection = copyOutputSelection(DefaultOutputSelection)
+	}
+
+	for _, sourceUnit := range s.SourceUnits {
+		name := s.GetSourceUnitName(sourceUnit)
+		if _, exists := toReturn.Sources[name]; exists {
+			return nil, fmt.Errorf("duplicate source unit name %s", name)
+		}
+
+		toReturn.Sources[name] = StandardJSONSource{
+			Content:   sourceUnit.Content,
+			Keccak256: sourceUnit.Keccak256,
+			Urls:      sourceUnit.Urls,
+		}
+	}
+
+	return toReturn, nil
+}
+
+// copyOutputSelection returns a deep copy of the output selection, so that changes to the exported
+// settings do not leak into the selection they were created from.
+func copyOutputSelection(selection map[string]map[string][]string) map[string]map[string][]string {
+	toReturn := make(map[string]map[string][]string, len(selection))
+	for file, contracts := range selection {
+		toReturn[file] = make(map[string][]string, len(contracts))
+		for contract, outputs := range contracts {
+			toReturn[file][contract] = append([]string(nil), outputs...)
+		}
+	}
+	return toReturn
+}
+
+// GetSourceUnitName returns the name the compiler knows the source unit under.
+// Paths within the base path are made relative to it, while source units without a path are
+// named after the source unit itself.
+func (s *Sources) GetSourceUnitName(sourceUnit *SourceUnit) string {
+	if sourceUnit.Path == "" {
+		return sourceUnit.Name + ".sol"
+	}
+
+	return s.relativeToBasePath(sourceUnit.Path)
+}
+
+// GetSourceUnitPath returns the path the source unit is reported under within the AST and diagnostics. It is
+// the source unit name, so that reports do not embed absolute paths of the machine they were built on.
+// Absolute paths outside of the base and local sources paths are reduced to the file name.
+func (s *Sources) GetSourceUnitPath(sourceUnit *SourceUnit) string {
+	path := s.GetSourceUnitName(sourceUnit)
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	if localPath, err := filepath.Abs(s.LocalSourcesPath); err == nil && s.LocalSourcesPath != "" {
+		if rel, err := filepath.Rel(localPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.Base(path)
+}
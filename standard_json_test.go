@@ -0,0 +1,120 @@
+package solgo
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/metadata"
+)
+
+func TestStandardJSON(t *testing.T) {
+	input := `{
+		"language": "Solidity",
+		"sources": {
+			"src/Token.sol": {
+				"content": "pragma solidity ^0.8.0;\nimport {ERC20} from \"@solmate/tokens/ERC20.sol\";\nimport \"./libraries/Math.sol\";\ncontract Token is ERC20 {}"
+			},
+			"src/libraries/Math.sol": {
+				"content": "pragma solidity ^0.8.0;\nlibrary Math {}",
+				"keccak256": "0x8a24b21d4a1a4ea4b4b8ea3d2d9efbb9c4b1be8c5c4f7cc5a0b8c0f7a8b6f0ed",
+				"urls": ["dweb:/ipfs/QmVzQ6kM4qT3PzEpHwETkFzNkE4bdpgWCRJHaaQwPuYc9N"]
+			},
+			"lib/solmate/src/tokens/ERC20.sol": {
+				"content": "pragma solidity ^0.8.0;\nabstract contract ERC20 {}"
+			}
+		},
+		"settings": {
+			"remappings": ["@solmate/=lib/solmate/src/"],
+			"optimizer": {"enabled": true, "runs": 1000, "details": {"yul": true}},
+			"evmVersion": "paris",
+			"libraries": {
+				"src/libraries/Math.sol": {"Math": "0x1234567890123456789012345678901234567890"}
+			},
+			"outputSelection": {"*": {"*": ["abi", "evm.deployedBytecode"]}},
+			"debug": {"revertStrings": "strip"},
+			"modelChecker": {"engine": "chc", "targets": ["assert"]}
+		}
+	}`
+
+	sources, err := NewSourcesFromStandardJSON("Token", []byte(input))
+	require.NoError(t, err)
+	require.NotNil(t, sources)
+
+	assert.Len(t, sources.SourceUnits, 3)
+	assert.Equal(t, "src/Token", sources.SourceUnits[len(sources.SourceUnits)-1].GetName())
+	assert.Equal(t, "lib/solmate/src/tokens/ERC20.sol", sources.GetSourceUnitByName("lib/solmate/src/tokens/ERC20").GetPath())
+	assert.Equal(t, "src/Token.sol", sources.GetSourceUnitByName("Token").GetPath())
+	assert.Equal(t, []string{"dweb:/ipfs/QmVzQ6kM4qT3PzEpHwETkFzNkE4bdpgWCRJHaaQwPuYc9N"}, sources.GetSourceUnitByName("Math").GetUrls())
+	assert.Equal(t, []*Remapping{{Prefix: "@solmate/", Target: "lib/solmate/src/"}}, sources.Remappings)
+	require.NotNil(t, sources.CompilerSettings)
+	assert.Equal(t, "paris", sources.CompilerSettings.EvmVersion)
+	assert.JSONEq(t, `{"revertStrings": "strip"}`, string(sources.CompilerSettings.Extra["debug"]))
+	assert.JSONEq(t, `{"engine": "chc", "targets": ["assert"]}`, string(sources.CompilerSettings.Extra["modelChecker"]))
+	assert.NotContains(t, sources.CompilerSettings.Extra, "evmVersion")
+
+	exported, err := sources.ToStandardJSON(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lib/solmate/src/tokens/ERC20.sol", "src/Token.sol", "src/libraries/Math.sol"}, exported.GetSourceNames())
+
+	var expected StandardJSONInput
+	require.NoError(t, json.Unmarshal([]byte(input), &expected))
+
+	exportedJSON, err := exported.ToJSON()
+	require.NoError(t, err)
+	expectedJSON, err := expected.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(exportedJSON))
+
+	// Overriding settings must keep the remappings of the sources and use default output selection.
+	overridden, err := sources.ToStandardJSON(&StandardJSONSettings{EvmVersion: "shanghai"})
+	require.NoError(t, err)
+	assert.Equal(t, "shanghai", overridden.Settings.EvmVersion)
+	assert.Equal(t, []string{"@solmate/=lib/solmate/src/"}, overridden.Settings.Remappings)
+	assert.Equal(t, DefaultOutputSelection, overridden.Settings.OutputSelection)
+
+	// Exported output selection must not share the default one.
+	overridden.Settings.OutputSelection["*"]["*"] = append(overridden.Settings.OutputSelection["*"]["*"][:0], "userdoc")
+	assert.Equal(t, "abi", DefaultOutputSelection["*"]["*"][0])
+
+	_, err = NewSourcesFromStandardJSON("Token", []byte(`{"language": "Vyper", "sources": {"a.vy": {"content": "x"}}}`))
+	assert.Error(t, err)
+
+	// Source units sharing a base name in different directories must be kept apart.
+	sameName, err := NewSourcesFromStandardJSON("Token", []byte(`{
+		"language": "Solidity",
+		"sources": {
+			"src/Token.sol": {"content": "pragma solidity ^0.8.0;\nimport \"./interfaces/Token.sol\";\ncontract Token is IToken {}"},
+			"src/interfaces/Token.sol": {"content": "pragma solidity ^0.8.0;\ninterface IToken {}"}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, sameName.SourceUnits, 2)
+	assert.Equal(t, "src/interfaces/Token", sameName.SourceUnits[0].GetName())
+	assert.Equal(t, "src/Token", sameName.SourceUnits[1].GetName())
+	assert.Equal(t, "src/Token.sol", sameName.GetSourceUnitByName("Token").GetPath())
+
+	exported, err = sameName.ToStandardJSON(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/Token.sol", "src/interfaces/Token.sol"}, exported.GetSourceNames())
+}
+
+func TestNewStandardJSONSettingsFromMetadata(t *testing.T) {
+	md := &metadata.ContractMetadata{}
+	md.Settings.EvmVersion = "london"
+	md.Settings.Remappings = []string{"@openzeppelin/=lib/openzeppelin-contracts/"}
+	md.Settings.Optimizer.Enabled = true
+	md.Settings.Optimizer.Runs = 200
+	md.Settings.Libraries = map[string]interface{}{
+		"contracts/Math.sol:Math": "0x1234567890123456789012345678901234567890",
+	}
+
+	settings, err := NewStandardJSONSettingsFromMetadata(md)
+	require.NoError(t, err)
+	assert.Equal(t, "london", settings.EvmVersion)
+	assert.Equal(t, 200, settings.Optimizer.Runs)
+	assert.Equal(t, map[string]map[string]string{
+		"contracts/Math.sol": {"Math": "0x1234567890123456789012345678901234567890"},
+	}, settings.Libraries)
+}
@@ -15,6 +15,7 @@ import (
 // It includes details like state variables, target variables, constant variables, and storage layouts.
 type Descriptor struct {
 	Detector          *detector.Detector     `json:"-"`
+	irBuilder         *ir.Builder            `json:"-"`
 	cfgBuilder        *cfg.Builder           `json:"-"`
 	Address           common.Address         `json:"address"`
 	Block             *big.Int               `json:"block"`
@@ -22,6 +23,10 @@ type Descriptor struct {
 	TargetVariables   map[string][]*Variable `json:"-"`
 	ConstantVariables map[string][]*Variable `json:"-"`
 	StorageLayout     *StorageLayout         `json:"storage_layout"`
+
+	// orderedTargetVariables are the target variables in the order they are laid out in storage, from the
+	// most base contract to the entry contract.
+	orderedTargetVariables []*Variable
 }
 
 // GetDetector retrieves the contract's detector, which is essential for contract analysis.
@@ -31,15 +36,24 @@ func (s *Descriptor) GetDetector() *detector.Detector {
 }
 
 // GetAST retrieves the abstract syntax tree (AST) builder for the contract.
-// It returns an error if the AST builder is not available due to parsing failures or initialization issues.
+// It returns nil if the AST builder is not available due to parsing failures or initialization issues.
 func (s *Descriptor) GetAST() *ast.ASTBuilder {
-	return s.GetDetector().GetAST()
+	if builder := s.GetIR(); builder != nil {
+		return builder.GetAstBuilder()
+	}
+	return nil
 }
 
-// GetIR retrieves the intermediate representation (IR) builder of the contract.
-// It returns an error if the IR builder is not set, indicating parsing or initialization issues.
+// GetIR retrieves the intermediate representation (IR) builder of the contract, either the one of the
+// CFG builder or the one of the detector. It returns nil if none of them is set.
 func (s *Descriptor) GetIR() *ir.Builder {
-	return s.GetDetector().GetIR()
+	if s.irBuilder != nil {
+		return s.irBuilder
+	}
+	if s.Detector != nil {
+		return s.Detector.GetIR()
+	}
+	return nil
 }
 
 // GetCFG retrieves the control flow graph (CFG) builder of the contract.
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MismatchKind describes how a variable differs between two storage layouts.
+type MismatchKind string
+
+const (
+	// MismatchMissing is reported for variables of the expected layout that are not in the layout.
+	MismatchMissing MismatchKind = "missing"
+	// MismatchUnexpected is reported for variables of the layout that are not in the expected layout.
+	MismatchUnexpected MismatchKind = "unexpected"
+	// MismatchSlot is reported for variables stored at a different slot.
+	MismatchSlot MismatchKind = "slot"
+	// MismatchOffset is reported for variables stored at a different offset within their slot.
+	MismatchOffset MismatchKind = "offset"
+	// MismatchType is reported for variables of a different type.
+	MismatchType MismatchKind = "type"
+)
+
+// LayoutMismatch describes a difference of a single variable between two storage layouts.
+type LayoutMismatch struct {
+	Name     string       `json:"name"`     // Name is the name of the variable.
+	Kind     MismatchKind `json:"kind"`     // Kind is what differs.
+	Expected string       `json:"expected"` // Expected is the slot, byte offset or type within the expected layout.
+	Actual   string       `json:"actual"`   // Actual is the slot, byte offset or type within the layout.
+}
+
+// String returns the human readable description of the mismatch.
+func (m *LayoutMismatch) String() string {
+	switch m.Kind {
+	case MismatchMissing:
+		return fmt.Sprintf("%s: missing, expected at slot %s", m.Name, m.Expected)
+	case MismatchUnexpected:
+		return fmt.Sprintf("%s: unexpected variable at slot %s", m.Name, m.Actual)
+	default:
+		return fmt.Sprintf("%s: %s mismatch, expected %s got %s", m.Name, m.Kind, m.Expected, m.Actual)
+	}
+}
+
+// LayoutDiff holds the differences between two storage layouts.
+type LayoutDiff struct {
+	Mismatches []*LayoutMismatch `json:"mismatches"`
+}
+
+// GetMismatches returns the differences between the layouts.
+func (d *LayoutDiff) GetMismatches() []*LayoutMismatch {
+	return d.Mismatches
+}
+
+// HasMismatches returns true if the layouts differ.
+func (d *LayoutDiff) HasMismatches() bool {
+	return len(d.Mismatches) > 0
+}
+
+// String returns the differences between the layouts, one per line.
+func (d *LayoutDiff) String() string {
+	lines := make([]string, 0, len(d.Mismatches))
+	for _, mismatch := range d.Mismatches {
+		lines = append(lines, mismatch.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares the layout against the expected one, usually loaded from the solc output with
+// NewStorageLayoutFromSolc, and reports variables that are missing or differ in slot, offset or type.
+// Variables are matched by name and, as inherited contracts can declare variables with the same name such as
+// storage gaps, by the order of occurrence of the name. Types are compared by their storage type labels, falling
+// back to type names for slots whose type was not resolved.
+func (s *StorageLayout) Diff(expected *StorageLayout) *LayoutDiff {
+	toReturn := &LayoutDiff{Mismatches: make([]*LayoutMismatch, 0)}
+
+	actualKeys := layoutSlotKeys(s.GetSlots())
+	actual := make(map[string]*SlotDescriptor)
+	for i, slot := range s.GetSlots() {
+		actual[actualKeys[i]] = slot
+	}
+
+	expectedKeys := layoutSlotKeys(expected.GetSlots())
+	matched := make(map[string]bool)
+	for i, slot := range expected.GetSlots() {
+		key := expectedKeys[i]
+		actualSlot, found := actual[key]
+		if !found {
+			toReturn.Mismatches = append(toReturn.Mismatches, &LayoutMismatch{
+				Name:     slot.Name,
+				Kind:     MismatchMissing,
+				Expected: fmt.Sprintf("%d", slot.Slot),
+			})
+			continue
+		}
+		matched[key] = true
+
+		if actualSlot.Slot != slot.Slot {
+			toReturn.Mismatches = append(toReturn.Mismatches, &LayoutMismatch{
+				Name:     slot.Name,
+				Kind:     MismatchSlot,
+				Expected: fmt.Sprintf("%d", slot.Slot),
+				Actual:   fmt.Sprintf("%d", actualSlot.Slot),
+			})
+		}
+
+		if actualSlot.Offset != slot.Offset {
+			toReturn.Mismatches = append(toReturn.Mismatches, &LayoutMismatch{
+				Name:     slot.Name,
+				Kind:     MismatchOffset,
+				Expected: fmt.Sprintf("%d", slot.Offset/8),
+				Actual:   fmt.Sprintf("%d", actualSlot.Offset/8),
+			})
+		}
+
+		if expectedType, actualType := layoutSlotType(slot), layoutSlotType(actualSlot); expectedType != actualType {
+			toReturn.Mismatches = append(toReturn.Mismatches, &LayoutMismatch{
+				Name:     slot.Name,
+				Kind:     MismatchType,
+				Expected: expectedType,
+				Actual:   actualType,
+			})
+		}
+	}
+
+	for i, slot := range s.GetSlots() {
+		if !matched[actualKeys[i]] {
+			toReturn.Mismatches = append(toReturn.Mismatches, &LayoutMismatch{
+				Name:   slot.Name,
+				Kind:   MismatchUnexpected,
+				Actual: fmt.Sprintf("%d", slot.Slot),
+			})
+		}
+	}
+
+	return toReturn
+}
+
+// layoutSlotKeys returns the keys the slots are matched by, which are their names and the number of times the
+// name occurred before.
+func layoutSlotKeys(slots []*SlotDescriptor) []string {
+	toReturn := make([]string, 0, len(slots))
+	occurrences := make(map[string]int)
+	for _, slot := range slots {
+		toReturn = append(toReturn, fmt.Sprintf("%s#%d", slot.Name, occurrences[slot.Name]))
+		occurrences[slot.Name]++
+	}
+	return toReturn
+}
+
+// layoutSlotType returns the type of the slot used to compare layouts, preferring the label of its storage type.
+// Whitespace is removed, as solgo and solc format type names differently.
+func layoutSlotType(slot *SlotDescriptor) string {
+	if slot.StorageType != nil {
+		return strings.ReplaceAll(slot.StorageType.Label, " ", "")
+	}
+	return strings.ReplaceAll(slot.Type, " ", "")
+}
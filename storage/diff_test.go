@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/tests"
+)
+
+func TestNewStorageLayoutFromSolc(t *testing.T) {
+	layout, err := NewStorageLayoutFromSolc(tests.ReadJsonBytesForTest(t, "storage/Layout").Bytes)
+	require.NoError(t, err)
+	require.Len(t, layout.GetSlots(), 17)
+
+	treasury := layout.GetSlotByName("treasury")
+	require.NotNil(t, treasury)
+	assert.Equal(t, int64(14), treasury.Slot)
+	assert.Equal(t, int64(16), treasury.Offset)
+	assert.Equal(t, int64(160), treasury.Size)
+	assert.Equal(t, "address", treasury.Type)
+
+	positions := layout.GetSlotByName("positions").StorageType
+	require.NotNil(t, positions)
+	assert.True(t, positions.IsMapping())
+	assert.Equal(t, "uint256", positions.Key.Label)
+	assert.True(t, positions.Value.IsStruct())
+	assert.Equal(t, positions.Value, layout.GetSlotByName("position").StorageType)
+
+	history := positions.Value.GetMember("history")
+	require.NotNil(t, history)
+	assert.Equal(t, int64(2), history.Slot)
+	assert.True(t, history.Type.IsDynamicArray())
+	assert.Equal(t, "uint256", history.Type.Base.Label)
+
+	invalid := []string{
+		`{"storage": [{"label": "x", "slot": "0", "type": "t_missing"}], "types": {}}`,
+		`{"storage": [{"label": "x", "slot": "a", "type": "t_uint256"}], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}}`,
+		`{"storage": {}}`,
+	}
+	for _, data := range invalid {
+		_, err := NewStorageLayoutFromSolc([]byte(data))
+		assert.Error(t, err)
+	}
+}
+
+func TestStorageLayoutDiff(t *testing.T) {
+	reader := describeContract(t, "Layout", tests.ReadContractFileForTest(t, "storage/Layout").Content)
+	computed := reader.GetDescriptor().GetStorageLayout()
+
+	expected, err := NewStorageLayoutFromSolc(tests.ReadJsonBytesForTest(t, "storage/Layout").Bytes)
+	require.NoError(t, err)
+
+	diff := computed.Diff(expected)
+	assert.False(t, diff.HasMismatches(), diff.String())
+
+	// Entries of the computed layout resolve to the same slots as the ones of the compiler layout.
+	reader.GetDescriptor().StorageLayout = expected
+	fromSolc, err := reader.ResolveEntry("positions", 7, "history", 2)
+	require.NoError(t, err)
+	reader.GetDescriptor().StorageLayout = computed
+	fromSolgo, err := reader.ResolveEntry("positions", 7, "history", 2)
+	require.NoError(t, err)
+	assert.Equal(t, fromSolc.GetSlot(), fromSolgo.GetSlot())
+
+	// A layout with a variable inserted before existing ones, as in a broken upgrade, shifts the following slots.
+	upgraded := &StorageLayout{Slots: []*SlotDescriptor{
+		{Name: "_owner", Type: "address", Slot: 0, Offset: 0},
+		{Name: "_admin", Type: "address", Slot: 1, Offset: 0},
+		{Name: "_paused", Type: "bool", Slot: 1, Offset: 160},
+		{Name: "decimals", Type: "uint16", Slot: 1, Offset: 168},
+	}}
+
+	diff = upgraded.Diff(&StorageLayout{Slots: expected.GetSlots()[:4]})
+	assert.True(t, diff.HasMismatches())
+	assert.Equal(t, []*LayoutMismatch{
+		{Name: "_paused", Kind: MismatchSlot, Expected: "0", Actual: "1"},
+		{Name: "decimals", Kind: MismatchSlot, Expected: "0", Actual: "1"},
+		{Name: "decimals", Kind: MismatchType, Expected: "uint8", Actual: "uint16"},
+		{Name: "status", Kind: MismatchMissing, Expected: "0"},
+		{Name: "_admin", Kind: MismatchUnexpected, Actual: "1"},
+	}, diff.GetMismatches())
+	assert.Equal(t, "_paused: slot mismatch, expected 0 got 1\n"+
+		"decimals: slot mismatch, expected 0 got 1\n"+
+		"decimals: type mismatch, expected uint8 got uint16\n"+
+		"status: missing, expected at slot 0\n"+
+		"_admin: unexpected variable at slot 1", diff.String())
+}
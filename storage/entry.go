@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxBytesLength guards against decoding corrupted string or bytes lengths, which would otherwise result in
+// reading an arbitrary amount of slots.
+const maxBytesLength = 1024 * 1024
+
+// twoTo256 is the size of the storage address space, slot arithmetic wraps around it.
+var twoTo256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// Entry describes a value stored within a state variable, such as a mapping value, an array element or a
+// struct member, located by following a path of mapping keys, array indexes and struct member names.
+type Entry struct {
+	Variable    string        `json:"variable"`     // Variable is the name of the state variable.
+	Path        []interface{} `json:"path"`         // Path are the keys, indexes and member names leading to the value.
+	Type        *StorageType  `json:"type"`         // Type is the storage type of the value.
+	Slot        common.Hash   `json:"slot"`         // Slot is the first slot of the value.
+	Offset      int64         `json:"offset"`       // Offset is the byte offset of the value within the slot.
+	BlockNumber *big.Int      `json:"block_number"` // BlockNumber is the block the value was read at.
+	RawValue    common.Hash   `json:"raw_value"`    // RawValue is the content of the slot.
+	Value       interface{}   `json:"value"`        // Value is the decoded value.
+}
+
+// GetSlot returns the first slot of the value.
+func (e *Entry) GetSlot() common.Hash {
+	return e.Slot
+}
+
+// GetType returns the storage type of the value.
+func (e *Entry) GetType() *StorageType {
+	return e.Type
+}
+
+// GetValue returns the decoded value.
+func (e *Entry) GetValue() interface{} {
+	return e.Value
+}
+
+// slotReader reads the content of a storage slot.
+type slotReader func(slot common.Hash) (common.Hash, error)
+
+// resolveEntry follows the path from the position of a state variable and returns the entry of the value it
+// leads to, according to the Solidity storage layout rules:
+//   - the value of a mapping key k is stored at keccak256(k . slot),
+//   - the elements of a dynamic array are stored starting at keccak256(slot),
+//   - the elements of static arrays and the members of structs are stored starting at the slot itself.
+func resolveEntry(variable string, typ *StorageType, slot *big.Int, offset int64, path []interface{}) (*Entry, error) {
+	position := new(big.Int).Set(slot)
+
+	for i, step := range path {
+		switch {
+		case typ.IsMapping():
+			key, err := encodeMappingKey(typ.Key, step)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key at path position %d of %s: %w", i, variable, err)
+			}
+			position = crypto.Keccak256Hash(key, common.BigToHash(position).Bytes()).Big()
+			typ, offset = typ.Value, 0
+
+		case typ.IsArray():
+			index, err := toBigInt(step)
+			if err != nil || index.Sign() < 0 || !index.IsInt64() {
+				return nil, fmt.Errorf("invalid array index at path position %d of %s: %v", i, variable, step)
+			}
+
+			if typ.IsStaticArray() && index.Int64() >= typ.Length {
+				return nil, fmt.Errorf("array index %d out of bounds at path position %d of %s", index.Int64(), i, variable)
+			}
+
+			if typ.IsDynamicArray() {
+				position = crypto.Keccak256Hash(common.BigToHash(position).Bytes()).Big()
+			}
+
+			elementSlot, elementOffset := typ.elementPosition(index.Int64())
+			position = wrapSlot(position.Add(position, big.NewInt(elementSlot)))
+			typ, offset = typ.Base, elementOffset
+
+		case typ.IsStruct():
+			name, ok := step.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid struct member at path position %d of %s: %v", i, variable, step)
+			}
+
+			member := typ.GetMember(name)
+			if member == nil {
+				return nil, fmt.Errorf("struct %s has no member %s", typ.Label, name)
+			}
+
+			position = wrapSlot(position.Add(position, big.NewInt(member.Slot)))
+			typ, offset = member.Type, member.Offset
+
+		default:
+			return nil, fmt.Errorf("cannot index %s of type %s at path position %d", variable, typ.Label, i)
+		}
+	}
+
+	return &Entry{
+		Variable: variable,
+		Path:     path,
+		Type:     typ,
+		Slot:     common.BigToHash(position),
+		Offset:   offset,
+	}, nil
+}
+
+// decodeEntry reads the slots of the entry and decodes its value.
+func decodeEntry(entry *Entry, read slotReader) error {
+	word, err := read(entry.Slot)
+	if err != nil {
+		return err
+	}
+	entry.RawValue = word
+
+	value, err := decodeStorageValue(entry.Type, entry.Slot.Big(), entry.Offset, word, read)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", entry.Variable, err)
+	}
+	entry.Value = value
+
+	return nil
+}
+
+// decodeStorageValue decodes the value of the type stored at the slot and offset, where word is the content
+// of the slot. Values of mappings are not enumerable and decode to nil, dynamic arrays decode to their length.
+func decodeStorageValue(typ *StorageType, slot *big.Int, offset int64, word common.Hash, read slotReader) (interface{}, error) {
+	switch {
+	case typ.IsMapping():
+		return nil, nil
+
+	case typ.IsDynamicArray():
+		return word.Big(), nil
+
+	case typ.Encoding == EncodingBytes:
+		data, err := decodeStorageBytes(slot, word, read)
+		if err != nil {
+			return nil, err
+		}
+		if typ.Label == "string" {
+			return string(data), nil
+		}
+		return data, nil
+
+	case typ.IsStruct():
+		toReturn := make(map[string]interface{})
+		for _, member := range typ.Members {
+			if member.Type.IsMapping() || member.Type.IsDynamicArray() {
+				continue
+			}
+
+			value, err := readStorageValue(member.Type, new(big.Int).Add(slot, big.NewInt(member.Slot)), member.Offset, read)
+			if err != nil {
+				return nil, err
+			}
+			toReturn[member.Name] = value
+		}
+		return toReturn, nil
+
+	case typ.IsStaticArray():
+		toReturn := make([]interface{}, 0, typ.Length)
+		for i := int64(0); i < typ.Length; i++ {
+			elementSlot, elementOffset := typ.elementPosition(i)
+			value, err := readStorageValue(typ.Base, new(big.Int).Add(slot, big.NewInt(elementSlot)), elementOffset, read)
+			if err != nil {
+				return nil, err
+			}
+			toReturn = append(toReturn, value)
+		}
+		return toReturn, nil
+	}
+
+	if offset < 0 || offset+typ.NumberOfBytes > 32 {
+		return nil, fmt.Errorf("offset %d out of range for type %s", offset, typ.Label)
+	}
+
+	// Values are stored right aligned, with the first value of a slot occupying its lowest-order bytes.
+	data := word.Bytes()[32-offset-typ.NumberOfBytes : 32-offset]
+
+	switch {
+	case typ.Label == "bool":
+		return data[0] != 0, nil
+	case strings.HasPrefix(typ.Label, "address"), strings.HasPrefix(typ.Label, "contract "), strings.HasPrefix(typ.Label, "interface "):
+		return common.BytesToAddress(data), nil
+	case strings.HasPrefix(typ.Label, "uint"), strings.HasPrefix(typ.Label, "enum "):
+		return new(big.Int).SetBytes(data), nil
+	case strings.HasPrefix(typ.Label, "int"):
+		value := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return value, nil
+	default:
+		return common.CopyBytes(data), nil
+	}
+}
+
+// readStorageValue reads the slot and decodes the value of the type stored at the offset within it.
+func readStorageValue(typ *StorageType, slot *big.Int, offset int64, read slotReader) (interface{}, error) {
+	position := common.BigToHash(wrapSlot(slot))
+	word, err := read(position)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStorageValue(typ, position.Big(), offset, word, read)
+}
+
+// decodeStorageBytes decodes a string or bytes value. Values shorter than 32 bytes are stored in the
+// higher-order bytes of the slot with twice their length in the lowest-order byte. Longer values store twice
+// their length plus one at the slot and their data starting at keccak256(slot).
+func decodeStorageBytes(slot *big.Int, word common.Hash, read slotReader) ([]byte, error) {
+	if word[31]&1 == 0 {
+		length := int(word[31] / 2)
+		if length > 31 {
+			return nil, fmt.Errorf("invalid short bytes length %d", length)
+		}
+		return common.CopyBytes(word[:length]), nil
+	}
+
+	length := new(big.Int).Rsh(word.Big(), 1)
+	if !length.IsInt64() || length.Int64() > maxBytesLength {
+		return nil, fmt.Errorf("bytes length %s exceeds maximum allowed length of %d", length.String(), maxBytesLength)
+	}
+
+	toReturn := make([]byte, 0, length.Int64()+31)
+	dataSlot := crypto.Keccak256Hash(common.BigToHash(slot).Bytes()).Big()
+	for i := int64(0); int64(len(toReturn)) < length.Int64(); i++ {
+		chunk, err := read(common.BigToHash(wrapSlot(new(big.Int).Add(dataSlot, big.NewInt(i)))))
+		if err != nil {
+			return nil, err
+		}
+		toReturn = append(toReturn, chunk.Bytes()...)
+	}
+
+	return toReturn[:length.Int64()], nil
+}
+
+// encodeMappingKey encodes the key as it is hashed together with the slot of the mapping. Value types are
+// padded to 32 bytes while string and bytes keys are hashed as they are.
+func encodeMappingKey(typ *StorageType, key interface{}) ([]byte, error) {
+	switch {
+	case typ.Encoding == EncodingBytes:
+		switch k := key.(type) {
+		case string:
+			if typ.Label == "bytes" && strings.HasPrefix(k, "0x") {
+				return common.FromHex(k), nil
+			}
+			return []byte(k), nil
+		case []byte:
+			return k, nil
+		}
+
+	case typ.Label == "bool":
+		if k, ok := key.(bool); ok {
+			if k {
+				return common.LeftPadBytes([]byte{1}, 32), nil
+			}
+			return make([]byte, 32), nil
+		}
+
+	case strings.HasPrefix(typ.Label, "address"), strings.HasPrefix(typ.Label, "contract "), strings.HasPrefix(typ.Label, "interface "):
+		switch k := key.(type) {
+		case common.Address:
+			return common.LeftPadBytes(k.Bytes(), 32), nil
+		case string:
+			if common.IsHexAddress(k) {
+				return common.LeftPadBytes(common.HexToAddress(k).Bytes(), 32), nil
+			}
+		}
+
+	case strings.HasPrefix(typ.Label, "bytes"):
+		var data []byte
+		switch k := key.(type) {
+		case []byte:
+			data = k
+		case common.Hash:
+			data = k.Bytes()[:typ.NumberOfBytes]
+		case string:
+			data = common.FromHex(k)
+		}
+		if data != nil {
+			if int64(len(data)) > typ.NumberOfBytes {
+				return nil, fmt.Errorf("key of %d bytes does not fit %s", len(data), typ.Label)
+			}
+			return common.RightPadBytes(data, 32), nil
+		}
+
+	case strings.HasPrefix(typ.Label, "uint"), strings.HasPrefix(typ.Label, "int"), strings.HasPrefix(typ.Label, "enum "):
+		value, err := toBigInt(key)
+		if err != nil {
+			return nil, err
+		}
+
+		bits := uint(typ.NumberOfBytes * 8)
+		if strings.HasPrefix(typ.Label, "int") {
+			limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+			if value.Cmp(limit) >= 0 || value.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return nil, fmt.Errorf("key %s does not fit %s", value.String(), typ.Label)
+			}
+			// Signed keys are sign extended to 32 bytes, which is their two's complement within 256 bits.
+			return common.BigToHash(wrapSlot(value)).Bytes(), nil
+		}
+
+		if value.Sign() < 0 || value.BitLen() > int(bits) {
+			return nil, fmt.Errorf("key %s does not fit %s", value.String(), typ.Label)
+		}
+		return common.BigToHash(value).Bytes(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported key %v of type %T for %s", key, key, typ.Label)
+}
+
+// toBigInt converts integers, big integers, hashes and decimal or 0x prefixed hexadecimal strings into a big integer.
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("nil big integer")
+		}
+		return new(big.Int).Set(v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int8:
+		return big.NewInt(int64(v)), nil
+	case int16:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case common.Hash:
+		return v.Big(), nil
+	case string:
+		if toReturn, ok := new(big.Int).SetString(v, 0); ok {
+			return toReturn, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot convert %v of type %T into an integer", value, value)
+}
+
+// wrapSlot wraps the slot into the 256 bit storage address space.
+func wrapSlot(slot *big.Int) *big.Int {
+	return slot.Mod(slot, twoTo256)
+}
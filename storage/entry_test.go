@@ -0,0 +1,366 @@
+package storage
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/detector"
+	"github.com/unpackdev/solgo/ir"
+	"github.com/unpackdev/solgo/tests"
+	"github.com/unpackdev/solgo/utils"
+)
+
+const vaultContract = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Vault {
+    struct Position {
+        uint128 amount;
+        uint64 since;
+        address owner;
+        uint256[] history;
+    }
+
+    mapping(address => uint256) public balances;
+    mapping(address => mapping(address => uint256)) public allowances;
+    address[] public holders;
+    mapping(uint256 => Position) public positions;
+    uint64[] public checkpoints;
+    Position public position;
+}
+`
+
+// describeContract describes the storage layout of the contract without reading any of its values.
+func describeContract(t *testing.T, name string, content string) *Reader {
+	ctx := context.Background()
+	sources := &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{
+				Name:    name,
+				Path:    name + ".sol",
+				Content: content,
+			},
+		},
+		EntrySourceUnitName: name,
+		LocalSourcesPath:    t.TempDir(),
+	}
+
+	parser, err := detector.NewDetectorFromSources(ctx, nil, sources)
+	require.NoError(t, err)
+	require.Empty(t, parser.Parse())
+	require.NoError(t, parser.Build())
+
+	builder, err := cfg.NewBuilder(ctx, parser.GetIR())
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+
+	storage, err := NewStorage(ctx, utils.Ethereum, nil, nil, NewDefaultOptions())
+	require.NoError(t, err)
+
+	reader, err := storage.DescribeLayout(ctx, common.Address{}, parser, builder, big.NewInt(1))
+	require.NoError(t, err)
+	return reader
+}
+
+func TestStorageTypes(t *testing.T) {
+	reader := describeContract(t, "Vault", vaultContract)
+
+	testCases := []struct {
+		typeName      string
+		label         string
+		encoding      TypeEncoding
+		numberOfBytes int64
+		expectError   bool
+	}{
+		{typeName: "uint256", label: "uint256", encoding: EncodingInplace, numberOfBytes: 32},
+		{typeName: "uint", label: "uint256", encoding: EncodingInplace, numberOfBytes: 32},
+		{typeName: "int24", label: "int24", encoding: EncodingInplace, numberOfBytes: 3},
+		{typeName: "address payable", label: "address payable", encoding: EncodingInplace, numberOfBytes: 20},
+		{typeName: "contract Vault", label: "contract Vault", encoding: EncodingInplace, numberOfBytes: 20},
+		{typeName: "bytes4", label: "bytes4", encoding: EncodingInplace, numberOfBytes: 4},
+		{typeName: "string storage ref", label: "string", encoding: EncodingBytes, numberOfBytes: 32},
+		{typeName: "uint8[3]", label: "uint8[3]", encoding: EncodingInplace, numberOfBytes: 32},
+		{typeName: "uint128[3]", label: "uint128[3]", encoding: EncodingInplace, numberOfBytes: 64},
+		{typeName: "address[][]", label: "address[][]", encoding: EncodingDynamicArray, numberOfBytes: 32},
+		{typeName: "Position[2]", label: "struct Vault.Position[2]", encoding: EncodingInplace, numberOfBytes: 192},
+		{typeName: "struct Vault.Position", label: "struct Vault.Position", encoding: EncodingInplace, numberOfBytes: 96},
+		{
+			typeName:      "mapping(address=>mapping(address=>uint256))",
+			label:         "mapping(address => mapping(address => uint256))",
+			encoding:      EncodingMapping,
+			numberOfBytes: 32,
+		},
+		{
+			typeName:      "mapping(uint256 => Position)",
+			label:         "mapping(uint256 => struct Vault.Position)",
+			encoding:      EncodingMapping,
+			numberOfBytes: 32,
+		},
+		{typeName: "mapping(uint256[] => bool)", expectError: true},
+		{typeName: "uint7", expectError: true},
+		{typeName: "function", expectError: true},
+		{typeName: "struct Vault.Missing", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.typeName, func(t *testing.T) {
+			typ, err := reader.getTypeResolver().resolve(testCase.typeName)
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.label, typ.Label)
+			assert.Equal(t, testCase.encoding, typ.Encoding)
+			assert.Equal(t, testCase.numberOfBytes, typ.NumberOfBytes)
+		})
+	}
+
+	position, err := reader.GetVariableType("position")
+	require.NoError(t, err)
+	require.True(t, position.IsStruct())
+
+	members := make([][3]interface{}, 0)
+	for _, member := range position.Members {
+		members = append(members, [3]interface{}{member.Name, member.Slot, member.Offset})
+	}
+	assert.Equal(t, [][3]interface{}{
+		{"amount", int64(0), int64(0)},
+		{"since", int64(0), int64(16)},
+		{"owner", int64(1), int64(0)},
+		{"history", int64(2), int64(0)},
+	}, members)
+}
+
+func TestDescribeLayoutFromCFG(t *testing.T) {
+	ctx := context.Background()
+
+	irBuilder, err := ir.NewBuilderFromSolcJSON(ctx, tests.ReadJsonBytesForTest(t, "ast/TokenSale.solc.ast").Bytes, nil)
+	require.NoError(t, err)
+	require.Empty(t, irBuilder.Parse())
+	require.NoError(t, irBuilder.Build())
+
+	builder, err := cfg.NewBuilder(ctx, irBuilder)
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+
+	storage, err := NewStorage(ctx, utils.Ethereum, nil, nil, NewDefaultOptions())
+	require.NoError(t, err)
+
+	reader, err := storage.DescribeLayoutFromCFG(ctx, common.Address{}, builder, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Nil(t, reader.GetDescriptor().GetDetector())
+	assert.Equal(t, irBuilder, reader.GetDescriptor().GetIR())
+
+	slots := make([][3]interface{}, 0)
+	for _, slot := range reader.GetDescriptor().GetSlots() {
+		slots = append(slots, [3]interface{}{slot.Name, slot.Slot, slot.Offset})
+	}
+	assert.Equal(t, [][3]interface{}{
+		{"token", int64(0), int64(0)},
+		{"owner", int64(1), int64(0)},
+		{"tokenPrice", int64(2), int64(0)},
+	}, slots)
+
+	_, err = storage.DescribeLayoutFromCFG(ctx, common.Address{}, nil, big.NewInt(1))
+	assert.Error(t, err)
+}
+
+func TestResolveEntry(t *testing.T) {
+	reader := describeContract(t, "Vault", vaultContract)
+
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	testCases := []struct {
+		name        string
+		variable    string
+		path        []interface{}
+		slot        string
+		offset      int64
+		label       string
+		expectError string
+	}{
+		{
+			name:     "Mapping Value",
+			variable: "balances",
+			path:     []interface{}{alice},
+			slot:     "0xd6f751104ddfead9549c96fabdbd4d2fc6876c8cd9a49ea4a821de938f71a011",
+			label:    "uint256",
+		},
+		{
+			name:     "Nested Mapping Value With Hex Keys",
+			variable: "allowances",
+			path:     []interface{}{alice.Hex(), strings.ToLower(bob.Hex())},
+			slot:     "0x336ed3b8d8f06100235ffc9f8b56456dfc3817792e5be89000ef929f1f52bf42",
+			label:    "uint256",
+		},
+		{
+			name:     "Dynamic Array Element",
+			variable: "holders",
+			path:     []interface{}{1},
+			slot:     "0x405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5acf",
+			label:    "address",
+		},
+		{
+			name:     "Packed Dynamic Array Element",
+			variable: "checkpoints",
+			path:     []interface{}{uint64(5)},
+			slot:     "0x8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19c",
+			offset:   8,
+			label:    "uint64",
+		},
+		{
+			name:     "Struct Member",
+			variable: "position",
+			path:     []interface{}{"owner"},
+			slot:     "0x0000000000000000000000000000000000000000000000000000000000000006",
+			label:    "address",
+		},
+		{
+			name:     "Packed Struct Member",
+			variable: "position",
+			path:     []interface{}{"since"},
+			slot:     "0x0000000000000000000000000000000000000000000000000000000000000005",
+			offset:   16,
+			label:    "uint64",
+		},
+		{
+			name:     "Struct Member Array Element",
+			variable: "position",
+			path:     []interface{}{"history", big.NewInt(3)},
+			slot:     "0xa66cc928b5edb82af9bd49922954155ab7b0942694bea4ce44661d9a8736c68b",
+			label:    "uint256",
+		},
+		{
+			name:     "Struct In Mapping",
+			variable: "positions",
+			path:     []interface{}{"1", "history"},
+			slot:     "0xa15bc60c955c405d20d9149c709e2460f1c2d9a497496a7f46004d1772c3054e",
+			label:    "uint256[]",
+		},
+		{
+			name:        "Unknown Variable",
+			variable:    "missing",
+			expectError: "not found in storage layout",
+		},
+		{
+			name:        "Invalid Key",
+			variable:    "balances",
+			path:        []interface{}{true},
+			expectError: "invalid key",
+		},
+		{
+			name:        "Unknown Member",
+			variable:    "position",
+			path:        []interface{}{"missing"},
+			expectError: "has no member missing",
+		},
+		{
+			name:        "Index Into Value",
+			variable:    "balances",
+			path:        []interface{}{alice, 1},
+			expectError: "cannot index balances of type uint256",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entry, err := reader.ResolveEntry(testCase.variable, testCase.path...)
+			if testCase.expectError != "" {
+				assert.ErrorContains(t, err, testCase.expectError)
+				assert.Nil(t, entry)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.slot, entry.GetSlot().Hex())
+			assert.Equal(t, testCase.offset, entry.Offset)
+			assert.Equal(t, testCase.label, entry.GetType().Label)
+		})
+	}
+
+	_, err := reader.ReadMappingValue("holders", 1)
+	assert.ErrorContains(t, err, "is not a mapping")
+
+	_, err = reader.ReadArrayElement("balances", 1)
+	assert.ErrorContains(t, err, "is not an array")
+}
+
+func TestDecodeEntry(t *testing.T) {
+	reader := describeContract(t, "Vault", vaultContract)
+
+	longString := strings.Repeat("solgo ", 10)
+	longSlot := common.BigToHash(big.NewInt(9))
+	longData := crypto.Keccak256Hash(longSlot.Bytes()).Big()
+
+	slots := map[common.Hash]common.Hash{
+		// Checkpoints 4 to 7, with the fifth one being 0x1234.
+		common.HexToHash("0x8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19c"): common.HexToHash("0x0000000000001234000000000000000a"),
+		// Position with an amount of 1000, since 7 and owner 0xaa.
+		common.BigToHash(big.NewInt(5)): common.HexToHash("0x0000000000000000000000000000000700000000000000000000000000000" + "3e8"),
+		common.BigToHash(big.NewInt(6)): common.HexToHash("0xaa"),
+		// Short string "solgo" and a long string spanning two data slots.
+		common.BigToHash(big.NewInt(8)): common.BytesToHash(append(common.RightPadBytes([]byte("solgo"), 31), 10)),
+		longSlot:                        common.BigToHash(big.NewInt(int64(len(longString)*2 + 1))),
+		common.BigToHash(longData):      common.BytesToHash([]byte(longString[:32])),
+		common.BigToHash(new(big.Int).Add(longData, big.NewInt(1))): common.BytesToHash(common.RightPadBytes([]byte(longString[32:]), 32)),
+		// Negative int16 packed at offset 2.
+		common.BigToHash(big.NewInt(10)): common.HexToHash("0xff850000"),
+	}
+
+	read := func(slot common.Hash) (common.Hash, error) {
+		return slots[slot], nil
+	}
+
+	resolver := reader.getTypeResolver()
+	stringType, err := resolver.resolve("string")
+	require.NoError(t, err)
+	intType, err := resolver.resolve("int16")
+	require.NoError(t, err)
+
+	checkpoint, err := reader.ResolveEntry("checkpoints", 5)
+	require.NoError(t, err)
+	member, err := reader.ResolveEntry("position", "since")
+	require.NoError(t, err)
+	position, err := reader.ResolveEntry("position")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		entry    *Entry
+		expected interface{}
+	}{
+		{name: "Packed Array Element", entry: checkpoint, expected: big.NewInt(0x1234)},
+		{name: "Packed Struct Member", entry: member, expected: big.NewInt(7)},
+		{
+			name:  "Struct",
+			entry: position,
+			expected: map[string]interface{}{
+				"amount": big.NewInt(1000),
+				"since":  big.NewInt(7),
+				"owner":  common.HexToAddress("0xaa"),
+			},
+		},
+		{name: "Short String", entry: &Entry{Type: stringType, Slot: common.BigToHash(big.NewInt(8))}, expected: "solgo"},
+		{name: "Long String", entry: &Entry{Type: stringType, Slot: longSlot}, expected: longString},
+		{name: "Signed Integer", entry: &Entry{Type: intType, Slot: common.BigToHash(big.NewInt(10)), Offset: 2}, expected: big.NewInt(-123)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.NoError(t, decodeEntry(testCase.entry, read))
+			assert.Equal(t, slots[testCase.entry.Slot], testCase.entry.RawValue)
+			assert.Equal(t, testCase.expected, testCase.entry.GetValue())
+		})
+	}
+}
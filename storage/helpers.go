@@ -35,23 +35,36 @@ func calculateSlot(variable *Variable, currentSlot int64, previousVars []*Variab
 func calculateOffset(previousVars []*Variable) int64 {
 	totalUsedBits := int64(0)
 	for _, prevVar := range previousVars {
-		bitSize, _ := prevVar.GetAST().GetTypeName().StorageSize()
-		totalUsedBits += bitSize
+		totalUsedBits += storageSizeInBits(prevVar)
 	}
 
 	return totalUsedBits
 }
 
-// canBePacked checks if a given variable can be packed into the same storage slot
-// as previous variables. Considers the total bit size and special cases like boolean variables.
-func canBePacked(variable *Variable, previousVars []*Variable) bool {
-	totalUsedBits := int64(0)
-	for _, prevVar := range previousVars {
-		bitSize, _ := prevVar.GetAST().GetTypeName().StorageSize()
-		totalUsedBits += bitSize
+// storageSizeInBits returns the number of bits the variable occupies within its slot. Only value types share
+// slots, all other types occupy at least a whole slot. Mappings and dynamic arrays always occupy a single slot,
+// as their contents are stored at keccak256 derived slots.
+func storageSizeInBits(variable *Variable) int64 {
+	if variable.storageType != nil {
+		if variable.storageType.IsValueType() {
+			return variable.storageType.NumberOfBytes * 8
+		}
+		return 256
+	}
+
+	if variable.IsMappingType() || variable.IsDynamicArray() {
+		return 256
 	}
 
 	bitSize, _ := variable.GetAST().GetTypeName().StorageSize()
+	return bitSize
+}
+
+// canBePacked checks if a given variable can be packed into the same storage slot
+// as previous variables. Considers the total bit size and special cases like boolean variables.
+func canBePacked(variable *Variable, previousVars []*Variable) bool {
+	totalUsedBits := calculateOffset(previousVars)
+	bitSize := storageSizeInBits(variable)
 
 	// Check if the total size exceeds the 256-bit boundary of a single slot.
 	if totalUsedBits+bitSize > 256 {
@@ -3,7 +3,11 @@ package storage
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/unpackdev/solgo/ir"
 )
 
 // Reader is responsible for reading and interpreting storage-related information of a smart contract.
@@ -12,6 +16,7 @@ type Reader struct {
 	ctx        context.Context // ctx is the context for operations within Reader.
 	storage    *Storage        // storage is the storage system associated with the Reader.
 	descriptor *Descriptor     // descriptor contains the contract's storage layout and variable information.
+	types      *typeResolver   // types resolves the storage types of variables, built on first use.
 }
 
 // NewReader creates a new instance of Reader with the given context, Storage, and Descriptor.
@@ -54,6 +59,7 @@ func (r *Reader) DiscoverStorageVariables() error {
 
 		if !variable.StateVariable.IsConstant() {
 			r.descriptor.TargetVariables[contractName] = append(r.descriptor.TargetVariables[contractName], variable)
+			r.descriptor.orderedTargetVariables = append(r.descriptor.orderedTargetVariables, variable)
 		} else {
 			r.descriptor.ConstantVariables[contractName] = append(r.descriptor.ConstantVariables[contractName], variable)
 		}
@@ -70,29 +76,35 @@ func (r *Reader) CalculateStorageLayout() error {
 
 	var sortedSlots []*SlotDescriptor
 
-	for _, variables := range r.descriptor.GetTargetVariables() {
-		for _, variable := range variables {
-			storageSize, found := variable.GetAST().GetTypeName().StorageSize()
-			if !found {
-				//utils.DumpNodeWithExit(variable.GetAST().GetTypeName())
-				return fmt.Errorf("error calculating storage size for variable: %s", variable.GetName())
-			}
-
-			typeName := variable.GetType()
-			if strings.HasPrefix(typeName, "contract") {
-				typeName = "address"
-			}
-
-			sortedSlots = append(sortedSlots, &SlotDescriptor{
-				DeclarationId:   variable.StateVariable.GetId(),
-				Variable:        variable,
-				Contract:        variable.Contract,
-				Name:            variable.GetName(),
-				Type:            typeName,
-				TypeDescription: variable.StateVariable.GetTypeDescription(),
-				Size:            storageSize,
-			})
+	// Variables are laid out in the order of inheritance, so they are iterated in the order they were
+	// discovered rather than grouped by contract.
+	for _, variable := range r.descriptor.orderedTargetVariables {
+		storageSize, found := variable.GetAST().GetTypeName().StorageSize()
+		if !found {
+			//utils.DumpNodeWithExit(variable.GetAST().GetTypeName())
+			return fmt.Errorf("error calculating storage size for variable: %s", variable.GetName())
+		}
+
+		typeName := variable.GetType()
+		if strings.HasPrefix(typeName, "contract") {
+			typeName = "address"
 		}
+
+		// Types that fail to resolve fall back to the size reported by the AST.
+		if storageType, err := r.getTypeResolver().resolve(variable.GetType()); err == nil {
+			variable.storageType = storageType
+		}
+
+		sortedSlots = append(sortedSlots, &SlotDescriptor{
+			DeclarationId:   variable.StateVariable.GetId(),
+			Variable:        variable,
+			Contract:        variable.Contract,
+			Name:            variable.GetName(),
+			Type:            typeName,
+			TypeDescription: variable.StateVariable.GetTypeDescription(),
+			StorageType:     variable.storageType,
+			Size:            storageSize,
+		})
 	}
 
 	for i, variable := range sortedSlots {
@@ -104,6 +116,11 @@ func (r *Reader) CalculateStorageLayout() error {
 		if slot != currentSlot {
 			currentSlot = slot
 		}
+
+		// Structs and static arrays can span multiple slots, the variable following them starts after the last one.
+		if variable.StorageType != nil && !variable.StorageType.IsValueType() {
+			currentSlot += variable.StorageType.GetSlotCount() - 1
+		}
 	}
 
 	r.descriptor.StorageLayout = &StorageLayout{
@@ -112,3 +129,188 @@ func (r *Reader) CalculateStorageLayout() error {
 
 	return nil
 }
+
+// GetVariableType returns the storage type of the state variable with the provided name.
+func (r *Reader) GetVariableType(variable string) (*StorageType, error) {
+	slot := r.descriptor.GetStorageLayout().GetSlotByName(variable)
+	if slot == nil {
+		return nil, fmt.Errorf("state variable %s not found in storage layout", variable)
+	}
+
+	return r.getSlotType(slot)
+}
+
+// ResolveEntry computes the slot and offset of the value reached by following the path from the state variable,
+// without reading it. Each step of the path is a key when the current type is a mapping, an index when it is an
+// array and a member name when it is a struct.
+func (r *Reader) ResolveEntry(variable string, path ...interface{}) (*Entry, error) {
+	slot := r.descriptor.GetStorageLayout().GetSlotByName(variable)
+	if slot == nil {
+		return nil, fmt.Errorf("state variable %s not found in storage layout", variable)
+	}
+
+	typ, err := r.getSlotType(slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve type of %s: %w", variable, err)
+	}
+
+	// Offsets of the storage layout are in bits while offsets within entries are in bytes.
+	return resolveEntry(variable, typ, big.NewInt(slot.Slot), slot.Offset/8, path)
+}
+
+// ReadEntry reads and decodes the value reached by following the path from the state variable.
+// See ResolveEntry for how the path is followed.
+func (r *Reader) ReadEntry(variable string, path ...interface{}) (*Entry, error) {
+	entry, err := r.ResolveEntry(variable, path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decodeEntry(entry, r.readSlot); err != nil {
+		return nil, err
+	}
+	entry.BlockNumber = r.descriptor.GetBlock()
+
+	return entry, nil
+}
+
+// ReadMappingValue reads the value stored under the keys of a mapping, one key per level of nested mappings.
+func (r *Reader) ReadMappingValue(variable string, keys ...interface{}) (*Entry, error) {
+	typ, err := r.GetVariableType(variable)
+	if err != nil {
+		return nil, err
+	}
+
+	for range keys {
+		if !typ.IsMapping() {
+			return nil, fmt.Errorf("too many keys for %s, %s is not a mapping", variable, typ.Label)
+		}
+		typ = typ.Value
+	}
+
+	return r.ReadEntry(variable, keys...)
+}
+
+// ReadArrayElement reads the element at the index of an array state variable.
+func (r *Reader) ReadArrayElement(variable string, index uint64) (*Entry, error) {
+	typ, err := r.GetVariableType(variable)
+	if err != nil {
+		return nil, err
+	}
+
+	if !typ.IsArray() {
+		return nil, fmt.Errorf("state variable %s of type %s is not an array", variable, typ.Label)
+	}
+
+	if typ.IsDynamicArray() {
+		length, err := r.ReadArrayLength(variable)
+		if err != nil {
+			return nil, err
+		}
+
+		if index >= length {
+			return nil, fmt.Errorf("array index %d out of bounds for %s of length %d", index, variable, length)
+		}
+	}
+
+	return r.ReadEntry(variable, index)
+}
+
+// ReadStructMember reads a member of a struct state variable. Multiple names read members of nested structs.
+func (r *Reader) ReadStructMember(variable string, members ...string) (*Entry, error) {
+	path := make([]interface{}, len(members))
+	for i, member := range members {
+		path[i] = member
+	}
+
+	return r.ReadEntry(variable, path...)
+}
+
+// ReadArrayLength returns the length of the array reached by following the path from the state variable.
+func (r *Reader) ReadArrayLength(variable string, path ...interface{}) (uint64, error) {
+	entry, err := r.ResolveEntry(variable, path...)
+	if err != nil {
+		return 0, err
+	}
+
+	if entry.Type.IsStaticArray() {
+		return uint64(entry.Type.Length), nil
+	}
+
+	if !entry.Type.IsDynamicArray() {
+		return 0, fmt.Errorf("%s of type %s is not an array", variable, entry.Type.Label)
+	}
+
+	word, err := r.readSlot(entry.Slot)
+	if err != nil {
+		return 0, err
+	}
+
+	if !word.Big().IsUint64() {
+		return 0, fmt.Errorf("invalid length %s of array %s", word.Big().String(), variable)
+	}
+
+	return word.Big().Uint64(), nil
+}
+
+// ReadArrayElements reads the elements of the array reached by following the path from the state variable,
+// up to the limit. A limit of zero reads all elements.
+func (r *Reader) ReadArrayElements(variable string, limit uint64, path ...interface{}) ([]*Entry, error) {
+	length, err := r.ReadArrayLength(variable, path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && length > limit {
+		length = limit
+	}
+
+	toReturn := make([]*Entry, 0, length)
+	for i := uint64(0); i < length; i++ {
+		elementPath := append(append(make([]interface{}, 0, len(path)+1), path...), i)
+		entry, err := r.ReadEntry(variable, elementPath...)
+		if err != nil {
+			return nil, err
+		}
+		toReturn = append(toReturn, entry)
+	}
+
+	return toReturn, nil
+}
+
+// readSlot reads the storage slot of the described contract. Reads are pinned to the block of the descriptor,
+// which is set to the latest block on first read if the descriptor was not created for a specific block.
+func (r *Reader) readSlot(slot common.Hash) (common.Hash, error) {
+	blockNumber, value, err := r.storage.getStorageValueAtPosition(r.ctx, r.descriptor.Address, slot, r.descriptor.GetBlock())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error reading storage slot %s: %v", slot.Hex(), err)
+	}
+
+	if r.descriptor.Block == nil {
+		r.descriptor.Block = blockNumber
+	}
+
+	return common.BytesToHash(value), nil
+}
+
+// getSlotType returns the storage type of the slot, resolving it from the slot type if the layout did not.
+func (r *Reader) getSlotType(slot *SlotDescriptor) (*StorageType, error) {
+	if slot.StorageType != nil {
+		return slot.StorageType, nil
+	}
+
+	return r.getTypeResolver().resolve(slot.Type)
+}
+
+// getTypeResolver returns the type resolver of the contract, creating it on first use.
+func (r *Reader) getTypeResolver() *typeResolver {
+	if r.types == nil {
+		var root *ir.RootSourceUnit
+		if r.descriptor.GetIR() != nil {
+			root = r.descriptor.GetIR().GetRoot()
+		}
+		r.types = newTypeResolver(root)
+	}
+
+	return r.types
+}
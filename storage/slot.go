@@ -33,6 +33,9 @@ type SlotDescriptor struct {
 	// TypeDescription provides a detailed AST-based type description of the variable.
 	TypeDescription *ast.TypeDescription `json:"type_description"`
 
+	// StorageType describes how the variable is stored. It is nil if the type of the variable could not be resolved.
+	StorageType *StorageType `json:"storage_type,omitempty"`
+
 	// Slot is the index of the storage slot in the contract.
 	Slot int64 `json:"slot"`
 
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// solcStorageLayout is the storageLayout output of solc for a single contract.
+type solcStorageLayout struct {
+	Storage []*solcStorageItem          `json:"storage"`
+	Types   map[string]*solcStorageType `json:"types"`
+}
+
+// solcStorageItem is a state variable or struct member within the solc storage layout.
+type solcStorageItem struct {
+	AstId    int64  `json:"astId"`
+	Contract string `json:"contract"`
+	Label    string `json:"label"`
+	Offset   int64  `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+// solcStorageType is a type within the solc storage layout, referenced by its identifier.
+type solcStorageType struct {
+	Encoding      TypeEncoding       `json:"encoding"`
+	Label         string             `json:"label"`
+	NumberOfBytes string             `json:"numberOfBytes"`
+	Key           string             `json:"key,omitempty"`
+	Value         string             `json:"value,omitempty"`
+	Base          string             `json:"base,omitempty"`
+	Members       []*solcStorageItem `json:"members,omitempty"`
+}
+
+// NewStorageLayoutFromSolc loads the storageLayout output of solc for a single contract, as found under
+// contracts[file][contract].storageLayout of the standard JSON output. Offsets and sizes are converted into
+// bits, the same as in layouts calculated by the Reader, and slots carry the storage types reported by solc.
+func NewStorageLayoutFromSolc(data []byte) (*StorageLayout, error) {
+	var layout solcStorageLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("failed to decode solc storage layout: %w", err)
+	}
+
+	types := make(map[string]*StorageType)
+	toReturn := &StorageLayout{Slots: make([]*SlotDescriptor, 0, len(layout.Storage))}
+
+	for _, item := range layout.Storage {
+		storageType, err := layout.resolveType(item.Type, types)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve type of %s: %w", item.Label, err)
+		}
+
+		slot, err := strconv.ParseInt(item.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot %q of %s: %w", item.Slot, item.Label, err)
+		}
+
+		toReturn.Slots = append(toReturn.Slots, &SlotDescriptor{
+			DeclarationId: item.AstId,
+			Name:          item.Label,
+			Type:          storageType.Label,
+			StorageType:   storageType,
+			Slot:          slot,
+			Size:          storageType.NumberOfBytes * 8,
+			Offset:        item.Offset * 8,
+		})
+	}
+
+	return toReturn, nil
+}
+
+// resolveType builds the storage type with the identifier, caching resolved types. Types are cached before
+// their members are resolved, as structs can refer to themselves through mappings and dynamic arrays.
+func (l *solcStorageLayout) resolveType(id string, types map[string]*StorageType) (*StorageType, error) {
+	if cached, ok := types[id]; ok {
+		return cached, nil
+	}
+
+	solcType, ok := l.Types[id]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in storage layout", id)
+	}
+
+	numberOfBytes, err := strconv.ParseInt(solcType.NumberOfBytes, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of bytes %q of type %s: %w", solcType.NumberOfBytes, id, err)
+	}
+
+	toReturn := &StorageType{
+		Label:         solcType.Label,
+		Encoding:      solcType.Encoding,
+		NumberOfBytes: numberOfBytes,
+	}
+	types[id] = toReturn
+
+	if solcType.Key != "" {
+		if toReturn.Key, err = l.resolveType(solcType.Key, types); err != nil {
+			return nil, err
+		}
+	}
+
+	if solcType.Value != "" {
+		if toReturn.Value, err = l.resolveType(solcType.Value, types); err != nil {
+			return nil, err
+		}
+	}
+
+	if solcType.Base != "" {
+		if toReturn.Base, err = l.resolveType(solcType.Base, types); err != nil {
+			return nil, err
+		}
+
+		// Solc does not report the length of static arrays other than within their label.
+		if toReturn.Encoding == EncodingInplace {
+			open := strings.LastIndex(solcType.Label, "[")
+			length, err := strconv.ParseInt(strings.TrimSuffix(solcType.Label[open+1:], "]"), 10, 64)
+			if open < 0 || err != nil {
+				return nil, fmt.Errorf("invalid static array type %s", solcType.Label)
+			}
+			toReturn.Length = length
+		}
+	}
+
+	for _, member := range solcType.Members {
+		memberType, err := l.resolveType(member.Type, types)
+		if err != nil {
+			return nil, err
+		}
+
+		slot, err := strconv.ParseInt(member.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot %q of member %s: %w", member.Slot, member.Label, err)
+		}
+
+		toReturn.Members = append(toReturn.Members, &StorageMember{
+			Name:   member.Label,
+			Slot:   slot,
+			Offset: member.Offset,
+			Type:   memberType,
+		})
+	}
+
+	return toReturn, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/unpackdev/solgo/cfg"
 	"github.com/unpackdev/solgo/clients"
 	"github.com/unpackdev/solgo/detector"
+	"github.com/unpackdev/solgo/simulator"
 	"github.com/unpackdev/solgo/utils"
 )
 
@@ -18,7 +19,7 @@ type Storage struct {
 	network     utils.Network
 	clientsPool *clients.ClientPool
 	opts        *Options
-	//simulator   *simulator.Simulator
+	simulator   *simulator.Simulator
 }
 
 // NewStorage creates a new Storage instance. It requires context, network configuration, and various components
@@ -28,19 +29,23 @@ func NewStorage(
 	ctx context.Context,
 	network utils.Network,
 	pool *clients.ClientPool,
-	//simulator *simulator.Simulator,
+	simulator *simulator.Simulator,
 	opts *Options,
 ) (*Storage, error) {
 	if opts == nil {
 		return nil, fmt.Errorf("options cannot be nil")
 	}
 
+	if opts.UseSimulator && simulator == nil {
+		return nil, fmt.Errorf("simulator cannot be nil when UseSimulator is set")
+	}
+
 	return &Storage{
 		ctx:         ctx,
 		network:     network,
 		clientsPool: pool,
 		opts:        opts,
-		//simulator:   simulator,
+		simulator:   simulator,
 	}, nil
 }
 
@@ -55,11 +60,29 @@ func (s *Storage) DescribeLayout(ctx context.Context, addr common.Address, detec
 	return s._describe(ctx, addr, detector, cfgBuilder, atBlock, false)
 }
 
+// DescribeFromCFG queries and returns detailed information about a smart contract at a specific address, same as
+// Describe, but without a detector. The CFG builder can be built from any IR builder, including one created from
+// the AST produced by the solc compiler through ir.NewBuilderFromSolcJSON.
+func (s *Storage) DescribeFromCFG(ctx context.Context, addr common.Address, cfgBuilder *cfg.Builder, atBlock *big.Int) (*Reader, error) {
+	return s._describe(ctx, addr, nil, cfgBuilder, atBlock, true)
+}
+
+// DescribeLayoutFromCFG describes the storage layout of the contract without reading any of its values, same as
+// DescribeLayout, but without a detector.
+func (s *Storage) DescribeLayoutFromCFG(ctx context.Context, addr common.Address, cfgBuilder *cfg.Builder, atBlock *big.Int) (*Reader, error) {
+	return s._describe(ctx, addr, nil, cfgBuilder, atBlock, false)
+}
+
 // _describe is an internal method that performs the actual description process of a contract.
 // It constructs a Descriptor and utilizes a Reader to discover and calculate storage variables and layouts.
 func (s *Storage) _describe(ctx context.Context, addr common.Address, detector *detector.Detector, cfgBuilder *cfg.Builder, atBlock *big.Int, fetchValues bool) (*Reader, error) {
+	if cfgBuilder == nil {
+		return nil, fmt.Errorf("CFG builder is not available")
+	}
+
 	descriptor := &Descriptor{
 		Detector:          detector,
+		irBuilder:         cfgBuilder.GetIR(),
 		cfgBuilder:        cfgBuilder,
 		Address:           addr,
 		Block:             atBlock,
@@ -129,9 +152,15 @@ func (s *Storage) populateStorageValues(ctx context.Context, reader *Reader, add
 
 // getStorageValueAt retrieves the storage value at a given slot for a contract.
 func (s *Storage) getStorageValueAt(ctx context.Context, contractAddress common.Address, slot int64, blockNumber *big.Int) (*big.Int, []byte, error) {
-	client := s.clientsPool.GetClientByGroup(s.network.String())
-	if client == nil {
-		return blockNumber, nil, fmt.Errorf("no client found for network %s", s.network)
+	return s.getStorageValueAtPosition(ctx, contractAddress, common.BigToHash(big.NewInt(slot)), blockNumber)
+}
+
+// getStorageValueAtPosition retrieves the storage value at a given position for a contract. Positions of
+// mapping values and array elements are hashes and do not fit into a slot index.
+func (s *Storage) getStorageValueAtPosition(ctx context.Context, contractAddress common.Address, position common.Hash, blockNumber *big.Int) (*big.Int, []byte, error) {
+	client, err := s.getClient()
+	if err != nil {
+		return blockNumber, nil, err
 	}
 
 	if blockNumber == nil {
@@ -142,13 +171,24 @@ func (s *Storage) getStorageValueAt(ctx context.Context, contractAddress common.
 		blockNumber = latestHeader.Number()
 	}
 
-	bigIntIndex := big.NewInt(slot)
-	position := common.BigToHash(bigIntIndex)
-
 	response, err := client.StorageAt(ctx, contractAddress, position, blockNumber)
 	return blockNumber, response, err
 }
 
+// getClient returns the client storage is read with, the simulator client if the simulator is used.
+func (s *Storage) getClient() (*clients.Client, error) {
+	if s.opts.UseSimulator {
+		return s.simulator.GetClient(), nil
+	}
+
+	client := s.clientsPool.GetClientByGroup(s.network.String())
+	if client == nil {
+		return nil, fmt.Errorf("no client found for network %s", s.network)
+	}
+
+	return client, nil
+}
+
 // ReadStorageSlot reads the storage at a given slot for a specific contract.
 func (s *Storage) ReadStorageSlot(ctx context.Context, contractAddress common.Address, slot int64, blockNumber *big.Int) ([]byte, error) {
 	_, storageValue, err := s.getStorageValueAt(ctx, contractAddress, slot, blockNumber)
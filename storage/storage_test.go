@@ -16,6 +16,7 @@ import (
 	"github.com/unpackdev/solgo/clients"
 	"github.com/unpackdev/solgo/detector"
 	"github.com/unpackdev/solgo/providers/etherscan"
+	"github.com/unpackdev/solgo/simulator"
 	"github.com/unpackdev/solgo/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -65,7 +66,7 @@ func TestStorage(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	storage, err := NewStorage(ctx, utils.Ethereum, pool, NewDefaultOptions())
+	storage, err := NewStorage(ctx, utils.Ethereum, pool, nil, NewDefaultOptions())
 	tAssert.NoError(err)
 	require.NoError(t, err)
 
@@ -186,3 +187,30 @@ func TestStorage(t *testing.T) {
 	}
 
 }
+
+func TestStorageSimulator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := NewStorage(ctx, utils.AnvilNetwork, nil, nil, NewSimulatorOptions())
+	require.Error(t, err)
+
+	sim, err := simulator.NewSimulator(ctx, utils.AnvilNetwork, nil, simulator.NewDefaultOptions())
+	require.NoError(t, err)
+	defer sim.Close()
+
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.NoError(t, sim.SetCode(addr, common.FromHex("600054")))
+	require.NoError(t, sim.SetStorageAt(addr, common.BigToHash(big.NewInt(3)), common.BigToHash(big.NewInt(42))))
+
+	storage, err := NewStorage(ctx, utils.AnvilNetwork, nil, sim, NewSimulatorOptions())
+	require.NoError(t, err)
+
+	value, err := storage.ReadStorageSlot(ctx, addr, 3, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(42)).Bytes(), value)
+
+	value, err = storage.ReadStorageSlot(ctx, addr, 4, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}.Bytes(), value)
+}
@@ -0,0 +1,393 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/unpackdev/solgo/ir"
+)
+
+// TypeEncoding describes how values of a type are laid out in storage. The encodings follow the ones used by
+// solc in its storageLayout output.
+type TypeEncoding string
+
+const (
+	// EncodingInplace is used by value types, structs and static arrays whose data is stored at the slot itself.
+	EncodingInplace TypeEncoding = "inplace"
+	// EncodingMapping is used by mappings, whose values are stored at keccak256(key . slot).
+	EncodingMapping TypeEncoding = "mapping"
+	// EncodingDynamicArray is used by dynamic arrays, which store their length at the slot and their elements
+	// starting at keccak256(slot).
+	EncodingDynamicArray TypeEncoding = "dynamic_array"
+	// EncodingBytes is used by string and bytes, which are stored at the slot when shorter than 32 bytes and
+	// starting at keccak256(slot) otherwise.
+	EncodingBytes TypeEncoding = "bytes"
+)
+
+// StorageType describes how a Solidity type is stored.
+type StorageType struct {
+	Label         string           `json:"label"`             // Label is the type name, such as mapping(address => uint256).
+	Encoding      TypeEncoding     `json:"encoding"`          // Encoding is how values of the type are stored.
+	NumberOfBytes int64            `json:"number_of_bytes"`   // NumberOfBytes is the storage size of the type.
+	Key           *StorageType     `json:"key,omitempty"`     // Key is the key type of a mapping.
+	Value         *StorageType     `json:"value,omitempty"`   // Value is the value type of a mapping.
+	Base          *StorageType     `json:"base,omitempty"`    // Base is the element type of an array.
+	Length        int64            `json:"length,omitempty"`  // Length is the length of a static array.
+	Members       []*StorageMember `json:"members,omitempty"` // Members are the members of a struct.
+}
+
+// StorageMember describes a member of a struct and its position relative to the first slot of the struct.
+type StorageMember struct {
+	Name   string       `json:"name"`
+	Slot   int64        `json:"slot"`
+	Offset int64        `json:"offset"` // Offset is the byte offset of the member within its slot.
+	Type   *StorageType `json:"type"`
+}
+
+// IsMapping checks if the type is a mapping.
+func (t *StorageType) IsMapping() bool {
+	return t.Encoding == EncodingMapping
+}
+
+// IsDynamicArray checks if the type is a dynamic array.
+func (t *StorageType) IsDynamicArray() bool {
+	return t.Encoding == EncodingDynamicArray
+}
+
+// IsStaticArray checks if the type is an array with a length known at compile time.
+func (t *StorageType) IsStaticArray() bool {
+	return t.Encoding == EncodingInplace && t.Base != nil
+}
+
+// IsArray checks if the type is either a static or a dynamic array.
+func (t *StorageType) IsArray() bool {
+	return t.IsDynamicArray() || t.IsStaticArray()
+}
+
+// IsStruct checks if the type is a struct.
+func (t *StorageType) IsStruct() bool {
+	return strings.HasPrefix(t.Label, "struct ")
+}
+
+// IsValueType checks if the type is a value type, which are the only types that can share a slot.
+func (t *StorageType) IsValueType() bool {
+	return t.Encoding == EncodingInplace && t.Base == nil && !t.IsStruct()
+}
+
+// GetMember returns the struct member with the provided name or nil if the type has no such member.
+func (t *StorageType) GetMember(name string) *StorageMember {
+	for _, member := range t.Members {
+		if member.Name == name {
+			return member
+		}
+	}
+	return nil
+}
+
+// GetSlotCount returns the number of slots the type occupies at its position.
+func (t *StorageType) GetSlotCount() int64 {
+	return (t.NumberOfBytes + 31) / 32
+}
+
+// elementPosition returns the slot and byte offset of the array element at the index, relative to the first
+// slot of the array data. Value types of up to 16 bytes share slots, all other elements start a new slot.
+func (t *StorageType) elementPosition(index int64) (int64, int64) {
+	if t.Base.IsValueType() {
+		perSlot := 32 / t.Base.NumberOfBytes
+		return index / perSlot, (index % perSlot) * t.Base.NumberOfBytes
+	}
+	return index * t.Base.GetSlotCount(), 0
+}
+
+// typeResolver resolves Solidity type names into storage types. User defined types are looked up in the
+// contracts of the IR.
+type typeResolver struct {
+	structs   map[string]*ir.Struct
+	enums     map[string]*ir.Enum
+	contracts map[string]bool
+	cache     map[string]*StorageType
+}
+
+// newTypeResolver creates a type resolver aware of the structs, enums and contracts of the IR root. A nil
+// root resolves only elementary types, mappings and arrays of them.
+func newTypeResolver(root *ir.RootSourceUnit) *typeResolver {
+	resolver := &typeResolver{
+		structs:   make(map[string]*ir.Struct),
+		enums:     make(map[string]*ir.Enum),
+		contracts: make(map[string]bool),
+		cache:     make(map[string]*StorageType),
+	}
+
+	if root == nil {
+		return resolver
+	}
+
+	for _, contract := range root.GetContracts() {
+		resolver.contracts[contract.GetName()] = true
+		for _, structDef := range contract.GetStructs() {
+			resolver.structs[structDef.GetName()] = structDef
+			resolver.structs[structDef.GetCanonicalName()] = structDef
+		}
+		for _, enumDef := range contract.GetEnums() {
+			resolver.enums[enumDef.GetName()] = enumDef
+			resolver.enums[enumDef.GetCanonicalName()] = enumDef
+		}
+	}
+
+	return resolver
+}
+
+// resolve parses the Solidity type name, either as produced by solgo or by solc, into its storage type.
+func (r *typeResolver) resolve(typeName string) (*StorageType, error) {
+	typeName = normalizeTypeName(typeName)
+	if cached, ok := r.cache[typeName]; ok {
+		return cached, nil
+	}
+
+	switch {
+	case strings.HasPrefix(typeName, "mapping(") && strings.HasSuffix(typeName, ")"):
+		return r.resolveMapping(typeName)
+	case strings.HasSuffix(typeName, "]"):
+		return r.resolveArray(typeName)
+	case strings.HasPrefix(typeName, "struct "):
+		return r.resolveStruct(strings.TrimPrefix(typeName, "struct "))
+	case strings.HasPrefix(typeName, "enum "):
+		return r.resolveEnum(strings.TrimPrefix(typeName, "enum "))
+	case strings.HasPrefix(typeName, "contract "), strings.HasPrefix(typeName, "interface "):
+		return &StorageType{Label: typeName, Encoding: EncodingInplace, NumberOfBytes: 20}, nil
+	}
+
+	if elementary, ok := elementaryStorageType(typeName); ok {
+		return elementary, nil
+	}
+
+	// Mapping values and struct members refer to user defined types by their name alone.
+	switch {
+	case r.structs[typeName] != nil:
+		return r.resolveStruct(typeName)
+	case r.enums[typeName] != nil:
+		return r.resolveEnum(typeName)
+	case r.contracts[typeName]:
+		return &StorageType{Label: "contract " + typeName, Encoding: EncodingInplace, NumberOfBytes: 20}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported storage type: %s", typeName)
+}
+
+// resolveMapping resolves the key and value types of a mapping.
+func (r *typeResolver) resolveMapping(typeName string) (*StorageType, error) {
+	inner := typeName[len("mapping(") : len(typeName)-1]
+	separator := topLevelIndex(inner, "=>")
+	if separator < 0 {
+		return nil, fmt.Errorf("invalid mapping type: %s", typeName)
+	}
+
+	key, err := r.resolve(inner[:separator])
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.IsValueType() && key.Encoding != EncodingBytes {
+		return nil, fmt.Errorf("invalid mapping key type: %s", key.Label)
+	}
+
+	value, err := r.resolve(inner[separator+2:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageType{
+		Label:         fmt.Sprintf("mapping(%s => %s)", key.Label, value.Label),
+		Encoding:      EncodingMapping,
+		NumberOfBytes: 32,
+		Key:           key,
+		Value:         value,
+	}, nil
+}
+
+// resolveArray resolves the element type of an array and, for static arrays, the storage it occupies.
+func (r *typeResolver) resolveArray(typeName string) (*StorageType, error) {
+	open := -1
+	depth := 0
+	for i := len(typeName) - 1; i >= 0; i-- {
+		if typeName[i] == ']' {
+			depth++
+		} else if typeName[i] == '[' {
+			depth--
+			if depth == 0 {
+				open = i
+				break
+			}
+		}
+	}
+
+	if open <= 0 {
+		return nil, fmt.Errorf("invalid array type: %s", typeName)
+	}
+
+	base, err := r.resolve(typeName[:open])
+	if err != nil {
+		return nil, err
+	}
+
+	lengthStr := strings.TrimSpace(typeName[open+1 : len(typeName)-1])
+	if lengthStr == "" {
+		return &StorageType{
+			Label:         base.Label + "[]",
+			Encoding:      EncodingDynamicArray,
+			NumberOfBytes: 32,
+			Base:          base,
+		}, nil
+	}
+
+	length, err := strconv.ParseInt(lengthStr, 0, 64)
+	if err != nil || length < 1 {
+		return nil, fmt.Errorf("unsupported array length %q in type: %s", lengthStr, typeName)
+	}
+
+	toReturn := &StorageType{
+		Label:    fmt.Sprintf("%s[%d]", base.Label, length),
+		Encoding: EncodingInplace,
+		Base:     base,
+		Length:   length,
+	}
+
+	lastSlot, _ := toReturn.elementPosition(length - 1)
+	toReturn.NumberOfBytes = (lastSlot + base.GetSlotCount()) * 32
+
+	return toReturn, nil
+}
+
+// resolveStruct resolves the members of a struct and lays them out.
+func (r *typeResolver) resolveStruct(name string) (*StorageType, error) {
+	structDef := r.structs[name]
+	if structDef == nil {
+		return nil, fmt.Errorf("struct definition not found: %s", name)
+	}
+
+	label := "struct " + structDef.GetCanonicalName()
+	if cached, ok := r.cache[label]; ok {
+		return cached, nil
+	}
+
+	// The type is cached before its members are resolved, as members can refer to the struct itself
+	// through mappings and dynamic arrays.
+	toReturn := &StorageType{Label: label, Encoding: EncodingInplace}
+	r.cache[label] = toReturn
+
+	slot, offset := int64(0), int64(0)
+	for _, member := range structDef.GetMembers() {
+		typeName := member.GetType()
+		if description := member.GetTypeDescription(); description != nil && description.GetString() != "" {
+			typeName = description.GetString()
+		}
+
+		memberType, err := r.resolve(typeName)
+		if err != nil {
+			delete(r.cache, label)
+			return nil, fmt.Errorf("failed to resolve member %s of struct %s: %w", member.GetName(), name, err)
+		}
+
+		// Value types share a slot while they fit, everything else starts and ends a slot.
+		if offset > 0 && (!memberType.IsValueType() || offset+memberType.NumberOfBytes > 32) {
+			slot++
+			offset = 0
+		}
+
+		toReturn.Members = append(toReturn.Members, &StorageMember{
+			Name:   member.GetName(),
+			Slot:   slot,
+			Offset: offset,
+			Type:   memberType,
+		})
+
+		if memberType.IsValueType() {
+			offset += memberType.NumberOfBytes
+		} else {
+			slot += memberType.GetSlotCount()
+		}
+	}
+
+	if offset > 0 {
+		slot++
+	}
+	toReturn.NumberOfBytes = slot * 32
+
+	return toReturn, nil
+}
+
+// resolveEnum resolves an enum into the smallest unsigned integer able to hold its members.
+func (r *typeResolver) resolveEnum(name string) (*StorageType, error) {
+	size := int64(1)
+	label := "enum " + name
+	if enumDef := r.enums[name]; enumDef != nil {
+		label = "enum " + enumDef.GetCanonicalName()
+		for members := len(enumDef.GetMembers()) - 1; members > 255; members >>= 8 {
+			size++
+		}
+	}
+
+	return &StorageType{Label: label, Encoding: EncodingInplace, NumberOfBytes: size}, nil
+}
+
+// elementaryStorageType returns the storage type of elementary Solidity types.
+func elementaryStorageType(typeName string) (*StorageType, bool) {
+	switch typeName {
+	case "bool":
+		return &StorageType{Label: "bool", Encoding: EncodingInplace, NumberOfBytes: 1}, true
+	case "address", "address payable":
+		return &StorageType{Label: typeName, Encoding: EncodingInplace, NumberOfBytes: 20}, true
+	case "string", "bytes":
+		return &StorageType{Label: typeName, Encoding: EncodingBytes, NumberOfBytes: 32}, true
+	case "byte":
+		return &StorageType{Label: "bytes1", Encoding: EncodingInplace, NumberOfBytes: 1}, true
+	case "uint", "int":
+		return &StorageType{Label: typeName + "256", Encoding: EncodingInplace, NumberOfBytes: 32}, true
+	}
+
+	for _, prefix := range []string{"uint", "int"} {
+		if bits, err := strconv.Atoi(strings.TrimPrefix(typeName, prefix)); err == nil && strings.HasPrefix(typeName, prefix) {
+			if bits < 8 || bits > 256 || bits%8 != 0 {
+				return nil, false
+			}
+			return &StorageType{Label: typeName, Encoding: EncodingInplace, NumberOfBytes: int64(bits / 8)}, true
+		}
+	}
+
+	if size, err := strconv.Atoi(strings.TrimPrefix(typeName, "bytes")); err == nil && strings.HasPrefix(typeName, "bytes") {
+		if size < 1 || size > 32 {
+			return nil, false
+		}
+		return &StorageType{Label: typeName, Encoding: EncodingInplace, NumberOfBytes: int64(size)}, true
+	}
+
+	return nil, false
+}
+
+// normalizeTypeName removes data locations and surrounding whitespace from the type name.
+func normalizeTypeName(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+	for _, suffix := range []string{" storage ref", " storage pointer", " storage", " memory", " calldata"} {
+		typeName = strings.TrimSpace(strings.TrimSuffix(typeName, suffix))
+	}
+	return typeName
+}
+
+// topLevelIndex returns the index of the first occurrence of sep outside of parentheses and brackets.
+func topLevelIndex(s string, sep string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(s[i:], sep) {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -15,6 +15,7 @@ type Variable struct {
 	*ir.StateVariable `json:"state_variable"` // StateVariable is the underlying state variable from the IR.
 	Contract          *ir.Contract            `json:"contract"`       // Contract is the contract to which this variable belongs.
 	EntryContract     bool                    `json:"entry_contract"` // EntryContract indicates if this variable is part of the entry contract.
+	storageType       *StorageType            // storageType is the resolved storage type, set while calculating the layout.
 }
 
 // IsAddressType checks if the variable is of an address type.
@@ -0,0 +1,231 @@
+package syntaxerrors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// punctuation maps the symbolic names of punctuation tokens without a literal name in the grammar to the
+// literal they stand for, so expected tokens read the same way as in the messages.
+var punctuation = map[string]string{
+	"Semicolon": "';'",
+	"Comma":     "','",
+	"Period":    "'.'",
+	"Colon":     "':'",
+	"LParen":    "'('",
+	"RParen":    "')'",
+	"LBrace":    "'{'",
+	"RBrace":    "'}'",
+	"LBrack":    "'['",
+	"RBrack":    "']'",
+}
+
+// newDiagnostic builds the structured diagnostic of the syntax error reported by the recognizer. Errors of
+// the parser are located by their offending token, while errors of the lexer have no token and are located
+// by the line and column they are reported at.
+func (l *SyntaxErrorListener) newDiagnostic(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) *Diagnostic {
+	toReturn := &Diagnostic{
+		Code:     classify(recognizer, msg, e),
+		Severity: l.DetermineSeverity(msg, l.currentContext()),
+		Message:  msg,
+		Context:  l.currentContext(),
+	}
+
+	source := l.sourceOf(recognizer)
+	if source == nil {
+		toReturn.Range = Range{Line: line, Column: column, EndLine: line, EndColumn: column}
+		return toReturn
+	}
+
+	token, _ := offendingSymbol.(antlr.Token)
+	start := source.lineOffset(line, column)
+	end := min(start+1, len(source.text))
+	if token != nil {
+		start = source.byteOffset(token.GetStart())
+		end = start
+		if token.GetTokenType() != antlr.TokenEOF && token.GetStop() >= token.GetStart() {
+			end = source.byteOffset(token.GetStop() + 1)
+		}
+
+		toReturn.OffendingToken = token.GetText()
+	}
+
+	toReturn.Range = source.rangeOf(start, end)
+	toReturn.Snippet = source.snippet(toReturn.Range)
+
+	if p, ok := recognizer.(antlr.Parser); ok && token != nil {
+		toReturn.Expected = expectedTokens(p)
+		refine(toReturn, p, token, source)
+	}
+
+	return toReturn
+}
+
+// sourceOf returns the text of the input the recognizer reads from, reusing it between errors.
+func (l *SyntaxErrorListener) sourceOf(recognizer antlr.Recognizer) *sourceText {
+	var input antlr.CharStream
+	switch recognizer := recognizer.(type) {
+	case antlr.Parser:
+		if stream := recognizer.GetTokenStream(); stream != nil && stream.GetTokenSource() != nil {
+			input = stream.GetTokenSource().GetInputStream()
+		}
+	case antlr.Lexer:
+		input = recognizer.GetInputStream()
+	}
+
+	if input == nil {
+		return nil
+	}
+
+	if l.source == nil || l.source.input != input {
+		l.source = newSourceText(input, l.files)
+	}
+
+	return l.source
+}
+
+// classify returns the code of the error based on the exception raised by the recognizer or, when errors
+// are reported during the recovery without an exception, on the message.
+func classify(recognizer antlr.Recognizer, msg string, e antlr.RecognitionException) Code {
+	if _, ok := recognizer.(antlr.Lexer); ok {
+		return CodeTokenRecognition
+	}
+
+	switch e.(type) {
+	case *antlr.InputMisMatchException:
+		return CodeMismatchedInput
+	case *antlr.NoViableAltException:
+		return CodeNoViableAlternative
+	case *antlr.LexerNoViableAltException:
+		return CodeTokenRecognition
+	}
+
+	switch {
+	case strings.HasPrefix(msg, "missing "):
+		return CodeMissingToken
+	case strings.HasPrefix(msg, "extraneous input"):
+		return CodeExtraneousInput
+	case strings.HasPrefix(msg, "mismatched input"):
+		return CodeMismatchedInput
+	case strings.HasPrefix(msg, "no viable alternative"):
+		return CodeNoViableAlternative
+	case strings.HasPrefix(msg, "token recognition error"):
+		return CodeTokenRecognition
+	}
+
+	return CodeSyntaxError
+}
+
+// refine narrows the code of the diagnostic down to the errors that can be fixed automatically and
+// attaches the fixes resolving them.
+func refine(diagnostic *Diagnostic, p antlr.Parser, token antlr.Token, source *sourceText) {
+	text := token.GetText()
+	start := source.byteOffset(token.GetStart())
+	previous := p.GetTokenStream().LT(-1)
+	if previous == token {
+		previous = nil
+	}
+
+	switch {
+	case (diagnostic.Code == CodeMismatchedInput || diagnostic.Code == CodeExtraneousInput || diagnostic.Code == CodeNoViableAlternative) &&
+		isKeyword(text, p.GetLiteralNames()) && contains(diagnostic.Expected, "Identifier"):
+		diagnostic.Code = CodeReservedKeyword
+		diagnostic.Fixes = []Fix{{
+			Description: fmt.Sprintf("rename '%s' to '%s_'", text, text),
+			Edits:       []TextEdit{{Range: diagnostic.Range, NewText: text + "_"}},
+		}}
+
+	case diagnostic.Code == CodeExtraneousInput && text == "}" && source.braceBalance(source.file(start)) < 0:
+		diagnostic.Code = CodeUnbalancedBraces
+		diagnostic.Fixes = []Fix{{
+			Description: "remove the unmatched '}'",
+			Edits:       []TextEdit{{Range: diagnostic.Range}},
+		}}
+
+	case token.GetTokenType() == antlr.TokenEOF && contains(diagnostic.Expected, "'}'"):
+		diagnostic.Code = CodeUnbalancedBraces
+		missing := max(source.braceBalance(source.file(start)), 1)
+		diagnostic.Fixes = []Fix{{
+			Description: fmt.Sprintf("insert %d missing '}' at the end of the file", missing),
+			Edits:       []TextEdit{{Range: source.endOfFile(start), NewText: strings.Repeat("}", missing)}},
+		}}
+
+	case (diagnostic.Code == CodeMissingToken && strings.HasPrefix(diagnostic.Message, "missing ';'")) ||
+		((diagnostic.Code == CodeMismatchedInput || diagnostic.Code == CodeNoViableAlternative) &&
+			contains(diagnostic.Expected, "';'") && previous != nil && previous.GetLine() < token.GetLine()):
+		diagnostic.Code = CodeMissingSemicolon
+
+		// The semicolon belongs right after the statement, not in front of the token on the next line.
+		at := start
+		if previous != nil && previous.GetTokenType() != antlr.TokenEOF {
+			at = source.byteOffset(previous.GetStop() + 1)
+		}
+		diagnostic.Fixes = []Fix{{
+			Description: "insert ';'",
+			Edits:       []TextEdit{{Range: source.rangeOf(at, at), NewText: ";"}},
+		}}
+	}
+}
+
+// expectedTokens returns the names of the tokens the parser expected at the point of the error, in the
+// notation ANTLR uses in its messages, with punctuation written as the literal it stands for.
+func expectedTokens(p antlr.Parser) (toReturn []string) {
+	// Computing the expected tokens walks the ATN from the current state, which is not guaranteed to be
+	// consistent once the error recovery has kicked in, so a failure only leaves the list empty.
+	defer func() {
+		if recover() != nil {
+			toReturn = nil
+		}
+	}()
+
+	expected := p.GetExpectedTokens()
+	if expected == nil {
+		return nil
+	}
+
+	literalNames := p.GetLiteralNames()
+	symbolicNames := p.GetSymbolicNames()
+	for _, interval := range expected.GetIntervals() {
+		for tokenType := interval.Start; tokenType < interval.Stop; tokenType++ {
+			switch {
+			case tokenType == antlr.TokenEOF:
+				toReturn = append(toReturn, "<EOF>")
+			case tokenType < len(literalNames) && literalNames[tokenType] != "":
+				toReturn = append(toReturn, literalNames[tokenType])
+			case tokenType >= 0 && tokenType < len(symbolicNames) && punctuation[symbolicNames[tokenType]] != "":
+				toReturn = append(toReturn, punctuation[symbolicNames[tokenType]])
+			case tokenType >= 0 && tokenType < len(symbolicNames) && symbolicNames[tokenType] != "":
+				toReturn = append(toReturn, symbolicNames[tokenType])
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// isKeyword reports whether the text is a keyword of the language, i.e. a word with a literal token.
+func isKeyword(text string, literalNames []string) bool {
+	if text == "" {
+		return false
+	}
+
+	for _, char := range text {
+		if (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') {
+			return false
+		}
+	}
+
+	return contains(literalNames, "'"+text+"'")
+}
+
+// contains reports whether the value is in the list.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package syntaxerrors
+
+// Code is a stable identifier of the kind of a diagnostic. Once released, a code never changes its
+// meaning, so it can be relied upon to filter, suppress or document diagnostics.
+type Code string
+
+const (
+	// CodeSyntaxError is a syntax error not covered by any of the more specific codes.
+	CodeSyntaxError Code = "SG1000"
+
+	// CodeTokenRecognition is reported by the lexer for characters no token can be made of.
+	CodeTokenRecognition Code = "SG1001"
+
+	// CodeMissingToken is reported when a token is missing and the parser recovered by assuming it.
+	CodeMissingToken Code = "SG1002"
+
+	// CodeMismatchedInput is reported when the input does not match any of the expected tokens.
+	CodeMismatchedInput Code = "SG1003"
+
+	// CodeExtraneousInput is reported when the parser recovered by skipping an unexpected token.
+	CodeExtraneousInput Code = "SG1004"
+
+	// CodeNoViableAlternative is reported when the input cannot be parsed as any alternative of a rule.
+	CodeNoViableAlternative Code = "SG1005"
+
+	// CodeMissingSemicolon is reported when a statement or declaration is not terminated with a semicolon.
+	CodeMissingSemicolon Code = "SG2001"
+
+	// CodeUnbalancedBraces is reported when opening and closing braces do not pair up.
+	CodeUnbalancedBraces Code = "SG2002"
+
+	// CodeReservedKeyword is reported when a reserved keyword is used where an identifier is expected.
+	CodeReservedKeyword Code = "SG2003"
+)
+
+// codeNames maps codes to the names of the rules they stand for, as exported to SARIF.
+var codeNames = map[Code]string{
+	CodeSyntaxError:         "SyntaxError",
+	CodeTokenRecognition:    "TokenRecognition",
+	CodeMissingToken:        "MissingToken",
+	CodeMismatchedInput:     "MismatchedInput",
+	CodeExtraneousInput:     "ExtraneousInput",
+	CodeNoViableAlternative: "NoViableAlternative",
+	CodeMissingSemicolon:    "MissingSemicolon",
+	CodeUnbalancedBraces:    "UnbalancedBraces",
+	CodeReservedKeyword:     "ReservedKeyword",
+}
+
+// codeDescriptions maps codes to the short description of the rules they stand for.
+var codeDescriptions = map[Code]string{
+	CodeSyntaxError:         "The source code is not valid Solidity.",
+	CodeTokenRecognition:    "The source code contains characters that do not form a valid token.",
+	CodeMissingToken:        "A required token is missing.",
+	CodeMismatchedInput:     "The input does not match any of the expected tokens.",
+	CodeExtraneousInput:     "The input contains an unexpected token.",
+	CodeNoViableAlternative: "The input cannot be parsed as any valid construct.",
+	CodeMissingSemicolon:    "A statement or declaration is not terminated with a semicolon.",
+	CodeUnbalancedBraces:    "Opening and closing braces do not pair up.",
+	CodeReservedKeyword:     "A reserved keyword is used as an identifier.",
+}
+
+// String returns the code itself.
+func (c Code) String() string {
+	return string(c)
+}
+
+// Name returns the name of the rule the code stands for.
+func (c Code) Name() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return codeNames[CodeSyntaxError]
+}
+
+// Description returns the short description of the rule the code stands for.
+func (c Code) Description() string {
+	if description, ok := codeDescriptions[c]; ok {
+		return description
+	}
+	return codeDescriptions[CodeSyntaxError]
+}
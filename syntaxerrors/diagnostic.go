@@ -0,0 +1,155 @@
+package syntaxerrors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// SourceFile describes a file that is part of the parsed input. Offset and Length are in bytes and locate
+// the content of the file within the input, which may be a concatenation of several files.
+type SourceFile struct {
+	Path   string
+	Offset int
+	Length int
+}
+
+// Range represents a span of source code within a file. Start and End are byte offsets relative to the
+// start of the file, with End being exclusive. Lines are 1-based and columns are 0-based character offsets
+// within the line, matching the positions reported by the parser.
+type Range struct {
+	File      string `json:"file,omitempty"`
+	Start     int    `json:"start"`
+	End       int    `json:"end"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+}
+
+// TextEdit replaces the source code within the range with the new text. Edits with an empty range insert
+// the text, while edits with an empty text delete the source code within the range.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// Fix represents a machine-applicable suggestion that resolves a diagnostic.
+type Fix struct {
+	Description string     `json:"description"`
+	Edits       []TextEdit `json:"edits"`
+}
+
+// Apply applies the edits of the fix to the content of the file they belong to and returns the result.
+func (f Fix) Apply(content string) (string, error) {
+	edits := make([]TextEdit, len(f.Edits))
+	copy(edits, f.Edits)
+
+	// Edits are applied from the end of the file so offsets of the remaining ones stay valid.
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Range.Start > edits[j].Range.Start
+	})
+
+	for i, edit := range edits {
+		if edit.Range.Start < 0 || edit.Range.Start > edit.Range.End || edit.Range.End > len(content) {
+			return "", fmt.Errorf("edit range %d-%d is out of bounds", edit.Range.Start, edit.Range.End)
+		}
+		if i > 0 && edit.Range.End > edits[i-1].Range.Start {
+			return "", fmt.Errorf("edit range %d-%d overlaps with another edit", edit.Range.Start, edit.Range.End)
+		}
+		content = content[:edit.Range.Start] + edit.NewText + content[edit.Range.End:]
+	}
+
+	return content, nil
+}
+
+// Diagnostic represents a syntax error in a structured form. Besides the message, it carries a stable code,
+// the exact location of the error, the offending source snippet, the tokens the parser expected and fixes
+// that can be applied to resolve the error.
+type Diagnostic struct {
+	Code           Code          `json:"code"`
+	Severity       SeverityLevel `json:"severity"`
+	Message        string        `json:"message"`
+	Context        string        `json:"context,omitempty"`
+	Range          Range         `json:"range"`
+	OffendingToken string        `json:"offendingToken,omitempty"`
+	Expected       []string      `json:"expected,omitempty"`
+	Snippet        string        `json:"snippet,omitempty"`
+	Fixes          []Fix         `json:"fixes,omitempty"`
+}
+
+// MarshalJSON encodes the diagnostic with its severity written as a string.
+func (d *Diagnostic) MarshalJSON() ([]byte, error) {
+	type diagnostic Diagnostic
+	return json.Marshal(&struct {
+		*diagnostic
+		Severity string `json:"severity"`
+	}{
+		diagnostic: (*diagnostic)(d),
+		Severity:   d.Severity.String(),
+	})
+}
+
+// Error returns a single line compiler-style description of the diagnostic.
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s[%s]: %s", d.fileName(), d.Range.Line, d.Range.Column+1, d.Severity.String(), d.Code, d.Message)
+}
+
+// String returns the compiler-style text representation of the diagnostic, including the source snippet,
+// the expected tokens and the suggested fixes.
+func (d *Diagnostic) String() string {
+	var builder strings.Builder
+	builder.WriteString(d.Error())
+	builder.WriteString("\n")
+
+	if d.Snippet != "" {
+		gutter := fmt.Sprintf("%d", d.Range.Line)
+		for i, line := range strings.Split(d.Snippet, "\n") {
+			if i == 0 {
+				fmt.Fprintf(&builder, " %s | %s\n", gutter, line)
+			} else {
+				fmt.Fprintf(&builder, " %s | %s\n", strings.Repeat(" ", len(gutter)), line)
+			}
+		}
+	}
+
+	if len(d.Expected) > 0 {
+		fmt.Fprintf(&builder, " = expected: %s\n", strings.Join(d.Expected, ", "))
+	}
+
+	for _, fix := range d.Fixes {
+		fmt.Fprintf(&builder, " = fix: %s\n", fix.Description)
+	}
+
+	return builder.String()
+}
+
+// fileName returns the name of the file the diagnostic belongs to, as used in the text representation.
+func (d *Diagnostic) fileName() string {
+	if d.Range.File == "" {
+		return "<input>"
+	}
+	return d.Range.File
+}
+
+// Diagnostics is a list of diagnostics in the order they were reported.
+type Diagnostics []*Diagnostic
+
+// ToText returns the compiler-style text representation of the diagnostics.
+func (d Diagnostics) ToText() string {
+	var builder strings.Builder
+	for i, diagnostic := range d {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(diagnostic.String())
+	}
+	return builder.String()
+}
+
+// ToJSON returns the diagnostics encoded as JSON.
+func (d Diagnostics) ToJSON() ([]byte, error) {
+	return json.Marshal(d)
+}
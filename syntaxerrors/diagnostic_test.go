@@ -0,0 +1,267 @@
+package syntaxerrors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/parser"
+	"github.com/unpackdev/solgo/tests"
+)
+
+// diagnose parses the contract and returns the listener holding its diagnostics.
+func diagnose(contract string, files ...SourceFile) *SyntaxErrorListener {
+	listener := NewSyntaxErrorListener()
+	listener.SetSourceFiles(files...)
+
+	lexer := parser.NewSolidityLexer(antlr.NewInputStream(contract))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+
+	NewContextualParser(antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel), listener).SourceUnit()
+	return listener
+}
+
+func TestDiagnostics(t *testing.T) {
+	buggyContract := tests.ReadContractFileForTest(t, "BuggyContract").Content
+
+	testCases := []struct {
+		name     string
+		contract string
+		codes    []Code
+		fixed    string
+	}{
+		{
+			name:     "Randomly Corrupted Contract",
+			contract: buggyContract,
+			codes: []Code{
+				CodeMissingSemicolon,
+				CodeMismatchedInput,
+				CodeMismatchedInput,
+				CodeExtraneousInput,
+				CodeMissingSemicolon,
+				CodeExtraneousInput,
+			},
+			fixed: strings.Replace(strings.Replace(buggyContract, "count += 1\n", "count += 1;\n", 1), "return count\n", "return count;\n", 1),
+		},
+		{
+			name:     "Missing Semicolon Before Next Statement",
+			contract: "contract A {\n    function f() public {\n        uint x = 1\n        x = 2;\n    }\n}\n",
+			codes:    []Code{CodeMissingSemicolon},
+			fixed:    "contract A {\n    function f() public {\n        uint x = 1;\n        x = 2;\n    }\n}\n",
+		},
+		{
+			name:     "Missing Closing Braces",
+			contract: "contract A {\n    function f() public {\n        uint x = 1;\n",
+			codes:    []Code{CodeUnbalancedBraces},
+			fixed:    "contract A {\n    function f() public {\n        uint x = 1;\n}}",
+		},
+		{
+			name:     "Unmatched Closing Brace",
+			contract: "contract A {}\n}\n",
+			codes:    []Code{CodeUnbalancedBraces},
+			fixed:    "contract A {}\n\n",
+		},
+		{
+			name:     "Reserved Keyword As Identifier",
+			contract: "contract A {\n    uint public contract;\n}\n",
+			codes:    []Code{CodeReservedKeyword, CodeMismatchedInput},
+			fixed:    "contract A {\n    uint public contract_;\n}\n",
+		},
+		{
+			name:     "Unknown Character",
+			contract: "contract A {\n    uint x = 1 # 2;\n}\n",
+			codes:    []Code{CodeTokenRecognition, CodeExtraneousInput},
+			fixed:    "contract A {\n    uint x = 1 # 2;\n}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listener := diagnose(testCase.contract)
+			require.Len(t, listener.GetDiagnostics(), len(listener.Errors))
+
+			codes := make([]Code, 0)
+			fix := Fix{}
+			for i, diagnostic := range listener.GetDiagnostics() {
+				codes = append(codes, diagnostic.Code)
+
+				// Diagnostics describe the very same errors as the plain syntax errors.
+				assert.Equal(t, listener.Errors[i].Message, diagnostic.Message)
+				assert.Equal(t, listener.Errors[i].Severity, diagnostic.Severity)
+				assert.Equal(t, listener.Errors[i].Line, diagnostic.Range.Line)
+				assert.Equal(t, listener.Errors[i].Column, diagnostic.Range.Column)
+				assert.NotEmpty(t, diagnostic.Snippet)
+
+				for _, diagnosticFix := range diagnostic.Fixes {
+					fix.Edits = append(fix.Edits, diagnosticFix.Edits...)
+				}
+			}
+			assert.Equal(t, testCase.codes, codes)
+
+			fixed, err := fix.Apply(testCase.contract)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.fixed, fixed)
+
+			// Sources without remaining errors parse cleanly once fixed.
+			if testCase.contract != buggyContract && testCase.fixed != testCase.contract {
+				assert.Empty(t, diagnose(fixed).GetDiagnostics())
+			}
+		})
+	}
+}
+
+func TestDiagnosticDetails(t *testing.T) {
+	listener := diagnose("contract A {\n    uint public contract;\n}\n")
+	require.NotEmpty(t, listener.GetDiagnostics())
+
+	diagnostic := listener.GetDiagnostics()[0]
+	assert.Equal(t, CodeReservedKeyword, diagnostic.Code)
+	assert.Equal(t, "contract", diagnostic.OffendingToken)
+	assert.Equal(t, Range{Start: 29, End: 37, Line: 2, Column: 16, EndLine: 2, EndColumn: 24}, diagnostic.Range)
+	assert.Equal(t, "    uint public contract;\n                ^~~~~~~~", diagnostic.Snippet)
+	assert.Contains(t, diagnostic.Expected, "Identifier")
+	assert.Equal(t, []Fix{{
+		Description: "rename 'contract' to 'contract_'",
+		Edits:       []TextEdit{{Range: diagnostic.Range, NewText: "contract_"}},
+	}}, diagnostic.Fixes)
+
+	// Expected tokens use literals for punctuation, the same way the messages do.
+	listener = diagnose("contract A {\n    function f() public {\n        uint x = 1\n        x = 2;\n    }\n}\n")
+	require.Len(t, listener.GetDiagnostics(), 1)
+	assert.Equal(t, []string{"';'"}, listener.GetDiagnostics()[0].Expected)
+	assert.Equal(t, Range{Start: 57, End: 57, Line: 3, Column: 18, EndLine: 3, EndColumn: 18}, listener.GetDiagnostics()[0].Fixes[0].Edits[0].Range)
+}
+
+func TestDiagnosticsSourceFiles(t *testing.T) {
+	first := "contract A {}"
+	second := "contract B {\n    uint x = 1\n}\n"
+
+	listener := diagnose(first+"\n\n"+second,
+		SourceFile{Path: "A.sol", Offset: 0, Length: len(first)},
+		SourceFile{Path: "B.sol", Offset: len(first) + 2, Length: len(second)},
+	)
+	require.Len(t, listener.GetDiagnostics(), 1)
+
+	diagnostic := listener.GetDiagnostics()[0]
+	assert.Equal(t, CodeMissingSemicolon, diagnostic.Code)
+	assert.Equal(t, "B.sol", diagnostic.Range.File)
+	assert.Equal(t, 3, diagnostic.Range.Line)
+	assert.Equal(t, 0, diagnostic.Range.Column)
+
+	// Fixes are relative to the file, so they apply to its content alone.
+	fixed, err := diagnostic.Fixes[0].Apply(second)
+	require.NoError(t, err)
+	assert.Equal(t, "contract B {\n    uint x = 1;\n}\n", fixed)
+}
+
+func TestDiagnosticsToText(t *testing.T) {
+	contract := "contract A {\n    uint x = 1\n}\n"
+	listener := diagnose(contract, SourceFile{Path: "A.sol", Length: len(contract)})
+
+	assert.Equal(t, "A.sol:3:1: error[SG2001]: missing ';' at '}'\n"+
+		" 3 | }\n"+
+		"   | ^\n"+
+		" = expected: ';'\n"+
+		" = fix: insert ';'\n", listener.GetDiagnostics().ToText())
+	assert.Equal(t, "A.sol:3:1: error[SG2001]: missing ';' at '}'", listener.GetDiagnostics()[0].Error())
+
+	assert.Equal(t, "<input>:3:1: error[SG2001]: missing ';' at '}'", diagnose(contract).GetDiagnostics()[0].Error())
+}
+
+func TestDiagnosticsToJSON(t *testing.T) {
+	listener := diagnose("contract A {\n    uint x = 1\n}\n")
+
+	data, err := listener.GetDiagnostics().ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"code": "SG2001",
+		"severity": "error",
+		"message": "missing ';' at '}'",
+		"context": "SourceUnit",
+		"range": {"start": 28, "end": 29, "line": 3, "column": 0, "endLine": 3, "endColumn": 1},
+		"offendingToken": "}",
+		"expected": ["';'"],
+		"snippet": "}\n^",
+		"fixes": [{
+			"description": "insert ';'",
+			"edits": [{
+				"range": {"start": 27, "end": 27, "line": 2, "column": 14, "endLine": 2, "endColumn": 14},
+				"newText": ";"
+			}]
+		}]
+	}]`, string(data))
+}
+
+func TestDiagnosticsToSARIF(t *testing.T) {
+	contract := "contract A {\n    uint x = 1\n    uint public contract;\n}\n"
+	listener := diagnose(contract, SourceFile{Path: "contracts/A.sol", Length: len(contract)})
+
+	data, err := listener.GetDiagnostics().ToSARIF()
+	require.NoError(t, err)
+
+	var log map[string]any
+	require.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, "2.1.0", log["version"])
+
+	runs := log["runs"].([]any)
+	require.Len(t, runs, 1)
+	run := runs[0].(map[string]any)
+
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	assert.Equal(t, "solgo", driver["name"])
+
+	ruleIds := make([]string, 0)
+	for _, rule := range driver["rules"].([]any) {
+		ruleIds = append(ruleIds, rule.(map[string]any)["id"].(string))
+	}
+
+	results := run["results"].([]any)
+	require.Len(t, results, len(listener.GetDiagnostics()))
+
+	for i, result := range results {
+		result := result.(map[string]any)
+		diagnostic := listener.GetDiagnostics()[i]
+
+		assert.Equal(t, diagnostic.Code.String(), result["ruleId"])
+		assert.Equal(t, diagnostic.Code.String(), ruleIds[int(result["ruleIndex"].(float64))])
+		assert.Equal(t, "error", result["level"])
+
+		location := result["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+		assert.Equal(t, "contracts/A.sol", location["artifactLocation"].(map[string]any)["uri"])
+
+		region := location["region"].(map[string]any)
+		assert.Equal(t, float64(diagnostic.Range.Line), region["startLine"])
+		assert.Equal(t, float64(diagnostic.Range.Column+1), region["startColumn"])
+		assert.Equal(t, float64(diagnostic.Range.Start), region["byteOffset"])
+		assert.Equal(t, float64(diagnostic.Range.End-diagnostic.Range.Start), region["byteLength"])
+	}
+
+	first := results[0].(map[string]any)
+	assert.Equal(t, CodeMissingSemicolon.String(), first["ruleId"])
+
+	replacement := first["fixes"].([]any)[0].(map[string]any)["artifactChanges"].([]any)[0].(map[string]any)["replacements"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"text": ";"}, replacement["insertedContent"])
+	assert.Equal(t, float64(strings.Index(contract, "1\n")+1), replacement["deletedRegion"].(map[string]any)["byteOffset"])
+	assert.Equal(t, float64(0), replacement["deletedRegion"].(map[string]any)["byteLength"])
+}
+
+func TestFixApply(t *testing.T) {
+	fix := Fix{Edits: []TextEdit{
+		{Range: Range{Start: 0, End: 4}, NewText: "uint256"},
+		{Range: Range{Start: 6, End: 6}, NewText: ";"},
+	}}
+
+	fixed, err := fix.Apply("uint x")
+	require.NoError(t, err)
+	assert.Equal(t, "uint256 x;", fixed)
+
+	_, err = Fix{Edits: []TextEdit{{Range: Range{Start: 2, End: 10}}}}.Apply("uint x")
+	assert.Error(t, err)
+
+	_, err = Fix{Edits: []TextEdit{{Range: Range{Start: 0, End: 4}}, {Range: Range{Start: 2, End: 5}}}}.Apply("uint x")
+	assert.Error(t, err)
+}
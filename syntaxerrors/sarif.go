@@ -0,0 +1,214 @@
+package syntaxerrors
+
+import (
+	"sort"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// sarifSchema is the location of the JSON schema of the SARIF version the diagnostics are exported to.
+	sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifVersion is the SARIF version the diagnostics are exported to.
+	sarifVersion = "2.1.0"
+)
+
+// sarifLog is the root of a SARIF 2.1.0 document. Only the subset of the format needed to describe syntax
+// diagnostics is modelled.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation,omitempty"`
+	Region           sarifRegion            `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine,omitempty"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndLine     int           `json:"endLine,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	ByteOffset  int           `json:"byteOffset"`
+	ByteLength  int           `json:"byteLength"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// ToSARIF returns the diagnostics as a SARIF 2.1.0 log, the format consumed by code scanning tools. Every
+// code reported becomes a rule of the run, and fixes are exported as replacements of byte regions.
+func (d Diagnostics) ToSARIF() ([]byte, error) {
+	codes := make([]Code, 0)
+	seen := make(map[Code]bool)
+	for _, diagnostic := range d {
+		if !seen[diagnostic.Code] {
+			seen[diagnostic.Code] = true
+			codes = append(codes, diagnostic.Code)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	rules := make([]sarifRule, 0, len(codes))
+	ruleIndexes := make(map[Code]int)
+	for i, code := range codes {
+		ruleIndexes[code] = i
+		rules = append(rules, sarifRule{
+			Id:               code.String(),
+			Name:             code.Name(),
+			ShortDescription: sarifMessage{Text: code.Description()},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(d))
+	for _, diagnostic := range d {
+		result := sarifResult{
+			RuleId:    diagnostic.Code.String(),
+			RuleIndex: ruleIndexes[diagnostic.Code],
+			Level:     sarifLevel(diagnostic.Severity),
+			Message:   sarifMessage{Text: diagnostic.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifact(diagnostic.Range.File),
+					Region:           sarifRegionOf(diagnostic.Range),
+				},
+			}},
+		}
+
+		if diagnostic.Snippet != "" {
+			result.Locations[0].PhysicalLocation.Region.Snippet = &sarifMessage{Text: diagnostic.Snippet}
+		}
+
+		for _, fix := range diagnostic.Fixes {
+			changes := make(map[string]*sarifArtifactChange)
+			order := make([]string, 0)
+			for _, edit := range fix.Edits {
+				change, ok := changes[edit.Range.File]
+				if !ok {
+					change = &sarifArtifactChange{ArtifactLocation: sarifArtifactLocation{Uri: edit.Range.File}}
+					changes[edit.Range.File] = change
+					order = append(order, edit.Range.File)
+				}
+
+				replacement := sarifReplacement{DeletedRegion: sarifRegionOf(edit.Range)}
+				if edit.NewText != "" {
+					replacement.InsertedContent = &sarifMessage{Text: edit.NewText}
+				}
+				change.Replacements = append(change.Replacements, replacement)
+			}
+
+			sarif := sarifFix{Description: sarifMessage{Text: fix.Description}}
+			for _, file := range order {
+				sarif.ArtifactChanges = append(sarif.ArtifactChanges, *changes[file])
+			}
+			result.Fixes = append(result.Fixes, sarif)
+		}
+
+		results = append(results, result)
+	}
+
+	return json.Marshal(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "solgo",
+					InformationUri: "https://github.com/unpackdev/solgo",
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	})
+}
+
+// sarifLevel returns the SARIF level matching the severity.
+func sarifLevel(severity SeverityLevel) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifArtifact returns the location of the file, which is omitted for unnamed inputs.
+func sarifArtifact(file string) *sarifArtifactLocation {
+	if file == "" {
+		return nil
+	}
+	return &sarifArtifactLocation{Uri: file}
+}
+
+// sarifRegionOf returns the SARIF region of the range. SARIF columns are 1-based.
+func sarifRegionOf(r Range) sarifRegion {
+	return sarifRegion{
+		StartLine:   r.Line,
+		StartColumn: r.Column + 1,
+		EndLine:     r.EndLine,
+		EndColumn:   r.EndColumn + 1,
+		ByteOffset:  r.Start,
+		ByteLength:  r.End - r.Start,
+	}
+}
@@ -0,0 +1,193 @@
+package syntaxerrors
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/unpackdev/solgo/parser"
+)
+
+// sourceText is the text of the parsed input with the files it consists of. ANTLR positions tokens by
+// character index, so it translates them into byte offsets and file relative locations.
+type sourceText struct {
+	input      antlr.CharStream
+	text       string
+	runeStarts []int // Byte offset of every character, only set when the text is not pure ASCII.
+	files      []SourceFile
+	braces     map[int]int // Brace balance of the files keyed by their offset, counted on first use.
+}
+
+// newSourceText reads the text of the input stream and splits it into the provided files. When no files
+// are provided, the whole input is treated as a single unnamed file.
+func newSourceText(input antlr.CharStream, files []SourceFile) *sourceText {
+	toReturn := &sourceText{input: input}
+	if input.Size() > 0 {
+		toReturn.text = input.GetText(0, input.Size()-1)
+	}
+
+	if utf8.RuneCountInString(toReturn.text) != len(toReturn.text) {
+		toReturn.runeStarts = make([]int, 0, len(toReturn.text))
+		for offset := range toReturn.text {
+			toReturn.runeStarts = append(toReturn.runeStarts, offset)
+		}
+	}
+
+	toReturn.files = files
+	if len(toReturn.files) == 0 {
+		toReturn.files = []SourceFile{{Length: len(toReturn.text)}}
+	}
+
+	return toReturn
+}
+
+// byteOffset converts the character index within the input into a byte offset.
+func (s *sourceText) byteOffset(index int) int {
+	if index < 0 {
+		return 0
+	}
+	if s.runeStarts == nil {
+		return min(index, len(s.text))
+	}
+	if index >= len(s.runeStarts) {
+		return len(s.text)
+	}
+	return s.runeStarts[index]
+}
+
+// lineOffset returns the byte offset of the 1-based line and 0-based character column within the input.
+func (s *sourceText) lineOffset(line, column int) int {
+	offset := 0
+	for current := 1; current < line; current++ {
+		next := strings.IndexByte(s.text[offset:], '\n')
+		if next < 0 {
+			return len(s.text)
+		}
+		offset += next + 1
+	}
+
+	for ; column > 0 && offset < len(s.text) && s.text[offset] != '\n'; column-- {
+		_, size := utf8.DecodeRuneInString(s.text[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// file returns the file containing the byte offset within the input. Offsets between two files belong to
+// the preceding one.
+func (s *sourceText) file(offset int) SourceFile {
+	toReturn := s.files[0]
+	for _, file := range s.files {
+		if file.Offset > offset {
+			break
+		}
+		toReturn = file
+	}
+	return toReturn
+}
+
+// content returns the content of the file.
+func (s *sourceText) content(file SourceFile) string {
+	start := min(max(file.Offset, 0), len(s.text))
+	end := min(max(start+file.Length, start), len(s.text))
+	return s.text[start:end]
+}
+
+// rangeOf returns the range between the byte offsets within the input, relative to the file containing it.
+func (s *sourceText) rangeOf(start, end int) Range {
+	file := s.file(start)
+	content := s.content(file)
+
+	start = min(max(start-file.Offset, 0), len(content))
+	end = min(max(end-file.Offset, start), len(content))
+
+	toReturn := Range{File: file.Path, Start: start, End: end}
+	toReturn.Line, toReturn.Column = position(content, start)
+	toReturn.EndLine, toReturn.EndColumn = position(content, end)
+	return toReturn
+}
+
+// endOfFile returns the empty range at the end of the file containing the byte offset within the input.
+func (s *sourceText) endOfFile(offset int) Range {
+	file := s.file(offset)
+	return s.rangeOf(file.Offset+len(s.content(file)), file.Offset+len(s.content(file)))
+}
+
+// snippet returns the line containing the start of the range followed by a line marking the range with
+// a caret and tildes.
+func (s *sourceText) snippet(r Range) string {
+	content := s.content(s.fileByPath(r.File))
+
+	lineStart := strings.LastIndexByte(content[:r.Start], '\n') + 1
+	lineEnd := strings.IndexByte(content[r.Start:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += r.Start
+	}
+	line := strings.TrimRight(content[lineStart:lineEnd], "\r")
+
+	var marker strings.Builder
+	for _, char := range content[lineStart:r.Start] {
+		// Tabs are kept so the caret lines up regardless of the tab width.
+		if char == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteRune(' ')
+		}
+	}
+	marker.WriteRune('^')
+	if end := min(r.End, lineStart+len(line)); end > r.Start {
+		if length := utf8.RuneCountInString(content[r.Start:end]); length > 1 {
+			marker.WriteString(strings.Repeat("~", length-1))
+		}
+	}
+
+	return line + "\n" + marker.String()
+}
+
+// fileByPath returns the file with the path, falling back to the first file.
+func (s *sourceText) fileByPath(path string) SourceFile {
+	for _, file := range s.files {
+		if file.Path == path {
+			return file
+		}
+	}
+	return s.files[0]
+}
+
+// braceBalance returns the number of opening braces in the file that are not closed, which is negative
+// when there are more closing braces than opening ones. Braces are counted on tokens so the ones within
+// comments and strings are ignored.
+func (s *sourceText) braceBalance(file SourceFile) int {
+	if balance, ok := s.braces[file.Offset]; ok {
+		return balance
+	}
+
+	lexer := parser.NewSolidityLexer(antlr.NewInputStream(s.content(file)))
+	lexer.RemoveErrorListeners()
+
+	balance := 0
+	for token := lexer.NextToken(); token.GetTokenType() != antlr.TokenEOF; token = lexer.NextToken() {
+		switch token.GetTokenType() {
+		case parser.SolidityLexerLBrace, parser.SolidityLexerAssemblyLBrace, parser.SolidityLexerYulLBrace:
+			balance++
+		case parser.SolidityLexerRBrace, parser.SolidityLexerYulRBrace:
+			balance--
+		}
+	}
+
+	if s.braces == nil {
+		s.braces = make(map[int]int)
+	}
+	s.braces[file.Offset] = balance
+	return balance
+}
+
+// position returns the 1-based line and 0-based character column of the byte offset within the content.
+func position(content string, offset int) (int, int) {
+	line := strings.Count(content[:offset], "\n") + 1
+	lineStart := strings.LastIndexByte(content[:offset], '\n') + 1
+	return line, utf8.RuneCountInString(content[lineStart:offset])
+}
@@ -23,12 +23,15 @@ func (e SyntaxError) Error() error {
 }
 
 // SyntaxErrorListener is a listener for syntax errors in Solidity contracts.
-// It maintains a stack of contexts and a slice of SyntaxErrors.
+// It maintains a stack of contexts, a slice of SyntaxErrors and their structured Diagnostics.
 type SyntaxErrorListener struct {
 	*parser.BaseSolidityParserListener
 	*antlr.DefaultErrorListener
-	Errors   []SyntaxError
-	contexts []string
+	Errors      []SyntaxError
+	Diagnostics Diagnostics
+	contexts    []string
+	files       []SourceFile
+	source      *sourceText
 }
 
 // NewSyntaxErrorListener creates a new SyntaxErrorListener.
@@ -40,6 +43,18 @@ func NewSyntaxErrorListener() *SyntaxErrorListener {
 	}
 }
 
+// SetSourceFiles sets the files the parsed input consists of, so diagnostics are located relative to the
+// file they occurred in. Without files, the whole input is treated as a single unnamed file.
+func (l *SyntaxErrorListener) SetSourceFiles(files ...SourceFile) {
+	l.files = files
+	l.source = nil
+}
+
+// GetDiagnostics returns the structured diagnostics of the syntax errors in the order they were reported.
+func (l *SyntaxErrorListener) GetDiagnostics() Diagnostics {
+	return l.Diagnostics
+}
+
 // PushContext adds a context to the stack.
 func (l *SyntaxErrorListener) PushContext(ctx string) {
 	l.contexts = append(l.contexts, ctx)
@@ -70,6 +85,9 @@ func (l *SyntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offending
 
 	// Add the error to the Errors slice
 	l.Errors = append(l.Errors, err)
+
+	// Add the structured diagnostic of the error
+	l.Diagnostics = append(l.Diagnostics, l.newDiagnostic(recognizer, offendingSymbol, line, column, msg, e))
 }
 
 func (l *SyntaxErrorListener) currentContext() string {
@@ -20,6 +20,7 @@ type Descriptor struct {
 	TotalBurnedSupply *big.Int        `json:"total_burned_supply"` // Supply of tokens that have been burned.
 	LatestBlockNumber *big.Int        `json:"latest_block_number"` // Latest block number processed by the application.
 	Entity            *entities.Token `json:"-"`                   // Associated token entity, not serialized to JSON.
+	Safety            *Safety         `json:"safety,omitempty"`    // Outcome of the safety analysis, if analyzed.
 }
 
 // GetAddress returns the Ethereum address of the token contract.
@@ -57,6 +58,11 @@ func (d *Descriptor) GetEntity() *entities.Token {
 	return d.Entity
 }
 
+// GetSafety returns the outcome of the token safety analysis, nil if the token was not analyzed.
+func (d *Descriptor) GetSafety() *Safety {
+	return d.Safety
+}
+
 // GetTotalCirculatingSupply calculates and returns the total circulating supply of the token,
 // which is the total supply minus the total burned supply.
 func (d *Descriptor) GetTotalCirculatingSupply() *big.Int {
@@ -1,4 +1,5 @@
 // Package tokens provides a high-level abstraction for interacting with Ethereum tokens.
 // It offers functionalities to create, manipulate, and query token information using
-// smart contract bindings and Ethereum network clients.
+// smart contract bindings and Ethereum network clients. Tokens can be analyzed for taxes,
+// transfer limits, blacklists and trading toggles by simulating trades within a local EVM.
 package tokens
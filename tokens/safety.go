@@ -0,0 +1,933 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/unpackdev/solgo/clients"
+	"github.com/unpackdev/solgo/opcode"
+	"github.com/unpackdev/solgo/simulator"
+	"github.com/unpackdev/solgo/utils"
+	"github.com/unpackdev/solgo/utils/entities"
+	"go.uber.org/zap"
+)
+
+// DefaultUniswapV2Factory is the UniswapV2 factory pairs are looked up with on Ethereum.
+var DefaultUniswapV2Factory = common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+
+// DefaultUniswapV2Router is the UniswapV2 router sells are simulated to be executed by on Ethereum.
+var DefaultUniswapV2Router = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+
+// safetyABI holds the ERC20, UniswapV2 pair and factory methods used by the safety analysis.
+var safetyABI = mustParseABI(`[
+	{"type":"function","name":"balanceOf","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"token0","inputs":[],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"},
+	{"type":"function","name":"token1","inputs":[],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"},
+	{"type":"function","name":"getReserves","inputs":[],"outputs":[{"name":"reserve0","type":"uint112"},{"name":"reserve1","type":"uint112"},{"name":"blockTimestampLast","type":"uint32"}],"stateMutability":"view"},
+	{"type":"function","name":"sync","inputs":[],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"swap","inputs":[{"name":"amount0Out","type":"uint256"},{"name":"amount1Out","type":"uint256"},{"name":"to","type":"address"},{"name":"data","type":"bytes"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"getPair","inputs":[{"name":"tokenA","type":"address"},{"name":"tokenB","type":"address"}],"outputs":[{"name":"","type":"address"}],"stateMutability":"view"}
+]`)
+
+// mustParseABI parses the ABI definition, panicking if it is invalid as it is defined within the package.
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse abi definition: %s", err))
+	}
+
+	return parsed
+}
+
+var (
+	// maxTransactionGetters are getters commonly exposing the maximum amount of a single transfer.
+	maxTransactionGetters = []string{
+		"_maxTxAmount()", "maxTxAmount()", "maxTransactionAmount()", "_maxTransactionAmount()", "maxTxnAmount()",
+		"maxTransferAmount()", "_maxTransferAmount()",
+	}
+
+	// maxWalletGetters are getters commonly exposing the maximum balance of a single holder.
+	maxWalletGetters = []string{
+		"_maxWalletSize()", "maxWalletSize()", "maxWallet()", "_maxWallet()", "maxWalletAmount()", "_maxWalletAmount()",
+		"_maxWalletToken()", "maxWalletToken()",
+	}
+
+	// blacklistFunctions are functions commonly used to block holders from transferring.
+	blacklistFunctions = []string{
+		"blacklist(address)", "blacklistAddress(address,bool)", "setBlacklist(address,bool)", "addToBlacklist(address)",
+		"addBlacklist(address)", "blockAddress(address)", "setBots(address[],bool)", "setBots(address[])", "addBots(address[])",
+		"addBot(address)", "setBot(address,bool)", "blockBots(address[])", "setSniper(address,bool)", "manageBlacklist(address[],bool)",
+		"isBlacklisted(address)", "isBot(address)", "bots(address)",
+	}
+
+	// tradingFunctions are functions commonly used to enable or disable trading.
+	tradingFunctions = []string{
+		"enableTrading()", "openTrading()", "startTrading()", "setTrading(bool)", "setTradingEnabled(bool)",
+		"setTradingOpen(bool)", "tradingStatus(bool)", "pauseTrading()", "disableTrading()", "pause()",
+	}
+)
+
+// SafetyOptions defines the configuration of the token safety analysis.
+type SafetyOptions struct {
+	// Simulator is the simulator trades are executed in. Its state is modified by the analysis. If not set, a
+	// simulator forking the token network at the descriptor block is created for the analysis.
+	Simulator *simulator.Simulator `json:"-"`
+
+	// Pair is the UniswapV2-style pair of the token. If not set, it is looked up through the factory.
+	Pair common.Address `json:"pair"`
+
+	// Factory is the UniswapV2-style factory the pair is looked up with. Defaults to the UniswapV2 factory.
+	Factory common.Address `json:"factory"`
+
+	// Router is the UniswapV2-style router approved by and moving the tokens of sellers. Tokens commonly exempt or
+	// restrict it, which is why sells are executed on its behalf. Defaults to the UniswapV2 router.
+	Router common.Address `json:"router"`
+
+	// BaseToken is the token the pair trades against. Defaults to the WETH of the network.
+	BaseToken common.Address `json:"base_token"`
+
+	// TradeAmountBps is the amount traded, in basis points of the token balance of the pair. Defaults to 100.
+	TradeAmountBps uint64 `json:"trade_amount_bps"`
+
+	// WarnTax is the tax percentage above which the token is considered a high tax token. Defaults to 10.
+	WarnTax float64 `json:"warn_tax"`
+
+	// HoneypotTax is the sell tax percentage above which the token is considered a honeypot. Defaults to 90.
+	HoneypotTax float64 `json:"honeypot_tax"`
+}
+
+// NewDefaultSafetyOptions creates and returns a new instance of SafetyOptions with default settings.
+func NewDefaultSafetyOptions() *SafetyOptions {
+	return &SafetyOptions{
+		Factory:        DefaultUniswapV2Factory,
+		Router:         DefaultUniswapV2Router,
+		TradeAmountBps: 100,
+		WarnTax:        10,
+		HoneypotTax:    90,
+	}
+}
+
+// TradeSimulation is the outcome of a simulated token transfer along a trade path.
+type TradeSimulation struct {
+	Type     utils.TradeType `json:"type"`            // Trade path the transfer simulates.
+	From     common.Address  `json:"from"`            // Sender of the tokens.
+	To       common.Address  `json:"to"`              // Recipient of the tokens.
+	Amount   *big.Int        `json:"amount"`          // Amount of tokens sent.
+	Received *big.Int        `json:"received"`        // Amount of tokens credited to the recipient.
+	Tax      float64         `json:"tax"`             // Percentage of the amount not credited to the recipient.
+	GasUsed  uint64          `json:"gas_used"`        // Gas used by the transfer.
+	Success  bool            `json:"success"`         // Whether the transfer succeeded.
+	Error    string          `json:"error,omitempty"` // Reason of the failure, if any.
+}
+
+// Safety is the outcome of the token safety analysis. Every finding is backed by an entry in Evidence.
+type Safety struct {
+	Pair               common.Address        `json:"pair"`
+	BaseToken          common.Address        `json:"base_token"`
+	State              utils.SafetyStateType `json:"state"`
+	Honeypot           bool                  `json:"honeypot"`
+	BuyTax             float64               `json:"buy_tax"`
+	SellTax            float64               `json:"sell_tax"`
+	TransferTax        float64               `json:"transfer_tax"`
+	MaxTransaction     *big.Int              `json:"max_transaction,omitempty"`
+	MaxWallet          *big.Int              `json:"max_wallet,omitempty"`
+	AntiWhale          []utils.AntiWhaleType `json:"anti_whale"`
+	Blacklists         []utils.BlacklistType `json:"blacklists"`
+	BlacklistFunctions []string              `json:"blacklist_functions"`
+	TradingFunctions   []string              `json:"trading_functions"`
+	Simulations        []*TradeSimulation    `json:"simulations"`
+	Evidence           []string              `json:"evidence"`
+}
+
+// GetSimulation returns the simulation of the trade path, nil if it was not simulated.
+func (s *Safety) GetSimulation(tradeType utils.TradeType) *TradeSimulation {
+	for _, simulation := range s.Simulations {
+		if simulation.Type == tradeType {
+			return simulation
+		}
+	}
+	return nil
+}
+
+// HasBlacklist checks if the token falls into the blacklist category.
+func (s *Safety) HasBlacklist(blacklistType utils.BlacklistType) bool {
+	for _, blacklist := range s.Blacklists {
+		if blacklist == blacklistType {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAntiWhale checks if the token enforces the anti-whale measure.
+func (s *Safety) HasAntiWhale(antiWhaleType utils.AntiWhaleType) bool {
+	for _, antiWhale := range s.AntiWhale {
+		if antiWhale == antiWhaleType {
+			return true
+		}
+	}
+	return false
+}
+
+// addEvidence records the evidence backing a finding.
+func (s *Safety) addEvidence(format string, args ...interface{}) {
+	s.Evidence = append(s.Evidence, fmt.Sprintf(format, args...))
+}
+
+// Simulated accounts trading the token. They hold no code and no tokens before the analysis.
+var (
+	safetyBuyer     = common.BytesToAddress(crypto.Keccak256([]byte("solgo.tokens.safety.buyer")))
+	safetyRecipient = common.BytesToAddress(crypto.Keccak256([]byte("solgo.tokens.safety.recipient")))
+	safetyProbe     = common.BytesToAddress(crypto.Keccak256([]byte("solgo.tokens.safety.probe")))
+)
+
+// safetyAnalysis holds the state of a running token safety analysis.
+type safetyAnalysis struct {
+	token  common.Address
+	token0 bool
+	router common.Address
+	sim    *simulator.Simulator
+	client *clients.Client
+	opts   *SafetyOptions
+	safety *Safety
+}
+
+// AnalyzeSafety measures the trading behaviour of the token by simulating transfers along the buy, sell and
+// wallet to wallet paths of its UniswapV2-style pair within a local EVM. Buys and sells are swaps through the
+// pair, sells moving the tokens of the seller with transferFrom on behalf of the router, as done when swapping
+// through the router, which is where tokens apply taxes and restrictions. Effective taxes are measured from
+// balance changes. Transfer limits are probed with calls and read from common getters, while blacklist and
+// trading toggle functions are detected from the selectors of the token dispatcher. The result is stored within
+// the token descriptor.
+func (t *Token) AnalyzeSafety(ctx context.Context, opts *SafetyOptions) (*Safety, error) {
+	if opts == nil {
+		opts = NewDefaultSafetyOptions()
+	}
+
+	sim := opts.Simulator
+	if sim == nil {
+		client, err := t.GetClient()
+		if err != nil {
+			return nil, err
+		}
+
+		sim, err = simulator.NewSimulator(ctx, t.network, client, &simulator.Options{ForkBlock: t.descriptor.BlockNumber})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create simulator: %w", err)
+		}
+		defer sim.Close()
+	}
+
+	previousSimulatorType := t.simulatorType
+	t.simulatorType = utils.LocalSimulator
+	t.SetInSimulation(true)
+	defer func() {
+		t.simulatorType = previousSimulatorType
+		t.SetInSimulation(false)
+	}()
+
+	router := opts.Router
+	if router == utils.ZeroAddress {
+		router = DefaultUniswapV2Router
+	}
+
+	analysis := &safetyAnalysis{
+		token:  t.descriptor.Address,
+		router: router,
+		sim:    sim,
+		client: sim.GetClient(),
+		opts:   opts,
+		safety: &Safety{
+			State:              utils.UnknownSafetyState,
+			AntiWhale:          make([]utils.AntiWhaleType, 0),
+			Blacklists:         make([]utils.BlacklistType, 0),
+			BlacklistFunctions: make([]string, 0),
+			TradingFunctions:   make([]string, 0),
+			Simulations:        make([]*TradeSimulation, 0),
+			Evidence:           make([]string, 0),
+		},
+	}
+
+	if err := analysis.resolvePair(ctx, t.networkID); err != nil {
+		return nil, err
+	}
+
+	if err := analysis.simulateTrades(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := analysis.detectLimits(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := analysis.detectFunctions(ctx); err != nil {
+		return nil, err
+	}
+
+	analysis.assess()
+	t.descriptor.Safety = analysis.safety
+
+	zap.L().Debug(
+		"analyzed token safety",
+		zap.String("network", t.network.String()),
+		zap.String("token", t.descriptor.Address.Hex()),
+		zap.String("pair", analysis.safety.Pair.Hex()),
+		zap.String("state", analysis.safety.State.String()),
+		zap.Float64("buy_tax", analysis.safety.BuyTax),
+		zap.Float64("sell_tax", analysis.safety.SellTax),
+	)
+
+	return analysis.safety, nil
+}
+
+// resolvePair looks up the pair of the token if not configured and checks it trades the token.
+func (a *safetyAnalysis) resolvePair(ctx context.Context, networkID utils.NetworkID) error {
+	baseToken := a.opts.BaseToken
+	if baseToken == utils.ZeroAddress {
+		if weth, ok := entities.WETH9[uint(networkID)]; ok {
+			baseToken = weth.Address
+		}
+	}
+
+	pair := a.opts.Pair
+	if pair == utils.ZeroAddress {
+		if baseToken == utils.ZeroAddress {
+			return errors.New("pair and base token not set")
+		}
+
+		factory := a.opts.Factory
+		if factory == utils.ZeroAddress {
+			factory = DefaultUniswapV2Factory
+		}
+
+		if err := a.call(ctx, factory, &pair, "getPair", a.token, baseToken); err != nil {
+			return fmt.Errorf("failed to look up pair: %w", err)
+		}
+
+		if pair == utils.ZeroAddress {
+			return fmt.Errorf("no pair found for token %s and base token %s", a.token.Hex(), baseToken.Hex())
+		}
+	}
+
+	var token0, token1 common.Address
+	if err := a.call(ctx, pair, &token0, "token0"); err != nil {
+		return fmt.Errorf("failed to get pair token0: %w", err)
+	}
+	if err := a.call(ctx, pair, &token1, "token1"); err != nil {
+		return fmt.Errorf("failed to get pair token1: %w", err)
+	}
+
+	switch a.token {
+	case token0:
+		baseToken, a.token0 = token1, true
+	case token1:
+		baseToken, a.token0 = token0, false
+	default:
+		return fmt.Errorf("pair %s does not trade token %s", pair.Hex(), a.token.Hex())
+	}
+
+	a.safety.Pair, a.safety.BaseToken = pair, baseToken
+	return nil
+}
+
+// simulateTrades simulates a buy from the pair, a transfer of half of the bought tokens to another wallet and a
+// sell of the other half back to the pair.
+func (a *safetyAnalysis) simulateTrades(ctx context.Context) error {
+	pair := a.safety.Pair
+
+	// Simulated senders pay for gas if the simulator charges a base fee.
+	for _, addr := range []common.Address{pair, a.router, safetyBuyer, safetyRecipient} {
+		if err := a.sim.SetBalance(addr, new(big.Int).Lsh(common.Big1, 128)); err != nil {
+			return fmt.Errorf("failed to fund %s: %w", addr.Hex(), err)
+		}
+	}
+
+	tokenReserve, _, err := a.getReserves(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tokenReserve.Sign() == 0 {
+		return fmt.Errorf("pair %s holds no liquidity of token %s", pair.Hex(), a.token.Hex())
+	}
+
+	amount := new(big.Int).Div(new(big.Int).Mul(tokenReserve, new(big.Int).SetUint64(a.opts.TradeAmountBps)), big.NewInt(10_000))
+	if amount.Sign() == 0 {
+		amount.SetInt64(1)
+	}
+
+	buy, err := a.simulateBuy(ctx, amount)
+	if err != nil {
+		return err
+	}
+	a.safety.BuyTax = buy.Tax
+
+	if !buy.Success {
+		a.safety.addEvidence("buy of %s tokens from pair %s failed: %s", amount, pair.Hex(), buy.Error)
+		return nil
+	}
+	a.safety.addEvidence("buy of %s tokens from pair %s credited %s tokens, %.2f%% tax", amount, pair.Hex(), buy.Received, buy.Tax)
+
+	if buy.Received.Sign() == 0 {
+		return nil
+	}
+
+	transferAmount := new(big.Int).Div(buy.Received, common.Big2)
+	if transferAmount.Sign() > 0 {
+		transfer, err := a.simulateTransfer(ctx, safetyBuyer, safetyRecipient, transferAmount)
+		if err != nil {
+			return err
+		}
+		a.safety.TransferTax = transfer.Tax
+
+		if transfer.Success {
+			a.safety.addEvidence("transfer of %s tokens between wallets credited %s tokens, %.2f%% tax", transferAmount, transfer.Received, transfer.Tax)
+		} else {
+			a.safety.addEvidence("transfer of %s tokens between wallets failed: %s", transferAmount, transfer.Error)
+		}
+	}
+
+	sellAmount, err := a.balanceOf(ctx, safetyBuyer)
+	if err != nil {
+		return err
+	}
+
+	sell, err := a.simulateSell(ctx, sellAmount)
+	if err != nil {
+		return err
+	}
+	a.safety.SellTax = sell.Tax
+
+	if sell.Success {
+		a.safety.addEvidence("sell of %s tokens to pair %s credited %s tokens, %.2f%% tax", sellAmount, pair.Hex(), sell.Received, sell.Tax)
+	} else {
+		a.safety.addEvidence("sell of %s tokens to pair %s failed: %s", sellAmount, pair.Hex(), sell.Error)
+	}
+
+	return nil
+}
+
+// simulateBuy swaps base tokens for the amount of tokens through the pair and measures the amount credited to the
+// buyer. The base tokens paid are taken from the pair, which is synced before they are paid back, so that no base
+// token holder is needed. Failed buys are reported through the simulation.
+func (a *safetyAnalysis) simulateBuy(ctx context.Context, amount *big.Int) (*TradeSimulation, error) {
+	pair, baseToken := a.safety.Pair, a.safety.BaseToken
+	toReturn := a.newSimulation(utils.BuyTradeType, pair, safetyBuyer, amount)
+
+	tokenReserve, baseReserve, err := a.getReserves(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syncing lowers the base reserve by the amount paid, which covers the output at swap fees of up to 0.3%.
+	amountIn := new(big.Int).Mul(baseReserve, amount)
+	amountIn.Mul(amountIn, big.NewInt(1_000))
+	amountIn.Div(amountIn, new(big.Int).Add(new(big.Int).Mul(tokenReserve, big.NewInt(997)), new(big.Int).Mul(amount, big.NewInt(3))))
+	amountIn.Add(amountIn, common.Big1)
+
+	if amountIn.Cmp(baseReserve) >= 0 {
+		return nil, fmt.Errorf("pair %s base reserve %s can not pay for %s tokens", pair.Hex(), baseReserve, amount)
+	}
+
+	if _, err := a.send(ctx, pair, baseToken, "transfer", safetyBuyer, amountIn); err != nil {
+		return nil, fmt.Errorf("failed to take base tokens from pair: %w", err)
+	}
+
+	if _, err := a.send(ctx, safetyBuyer, pair, "sync"); err != nil {
+		return nil, fmt.Errorf("failed to sync pair: %w", err)
+	}
+
+	if _, err := a.send(ctx, safetyBuyer, baseToken, "transfer", pair, amountIn); err != nil {
+		return nil, fmt.Errorf("failed to pay base tokens to pair: %w", err)
+	}
+
+	before, err := a.balanceOf(ctx, safetyBuyer)
+	if err != nil {
+		return nil, err
+	}
+
+	amount0Out, amount1Out := a.swapAmounts(amount, true)
+	receipt, err := a.send(ctx, safetyBuyer, pair, "swap", amount0Out, amount1Out, safetyBuyer, []byte{})
+	if err != nil {
+		// Pairs hide the reason the token transfer failed for, which the token gives when called directly.
+		if transferErr := a.check(ctx, pair, a.token, "transfer", safetyBuyer, amount); transferErr != nil {
+			err = fmt.Errorf("%w, token transfer from pair: %s", err, transferErr)
+		}
+		toReturn.Error = err.Error()
+		return toReturn, nil
+	}
+
+	return toReturn, a.credit(ctx, toReturn, receipt, before)
+}
+
+// simulateSell sells the amount of tokens through the pair as done by the router, approving the router and having
+// it move the tokens to the pair with transferFrom before swapping them for base tokens. The amount credited to
+// the pair is measured. Failed sells are reported through the simulation.
+func (a *safetyAnalysis) simulateSell(ctx context.Context, amount *big.Int) (*TradeSimulation, error) {
+	pair := a.safety.Pair
+	toReturn := a.newSimulation(utils.SellTradeType, safetyBuyer, pair, amount)
+
+	tokenReserve, baseReserve, err := a.getReserves(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.send(ctx, safetyBuyer, a.token, "approve", a.router, amount); err != nil {
+		toReturn.Error = fmt.Sprintf("approve failed: %s", err)
+		return toReturn, nil
+	}
+
+	before, err := a.balanceOf(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := a.send(ctx, a.router, a.token, "transferFrom", safetyBuyer, pair, amount)
+	if err != nil {
+		toReturn.Error = fmt.Sprintf("transferFrom failed: %s", err)
+		return toReturn, nil
+	}
+
+	toReturn.GasUsed = receipt.GasUsed
+
+	after, err := a.balanceOf(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn.Received = new(big.Int).Sub(after, before)
+	if toReturn.Received.Sign() < 0 {
+		toReturn.Received.SetInt64(0)
+	}
+	toReturn.Tax = taxPercentage(amount, toReturn.Received)
+
+	// The pair pays for the tokens it holds above its reserve, with the 0.3% swap fee.
+	amountIn := new(big.Int).Mul(new(big.Int).Sub(after, tokenReserve), big.NewInt(997))
+	amountOut := new(big.Int).Div(
+		new(big.Int).Mul(amountIn, baseReserve),
+		new(big.Int).Add(new(big.Int).Mul(tokenReserve, big.NewInt(1_000)), amountIn),
+	)
+
+	if amountOut.Sign() <= 0 {
+		toReturn.Error = "swap pays no base tokens"
+		return toReturn, nil
+	}
+
+	amount0Out, amount1Out := a.swapAmounts(amountOut, false)
+	swapReceipt, err := a.send(ctx, a.router, pair, "swap", amount0Out, amount1Out, safetyBuyer, []byte{})
+	if err != nil {
+		toReturn.Error = fmt.Sprintf("swap failed: %s", err)
+		return toReturn, nil
+	}
+
+	toReturn.GasUsed += swapReceipt.GasUsed
+	toReturn.Success = true
+	return toReturn, nil
+}
+
+// simulateTransfer sends the tokens on behalf of the sender and measures the amount credited to the recipient.
+// Failed transfers are reported through the simulation, errors are only returned if balances can not be read.
+func (a *safetyAnalysis) simulateTransfer(ctx context.Context, from, to common.Address, amount *big.Int) (*TradeSimulation, error) {
+	toReturn := a.newSimulation(utils.TransferTradeType, from, to, amount)
+
+	before, err := a.balanceOf(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := a.send(ctx, from, a.token, "transfer", to, amount)
+	if err != nil {
+		toReturn.Error = err.Error()
+		return toReturn, nil
+	}
+
+	return toReturn, a.credit(ctx, toReturn, receipt, before)
+}
+
+// newSimulation records a new simulation of the trade path.
+func (a *safetyAnalysis) newSimulation(tradeType utils.TradeType, from, to common.Address, amount *big.Int) *TradeSimulation {
+	toReturn := &TradeSimulation{
+		Type:     tradeType,
+		From:     from,
+		To:       to,
+		Amount:   amount,
+		Received: new(big.Int),
+	}
+	a.safety.Simulations = append(a.safety.Simulations, toReturn)
+	return toReturn
+}
+
+// credit completes the successful simulation with the amount credited to its recipient since the balance before.
+func (a *safetyAnalysis) credit(ctx context.Context, simulation *TradeSimulation, receipt *types.Receipt, before *big.Int) error {
+	after, err := a.balanceOf(ctx, simulation.To)
+	if err != nil {
+		return err
+	}
+
+	simulation.Success = true
+	simulation.GasUsed = receipt.GasUsed
+	simulation.Received = new(big.Int).Sub(after, before)
+	if simulation.Received.Sign() < 0 {
+		simulation.Received.SetInt64(0)
+	}
+	simulation.Tax = taxPercentage(simulation.Amount, simulation.Received)
+
+	return nil
+}
+
+// getReserves returns the reserves of the token and of the base token held by the pair.
+func (a *safetyAnalysis) getReserves(ctx context.Context) (*big.Int, *big.Int, error) {
+	data, err := safetyABI.Pack("getReserves")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := a.client.CallContract(ctx, ethereum.CallMsg{To: &a.safety.Pair, Data: data}, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pair reserves: %w", err)
+	}
+
+	unpacked, err := safetyABI.Unpack("getReserves", result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode pair reserves: %w", err)
+	}
+
+	reserve0, reserve1 := unpacked[0].(*big.Int), unpacked[1].(*big.Int)
+	if a.token0 {
+		return reserve0, reserve1, nil
+	}
+	return reserve1, reserve0, nil
+}
+
+// swapAmounts returns the amount0Out and amount1Out swap arguments paying the amount of tokens, or of base tokens.
+func (a *safetyAnalysis) swapAmounts(amount *big.Int, token bool) (*big.Int, *big.Int) {
+	if token == a.token0 {
+		return amount, new(big.Int)
+	}
+	return new(big.Int), amount
+}
+
+// check checks with a call whether the method of the contract succeeds on behalf of the sender.
+func (a *safetyAnalysis) check(ctx context.Context, from, contract common.Address, method string, args ...interface{}) error {
+	data, err := safetyABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.CallContract(ctx, ethereum.CallMsg{From: from, To: &contract, Data: data}, nil)
+	if err != nil {
+		return err
+	}
+
+	// Tokens not reverting on failure return false instead.
+	if outputs := safetyABI.Methods[method].Outputs; len(outputs) == 1 && outputs[0].Type.T == abi.BoolTy {
+		if len(result) >= 32 && new(big.Int).SetBytes(result[:32]).Sign() == 0 {
+			return fmt.Errorf("%s returned false", method)
+		}
+	}
+
+	return nil
+}
+
+// send calls the method of the contract on behalf of the impersonated sender through the simulator client.
+func (a *safetyAnalysis) send(ctx context.Context, from, contract common.Address, method string, args ...interface{}) (*types.Receipt, error) {
+	if err := a.check(ctx, from, contract, method, args...); err != nil {
+		return nil, err
+	}
+
+	data, err := safetyABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var hash common.Hash
+	txArgs := map[string]interface{}{"from": from, "to": contract, "input": hexutil.Bytes(data)}
+	if err := a.client.GetRpcClient().CallContext(ctx, &hash, "eth_sendTransaction", txArgs); err != nil {
+		return nil, err
+	}
+
+	receipt, err := a.client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s receipt: %w", method, err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%s reverted", method)
+	}
+
+	return receipt, nil
+}
+
+// canTransfer checks with a call whether the sender can transfer the amount to the recipient.
+func (a *safetyAnalysis) canTransfer(ctx context.Context, from, to common.Address, amount *big.Int) bool {
+	data, err := safetyABI.Pack("transfer", to, amount)
+	if err != nil {
+		return false
+	}
+
+	result, err := a.client.CallContract(ctx, ethereum.CallMsg{From: from, To: &a.token, Data: data}, nil)
+	if err != nil {
+		return false
+	}
+
+	return len(result) < 32 || new(big.Int).SetBytes(result[:32]).Sign() != 0
+}
+
+// detectLimits detects maximum transaction and maximum wallet limits, both from common getters and by probing
+// transfers from the pair of growing amounts.
+func (a *safetyAnalysis) detectLimits(ctx context.Context) error {
+	var totalSupply *big.Int
+	if err := a.call(ctx, a.token, &totalSupply, "totalSupply"); err != nil {
+		return fmt.Errorf("failed to get total supply: %w", err)
+	}
+
+	for _, getter := range maxTransactionGetters {
+		if value := a.readLimit(ctx, getter, totalSupply); value != nil {
+			a.safety.MaxTransaction = value
+			a.safety.addEvidence("getter %s returned a maximum transaction amount of %s", getter, value)
+			break
+		}
+	}
+
+	for _, getter := range maxWalletGetters {
+		if value := a.readLimit(ctx, getter, totalSupply); value != nil {
+			a.safety.MaxWallet = value
+			a.safety.addEvidence("getter %s returned a maximum wallet amount of %s", getter, value)
+			break
+		}
+	}
+
+	// Probing requires a successful buy, which is the lower bound of the limit.
+	buy := a.safety.GetSimulation(utils.BuyTradeType)
+	if buy == nil || !buy.Success {
+		return nil
+	}
+
+	pair := a.safety.Pair
+	pairBalance, err := a.balanceOf(ctx, pair)
+	if err != nil {
+		return err
+	}
+
+	if a.canTransfer(ctx, pair, safetyProbe, pairBalance) {
+		return nil
+	}
+
+	lo, hi := new(big.Int).Set(buy.Amount), new(big.Int).Set(pairBalance)
+	if lo.Cmp(hi) >= 0 || !a.canTransfer(ctx, pair, safetyProbe, lo) {
+		return nil
+	}
+
+	// The limit is searched for with a precision of a thousandth of the lower bound.
+	precision := new(big.Int).Div(lo, big.NewInt(1_000))
+	for new(big.Int).Sub(hi, lo).Cmp(precision) > 0 && new(big.Int).Sub(hi, lo).Cmp(common.Big1) > 0 {
+		mid := new(big.Int).Add(lo, hi)
+		mid.Rsh(mid, 1)
+
+		if a.canTransfer(ctx, pair, safetyProbe, mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	// Limits depending on the balance of the recipient are wallet limits, others are transaction limits.
+	if !a.canTransfer(ctx, pair, safetyRecipient, lo) {
+		if a.safety.MaxWallet == nil {
+			a.safety.MaxWallet = lo
+		}
+		a.safety.addEvidence("buys of up to %s tokens succeed for an empty wallet but fail for a holder, out of %s held by the pair", lo, pairBalance)
+	} else {
+		if a.safety.MaxTransaction == nil {
+			a.safety.MaxTransaction = lo
+		}
+		a.safety.addEvidence("buys of up to %s tokens succeed while larger buys fail, out of %s held by the pair", lo, pairBalance)
+	}
+
+	return nil
+}
+
+// readLimit reads the limit through the getter, returning nil if the getter does not exist or does not limit
+// anything below the total supply.
+func (a *safetyAnalysis) readLimit(ctx context.Context, getter string, totalSupply *big.Int) *big.Int {
+	selector := crypto.Keccak256([]byte(getter))[:4]
+	result, err := a.client.CallContract(ctx, ethereum.CallMsg{To: &a.token, Data: selector}, nil)
+	if err != nil || len(result) != 32 {
+		return nil
+	}
+
+	value := new(big.Int).SetBytes(result)
+	if value.Sign() == 0 || (totalSupply != nil && value.Cmp(totalSupply) >= 0) {
+		return nil
+	}
+
+	return value
+}
+
+// detectFunctions detects blacklist and trading toggle functions from the selectors of the token dispatcher.
+func (a *safetyAnalysis) detectFunctions(ctx context.Context) error {
+	code, err := a.client.CodeAt(ctx, a.token, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get token code: %w", err)
+	}
+
+	if len(code) == 0 {
+		return fmt.Errorf("token %s has no code", a.token.Hex())
+	}
+
+	decompiler, err := opcode.NewDecompiler(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to create decompiler: %w", err)
+	}
+
+	if err := decompiler.Decompile(); err != nil {
+		return fmt.Errorf("failed to decompile token code: %w", err)
+	}
+
+	graph, err := decompiler.BuildControlFlowGraph()
+	if err != nil {
+		return fmt.Errorf("failed to build token control flow graph: %w", err)
+	}
+
+	selectors := make(map[string]bool)
+	for _, function := range graph.Functions {
+		selectors[strings.ToLower(function.GetSelector())] = true
+	}
+
+	for _, signature := range blacklistFunctions {
+		if selectors[hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])] {
+			a.safety.BlacklistFunctions = append(a.safety.BlacklistFunctions, signature)
+			a.safety.addEvidence("dispatcher exposes blacklist function %s", signature)
+		}
+	}
+
+	for _, signature := range tradingFunctions {
+		if selectors[hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])] {
+			a.safety.TradingFunctions = append(a.safety.TradingFunctions, signature)
+			a.safety.addEvidence("dispatcher exposes trading toggle function %s", signature)
+		}
+	}
+
+	return nil
+}
+
+// assess derives the anti-whale measures, blacklist categories and safety state from the findings.
+func (a *safetyAnalysis) assess() {
+	safety := a.safety
+	buy := safety.GetSimulation(utils.BuyTradeType)
+	sell := safety.GetSimulation(utils.SellTradeType)
+
+	unsafe := false
+	if buy != nil && !buy.Success {
+		unsafe = true
+		if strings.Contains(strings.ToLower(buy.Error), "trading") {
+			safety.Blacklists = append(safety.Blacklists, utils.TradingToggleBlacklistType)
+		}
+	}
+
+	if sell != nil && (!sell.Success || sell.Tax >= a.opts.HoneypotTax) {
+		unsafe = true
+		safety.Honeypot = true
+		safety.Blacklists = append(safety.Blacklists, utils.HoneypotBlacklistType)
+	}
+
+	if safety.BuyTax >= a.opts.WarnTax || safety.SellTax >= a.opts.WarnTax {
+		safety.Blacklists = append(safety.Blacklists, utils.HighTaxTokenBlacklistType)
+	}
+
+	if len(safety.BlacklistFunctions) > 0 {
+		safety.Blacklists = append(safety.Blacklists, utils.HolderBlacklistType)
+	}
+
+	if len(safety.TradingFunctions) > 0 && !safety.HasBlacklist(utils.TradingToggleBlacklistType) {
+		safety.Blacklists = append(safety.Blacklists, utils.TradingToggleBlacklistType)
+	}
+
+	if safety.MaxTransaction != nil {
+		safety.AntiWhale = append(safety.AntiWhale, utils.AntiWhaleMaxTransaction)
+	}
+
+	if safety.MaxWallet != nil {
+		safety.AntiWhale = append(safety.AntiWhale, utils.AntiWhaleMaxWallet)
+	}
+
+	switch {
+	case unsafe:
+		safety.State = utils.UnsafeSafetyState
+	case len(safety.Blacklists) > 0 || len(safety.AntiWhale) > 0 || safety.TransferTax >= a.opts.WarnTax:
+		safety.State = utils.WarnSafetyState
+	default:
+		safety.State = utils.SafeSafetyState
+	}
+}
+
+// balanceOf returns the token balance of the account.
+func (a *safetyAnalysis) balanceOf(ctx context.Context, account common.Address) (*big.Int, error) {
+	var toReturn *big.Int
+	if err := a.call(ctx, a.token, &toReturn, "balanceOf", account); err != nil {
+		return nil, fmt.Errorf("failed to get token balance of %s: %w", account.Hex(), err)
+	}
+	return toReturn, nil
+}
+
+// call calls the method of the contract and unpacks its single result into the value.
+func (a *safetyAnalysis) call(ctx context.Context, contract common.Address, value interface{}, method string, args ...interface{}) error {
+	data, err := safetyABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return err
+	}
+
+	unpacked, err := safetyABI.Unpack(method, result)
+	if err != nil {
+		return err
+	}
+
+	if len(unpacked) != 1 {
+		return fmt.Errorf("unexpected %s results: %d", method, len(unpacked))
+	}
+
+	switch v := value.(type) {
+	case *common.Address:
+		*v = *abi.ConvertType(unpacked[0], new(common.Address)).(*common.Address)
+	case **big.Int:
+		*v = abi.ConvertType(unpacked[0], new(big.Int)).(*big.Int)
+	default:
+		return fmt.Errorf("unsupported %s result type %T", method, value)
+	}
+
+	return nil
+}
+
+// taxPercentage returns the percentage of the amount that was not received, rounded to two decimals.
+func taxPercentage(amount, received *big.Int) float64 {
+	if amount.Sign() == 0 {
+		return 0
+	}
+
+	lost := new(big.Int).Sub(amount, received)
+	if lost.Sign() <= 0 {
+		return 0
+	}
+
+	// The lost share in basis points keeps two decimals of the percentage.
+	scaled := new(big.Int).Div(new(big.Int).Mul(lost, big.NewInt(10_000)), amount)
+	return float64(scaled.Int64()) / 100
+}
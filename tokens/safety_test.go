@@ -0,0 +1,464 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/bindings"
+	"github.com/unpackdev/solgo/simulator"
+	"github.com/unpackdev/solgo/utils"
+)
+
+// assemble assembles whitespace separated EVM instructions. Hex literals are pushed with the smallest PUSH
+// fitting their digits, "name:" defines a jump destination and "@name" pushes its offset.
+func assemble(t *testing.T, source string) []byte {
+	fields := strings.Fields(source)
+
+	size := func(field string) int {
+		switch {
+		case strings.HasSuffix(field, ":"):
+			return 1
+		case strings.HasPrefix(field, "@"):
+			return 3
+		case strings.HasPrefix(field, "0x"):
+			return 1 + len(common.FromHex(field))
+		default:
+			return 1
+		}
+	}
+
+	labels := make(map[string]int)
+	offset := 0
+	for _, field := range fields {
+		if strings.HasSuffix(field, ":") {
+			labels[strings.TrimSuffix(field, ":")] = offset
+		}
+		offset += size(field)
+	}
+
+	code := make([]byte, 0, offset)
+	for _, field := range fields {
+		switch {
+		case strings.HasSuffix(field, ":"):
+			code = append(code, byte(vm.JUMPDEST))
+		case strings.HasPrefix(field, "@"):
+			label, ok := labels[field[1:]]
+			require.True(t, ok, "unknown label %s", field)
+			code = append(code, byte(vm.PUSH2), byte(label>>8), byte(label))
+		case strings.HasPrefix(field, "0x"):
+			value := common.FromHex(field)
+			code = append(code, byte(vm.PUSH1)+byte(len(value)-1))
+			code = append(code, value...)
+		default:
+			op := vm.StringToOp(field)
+			require.True(t, op != vm.STOP || field == "STOP", "unknown instruction %s", field)
+			code = append(code, byte(op))
+		}
+	}
+
+	return code
+}
+
+// selector returns the hex literal of the selector of the function signature.
+func selector(signature string) string {
+	return fmt.Sprintf("0x%x", crypto.Keccak256([]byte(signature))[:4])
+}
+
+// Storage slots configuring the test token. They are above the address range used for balances.
+var (
+	tokenSupplySlot      = new(big.Int).Lsh(big.NewInt(1), 160)
+	tokenPairSlot        = new(big.Int).Lsh(big.NewInt(2), 160)
+	tokenBuyTaxSlot      = new(big.Int).Lsh(big.NewInt(3), 160)
+	tokenSellTaxSlot     = new(big.Int).Lsh(big.NewInt(4), 160)
+	tokenTransferTaxSlot = new(big.Int).Lsh(big.NewInt(5), 160)
+	tokenMaxTxSlot       = new(big.Int).Lsh(big.NewInt(6), 160)
+	tokenMaxWalletSlot   = new(big.Int).Lsh(big.NewInt(7), 160)
+	tokenSellBlockedSlot = new(big.Int).Lsh(big.NewInt(8), 160)
+	tokenTradingOffSlot  = new(big.Int).Lsh(big.NewInt(9), 160)
+	tokenNoAllowanceSlot = new(big.Int).Lsh(big.NewInt(10), 160)
+)
+
+// tokenCode returns the runtime code of a token taxing buys, sells and transfers by the basis points configured
+// in storage, enforcing the configured limits and toggles. Allowances are stored at the hash of the owner and the
+// spender, transferFrom reverting if disabled in storage. The functions are added to the dispatcher, either
+// returning the value of a storage slot or doing nothing.
+func tokenCode(t *testing.T, getters map[string]*big.Int, functions ...string) []byte {
+	slot := func(value *big.Int) string {
+		return fmt.Sprintf("0x%x", value)
+	}
+
+	var dispatcher, bodies strings.Builder
+	for i, signature := range functions {
+		fmt.Fprintf(&dispatcher, "DUP1 %s EQ @function%d JUMPI\n", selector(signature), i)
+		fmt.Fprintf(&bodies, "function%d: STOP\n", i)
+	}
+
+	i := 0
+	for signature, value := range getters {
+		fmt.Fprintf(&dispatcher, "DUP1 %s EQ @getter%d JUMPI\n", selector(signature), i)
+		fmt.Fprintf(&bodies, "getter%d: %s SLOAD 0x00 MSTORE 0x20 0x00 RETURN\n", i, slot(value))
+		i++
+	}
+
+	source := fmt.Sprintf(`
+		0x00 CALLDATALOAD 0xe0 SHR
+		DUP1 %[1]s EQ @balanceOf JUMPI
+		DUP1 %[2]s EQ @transfer JUMPI
+		DUP1 %[3]s EQ @totalSupply JUMPI
+		DUP1 %[16]s EQ @approve JUMPI
+		DUP1 %[17]s EQ @transferFrom JUMPI
+		%[4]s
+		0x00 DUP1 REVERT
+
+		balanceOf: 0x04 CALLDATALOAD SLOAD 0x00 MSTORE 0x20 0x00 RETURN
+		totalSupply: %[5]s SLOAD 0x00 MSTORE 0x20 0x00 RETURN
+		%[6]s
+
+		approve:
+		CALLER 0x00 MSTORE 0x04 CALLDATALOAD 0x20 MSTORE
+		0x24 CALLDATALOAD 0x40 0x00 KECCAK256 SSTORE
+		0x01 0x00 MSTORE 0x20 0x00 RETURN
+
+		transferFrom:
+		%[18]s SLOAD @fail JUMPI
+		0x04 CALLDATALOAD 0xc0 MSTORE
+		0x24 CALLDATALOAD 0x80 MSTORE
+		0x44 CALLDATALOAD 0xa0 MSTORE
+		0xc0 MLOAD 0x00 MSTORE CALLER 0x20 MSTORE
+		0x40 0x00 KECCAK256 DUP1 SLOAD
+		DUP1 0xa0 MLOAD GT @fail JUMPI
+		0xa0 MLOAD SWAP1 SUB SWAP1 SSTORE
+		@move JUMP
+
+		transfer:
+		0x04 CALLDATALOAD 0x80 MSTORE
+		0x24 CALLDATALOAD 0xa0 MSTORE
+		CALLER 0xc0 MSTORE
+
+		move:
+
+		%[7]s SLOAD 0xc0 MLOAD EQ %[7]s SLOAD 0x80 MLOAD EQ OR %[8]s SLOAD AND @tradingDisabled JUMPI
+		%[7]s SLOAD 0x80 MLOAD EQ %[9]s SLOAD AND @fail JUMPI
+		%[10]s SLOAD ISZERO ISZERO %[10]s SLOAD 0xa0 MLOAD GT AND @fail JUMPI
+		0xa0 MLOAD 0xc0 MLOAD SLOAD LT @fail JUMPI
+		0xa0 MLOAD 0xc0 MLOAD SLOAD SUB 0xc0 MLOAD SSTORE
+
+		%[11]s SLOAD 0xe0 MSTORE
+		%[7]s SLOAD 0x80 MLOAD EQ ISZERO @notSell JUMPI
+		%[12]s SLOAD 0xe0 MSTORE
+		notSell:
+		%[7]s SLOAD 0xc0 MLOAD EQ ISZERO @notBuy JUMPI
+		%[13]s SLOAD 0xe0 MSTORE
+		notBuy:
+		0x2710 0xe0 MLOAD 0xa0 MLOAD MUL DIV 0xa0 MLOAD SUB
+		0x80 MLOAD SLOAD ADD 0x80 MLOAD SSTORE
+
+		%[14]s SLOAD ISZERO ISZERO %[7]s SLOAD 0x80 MLOAD EQ ISZERO AND %[14]s SLOAD 0x80 MLOAD SLOAD GT AND @fail JUMPI
+		0x01 0x00 MSTORE 0x20 0x00 RETURN
+
+		fail: 0x00 DUP1 REVERT
+
+		tradingDisabled:
+		0x08c379a0 0xe0 SHL 0x00 MSTORE 0x20 0x04 MSTORE 0x13 0x24 MSTORE
+		0x%[15]x 0x44 MSTORE
+		0x64 0x00 REVERT
+	`,
+		selector("balanceOf(address)"), selector("transfer(address,uint256)"), selector("totalSupply()"),
+		dispatcher.String(), slot(tokenSupplySlot), bodies.String(),
+		slot(tokenPairSlot), slot(tokenTradingOffSlot), slot(tokenSellBlockedSlot), slot(tokenMaxTxSlot),
+		slot(tokenTransferTaxSlot), slot(tokenSellTaxSlot), slot(tokenBuyTaxSlot), slot(tokenMaxWalletSlot),
+		common.RightPadBytes([]byte("trading not enabled"), 32),
+		selector("approve(address,uint256)"), selector("transferFrom(address,address,uint256)"), slot(tokenNoAllowanceSlot),
+	)
+
+	return assemble(t, source)
+}
+
+// pairCode returns the runtime code of a pair storing its reserves in the first two slots. Swaps pay the amounts
+// out, reverting if a transfer fails, and sync the reserves without checking what was paid in.
+func pairCode(t *testing.T, token0, token1 common.Address) []byte {
+	transfer := func(token common.Address, amount string) string {
+		return fmt.Sprintf(`
+			%[1]s ISZERO @skip%[2]x JUMPI
+			%[3]s 0xe0 SHL 0x00 MSTORE 0x44 CALLDATALOAD 0x04 MSTORE %[1]s 0x24 MSTORE
+			0x00 0x00 0x44 0x00 0x00 0x%[2]x GAS CALL ISZERO @fail JUMPI
+			skip%[2]x:
+		`, amount, token.Bytes(), selector("transfer(address,uint256)"))
+	}
+
+	sync := func(token common.Address, reserve string) string {
+		return fmt.Sprintf(`
+			%s 0xe0 SHL 0x00 MSTORE ADDRESS 0x04 MSTORE
+			0x20 0x40 0x24 0x00 0x%x GAS STATICCALL ISZERO @fail JUMPI
+			0x40 MLOAD %s SSTORE
+		`, selector("balanceOf(address)"), token.Bytes(), reserve)
+	}
+
+	return assemble(t, fmt.Sprintf(`
+		0x00 CALLDATALOAD 0xe0 SHR
+		DUP1 %s EQ @token0 JUMPI
+		DUP1 %s EQ @token1 JUMPI
+		DUP1 %s EQ @getReserves JUMPI
+		DUP1 %s EQ @swap JUMPI
+		DUP1 %s EQ @sync JUMPI
+		STOP
+		token0: 0x%x 0x00 MSTORE 0x20 0x00 RETURN
+		token1: 0x%x 0x00 MSTORE 0x20 0x00 RETURN
+		getReserves: 0x00 SLOAD 0x00 MSTORE 0x01 SLOAD 0x20 MSTORE 0x00 0x40 MSTORE 0x60 0x00 RETURN
+		swap: %s %s
+		sync: %s %s STOP
+		fail: 0x00 DUP1 REVERT
+	`,
+		selector("token0()"), selector("token1()"), selector("getReserves()"),
+		selector("swap(uint256,uint256,address,bytes)"), selector("sync()"),
+		token0.Bytes(), token1.Bytes(),
+		transfer(token0, "0x04 CALLDATALOAD"), transfer(token1, "0x24 CALLDATALOAD"),
+		sync(token0, "0x00"), sync(token1, "0x01"),
+	))
+}
+
+func TestAnalyzeSafety(t *testing.T) {
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	units := func(amount int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(amount), ether)
+	}
+
+	tokenAddr := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	pairAddr := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	baseAddr := common.HexToAddress("0x3000000000000000000000000000000000000003")
+
+	testCases := []struct {
+		name               string
+		config             map[*big.Int]*big.Int
+		getters            map[string]*big.Int
+		functions          []string
+		state              utils.SafetyStateType
+		honeypot           bool
+		buyTax             float64
+		sellTax            float64
+		transferTax        float64
+		buyFails           bool
+		maxTransaction     *big.Int
+		maxWallet          *big.Int
+		antiWhale          []utils.AntiWhaleType
+		blacklists         []utils.BlacklistType
+		blacklistFunctions []string
+		tradingFunctions   []string
+	}{
+		{
+			name:       "Safe Token",
+			state:      utils.SafeSafetyState,
+			antiWhale:  []utils.AntiWhaleType{},
+			blacklists: []utils.BlacklistType{},
+		},
+		{
+			name: "Taxed Token",
+			config: map[*big.Int]*big.Int{
+				tokenBuyTaxSlot:      big.NewInt(500),
+				tokenSellTaxSlot:     big.NewInt(1_500),
+				tokenTransferTaxSlot: big.NewInt(100),
+			},
+			state:       utils.WarnSafetyState,
+			buyTax:      5,
+			sellTax:     15,
+			transferTax: 1,
+			antiWhale:   []utils.AntiWhaleType{},
+			blacklists:  []utils.BlacklistType{utils.HighTaxTokenBlacklistType},
+		},
+		{
+			name: "Honeypot Blocking Sells",
+			config: map[*big.Int]*big.Int{
+				tokenSellBlockedSlot: big.NewInt(1),
+			},
+			state:      utils.UnsafeSafetyState,
+			honeypot:   true,
+			antiWhale:  []utils.AntiWhaleType{},
+			blacklists: []utils.BlacklistType{utils.HoneypotBlacklistType},
+		},
+		{
+			name: "Honeypot Reverting TransferFrom",
+			config: map[*big.Int]*big.Int{
+				tokenNoAllowanceSlot: big.NewInt(1),
+			},
+			state:      utils.UnsafeSafetyState,
+			honeypot:   true,
+			antiWhale:  []utils.AntiWhaleType{},
+			blacklists: []utils.BlacklistType{utils.HoneypotBlacklistType},
+		},
+		{
+			name: "Honeypot Taxing Sells",
+			config: map[*big.Int]*big.Int{
+				tokenSellTaxSlot: big.NewInt(9_900),
+			},
+			state:      utils.UnsafeSafetyState,
+			honeypot:   true,
+			sellTax:    99,
+			antiWhale:  []utils.AntiWhaleType{},
+			blacklists: []utils.BlacklistType{utils.HoneypotBlacklistType, utils.HighTaxTokenBlacklistType},
+		},
+		{
+			name: "Max Transaction Getter",
+			config: map[*big.Int]*big.Int{
+				tokenMaxTxSlot: units(50_000),
+			},
+			getters: map[string]*big.Int{
+				"_maxTxAmount()": tokenMaxTxSlot,
+			},
+			state:          utils.WarnSafetyState,
+			maxTransaction: units(50_000),
+			antiWhale:      []utils.AntiWhaleType{utils.AntiWhaleMaxTransaction},
+			blacklists:     []utils.BlacklistType{},
+		},
+		{
+			name: "Max Wallet Probed",
+			config: map[*big.Int]*big.Int{
+				tokenMaxWalletSlot: units(30_000),
+			},
+			state:      utils.WarnSafetyState,
+			maxWallet:  units(30_000),
+			antiWhale:  []utils.AntiWhaleType{utils.AntiWhaleMaxWallet},
+			blacklists: []utils.BlacklistType{},
+		},
+		{
+			name: "Trading Disabled",
+			config: map[*big.Int]*big.Int{
+				tokenTradingOffSlot: big.NewInt(1),
+			},
+			functions:        []string{"enableTrading()"},
+			state:            utils.UnsafeSafetyState,
+			buyFails:         true,
+			antiWhale:        []utils.AntiWhaleType{},
+			blacklists:       []utils.BlacklistType{utils.TradingToggleBlacklistType},
+			tradingFunctions: []string{"enableTrading()"},
+		},
+		{
+			name:               "Blacklist Function",
+			functions:          []string{"setBots(address[],bool)", "isBot(address)"},
+			state:              utils.WarnSafetyState,
+			antiWhale:          []utils.AntiWhaleType{},
+			blacklists:         []utils.BlacklistType{utils.HolderBlacklistType},
+			blacklistFunctions: []string{"setBots(address[],bool)", "isBot(address)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sim, err := simulator.NewSimulator(ctx, utils.Ethereum, nil, simulator.NewDefaultOptions())
+			require.NoError(t, err)
+			defer sim.Close()
+
+			storage := map[*big.Int]*big.Int{
+				tokenSupplySlot: units(10_000_000),
+				tokenPairSlot:   pairAddr.Big(),
+				pairAddr.Big():  units(1_000_000),
+			}
+			for slot, value := range tc.config {
+				storage[slot] = value
+			}
+
+			require.NoError(t, sim.SetCode(tokenAddr, tokenCode(t, tc.getters, tc.functions...)))
+			for slot, value := range storage {
+				require.NoError(t, sim.SetStorageAt(tokenAddr, common.BigToHash(slot), common.BigToHash(value)))
+			}
+
+			// The base token is an untaxed token paired with nothing.
+			require.NoError(t, sim.SetCode(baseAddr, tokenCode(t, nil)))
+			require.NoError(t, sim.SetStorageAt(baseAddr, common.BigToHash(tokenSupplySlot), common.BigToHash(units(1_000_000))))
+			require.NoError(t, sim.SetStorageAt(baseAddr, common.BigToHash(pairAddr.Big()), common.BigToHash(units(1_000))))
+
+			require.NoError(t, sim.SetCode(pairAddr, pairCode(t, baseAddr, tokenAddr)))
+			require.NoError(t, sim.SetStorageAt(pairAddr, common.Hash{}, common.BigToHash(units(1_000))))
+			require.NoError(t, sim.SetStorageAt(pairAddr, common.BigToHash(common.Big1), common.BigToHash(units(1_000_000))))
+
+			manager, err := bindings.NewManager(ctx, sim.GetClientPool())
+			require.NoError(t, err)
+
+			token, err := NewToken(ctx, utils.Ethereum, tokenAddr, manager, sim.GetClientPool())
+			require.NoError(t, err)
+
+			opts := NewDefaultSafetyOptions()
+			opts.Simulator = sim
+			opts.Pair = pairAddr
+
+			simulatorType := token.GetSimulatorType()
+			safety, err := token.AnalyzeSafety(ctx, opts)
+			require.NoError(t, err)
+			require.NotNil(t, safety)
+
+			assert.Equal(t, safety, token.GetDescriptor().GetSafety())
+			assert.False(t, token.IsInSimulation())
+			assert.Equal(t, simulatorType, token.GetSimulatorType())
+			assert.Equal(t, pairAddr, safety.Pair)
+			assert.Equal(t, baseAddr, safety.BaseToken)
+			assert.NotEmpty(t, safety.Evidence)
+
+			assert.Equal(t, tc.state, safety.State, "evidence: %v", safety.Evidence)
+			assert.Equal(t, tc.honeypot, safety.Honeypot)
+			assert.Equal(t, tc.buyTax, safety.BuyTax)
+			assert.Equal(t, tc.sellTax, safety.SellTax)
+			assert.Equal(t, tc.transferTax, safety.TransferTax)
+			assert.Equal(t, tc.antiWhale, safety.AntiWhale)
+			assert.ElementsMatch(t, tc.blacklists, safety.Blacklists)
+
+			if tc.blacklistFunctions != nil {
+				assert.ElementsMatch(t, tc.blacklistFunctions, safety.BlacklistFunctions)
+			} else {
+				assert.Empty(t, safety.BlacklistFunctions)
+			}
+
+			if tc.tradingFunctions != nil {
+				assert.ElementsMatch(t, tc.tradingFunctions, safety.TradingFunctions)
+			} else {
+				assert.Empty(t, safety.TradingFunctions)
+			}
+
+			buy := safety.GetSimulation(utils.BuyTradeType)
+			require.NotNil(t, buy)
+			assert.Equal(t, !tc.buyFails, buy.Success)
+			if tc.buyFails {
+				assert.Contains(t, buy.Error, "trading not enabled")
+				assert.Nil(t, safety.GetSimulation(utils.SellTradeType))
+			} else {
+				assert.Equal(t, units(10_000), buy.Amount)
+				assert.NotNil(t, safety.GetSimulation(utils.TransferTradeType))
+
+				sell := safety.GetSimulation(utils.SellTradeType)
+				require.NotNil(t, sell)
+				assert.Equal(t, !tc.honeypot || tc.sellTax > 0, sell.Success, "error: %s", sell.Error)
+
+				// Sells swap the tokens for base tokens paid to the seller.
+				data, err := safetyABI.Pack("balanceOf", sell.From)
+				require.NoError(t, err)
+				result, err := sim.GetClient().CallContract(ctx, ethereum.CallMsg{To: &baseAddr, Data: data}, nil)
+				require.NoError(t, err)
+				assert.Equal(t, sell.Success, new(big.Int).SetBytes(result).Sign() > 0)
+			}
+
+			assertLimit := func(expected, actual *big.Int) {
+				if expected == nil {
+					assert.Nil(t, actual)
+					return
+				}
+
+				// Probed limits are precise to a thousandth of the traded amount.
+				require.NotNil(t, actual)
+				diff := new(big.Int).Abs(new(big.Int).Sub(expected, actual))
+				assert.True(t, diff.Cmp(units(10)) <= 0, "expected limit %s, got %s", expected, actual)
+			}
+			assertLimit(tc.maxTransaction, safety.MaxTransaction)
+			assertLimit(tc.maxWallet, safety.MaxWallet)
+		})
+	}
+}
@@ -7,9 +7,17 @@ import (
 
 // SimplifyImportPaths simplifies the paths in import statements as file will already be present in the
 // directory for future consumption and is rather corrupted for import paths to stay the same.
+// Unit aliases (`import * as X from "path";` and `import "path" as X;`) are preserved as they are
+// required for the aliased references to resolve.
 func SimplifyImportPaths(content string) string {
 	re := regexp.MustCompile(`import (?:{[^}]+} from )?[\"\']([^\"\']+/([^/]+\.sol))[\"\'];`)
-	return re.ReplaceAllString(content, `import "./$2";`)
+	content = re.ReplaceAllString(content, `import "./$2";`)
+
+	aliasRe := regexp.MustCompile(`import\s+\*\s*as\s+(\w+)\s+from\s+[\"\'](?:[^\"\']+/)?([^/\"\']+\.sol)[\"\']\s*;`)
+	content = aliasRe.ReplaceAllString(content, `import "./$2" as $1;`)
+
+	unitAliasRe := regexp.MustCompile(`import\s+[\"\'](?:[^\"\']+/)?([^/\"\']+\.sol)[\"\']\s+as\s+(\w+)\s*;`)
+	return unitAliasRe.ReplaceAllString(content, `import "./$1" as $2;`)
 }
 
 // StripImportPaths removes the import paths entirely from the content.
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestSimplifyImportPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain import",
+			input: `import "@openzeppelin/contracts/token/ERC20/IERC20.sol";`,
+			want:  `import "./IERC20.sol";`,
+		},
+		{
+			name:  "symbol import",
+			input: `import {IERC20, IERC20Metadata} from "@openzeppelin/contracts/token/ERC20/IERC20.sol";`,
+			want:  `import "./IERC20.sol";`,
+		},
+		{
+			name:  "wildcard alias import",
+			input: `import * as Tokens from "@openzeppelin/contracts/token/ERC20/IERC20.sol";`,
+			want:  `import "./IERC20.sol" as Tokens;`,
+		},
+		{
+			name:  "wildcard alias import from nested relative path",
+			input: `import * as Math from '../../libraries/math/FullMath.sol';`,
+			want:  `import "./FullMath.sol" as Math;`,
+		},
+		{
+			name:  "wildcard alias import from current directory",
+			input: `import * as Lib from "Lib.sol";`,
+			want:  `import "./Lib.sol" as Lib;`,
+		},
+		{
+			name:  "unit alias import",
+			input: `import "@openzeppelin/contracts/utils/Address.sol" as AddressLib;`,
+			want:  `import "./Address.sol" as AddressLib;`,
+		},
+		{
+			name:  "unit alias import from nested relative path",
+			input: `import "../../libraries/math/FullMath.sol" as Math;`,
+			want:  `import "./FullMath.sol" as Math;`,
+		},
+		{
+			name: "alias imports mixed with symbol imports",
+			input: `pragma solidity ^0.8.0;
+
+import {IERC20, IERC20Metadata} from "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import * as Math from "../libraries/math/FullMath.sol";
+import "./utils/Address.sol" as AddressLib;
+import "../interfaces/IPool.sol";
+
+contract Vault {}`,
+			want: `pragma solidity ^0.8.0;
+
+import "./IERC20.sol";
+import "./FullMath.sol" as Math;
+import "./Address.sol" as AddressLib;
+import "./IPool.sol";
+
+contract Vault {}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SimplifyImportPaths(tt.input)
+			if got != tt.want {
+				t.Errorf("SimplifyImportPaths(%q) = %q; want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -50,8 +50,8 @@ func (t AccountType) String() string {
 }
 
 // SimulatorType represents the types of simulators used for transaction execution or testing.
-// Types include "no_simulator", "anvil" for the Anvil Ethereum simulator, and "trace" for simulators
-// that support tracing capabilities.
+// Types include "no_simulator", "anvil" for the Anvil Ethereum simulator, "trace" for simulators
+// that support tracing capabilities, and "local" for the in-process simulator.
 type SimulatorType string
 
 // String returns the string representation of a SimulatorType.
@@ -78,7 +78,7 @@ func (t TokenType) String() string {
 }
 
 // AntiWhaleType represents types of anti-whale measures, such as "pinksale" for specific
-// project launches with anti-whale features.
+// project launches with anti-whale features, or "max_transaction" and "max_wallet" limits.
 type AntiWhaleType string
 
 // String returns the string representation of an AntiWhaleType.
@@ -86,7 +86,7 @@ func (t AntiWhaleType) String() string {
 	return string(t)
 }
 
-// TradeType defines the type of trade action, including "buy" and "sell".
+// TradeType defines the type of trade action, including "buy", "sell" and wallet to wallet "transfer".
 type TradeType string
 
 // String returns the string representation of a TradeType.
@@ -104,7 +104,8 @@ func (t SafetyStateType) String() string {
 }
 
 // BlacklistType defines types of blacklist categories for tokens or contracts,
-// such as "rugpull", "honeypot", and others related to specific risks.
+// such as "rugpull", "honeypot", and others related to specific risks, including tokens
+// able to blacklist holders or to toggle trading.
 type BlacklistType string
 
 // String returns the string representation of a BlacklistType.
@@ -137,6 +138,7 @@ const (
 	NoSimulator    SimulatorType = "no_simulator"
 	AnvilSimulator SimulatorType = "anvil"
 	TraceSimulator SimulatorType = "trace"
+	LocalSimulator SimulatorType = "local"
 
 	SimulatorAccountType AccountType = "simulator"
 	SimpleAccountType    AccountType = "simple"
@@ -151,10 +153,13 @@ const (
 	Erc20TokenType  TokenType = "erc20"
 	Erc721TokenType TokenType = "erc721"
 
-	AntiWhalePinksale AntiWhaleType = "pinksale"
+	AntiWhalePinksale       AntiWhaleType = "pinksale"
+	AntiWhaleMaxTransaction AntiWhaleType = "max_transaction"
+	AntiWhaleMaxWallet      AntiWhaleType = "max_wallet"
 
-	BuyTradeType  TradeType = "buy"
-	SellTradeType TradeType = "sell"
+	BuyTradeType      TradeType = "buy"
+	SellTradeType     TradeType = "sell"
+	TransferTradeType TradeType = "transfer"
 
 	UnknownSafetyState SafetyStateType = "unknown"
 	SafeSafetyState    SafetyStateType = "safe"
@@ -166,6 +171,8 @@ const (
 	HighTaxTokenBlacklistType     BlacklistType = "high_tax_token"
 	PumpAndDumpTokenBlacklistType BlacklistType = "pump_and_dump_token"
 	MixerUsageBlacklistType       BlacklistType = "mixer_usage"
+	HolderBlacklistType           BlacklistType = "holder_blacklist"
+	TradingToggleBlacklistType    BlacklistType = "trading_toggle"
 )
 
 // ZeroSignatureBytes and ZeroSignature represent a null signature in Ethereum transactions.
@@ -0,0 +1,344 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/unpackdev/solgo/bytecode"
+	"github.com/unpackdev/solgo/opcode"
+)
+
+// MatchType classifies how the on-chain bytecode matches the compiled bytecode.
+type MatchType string
+
+const (
+	// MatchFull is reported when the bytecode matches apart from immutables, linked libraries and constructor arguments.
+	MatchFull MatchType = "full"
+	// MatchPartial is reported when the bytecode differs only within the CBOR metadata, such as the metadata hash.
+	MatchPartial MatchType = "partial"
+	// MatchNone is reported when the executable code differs.
+	MatchNone MatchType = "mismatch"
+)
+
+// RegionKind describes what a region of the bytecode holds.
+type RegionKind string
+
+const (
+	// RegionCode is executable code outside any of the other regions.
+	RegionCode RegionKind = "code"
+	// RegionImmutable holds the value of an immutable variable, set by the constructor.
+	RegionImmutable RegionKind = "immutable"
+	// RegionLibrary holds the address of a linked library.
+	RegionLibrary RegionKind = "library"
+	// RegionMetadata holds the CBOR encoded metadata appended by the compiler.
+	RegionMetadata RegionKind = "metadata"
+	// RegionConstructorArguments holds the ABI encoded constructor arguments appended to the creation bytecode.
+	RegionConstructorArguments RegionKind = "constructor_arguments"
+	// RegionCallProtection holds the address of a deployed library, pushed at the start of its runtime code to
+	// prevent state modifying functions from being called other than through DELEGATECALL.
+	RegionCallProtection RegionKind = "call_protection"
+)
+
+// libraryPlaceholderLength is the length of library placeholders within the compiled bytecode, in hex characters.
+const libraryPlaceholderLength = 40
+
+// ByteRange is a range of the bytecode, as reported by the compiler within immutable and link references.
+type ByteRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// BytecodeReferences holds the positions of immutables and libraries within the compiled bytecode,
+// in the shape of the evm.deployedBytecode compiler output.
+type BytecodeReferences struct {
+	ImmutableReferences map[string][]ByteRange            `json:"immutableReferences,omitempty"` // Keyed by the AST id of the immutable.
+	LinkReferences      map[string]map[string][]ByteRange `json:"linkReferences,omitempty"`      // Keyed by the source unit and library name.
+}
+
+// NewBytecodeReferencesFromJSON loads the references from the evm.bytecode or evm.deployedBytecode compiler output.
+func NewBytecodeReferencesFromJSON(data []byte) (*BytecodeReferences, error) {
+	var toReturn BytecodeReferences
+	if err := json.Unmarshal(data, &toReturn); err != nil {
+		return nil, fmt.Errorf("failed to decode bytecode references: %w", err)
+	}
+	return &toReturn, nil
+}
+
+// Region is a range of the compiled bytecode compared against the on-chain bytecode.
+type Region struct {
+	Kind    RegionKind `json:"kind"`            // Kind is what the region holds.
+	Name    string     `json:"name,omitempty"`  // Name is the AST id of the immutable or the name of the library.
+	Start   int        `json:"start"`           // Start is the offset of the region, in bytes.
+	Length  int        `json:"length"`          // Length is the length of the region, in bytes.
+	Value   string     `json:"value,omitempty"` // Value is the hex encoded on-chain content of the region.
+	Matches bool       `json:"matches"`         // Matches is false if the region differs from the compiled bytecode.
+}
+
+// BytecodeComparison is the region-aware comparison of the on-chain bytecode against the compiled bytecode.
+type BytecodeComparison struct {
+	Match                MatchType                 `json:"match"`                           // Match classifies the comparison.
+	Regions              []*Region                 `json:"regions"`                         // Regions holds the masked regions and code that differs, ordered by offset.
+	Immutables           map[string]string         `json:"immutables,omitempty"`            // Immutables holds the hex encoded on-chain values of immutables.
+	Libraries            map[string]common.Address `json:"libraries,omitempty"`             // Libraries holds the on-chain addresses of linked libraries.
+	ConstructorArguments []byte                    `json:"constructor_arguments,omitempty"` // ConstructorArguments holds the bytes appended to the compiled bytecode.
+}
+
+// GetMatch returns the classification of the comparison.
+func (c *BytecodeComparison) GetMatch() MatchType {
+	return c.Match
+}
+
+// GetRegions returns the masked regions and code that differs, ordered by offset.
+func (c *BytecodeComparison) GetRegions() []*Region {
+	return c.Regions
+}
+
+// GetMismatchedRegions returns the regions that differ from the compiled bytecode.
+func (c *BytecodeComparison) GetMismatchedRegions() []*Region {
+	toReturn := make([]*Region, 0)
+	for _, region := range c.Regions {
+		if !region.Matches {
+			toReturn = append(toReturn, region)
+		}
+	}
+	return toReturn
+}
+
+// GetImmutables returns the hex encoded on-chain values of immutables.
+func (c *BytecodeComparison) GetImmutables() map[string]string {
+	return c.Immutables
+}
+
+// GetLibraries returns the on-chain addresses of linked libraries.
+func (c *BytecodeComparison) GetLibraries() map[string]common.Address {
+	return c.Libraries
+}
+
+// GetConstructorArguments returns the bytes appended to the compiled bytecode.
+func (c *BytecodeComparison) GetConstructorArguments() []byte {
+	return c.ConstructorArguments
+}
+
+// CompareBytecode compares the on-chain bytecode against the hex encoded compiled bytecode region by region.
+// Immutables, libraries and the call protection of libraries are masked out and their on-chain values extracted, the CBOR metadata is compared
+// separately from the code and bytes past the compiled bytecode are treated as constructor arguments.
+// References are optional. Without them library placeholders are located within the compiled bytecode and
+// immutables are located as PUSH32 instructions with zero arguments, which is how the compiler emits them.
+func CompareBytecode(onchain []byte, compiled string, references *BytecodeReferences) (*BytecodeComparison, error) {
+	compiled = strings.TrimPrefix(compiled, "0x")
+	libraries, compiled := extractLibraryPlaceholders(compiled, references)
+
+	code, err := hex.DecodeString(compiled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode compiled bytecode: %w", err)
+	}
+
+	toReturn := &BytecodeComparison{
+		Match:      MatchFull,
+		Regions:    make([]*Region, 0),
+		Immutables: make(map[string]string),
+		Libraries:  make(map[string]common.Address),
+	}
+
+	masked := make([]bool, len(code))
+	metadataStart := metadataOffset(code)
+	for i := metadataStart; i < len(code); i++ {
+		masked[i] = true
+	}
+
+	regions := append(libraries, immutableRegions(code[:metadataStart], references)...)
+	if region := callProtectionRegion(code); region != nil {
+		regions = append(regions, region)
+	}
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].Start < regions[j].Start
+	})
+
+	for _, region := range regions {
+		if region.Start < 0 || region.Start+region.Length > len(code) {
+			return nil, fmt.Errorf("%s %s at offset %d is out of compiled bytecode bounds", region.Kind, region.Name, region.Start)
+		}
+		for i := region.Start; i < region.Start+region.Length; i++ {
+			masked[i] = true
+		}
+
+		toReturn.Regions = append(toReturn.Regions, region)
+		if region.Start+region.Length > len(onchain) {
+			continue
+		}
+
+		value := onchain[region.Start : region.Start+region.Length]
+		region.Value = hex.EncodeToString(value)
+		region.Matches = true
+
+		// The same immutable or library can be referenced multiple times, each of which must hold the same value.
+		switch region.Kind {
+		case RegionImmutable:
+			if existing, ok := toReturn.Immutables[region.Name]; ok && existing != region.Value {
+				region.Matches = false
+			} else {
+				toReturn.Immutables[region.Name] = region.Value
+			}
+		case RegionLibrary:
+			if existing, ok := toReturn.Libraries[region.Name]; ok && existing != common.BytesToAddress(value) {
+				region.Matches = false
+			} else {
+				toReturn.Libraries[region.Name] = common.BytesToAddress(value)
+			}
+		}
+	}
+
+	// Consecutive differing bytes of the code outside masked regions are reported as a single region.
+	var mismatch *Region
+	for i := 0; i < len(code); i++ {
+		if !masked[i] && (i >= len(onchain) || onchain[i] != code[i]) {
+			if mismatch == nil {
+				mismatch = &Region{Kind: RegionCode, Start: i}
+				toReturn.Regions = append(toReturn.Regions, mismatch)
+			}
+			mismatch.Length++
+			continue
+		}
+		mismatch = nil
+	}
+
+	if metadataStart < len(code) {
+		region := &Region{Kind: RegionMetadata, Start: metadataStart, Length: len(code) - metadataStart}
+		if len(onchain) >= len(code) {
+			region.Value = hex.EncodeToString(onchain[metadataStart:len(code)])
+			region.Matches = bytes.Equal(onchain[metadataStart:len(code)], code[metadataStart:])
+		}
+		toReturn.Regions = append(toReturn.Regions, region)
+	}
+
+	if len(onchain) > len(code) {
+		toReturn.ConstructorArguments = onchain[len(code):]
+		toReturn.Regions = append(toReturn.Regions, &Region{
+			Kind:    RegionConstructorArguments,
+			Start:   len(code),
+			Length:  len(toReturn.ConstructorArguments),
+			Value:   hex.EncodeToString(toReturn.ConstructorArguments),
+			Matches: true,
+		})
+	}
+
+	sort.SliceStable(toReturn.Regions, func(i, j int) bool {
+		return toReturn.Regions[i].Start < toReturn.Regions[j].Start
+	})
+
+	for _, region := range toReturn.GetMismatchedRegions() {
+		if region.Kind != RegionMetadata || len(onchain) < len(code) {
+			toReturn.Match = MatchNone
+			break
+		}
+		toReturn.Match = MatchPartial
+	}
+
+	return toReturn, nil
+}
+
+// extractLibraryPlaceholders locates library placeholders within the hex encoded compiled bytecode and returns
+// their regions along with the bytecode where placeholders are replaced with zero addresses. Libraries are named
+// after link references when these are provided, otherwise after the placeholders.
+func extractLibraryPlaceholders(compiled string, references *BytecodeReferences) ([]*Region, string) {
+	toReturn := make([]*Region, 0)
+
+	names := make(map[int]string)
+	if references != nil {
+		for source, libraries := range references.LinkReferences {
+			for library, ranges := range libraries {
+				for _, position := range ranges {
+					names[position.Start] = source + ":" + library
+				}
+			}
+		}
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(compiled); {
+		if i%2 != 0 || !strings.HasPrefix(compiled[i:], "__") || i+libraryPlaceholderLength > len(compiled) {
+			builder.WriteByte(compiled[i])
+			i++
+			continue
+		}
+
+		placeholder := compiled[i : i+libraryPlaceholderLength]
+		name, ok := names[i/2]
+		if !ok {
+			name = strings.Trim(placeholder, "_$")
+		}
+
+		toReturn = append(toReturn, &Region{Kind: RegionLibrary, Name: name, Start: i / 2, Length: common.AddressLength})
+		builder.WriteString(strings.Repeat("0", libraryPlaceholderLength))
+		i += libraryPlaceholderLength
+	}
+
+	return toReturn, builder.String()
+}
+
+// callProtectionRegion returns the region of the library call protection, if the code starts with it. The compiler
+// emits the runtime code of libraries as PUSH20 with a zero address followed by ADDRESS EQ, and the zero address is
+// replaced with the address of the library when it is deployed.
+func callProtectionRegion(code []byte) *Region {
+	if len(code) < common.AddressLength+3 || opcode.OpCode(code[0]) != opcode.PUSH20 {
+		return nil
+	}
+
+	if !bytes.Equal(code[1:common.AddressLength+1], make([]byte, common.AddressLength)) ||
+		opcode.OpCode(code[common.AddressLength+1]) != opcode.ADDRESS || opcode.OpCode(code[common.AddressLength+2]) != opcode.EQ {
+		return nil
+	}
+
+	return &Region{Kind: RegionCallProtection, Start: 1, Length: common.AddressLength}
+}
+
+// immutableRegions returns the regions of immutables within the code. Without immutable references, immutables are
+// located as PUSH32 instructions with zero arguments, which the compiler never emits for constants.
+func immutableRegions(code []byte, references *BytecodeReferences) []*Region {
+	toReturn := make([]*Region, 0)
+
+	if references != nil && references.ImmutableReferences != nil {
+		for id, ranges := range references.ImmutableReferences {
+			for _, position := range ranges {
+				toReturn = append(toReturn, &Region{Kind: RegionImmutable, Name: id, Start: position.Start, Length: position.Length})
+			}
+		}
+		return toReturn
+	}
+
+	zero := make([]byte, 32)
+	for i := 0; i < len(code); i++ {
+		op := opcode.OpCode(code[i])
+		if !op.IsPush() {
+			continue
+		}
+
+		size := int(op-opcode.PUSH1) + 1
+		if op == opcode.PUSH32 && i+1+size <= len(code) && bytes.Equal(code[i+1:i+1+size], zero) {
+			toReturn = append(toReturn, &Region{Kind: RegionImmutable, Name: fmt.Sprintf("%d", i+1), Start: i + 1, Length: size})
+		}
+		i += size
+	}
+
+	return toReturn
+}
+
+// metadataOffset returns the offset of the CBOR metadata appended to the code, or the length of the code if the
+// code does not end with metadata.
+func metadataOffset(code []byte) int {
+	if len(code) < 2 {
+		return len(code)
+	}
+
+	metadata, err := bytecode.DecodeContractMetadata(code)
+	if err != nil || metadata.IsPartial() {
+		return len(code)
+	}
+
+	return len(code) - int(metadata.GetCborLength()) - 2
+}
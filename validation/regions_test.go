@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareBytecode(t *testing.T) {
+	placeholder := "__$7f3b4c5d6e7f8091a2b3c4d5e6f7081929$__"
+	library := "1111111111111111111111111111111111111111"
+	immutable := strings.Repeat("00", 31) + "2a"
+	metadata := "a2646970667358221220e27ebb8a52fb9a56833f8bc098c1e940d6f786c2fdecc619def204c20ad5006664736f6c6343000800"
+	otherMetadata := "a264697066735822122075b52d263e178d92ba189a257f5531f21895522fcb964483ecd847d00565385164736f6c6343000800"
+
+	// PUSH1 0x80 PUSH1 0x40 MSTORE PUSH32 <immutable> PUSH20 <library> STOP INVALID <metadata> <metadata length>
+	compiled := func(immutable, library, metadata string) string {
+		return "6080604052" + "7f" + immutable + "73" + library + "00fe" + metadata + "0033"
+	}
+	onchain := func(code string) []byte {
+		b, err := hex.DecodeString(code)
+		require.NoError(t, err)
+		return b
+	}
+
+	testCases := []struct {
+		name           string
+		onchain        []byte
+		compiled       string
+		references     *BytecodeReferences
+		wantErr        bool
+		wantMatch      MatchType
+		wantMismatched []RegionKind
+		wantImmutables map[string]string
+		wantLibraries  map[string]common.Address
+		wantArguments  []byte
+	}{
+		{
+			name:           "Full Match Without References",
+			onchain:        onchain(compiled(immutable, library, metadata)),
+			compiled:       compiled(strings.Repeat("00", 32), placeholder, metadata),
+			wantMatch:      MatchFull,
+			wantMismatched: []RegionKind{},
+			wantImmutables: map[string]string{"6": immutable},
+			wantLibraries:  map[string]common.Address{"7f3b4c5d6e7f8091a2b3c4d5e6f7081929": common.HexToAddress(library)},
+		},
+		{
+			name:     "Full Match With References",
+			onchain:  onchain(compiled(immutable, library, metadata)),
+			compiled: compiled(strings.Repeat("00", 32), placeholder, metadata),
+			references: &BytecodeReferences{
+				ImmutableReferences: map[string][]ByteRange{"12": {{Start: 6, Length: 32}}},
+				LinkReferences:      map[string]map[string][]ByteRange{"contracts/Lib.sol": {"Lib": {{Start: 39, Length: 20}}}},
+			},
+			wantMatch:      MatchFull,
+			wantMismatched: []RegionKind{},
+			wantImmutables: map[string]string{"12": immutable},
+			wantLibraries:  map[string]common.Address{"contracts/Lib.sol:Lib": common.HexToAddress(library)},
+		},
+		{
+			name:           "Partial Match With Different Metadata Hash",
+			onchain:        onchain(compiled(immutable, library, otherMetadata)),
+			compiled:       compiled(strings.Repeat("00", 32), placeholder, metadata),
+			wantMatch:      MatchPartial,
+			wantMismatched: []RegionKind{RegionMetadata},
+			wantImmutables: map[string]string{"6": immutable},
+			wantLibraries:  map[string]common.Address{"7f3b4c5d6e7f8091a2b3c4d5e6f7081929": common.HexToAddress(library)},
+		},
+		{
+			name:           "Mismatch With Different Code",
+			onchain:        onchain(strings.Replace(compiled(immutable, library, metadata), "6080604052", "6080604053", 1)),
+			compiled:       compiled(strings.Repeat("00", 32), placeholder, metadata),
+			wantMatch:      MatchNone,
+			wantMismatched: []RegionKind{RegionCode},
+			wantImmutables: map[string]string{"6": immutable},
+			wantLibraries:  map[string]common.Address{"7f3b4c5d6e7f8091a2b3c4d5e6f7081929": common.HexToAddress(library)},
+		},
+		{
+			name:     "Mismatch With Inconsistent Immutable References",
+			onchain:  onchain(compiled(immutable, library, metadata)),
+			compiled: compiled(strings.Repeat("00", 32), library, metadata),
+			references: &BytecodeReferences{
+				ImmutableReferences: map[string][]ByteRange{"12": {{Start: 6, Length: 32}, {Start: 39, Length: 20}}},
+			},
+			wantMatch:      MatchNone,
+			wantMismatched: []RegionKind{RegionImmutable},
+			wantImmutables: map[string]string{"12": immutable},
+			wantLibraries:  map[string]common.Address{},
+		},
+		{
+			name:           "Full Match With Constructor Arguments",
+			onchain:        onchain(compiled(immutable, library, metadata) + immutable),
+			compiled:       compiled(strings.Repeat("00", 32), placeholder, metadata),
+			wantMatch:      MatchFull,
+			wantMismatched: []RegionKind{},
+			wantImmutables: map[string]string{"6": immutable},
+			wantLibraries:  map[string]common.Address{"7f3b4c5d6e7f8091a2b3c4d5e6f7081929": common.HexToAddress(library)},
+			wantArguments:  onchain(immutable),
+		},
+		{
+			name:           "Mismatch With Truncated Bytecode",
+			onchain:        onchain("6080604052"),
+			compiled:       compiled(strings.Repeat("00", 32), placeholder, metadata),
+			wantMatch:      MatchNone,
+			wantMismatched: []RegionKind{RegionCode, RegionImmutable, RegionCode, RegionLibrary, RegionCode, RegionMetadata},
+			wantImmutables: map[string]string{},
+			wantLibraries:  map[string]common.Address{},
+		},
+		{
+			name:           "Library Call Protection",
+			onchain:        onchain("73" + library + "3014" + "6080604052" + "00fe" + metadata + "0033"),
+			compiled:       "73" + strings.Repeat("00", 20) + "3014" + "6080604052" + "00fe" + metadata + "0033",
+			wantMatch:      MatchFull,
+			wantMismatched: []RegionKind{},
+			wantImmutables: map[string]string{},
+			wantLibraries:  map[string]common.Address{},
+		},
+		{
+			name:           "Zero Address Push Without Call Protection",
+			onchain:        onchain("73" + library + "3114" + "00fe" + metadata + "0033"),
+			compiled:       "73" + strings.Repeat("00", 20) + "3114" + "00fe" + metadata + "0033",
+			wantMatch:      MatchNone,
+			wantMismatched: []RegionKind{RegionCode},
+			wantImmutables: map[string]string{},
+			wantLibraries:  map[string]common.Address{},
+		},
+		{
+			name:     "Invalid Compiled Bytecode",
+			onchain:  onchain("6080604052"),
+			compiled: "60806040zz",
+			wantErr:  true,
+		},
+		{
+			name:     "References Out Of Bounds",
+			onchain:  onchain("6080604052"),
+			compiled: "6080604052",
+			references: &BytecodeReferences{
+				ImmutableReferences: map[string][]ByteRange{"12": {{Start: 4, Length: 32}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			comparison, err := CompareBytecode(testCase.onchain, testCase.compiled, testCase.references)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, comparison)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantMatch, comparison.GetMatch())
+			assert.Equal(t, testCase.wantImmutables, comparison.GetImmutables())
+			assert.Equal(t, testCase.wantLibraries, comparison.GetLibraries())
+			assert.Equal(t, testCase.wantArguments, comparison.GetConstructorArguments())
+
+			mismatched := make([]RegionKind, 0)
+			for _, region := range comparison.GetMismatchedRegions() {
+				mismatched = append(mismatched, region.Kind)
+			}
+			assert.Equal(t, testCase.wantMismatched, mismatched)
+		})
+	}
+}
+
+func TestNewBytecodeReferencesFromJSON(t *testing.T) {
+	references, err := NewBytecodeReferencesFromJSON([]byte(`{
+		"object": "6080",
+		"immutableReferences": {"7": [{"start": 102, "length": 32}, {"start": 287, "length": 32}]},
+		"linkReferences": {"contracts/Math.sol": {"Math": [{"start": 45, "length": 20}]}}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []ByteRange{{Start: 102, Length: 32}, {Start: 287, Length: 32}}, references.ImmutableReferences["7"])
+	assert.Equal(t, []ByteRange{{Start: 45, Length: 20}}, references.LinkReferences["contracts/Math.sol"]["Math"])
+
+	_, err = NewBytecodeReferencesFromJSON([]byte(`{"immutableReferences": []}`))
+	assert.Error(t, err)
+}
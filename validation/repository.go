@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"github.com/unpackdev/solgo/metadata"
+	"github.com/unpackdev/solgo/utils"
+)
+
+// Publish stores the verified contract within the Sourcify compatible local repository, as a full match when the
+// bytecode fully matched and as a partial match when only the metadata differed. The metadata is the one produced
+// by the compiler for the verified contract, with the content of sources taken from the verifier sources.
+// Constructor arguments and linked libraries found during verification are stored along with it.
+func (v *Verifier) Publish(repository *metadata.Repository, chainID utils.NetworkID, address common.Address, result *VerifyResult) (*metadata.RepositoryEntry, error) {
+	if repository == nil {
+		return nil, errors.New("repository must be set")
+	}
+
+	if result == nil || !result.IsVerified() {
+		return nil, errors.New("only verified contracts can be published")
+	}
+
+	if result.GetCompilerResult() == nil || result.GetCompilerResult().GetMetadata() == "" {
+		return nil, errors.New("compiler result does not contain contract metadata")
+	}
+
+	raw := result.GetCompilerResult().GetMetadata()
+	var md metadata.ContractMetadata
+	if err := json.Unmarshal([]byte(raw), &md); err != nil {
+		return nil, errors.Wrap(err, "failure to decode compiler metadata while publishing contract")
+	}
+	md.Raw = raw
+
+	for path, source := range md.Sources {
+		if source.Content != "" {
+			continue
+		}
+
+		unit := v.sources.GetSourceUnitByPath(path)
+		if unit == nil {
+			unit = v.sources.GetSourceUnitByName(strings.TrimSuffix(filepath.Base(path), ".sol"))
+		}
+
+		if unit == nil {
+			return nil, fmt.Errorf("source %s of the compiler metadata not found within verifier sources", path)
+		}
+
+		source.Content = unit.GetContent()
+		md.Sources[path] = source
+	}
+
+	toReturn := &metadata.RepositoryEntry{
+		Match:    metadata.FullMatch,
+		ChainID:  chainID,
+		Address:  address,
+		Metadata: &md,
+	}
+
+	if result.GetMatch() == MatchPartial {
+		toReturn.Match = metadata.PartialMatch
+	}
+
+	if comparison := result.GetComparison(); comparison != nil {
+		toReturn.ConstructorArguments = comparison.GetConstructorArguments()
+		toReturn.Libraries = comparison.GetLibraries()
+	}
+
+	if err := repository.Store(toReturn); err != nil {
+		return nil, err
+	}
+
+	return toReturn, nil
+}
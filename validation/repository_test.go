@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/0x19/solc-switch"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/metadata"
+	"github.com/unpackdev/solgo/utils"
+)
+
+func TestVerifierPublish(t *testing.T) {
+	verifier := &Verifier{sources: &solgo.Sources{
+		SourceUnits: []*solgo.SourceUnit{
+			{Name: "Storage", Path: "contracts/Storage.sol", Content: "contract Storage {}"},
+		},
+		EntrySourceUnitName: "Storage",
+	}}
+
+	address := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	compilerMetadata := `{"compiler":{"version":"0.8.19+commit.7dd6d404"},"language":"Solidity","settings":{"compilationTarget":{"contracts/Storage.sol":"Storage"}},"sources":{"contracts/Storage.sol":{"keccak256":"0x01","urls":[]}},"version":1}`
+
+	testCases := []struct {
+		name      string
+		result    *VerifyResult
+		wantErr   bool
+		wantMatch metadata.MatchType
+	}{
+		{
+			name: "Full Match With Constructor Arguments",
+			result: &VerifyResult{
+				Verified:       true,
+				Match:          MatchFull,
+				CompilerResult: &solc.CompilerResult{Metadata: compilerMetadata},
+				Comparison:     &BytecodeComparison{Match: MatchFull, ConstructorArguments: []byte{0x2a}},
+			},
+			wantMatch: metadata.FullMatch,
+		},
+		{
+			name: "Partial Match",
+			result: &VerifyResult{
+				Verified:       true,
+				Match:          MatchPartial,
+				CompilerResult: &solc.CompilerResult{Metadata: compilerMetadata},
+			},
+			wantMatch: metadata.PartialMatch,
+		},
+		{
+			name:    "Mismatch",
+			result:  &VerifyResult{Verified: false, Match: MatchNone, CompilerResult: &solc.CompilerResult{Metadata: compilerMetadata}},
+			wantErr: true,
+		},
+		{
+			name:    "Missing Compiler Metadata",
+			result:  &VerifyResult{Verified: true, Match: MatchFull, CompilerResult: &solc.CompilerResult{}},
+			wantErr: true,
+		},
+		{
+			name: "Missing Source",
+			result: &VerifyResult{
+				Verified: true,
+				Match:    MatchFull,
+				CompilerResult: &solc.CompilerResult{
+					Metadata: `{"sources":{"contracts/Missing.sol":{"keccak256":"0x01"}}}`,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repository, err := metadata.NewRepository(t.TempDir())
+			require.NoError(t, err)
+
+			entry, err := verifier.Publish(repository, utils.EthereumNetworkID, address, testCase.result)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, entry)
+				assert.False(t, repository.Has(utils.EthereumNetworkID, address))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantMatch, entry.Match)
+
+			loaded, err := repository.Get(utils.EthereumNetworkID, address)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantMatch, loaded.Match)
+			assert.Equal(t, compilerMetadata, loaded.GetMetadata().Raw)
+			assert.Equal(t, "contract Storage {}", loaded.GetMetadata().Sources["contracts/Storage.sol"].Content)
+			assert.Equal(t, entry.ConstructorArguments, loaded.ConstructorArguments)
+		})
+	}
+}
@@ -1,17 +1,21 @@
 package validation
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/pkg/errors"
-	"github.com/unpackdev/solgo/bytecode"
+	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/0x19/solc-switch"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/bytecode"
 	"github.com/unpackdev/solgo/utils"
 	"go.uber.org/zap"
 )
@@ -95,27 +99,33 @@ func (v *Verifier) VerifyFromResults(bytecode []byte, results *solc.CompilerResu
 		return nil, errors.New("no appropriate compilation results found (compiled but missing entry contract)")
 	}
 
-	encoded := hex.EncodeToString(bytecode)
-	if !strings.Contains(result.GetDeployedBytecode(), encoded) {
-		dmp := diffmatchpatch.New()
-		diffs := dmp.DiffMain(encoded, result.GetDeployedBytecode(), false)
-		toReturn := &VerifyResult{
-			Verified:            false,
-			CompilerResult:      result,
-			ExpectedBytecode:    encoded,
-			Diffs:               diffs,
-			DiffPretty:          dmp.DiffPrettyText(diffs),
-			LevenshteinDistance: dmp.DiffLevenshtein(diffs),
-		}
+	toReturn, err := v.compareResult(bytecode, result, nil)
+	if err != nil {
+		return nil, err
+	}
 
+	if !toReturn.IsVerified() {
 		return toReturn, errors.New("contract bytecode mismatch, failed to verify")
 	}
 
-	toReturn := &VerifyResult{
-		Verified:         true,
-		ExpectedBytecode: encoded,
-		CompilerResult:   result,
-		Diffs:            make([]diffmatchpatch.Diff, 0),
+	return toReturn, nil
+}
+
+// VerifyWithReferences verifies the bytecode against the compiled contract, using the immutable and link references
+// of the compiler output to mask and extract immutable values and linked library addresses.
+// References are the ones of the deployed bytecode and can be loaded with NewBytecodeReferencesFromJSON.
+func (v *Verifier) VerifyWithReferences(bytecode []byte, result *solc.CompilerResult, references *BytecodeReferences) (*VerifyResult, error) {
+	if result == nil {
+		return nil, errors.New("compiler result must be set")
+	}
+
+	toReturn, err := v.compareResult(bytecode, result, references)
+	if err != nil {
+		return nil, err
+	}
+
+	if !toReturn.IsVerified() {
+		return toReturn, errors.New("bytecode mismatch, failed to verify")
 	}
 
 	return toReturn, nil
@@ -172,57 +182,75 @@ func (v *Verifier) VerifyAuxFromResults(bCode []byte, results *solc.CompilerResu
 // Returns true if the bytecode matches, otherwise returns false.
 // Also returns an error if there's any issue in the compilation or verification process.
 func (v *Verifier) Verify(ctx context.Context, bytecode []byte, config *solc.CompilerConfig) (*VerifyResult, error) {
-	var source string
-
 	if config.GetJsonConfig() != nil {
-		sourceBytes, err := config.GetJsonConfig().ToJSON()
+		source, err := config.GetJsonConfig().ToJSON()
 		if err != nil {
 			return nil, err
 		}
-		source = string(sourceBytes)
-	} else {
-		source = utils.StripExtraSPDXLines(utils.SimplifyImportPaths(
-			v.GetSources().GetCombinedSource(),
-		))
+
+		results, references, entry, err := v.compileStandardJSON(ctx, config, source)
+		if err != nil {
+			return nil, err
+		}
+
+		return v.verifyEntryResult(bytecode, results, references[entry])
 	}
 
+	source := utils.StripExtraSPDXLines(utils.SimplifyImportPaths(
+		v.GetSources().GetCombinedSource(),
+	))
+
 	results, err := v.solc.Compile(ctx, source, config)
 	if err != nil {
 		return nil, err
 	}
 
+	return v.verifyEntryResult(bytecode, results, nil)
+}
+
+// VerifyStandardJSON compiles the sources through the solc standard JSON input and then verifies the bytecode.
+// Unlike Verify, sources are not flattened, so original source paths, remappings and linked libraries
+// are passed to the compiler exactly as they were provided. If settings are nil, settings the sources
+// were created with are used.
+func (v *Verifier) VerifyStandardJSON(ctx context.Context, bytecode []byte, compilerVersion string, settings *solgo.StandardJSONSettings) (*VerifyResult, error) {
+	input, err := v.GetSources().ToStandardJSON(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := input.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &solc.CompilerConfig{
+		CompilerVersion: compilerVersion,
+		EntrySourceName: v.GetSources().EntrySourceUnitName,
+		Arguments:       []string{"--standard-json"},
+	}
+
+	results, references, entry, err := v.compileStandardJSON(ctx, config, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.verifyEntryResult(bytecode, results, references[entry])
+}
+
+// verifyEntryResult compares the bytecode against the entry contract of the compiler results, masking immutables and
+// libraries by the references of its deployed bytecode when these are known.
+func (v *Verifier) verifyEntryResult(bytecode []byte, results *solc.CompilerResults, references *BytecodeReferences) (*VerifyResult, error) {
 	for _, result := range results.GetResults() {
 		if result.IsEntry() {
-			encoded := hex.EncodeToString(bytecode)
-			var retBytecode string
-			if result.GetDeployedBytecode() == "" {
-				retBytecode = result.GetBytecode()
-			} else {
-				retBytecode = result.GetDeployedBytecode()
+			toReturn, err := v.compareResult(bytecode, result, references)
+			if err != nil {
+				return nil, err
 			}
 
-			if encoded != retBytecode {
-				dmp := diffmatchpatch.New()
-				diffs := dmp.DiffMain(encoded, retBytecode, false)
-				toReturn := &VerifyResult{
-					Verified:            false,
-					CompilerResult:      result,
-					ExpectedBytecode:    encoded,
-					Diffs:               diffs,
-					DiffPretty:          dmp.DiffPrettyText(diffs),
-					LevenshteinDistance: dmp.DiffLevenshtein(diffs),
-				}
-
+			if !toReturn.IsVerified() {
 				return toReturn, errors.New("bytecode missmatch, failed to verify")
 			}
 
-			toReturn := &VerifyResult{
-				Verified:         true,
-				ExpectedBytecode: encoded,
-				CompilerResult:   result,
-				Diffs:            make([]diffmatchpatch.Diff, 0),
-			}
-
 			return toReturn, nil
 		}
 	}
@@ -236,9 +264,196 @@ func (v *Verifier) Verify(ctx context.Context, bytecode []byte, config *solc.Com
 	return nil, fmt.Errorf("compilation did not contain entry contract results")
 }
 
+// compileStandardJSON compiles the standard JSON input with the solc binary of the configured compiler version and
+// the sanitized configuration arguments. The solc package does not expose the immutable and link references of the
+// output, so the binary is run directly and the references of the deployed bytecode are returned along with the
+// results, keyed by sourcePath:contractName, together with the key of the entry contract.
+func (v *Verifier) compileStandardJSON(ctx context.Context, config *solc.CompilerConfig, input []byte) (*solc.CompilerResults, map[string]*BytecodeReferences, string, error) {
+	binary, err := v.solc.GetBinary(config.GetCompilerVersion())
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	args, err := config.SanitizeArguments(config.GetArguments())
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	// #nosec G204
+	// The binary is resolved by the solc package, arguments are sanitized and the input is passed through stdin only.
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to compile standard JSON input: %w: %s", err, stderr.String())
+	}
+
+	return resultsFromStandardJSON(config.GetCompilerVersion(), config.GetEntrySourceName(), stdout.Bytes())
+}
+
+// standardJSONBytecode is the bytecode of a contract within the solc standard JSON output.
+type standardJSONBytecode struct {
+	Object  string `json:"object"`
+	Opcodes string `json:"opcodes"`
+	BytecodeReferences
+}
+
+// standardJSONOutput is the part of the solc standard JSON output the verifier relies on.
+type standardJSONOutput struct {
+	Contracts map[string]map[string]struct {
+		Abi      json.RawMessage `json:"abi"`
+		Metadata string          `json:"metadata"`
+		Evm      struct {
+			Bytecode         standardJSONBytecode `json:"bytecode"`
+			DeployedBytecode standardJSONBytecode `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+	Errors []solc.CompilationError `json:"errors"`
+}
+
+// resultsFromStandardJSON parses the solc standard JSON output the same way the solc package does, additionally
+// returning the references of the deployed bytecode of every contract, keyed by sourcePath:contractName, and the
+// key of the entry contract. The entry source name may be either a bare contract name or sourcePath:contractName,
+// and a bare name declared by more than one source file is rejected as ambiguous.
+func resultsFromStandardJSON(compilerVersion string, entrySourceName string, output []byte) (*solc.CompilerResults, map[string]*BytecodeReferences, string, error) {
+	var decoded standardJSONOutput
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to decode standard JSON output: %w", err)
+	}
+
+	results := make([]*solc.CompilerResult, 0)
+	references := make(map[string]*BytecodeReferences)
+	entries := make(map[string]*solc.CompilerResult)
+
+	for sourcePath, contracts := range decoded.Contracts {
+		for name, contract := range contracts {
+			result := &solc.CompilerResult{
+				RequestedVersion: compilerVersion,
+				ContractName:     name,
+				Bytecode:         contract.Evm.Bytecode.Object,
+				DeployedBytecode: contract.Evm.DeployedBytecode.Object,
+				ABI:              string(contract.Abi),
+				Opcodes:          contract.Evm.Bytecode.Opcodes,
+				Metadata:         contract.Metadata,
+				Errors:           decoded.Errors,
+			}
+			results = append(results, result)
+
+			qualifiedName := sourcePath + ":" + name
+			deployed := contract.Evm.DeployedBytecode.BytecodeReferences
+			references[qualifiedName] = &deployed
+
+			if entrySourceName != "" && (entrySourceName == name || entrySourceName == qualifiedName) {
+				entries[qualifiedName] = result
+			}
+		}
+	}
+
+	entry := ""
+	if len(entries) > 1 {
+		candidates := make([]string, 0, len(entries))
+		for qualifiedName := range entries {
+			candidates = append(candidates, qualifiedName)
+		}
+		sort.Strings(candidates)
+
+		return nil, nil, "", fmt.Errorf(
+			"entry contract %s is ambiguous, declared as %s; use sourcePath:contractName instead",
+			entrySourceName, strings.Join(candidates, ", "),
+		)
+	}
+
+	for qualifiedName, result := range entries {
+		result.IsEntryContract = true
+		entry = qualifiedName
+	}
+
+	if len(decoded.Errors) > 0 {
+		results = append(results, &solc.CompilerResult{
+			RequestedVersion: compilerVersion,
+			Errors:           decoded.Errors,
+		})
+	}
+
+	return &solc.CompilerResults{Results: results}, references, entry, nil
+}
+
+// compareResult compares the bytecode against the compiled contract region by region. The deployed bytecode is
+// compared first, falling back to the creation bytecode, which is the only one constructor arguments can be
+// appended to. Partial matches, where only the metadata differs, are considered verified.
+func (v *Verifier) compareResult(bCode []byte, result *solc.CompilerResult, references *BytecodeReferences) (*VerifyResult, error) {
+	encoded := hex.EncodeToString(bCode)
+
+	compiled := result.GetDeployedBytecode()
+	if compiled == "" {
+		compiled = result.GetBytecode()
+	}
+
+	comparison, err := CompareBytecode(bCode, compiled, references)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := comparison.GetMatch() != MatchNone &&
+		(compiled == result.GetBytecode() || len(comparison.GetConstructorArguments()) == 0)
+
+	if !matches && compiled != result.GetBytecode() && result.GetBytecode() != "" {
+		// References are the ones of the deployed bytecode, so these cannot be applied to the creation bytecode.
+		creation, err := CompareBytecode(bCode, result.GetBytecode(), nil)
+		if err == nil && creation.GetMatch() != MatchNone {
+			compiled, comparison, matches = result.GetBytecode(), creation, true
+		}
+	}
+
+	if !matches {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(encoded, compiled, false)
+		return &VerifyResult{
+			Verified:            false,
+			Match:               MatchNone,
+			Comparison:          comparison,
+			CompilerResult:      result,
+			ExpectedBytecode:    encoded,
+			Diffs:               diffs,
+			DiffPretty:          dmp.DiffPrettyText(diffs),
+			LevenshteinDistance: dmp.DiffLevenshtein(diffs),
+		}, nil
+	}
+
+	toReturn := &VerifyResult{
+		Verified:         true,
+		Match:            comparison.GetMatch(),
+		Comparison:       comparison,
+		ExpectedBytecode: encoded,
+		CompilerResult:   result,
+		Diffs:            make([]diffmatchpatch.Diff, 0),
+	}
+
+	if args := comparison.GetConstructorArguments(); len(args) > 0 && result.GetABI() != "" {
+		constructor, err := bytecode.DecodeConstructorFromAbi(args, result.GetABI())
+		if err != nil {
+			zap.L().Debug(
+				"failed to decode constructor arguments of verified contract",
+				zap.String("contract_name", result.GetContractName()),
+				zap.Error(err),
+			)
+		}
+		toReturn.Constructor = constructor
+	}
+
+	return toReturn, nil
+}
+
 // VerifyResult represents the result of the verification process.
 type VerifyResult struct {
 	Verified            bool                  `json:"verified"`             // Whether the verification was successful or not.
+	Match               MatchType             `json:"match"`                // Whether the bytecode fully, partially or does not match.
+	Comparison          *BytecodeComparison   `json:"comparison"`           // The region by region comparison of the provided and the compiled bytecode.
+	Constructor         *bytecode.Constructor `json:"constructor"`          // The decoded constructor arguments appended to the provided bytecode.
 	CompilerResult      *solc.CompilerResult  `json:"compiler_results"`     // The results from the solc compiler.
 	ExpectedBytecode    string                `json:"expected_bytecode"`    // The expected bytecode.
 	Diffs               []diffmatchpatch.Diff `json:"diffs"`                // The diffs between the provided bytecode and the compiled bytecode.
@@ -251,6 +466,21 @@ func (vr *VerifyResult) IsVerified() bool {
 	return vr.Verified
 }
 
+// GetMatch returns whether the bytecode fully, partially (metadata only) or does not match.
+func (vr *VerifyResult) GetMatch() MatchType {
+	return vr.Match
+}
+
+// GetComparison returns the region by region comparison of the provided and the compiled bytecode.
+func (vr *VerifyResult) GetComparison() *BytecodeComparison {
+	return vr.Comparison
+}
+
+// GetConstructor returns the decoded constructor arguments appended to the provided bytecode, if any.
+func (vr *VerifyResult) GetConstructor() *bytecode.Constructor {
+	return vr.Constructor
+}
+
 // GetCompilerResults returns the results from the solc compiler.
 func (vr *VerifyResult) GetCompilerResult() *solc.CompilerResult {
 	return vr.CompilerResult
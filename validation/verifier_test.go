@@ -5,9 +5,11 @@ import (
 	"encoding/hex"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/0x19/solc-switch"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/unpackdev/solgo"
@@ -66,6 +68,7 @@ func TestVerifier(t *testing.T) {
 		wantNilResults       bool
 		buildJsonConfig      bool
 		verifyFromResults    bool
+		wantMatch            MatchType
 		config               *solc.Config
 		compilerConfig       *solc.CompilerConfig
 		compiler             *solc.Solc
@@ -156,7 +159,7 @@ func TestVerifier(t *testing.T) {
 			compiler:       compiler,
 		},
 		{
-			name:       "Reentrancy Contract Test Bytecode Match From Json Config Failure",
+			name:       "Reentrancy Contract Test Bytecode Partial Match From Json Config",
 			outputPath: "audits/",
 			sources: &solgo.Sources{
 				SourceUnits: []*solgo.SourceUnit{
@@ -170,15 +173,16 @@ func TestVerifier(t *testing.T) {
 				MaskLocalSourcesPath: false,
 				LocalSourcesPath:     utils.GetLocalSourcesPath(),
 			},
-			wantErr: true,
+			wantErr: false,
 			bytecode: func() []byte {
 				b, _ := hex.DecodeString("608060405234801561001057600080fd5b506105ca806100206000396000f3fe6080604052600436106100345760003560e01c806327e235e3146100395780633ccfd60b14610076578063d0e30db01461008d575b600080fd5b34801561004557600080fd5b50610060600480360381019061005b91906102d8565b610097565b60405161006d9190610485565b60405180910390f35b34801561008257600080fd5b5061008b6100af565b005b610095610229565b005b60006020528060005260406000206000915090505481565b60008060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905060008111610135576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161012c90610465565b60405180910390fd5b60003373ffffffffffffffffffffffffffffffffffffffff168260405161015b90610410565b60006040518083038185875af1925050503d8060008114610198576040519150601f19603f3d011682016040523d82523d6000602084013e61019d565b606091505b50509050806101e1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016101d890610445565b60405180910390fd5b60008060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055505050565b6000341161026c576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161026390610425565b60405180910390fd5b346000803373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546102ba91906104bc565b92505081905550565b6000813590506102d28161057d565b92915050565b6000602082840312156102ea57600080fd5b60006102f8848285016102c3565b91505092915050565b600061030e6027836104ab565b91507f4465706f73697420616d6f756e742073686f756c64206265206772656174657260008301527f207468616e2030000000000000000000000000000000000000000000000000006020830152604082019050919050565b6000610374600f836104ab565b91507f5472616e73666572206661696c656400000000000000000000000000000000006000830152602082019050919050565b60006103b46014836104ab565b91507f496e73756666696369656e742062616c616e63650000000000000000000000006000830152602082019050919050565b60006103f46000836104a0565b9150600082019050919050565b61040a81610544565b82525050565b600061041b826103e7565b9150819050919050565b6000602082019050818103600083015261043e81610301565b9050919050565b6000602082019050818103600083015261045e81610367565b9050919050565b6000602082019050818103600083015261047e816103a7565b9050919050565b600060208201905061049a6000830184610401565b92915050565b600081905092915050565b600082825260208201905092915050565b60006104c782610544565b91506104d283610544565b9250827fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff038211156105075761050661054e565b5b828201905092915050565b600061051d82610524565b9050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b61058681610512565b811461059157600080fd5b5056fea2646970667358221220e27ebb8a52fb9a56833f8bc098c1e940d6f786c2fdecc619def204c20ad5006664736f6c63430008000033")
 				return b
 			}(),
 			wantBuildErr:    false,
-			wantDiff:        true,
+			wantDiff:        false,
+			wantMatch:       MatchPartial,
 			buildJsonConfig: true,
-			diffCount:       54,
+			diffCount:       0,
 			config:          solcConfig,
 			compilerConfig:  compilerConfig,
 			compiler:        compiler,
@@ -357,6 +361,9 @@ func TestVerifier(t *testing.T) {
 				assert.NotNil(t, results.GetCompilerResult())
 				assert.Empty(t, results.GetDiffPretty())
 				assert.Zero(t, results.GetLevenshteinDistance())
+				if testCase.wantMatch != "" {
+					assert.Equal(t, testCase.wantMatch, results.GetMatch())
+				}
 
 			} else {
 				compiled, err := verifier.Compile(context.Background(), testCase.compilerConfig)
@@ -388,7 +395,120 @@ func TestVerifier(t *testing.T) {
 				assert.NotNil(t, results.GetCompilerResult())
 				assert.Empty(t, results.GetDiffPretty())
 				assert.Zero(t, results.GetLevenshteinDistance())
+				if testCase.wantMatch != "" {
+					assert.Equal(t, testCase.wantMatch, results.GetMatch())
+				}
 			}
 		})
 	}
 }
+
+func TestResultsFromStandardJSON(t *testing.T) {
+	immutable := strings.Repeat("00", 31) + "2a"
+	library := "1111111111111111111111111111111111111111"
+
+	// PUSH32 <immutable> PUSH20 <library> STOP
+	deployed := "7f" + strings.Repeat("00", 32) + "73" + "__$7f3b4c5d6e7f8091a2b3c4d5e6f7081929$__" + "00"
+	output := []byte(`{
+		"contracts": {
+			"contracts/Vault.sol": {
+				"Vault": {
+					"abi": [],
+					"evm": {
+						"bytecode": {"object": "6080", "opcodes": "PUSH1 0x80 "},
+						"deployedBytecode": {
+							"object": "` + deployed + `",
+							"immutableReferences": {"12": [{"start": 1, "length": 32}]},
+							"linkReferences": {"contracts/Lib.sol": {"Lib": [{"start": 34, "length": 20}]}}
+						}
+					}
+				}
+			}
+		}
+	}`)
+
+	results, references, entryName, err := resultsFromStandardJSON("0.8.20", "Vault", output)
+	require.NoError(t, err)
+	assert.Equal(t, "contracts/Vault.sol:Vault", entryName)
+
+	entry := results.GetEntryContract()
+	require.NotNil(t, entry)
+	assert.Equal(t, "Vault", entry.GetContractName())
+	assert.Equal(t, deployed, entry.GetDeployedBytecode())
+	assert.Equal(t, "PUSH1 0x80 ", entry.GetOpcodes())
+	assert.Equal(t, []ByteRange{{Start: 1, Length: 32}}, references[entryName].ImmutableReferences["12"])
+
+	onchain, err := hex.DecodeString("7f" + immutable + "73" + library + "00")
+	require.NoError(t, err)
+
+	verifier := &Verifier{}
+	result, err := verifier.verifyEntryResult(onchain, results, references[entryName])
+	require.NoError(t, err)
+	assert.True(t, result.IsVerified())
+	assert.Equal(t, map[string]string{"12": immutable}, result.GetComparison().GetImmutables())
+	assert.Equal(t, map[string]common.Address{"contracts/Lib.sol:Lib": common.HexToAddress(library)}, result.GetComparison().GetLibraries())
+
+	_, _, _, err = resultsFromStandardJSON("0.8.20", "Vault", []byte(`{"contracts": []}`))
+	assert.Error(t, err)
+}
+
+func TestResultsFromStandardJSONDuplicateNames(t *testing.T) {
+	output := []byte(`{
+		"contracts": {
+			"contracts/Vault.sol": {
+				"Vault": {"abi": [], "evm": {"bytecode": {"object": "6001"}, "deployedBytecode": {"object": "6001", "immutableReferences": {"3": [{"start": 0, "length": 32}]}}}}
+			},
+			"mocks/Vault.sol": {
+				"Vault": {"abi": [], "evm": {"bytecode": {"object": "6002"}, "deployedBytecode": {"object": "6002"}}}
+			}
+		}
+	}`)
+
+	// A bare contract name declared by more than one source file cannot identify the entry contract.
+	_, _, _, err := resultsFromStandardJSON("0.8.20", "Vault", output)
+	assert.ErrorContains(t, err, "contracts/Vault.sol:Vault, mocks/Vault.sol:Vault")
+
+	results, references, entryName, err := resultsFromStandardJSON("0.8.20", "mocks/Vault.sol:Vault", output)
+	require.NoError(t, err)
+	assert.Equal(t, "mocks/Vault.sol:Vault", entryName)
+	assert.Len(t, references, 2)
+	assert.Empty(t, references[entryName].ImmutableReferences)
+	assert.NotEmpty(t, references["contracts/Vault.sol:Vault"].ImmutableReferences)
+
+	entries := 0
+	for _, result := range results.GetResults() {
+		if result.IsEntry() {
+			entries++
+			assert.Equal(t, "6002", result.GetDeployedBytecode())
+		}
+	}
+	assert.Equal(t, 1, entries)
+}
+
+func TestVerifyFromResults(t *testing.T) {
+	deployed := "6080604052348015600f57600080fd5b50"
+	results, _, _, err := resultsFromStandardJSON("0.8.20", "Vault", []byte(`{
+		"contracts": {
+			"contracts/Vault.sol": {
+				"Vault": {"abi": [], "evm": {"bytecode": {"object": "60806040526000"}, "deployedBytecode": {"object": "`+deployed+`"}}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	verifier := &Verifier{}
+	result, err := verifier.VerifyFromResults(common.FromHex(deployed), results)
+	require.NoError(t, err)
+	assert.True(t, result.IsVerified())
+	assert.Equal(t, MatchFull, result.GetMatch())
+	require.NotNil(t, result.GetComparison())
+
+	// Empty or truncated bytecode is contained within the compiled one, yet does not match it.
+	for _, bytecode := range [][]byte{{}, common.FromHex(deployed[:8])} {
+		result, err = verifier.VerifyFromResults(bytecode, results)
+		assert.Error(t, err)
+		require.NotNil(t, result)
+		assert.False(t, result.IsVerified())
+		assert.Equal(t, MatchNone, result.GetMatch())
+	}
+}